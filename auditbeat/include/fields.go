@@ -31,5 +31,5 @@ func init() {
 
 // Asset returns asset data
 func Asset() string {
-	return "eJzsfVuT2zb257s/BSovdqq6lbgdu6b88K/tsSeTrkkmrnG8O1tbW2qIPKKQJgEaAKVWPv3WwYUEbxKPujP78veDqyURvwMC544D4Jo9wPE9y1RVKfmCMStsCe/Zh/g5B5NpUVuh5Hv2Xy8YY+yDkpYLaUIjthVQ5obxPRcl35TAhGS8LBnsQVpmjzWY1QsWHnv/grFrJnkF7/0Dq0rlTQkOeYIYY7/twD3P1JbZHTD/PLM7blkBEjS3kLtfPN6LEQWeIaKDw968x1c+KJ27b+CRVzW+cqmKAvJrIee7cuuAwm8bMI5qtuOyCP2xWhQF6FF/8N+PSrtvt8INkYVCC3vsXgdYrYwROIB7XjZgGNfwPjTm1mqxaSyYdaVysRWQX7FMA777FcuhBPdHU+f+m0rt3RNKbkWx9l28YlzmTEhhBS/XJuOyN1bYr2SMCq2aejwUP2Lvu96s3BPd1HZwNbe70HkPaOHRhi96iDi/+DCzqh2fVXiwakor1il8R0DzQ/hmalpn5zCy1Igk+20nDBOGcSaVvOaSl8c/IPcv56dXGNYY2DZlgrVVmvGi0FBwJGTCfMdeWq4LsOvRaPT7OhoQ38x3EimYY1UK+TBCP9ZAgXW8h0+yV/jnFcuFvmId/rcD/Bz2IiNR8C0GMEKqnITiGjANtQYD0gpZpKLT/m2OxkI1INaIfCmpjieQaGNAs7uP7NWXu4/fukGBrHEyKnLsxFaAZq8+ux+3XX/UQYIe9MF9R54Y1+ql4zGNOAPQYvmLOQbXouL66AXZvdffB10f4rcSfxGFVEdPoFenWaBVw9+/++H7OYKI0Rt7IZnKLC87VnFCOCBtwA6ZYqNUCVwOiVvdwBTxz2CZSOjuuNf99x76nm2ERTIr9mslLNojZXegD8IMh8GAHc7jM/aloPVF/NGfklLJ4iSHYgsc9M3RgkEdgqpRGKZkeWQ8zyFnhx1Ido949/jLPba7H+qVyoqqTxrt1hzpkhvLotlj2LTHBK/cN45upqRFp+PATdtgSDuj044m/gTlCizPueWOtH9+RFlpUQi5yCim1upWMq41PyJpY7WQhYkuCCpG3vkN8GhRc5SBElqOBMeiffOmDn2RwGhX7nW+/OtnJvy45eogS8VxLrdaVSv2qyyPCYxp6lpp5CshWcWzXz9fsb3gDubhl493Fqr/tQMNP2pVmc5VWCUQkTHFNvZUyK3SlRNe5BqpbOdP/unOwGKzPzL2TDljlKCF90FWGEl+KWTz2Jv+VOWOWO/z337GBsH62GNfufpG06OBBoQyHEjNGZ9IQm1+h8yuhoOsSiDDOqSXpn0bBBkC56rirWBcDu1h0HIjwpBICXsoz9Bola/5nkDXIa9evAix1Aa47SKpv/pPC+IobEcNpnochgAr/PO8r5Maa+whMyDz6GaVqmAVGMMLMCt2lzzlmgnTQhm0Rd4f86FGo70Me+/SomZ0IuQimiBIucMUNgh6CuaV0U4ZGyiF539TjlSvH1f4m7d8+PG+xVG1VyNz/VqNBy1SPD9wbd+4YRpsoyXkbHP0UlNjMIqj6B1T1A6Hnch2XceTsdONlEIWE71Bq/KHkgt6E5/8M3uzB21i+HyyM+HByFaOnUdRujC9sDhS+uZ/4KsYy6v6mznbrOFrIzTkPdfIm43ec60Q3zZFYyy7eWd37Ob71++u2Oub92/evn/7ZvXmzc2y0XVd8ia+DemdgGjIlM6dxWzfbxT7FeY0lVu9EVajD43P+tHKOKoCx+81aD9RGLjjB6u5NEk6I47TgLDXDr1x9EorfOU/rAnxUaurXJDUyhQqKE9s0APQWullpq4j8jdsFDVg5ik6DyfPBT7LS+cnoGRn3Dj95eiYaWuYuJtemaW+0ND7OtGtrmsBZzUikHXBDZvyphehI8gYOgnx2azdWoDu2SSm+0rV5Em2Dz+yWqu9yEG3vuy02folerroHXqktqkJQUBQQTzP1+6Bdese11plYIzSs1YMH125VqsIOxRsyM5I7z8T89bv4Yp9GmfZEPCKFRm4XEguCmF5qTLgw0gy6ZuQxnKZwVqcEZ278CCG4KFLaETQdd4JOQqTJyict0wtjdSuL6MSHlgnfNaFoDerCnLRVKep/+IhfGKJRDy4OaIU9rhOTF7bg8ZcAzf2+nV2RpEmQMxZRNFZO2F8d4TpzNwJlnO6UYwSE+GX68flrBeaYF/+rlRRgpe0eeoairOm9l/umXPvFwQ9V9mDk58g6R/j5wlw/xszlltUv2UJmQ1BYPgNZdbslLZrbwHesy0vDU4al9lO6UjvupXyF9PJ4bZbbNI+zOnxYBNAr0T+NJ34RYqvDXSATORTWr0lV02ZDxLFlC8cXPROQwfQkdg0orRMyVNdSZTBhT350NL0ScZ5WiXfQGlG1Hq+BDvtT5zpy50bCU+nZVpk5o5lf/KfJkDu0BlIGDUssPRVT8eb+P1Zzgy0aXz59Dn5KYQV49l4Jk73CmKCybnOdsJCZhv9DO/Qg2OvYFWs2ONf3q3f/XDFuK6uWF1nV6wStfl23BVlVnXJLbr0T+vJr59ZBAp9yEBaZa5Ys2mkba7YQchcHWY60Y94Lu9DwJmkseWV6KXVLiPhYcJLash33F6xHDaCyyu21QAbk5952wfQEsqn9eS3iXjzpWEeen4cRD0i2/vqBMWfhbGoTu8+XfM812AMmDGBimdPe7FIZsd1fuAaOmJXrDENL8sj++X2Q9qHqMUemg2+vgXT6bJ/pN9NkO1+7xLKPY+6A2WpJjttlLtGZ9Vfr9OMpARrlT+DcUpGoFa516yTpJqnKsaE0ieVsy93H8eE8H9T8+z5XqpDHBPD+O9ZR9Ctok4P4VLTvoyQR2MVr8eUuJTKL8s9G7kEcprmc7pLCd2s5zmdIvsMDuMkXY8bNAxvcmGTMP42fh6mrA24KNcvHrjsSpeSi6G6axuqUU6U67xgE0phSpNk3EKh9PHF/AC0oZUjfq2bckHK0/XipWnx/dKNzy/noMU+hiwuM+yzdM4zve/nNqmlE66PIev30nRZt9Gay5LB4YQihVIVQsbihB4KAWSqORDaw3YLmRV7mETaGgKUX0j2KekpMAqWAWknQQi1ErE+oj82BIBubCahDAHKgJ0G2VJQ0hGeRMP/16g4U8AFCdq7LUqUUeUe1iI3boHfrcsol5KfXnJxi0D3kWS3THMQZRk1G+Oox2t0HtUW+TosvzprGau0AuW8H2551xcFil3/F9NK2W9PL47y1HNYsNSYyF9CeCyDi8DmYICIM5DHCcRUJhdBjuRyApSK2cnnBFhBw+qqjIZjRwQayuvUexIhO7mdnAki2lh+W9RnLybgmfW5+HmBoVYT8CxTjbTMNJtQ5+HKJhpUBFZkPFm9urSyALs0qCkYIF5WUuBG4z9fUTAkGwoK+tQGDtXF79Q6Tm7Jqaq5thVIa3reTIgTJx2aHoVP/sFlJcHLTViEvfvY99pqCgbXqH7qGahEUhd3xy2vvMpUVX078CbRA6eiuUZhGiouc1YKCazmmldgQRv2CvvunhqQg8fFxG43RpWNDQXPQerhEbLGdsVVLY8dSCWmWaPdEB+UfkDbnQsNyG3HPirXhaHAxhnjumgcazKOPn4pTL+636hGZwtq1j+7505U6M8xrOh7SGJWu2qolHXrnRqMGbCs0pb09kpbJptqA7oPNChSOQsWc8px0v1wDTpHqkv3hXI2Vs9zY1QmXBR5EPiZNVI8MqOyB+jPVA7GCskH2zBmputj93Ac0f+eu/9/cyfBonyvvHDPb6Tpz2F8OL5AQGFW8+1WZOzVvZCZqoQs7lED3qvGFgo/fdsj3qY4lsXVBr42ILNlVc39vEJsGqav1ZSu4Af1jzGiSMqrfG57xT73SbLQ3tcVGKuQXZ36aoS0b25ijBRS46j1My7RgynVfsg1BkyyEEHczhAas7uPXd+tQj2KAc2K3caqQsM0lL48q/25RYooLlrb8b3PIhlkTZdz6XdYg2nKUxLTuUNN5nS80mzLRTkvnAjYOqvIDZDHpQYl2auA8x2CDOutTVNVfJCBms9ktyyHFmzgZKWt5lysKF4u3JnQVz6BlGitcRdS/0skXZ8bzRMcELkg7tGY9vt7eiE6ir6EmZcDTsB/dx9X7M56ZpCqLYzHl4p7EnyBmPveRe5cuu0HMSoYr9EYyJTML3hZz+Sh8dkXPNYi42VvyY11vBz2UITRumLwmEFtXeDS1v65N9txX5PK7k1zP3TRB+nss7wzVYaOlOzOZUJ0AGQbcGVwbpW7qbva1oXM9PQSstv0xzjCB8fjO2DfuP5+g7332Rhf/ettyVUPCMfwOuiUieXIC9n+m2+ej6tarM50P21rQdiqAQaRIms6lcoNq0Fvla4gX7EvYTnRJozQ+fnM7S1oIwWnX1zJiGMN789Dfm4Xauo6zK/w9d4nroC6JiO3YcSF0zo13QC4aBT9BkBvSoeRPewpSH5Doo/cuGFb1bhNsOy7DicR3zU12TFiFwShpjMmQegZjEmYJUmKsyg9DXIRxiXJSL8lJU3vJ54GkQ1suze1t5vT1JAJXvrdpG4b27cDQvg/9fUfhMxRZvxbtM6Kl1UNW9DoMebDMbokTejHqJflT8TOQkVB9AWF2AoVUOxupnQ+6mxFFGi3dXNb8sLtmeLdbvOZKqql7+/kWkjG95kZ7F32FcxsWX60q2jufLV2D8w5f3FYjn+m4xLsVpQWNKs5mkiWC1MrIyYSo5WQI2d0ibpz7ab1J89GLsrpdG7Mtsa87kSKG4NnqjT24+5guaKbJdyit8QYUhZuXgYUM1HvaJo6htOZPtZWBQBmwFcPDiWHJIl5o72D5gcoeL8DSJBWCzCkLrehaGgcHb+Og1wSb0AJ1Q+FjAG9RzKaZaXAcFfIOEqt8hqSSPYOL1OJcPS7h9GlPgNu6gtWmbo075A3dbaj8iaU234lo9NcI+AiowJ3M9olN12Q5Fy4vVv2wTBlqAb475epAWw3rQYaKUhqwK9H5Wm7bp/ThubvDNh3SqNY0BUGoaQBDm28qKe6JJTFI5MUmldogwb0Cs2lVZoknjWvQsGMYbx2O0zCDsqeTWgNUi+BtnQi00TaGblRNZUdh75JG460G59DymU4O5a0DGXtkTWBJWnT0heHpYyKTaKoCRkWfIeb+FTmli+Gr5a4DcuW2H773+k+snbRt1/fS/MKtLIqU2MlQFI3gVl+uf3AeFkoLeyumjN39ZbG+KDdiG6VPnCdY7CtITuyCuxOjUyphYqE3teTLlvtnVDTy3sMtfH3TwpQ+OunNb95WvM3T2o+WF1YPMrtZmai81Um6yILa2gyVMthDSBp3YZzVMTgPSYLLkN365Fs35zawHbpfs5WoEnKNQgfej1bIQsn1GLEsyXVb+6PY3CdR97TRZMzP5IZr8POOpKOUEY8dm3FyB+WybLwk/2nkGxkE76ThMM1SPQzSE6lhIPT7SFt7wGYAYtCMRRffGa94dlDqYp1KSoa63kS7a6JgMO+NtBAekhP4kjQXQilj1N+VsbrNS25ER3troyqYxDWFRWmiQKa/Y6nbrmWwTFxKXcMHfZipDskHNY1STpxbuNr1Og0uiqlk6+hyvwSLgqnUi3mJEWtTmwPIxmXCfc6T8Isc7+4N4DZcCmB5iKHJn7+lPRaEHJWd+XxrYIF3tuDtiiodIuooWVglXD40gB9X11n9pGqbvYoj+5QqUcbzl4a+dwXztdsvGoAWZI0yrG0y/GyW1sxidyM8HurtCQCt//zA8shE25J2IVMkH+XgxQjKqh1dXL+ATFTr7ZMyyIufOXxMCrG2X7ojKBkSiC9TpRK5xuH/FO6Rj6iYUQB+zU+oEiqTBQuHJ2yqoIGdWJBRJX5GuRW6UzQBhzlHIfANwa0pm73dzM01TjE+6xuLhnjzmJ/+PSFZUqPHAGN8kqBbqs/WSj/TACSSgSaO3myzqBfZjC0/nlOdC3aIUFD5JcecrBTgdmWkxL4wQuKPN0LQlvJLOXDNVFr1SL33bWlkA8xbW1A5iNuNM3md5L3aeraNfJq8aSy5f/n++s3/5eqxIee4mSGLesv0y/K/vgDZH1LDE3N0WyHzD3YXbvYqL007XlK00KfqYrEGqob4aTYdMIjRM1N1KepHg0q+5Q2jWZqT5L6toLdpQE6jHQpqp3Nkhuq/PsiUdfydPdd0TRpfIRZ+0Zry81Dv0qqlXRBQXRrX20vhdyBFmd92L5nRFRVobFXV0Mf35Dsji9CPJaK5z2NG9I8w2jn2VMDyOEGqCv9qKsjDyZtO3u+56XI10GBXcLZ/abt+xPzfuH1E5kc9rR+pAr43ad/t1mHaW+GGLaIOus00nTQUvHMJUIpsGB3bs9stFDYhN199Cu3Qw16gZ0K9VgnjZQg2ta7Ty7nXGhesa3mhXPDuhqFCd6lJWvT3USpPz2l2vYV1TNwEdJsLOOyCyTFFqEWqjT0TKkhc61hL1Rj/KbBqUBXGZr32HJyV3M71O70yo74zdwCniCWi/REbqZgBLkrF+aBFLYJ83CWsWq3F4dqJNKgpz1Ty+/qmagfKYG0slaCLNoC+nbmtyWnxVA1yDZ3PLVK3BC5k7MvX8ZyRKy2MZChwxErDSfS2S4KKDQxbebd/7AncSZXvKZlyh2vL0zCIXtWtJImtNq8cmUZassqqNyRAZL946/DuMWlXS4x213WJYhBDpnIXb5rQOOiKB3fYFGUjqOT7Ti1Mg7xsRnPLOiYhFngxaPqfa4MD/os+1M5nsaKLUksG+vuWNFbns2kTbKKJJcxbOoX3A4wd0qRdGe35ost/TJccF0yHKSJtTecZXJNJ86xbmRvx2rL+FT7acIRuLPpZiNIXHEqS9ZfWTlwYdfJ8f1PWV1BLJZgtZbKZahJkUFoMhVMo5AQNVZMnC3SWlSd1V5dMFJaE5liVeZE702VOdWDQ1V2wXzmHCpn2dKjJnxg7Y4gS1BbH8wdFU0nVaripem3TpeeiG4YimJ7JUtfzQ7A7QWhpG3vDnIbedKsz4mQpeKkUOh8cdmBW1rRXb+W17efSQekVxJRrc4Ai+j/zJ7wsFGKpo6DAYweRP8GmNbBz6o6PZZ3GQOEM3ix8eDc65RpS5URDdUhnLGktu16WwLSUxmd0VpqBH1ZiFaVMFmDeiJxw7u6ZdIYc7+/MF4Z1Cb4Tvo0guoPp3UYriphYD96a9QkVZquUS9UpTlseVPa6wv0RmjqHM3pNJSLxajKrlV08eBbBEHNlNyP1XNsVLGuuTEHskr1zLlV2i21Owylc3ep31jfudkgVqweqIUPsdTukumIPn/U2inT9jcF9wKvPc1vSTNDaaHO6ZWHJPKmqfbRJBCHxAvbfF4zKQ1ZttASdulgu4aX4o9Fu3RcSou2UESvNCEviUansT0RZ3pJVBMzyUFnnnRT3Eo/tb9pMn2qr2rz+1N0TW8jbufZE7PJiWGaqsfFiXpCsqwX844skQ98tlyUxJqYQbgTEKYWyYSkxdTumq6TIfWeZPeD3MWjuqd0RJpQW4RZVbw+mY5zeS/iqnq77B34YLYM48mZ+dM8gRSImek2121QrU+kug18Jda09M/JGE7Yf8QEfXX3D5J4t01+G/a14e1JASlQNyTUpcFhQYpLiyT77HpGmm48W+u/0PNAjUwt10GFfL5UBzmQnHxSZT6bfHLxAsmapimGhRY1p5ZZhyXUu08znoYz0cQ93iMLPT6zTqjMlsS0pD8k5WsD7ux9X+gSD8x0eDP1Loa4quyLxOedL17XXFe0TWqxTdiQk9wBOdQqf77MICutK06z5sNMGrYfHsEQkxlTe1aIytznXGfXGJ12oVeRLF1p3fy+vuSw0xMOmQZuaLvhkhQWy0Eq6y9A9UDtBSCTu+9KYciZtKEv5VP1iDRfiUnNrES9sCS7smlo24dDmsk0m+hlNO6w7Cp17UslC8Naz7inmUlyl2pmgqWiKtJe6DBWon9S9qnRJMn68q87VisRri5Xs3kh7+ZfsLEgZU3z0oRtBd/lwrgdtbNlvJdlWPpMujDL4t1Vqi81tJMTpVY1DbGMq7RGSZ5sY2q18IV59oVFq5ZcjNgl8EO5EQKI7fTRuUSHO109OVdjKoF08pFnDh/IhIth+3s62i7TtnW7uwpEFvCnTiCa2xAtquLiXR8uUvcXdZ3eAFLml6y6RzYfrbyfW3NfE0cvntHa2pfTiw6h0J1C4bBzZXdeJkLofeAmQm2bkimd3PTbzyhf7lOcSyiHYJpkDNoIKIcS7Ey5q6+yH+D268fnL8nR6XUn012acPFHB4v2+tOLbwiomh+GWwaM1Y078mLiprBLqUwcsJriLr4Kq7sVUorHUxTDO8XLsfDxKybq/Q/u/3dXMaMzdQJdd6gq4SVph6um9OIZQy9Seok16hFK7ruUTOncBRhlOKDNhgmNiExDBv3rUMJ+OYxQWqQDaPDb86xCTecZwJ9Cl6vMBZThGEV/J4MwrfISW3dySXLXL2uPVYiuYDwSwx2wpDS2uRcyK5sc1pof1qG78S6JFqd3l0R/zA5cSyGXnyndPxc1th7fgeOuWg5n+QTafjSSQw8HRy52l+sEz8uBxdU0LnP3W7t7NYc9lKp2QTr+mMOm6Wpl6kbXyoRzyHrH4BagwtLkUNlMvii+pmvSXiePHYStkPEw2kzJPUjhEnnxOuWjakLpWhcNgNTxxnA3gY3xEVdEdwGRkOxnVRjLzQ5n+E4WYCz7Z3ud8fS5WWhVhQRp0/ttF2np/hGL/UtvW9TR8fnCHp+XkrDHIRF/f+uzkvGQo7dRjbT6uBZGranFoSm1Dx6H3X3+tXf/dBs1q57T2fIfqLULb5a9D4aYwjY5OKYvuXUf2vs+0cauMToqtPfOw31WP4oS2F3y/VDRL7jXqo998n6rHTe75TL2Ezc7MHGWkMzKvesDHL28dYeuSHeLjT+2M712OuqvHbAdPDKQOAM5y4WTH/9cOLhz6rDrTckf4Gazvnn7bqkm/OvPt//4283m+ubtO/e6ve6/mER/85cfqOhv/vLDUvS3r2+o6G9f35xDr/K3S1F/+fj2HJrZtYfDnIX7/NPt6wV4NzeLB/XzT7c3N2fHEzGXswFinucAs+OEyf/80+2CeUfMNe3t3fPLcEkj4J5fhEschfXScSAwv8NdwPlmx2moizGJs/b29c13y+bNYZNmzmGfn7vHx927xV3+97/fTXX2/wUAAP//J3zxGg=="
+	return "eJzsfeuT2zaW7/f+K1D5YqdutxK3Y9dcV92p27Hz6Eo88R3bd2dna0sNEYcU0iRAA6DUyl+/dfAgwZdEqDuzX7acciyJ+J0D4ODgvABekXs4vCGZrCopLggx3JTwhrwNnxnoTPHacCnekL9eEELIWykM5UL7RiTnUDJN6I7ykm5KIFwQWpYEdiAMMYca9OqC+MfeXBByRQSt4I17YFVJ1pRgkSeIEfJpC/Z5InNitkDc88RsqSEFCFDUALO/OLyLEQWaIfsWDrl5g13eS8XsN/BAqxq7XMqiAHbFxTwrNxbI/7YBbalmWyoKz49RvChAjfjB1j9KZb/NuR0iA4Xi5tB1B0gtteY4gDtaNqAJVfDGN6bGKL5pDOh1JRnPObBLkinAvl8SBiXYfzQ1c99Ucof/y6TIebF2LF4SKhjhghtOy7XOqOiNFfIVjVGhZFOPh+JH5L7jBic2ntoOrqZm65l3g27gwfgveog4v/gwMbIdn5V/sGpKw9cxfEdA0b3/ZmpaZ+cwiNSIJPm05ZpwTSgRUlxRQcvDH8Bc59z0ck0aDXlTRli5VIQWhYKC4gjp1UVvGAxVBZj1aDT6vI4GxDUj2MxS0Ieq5OJ+hH6oIQUWO2oZIM/xn5eEcXVJOvyvB/gMdjxLouBaDGC4kCwJxTYgCmoFGoThomjnCbVL+Lc+aAPVgFjD2VJSnUygRDQaFLl9R55/vn33tR0UyBq7RjlDJnIOijz/aH/MO37kXoAa8GC/W8pFOzG21TMrYwrndwBaLO8YQtaKV1Qd3EK2/fppwPoQv13xZ1GIdfQEenVcBFo1/O3r776dI4gYMQmUBZkZWnaiYhfhgLQGMxSKjZQlUDEkblQDU8Q/giE8orulTvffOeg7suGGaDAr8lvFDe5H0mxB7bkeDoMGU/x5vBRpvPA/AgXHSSlFcVRCNf8D9y6yORjQqEOgZKgypSgPhDIGjOy3IMgd4t3hL3fY7m6oVyrDqz5p3LfmSJdUGxK2PWJ4X87Ic/uNpZtJYdDo2FPdNhjSztJphy3+COUKDGXUUEvaPT+iLBUvuOiRntsU493qRhCqFD1gp7VRXBTaP7xBxUg7uwEeDChBS08Jd44Ix+D+hiO2sraIF7RL253Pf/+VcDduTO5FKSnOZa5ktSK/ifIQweimrqVCGeeCVDT77eMl2XFqYe7fv7s1UP3bFhT8qGSlO1MhbOn4JwgmzwOnXORSVXbxotQIaTp78k83BhZv+6PNnki7GUVovj8oCoPZ11By0Tz4Z4dG1oBBFPuPP/yKDfzuYw6x6IUxmR4N3EBShgOp2c0nkJCb3yEzqwGskiUkw1qkZ7rtjZId9wGYyYpy8WhoB4M7N47ukEgJOyhP0GiVr/42ga5FXl1ceF9qA9R0ntT37tMCPwrbpTpTPQlDgBV29mKC807khw4Vckg0CBbMrFIWpAKtaQF6RW6jp2wzrlsojXuRXQLe1WiUW8OoZqxmwR+pcR6NX0jMYnLjF3oMZpuQrdTGU/LPf5L4a5+PS/zNfnWHH+9aHGlHeZ6v1XjQAsXTA9fyRjVRYBolgJHNwfIha3RGcRSdYYraYb/l2bZjPBo71QjBRTHBDW5Qf0ixgJvw5J/JzQ6UDu7zUWb8g0GssPHYS+e65xYHSl/9X+yKNrSqv5rbmxV8abgC1jON3LbRe65dxDdN0WhDrl+bLbn+9sXrS/Li+s3LV29evVy9fHl9ukMtS86oaV16u0AUZFIxu2O2/Rt0ytBCH6dyozbcKLTS8Vk3WhkVZANW3mtQbqLQcccPRlGho3BGGKcBYacd/BP4+xuvtPxX7sN6SgfOMNrqKtxaujWFCsoRG3AASkm1bKvriPyAjTwe0kDtiKuJMsbxWVpaOwFXdka1NcUsHT29G3bceGXWfj9hfR1hq2PN46xGBDLJxuiRNb0IHUHG0OZQj6GH+9YCdMRZhS0qK2XDomgffiS1kjvOALvpbNnpbet9sHTROiRZr6n2ToBXQZSxtX1g3ZrHtZIZaC3V7C6Gj65sq1WAHS5syE6s3r9F21ufwxX5MI6yEciuL0mRgY2FMF5wQ0uZgY+QTfLGhTZUZLDm7Dgvt/5BdME9S7iJoOm85QIWUDi9M7U04n19GRX/wDqSs3aczfWqAsab6jj19w7CymYacW/m8JKbwzra8loOGn0FVJurF9lxFm4iIIJAhHe7HdfWpEBzot3m5jiqlbS6kbMhK/6Xq4fjnMSi55sgLz9JWZTgVto8dQXFya327/aZU/3zC53J7B5Ut9Lfhc8T4O43og01aJOWJWTGO4G+Ha5ZvZXKrN0O8IbktNQoNlRkW6kCvat2lUeLPO5yy9b0/hA3iZv5PQHUirPH6cTPgn9poAMknK2Okato8UgtHMuFhQvWqWcADYlNw0tDpDjGSqQMzuTE7+WgrPwdo1XSDZR6RK1nS5ywJ07wcmtHwtFphRYXayeyP7tPEyC3aAxEgirVhOrpZBNhT0qmp50ml4+fk5+9WzGejSeSdOzXpJBTlW25gcw06gn60IMjz2FVrMjDX16vX393SaiqLkldZ5ek4rX+esyK1Ku6pAZN+sdx8ttHEoA8DxkII/UlaTaNMM0l2XPB5H6Gib7Hcz4PHmeSRk4rXh4eTcLB+E4qYFtqMB244VRcklwBbDQ70dt7UALKx3HyacLffKaJg54fB16PyPJ6GcVfuTaoTm8/XFHGFGgNekygotmIQlLHApktVWxPFXTEMPzQ0LI8kPc3b2Megha7bzbYfQO602W/xN9NkO1+b43wvkXdgZJYkx3flLtGJ9Vf92iyEqwle4LNKRqBWjILfTFJqsvoPJ7SB8nI59t3Y0L4t65pBk9GqkMcE0P/70lH0GZRp4dw6da+jJBDIxWtx5SoENLY6NuTkYsgp2k+pbkU0W1hZ+SyI/sEBuMkXYfrNUwmq6oR6Drx2JkP35Lbd9Na5sdS7jFtuO3rlhiu0y6xBdU+ccXZSVUiwOyluk/WIzEbjxvBtwEJXe88dPrSJ1TyQ1tWgD/RTEmtiZGy1CHHxXsBHEK4uSQlvwfyT4B7W03zsVE8s7me8EzkMb548+v/+/y/v/yz/OV/ff/qx5e/vKtg97p69eE936ji/4RZLEDyupu+n0Defpiet59AForWW56RUmZ2SZHxFmHxpufP/nRy4gqQZ0waVmmAMHGw4gk8pRZ3vLdnshFGHdZcy/Vk/C2J6O3H30gWlRd49DFZ56I/cTcd6CWxZhySRyccMBhl41Yim3AMMlwiTzza3BzGhIKsRc28YIBc15KLhQr8VykKbhoGduWU1NgPY3pUi0fEUm8aI4WsZKND3kM01QYrdIZ0pCqo4H9Q8/TzGUOH7+iQsdbfpQ3jJtLgN+Fzj9qnLWiw0UpE81HyLrXiV7/D8lWFcYy167fNYUxW+sWrOzydUQOFVIeL+YFpFZ4lfqVCOefscH0KmZRnusXH6Bz1eUIGiu9C6Al7e+eyLTbCcLe6mImPT/E2HELHo8/ePNNd9mSUO18yOHRYV3SEeCkLLkKRWQ8lAWSqOSS0hzyHzPAdTCLlOgEKU6lhiU2BpWBh5dYkSELNW6hz67WH4pyxmYTSCVCYvJsEyXVx3ghPouFAr9EAJmRKYmfX321O7hRoWe4wfaFtoRayjBW4qoHp1LlN5t8Fkl26fc/LMliohKI9XqNhJXPS8FBGg610qLb1lL1zF2BcCAMXFLn6K1FSmjZcEa+8ybU3PZbz6y8iHNASweZgIBFnsB4nEHOdCDlalxOgqZjd+pwAK9KwumrRAQ4kAg3X6wSkToTs1u0EWJ6KNl6/LeqTF4XRzEh1fMGkVoXRzBrBRDcbX69ny98aVASG92zCcyvEkKWokCpq/rjSMDsa//rKsCFZXxhGyBGD6uw+tYaTLR2oaqpMBcLonjXjfb1Jg6ZH4YN7sKvX9NHUWJRa0HYZTLE+CXv7rm+11SkYVKH68V0ZQUUrdTE72IY8z2RVfd0HsxZ4KppthM4aAlrfhgs8YKJoBQaLIp4j7/apATl4WMz6zUbLsjH+4Ipf9fAAWWO6ItmAm+3ZUlyU26xRdogxXoN7N+MKUNoOfVSqCp0CG2aMqqKxoollapSUXPdPaWnZqAxOy+hH+9yRk1ZzAtsmFQY5hhHHCippgPhofr/3WPSc1HupTM/3DECDYsOTYCE3GFS9G67V8dNWR5lzBc8mnIKiWsuM48Exsuf4mTSCPxCNSfr+TDHQhotO8x+drnfdw2FE/2fu/vvmzsdjV25x92cwptij9y48HDrgUYhRNM95Rp7fcZHJioviDjXgnWxMIfHT1z3ibYhjLDNTk67hSwOiTbuMYkAzfg1OXWjqpy/wbaXM6h+teRGVyboc5Yp87JMkvj1qLwzHSRRXbE4aLszL6+Aj+RQnav2MCqJkWcrdUGo06CihfHKShx2yjTGG3fJuJKHOoVmRm1AdjiXAJTW9n1ukgGK9tS3duROnGkXTxlz6DCvQTWmWhHx0k9kdFQs3KS+nuoOdcIBhMqw04MEkV6IsBXnucb7JKS+H52Z0U+E5M489lJ++BHWtrDHmv5tqNcGlbRKWF9qmU/rKMh9rrTELHRP+iFz7/fRoHpGAIAXhrN203d/TC8FQdEdRaDmQBPzv9t2K3BonDEK2B5ywU+Fsme2++9567lTYI23BK1iNuqohk4Kd0VnsXtv4ZAcPNc8w7R6hkU6W/Vk4P1qXBB4yqI09t9PWcNue4Qk+PFtA7nTjI4pdVwZpyZOyE09XPBNmi9aUVB6QbAA/U1tW19TdGYWFwhSFOs+UpJv4xzDCeyvjWyBfWX6/Qu5dNAYd2Eu/l1z2gHAMr7xO+Xo14tSPfyqzX301QjpbqlqsgLSVjzsi1p3638p9EE2rUqkmNSisNgK2Ip99WYiJBKGz8/FP5ClY/WJL/6xoOHse2JTe8ULS04xovumLOSnt9SdUstgmI7NhJIV9GZw6yL1oFG0Dv5UOsBjsUpDcwXISzt7ksrGXGZBvOpyALDe/r1ODHSNxQZDUcMYkSHoEYxJmSZDiJEpPg5yFcU4w0h0tjMP7HaBKFAPT3jHgdwp3Kl/XkHFaWnruOPLXA0JILrX791wwXDOIGhkrTo0ryEGhpckGlOQ5YUI3Rr0of4fIDVQpiK4wHFuhLg/sYgZtxGx0LH8RND5P8pIWOMqEdreGdJCRf7QIEiEsN7ic6S7TfevPn0RZoOLQkOhOpnS2mj+2o4/rtoljVScYF2ByXhpQpKboKhPGdS01j3ACeMWFVCnYXsZtu2n9SbPMpCDSEG0Ncd1onlpMxpK4NCO/2+9cwczieJJACPQhRWHnZUAx4/U2TVMHdzpTh9pID0A0uOMKA/i0lcgaPF3fDpC3fgeQIIzioFNwO1fUNw6GXydBNog3oITqJ4WMBrVDMopkJUd3Fw/KuVFqldeQRHQHxCIa93Cw90ZYk/oEuK55Iv+9MO8ADSvLU9BwfKHM+xXpVnONgIssFbib0S64aZ0ka8LtbNoH3ZQBqYr+fp4aoL/PqQEscEtBdBl7FrcLULEMJipA23RKoxhQFTqhKbChjcXr6RJ/vAmFpFC0wj1oQK9QVBipkpZnTStfMKMJrV3FVRFZ+AMS/QDaQhpto0h1za0bWacgm+3YNmndkfYCCx9yGVAy5pBEyhxI40UybVr6y2ERMd8kKE8ufMJ3eBhbZjZ9wQYUI7NhEblPn/49Pg8cYAbnNJIWRa2kkZksB5DxoYQFeF5Y3t+8JbQspOJmW81td3WeJvigMIWOunxPFSMaMgXZAc8ebOUQG81Jfb6eRL/TG6G6F/cYkKHfptAYOSj0xeOaXz+u+ctHNR9kFxaBhBNvXlcmGV9llBdZRAxLQcs2BxC1DogqFdFbj1HCZYAID8n7G268tl18Lj/g5fUZiw+tnpyLwi7qruA1YJapdnN/HL3pPADVqUPpQOdHMqO1PyGdgooOzUPXlsOQTxGlhc/QC337yQcbyYTtJGB/BQLtDJZCTcDe6nYftncAeLtGVN0fSNhn1hua3ZeyWJe8ShM929wbWM808TjkSwMNxJetBXKMJwlNm5WfsrMyWq/TghvB0O7KqDoBIV1RYSCAjn+Zgt/enmhbesPEhtzRddhxZQYUBOzXddLqxLkN3ajRaLRVSke7IUt2jhT52wUXS5JMrU5sL5Ualwn3mE/CLJkrqB7AbKgQkGYi+ya4rzAM/NSKCxzrmhZD9Bxo7yzxAnSfRPUtvaj4S/QG6LvqKjMPKeC4Qe4qrDTHywEfjL9Db4Ar6zPna9Zf1YAimTTKobQLG3Kb9Q3ROxz3EX4vS5tE4Ob/vyUMMo4knMsE7BsGgo+ooNZVokgh4h2EEKdRovCdcMeU0A4hlOyGxgiuTAHmnFVpbWMff4pz5CMamhewW+MDMoUONqDl9K7KhXyihIgs2RrwHsSsE89FmLJkeAiZuMaAu6m9xaPRYwpXu6xuUsDDGHc79tsPn0km1cgQULheU6Db6k/ixD4GaEFBp0CeqDPolxkMSFnxTCHWDQluRC71wMBMOWY5rVKQvRUUYqo9JzRAilLcXyVqrZrbfIYAU3JxH8LWePffSBp1s/k9BbrUdY2lwl4tHlW29D++vXr5nynodjYHluJkhC2LswOLoMNF4K4luqb6oHM9wB3ckrAA17V4psP1B15cBrgyk1V11sbTKzadsAhRcyfq01iPepV9TJuGbWoHKUTCHoTZcRFhxKmoQCErqdYp4Hi2BKsjXcvj7GeyqmnS+HC9do3Whup7r2wGqDlPQUSl0XHJxRYUP2nD9i2jRFXlG9vE3VDIK5207+CirOkBLwvuaVwf5hmAsycPDaCEa0jN9KOuDjLY6BEoFztacrb2CiwFOaD2mwZglhj3892P1uQAkNcPqQv89sM/2qjD5JDyRLeF11mnkaadlopmNhCaAgtYaibwBky3QyEnWABpq1IG+PKMfcrXYx3dpHji3nr7wR7Gx7wEyRUtrBnW1SgM0FF2c33uaaLYnp5Sbbsq1TLYVcd8GRtdSFJsAWqhSkPLNNVlrhXsOB6nxoaTjq7UoM/aW0O17GggcpaCZ6csfDOXwOPFI5bcTMEIShfj+j4FF58/KVi1PYuTAou7Quz0hJ3CIU3Vj5QgUgiUIAqzHWBITPSmoMgaRBs7nsoSN4nSScnnz6N56d1TsQBEQ4YGR6g0nAhnWy+gUHUKajD//ZnEKaHEBZ8WKceJXBqEQ/Gs0kqacNemla1bkTmpoMI4KBfkl+8H2C7skgIdtu1girZZVIyQoIYdZTPP8tKxB4u8dBydbEtTK+MQH5thebEKQRi/no+Zwah6nyrCgzbL7liMpzE8T6EkG6xiMaBy2h7pGGBmFUtBDG5Tv+B2gLmV8v68nC+2dGk4b7pkGAabyL3hLCfXdOIcq0b0TqwGQJ26f2p/lflsuFnz4qmiZP3Myp5ys45ew/KY7ApikQgrUHQRapVCwTeZSq/gIknUWCFwtkhrpeqs9hU0I6U1ESnGwGKa9YYRxUQLDlUZpNCwIkEYBXyHYP+qCedY4/qMUQMlLLRP89sdqVIWz3S/dYDE1FOiGYZLMVhffuiDHz8AN31/cBG6ad8B90x3B0hOxdUq+vsZavxIcdmemmx7fi2vaz8TDohfLZfC7sTqTLR/Zm94wDdxpQCFDTBYEP03eQVUnlV1fL36Iuhwlzo2DgXMA1wU2lJmiRvV3t+xJPM23xaBBGyrMrpNayG2LwtRsuI6a9D2i8zwAE2zpDGm9lxg++q3NsB31KbhdQqJwHgIfWJVwmD/CMA45jU/N0e9UJUyyGlTmqsz9IZvatNB/eYB3PpiqcquVXTeebMgz3TvPYeBgDVsZLGuqdb7JCqdcOZS2VS7xcDXrODLWcf6zs4GnDsbCwsfQqndOdMRbH7ftGeI+xjDgJp1vHagUqjEkaG2KxxOZB4iz3s5lQmlmRiCDIttPq4ZlYYkndLBdg0tw7V7J07p4ECnVZsEuVno5KLJmJwSDUbjxx+OpkRVdlZS5KiZgus2md84mD7FK56ie4Su6R3EDZjJ0eQgcjP1uDhRjwiW9Xze0U5kTc81nmpv1GPcHY8wlSTjIs2n5uKUS72rUvD8uguvXJjSEXFAbRFmVdH6aDjOxr0Ss+pt2tvLwXTnURweG5k/LhNIITEy3ca6NejJULeGL4k1Lf17MgZw/5ot6It9j2wKnS74rcmXhrY3BcRAAT09NTgsSMHP8Tm7gIx7Rw4pyP0870LLAzVyarkOKuTTpToogcnBJwwKzAWfrL9Qp6Il7qgstczap1BvP8xYGjgMqWe8Rzt01z7AcpmZMjEsiWdX8QWHoE1b6BIuzLR4QRsOaOnErLIrEp83vmhdU1WlHVILbfyBnOhdvgPwf8GaQVFaV1Tfn5e7sCY5wfbDKxhCMGPqzEqiMnclvrM5RqtdknxM/y70Rc4lWmSJgWIfHZ41yBRQLUUKYhTCIgyExApqQj1Q+yKnydN3eHtcEqktjGwpd+QWkab3JdRmqZGVoBeWRFc2jU6BDmEm3WyClYFZb7KrYtMer8fCQxQDWtiXGs7VzAk7Vaoi7bkOYyX6J0WfGpW0sj7//ZbYK+5RQI2cjQs5M/+MgwWxaOpn2h8r+IZxjVDzZbznRVj6QrowyoLk0m2p4T4ZtQ/AdRpiGbK0Gl8F2x1jCnD6jAANbq8Li1ZNcjFiF8D35UYIwPPpq3MTDe44e3KqxlRA0s1HTjicI+Nf8O112QB3l3as276rgGde+KZuIGrrtAeEeFWcferDeur2tTnHpxel/IysexDzUeb9aHwalXLi6IU7Wtv95XjSwRe6p1DYb23Znd16g+uNV1N5qLwpMRPVvbE9kLLB4EfYFKcCyt6ZTkHvPCAGJZiZcldXZd8ijG+XGV4V0zXt1bLOsbTkYtEePz3/JgFV0X04OOmtepTxxl55MSZzNpWJC1aPHJWfR24vj8SrSo9R9H0KLznExy8Jr3ff2b9fX4aIztQNdN2lqgmdTLtcNaYX7hjy2KP3i8/cLXeDl5Iz62CU/oI24yc0IGL6FPqvQ/Hn5dBDaZH2oOyFDDa3kEnhBMDdQsdkZh1Kf42iFWrsaFBePLc3l0TvbCfttQrBFAxXYmCNK+oBnhO8+rVsGKwV3a89u+FdEi2OP+rvEvz9MdtThS8r1hfzczQzbCgcofX4HTj2lfn+Lh9P241GdOlh77I7Er1cx1teFixk07BeiIbhRauJYexE1jim9mVGDDZN2FAIqRtVY+mpvYesdw1ueNfXWNlMdvRT+zov/7ofZIJBzkW4jDaTYofH9jCQF16Lf5CNPdzHoPMGQCi8EqydwMbOJ23RrUPEBflVFtrgm+GkIreiwGjE39rX0k/fmzXz6q/pFTczn3YCWj9n8sVf86+9eiyl6KVXx17y9UgyDnJ1sfAlZonU3mKNkTq0rzFbXZx4h9fcG7yO9qeceYOXf48V7rFrTC0Uylnn/n1WP2J44jb6vkdk2Xut+thH32+FL/lbvsZ+pnqLSzVvyaCWRB17cHc/d5eu4FBqu97xUWuI2Uc8En65hQcCAmeAEcbt+nHPrS5GSyjwuynpPVxv1tevXl/MT36P+e9/vfnlh+vN1fWr1+5FjjH7F5PoL//yXSr6y798txT91YvrVPRXL65PoVfs1VLU9+9enULTW/piKdzHn29eLMC7vl48qB9/vrm+PjmeiLlcDBDztAToLU2Y/I8/3yyYd8Rcp/X+5XpZ/1+mLATkdr1wDFKWgMVdOg4Jwm9xF0i+3tI01MWYibP26sX1N8vmzWInzZzFPj13Dw/b14tZ/sc/Xk8x+18DAG+loSY="
 }
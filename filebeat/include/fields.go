@@ -31,5 +31,5 @@ func init() {
 
 // Asset returns asset data
 func Asset() string {
-	return "eJzsfetzG7mx7/f9K1D6EvsWNSvLXp+sTtWp40iyzcQPxZKTm+O4KHAGJLGaAWYBjGjuqfzvt9DAvDEvcih5b6gPLpOc6f7h1d1odDeO0R3ZnKGQL39ASFEVkjP0ji/RgoYE+ZwpwtQPCAVE+oLGinJ2hv7rB4QQOudMYcqkftc8HlJGpPcDQgtKwkCewWPHiOGInCHJE+ET+AohtYnJmea85iKw3wnya0IFCc6QEkn6oIOv/rtZEcNyIXiE1ivqr5BaGQRojSUSBAceullRacBAUwCtfgzPJQ8TRVCM1QopDl9qel7G4TUXiHzDUaw75PbHeyx+DPnyR7mRikReyJe33g+l9vHFQhJVal/I2bLWuAUOZd/WGZqATpCYC0UC00SpsFASYVUBEREp8bLcy4p8S2HRJeOCzPCc35MzdLJlx9tZgfgi73Pd32Yw4Cs7IyropBIER72mQI9e0rPUUETrFWEAgbJlOtJEaBhygnzM0JygP0gV8ET9AXEB/ydC/KEMLxZcxsRXXHgaXHvvxIL4WOmvX3rPu/uMsjhR0ObqlCX3ui/1nF0SRoSmWZq4VCKYA2aS3uMwIUjDpAtKgozHggv4/VazuEUcQCDK4EvDXBIfvrTD9pqGZE6w0v21oHa80JOLy6tPl+evbi4vzpAkBN3Cy9Aht0/L/ZX/suVE+p10SrnVeprNFI2IVDiK2xs5ZcjHklh+SyIVimlMYMXEWEhixFFGrbyC7DqTE0QVkooLIjPK+hku6JIyHKLb/84o3KInQs9NSZjSiyElb5ZISrkkJp+aHqE5cejjSrN1T0iivIgHSdhjbLOeNC8gtcIqH0zgZ0a5gY/+NICLfa03G7mRIV96C+zTkKrNeGLbEkTkmxLY1xiyMY0F5YKqjRtK+utoUFKC6dw2fNp6Q5J7ot+YhXhOwrHktMaySiJsJDSehwSljNoHZe8wUkZeTQ/4REovFnwpxtNXGoBmkI6HJd/EnAbjzQQaFJgC+TJTMyfSURmNb0owZe6cekTcU58U17urpxu4XJu3gVaFsJ5JIblvnUDNlsVSC0943UF2EeKl7Gq+2/KEV9v6w3znC6LlVwl6gFVHn5feLfPVLyPOnCrWvuBl6oovMpKZxJBGllLZoknQfJNJZEONRzEWVHKWEcxVlaZVmJJaWrv1oObhoekCzblaISwIooFWbz4OM7KchZsibbniSRhouy+RpKrLVkrFniAy5kwSTyqsEjnzeUCaZn5Df7+9ublCKR1UoJNuI7INxIuTF20QSIhjSYxZMRDDpXnVKPk5UWsCpvCviTY2MAtyfJShiIYh1TYPZ0FVBpQRWdtjFhK2VKuBmM7tBsG8nM72cm/NeVCVuxYBQPciolY8GL52P9mmm/e9H36wG1w9J/Md7p/Mp7Zdrc+jiDNkrQu9n0X4HtMQNAdlCIehXUMaXWnbW2oVLIZ+1kxRO2iESBIWpFacXgl2eydhNWRPwWsF803bQdbINWZsIjAYudpImujvmbGTjN1MpVkjmiZV+iPjqkgMXkErLpXlZJ+/4SjdnWY4Jvo3Y3Trj7f5Ai0Z33VcXr3TUo499HqKDQSRSgQjIIzASo61Lah70ezdy1IQgBf6TiSMUbZ0oNEL7DfOeqBJn9wnmnsiJM3EagsY+2A6rWA69zWSj3KBetSkipx7vgUXEVal5zJR+CpZJlKh05dqhU5Pnr2coGenZ89/Ovvpuff8+Wm/3jUyPlNEZhnqBSKIz0VQ2TiWG6U6dfcrMadKYLGBZ01vWSeCnu8xEWagtHTVH5TATGLYR+b7s01cNUiMdCj1I5//Qvx0rZkPswGyLpNViSQiX1Ng2gKzqm0hBBclAEvBk4497KV+KZWA1qbQ8xcHAdXP4hBRtuB6ZVvjwfCRqRIsOgNRo68KufxVLbByaJaOV2NQ0OjIpb16US+q88Ikyp0eqEE/9aJupolVUX7IkyDXUef6o7aO7mlAdDMVDrDCbrX13v5qLCe/9KrUY5WLIBwEM3hglpJMTTAuGrWYftSDt7yUbHVhE79j9X4oqLcyQg9dcSmpnrigkyRYecQ/naClTyaICxTQJVU45D7BrOb0zLBRJhVmPpnRjqUztQ+i6UUKSSsRFGF/pc3Nbg7dminjUdTr/bjYB2aFeZb1szr1IhLQJGrn/t6QMO61QcytmWP24AWVlyFI5DHBUh0/8zsEaYEQAo1Ic21HpYFDZa7mWqYcyMZsVDMo9pfjb/2nnn1FY3nD+TIkZqU1cxdk2alqP8EzXe2zCz3g/h2sH7vSL9LPDuLmN9hcaPEbhiR3Kpnf9JqVKy7UzGiAfHuOmb/iIuV3nK3yhhOaDBZy6ocmOZ752z0a7CYTPzP6a0IKDnwauKR6xi5yqY9BHIvzAsil1qkFoA2JeUJDhThrg1IQBlsiOc94Gl9GMy/wiskat5ItgdrtiQ4sU+gJwyebtHoy51P2rfnkIDLVxkBholoffFn05HNTf985My3vYfNy9zF5a7cV9dEYaaYbAeGY5Fj4K6qIrxIxQhtK5NAT4i099O2PL2cvX0wQFtEExbE/QRGN5dM6FC69OMRKm/S7Ifl4jVJCFoNPmOJygpJ5wlQyQWvKAr5uAFHe8WyPwdJx8ljgiIabnVkYMraRggQrrCYoIHOK2QQtBCFzGXS09o4IRsLdkNw49pt/kMiQbu4HGtfYlr5q4fiOSjgWnl4d4yAQREoi6wwi7O/WsJTNCotgjQXJmU1QIhMchhv0/tV5EUMqxe6SuW6+gqM0K8v+UvzOwTb/PTPCyxZ1ThQVJVm7Us5f6hR/JdBokBCMeTCCcir0QMwDI1mdrJJdBWOB0xUP0OfpRZ2R/lfG2B+vUTnFOjO9/xu1BzXFhi7sq9r7MTLUUITjOifMGFfgfRuNXYGkm+eY5lKBr1+ynNrYjmAwOvkaulbC4Bj7K3Kai5ejV+abI7d0sb+i9+nBellsWK+aSyzknNAQh07KMHURmW+bBAj2tWiqdVqRT0eXZV4q60NKLcIUx9ubm6sLywdCdrzC61VYqBSJEXFFZiXl1DasHTgBa0gJU2h6hazu8JycE0nErDKJd+SslTW48cBZkEgSGP/mHEvqI5yolTnyMl5064J3giudnPRBlm2m31zeDAednjXB8U566uLsNBGO210lzp8/vXOzXSkVz+rG4wj8gW/NjEKlGWpOu2YVVyRqckcO4ZwdpZVdlEX+cx5sZpIw5c03isi+CFL3veulHuhYEs2J0AYaEMjiT4i4J6J6AujutgURInNFlPHuNlwpaTdjvDTRrnWuFad0D5bnxeP3hB1DvFdg1jjwQVIJypYe+sjCDbIxW4iaztKP1Uia1y5DLBX1JdG7OhSHyZIye2pXOKHkAr5oFhMgw5obXBXwQ1tsm/s5b66JKhurtXlLMQsczXSrjmIHBOSe+tVViTrmWY9uQK5Yn9VGUh+HlmkVanFv9Auvd0XLWh0ACGhXDwTz+dgCirL9gdK0twEVY+Wv9jd6QH4bXA6zoA+sTAmfrwR3Uthi2vXBy6sSvg/aLbBUPRFtiGYPvgyGoXvo9TAI3ZYTcK9DKvC6EZJDtfbEg9AnvC5qVBPQMicLLowG0tjmGxt7fayfPDZPGkXi1oxLwhv2FLsoxTeET6/gqFwbV3pwl1itiCCBtvFJgDiziR52V5OGYVcpuhSoId5LV9bobaM79d6XMsLUA862jGfzNPN5wpTYzKjkLpN7JGDnhguaXn902N6oFCxqNmyNOJaEz2JOazbYgC7S4oaqJDCGUIgVfGhZinBSuedxM0wqR1lVJD5Vmz3j0Cw6UNj+2O+UscfD9RnjCs1B23hZXlvnSho4a7wrhu4gr0oxZrpPD/TYeWVx4EA7Xc+1uJ0iCh8cMePCyL06eRQKuHtq8XC235r8K9W4JbTDNtEs4eWSBO0dElO3S2c7B4I9cUDTCzc3NSo3tYLo8CZmpZSiMr+tx9pmHcWCB4lfCKEt9XPqsU0CqoKiwxa+aPDXGj8teDFTC8MQyFZZfwduyhgN8d9WV3qFO2px5pos33IX7yBj3lGWfDP8IS8CfeAK4qLTeGlBUMD9JCJMrytt7KA58XEiy6OtVmRjHt4wHFEfNNk9FhttuxnyeaR1f++wz0Uwq0Tq9Zw+bUwLxnYYzHBSWyod9F8bgUxZNbECTOowsMynF8YLnLrLwcyFnC6keI0o0ACqbqiMrMeGysg6g+oVem16kUbLAn4XWIF9ghYJhCOklHneSv2VtWypsMkeaoP8FdZ2PHoS0ru6np4Tn0d6NQrO1dPmAZNDXZqd4yWJhM3a+CM2LlY9YDlWD01VZaCQogRh1wZBt6AyYPNNkZizCZL8mhBW87HtokqKCzMlbx3ODS5d399CI5s9pQ/7CWQzCyT3KdgHa6pWxfwtF9u6uu5joFzU0vSctPdJnCoS7eTzBwKQIMPaOkg/NpyNfitNAWcB9bEi0kZcwk88ycoSKK5wWMVV3wZAop59ikr0GxH8GPbj/4mw9SfwBTpBEcFM2vwYUy1CSAVEG+bdyfDWGZpYLEFjpiLRJor4OAwbT5mG8xJEJqEq5AanPNATmZizWC7QAtMwEaRBnD6uo+TWGD6etjy0XX9bI9ly4nBwmDzUFryECJKtm8A8iGeiCMcwPLiTiv2zpTvpgd0ndudGiuu3sIErfd+wjys9k0ffuPZpVTao/3atMTjZEVZWInBUDE3XTx8VnswOi47u//7hz/J/nh/VtnXV/s6LtgTkWzvnqX4EHnfzXNgU72NFpDqGOipD+dPGcCvLnQZu3vjjm+XFev750+L8bz/9x6tr/9f5+XLdn71cYRG0ss9KJcCjbhQn/RmCktp+093qqcOb2rF5uTGwoPVT5fo6abpnWkEGyhgJItXE5DLGXOjfEI1nCxoqIo4qXPKe0G9Vf21e8KUiB51bc4Cfpi/ZvfgKK8R9PxGQcooZZ5uIJ3JmwsdmAWGUBJNKvNRMmzHwdeUp83EpMFP6s88ZM/WAnN+lrykcxdocmdkApAkSCZvhAiH72bzQ3Hll/sO70Qxfdz/+HTwvqhAyVR149KT+i5kzGH26vL5Br66m6ctPi7Mke8/UgPAJvc8ttPwxvXVnJHw6AR0WziAG9onxyfnaTNefqZSJdb+mrJr7Lqezdb9ZZ3DnFCz4jStlquqd1gz42c+n3rOXf/SeeS9O3ZArtnReEYYyn8a46pSvA82eRE/0Bla//tQsGbMAKsuiGessW1jDO7eSCN2EtWiHmVcMUj2PyDfiJ62d6YeJVEScRZxRxcWPEaa15nRDTQTtxAmzn7AAzCr0+dO0EdSPs28x9u9+lMRPBFWbH2eF7u7v3s4NK5hbvQVkOhcH9OJ5SLC49gUPQ1s2Y3gfWrazOQ82nVj1Q7nxbYUnXSDC9GarBal+0Y2tdOKSh3aZ8nyufKGtVW+2661nrwzwob85z2qVlQOwXSyLbOMVlu5ZtMVm23rybck8HymugQGLoTvb/W3Xihbwm/M0p1BLCifQwvDbwiIzSfxGaIuQ4y33SecVJBlDcBkKU7LFOG/+jO8xuqdCJTgspj+6gUtfJPOZ3ERzHs6UXhNQEmhf7UBXGKq10Agys21dIOSHBEOJhyRGBgsCLA7vWQU4BLQ+APAeuAFKJ+41wXczQRZyZp2igH+PyG80ZhlDCFLGEWCY0GTCfCILjWqLfxQ4DEk4E0T6mD0U6kJ/R1jcQZk0ek9s0hA4Y0OCcByHhWQFqXgc151mxeN+LOUsYSG3xTUfoCWGG8wXBgcgAKJn7/txUqzWVcfoEso9MV7Zw/nzq89mjtv5QsSCi8iUuE0FkANis8hG1fBvdyejzo7u2RD9V2kET5SkgdmMmERUVwMKgmUjHwElZVWQqBWlIDh8CJg3cKZhq8VVQSsO9fZCotIqBpmWgm0L1IyGczzKqFy5Xfq/3EczkbCGJdjckD5RIBoqIPnz395bNElcWG0ThCXChrye5cbkbjvcM4ElcgZnPTMtZZqEx9bI32Axx8tSb1qu9oRJc7XD4BIa2UTWIhC0S4p57C7WEBTnd3qIDSiLsxVXoRxWGcJWoTdvziHIxqjeZQPLFcGjnRq9JThGOEw94+C0tuNCfxtsy+p3ZnfzRqFOmSJLR6pKP9UDsHTjgY+e+Hc05JAj1axotGbaG6TPEsJycNwCphg7sSTuFLotBu5jGKQhdxDo7vtJjJm/+f5HEAaPLyD0o9CC72A4G/u0e3Q3PGHLMcf3H5rg73yEN9U2fAdj3NKvbnR5MI64LzEtu2euTXZmenND/YCjOgfq45Qfm0YxZ9Xw3TK7d1Df3j5X9uzkXh/uEc/3Iu89UfgCK3wOhYrhgMgWfi6/2aS4nJ6bKiKjulwE67O/zU8Dk6ZtrRyZIXxz3uzucru6XKvQvVoymc3qG5QyliqnNhQtkVuZNbGuB7qNzjAfzhm/J2JFcNAyrk2TyzXSJUbZwgn5uhw4W1k55vc0Lg4s3MvqAXSd/5fTk2d/PD55eXz6882zk7OTl2fPXkx+fv7865fph9cf0dcv5qTUkPAsCO/XhIjNV/Tlfva3P69++dtX9CUiSlAfzmNfes+9k2NN1zt56Z2+/Prl5CuYhF9eeD9F8usEPsygCrT88gI+a8N5RZX88uznF89/0l9tYiK/fJ2YknPwH4AAx0xf/vr58tM/ZjdvLz/MXl/enL/NaMBpqfzyTD8PN/18+d9/HgHafx6d/e8/jyKs/NUMh6H5OOdcqn8enT3zTv71r399newibyCsW7QLm6WtrNA0G5ydvSCqPHrdIkZ3cAsSMNKpyux066OH/Rp0VhO+5ycnkXRBqWQcZDj0KLYB0b8PWRrNTYZ50sLqWmFFYTUM4dfQrsJcbGNpgjr0U008qxN5YJthis9gyNpwhHzdPq4DFsmAXoLLR2alG7dc8C71Y7YtxYC7EcapIGi6lgOshbQ4vd2rNiB4cTpwMabSrQ2D2ZZRNSpTIw472eqxpyQwsSZNAE6HARA8UbSiocu8P5knmoZZnjx7+z+nf/3T3c+/rF8s1RK/VmzY8qAtCnkajCJ1OiTATcvSD7jfxsvGllGfsiUuBJVN4YuGaDLzY3sYWUYR7R4/FpB5slMuT1NVJtsQoD8ob7BylVLXOHbA03/X9kIvidZQgj3dLkCFQQPTZnR1phNWrrYZAZyrYPUR9NrRBB0xrvTuZKINi1ysTtDRGgumlx5yFBA48gWFW02OHjvxMK9GQnc4QO+cZJr8YY79m88xOAhI6jVHx5tmlsNhpv2bzbRUkVNZ1OLT6/6JvdPpdeYRa7zYltLmsqw9UnhrPNCDV2PUELaov2g8iqPWX7zJ68R11WA8lDkssYVdpI3A2Q9/4GADayBqD7OG+n0xF+7SDdvlfKYAIJK47Tj4uy7LuYdqpTd5CYuu1fJoJRUfuwSmhLg0rJLe1S/7MpfJ3BBu4b6m7Pnp+Pz/bkrHo07+aXQuBBvU8nrHWJRpEF7Re9MwElSRPaxOTdamaLMA0ezOnRY5YRXX+FiKQe1WjZXud8tuyieQ8Ve5pswF9TGr1/qc39GRe6hyd7xhgaRJRsoSSNrVS+VewJGQwV0cK4IDK+zbMfxOK+sC7LSXvyvoRpo0Iz+U5h0lub5Qmjc5lOY9lOY9lObthnUozVtAdCjNeyjN2yta/VCa91Bp5lBL5VCatwnHv09p3iaX+/DavI/tQwTuI3t3LfNO5+7jnjZY7iO33TLvbPtjeoEO5ywlto/tzxYES85m8Uo0lQPY1Zuv6SNDv/GoKdmHJxeOIQuJwzHnoUNDHGzB7O9gCx5swYMtOB6WpjqDd3hxVwwF/Yv+3BBGAr/lNe2d9/lacmj3ONAdK7obsCFfopAy0tsOVTQiUuFooJBNs8Dh1byMfMreLeZd91Hksbx/f/XpQzVtqF+okCH82FFwqDNvbie1ep5FmaUdTSWyddJ1/zddvoFrZdG2bTwUwAGCgyBAifixlDtCN1BxnrKW+dZDmzq6BY0jeCq9ZArkt/UT6pytaBQv3ntDG8VY5JW1NbpmOIskrK7XcbBAiewkDNPuqY5mKqzpHLOitDZfNIhr82N74H5GEf1uBfaoVR7+Yvqsu9KDVEMq33VLs0QIczqLFdibBkjjvrV6lYFhXbveXP+ZL2fOTJCQL6XCslhgOP2qYVKlP7dPqwJdNPrEskDfFYCWu2GXe1/S5g3xXY2rUxuO8PXCcDFqMyZ23LVmpkTlXquJKbTrc2F29XDBxju+fPGLebwprHV/lyFxYVXMOqsHWykD3FZlZ6SBmxZ21njOE2OZiIQxc0sT3BSVA9S92wEv5MsZtKP/au/AeEc29gqiMCEmFQoEXcErkEOpLcR6vvjgBVcncVhZh5X14CureVUNR/cJr1GQRHF2QG1Yhw4mWRgJeMZGdjQWi4oaBm28a1mmu80YW6o3532GpixOlJyg11BoXU7Qx0Tpb/ScOucB8ZvqdnF+N6PMlWO9vSP6EsoRQI0zKNZm86hSF2WfKN8UF8OsFr6yN1jArA2VHc4YC9wQBT18Rl+bEqPZHUEFSObuP1sUthvQzKmkdtNfx/9VRlaCZBIZ5psCZodCa/uPNY0jzpY8mBcsY/tN/xyr9/qFiz9151nlvNCQXKuy+Vrgtu/bEh0Hv00IXCg60v26JmftylWX8s78aNPS11259z0KPNURvU4YlE/EIfKxIksu6G+2glUHuPOP79+/+nAxECKrregehg/5pjrhUEYVZkFIpaqUS+sC5SLbx8iwPphW91VBiqVrcyN/DQsr8/3m+q/v+q9LzQpeKa/M3pebpuzRtomRDQBQy4odP1SjDGR4xMZDesqNiTcb7ereVxClb1r+k/cf3umkdJ2ktShp4MG1k+Y5G0ogs3svi2/WOJib/PxScLotfkwbjv07jgNsQnJLQ9u3Go99HjDiJrJjLmsOg6ayIxOgR0MNM3OFKOS9Qw2/IK941ZzjM5wZ5PDYO1rSfU4L63QUmqJNa+EFfYIYapfFjADEJPdqgeCNXZQZyhCBWZ2j0Tb8ZKfCzCH37/aCF0dwJas2asuY15iqwt27GoCWPnOSh1XAJcU1qsZKpnKn9gq+lpAONpLoLWdMaepIEJUIlpvtLYsH0GihSBkZ8x73CiLpY9YPUJMW3AVMwui3go5U+I6wXMbdXl/e5L/etoGrF3HrF7uX1XZrEB5j9nx+CxmaXmST3HK39h5bUvatYO990J+H2Xvwypb2Xsoe7WLvOQCgBy+HkQPZoihGFhc20xsE5xTAQuCBE+4VM2+Zi540h4KiIRLu8qZaZmmm6R379rZin0cRZ1oYUuaHSUAmaE4kDYgxuEz8bY1jTn5SYmXGyuTJShTSO4Ju/+/xay7WWAQk0P+79dA1IQiH0tyBc5v1ya0rWG6Pwc3ntcDmwj3LcTIPqV9T2GXEMIq3pvM9NF0gxvMXa/zyXsKCpMF/1mp22LoWh6D3WNUtBxeQOkcA1mivfbfVMA5RxSW2jxng/dgRzb/TVPpHq6hyyIQfOxP+8yET/pAJf8iEP2TCHzLhD5nwbkiHTPhD9lP9gUP20yH76f/j7Kcqir1kwufetuGnqyMHHV4aABAx8YR4S89AmqC0lPHThuii0Xy9V9npJ2GKLigR6MnV9KKBrxrRx2zPclO2TRlKqRt6vFPm89y13cV+/GNYM+VSutaRzmV6JJC60j/K7BIXB1HrxCbfYi5Ufh5ya+ncticD5tzQ7kkAgsgkVLstUfAWL9xtMvSRuXNKEtV3oY7vhiwqXXtqucIqL6dpnK4QXNrgRvEdSm8HUK+5QJT5gkSEKb2JxgpP4I5sCAvWVpQJDM5Kf+IgqB3PIVMGM+L3JACvvo8ZmhPEGbT2CN45mqAj+8zRRL9wJBmO5YqrhlrrKy7VLF9d445EQVal8hzO4UuVT+0styYwlWlccl3lfdCmZxhuMkJ1zZh5hxj95rqFe1tR9Ll8pGhnF8yh4nE4kpT5Nso75v7KM/eo6sb7PIrhbh0jAf67cALp8zCJmiqt4pCwAAtnY5KtR8dGqApiDfEs3K5yPbTmCmfexuy3690OWXa+GHOploKUg8quzJeDI8vy97Y8biyhQdsHhJaB7DsmtHre2dQN6d93E1pGI/Jb/TbJnqx+s9IrY/sw8WtFc8otP+oe3TyUDAcRZYMCydLUghrZzJmLFZ7Xy7bkPKONiZwezNJJuV/I3OtXN6/ejR0wF7hi39tCf4o3HXong+BcpEHtfIHw0ECPnO/15bvL8xv0f9DrTx/fwxjK/xyE46/2fgSswAR4rEhCK60FCUr3nnzSnxtkNPzWnquakkOPngFtwGbSsqewHG+LdlMIUp1epNrUoDKnh01BWWMnn2mKZf5p9XsPnZfMxtsIS0XE7QTdyhDfE/0ff0XD4BY90Zr508XrH199fI3Wep/Llgh+ezpx2aa32pCgjIS3/eNzx8oDrDULUjN1Y+6JmHMJ7TKXFd2CXXxrLyhqwLqXxVijOmJI73UaswvxJULvwsi9Nj21FjdT4J5ihBEjas3FXWHD3teq8KMhURm9QteiCLMgveq24aA3VRjeaPdkvIWuYsum63ZTXJDV5ov2/LFRpUcuNVqU1R0Z8XovzfWObMpbsrQD9Fa0fXCwGLN6BITximUSwXXZa6pWDaB8HIYaktVo5vimoNKu4Yv++w5DYMv9RsYd7RLf6IKA2gIcE7Uac7/xjrLkG1DN068ePJ0FSwTexgyVxtNeGqnhyo+eKQHgK9qCayz4UuBoe/tga8ajypurXOCkwMBXJtO6UN2AxteUvZLadks9AXdOnnWROwRNgJVEijuyXot8pawGb2x9xGpXojS3QPpaG11fv9Xtpsygkv3ON9uS83tsiXXHVBhXzaqjV75PYmX8jK8xDTM345Td45AGR17hGQePiGAmEUYygfjpRRIadl5OwT5jB8YGgdj4sDRVOTtudrCwZ/kZviq9vIlYKRLFCq2wRAt4uNrPrTGpA7q0Ev9qw0yrnRtjKbXSPIIeNbHEd2Rz1ISqdsqfTkLHD72g5tWeKwlK5f7SGjjC9UPazGITPI5JUI/XHhmf7tncjLVDrM1fHhNm7vyKIhJQrEi4SVE1gXbUb26NiBkCGKo479Slki4ZVomoT/heOLLXMxevBWbi1e/IpomxK5ikTdb1ADQ4pOTWLmm9iryGVAHzN3ZsiTu6pDm+ZECESfe5fM8YogFxJv1iF/aHjKraPEO9Qzv2Bsuwbe2t7ric0dB1R+f0is/pE6EzoL/6RukMiUsZrcsao1OKeGQS8D1abMZOy/J304N+zfU23boOtOIqITXmL/NKg1n04eMNnD4mASeiHgjbSzeUAh00NR9Lo6I02Wzb3W4gqdqV4z2539z8o6AUSxxpk/OhoLTXWxplvq0XGVBBfMXFZgcQzuj/bJwE51va4gqLJVF2m8ILnpAqQLmmyl85jswLVVkil3rr11UVLx34ETWEjh2Sxo0D9251r2vOMt5y2Tm1T6+OytPf5oSypQniaJw0tX18b2uzjf30otGQG50hDGILx5UrD6AHXf0eWvAwKISNMGICpRvt4xVxlBbuwSwgC5yEyhBoYeec4tADjzLHU84PPsmLhpPuJQCyhznXCCD3WDnYF1yy+yqRYkgX3LWP7CG1eB7cR9qH7568pL1Y16beGO7QPpwf0CFqjz+UwGRB7wrnHzfmm2GBV/al7nJ7OT+0y4mHkx96lJoOKZRdqjo4B3yk2gSNBtYhi/+QxX/I4nehO2Txo0MW/yGLnx2y+A9Z/L1hHbL4D1n8hyz+YXgOWfyHLP4KrEMW/yGLv4Dju8/iLyOB/ewMZvGIu8VChVjDQTrZLwRnirCg2bGxnQ+tuIZTHiB03FtW7N9pEE3egg4Mbr+KyG4rsuTtmWPqQaDgjzLFMn/4fwEAAP//gSMepw=="
+	return "eJzsvWtz2ziWN/5enwKlN5P8/wo7t85Oe+vZWo+dpD2dW8fO9DOTSckQCUlokwAbAO2ot+a7P3VwIUEKvEimnZ5azXZtxSJ5fj8c3A4ODg4eoSuyOUIpX00QUlSl5Ai94Su0pClBMWeKMDVBKCEyFjRXlLMj9F8ThBA64UxhyiR8a15PKSMymiC0pCRN5JF+7RFiOCNHSPJCxET/hJDa5OQIkG+4SOxvgvxWUEGSI6RE4V4M4MJ/F2tiIJeCZ+hmTeM1UmvDAN1giQTBSYQu1lQaMroomi28hheSp4UiKMdqjRTX34K8qER4xQUiX3GWg0Iuv7vG4ruUr76TG6lIFqV8dRlNauXjy6Ukqla+lLPVVuGWOJVDS2dkanaC5FwokpgiSoWFkgirBomMSIlXTrxhochXR4uuGBdkjhf8mhyhx1vchinetgrEl5XOQd+mMvRPtkU02EklCM4GNYEBWoJWaiSimzVhusopW7maJgIappyhGDO0IOhPUiW8UH9CXOh/EyH+VKeXCy5zEisuItDcFqeadnJBYqygQl9Ez7qJgs4oywuly9xssuQadAltdkUYESCz1nCpRLoNmEZ6jdOCIKBJl5Q4vSG05EI/vwSIS8S1thBl+kcDLkmsf7TV9oqmZEGwAn0tqa0v9OD05YePL0+OL16eHiFJCLrUH2uFXD6s66t60q2qf3el1EsNzWyuaEakwlneXcgzhmIsicVbEalQTnOiu3COhSRSPyql1XuQ7WdyhqhCUnFBZCkZ3uGCrijDKbr871LCJXogSC6IJExBZ3DiTRdxkmvD5EOjEVoJ1yNmo9jQPCRRUcaTIh1Qt6UmzQdIrbGqKlPjmVpuwQFl74BiPxsMIzcy5atoiWOaUrUZb9i2AhH5qgSOFfFGxVxQLqjahKm4p6NRcQJd2zZF7tKGJNcEvpineEHSscZpqKd1kWEzQuNFSpAD6q6UO6fhgMI0romQlLPR6sPKg+oQy/j750+fWyRTJTLMIpMrmoypAxACHKypsCMdqUQRq0KQZJ5gha1kkHmE+OJXEjtTw/wxH4VyjgXOiCJClm25pIGABiIpyWCo3C5NKcgoeYYoS8hXkqDFxn2Fzk4bhc0Fj4mUUS74SoxnsUATBG26UljxbeA0Ga3t5TTxQLX4OqjWTjkAjIbrBDrw4OBDxDWNiT/ihzTdgnJuvta6awiG7pOSa5LuLvUNX61g+tSfB8QuU7yS3SLa1h760y59mN9iQWCitOKMQhKsenRe+7aOCx8jzoJGlv0gKg0WvixFViOCmbSp7LAloFe5OdlI41mOBZXlOIpQZayAGec1STALwpYQYETobIkWXK0RFgTRBAycGLu6RYizdOPLlmtepAlY/oUkSUPHa6XySBCZcyZJJBVWhZzHPCFtLb9F3z9eXHxATg7y5LiFZLmEfP74eRcFkuJcEmNY7sjhpflU6w4tiLohejH0WwHmJmZJxY8ylNE0pUiSmLNERl2MrPU5TwlbqfWOnE7sEtF87Fp7XVsLnmzCDDT1KCNqzZPd++5H8z0y30eTiXVxQJusfBx/MX91+TVinmUc1o7avgSPBsLXmKbadqAM4TS1fQjY1RwftVKBAH90Gzg7AEMkCUucHQ89wc7aUveG8i0QjjwDHixhu8wxC5lCYBjitHU9g9/hIVZ25USl6SMgkyrolYy7eRzB6lN/gtZcKotk37/gyPknSh4zeKZ/uoSXL0s59eXXNq9oW2kOsV9xJTc9EKlCMDPFAxTPYdEBWjTem/ooqIl7uhMFY5StAmygg/3O2QA27s27ZFM3T4fZnVBe+HjwMmlaDajTtqkouOpfcpFhVXuvHAqPi1UhFXr6Qq3R08dPXszQk6dHz74/+v5Z9OzZ0/4CVWN8ORGZbggdRJCYi6ThOqgXSvXO3cdiQZXAYqPfNdqybiRo7zkRpqJgdIU/lMBMYu1JKGXAmNDQpl5Xy71s5hai5VhVSCKqPgUDlAFrMCBCcGG/NjArwYseL8ZL+MjKczYF9CacJBSKjFNE2ZIjWhoPBke6SdB3B/ts7GBW/h7wWHbQqqhZOdEWgDejB2evQdL96bwS7bm92uanQdLhw8hNUXHKi6Sao07gT1gPXNOEQDEVtouugNi39qmxnOLapxLhJKmGIJwkc/3C3Il0JhgXrbMYvBrpryInttmxSdzTe99501udYYQ+cCkpNFw9J0lt5ZH46QytYjIDp21CV1ThlMcEs6iVG2VSYRaTOU26uZzZF9HZqaMEkwjKcLwGc7MfoX9mKjH8eX0Yin1h7rWzUs/qaZSRhBZZN/pbI6Jc8Q8Ht2aO9mTNvSmvZFDIRwRL9ehJ3E3h2BOEQBCi1WxHpTYpwJwop7k2RrngMFBWtVpSsU8efe1m4jc9+wlwec35KiWmp7WjC7LqnWo/6nf6ymc7esLjKyKqnn7q/g4IN8/04gJs0jQllVvRPIM+K9dcqLmZAarlOWbxmguH96js5V4n94tc0grPD/4n/md2TiAiosntxsRPjP5WkEogoknUBZfh1S1HYb9daHHOOrUEwJBYFDRViLMuKt5gsCcTO5cTYX0Z7VjaLyq30Gq2RI890cPlTGvC4JSNFjpr1WR/NH8FhJyBMeA1VC4CQ0/VNkFsb8u02Lu1y9vXyY92WbFdGyO1dChXsJFjEa+pItrXejskKENNHHpAolWEvv75xfzF8xnCIpuhPI9nKKO5fLhNhcsoT7ECk/52TN6fIyfIcogJU1zOULEomCpm6IayhN+0kKivePbnYOUEMZY4o+nm1hBGjC2kIMkaqxlKyIJiNkNLQchCJj2lvSKCkfR2TC4C680/SWREt+uB5luwNB+G+IZKBcPp2YdHOEkEePXkNkCG4y2EnQrmYNZYJDdYkAoM3A8FTtMNent84nNwo9hVsYDiKyKrsewn/7cAbPW8NMLrFnUltLKkeyfl6qPe4a96dedBMOfJCJOTp4GcJ1r0JAhV0GQ0pA88QZ/OTreB4P/LHMdkNKhK4jYYrP9G1SDjCWlR4dCpfRiQkYYynG8jYca40t630eA8kWHMMc0lD7cU26LUCnYEgzGIa+TaESbmWVYwWDpRfzHvfkVnp+FR5lXKb9Aay3V9bPHF1dfpbiAp33hEk96hhBF1w8XVzuOIT+N2GjxxkmDpvXSFntk9neXG+bv1IxwLLiVSnKcSySKHKDhac+AgRNUMpfSKoH8QcqWdcueFoDFWuJp6vBXjk6M3P3/64bd/pD/9/3/5/tWzn04zcv0i+/7DW7oQq//janFFOM2r6ntN+NmHcL29JrCNm69pjFIe6y6FtqcILS9cf/pRb8WtCN+j0iAMCLZxbt/h/JVSKXd7bo95wZTYzKnk/o7anqBn5+8RSKmAtfRtWLNEH7mYRugMaTMO4GERDoFXUI/XlMWhZRp0kZG1rYOGmkCurXmf2YZB+DznlA0cwN9wtqKqSIjuOSlW+o9tPCzZLXypx4XijGe8kG7fgxXZgohtHC5WmNHfsRq/Pn3R7jfcJFaud3GO4zV5Wo0B02PzyzQ8Dtin6K0Lkaubf3Z3JNS1K6Rw924pngN0rv7usQDHMLxsKdPHGeahsGENslzZOx6wv3tqcSBMwrP8t2n51ATJuCLz2iKjq7p7eMJ/JymFLaGzD8iuAaIgMuybNBvZLZFh0QVi9XgAm6GJ2adaYEljhAvYfYXQBd0I3VZqkFxtB3wIs3KKe/3yYnfSLmYAqrHcPQ/xKkS6A6ldkT99fBOGheiAuV20jouvS7y1HPaxXdRCc0prHQp3QS5DIupbTT4+BEvMIag3WmyqVWAvA7cNG/poADszRMNIqQW4IVMScU1ERRvItaltSYQoXcpjVpcTHQbGK8JUELWxuTgAshz2oOwFe6QjtxPot8LgIKkE7F6g9xCDZKOvETXKgte2RJrPXqZYKhpLAt45lKfFijIbfeFFmnChf2gfJgBh3l7g5gC/a4ltcT9VxdVD+WilrUoK9sd2McNTh6+AhEAI4Nbj7nY2QA1lN/BMiHy9kTTGqQWNWkll+Ndyq31QX92BkJbt/HeOWdUeO0hRdnekKNuPVI5VvJ7UHo1Ze1r8PrwCZsEQWuUkfLIWPCP7E/c3jYfw5XIPtntwaXqUuxjN770b7MbuvvvDTuz2bIB3WqUC3wQktk6tA/kg9BHfeI3cBiYuyJIL6MFCgsoWG3uK6hG8+ci8aabNaBIi63w4406K2hWkQ57AuIKmt8JqTeAwwgKDuc+Z9R7YVY2dMLckhiZQI3zQXLklb5+5s9VDdIetLeA92qbV6kUakdiJ8VWVXqZ2PgFnS6NBBZwuOzCB4SztccQ0OYVdXiOq56J0gTVCEppMQj6vkXkARA8Lq4+7bTI2zGe7xYRCLNvHmw6kV9a54g5AGO+KCancyavin30ZooGe0tfO82jZrj9vxV/6LGLtiBmXRuXVKYcUjbId12z1FqZmeU8GzmUDiKV8tSJJt0Kqc1291sYARLtroM+whdDUqGhqDYcnW8Fqh4NHqmsjExzuSRF7RyFqenYe2yKhyttzmx7rH1r8tcZPq72YzsLA+v2ylw134DrgcI9vKWazpzfQQ/3bAfonG0OIO44xbygrvppSAHyE3nGlz7dYTy8E4yY8LuC4JEkQGDtoQWJclIcALZE12ejI3WTDcAbuTpagawikX2ys+OrEjN+GmuX0y2pC+v1I2AEldM2nC7SC4Gkyx/XQhQHyIUdIysGN0TggB5XJ08SCn52CZVsFwoHxak5nI8UnNYnQekCGlhqmysjN2FQZuSmpRp7Wzk7dqQfNP0RW4JigZaHDypxkXpUSfrKWLRV2g1dtULzGYMejB7BruyV0QWKeQW8UnKuHYS1AhUkiR1QC1JckUi/Wxq+xcblChVVcI3SmGhWFFCUIT2oSzQJBQP3UK2yx8YUFiyDBQc9iMuJU4ndMJ35rT9DngONY7Q6jqw7Hej2B7AkxyWMK54LQDVXeUdih0/UA1OpMgZ2fW2TfpXCqSHYrn78WAAHt2DbCdpzdYeArl8yFJRRy6UgbOa8f8UK5UiqucNrkVedSHri2b1GJfieCP9Lr8f9E2PoT+BI9RhnBTNpzjlBDSyogBnfL6+HKh12epB1KZ2RisdIzphsSjcsHxThNw1B+hpfBWILIIi2V5WGgB7Iwe7FwfAvTtBDk4R/RUXKpx4IEsmpFsFt7OWlI7NpxODhMjMPk7pfgrDUkpUnmXjwTPh0DeHAnjeBOumf3iV25Eb//egu42u8t67jaO1X0jd+RXRlrr05qCvfGuWA5muNCV3hwTcDUj0CCt6eTwGbR9PqXd3+V/3g2nfTp2wHrRDbdyGfwin49jLm0qToeKXCq64xou+LTpAedJmFs/P716vRm8enj8uRv3//H8Xn82+JkdTMcXkLsfyd8mfJGvxpm8Xg4oJ6kJn3zY7DttM0rTnSKN1vb5vXC6A4Nb9Uz5blj+y4XnE5IKIhUM1iaMQnRshCuSPP5kqaKCL+4dU3AV82nYYX4zLVd2Ls0n/qJp+xaHDx1PI4LobMnYcbZBqID5yZ8bJ4QRkkya8RLzZeYpvrnxlvmz5XA4J+YQdwrM+kOg7+5zyCvAezbzG0A0gwOas6xJ8j+bT5oV54lbT/bXY2m+vr1+AtYT3bG04y3Kh492H5i2gxGH1+eX6DjD2fu44d+Kym/gxOPgsSEXlcWWvUaLN0ZSR/OdKxzOocBDT2Ad/Tf+rQEolIW1v3qoNp1V8nZW2/WGdypuobfuJFwcltp7YSf/PA0evLiz9GT6PnTMGWaB9nmgrKY5jjtJVq+iR7AAhYK+9A4t00HaHSLdq7zsmPtrtxGQos2rr4dZj4xTKEdka8kLjqVGaeFVEQcZZxRxcV3GaZsd6qFoL08desnLNGh0ejTx7NWUt/Nv+Y4vvpOkriA3Y7v5p66yc7kbNvqJegGSNcWd9DiSUqwOI8FT1Ob/mi6L805RPP1coWXXKXbD2ewIiMMYtY6mMKH0/4dF0fKJdqtN8RbTr1O+CreXyZCr09cslILEHVA+rD5Gjfc5m3oPQw8T75NfhuDq+H1iYFomvohTj6vhinZ33IGEWzG4L8+cWfDwXsZJFpRSmyCqLkkfl25/xlqy5TjPddJJw0mJSBEsXNhUm8Z581f8TVG11SoAqf+MfYwcRmLYjGXm2zB07mCPqFTu91VOdAH2IoxKeAoc/ndUJwSDHknUJEjwwVpLrKXuA5ovQfiA3hrKr28bwi+mguylHPrFNX875D5Beha5mDLVoiahglNBn+29ArVTj3HAqcpSeeCyBiz+2Lt6TvD4gqUnNJrYg9/amdsShDO89RaGeBPk4rnOUnaCxOnWMp5wVKOk/sqiUGDAhQMXHqGxEDtx3nhZ10cNigP5PjBbs6ffPiElNdeiIDAfCBcDYUBiu1Dtl8AMBBblNyv6IEFgf8aheCFkjQxuc1NQoGok6bcyG/AkrImSdTJUhCc3gfNC72nYbN+NkkrSJ8C9pJy2WjKWUovW/TtDzAvLSmjch1NQiX59Tqbi4K1dMH2gvQUwCXgM2vKv/7tLSQmEgpG6qq3zSDxITZ6glZuTO6uzT0TWCLneq9nDqPMfGzmr7FY4FVNmxYVaVRIsZ/baggNGo4qvJbr2cVxHlvFQEFxfgVVDGhOO928vLSGQ0y3Pm2dwPazToIMgsOQa4LzydAxswfwR4JzCDmxnnEdOWLrhf6+sy0r6e9kfrXYeu4IUqbIKnBUpZdm1Xmh8BoHppkrmnJ9RipqpQQz051R+gTDiGbUTsYRgdiJFWFjVdz7NHEhd1Bv4NPLMYs3f/wa1JXHl4jXS/AHqM5WnfbX7oYXbDVm/f4dBP6b1/CmWYY/QB136DXMrtSbPn45aQGbQkZ8c/mR9k9MJ31tYLueHBJYIZw1w3frcHANU/nedBL2+vCIRHGURZCk9BQrfKITzuvtKZvAfzoZMnEFPTdNRmbqmk6GtP5QG3UgutHUnjSRTBW+Pml3dzWftPEIM6m4VDk427g0kbpYdERuOUB1w+8e0IGt4jm/JmJNcDIZCtgGFgByMDLlN/XA2TrAuXnu4uK0hVsLLJlOQvifnz5+8udHj188evrDxZPHR49fHD15Pvvh2bMvn8/evXqPvnw2O6VmbzuyJKLfCiI2X9Dn6/nf/rr+9W9f0OeMKEFjvR/7InoWPX4EcqPHL6KnL758fvwFcuBcfX4efZ/JLzP9x1xn85efn+u/wXBeUyU/P/nh+bPv4SfISv/5ywwsdGX+oSnobabPP396+fHv84sfX76bv3p5cfJjKUPvlsrPT+B9fWff5//551Sz/ef06H/+Oc3gPOUcp6n5c8G5VP+cHj2JHv/rX//6MptO+lr7dkt3FQQWJxEdTQDu5bCZFdpaQ1DZS6LidaidtA8xoOAOJtr9Q1Vpp1sfvV6vaWW18Xv2+HEmp5Me/7fHA2qxiwg8bwPbrci6nXRAnUNyLx2msQteS7m8ttgFqd/STbkNs9mQdyyzbuJzXWVdPCAXVWe97tBJdtCSvkZsXrs7M0TvJbxmy+IH3LWR3YGBN9B0EKjWrO6SEbtWbWHw/GmAQXstVaNbFwd4CcFLY4Ka4bAXFtoGJQkyr7cQeLobAcGLrVxndeyP5o0WuKl8/OTHfzz9+S9XP/x683ylVviVYtOdKNCkHf0saYHdDaJnBLjo6PoJj7uwbGwZhWND2AsqO9M/tESTmYfdYWSlxPAkF5C6Pes5WQlZFKveOTMoshFua8/quEMLtiBafu1MUXgOrhi5a/FqD5u6db/20oP/zu3VnLCpgFW1XIA9QUvTnujqPU7oTiCORy508cBUa206Q1PGFaxOZmjqD6szNL3BAraopiiQQGAaCwrBAuk0XAhbwsZ3wWH4lgcPS0RM2R02MojaOLSx/+VtTG8EFPkdNjOLcGhp/8tampvIqZeze3p2dj78YO/Z2XnpEavd1OYXhJYm7nbDbWHtH6Tdwgg1TYdlIkYnTX3dsq8AhT3yL5qEbqPmX7yo8sRVUZdREP2Q5rCW5hBWEhsbgXM3+BrBBtboqD3MWvL3Qfz4ZIAjb0cCILZzO/gPnZbzDrKVXlQpLPp6yzdLqejSPYZOB92mUQxNgSl1XBrcsDo2uCwW4PoqZAf6DWXPno6P/4u58AT14tuuY4INsjEpuE7pgvB8702Yi6SqdvvZSG0QxNoj2ixB7pq1znHCTlzjc/GD2u00Vrun083ziOgTf43rJkNUv2X22pjzKzqyhuz9wE5JBgLuq4VDFOUBku7ppXG/60jMQCqCvSQ72Hdz+DfNrKtpOy3/oaib0aSd+SE179ipeYtDat5Dat5Dat5Dat5Dat5Dat5Dat5Dat5Dat5Dat5Dat7xU/O2udx3z837rX2IGn1k764F73XuftvdBos+ctkteG/Zv6UX6LDPUttncU6W0Ih0H/5sQbDkbJ6vRVs6gL0VYCmAfGTkhyn8VpDiLjy5MCb6B4dzztPADHGwBQ+24MEWPNiC92EL2hCSK7y88kNBf4K/W8JI9LMqp73fRV1ZnLjwiBXk2ezpI2V0N2RhWyqlzD81to3oo8Kmn4Q0aLWnnUiuqstPqzTyDj4KYoXuoyhNjOkvxx/fTXdnoSFBcBjThhBNBvpE9ohNCqGWIWGT4Q27B/qkjDJziqawXazzpIP+W4hAIoyRCq8T4OjMGjtRUKJ+T3976x7AAaELEAfb1O3tLdzi+9TSVz+D2G1pSZe+U0/9rbWz0gbSQuit6QngsVNuhtfs2uksizS9Ey4wfIBwpMK16QZrusDMH63NDy3DtXnYHbhfSgy3wiD5ZmP6xgP2qFkeftL6GJDpAeJTyHi4J5D2kyl3n/rSEgljZ0S5KnP/M9AmU1HjkflxXidnGxR4aBSWfoJh91NLo3KPu5uVe2vS1haC6mhWsyet/K0i+sZiTG/T6PxwVehyTuhOvqvwKNU6Kgxdr9W38GEwCAF1GRO3aJA1U8INjxZ/ZhLtxlwYlwHkPEBv+Or5r+Z1ee+XIXFhp5ibMh9sIw1wmJLJIzNSxZ15K2u8gPsaQGeiYBBIbqE8gqDdHnopX811OYb39h6OV3Adkb6CCPas9KEePdB5XoGKyqTJx54enjSZ7NDhtkUcetahZ917z2rvVbuz+4hvUFJkuatLC50GQBy82QgOuR5uUWt+UlED0IWtNvmI2BebvIF9hM4gfb6coVc60bqcofeFgl8gSuyEJyRuac36gDVloTPW+zuiX+p0BOACgWV6eY7KuSiHRPk6Xgwzfm+0NFgXK1udkDMzkyO16HN9EsJOErVaBR/okq5sUth+QvPgJHW7+evRf9WZ1ShpZzJEX1ScK70N+oc1jTPOVjxZeJax/WX4Gau38MHpX/rPWVVY4Tm1VSm++eqhlU2lObc6wFtO4oGN3zYG4Rm+87hfJzBC5/abagINTd6lH+1sMmSIc4TCjqoeRq8KplMm4xRBPvQVF/R3m8Gqh9zJ+7dvj9+d7kiRbfXoHoJQW+Sr6qVDGVWYJSmVirCdSIXE9pC6qMyebveVN4q5vrmRv6Vez3y7Of/5zfB+CVD6k3rPHHy5qYMP952WYjdXmgECXT12/FCNOpHdIzZKd3ft6aBqLz+tWISrvsTSJt68kXxj/2n3WB8wMCX/PvqP6Omsdp2ktShpEulrJ817NpRAlvde+l9uIWjNodhfcdis2Igm4UKG1hll15z+Yg8kdxS0e6kRBg113P0Nh679gBEXkT1tGRB2asqBkwADCmqaBXxr7veIdQ6/pMp4FQXB4DTO7mDwlbvqyK1zOqBdLbRFm9J8dwpVINGIRPRL+hBfNGbG4PKWTJDssQEbfnarxMwpj6/uhC/O9JWsfNnkfIOp8u7eBQIw+ixIFVYRgYQtqcZKpvJW5RX8BvKUMxUs6+5Db/3EFEhHgqhCsMps7+g88P4cBkXKSHJ3jODagWGE2mbB25ApGP1aCUYKXxGb6By0c3n+8qJ6etlFbjuJ2yB8WeZ2C4sdbRq2xybtLWRwj69r5Bbd2ntsRdlXz957B3/vZu/pT/a09xx8eK4aaO8FCISmJYdpklZMmjr2gXedJK1qDZE9kmKUcWFzWCDUXnH0sBB4xwZ3zMxXuu9pBG+iIVLf5Q0RZAhA3R379rbimGcZuE44oixOi4TM0ILA/QbG4DLxt1uIlfhZDcp0MXNOViK4iR1d/t9Hr7i4wSIhCfzrMkLnhCCcSnMHzmWpk8tQsNyW5hpc2lZVA9R2shXY7N2znBeLlMbeQ2/0KLnoWrw0yo/Q2RIxXn24hWcF2QQ6NvjPWs0BW9fyEPQaKzKIyDaiJhbU5x86G8YhqrgWVfwtA7y/dUTzv+lR+m+WUeVwEn7sk/CfDifhDyfhDyfhDyfhDyfhDyfhDyfhDyfhDyfhDyfhDyfh7+ckfOVt2313deSgw5eGAAhFD0i0ikyJZ8ilMn4YBWnko/l63dWvNIF745eUCPTgw9lpC64a0cds93IdbBiwckOPt8t8Urm2++Dtbulk4ATdW2Zim5yTax3pXLotAedKfy/LS1wCQq0Tm3yFc/zVfsillXNZRZ76bdkVqkILd6lgKZqdwgkTRBapul0X1d7iZbhMRj4yd05JUpvlmpx8XoEB9Bb9tDnp2l1L2Fot02kap6sOLg03JhwHJr1bkIIYCMpiQTLC4EaZBCs803dkw/0VBM6QaxVWqT9xkmxtzyHwiYD3+Zok2qsfY4YWBHGm1yhT/Q1kYrfvTGfwwVQynMs1Vy251mFffF71rvEKDTVRyS3Hc8CrZz61rdyawFS6uOQ6X/i/d2B6pummFLQ9M7piwRZg6BbufYeiT/UtRdu6dBvyt8ORpCy2Ud45j9eRuUcVCg+RcfpuHf308r+9HciYp0XWYszHOCUswSJYmGLv2rERqoJYQ7wMt2tcDw2o+vJlc7zB9ncu6/uLOZdqJUg9qOyD+XHnyLLquz23G2tswiNdq3b8Xcc6kdLyaA5mI8WE+shdakAoTMOnUrat2tNBXbb8dHBoGc3I79u3SQ6E+t2OXiXs/cSv+eZUEDDg0S09XFOcZJRNOxBbjxZsiXV4MCUsttO2VJjZJlnsBRmU3GUlV5ivji+O34wdMJeEYt+7Qn8qPs8eR493onPqgtr5EuGuiAzfztrGPX/55uXJBfr/0KuP799qp6T8z514/GzvR8BKmwBhDs7UDOllPxPWho5ZweVoLUhSu/fkI/zdMkbrZ+htl5XqxIVHvSDN5tg10hBqyJajpfesCXg3S7QLL0j17NTNpoaVyecVrnnBxz58BhLr+C77fYROambjZYalIuJyhi5liq8J/CNe0zS5RA/AbPl4+uq74/ev0A2sc9kK6WcPZ1uoXKBL8OhRRtLLaPBgc8tyVmNNs1j6aCYU5pqIBZe6XOayokttF1/aC4ou77EzbkkdMaT33MXs6vgSAaswcg2mJ8zipglcU4wwYkTdcHHlLdijgR0lzpJxay/mWQa7n/aq2yQKwroJIxrtnowftarYqu26XcdL3zwai+7zY6OOHtWo0TFZXZHNuPUAh8JqSzKnAFiKdlcOFmNmj4ChC4tVAZOkRDdUrVtIxThNSVLOaGb7xpvSzvUPw9cdRsCe640SPdxxW8rctPdDFEI9stR8oda3GTCa+G8oK77qQK3q+NUuDtfSgq89HVTl5aew0ARDv2IFfNywGQVxW678GADrvtwHNRd8JbCr9B1AnX2wN/Co482HasBxxPRRDOnyQvUTsg9HnCkHHWrrWKgNgNDunOrUReUQNAFWEilewQVxZZmcpbsHDiBke6I0t0DGMBudn/8I5abMsKp1wraO2H04v5eFGX0bwE2zanocxyRXxs/4CtO0dDOesWuc0mQaee8EMDKCGQQjy0LHTy+L1JQzqiTYd2zF2CAQGx/mjiqX280BCLuXX/JryquKCP6sLFdoDf4tXZioRaPBmNQdVNqIf7Vhpk3l5lhKmDTh0kw0NbHEV2QzbWO1tcvvGiHN96NaZXtuHFCq6wvMggwnpI1XIniek2R+1/ygJisz1lYxmL88J0xvJ9MsIwnFiqQbx6qNdCB/c8fYuhthkH07lUq6YlgVguzHo/zcjfaOmG5jYKy1AYeCSbrGugGEdg4pubRdGnpR1HJU4G5iS8LRJW3j704RJt2G8kBVhra8OuJMhsUu3B0zqjZdpLpDO+6MloHt1FZ/XM5o7PqjcwbF5wyJ0NlBX0OjdLYq8z5U1hqd4vORRcInQQ2NYrFpc0mW53fdRj+gXrqlazQZMoq0hdQ0vNLaLHr3/kLvPhYJJ0JOdtbeVqADSIuxNFMUkC+X3d0GklKb/dAvLv7uTYo1RNrmfKhg85tkP9jY5otMqCCx4mJzCxKBJYhXT4JztR9HhcWKKHs4nHuekCZBeUNVvA5smTuG9t39aDggpwbtRwQKFdokBAq8cZLcf5+zwHt2u+DsM0hR1fG3BQGnkg7IiFpgiq11/GBrswv+7LQNcDU6oK7EDsR16BzAALnwHVryNPHCRhgxgdJtWHJN0nQfsIQscZEqI6ADbhJC1Rr4Jm3cId97I/cNJ6gUTSSaIIQQQgihsdpcK4Gz0w54Byw38pb7KVvxqM5FZ0R77tpv7CG1fOz8HQWR78JHOgT3jrykg6Bpsjtsrzt0CLJ9eB8OUbv9oQQmS3rl7X9cmF+Gb4CAXPtRfQvCb9CuhBVeuGu1FMmObmU1BvFCncjhmpwLk6ZSb9OruahRuU1Wh9rT7rXNzrkJgsiHU/yHU/yHU/yHU/yHU/yHU/yHU/yHU/yHU/yHU/yHU/yHU/yHU/yHU/yHU/yHU/zDT/HXmej17Fy34snAqWWn9ZhFkEH4pYBc+ywJVcm+i6BmH3YYetAJsljg+IqwZN7mLejhEPariPK2Iive7jlafcBO3pKLGywSkkz+3wDVW2xo"
 }
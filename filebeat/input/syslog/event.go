@@ -71,6 +71,11 @@ type event struct {
 	nanosecond int
 	year       int
 	loc        *time.Location
+
+	// Set for rfc5424 events only.
+	version int
+	msgID   string
+	data    map[string]map[string]string
 }
 
 // newEvent() return a new event.
@@ -267,6 +272,22 @@ func (s *event) HasPid() bool {
 	return s.pid > 0
 }
 
+// Version returns the rfc5424 version, 0 for rfc3164 events.
+func (s *event) Version() int {
+	return s.version
+}
+
+// MsgID returns the rfc5424 message type.
+func (s *event) MsgID() string {
+	return s.msgID
+}
+
+// StructuredData returns the parameters of the rfc5424 structured data
+// elements, indexed by element ID.
+func (s *event) StructuredData() map[string]map[string]string {
+	return s.data
+}
+
 // SetNanoSecond sets the nanosecond.
 func (s *event) SetNanosecond(b []byte) {
 	// We assume that we receive a byte array representing a nanosecond, this might not be
@@ -304,9 +325,10 @@ func (s *event) Timestamp(timezone *time.Location) time.Time {
 	).UTC()
 }
 
-// IsValid returns true if the date and the message are present.
+// IsValid returns true if the date and the message are present. The message
+// is optional in rfc5424 events.
 func (s *event) IsValid() bool {
-	return s.day != -1 && s.hour != -1 && s.minute != -1 && s.second != -1 && s.message != ""
+	return s.day != -1 && s.hour != -1 && s.minute != -1 && s.second != -1 && (s.message != "" || s.version != 0)
 }
 
 // BytesToInt takes a variable length of bytes and assume ascii chars and convert it to int, this is
@@ -125,10 +125,12 @@ func NewInput(
 	forwarder := harvester.NewForwarder(out)
 	cb := func(data []byte, metadata inputsource.NetworkMetadata) {
 		ev := newEvent()
-		Parse(data, ev)
+		if !ParseRFC5424(data, ev) {
+			Parse(data, ev)
+		}
 		var d *util.Data
 		if !ev.IsValid() {
-			log.Errorw("can't not parse event as syslog rfc3164", "message", string(data))
+			log.Errorw("can't not parse event as syslog rfc5424 or rfc3164", "message", string(data))
 			// On error revert to the raw bytes content, we need a better way to communicate this kind of
 			// error upstream this should be a global effort.
 			d = &util.Data{
@@ -217,6 +219,26 @@ func createEvent(ev *event, metadata inputsource.NetworkMetadata, timezone *time
 		process["program"] = ev.Program()
 	}
 
+	if ev.Version() != 0 {
+		syslog["version"] = ev.Version()
+	}
+
+	if ev.MsgID() != "" {
+		syslog["msgid"] = ev.MsgID()
+	}
+
+	if len(ev.StructuredData()) > 0 {
+		data := common.MapStr{}
+		for id, params := range ev.StructuredData() {
+			values := common.MapStr{}
+			for name, value := range params {
+				values[name] = value
+			}
+			data[id] = values
+		}
+		syslog["structured_data"] = data
+	}
+
 	if ev.HasPriority() {
 		syslog["priority"] = ev.Priority()
 
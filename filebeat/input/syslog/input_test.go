@@ -166,6 +166,30 @@ func TestProgram(t *testing.T) {
 	})
 }
 
+func TestRFC5424Fields(t *testing.T) {
+	e := newEvent()
+	ParseRFC5424([]byte(`<165>1 2003-10-11T22:14:15.003Z mymachine evntslog - ID47 [exampleSDID@32473 iut="3"] An application event`), e)
+	m := dummyMetadata()
+	event := createEvent(e, m, time.Local, logp.NewLogger("syslog"))
+
+	v, err := event.GetValue("syslog")
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, common.MapStr{
+		"version":        1,
+		"msgid":          "ID47",
+		"priority":       165,
+		"facility":       20,
+		"facility_label": "local4",
+		"severity_label": "Notice",
+		"structured_data": common.MapStr{
+			"exampleSDID@32473": common.MapStr{"iut": "3"},
+		},
+	}, v)
+	assert.Equal(t, time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC), event.Timestamp)
+}
+
 func dummyMetadata() inputsource.NetworkMetadata {
 	ip := "127.0.0.1"
 	parsedIP := net.ParseIP(ip)
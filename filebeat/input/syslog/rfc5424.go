@@ -0,0 +1,270 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package syslog
+
+import (
+	"bytes"
+	"strconv"
+	"time"
+)
+
+const nilValue = '-'
+
+// utf8BOM can prefix the MSG part of an rfc5424 event.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// ParseRFC5424 parses an event in the format described by rfc5424, returning
+// false and leaving the event untouched when data is not in this format.
+//
+// <165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog - ID47 [exampleSDID@32473 iut="3"] An application event
+func ParseRFC5424(data []byte, event *event) bool {
+	p := rfc5424Parser{data: data}
+
+	priority, ok := p.priority()
+	if !ok {
+		return false
+	}
+	version, ok := p.digits(3)
+	if !ok || version == 0 || !p.space() {
+		return false
+	}
+	ts, ok := p.timestamp()
+	if !ok || !p.space() {
+		return false
+	}
+	hostname, ok := p.field(255)
+	if !ok || !p.space() {
+		return false
+	}
+	appName, ok := p.field(48)
+	if !ok || !p.space() {
+		return false
+	}
+	procID, ok := p.field(128)
+	if !ok || !p.space() {
+		return false
+	}
+	msgID, ok := p.field(32)
+	if !ok || !p.space() {
+		return false
+	}
+	if !p.structuredData() {
+		return false
+	}
+
+	var message []byte
+	if p.space() {
+		message = bytes.TrimPrefix(p.data[p.pos:], utf8BOM)
+	} else if p.pos != len(p.data) {
+		return false
+	}
+
+	event.priority = priority
+	event.version = version
+	event.SetMessage(message)
+	event.SetHostname(hostname)
+	event.SetProgram(appName)
+	if pid, err := strconv.Atoi(string(procID)); err == nil {
+		event.pid = pid
+	}
+	event.msgID = string(msgID)
+	event.data = p.elements
+
+	if ts.IsZero() {
+		// The sender has no clock, use the reception time.
+		ts = time.Now()
+	}
+	event.year = ts.Year()
+	event.month = ts.Month()
+	event.day = ts.Day()
+	event.hour = ts.Hour()
+	event.minute = ts.Minute()
+	event.second = ts.Second()
+	event.nanosecond = ts.Nanosecond()
+	event.loc = ts.Location()
+	return true
+}
+
+type rfc5424Parser struct {
+	data     []byte
+	pos      int
+	elements map[string]map[string]string
+}
+
+func (p *rfc5424Parser) peek() (byte, bool) {
+	if p.pos >= len(p.data) {
+		return 0, false
+	}
+	return p.data[p.pos], true
+}
+
+func (p *rfc5424Parser) consume(b byte) bool {
+	if c, ok := p.peek(); ok && c == b {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *rfc5424Parser) space() bool {
+	return p.consume(' ')
+}
+
+// digits reads a number of at most max digits.
+func (p *rfc5424Parser) digits(max int) (int, bool) {
+	start := p.pos
+	for p.pos < len(p.data) && p.pos-start < max && isDigit(p.data[p.pos]) {
+		p.pos++
+	}
+	if p.pos == start {
+		return 0, false
+	}
+	return bytesToInt(p.data[start:p.pos]), true
+}
+
+func (p *rfc5424Parser) priority() (int, bool) {
+	if !p.consume('<') {
+		return 0, false
+	}
+	priority, ok := p.digits(3)
+	if !ok || priority > 191 || !p.consume('>') {
+		return 0, false
+	}
+	return priority, true
+}
+
+// timestamp reads a full rfc3339 timestamp or the nil value, returning the
+// zero time for the latter.
+func (p *rfc5424Parser) timestamp() (time.Time, bool) {
+	if p.consume(nilValue) {
+		return time.Time{}, true
+	}
+	end := bytes.IndexByte(p.data[p.pos:], ' ')
+	if end < 0 {
+		return time.Time{}, false
+	}
+	ts, err := time.Parse(time.RFC3339Nano, string(p.data[p.pos:p.pos+end]))
+	if err != nil {
+		return time.Time{}, false
+	}
+	p.pos += end
+	return ts, true
+}
+
+// field reads a header field made of at most max printable characters,
+// returning nil for the nil value.
+func (p *rfc5424Parser) field(max int) ([]byte, bool) {
+	start := p.pos
+	for p.pos < len(p.data) && isPrintASCII(p.data[p.pos]) {
+		p.pos++
+	}
+	n := p.pos - start
+	if n == 0 || n > max {
+		return nil, false
+	}
+	value := p.data[start:p.pos]
+	if n == 1 && value[0] == nilValue {
+		return nil, true
+	}
+	return value, true
+}
+
+// structuredData reads the structured data elements, like
+// `[id param="value"][id2 param="value"]`, into p.elements.
+func (p *rfc5424Parser) structuredData() bool {
+	if p.consume(nilValue) {
+		return true
+	}
+
+	start := p.pos
+	for p.consume('[') {
+		id, ok := p.sdName()
+		if !ok {
+			return false
+		}
+		params := map[string]string{}
+		for p.space() {
+			name, ok := p.sdName()
+			if !ok || !p.consume('=') {
+				return false
+			}
+			value, ok := p.sdValue()
+			if !ok {
+				return false
+			}
+			params[name] = value
+		}
+		if !p.consume(']') {
+			return false
+		}
+
+		if p.elements == nil {
+			p.elements = map[string]map[string]string{}
+		}
+		p.elements[id] = params
+	}
+	return p.pos > start
+}
+
+// sdName reads an SD-ID or a PARAM-NAME.
+func (p *rfc5424Parser) sdName() (string, bool) {
+	start := p.pos
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		if !isPrintASCII(c) || c == '=' || c == ']' || c == '"' {
+			break
+		}
+		p.pos++
+	}
+	if n := p.pos - start; n == 0 || n > 32 {
+		return "", false
+	}
+	return string(p.data[start:p.pos]), true
+}
+
+// sdValue reads a quoted PARAM-VALUE, where '"', '\' and ']' are escaped
+// with a backslash.
+func (p *rfc5424Parser) sdValue() (string, bool) {
+	if !p.consume('"') {
+		return "", false
+	}
+	var value []byte
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		p.pos++
+		switch c {
+		case '"':
+			return string(value), true
+		case '\\':
+			if next, ok := p.peek(); ok && (next == '"' || next == '\\' || next == ']') {
+				c = next
+				p.pos++
+			}
+		}
+		value = append(value, c)
+	}
+	return "", false
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isPrintASCII(c byte) bool {
+	return c >= 33 && c <= 126
+}
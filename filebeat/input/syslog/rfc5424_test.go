@@ -0,0 +1,143 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package syslog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRFC5424(t *testing.T) {
+	tests := []struct {
+		title     string
+		log       string
+		timestamp time.Time
+		syslog    event
+	}{
+		{
+			title:     "rfc example with structured data",
+			log:       `<165>1 2003-10-11T22:14:15.003Z mymachine.example.com evntslog - ID47 [exampleSDID@32473 iut="3" eventSource="Application"][examplePriority@32473 class="high"] An application event`,
+			timestamp: time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC),
+			syslog: event{
+				priority: 165,
+				version:  1,
+				message:  "An application event",
+				hostname: "mymachine.example.com",
+				program:  "evntslog",
+				pid:      -1,
+				msgID:    "ID47",
+				data: map[string]map[string]string{
+					"exampleSDID@32473":     {"iut": "3", "eventSource": "Application"},
+					"examplePriority@32473": {"class": "high"},
+				},
+			},
+		},
+		{
+			title:     "timezone offset and pid",
+			log:       "<34>1 2003-08-24T05:14:15.000003-07:00 192.0.2.1 su 1234 - - 'su root' failed for lonvick",
+			timestamp: time.Date(2003, 8, 24, 12, 14, 15, 3000, time.UTC),
+			syslog: event{
+				priority: 34,
+				version:  1,
+				message:  "'su root' failed for lonvick",
+				hostname: "192.0.2.1",
+				program:  "su",
+				pid:      1234,
+			},
+		},
+		{
+			title:     "byte order mark and escaped values",
+			log:       "<13>1 2018-06-19T02:13:38Z host app - - [id x=\"a\\\"b\\]c\"] \xEF\xBB\xBFhello",
+			timestamp: time.Date(2018, 6, 19, 2, 13, 38, 0, time.UTC),
+			syslog: event{
+				priority: 13,
+				version:  1,
+				message:  "hello",
+				hostname: "host",
+				program:  "app",
+				pid:      -1,
+				data: map[string]map[string]string{
+					"id": {"x": `a"b]c`},
+				},
+			},
+		},
+		{
+			title:     "no message",
+			log:       `<13>1 2018-06-19T02:13:38Z - - - - [id x="1"]`,
+			timestamp: time.Date(2018, 6, 19, 2, 13, 38, 0, time.UTC),
+			syslog: event{
+				priority: 13,
+				version:  1,
+				pid:      -1,
+				data: map[string]map[string]string{
+					"id": {"x": "1"},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.title, func(t *testing.T) {
+			e := newEvent()
+			if !assert.True(t, ParseRFC5424([]byte(test.log), e)) {
+				return
+			}
+			assert.True(t, e.IsValid())
+			assert.Equal(t, test.syslog.Priority(), e.Priority())
+			assert.Equal(t, test.syslog.Version(), e.Version())
+			assert.Equal(t, test.syslog.Message(), e.Message())
+			assert.Equal(t, test.syslog.Hostname(), e.Hostname())
+			assert.Equal(t, test.syslog.Program(), e.Program())
+			assert.Equal(t, test.syslog.Pid(), e.Pid())
+			assert.Equal(t, test.syslog.MsgID(), e.MsgID())
+			assert.Equal(t, test.syslog.StructuredData(), e.StructuredData())
+			assert.Equal(t, test.timestamp, e.Timestamp(time.Local))
+		})
+	}
+}
+
+func TestParseRFC5424NilTimestamp(t *testing.T) {
+	e := newEvent()
+	before := time.Now().Add(-time.Second).UTC()
+	assert.True(t, ParseRFC5424([]byte("<13>1 - host app - - - hello"), e))
+	assert.True(t, e.Timestamp(time.Local).After(before))
+}
+
+func TestParseRFC5424Invalid(t *testing.T) {
+	logs := []string{
+		"<34>Oct 11 22:14:15 mymachine su: 'su root' failed for lonvick on /dev/pts/8",
+		"<190>2018-06-19T02:13:38.635322-07:00 super mon message",
+		"<13>1 2018-06-19 02:13:38 host app - - - hello",
+		"<13>1 2018-06-19T02:13:38Z host app - - hello",
+		`<13>1 2018-06-19T02:13:38Z host app - - [id x="1] hello`,
+		"<13>0 2018-06-19T02:13:38Z host app - - - hello",
+		"<192>1 2018-06-19T02:13:38Z host app - - - hello",
+		"hello",
+	}
+
+	for _, log := range logs {
+		t.Run(log, func(t *testing.T) {
+			e := newEvent()
+			assert.False(t, ParseRFC5424([]byte(log), e))
+			assert.Equal(t, -1, e.Priority())
+			assert.Equal(t, 0, e.Version())
+		})
+	}
+}
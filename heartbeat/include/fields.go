@@ -31,5 +31,5 @@ func init() {
 
 // Asset returns asset data
 func Asset() string {
-	return "eJzsW9+T27bxf/dfseOXfL8zOk1i1572Hjp1z26jSZzc2JdnHQSsRORAgAHA0ynTP76zAEiCIvXLkpvrTPWQHElw94P98VksQF/BA26ugZuyNPoFgJde4TW8vAk3oEBm/QKZh9Jo6Y19+QJAoONWVl4aff0CYClRCUd/AVyBZiVeN6PDPQC/qfAaVtbUVbqTi4C/ppsASWt6O0mevkjPc0W5MhLf3my0PeBmbazI7u/QSb+7Alul9HqrslNC/72YEgfc6KVc1RZFlDzQJ8UFtS1rpeBXs4DZe2AOaocCFpvOuyPzFbVlJHeAInfjAMOd8Uw1eqVegUfnx2Rt+zJXXbve7UaxMnq19aCn+33SAlJDKbk1DrnRwg3n5niB53rznRAWnYPaqiRvCv8wFvCJlZVCuPe8up/AvVeO/ld4T5dMi/i3ux+xeWGcPw/V98Z5kgVmCQ7to+QICyRHJJ+gmMIN07BAKKVzUq8mILuxsm/69iWKltntCGRZDQDLndHRxzq73YtylqPqIynSLCc9eb5AuJfVfYwtyjDPpHbhvkVn1CMKkBWw5LklJXuBwGtrUfsgdWSGzjPfi0iLv9XSorgGb+szo2imheSMaEcuWwbiplYCHpmSgnkMGBtLeEOeY49MKrZQxFOJwNMEMwanUABlzENdHUnanQw4hbQzRS1jxye7CPs/E+eXDtYubmot2uhZyUfUu2LH+uE89/Jny2FNkJHHk1+AaYKxtKZsM2D6R1FqE3dUPbqg+3u8GrHjTZONcaUR3kuIu3AmNUwpwEfKR4IYQqmZWC9YQ9nKivIO31EZbAKF3EUIwaEWoTgVCMqsoETn2ApdIJ12VHgtI0SHngAGykjVOxpnKRVO6D49ZJ4ytw6kRXU2yJSeLrXxubDwSuvJbvydCap6OCb0LDIcXd63ckzV+Gcc13RotEbjYcO12JgDi762OvIvqTIVkhq9ArdxHkswGtaF5EUHPLOdrbWWejWCxssSfzf6CDTNyK+J5hGt6xYqe8CkgU1YhXAOzl+hJiiUuYV0MZSn/dB9+TeaivOsDMzcpSPRfboxWmSWxpbM98al5cY1vKtXtfPw6q0v4NW3372dwHevrl+/uX7zevr69avjrBsgwToGMqY0pASxyI0VsGaum9/WpDxbuf1a3tmF9JbZTRgbrcXjUoTivUIbHUVrJLrwlmnHeG/hmC3OG8WRHXp2NItfkTe5Fi/mY0VmZxeSuKp2aLucIoLqdSQNArT29FbnA73UMGBaq1D8MiEkjWUKpF4aymzOXOCvoOdgcU1kNqg7Hp/8cbUuQktypgMF3Iih9K1KclA6CRmKPr+Ti9JjmDS9rTK16GrUDV1CZc2jFEjT9Ewwz8bL1sf0NFZd3nvVka86CmJCzMOAeSOSRnJ0ztidVYyGTsNb00bsdmIjP5C9P2XlrY9wCrfGOUmBG2qSA2aRBE5gxXECxoKQK+mZMhyZnu7EJrXzTHOcywOpM0sDqdFMkKiIQMl4IfV26o5pOFyZWh15XT9OSxowz+KstbN/NS1RyLrcr/1jFBFC7DTlaZkjlfSbeVbyWgS1u0Lm/NV3/ACRZoIgVETZVTvpIhzpujK3J+QCN7ZebaGkJ1dPx4deeoWw/NOYlcKYabu1W1wdLLWfwphD80uJLgx/CPmTMv19cz0iPD4LzR3Rr1LIqWaHNI/PKGddYayfxwpwDUumHDmNaV4Y2+i7arN8R1vVwoLR+rCLx1NNQDs9dyvoFy1/q7ETCFKMsXqrrhwrHydpzOMiiGtWpwkALSQWtVQejN4H5fxNt5tWZ79dGupSbIHKDbT11hKwfz1xAMssWCLqaYM29cIpZL+PVyNCZrQYyAI1tZ996ulik+4fjMysDz8+Ls/3yfeD5vVSm54p0iNBjAQ5s7yQHrmv7QXm0BMH/4fT1RSe/vx2/vZPE2C2nEBV8QmUsnL/P4Ri3LRSzNOS/jwkP3+GRlDCwFF74yZQL2rt6wmspRZmvQNEv+P5cgxJzqiOJSul2pytIopJk7QoCuYnIHAhmZ7A0iIunDgw2we0GtV5SO5G+s1vHETRu+1wxubTj9J5otPZ7VXahEI3VFAyft7EGjUFs2LNLHbKJlC7mim1gY/vbnIMDYs91AuavkfXcdkP+b0Rtd3zdhHeX1F3QiFnsv1FuXvpIP31QMNJJFgZcYHilFmgMrsOgkhVfS4xZppujYBfZu/HT7hcxfjlJtVJHCqj/u+iFiSJO0x4bGk/TlGUBiWrhpqY1saH3beLqctEjuu85HIp08t7K6d9ai+wYBzVG+UmhnGGP7g32fnG559vfvj8hpjhaXPkAUcrA07ZqckVgUUVNvf6xLCLJk7e/9+qND9+BsU2aMGGMwdvZRU36o7d9+dG637Q7QZyAEwAJEvsHU2g82yhpCuANbqoX3uUrDEbDdKiMlJvowBYMIcCjM5OADIh3vRMP916fWzasO/IA/Ydewwmf/jow6us2L0kX6HmdhM35IPbjgxLr3YXoF07tl1k9ALy0OYgR+vlUnLWFT04NmOb5cHth480U0OV+unNt3/pCR3psLqnc238PJxrzhe4NCNr8Gxne6D/A7NKovMhA4D5bIO/i5pvXA9O2PAKGo8Fxpa+bdaPw/Uj8xdE9YdTRsG0cAV7wK9GGkupiTEIaqss4wJlkYlNxgka/drYh4Hgzr7Phhuapt77Kj+Kv7u7PfEDqiRh3PC7DuJJzWl8UNthM3T8QcHndPJeW9V+U5SmmXukrJWX86FP2pBn6xdDRwzZ6ECk3WXfSYwhgrtCOpAOGGijr5hmavN7Y6l4HBXPYJe12o4nY4GtVhZXcSE2dtSPrjLaDSnthORt7NnIgopZVqJHe3T2xq9V5ltnNB0aqT2uegR30K4Anxo8UfqOw5szmStE73nU1Xwy82XM9a/BvNs8X6BfI2pYSus8LDY+7G6mfPutJvqPX5+srfQeNTAtBtJar8ahqTuNMZqQU5R2SrcYcSBwwJA9RhwM/8l4CoBlpyx9lAAkniAtjNiAsWC02gCDyuJSPk3CpvsIJdJP1+UCLQiDUdKyVooWyZVFF77hKBB8+CYwlEeNKHBomeQmE4DE83Qjns+Cb0xZE2pzQnrxeDO5k/qfAjERDsh7X4eNcE/8RTP+LxLgK0YCpTzOEw18USTsjYP8WyVuykqhxx7zjDDGkCl2rame4xpqTFkT4fMCmRiUry80c39h2nB8bnDnmfXbXiDjj5B7LAOUm1mVCOeJyVs99id/dJ7b0xn/t3uOG+1R++mXfYF4uJmw6K3ERxTtESOxTQMNErbpOLhASBdn7xxeqvJt4ORfNU3hM8WXg7X0xUBcODvV0kum4O7mNt8ZYd5jWfkpfNAivg2hbe34fCBNSAG8QP7QKxjPuTY8l6hOLZ3kZd7SzW4+3h7ZyqU34ZRWbnYLFdn6uC4ukc9wR2e42t93aB+9JJdAk4MPvDCfkuDAf5f4vrmVDJ8ywvyEFcVDf9V/5Jr/0l82N5t7PPc25d9JO3r8ZI+Tiobav2RnrzJ26IuT/N90nyQppewlXL61P3VzbpN34S3tUdbOt7W3uPeErqz7NwPPhcu+Qte8x6JdF0NXzmPVWQ+fpAvn533zPhdD/TsAAP//u9km5Q=="
+	return "eJzsW99z2zbyf9dfsZOXfr9zMqdJLpmrZ+7mUidtNUlaX+y+9EWGiBWJmgQYALStzv3xNwsCJCiSkmypbW7mWk0bkuB+PvsDu1gQOYNb3JxDqspSyRmAFbbAc3h24W5AjkzbFTILpZLCKv1sBsDRpFpUVih5PgNYCyy4oT8BnIFkJZ6H0e4egN1UeA6ZVnXl78Qi4B/+JoBH9W97ycnMP4+BYjAS394MaLe4uVeaR/cnMOl3nWOg7F5PZgMQgjoZiIFUybXIao3cyR/iCX5CtHVdFPCrWsHiLTADtUEOq03n3RF9ea0ZaREJHbpxwOFaWVYEXCEzsGjsmKxtX8bQtendDsCFktnWgx72W48CQkIpUq0MpkpyM9TNpDke6803nGs0BmpdeHkJfKc04AMrqwLhxqbVzRxubGHof7m1dMkkb/5sbkZsnitjj2P1gzKWdAS1BoP6TqQIKyRHeJ8gT+CCSVghlMIYIbM5iG6s6Ju+fYmiZXE5QlnEodD4SVSHcV1c7mS5iFn1meRey3lPns0RbkR106QNmmGWCWncfY1GFXfIQVTAvOfWNNlzhLTWGqV1Ukc0NJbZXkRq/FwLjfwcrK6PjKKF5CJllOvEOugIqaoLDnesEJxZdByDJawiz7E7Jgq2KjCZzXwC9wpGGZxCAQqlbuvqwKTdyRif7RN6REBtxt6dsP+YOD91sHZxU0veRk8m7lBOxY62Qz1ji07nsBBkYFUIXmCSaKy1KtsZkPxZKTXEHVWPLui+ba56MhrHXYTZmDYlnt7zjLtwpszNigLwjuYjmcuFUlCsF6wkIImK8ghmKIMhUMhdxBAMSk6xQjcKlUGJxrAMTQKLaJR7LUqIBi0RpOehejfGWYsC5/QePWSWZm7tkhbVWSdTWLqUqosGg9a90nqyG3+tHFSPx5xqtht/Q7RuWjmqCv4Z55UMjRYQ9xuu5cYMaLS1lk3+JShVIZVbmYHZGIslKAn3uUjzjnhkO11LKWQ2wsaKEn9T8gA2YeTvyeYOtekWKjvI+IEhrEiVxvkZSqJCMzcXpgnlZNZDevZPUsVYVrrM3E1HSvezHUVmrXTJbG+cX26cw5s6q42FF69tDi++fv56Ds9fnL98df7qZfLy5Yv9CrWU4L4JZPTTkCaIxlRpDvfMdPptKWVZZnajvNErYTXTGze2sVbaLEUo3ivUTdjQGokurGbSsLS3cIwW5wG4yQ5+BD0/B7X6FdMw15qL5ViRmSDa5qraoO7m1KpAD7bFALV+fKvzjl7y8sJaheKXcS5oLCtAyLWijJMygxRoDmdvcfXJrL0fOFl86NLPJK2OmpeTDABSxYfStyrJXukkJPkdOrmGO8lJQolKC1XzrkZd0CVUWt0JjqSmZZxZNl62PvqnTdVNe68aWkl2KYhxvnQDlkEkgaRojNKTVYyGJu6tJIjdntiY7pm9P0blrc8wgUtljKDAdTXJANMImL6YQ5biHJQGLjJhWaFSZDKZ5CaksUymuBR8N5eFH0iNpqdERQRKluZC4gEI+ytTixHX9cNQ/IBlFGetne2LpEQu6nI3+sdGhIvNx4H7ZY4ohN0so5LXMqjNGTJjz56nuym8iQQBCQLRVTth3JKClhNtmZtiVGnlcqPg21T8k7OH3Uzi0POvEJfvlcoKbGbaNLrGbG+p/eTG7NPPT3Su0lvU3Ux/G65HhDfPXHNHa9KiwNQib6Z584zmrMmVtsumApzDmhWGwobJNFc64J21szya5LHKLa3x+hC/Er/mawLqRPDjcuLPUnyusRMIgie74EqWHZmF47hw4sKq2ROghcSqFoUFJXdRiZLBE5n4Wo4a+u3SEKtgKyzMAK23ltizntjDZeEs0eC0Qet7YR+y1NeOB+yCFgNRoCo9knq62CSxeyMz6sMPj8vjfRKa96E3ThTppNdokDOd5sJiamt9Ah164uD/MMkSePjb6+Xrv86B6XIOVZXOoRSV+f8hFWWSqmCWlvTHMfnpCoIgzyFFaZWZQ72qpa3ncC8kV/cTJPodz9M5eDmjGGtWimJzNEQjxiupkefMzoHjSjA5h7VGXBm+R9tb1BKL45hcj/SbXxloRE/b4YjNpw/CWEqni8szvwmFZghQsnSA8CjFAkzONL9nGjsw2n6oWVFs4OObi5hDyGK39YrUt2i6XPY+vjcC2z1vF+H9FXUntFtJ7y3K3Ut701839NFJsFL8BMUpskClpj4EEVQt+MmQLhWHnxdvh0D0X1Ox9HRKdRKHYNT/ndSCUnGcMOGhpf0woEYalKwaIjEplXW7byeDi0SOY55yuRThtmInjNrBnmDBOIrbyPUZJlVlWUtqnUTczIe7sHg7nmW+K9Q95Mzk/dwSi+v36SGRtCPOBN+bSiTae6VvH51HYhrHWfAiSKLWex2UnoPgKK1Yb8J+t3vE6OuoAatUYcDUVaU0beX2BAo7h0LcIvyCeOs25a5qTR+rWFd6oo7x+fmHf/38zedfivd/+fbVdy/fvy3x7nX56vKjWOns78GLGSpRde77HtXictxv36PKNKtykUKhUjelYFginLxx/7lHex2XoXqC06QVEqWNNytO0Cm1coe1PVW1tHqzFEYtR/ffHgW6uPoJSEoH7KQPYZsW/cRqNkLn4JZxBE9NONJmlNu3kulYm0ZT5MTWFnYzBAqxFr3mAwPVslJCHpjAPyiZCVtzdDOnYNZdDPGYkUfspb6prZKqVLUJ3z1kXa5QD3GUzpgUvzF7en/GosM9tk2s7XeNSm/Nqy4FPLv66eL91Suaww+bAz9UtzLG5+0E7xgINBb0EWNrjvevjviO27fW9YcrKNgGNWj37dhqUTUfXDpHDcFjAqmSsr94mCayhwz9rkWJvU/MaCxbFYKqZMCihHsnWDAbDZJ8ewY0/64YHShSMvqSGwmxKshwPo5VnlJ756fryYkyqvz+T9i2iJqWZ+QrlKneuPcbtx0YlrZdYw4dM+GQKDJ6AZnMxo0ToFLUVqypGqN57EwObd7lu4+AkuoAh4dXX3/TE5rsglxKZZfufMpyhWs1spcSfaEc4L9juhB0LIxmADDbbu3GUfOV6dFxHy4c4qHE2NqifhSvD8yekNWfnjJyJrnJ2W2s7zSVpySNtZCUMSiGW7AoFxQaGd9EOaG/SO7+6ewb6zal3x+SG3xqoJN6UW744fr6MhwwOjAneAnjhp8wuIPpF6hkNm6Q1hC6OOKD75U/QUVnGcPZUK9m7JGyLqxYblPoSGh2H92dzkY7uTT7a2YHI7imgxXCAAOp5BmTrNj8FizVHCtoztKs69go/gAFsCzTmDm/m2Q2sKRGUylpcLZvxhxgzyALKqZZiRb1wbOX1sS12V7rd2yEtJj1EtxeuwJ8Cnwa6RMf4Y/MXC56j0td4ejjqObbVAZ0/r31ELp5vkJ7j0gno7SxsNpY15T4+fa5pvTfnCK818JalLR4H0hrvdoM9R1mE6OeudJJBLqVEQcCBxmylxEHw39UlvaO1x2YP1wGJJ4orRTfUD+lZLEBBpXGtXig42bCDKQ1v6ZtAK7cRpp1B8Y3oLHSaNxZPDp/5c52Uw0CichxaBnvJjqxyOj8EzomsdenPP+HJPUxMG9DXBLTk8ebip0Ul05nIOoMA344pbyde/wC25nxf5Hwe0YCTXlc+jTwpEjYGQfxmdNU0SkLi73MM5IxBgIn11Rf4hpqDCxE+DJH1p11OtLM/YVpyPGxwY1l2m57gYw/kOWdQYk/rhLuXIj3Vi/7kz86zw3E7V8N/7d4jjYnaWtyADoJ+AifuUPuVgukv6LhWy5LdSdQA88tGSfnEtKTgmlX9o7p+SrfBk58OjWBK4ovA/fC5gNx9IaQwgpWwPXFZeRvYNZiWdkE3knevA2ube3y+UAaFxzSHNPbXsH4kmvDlxLVvqUTaRm3dIuLj5cHtnL+zfHYmlgQLy6hoiR0WBfnk4+Z7V/tT+DR78fGS2INpBy8S3P1yQt2+S+ZDYEfu+RvJYMX7RLmJ6yKzfaqPxKxrfdOtx+UV/a626axt2n+PWpHL61mUzaZ8ABBhNT+lJ09+hQ327bCo/wfuk+S5KfsKVzeB7m+OLbJ83mw92yKyB4yk1k73tbeyr3blXkgsKvU3d/9inWb0m9nUO8M7MODezYG5hM9ntyiXRdDV8Zi1fW0+CAMfTreMu+XYqj/DAB1qtbi"
 }
@@ -62,7 +62,7 @@ func NewBuilder(settings BuilderSettings) (*Builder, error) {
 	resolveViaSocks5 := false
 	withProxy := settings.Socks5.URL != ""
 	if withProxy {
-		d.AddLayer(SOCKS5Layer(&settings.Socks5))
+		d.AddLayer(SOCKS5Layer(&settings.Socks5, settings.Timeout))
 		resolveViaSocks5 = !settings.Socks5.LocalResolve
 	}
 
@@ -19,6 +19,7 @@ package dialchain
 
 import (
 	"net"
+	"time"
 
 	"github.com/elastic/beats/heartbeat/look"
 	"github.com/elastic/beats/libbeat/common"
@@ -34,11 +35,11 @@ import (
 //        "rtt": { "connect": { "us": ... }}
 //    }
 //  }
-func SOCKS5Layer(config *transport.ProxyConfig) Layer {
+func SOCKS5Layer(config *transport.ProxyConfig, timeout time.Duration) Layer {
 	return func(event common.MapStr, next transport.Dialer) (transport.Dialer, error) {
 		var timer timer
 
-		dialer, err := transport.ProxyDialer(config, startTimerAfterDial(&timer, next))
+		dialer, err := transport.ProxyDialer(config, startTimerAfterDial(&timer, next), timeout)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,83 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/elastic/beats/libbeat/monitoring"
+)
+
+// metricsHandler reports the libbeat/monitoring metrics in the Prometheus
+// text exposition format.
+func metricsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+
+	info := monitoring.CollectFlatSnapshot(monitoring.GetNamespace("info").GetRegistry(), monitoring.Full, false)
+	stats := monitoring.CollectFlatSnapshot(monitoring.GetNamespace("stats").GetRegistry(), monitoring.Full, false)
+
+	writePrometheus(w, info.Strings["beat"], stats)
+}
+
+// writePrometheus writes the numeric and boolean metrics of the snapshot as
+// untyped Prometheus samples. Metric names are prefixed with the beat name.
+func writePrometheus(w io.Writer, prefix string, snapshot monitoring.FlatSnapshot) {
+	values := map[string]string{}
+	for name, v := range snapshot.Ints {
+		values[name] = fmt.Sprint(v)
+	}
+	for name, v := range snapshot.Floats {
+		values[name] = fmt.Sprint(v)
+	}
+	for name, v := range snapshot.Bools {
+		if v {
+			values[name] = "1"
+		} else {
+			values[name] = "0"
+		}
+	}
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		metric := prometheusName(prefix, name)
+		fmt.Fprintf(w, "# TYPE %s untyped\n%s %s\n", metric, metric, values[name])
+	}
+}
+
+// prometheusName converts a dotted metric name into a valid Prometheus
+// metric name.
+func prometheusName(prefix, name string) string {
+	if prefix != "" {
+		name = prefix + "_" + name
+	}
+	return strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
@@ -0,0 +1,48 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package api
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/monitoring"
+)
+
+func TestWritePrometheus(t *testing.T) {
+	snapshot := monitoring.MakeFlatSnapshot()
+	snapshot.Ints["libbeat.pipeline.events.active"] = 3
+	snapshot.Floats["system.load.1"] = 0.5
+	snapshot.Bools["output.enabled"] = true
+	snapshot.Strings["beat.info.version"] = "7.0.0"
+
+	var buf bytes.Buffer
+	writePrometheus(&buf, "packetbeat", snapshot)
+
+	expected := "# TYPE packetbeat_libbeat_pipeline_events_active untyped\n" +
+		"packetbeat_libbeat_pipeline_events_active 3\n" +
+		"# TYPE packetbeat_output_enabled untyped\n" +
+		"packetbeat_output_enabled 1\n" +
+		"# TYPE packetbeat_system_load_1 untyped\n" +
+		"packetbeat_system_load_1 0.5\n"
+	assert.Equal(t, expected, buf.String())
+}
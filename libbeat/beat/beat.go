@@ -46,6 +46,23 @@ type Beater interface {
 	Stop()
 }
 
+// Reloader is implemented by Beaters that can apply a new configuration at
+// runtime. The Reload method is invoked with the Beat specific configuration
+// section when the service is asked to reload its configuration (e.g. on
+// SIGHUP). Reload must validate the complete configuration before applying
+// it and leave the Beater unchanged if an error is returned.
+type Reloader interface {
+	Reload(cfg *common.Config) error
+}
+
+// ReadyNotifier is implemented by Beaters that finish their startup in the
+// Run method, for example by opening their inputs. SetReadyCallback is
+// invoked before Run with the function to call once the Beater is ready.
+// For other Beaters, readiness is reported to systemd before Run is invoked.
+type ReadyNotifier interface {
+	SetReadyCallback(ready func())
+}
+
 // Beat contains the basic beat data and the publisher client used to publish
 // events.
 type Beat struct {
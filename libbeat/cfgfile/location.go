@@ -0,0 +1,143 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cfgfile
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// settingError matches the setting and the file reported by configuration
+// errors, for example "accessing 'output.elasticsearch.hosts' (source:'beat.yml')".
+var settingError = regexp.MustCompile(`accessing '([^']+)' \(source:'([^']+)'\)`)
+
+// WithLine adds the line of the setting to a configuration error reporting the
+// setting and the file it was read from. Other errors are returned unchanged.
+func WithLine(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	m := settingError.FindStringSubmatch(err.Error())
+	if m == nil {
+		return err
+	}
+
+	contents, readErr := ioutil.ReadFile(m[2])
+	if readErr != nil {
+		return err
+	}
+
+	line := FindLine(contents, m[1])
+	if line == 0 {
+		return err
+	}
+	return fmt.Errorf("%v (line %d)", err, line)
+}
+
+// FindLine returns the line of a setting in a YAML document, given the path of
+// the setting as reported by configuration errors. Keys can be dotted or
+// nested, and list items are addressed by their index. If the setting itself
+// is not found, the line of its closest parent is returned, or 0 if none is
+// found.
+func FindLine(contents []byte, path string) int {
+	type entry struct {
+		indent int
+		path   string
+		item   bool
+	}
+
+	var (
+		stack    []entry
+		items    = map[string]int{}
+		found    int
+		foundLen int
+	)
+
+	match := func(setting string, line int) {
+		if setting == path {
+			found, foundLen = line, len(path)+1
+		} else if strings.HasPrefix(path, setting+".") && len(setting) > foundLen {
+			found, foundLen = line, len(setting)
+		}
+	}
+
+	pop := func(indent int, dash bool) string {
+		for len(stack) > 0 {
+			top := stack[len(stack)-1]
+			if top.indent < indent || (dash && top.indent == indent && !top.item) {
+				break
+			}
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			return ""
+		}
+		return stack[len(stack)-1].path
+	}
+
+	join := func(parent, key string) string {
+		if parent == "" {
+			return key
+		}
+		return parent + "." + key
+	}
+
+	for i, text := range strings.Split(string(contents), "\n") {
+		line := i + 1
+
+		trimmed := strings.TrimLeft(text, " ")
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || strings.HasPrefix(trimmed, "---") {
+			continue
+		}
+		indent := len(text) - len(trimmed)
+
+		if strings.HasPrefix(trimmed, "- ") || trimmed == "-" {
+			parent := pop(indent, true)
+			idx := items[parent]
+			items[parent] = idx + 1
+
+			setting := join(parent, strconv.Itoa(idx))
+			stack = append(stack, entry{indent: indent, path: setting, item: true})
+			match(setting, line)
+
+			rest := strings.TrimLeft(strings.TrimPrefix(trimmed, "-"), " ")
+			indent += len(trimmed) - len(rest)
+			trimmed = rest
+			if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+				continue
+			}
+		}
+
+		colon := strings.Index(trimmed, ":")
+		if colon <= 0 || (colon+1 < len(trimmed) && trimmed[colon+1] != ' ') {
+			continue
+		}
+
+		key := strings.Trim(trimmed[:colon], `"'`)
+		setting := join(pop(indent, false), key)
+		stack = append(stack, entry{indent: indent, path: setting})
+		delete(items, setting)
+		match(setting, line)
+	}
+
+	return found
+}
@@ -0,0 +1,93 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package cfgfile
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+const locationTestConfig = `# comment
+packetbeat.interfaces.device: any
+
+packetbeat.protocols:
+- type: dns
+  ports: [53]
+
+- type: http
+  ports: [80, 8080]
+  send_headers:
+    - User-Agent
+
+output.elasticsearch:
+  hosts: ["localhost:9200"]
+  ssl:
+    verification_mode: full
+`
+
+func TestFindLine(t *testing.T) {
+	tests := map[string]int{
+		"packetbeat.interfaces.device":               2,
+		"packetbeat.protocols":                       4,
+		"packetbeat.protocols.0.type":                5,
+		"packetbeat.protocols.0.ports":               6,
+		"packetbeat.protocols.1":                     8,
+		"packetbeat.protocols.1.ports":               9,
+		"packetbeat.protocols.1.send_headers.0":      11,
+		"output.elasticsearch.hosts":                 14,
+		"output.elasticsearch.hosts.0":               14,
+		"output.elasticsearch.ssl.verification_mode": 16,
+		"output.elasticsearch.username":              13,
+		"logging.level":                              0,
+	}
+
+	for path, line := range tests {
+		assert.Equal(t, line, FindLine([]byte(locationTestConfig), path), path)
+	}
+}
+
+func TestWithLine(t *testing.T) {
+	dir, err := ioutil.TempDir("", "location")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "beat.yml")
+	require.NoError(t, ioutil.WriteFile(file, []byte(locationTestConfig), 0600))
+
+	cfg, err := common.LoadFile(file)
+	require.NoError(t, err)
+
+	var settings struct {
+		Protocols []struct {
+			Ports []string `config:"ports"`
+		} `config:"packetbeat.protocols"`
+		VerificationMode int `config:"output.elasticsearch.ssl.verification_mode"`
+	}
+	err = WithLine(cfg.Unpack(&settings))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "(line 16)")
+}
@@ -26,12 +26,13 @@ import (
 func genExportCmd(name, idxPrefix, beatVersion string) *cobra.Command {
 	exportCmd := &cobra.Command{
 		Use:   "export",
-		Short: "Export current config or index template",
+		Short: "Export current config, index template or fields",
 	}
 
 	exportCmd.AddCommand(export.GenExportConfigCmd(name, idxPrefix, beatVersion))
 	exportCmd.AddCommand(export.GenTemplateConfigCmd(name, idxPrefix, beatVersion))
 	exportCmd.AddCommand(export.GenDashboardCmd(name, idxPrefix, beatVersion))
+	exportCmd.AddCommand(export.GenFieldsCmd(name, idxPrefix, beatVersion))
 
 	return exportCmd
 }
@@ -19,6 +19,7 @@ package export
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -26,20 +27,26 @@ import (
 
 	"github.com/elastic/beats/libbeat/cmd/instance"
 	"github.com/elastic/beats/libbeat/common/cli"
+	"github.com/elastic/beats/libbeat/paths"
 )
 
 // GenExportConfigCmd write to stdout the current configuration in the YAML format.
 func GenExportConfigCmd(name, idxPrefix, beatVersion string) *cobra.Command {
-	return &cobra.Command{
+	genExportConfigCmd := &cobra.Command{
 		Use:   "config",
 		Short: "Export current config to stdout",
 		Run: cli.RunWith(func(cmd *cobra.Command, args []string) error {
-			return exportConfig(name, idxPrefix, beatVersion)
+			reference, _ := cmd.Flags().GetBool("reference")
+			return exportConfig(name, idxPrefix, beatVersion, reference)
 		}),
 	}
+
+	genExportConfigCmd.Flags().Bool("reference", false, "Export the reference config with all the settings and their defaults")
+
+	return genExportConfigCmd
 }
 
-func exportConfig(name, idxPrefix, beatVersion string) error {
+func exportConfig(name, idxPrefix, beatVersion string, reference bool) error {
 	b, err := instance.NewBeat(name, idxPrefix, beatVersion)
 	if err != nil {
 		return fmt.Errorf("error initializing beat: %s", err)
@@ -50,6 +57,17 @@ func exportConfig(name, idxPrefix, beatVersion string) error {
 		return fmt.Errorf("error initializing beat: %s", err)
 	}
 
+	if reference {
+		// The reference config is shipped next to the config file.
+		path := paths.Resolve(paths.Config, name+".reference.yml")
+		res, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("error reading reference config: %s", err)
+		}
+		os.Stdout.Write(res)
+		return nil
+	}
+
 	var config map[string]interface{}
 	err = b.RawConfig.Unpack(&config)
 	if err != nil {
@@ -0,0 +1,74 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package export
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/beats/libbeat/cmd/instance"
+	"github.com/elastic/beats/libbeat/common/cli"
+	"github.com/elastic/beats/libbeat/paths"
+	"github.com/elastic/beats/libbeat/template"
+)
+
+// GenFieldsCmd writes to stdout the fields.yml the index template is
+// generated from.
+func GenFieldsCmd(name, idxPrefix, beatVersion string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "fields",
+		Short: "Export fields.yml to stdout",
+		Run: cli.RunWith(func(cmd *cobra.Command, args []string) error {
+			return exportFields(name, idxPrefix, beatVersion)
+		}),
+	}
+}
+
+func exportFields(name, idxPrefix, beatVersion string) error {
+	b, err := instance.NewBeat(name, idxPrefix, beatVersion)
+	if err != nil {
+		return fmt.Errorf("error initializing beat: %s", err)
+	}
+
+	err = b.Init()
+	if err != nil {
+		return fmt.Errorf("error initializing beat: %s", err)
+	}
+
+	cfg := template.DefaultConfig
+	if b.Config.Template.Enabled() {
+		err = b.Config.Template.Unpack(&cfg)
+		if err != nil {
+			return fmt.Errorf("error getting template settings: %s", err)
+		}
+	}
+
+	fields := b.Fields
+	if cfg.Fields != "" {
+		fields, err = ioutil.ReadFile(paths.Resolve(paths.Config, cfg.Fields))
+		if err != nil {
+			return fmt.Errorf("error reading fields file: %s", err)
+		}
+	}
+
+	os.Stdout.Write(fields)
+	return nil
+}
@@ -51,6 +51,7 @@ import (
 	"github.com/elastic/beats/libbeat/metric/system/host"
 	"github.com/elastic/beats/libbeat/monitoring"
 	"github.com/elastic/beats/libbeat/monitoring/report"
+	"github.com/elastic/beats/libbeat/monitoring/report/events"
 	"github.com/elastic/beats/libbeat/monitoring/report/log"
 	"github.com/elastic/beats/libbeat/outputs/elasticsearch"
 	"github.com/elastic/beats/libbeat/paths"
@@ -72,8 +73,16 @@ import (
 	_ "github.com/elastic/beats/libbeat/processors/add_host_metadata"
 	_ "github.com/elastic/beats/libbeat/processors/add_kubernetes_metadata"
 	_ "github.com/elastic/beats/libbeat/processors/add_locale"
+	_ "github.com/elastic/beats/libbeat/processors/communityid"
 	_ "github.com/elastic/beats/libbeat/processors/dissect"
 	_ "github.com/elastic/beats/libbeat/processors/dns"
+	_ "github.com/elastic/beats/libbeat/processors/exec"
+	_ "github.com/elastic/beats/libbeat/processors/fingerprint"
+	_ "github.com/elastic/beats/libbeat/processors/geoip"
+	_ "github.com/elastic/beats/libbeat/processors/ratelimit"
+	_ "github.com/elastic/beats/libbeat/processors/sample"
+	_ "github.com/elastic/beats/libbeat/processors/script"
+	_ "github.com/elastic/beats/libbeat/processors/timestamp"
 
 	// Register autodiscover providers
 	_ "github.com/elastic/beats/libbeat/autodiscover/providers/docker"
@@ -111,8 +120,9 @@ type beatConfig struct {
 	Keystore      *common.Config `config:"keystore"`
 
 	// output/publishing related configurations
-	Pipeline   pipeline.Config `config:",inline"`
-	Monitoring *common.Config  `config:"xpack.monitoring"`
+	Pipeline         pipeline.Config `config:",inline"`
+	Monitoring       *common.Config  `config:"xpack.monitoring"`
+	MonitoringEvents *common.Config  `config:"monitoring.events"`
 
 	// elastic stack 'setup' configurations
 	Dashboards *common.Config `config:"setup.dashboards"`
@@ -244,9 +254,13 @@ func (b *Beat) Init() error {
 
 // BeatConfig returns config section for this beat
 func (b *Beat) BeatConfig() (*common.Config, error) {
-	configName := strings.ToLower(b.Info.Beat)
-	if b.RawConfig.HasField(configName) {
-		sub, err := b.RawConfig.Child(configName, -1)
+	return beatConfigSection(b.RawConfig, b.Info.Beat)
+}
+
+func beatConfigSection(cfg *common.Config, beatName string) (*common.Config, error) {
+	configName := strings.ToLower(beatName)
+	if cfg.HasField(configName) {
+		sub, err := cfg.Child(configName, -1)
 		if err != nil {
 			return nil, err
 		}
@@ -320,7 +334,7 @@ func (b *Beat) launch(bt beat.Creator) error {
 
 	err := b.Init()
 	if err != nil {
-		return err
+		return cfgfile.WithLine(err)
 	}
 
 	svc.BeforeRun()
@@ -330,9 +344,11 @@ func (b *Beat) launch(bt beat.Creator) error {
 		return err
 	}
 
+	svc.StartWatchdog()
+
 	beater, err := b.createBeater(bt)
 	if err != nil {
-		return err
+		return cfgfile.WithLine(err)
 	}
 
 	if b.Config.Monitoring.Enabled() {
@@ -343,6 +359,14 @@ func (b *Beat) launch(bt beat.Creator) error {
 		defer reporter.Stop()
 	}
 
+	if b.Config.MonitoringEvents.Enabled() {
+		reporter, err := events.MakeReporter(b.Info, b.Publisher, b.Config.MonitoringEvents)
+		if err != nil {
+			return err
+		}
+		defer reporter.Stop()
+	}
+
 	if b.Config.MetricLogging == nil || b.Config.MetricLogging.Enabled() {
 		reporter, err := log.MakeReporter(b.Info, b.Config.MetricLogging)
 		if err != nil {
@@ -360,6 +384,9 @@ func (b *Beat) launch(bt beat.Creator) error {
 
 	ctx, cancel := context.WithCancel(context.Background())
 	svc.HandleSignals(beater.Stop, cancel)
+	if reloader, ok := beater.(beat.Reloader); ok {
+		b.handleReload(reloader)
+	}
 
 	err = b.loadDashboards(ctx, false)
 	if err != nil {
@@ -378,6 +405,12 @@ func (b *Beat) launch(bt beat.Creator) error {
 		api.Start(b.Config.HTTP)
 	}
 
+	if notifier, ok := beater.(beat.ReadyNotifier); ok {
+		notifier.SetReadyCallback(svc.NotifyReady)
+	} else {
+		svc.NotifyReady()
+	}
+
 	return beater.Run(&b.Beat)
 }
 
@@ -386,13 +419,13 @@ func (b *Beat) TestConfig(bt beat.Creator) error {
 	return handleError(func() error {
 		err := b.Init()
 		if err != nil {
-			return err
+			return cfgfile.WithLine(err)
 		}
 
 		// Create beater to ensure all settings are OK
 		_, err = b.createBeater(bt)
 		if err != nil {
-			return err
+			return cfgfile.WithLine(err)
 		}
 
 		fmt.Println("Config OK")
@@ -418,13 +451,11 @@ func (b *Beat) Setup(bt beat.Creator, template, dashboards, machineLearning, pip
 		}
 
 		if template {
-			outCfg := b.Config.Output
-
-			if outCfg.Name() != "elasticsearch" {
+			esConfig := b.elasticsearchOutputConfig()
+			if esConfig == nil {
 				return fmt.Errorf("Template loading requested but the Elasticsearch output is not configured/enabled")
 			}
 
-			esConfig := outCfg.Config()
 			if tmplCfg := b.Config.Template; tmplCfg == nil || tmplCfg.Enabled() {
 				loadCallback, err := b.templateLoadingCallback()
 				if err != nil {
@@ -465,7 +496,7 @@ func (b *Beat) Setup(bt beat.Creator, template, dashboards, machineLearning, pip
 		}
 
 		if pipelines && b.OverwritePipelinesCallback != nil {
-			esConfig := b.Config.Output.Config()
+			esConfig := b.elasticsearchOutputConfig()
 			err = b.OverwritePipelinesCallback(esConfig)
 			if err != nil {
 				return err
@@ -654,11 +685,7 @@ func (b *Beat) loadDashboards(ctx context.Context, force bool) error {
 	}
 
 	if b.Config.Dashboards.Enabled() {
-		var esConfig *common.Config
-
-		if b.Config.Output.Name() == "elasticsearch" {
-			esConfig = b.Config.Output.Config()
-		}
+		esConfig := b.elasticsearchOutputConfig()
 		err := dashboards.ImportDashboards(ctx, b.Info.Beat, b.Info.Hostname, paths.Resolve(paths.Home, ""),
 			b.Config.Kibana, esConfig, b.Config.Dashboards, nil)
 		if err != nil {
@@ -685,13 +712,13 @@ func (b *Beat) registerTemplateLoading() error {
 	}
 
 	// Loads template by default if esOutput is enabled
-	if b.Config.Output.Name() == "elasticsearch" {
+	if esConfig := b.elasticsearchOutputConfig(); esConfig != nil {
 
 		// Get ES Index name for comparison
 		esCfg := struct {
 			Index string `config:"index"`
 		}{}
-		err := b.Config.Output.Config().Unpack(&esCfg)
+		err := esConfig.Unpack(&esCfg)
 		if err != nil {
 			return err
 		}
@@ -715,6 +742,27 @@ func (b *Beat) registerTemplateLoading() error {
 	return nil
 }
 
+// elasticsearchOutputConfig returns the configuration of the Elasticsearch
+// output, or the first Elasticsearch output of the outputs setting. Returns nil
+// if no Elasticsearch output is configured.
+func (b *Beat) elasticsearchOutputConfig() *common.Config {
+	if b.Config.Output.Name() == "elasticsearch" {
+		return b.Config.Output.Config()
+	}
+
+	for _, cfg := range b.Config.Pipeline.Outputs {
+		if !cfg.HasField("elasticsearch") {
+			continue
+		}
+		esConfig, err := cfg.Child("elasticsearch", -1)
+		if err != nil || !esConfig.Enabled() {
+			continue
+		}
+		return esConfig
+	}
+	return nil
+}
+
 // Build and return a callback to load index template into ES
 func (b *Beat) templateLoadingCallback() (func(esClient *elasticsearch.Client) error, error) {
 	callback := func(esClient *elasticsearch.Client) error {
@@ -0,0 +1,137 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package instance
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/cfgfile"
+	"github.com/elastic/beats/libbeat/cloudid"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/outputs"
+	svc "github.com/elastic/beats/libbeat/service"
+)
+
+// outputReloader is implemented by publisher pipelines supporting to replace
+// their output at runtime.
+type outputReloader interface {
+	LoadOutput(outcfg common.ConfigNamespace) (outputs.Group, error)
+	SetOutput(out outputs.Group)
+}
+
+// reloadMutex serializes reload requests.
+var reloadMutex sync.Mutex
+
+// handleReload installs the handler reloading the configuration on SIGHUP
+// or on a Windows service parameter change request.
+func (b *Beat) handleReload(reloader beat.Reloader) {
+	handler := func() {
+		reloadMutex.Lock()
+		defer reloadMutex.Unlock()
+
+		logp.Info("Reloading the configuration")
+		if err := b.reload(reloader); err != nil {
+			logp.Err("Failed to reload the configuration, keeping the current configuration: %v", err)
+			return
+		}
+		logp.Info("Configuration reloaded")
+	}
+	svc.HandleReload(handler)
+}
+
+// reload reads the configuration files again and applies the output and
+// Beat specific settings. The new configuration is only applied if it is
+// valid as a whole, otherwise the running configuration is kept unchanged.
+func (b *Beat) reload(reloader beat.Reloader) error {
+	cfg, err := cfgfile.Load("")
+	if err != nil {
+		return fmt.Errorf("error loading config file: %v", err)
+	}
+	if err := cloudid.OverwriteSettings(cfg); err != nil {
+		return err
+	}
+
+	var config beatConfig
+	if err := cfg.Unpack(&config); err != nil {
+		return fmt.Errorf("error unpacking config data: %v", err)
+	}
+
+	var (
+		publisher outputReloader
+		out       outputs.Group
+	)
+	changed, err := outputChanged(b.Config.Output, config.Output)
+	if err != nil {
+		return err
+	}
+	if changed {
+		var ok bool
+		publisher, ok = b.Publisher.(outputReloader)
+		if !ok {
+			return errors.New("changing the output is not supported with multiple outputs")
+		}
+
+		out, err = publisher.LoadOutput(config.Output)
+		if err != nil {
+			return fmt.Errorf("error initializing output: %v", err)
+		}
+	}
+
+	sub, err := beatConfigSection(cfg, b.Info.Beat)
+	if err == nil {
+		err = reloader.Reload(sub)
+	}
+	if err != nil {
+		for _, client := range out.Clients {
+			client.Close()
+		}
+		return err
+	}
+
+	if publisher != nil {
+		logp.Info("Applying new %s output settings", config.Output.Name())
+		publisher.SetOutput(out)
+		b.Config.Output = config.Output
+	}
+	return nil
+}
+
+// outputChanged compares the settings of two output configurations.
+func outputChanged(old, new common.ConfigNamespace) (bool, error) {
+	if old.Name() != new.Name() {
+		return true, nil
+	}
+
+	var oldSettings, newSettings map[string]interface{}
+	if cfg := old.Config(); cfg != nil {
+		if err := cfg.Unpack(&oldSettings); err != nil {
+			return false, err
+		}
+	}
+	if cfg := new.Config(); cfg != nil {
+		if err := cfg.Unpack(&newSettings); err != nil {
+			return false, err
+		}
+	}
+	return !reflect.DeepEqual(oldSettings, newSettings), nil
+}
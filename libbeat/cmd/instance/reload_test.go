@@ -0,0 +1,73 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package instance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestOutputChanged(t *testing.T) {
+	current := map[string]interface{}{
+		"elasticsearch.hosts":    []string{"localhost:9200"},
+		"elasticsearch.password": "secret",
+	}
+
+	tests := map[string]struct {
+		config  map[string]interface{}
+		changed bool
+	}{
+		"unchanged": {
+			config:  current,
+			changed: false,
+		},
+		"changed setting": {
+			config: map[string]interface{}{
+				"elasticsearch.hosts":    []string{"localhost:9200"},
+				"elasticsearch.password": "changed",
+			},
+			changed: true,
+		},
+		"changed output type": {
+			config: map[string]interface{}{
+				"logstash.hosts": []string{"localhost:5044"},
+			},
+			changed: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			changed, err := outputChanged(outputNamespace(t, current), outputNamespace(t, test.config))
+			require.NoError(t, err)
+			assert.Equal(t, test.changed, changed)
+		})
+	}
+}
+
+func outputNamespace(t *testing.T, settings map[string]interface{}) common.ConfigNamespace {
+	var ns common.ConfigNamespace
+	require.NoError(t, common.MustNewConfigFrom(settings).Unpack(&ns))
+	return ns
+}
@@ -0,0 +1,130 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/beats/libbeat/common/cli"
+	"github.com/elastic/beats/libbeat/service"
+)
+
+// genServiceCmd initializes the service command to manage the Windows
+// service of the beat with the following subcommands:
+//  - install
+//  - uninstall
+//  - start
+//  - stop
+func genServiceCmd(name string) *cobra.Command {
+	serviceCmd := cobra.Command{
+		Use:   "service",
+		Short: "Manage the Windows service",
+	}
+
+	serviceCmd.AddCommand(genInstallServiceCmd(name))
+	serviceCmd.AddCommand(genUninstallServiceCmd(name))
+	serviceCmd.AddCommand(genStartServiceCmd(name))
+	serviceCmd.AddCommand(genStopServiceCmd(name))
+
+	return &serviceCmd
+}
+
+func genInstallServiceCmd(name string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "install [-- flags]",
+		Short: "Install the Windows service",
+		Long: "Install the Windows service. The service reads the configuration file next to the executable " +
+			"and keeps its data and logs in C:\\ProgramData\\" + name + ". Flags given after -- are added to " +
+			"the command line of the service.",
+		Run: cli.RunWith(func(cmd *cobra.Command, args []string) error {
+			exe, err := os.Executable()
+			if err != nil {
+				return err
+			}
+
+			options := service.ServiceOptions{
+				Name:        name,
+				DisplayName: strings.Title(name),
+				Args:        append(defaultServiceArgs(name, filepath.Dir(exe)), args...),
+			}
+			if err := service.Install(options); err != nil {
+				return err
+			}
+			fmt.Printf("Installed service %s\n", name)
+			return nil
+		}),
+	}
+}
+
+// defaultServiceArgs returns the command line flags of the service, like the
+// install-service script of the Windows packages.
+func defaultServiceArgs(name, home string) []string {
+	data := filepath.Join(`C:\ProgramData`, name)
+	return []string{
+		"-c", filepath.Join(home, name+".yml"),
+		"--path.home", home,
+		"--path.data", data,
+		"--path.logs", filepath.Join(data, "logs"),
+	}
+}
+
+func genUninstallServiceCmd(name string) *cobra.Command {
+	var timeout time.Duration
+	command := &cobra.Command{
+		Use:   "uninstall",
+		Short: "Stop and remove the Windows service",
+		Run: cli.RunWith(func(cmd *cobra.Command, args []string) error {
+			if err := service.Uninstall(name, timeout); err != nil {
+				return err
+			}
+			fmt.Printf("Removed service %s\n", name)
+			return nil
+		}),
+	}
+	command.Flags().DurationVar(&timeout, "timeout", 30*time.Second, "How long to wait for the service to stop")
+	return command
+}
+
+func genStartServiceCmd(name string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "start",
+		Short: "Start the Windows service",
+		Run: cli.RunWith(func(cmd *cobra.Command, args []string) error {
+			return service.Start(name)
+		}),
+	}
+}
+
+func genStopServiceCmd(name string) *cobra.Command {
+	var timeout time.Duration
+	command := &cobra.Command{
+		Use:   "stop",
+		Short: "Stop the Windows service",
+		Run: cli.RunWith(func(cmd *cobra.Command, args []string) error {
+			return service.Stop(name, timeout)
+		}),
+	}
+	command.Flags().DurationVar(&timeout, "timeout", 30*time.Second, "How long to wait for the service to stop")
+	return command
+}
@@ -20,6 +20,7 @@ package test
 import (
 	"fmt"
 	"os"
+	"runtime"
 
 	"github.com/spf13/cobra"
 
@@ -51,6 +52,7 @@ func GenTestOutputCmd(name, beatVersion string) *cobra.Command {
 				os.Exit(1)
 			}
 
+			failed := false
 			for _, client := range output.Clients {
 				tClient, ok := client.(testing.Testable)
 				if !ok {
@@ -59,8 +61,31 @@ func GenTestOutputCmd(name, beatVersion string) *cobra.Command {
 				}
 
 				// Perform test:
-				tClient.Test(testing.NewConsoleDriver(os.Stdout))
+				if !testClient(tClient) {
+					failed = true
+				}
+			}
+
+			if failed {
+				os.Exit(1)
 			}
 		},
 	}
 }
+
+// testClient runs the tests of a client, returning false if one of them
+// failed. A failure stops the tests of the client only, so all the hosts are
+// reported.
+func testClient(client testing.Testable) bool {
+	ok := true
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		client.Test(testing.NewConsoleDriverWithKiller(os.Stdout, func() {
+			ok = false
+			runtime.Goexit()
+		}))
+	}()
+	<-done
+	return ok
+}
@@ -0,0 +1,141 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/cmd/instance"
+	"github.com/elastic/beats/libbeat/common/jsontransform"
+	"github.com/elastic/beats/libbeat/outputs/codec"
+	jsoncodec "github.com/elastic/beats/libbeat/outputs/codec/json"
+	"github.com/elastic/beats/libbeat/processors"
+	"github.com/elastic/beats/libbeat/publisher/pipeline"
+)
+
+func GenTestProcessorsCmd(name, beatVersion string) *cobra.Command {
+	var pretty bool
+
+	cmd := &cobra.Command{
+		Use:   "processors [file]",
+		Short: "Run events read as JSON from a file or stdin through the configured processors",
+		Long: "Run events read as JSON from a file or stdin through the configured global processors.\n" +
+			"Processors, fields and tags configured per input or module are not applied.",
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			b, err := instance.NewBeat(name, "", beatVersion)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error initializing beat: %s\n", err)
+				os.Exit(1)
+			}
+
+			err = b.Init()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error initializing beat: %s\n", err)
+				os.Exit(1)
+			}
+
+			// The processors file is loaded once, the watcher is not started.
+			procs, _, err := pipeline.LoadProcessors(b.Config.Pipeline)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error initializing processors: %s\n", err)
+				os.Exit(1)
+			}
+			defer procs.Close()
+
+			in := io.Reader(os.Stdin)
+			if len(args) == 1 && args[0] != "-" {
+				f, err := os.Open(args[0])
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error opening events file: %s\n", err)
+					os.Exit(1)
+				}
+				defer f.Close()
+				in = f
+			}
+
+			enc := jsoncodec.New(pretty, false, b.Info.Version)
+			if err := runProcessors(procs, in, os.Stdout, os.Stderr, b.Info.Beat, enc); err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading events: %s\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&pretty, "pretty", false, "Pretty print the resulting events")
+	return cmd
+}
+
+// runProcessors decodes the JSON events from in, runs them through the
+// processors and writes the resulting events to out. Processor errors and
+// dropped events are reported to errOut.
+func runProcessors(
+	procs *processors.Processors,
+	in io.Reader,
+	out, errOut io.Writer,
+	index string,
+	enc codec.Codec,
+) error {
+	dec := json.NewDecoder(in)
+
+	for n := 1; ; n++ {
+		event := &beat.Event{Timestamp: time.Now().UTC()}
+		ok, err := jsontransform.DecodeEvent(dec, event)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "invalid event %d", n)
+		}
+		if !ok {
+			return errors.Errorf("invalid event %d: null", n)
+		}
+
+		for _, p := range procs.List {
+			var err error
+			event, err = p.Run(event)
+			if err != nil {
+				fmt.Fprintf(errOut, "Event %d: processor %s failed: %s\n", n, p, err)
+			}
+			if event == nil {
+				break
+			}
+		}
+
+		if event == nil {
+			fmt.Fprintf(errOut, "Event %d: dropped\n", n)
+			continue
+		}
+
+		serialized, err := enc.Encode(index, event)
+		if err != nil {
+			return errors.Wrapf(err, "failed to encode event %d", n)
+		}
+		if _, err := out.Write(append(serialized, '\n')); err != nil {
+			return err
+		}
+	}
+}
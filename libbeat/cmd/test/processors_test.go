@@ -0,0 +1,174 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	jsoncodec "github.com/elastic/beats/libbeat/outputs/codec/json"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+type testProcessor struct {
+	name string
+	fn   func(*beat.Event) (*beat.Event, error)
+}
+
+func (p testProcessor) Run(event *beat.Event) (*beat.Event, error) { return p.fn(event) }
+func (p testProcessor) String() string                             { return p.name }
+
+var (
+	addTag = testProcessor{"add_tag", func(event *beat.Event) (*beat.Event, error) {
+		event.Fields.Put("tags", []string{"processed"})
+		return event, nil
+	}}
+
+	dropDebug = testProcessor{"drop_debug", func(event *beat.Event) (*beat.Event, error) {
+		if level, _ := event.Fields.GetValue("level"); level == "debug" {
+			return nil, nil
+		}
+		return event, nil
+	}}
+
+	failing = testProcessor{"failing", func(event *beat.Event) (*beat.Event, error) {
+		return event, errors.New("oops")
+	}}
+)
+
+func TestRunProcessors(t *testing.T) {
+	tests := []struct {
+		name       string
+		processors []processors.Processor
+		input      string
+		output     []common.MapStr
+		errOut     string
+		err        string
+	}{
+		{
+			name:   "no events",
+			input:  "",
+			output: []common.MapStr{},
+		},
+		{
+			name:  "fields and numbers",
+			input: `{"@timestamp":"2018-09-10T11:12:13.456Z","count":3,"ratio":0.5,"nested":{"list":[1,"a"]}}`,
+			output: []common.MapStr{{
+				"@timestamp": "2018-09-10T11:12:13.456Z",
+				"count":      float64(3),
+				"ratio":      0.5,
+				"nested":     map[string]interface{}{"list": []interface{}{float64(1), "a"}},
+			}},
+		},
+		{
+			name:       "processors",
+			processors: []processors.Processor{dropDebug, addTag},
+			input: `{"@timestamp":"2018-09-10T11:12:13Z","level":"debug"}
+				{"@timestamp":"2018-09-10T11:12:14Z","level":"info"}`,
+			output: []common.MapStr{{
+				"@timestamp": "2018-09-10T11:12:14.000Z",
+				"level":      "info",
+				"tags":       []interface{}{"processed"},
+			}},
+			errOut: "Event 1: dropped\n",
+		},
+		{
+			name:       "processor errors",
+			processors: []processors.Processor{failing, addTag},
+			input:      `{"@timestamp":"2018-09-10T11:12:13Z"}`,
+			output: []common.MapStr{{
+				"@timestamp": "2018-09-10T11:12:13.000Z",
+				"tags":       []interface{}{"processed"},
+			}},
+			errOut: "Event 1: processor failing failed: oops\n",
+		},
+		{
+			name:  "metadata",
+			input: `{"@timestamp":"2018-09-10T11:12:13Z","@metadata":{"pipeline":"test"},"message":"hello"}`,
+			output: []common.MapStr{{
+				"@timestamp": "2018-09-10T11:12:13.000Z",
+				"@metadata":  map[string]interface{}{"beat": "testbeat", "type": "doc", "version": "1.2.3", "pipeline": "test"},
+				"message":    "hello",
+			}},
+		},
+		{
+			name:   "invalid timestamp",
+			input:  `{"@timestamp":123}`,
+			output: []common.MapStr{},
+			err:    "invalid event 1: unexpected @timestamp type int64",
+		},
+		{
+			name:   "invalid metadata",
+			input:  `{"@timestamp":"2018-09-10T11:12:13Z","message":"a"} {"@metadata":"test"}`,
+			output: []common.MapStr{{"@timestamp": "2018-09-10T11:12:13.000Z", "message": "a"}},
+			err:    "invalid event 2: unexpected @metadata type string",
+		},
+		{
+			name:   "null event",
+			input:  `null`,
+			output: []common.MapStr{},
+			err:    "invalid event 1: null",
+		},
+		{
+			name:   "invalid JSON",
+			input:  `{"message":`,
+			output: []common.MapStr{},
+			err:    "invalid event 1: unexpected EOF",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var out, errOut bytes.Buffer
+			procs := &processors.Processors{List: test.processors}
+			enc := jsoncodec.New(false, false, "1.2.3")
+
+			err := runProcessors(procs, strings.NewReader(test.input), &out, &errOut, "testbeat", enc)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.errOut, errOut.String())
+
+			events := []common.MapStr{}
+			dec := json.NewDecoder(&out)
+			for dec.More() {
+				var event common.MapStr
+				if !assert.NoError(t, dec.Decode(&event)) {
+					return
+				}
+				if meta, ok := event["@metadata"].(map[string]interface{}); ok && len(meta) == 3 {
+					// Only the default metadata set by the codec.
+					delete(event, "@metadata")
+				}
+				events = append(events, event)
+			}
+			assert.Equal(t, test.output, events)
+		})
+	}
+}
@@ -17,7 +17,10 @@
 
 package common
 
-import "time"
+import (
+	"math/rand"
+	"time"
+)
 
 // A Backoff waits on errors with exponential backoff (limited by maximum
 // backoff). Resetting Backoff will reset the next sleep timer to the initial
@@ -30,6 +33,9 @@ type Backoff struct {
 	max  time.Duration
 
 	last time.Time
+
+	// jitter randomizes each wait between half and the full backoff duration.
+	jitter bool
 }
 
 func NewBackoff(done <-chan struct{}, init, max time.Duration) *Backoff {
@@ -41,6 +47,15 @@ func NewBackoff(done <-chan struct{}, init, max time.Duration) *Backoff {
 	}
 }
 
+// NewEqualJitterBackoff returns a Backoff waiting a random duration between
+// half and the full exponential backoff duration. The randomization spreads
+// the retries of many clients failing at the same time.
+func NewEqualJitterBackoff(done <-chan struct{}, init, max time.Duration) *Backoff {
+	b := NewBackoff(done, init, max)
+	b.jitter = true
+	return b
+}
+
 func (b *Backoff) Reset() {
 	b.duration = b.init
 }
@@ -51,6 +66,10 @@ func (b *Backoff) Wait() bool {
 	if b.duration > b.max {
 		b.duration = b.max
 	}
+	if b.jitter && backoff > 1 {
+		half := backoff / 2
+		backoff = half + time.Duration(rand.Int63n(int64(backoff-half)+1))
+	}
 
 	select {
 	case <-b.done:
@@ -0,0 +1,59 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackoffExponential(t *testing.T) {
+	done := make(chan struct{})
+	b := NewBackoff(done, time.Millisecond, 4*time.Millisecond)
+
+	for _, expected := range []time.Duration{1, 2, 4, 4} {
+		start := time.Now()
+		assert.True(t, b.Wait())
+		assert.True(t, time.Since(start) >= expected*time.Millisecond)
+	}
+
+	b.Reset()
+	assert.Equal(t, time.Millisecond, b.duration)
+}
+
+func TestEqualJitterBackoff(t *testing.T) {
+	done := make(chan struct{})
+	b := NewEqualJitterBackoff(done, 10*time.Millisecond, 40*time.Millisecond)
+
+	for _, expected := range []time.Duration{10, 20, 40, 40} {
+		start := time.Now()
+		assert.True(t, b.Wait())
+		elapsed := time.Since(start)
+		assert.True(t, elapsed >= expected*time.Millisecond/2, "waited %v", elapsed)
+		assert.True(t, elapsed < expected*time.Millisecond+time.Second, "waited %v", elapsed)
+	}
+}
+
+func TestBackoffDone(t *testing.T) {
+	done := make(chan struct{})
+	b := NewEqualJitterBackoff(done, time.Hour, time.Hour)
+	close(done)
+	assert.False(t, b.Wait())
+}
@@ -205,6 +205,22 @@ func (c *Cache) CleanUp() int {
 	return count
 }
 
+// RemoveAll removes all elements from the cache, expired or not, invoking the
+// RemovalListener for each element. It returns the number of elements removed.
+func (c *Cache) RemoveAll() int {
+	c.Lock()
+	defer c.Unlock()
+	count := 0
+	for k, v := range c.elements {
+		delete(c.elements, k)
+		count++
+		if c.listener != nil {
+			c.listener(k, v.value)
+		}
+	}
+	return count
+}
+
 // Entries returns a shallow copy of the non-expired elements in the cache.
 func (c *Cache) Entries() map[Key]Value {
 	c.RLock()
@@ -77,6 +77,20 @@ func TestExpireWithoutRemovalListener(t *testing.T) {
 	assert.Equal(t, 2, c.CleanUp())
 }
 
+// Test that RemoveAll removes the non-expired elements too and invokes the
+// RemovalListener for them.
+func TestRemoveAll(t *testing.T) {
+	var removed []Key
+	c := newCache(Timeout, InitalSize, func(k Key, v Value) {
+		removed = append(removed, k)
+	}, fakeClock)
+	c.Put(alphaKey, alphaValue)
+	c.Put(bravoKey, bravoValue)
+	assert.Equal(t, 2, c.RemoveAll())
+	assert.ElementsMatch(t, []Key{alphaKey, bravoKey}, removed)
+	assert.Equal(t, 0, c.Size())
+}
+
 func TestPutIfAbsent(t *testing.T) {
 	c := newCache(Timeout, InitalSize, nil, fakeClock)
 	oldValue := c.PutIfAbsent(alphaKey, alphaValue)
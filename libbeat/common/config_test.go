@@ -165,3 +165,45 @@ func TestConfigFilePermissions(t *testing.T) {
 		assert.Contains(t, err.Error(), "writable")
 	}
 }
+
+func TestConfigEnvExpansion(t *testing.T) {
+	os.Setenv("TEST_CONFIG_ENV_HOST", "example.com")
+	defer os.Unsetenv("TEST_CONFIG_ENV_HOST")
+
+	cfg, err := NewConfigWithYAML([]byte(`
+hosts:
+  - "${TEST_CONFIG_ENV_HOST}:9200"
+  - "${TEST_CONFIG_ENV_MISSING:localhost}:9200"
+output:
+  settings:
+    name: "${TEST_CONFIG_ENV_MISSING:beat}-${TEST_CONFIG_ENV_HOST}"
+escaped: "$${TEST_CONFIG_ENV_HOST}"
+escaped_dollar: "pre$$post"
+dollar: "pa$word"
+mixed: "$${TEST_CONFIG_ENV_HOST}=${TEST_CONFIG_ENV_HOST}"
+`), "test")
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	var settings struct {
+		Hosts  []string `config:"hosts"`
+		Output struct {
+			Settings map[string]string `config:"settings"`
+		} `config:"output"`
+		Escaped       string `config:"escaped"`
+		EscapedDollar string `config:"escaped_dollar"`
+		Dollar        string `config:"dollar"`
+		Mixed         string `config:"mixed"`
+	}
+	if !assert.NoError(t, cfg.Unpack(&settings)) {
+		return
+	}
+
+	assert.Equal(t, []string{"example.com:9200", "localhost:9200"}, settings.Hosts)
+	assert.Equal(t, "beat-example.com", settings.Output.Settings["name"])
+	assert.Equal(t, "${TEST_CONFIG_ENV_HOST}", settings.Escaped)
+	assert.Equal(t, "pre$post", settings.EscapedDollar)
+	assert.Equal(t, "pa$word", settings.Dollar)
+	assert.Equal(t, "${TEST_CONFIG_ENV_HOST}=example.com", settings.Mixed)
+}
@@ -24,6 +24,7 @@ type Endpoint struct {
 	Name    string
 	Cmdline string
 	Proc    string
+	PID     int
 }
 
 // MakeEndpointPair returns source and destination endpoints from a TCP or IP tuple
@@ -34,12 +35,14 @@ func MakeEndpointPair(tuple BaseTuple, cmdlineTuple *CmdlineTuple) (src Endpoint
 		Port:    tuple.SrcPort,
 		Proc:    string(cmdlineTuple.Src),
 		Cmdline: string(cmdlineTuple.SrcCommand),
+		PID:     cmdlineTuple.SrcPID,
 	}
 	dst = Endpoint{
 		IP:      tuple.DstIP.String(),
 		Port:    tuple.DstPort,
 		Proc:    string(cmdlineTuple.Dst),
 		Cmdline: string(cmdlineTuple.DstCommand),
+		PID:     cmdlineTuple.DstPID,
 	}
 	return src, dst
 }
@@ -18,10 +18,13 @@
 package file
 
 import (
+	"compress/gzip"
+	"io"
 	"os"
 	"path/filepath"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -37,6 +40,7 @@ const (
 	rotateReasonInitializing rotateReason = iota + 1
 	rotateReasonFileSize
 	rotateReasonManualTrigger
+	rotateReasonInterval
 )
 
 func (rr rotateReason) String() string {
@@ -47,24 +51,33 @@ func (rr rotateReason) String() string {
 		return "file size"
 	case rotateReasonManualTrigger:
 		return "manual trigger"
+	case rotateReasonInterval:
+		return "interval"
 	default:
 		return "unknown"
 	}
 }
 
 // Rotator is a io.WriteCloser that automatically rotates the file it is
-// writing to when it reaches a maximum size. It also purges the oldest rotated
-// files when the maximum number of backups is reached.
+// writing to when it reaches a maximum size or age. It also purges the oldest
+// rotated files when the maximum number of backups is reached.
 type Rotator struct {
 	filename     string
 	maxSizeBytes uint
 	maxBackups   uint
 	permissions  os.FileMode
+	interval     time.Duration
+	compress     bool
 	log          Logger // Optional Logger (may be nil).
 
-	file  *os.File
-	size  uint
-	mutex sync.Mutex
+	file     *os.File
+	size     uint
+	openTime time.Time
+	mutex    sync.Mutex
+
+	// compressing tracks the compression of the last rotated file, which runs
+	// in the background so that writes are not blocked.
+	compressing sync.WaitGroup
 }
 
 // Logger allows the rotator to write debug information.
@@ -100,6 +113,26 @@ func Permissions(m os.FileMode) RotatorOption {
 	}
 }
 
+// Interval configures the maximum age of a file before it is rotated. The
+// rotation happens on the first write after the interval elapsed. An existing
+// file modified within the interval is appended to on startup, and its age is
+// counted from its modification time. The default is 0, which disables time
+// based rotation.
+func Interval(d time.Duration) RotatorOption {
+	return func(r *Rotator) {
+		r.interval = d
+	}
+}
+
+// Compress configures the Rotator to gzip the rotated files. Compressed
+// backups get a .gz suffix. The compression runs in the background, the
+// rotated file keeps the .1 suffix until it is done. The default is false.
+func Compress(enabled bool) RotatorOption {
+	return func(r *Rotator) {
+		r.compress = enabled
+	}
+}
+
 // WithLogger injects a logger implementation for logging debug information.
 // If no logger is injected then the no logging will occur.
 func WithLogger(l Logger) RotatorOption {
@@ -130,6 +163,9 @@ func NewFileRotator(filename string, options ...RotatorOption) (*Rotator, error)
 	if r.permissions > os.ModePerm {
 		return nil, errors.Errorf("file rotator permissions mask of %o is invalid", r.permissions)
 	}
+	if r.interval < 0 {
+		return nil, errors.New("file rotator interval must not be negative")
+	}
 
 	if r.log != nil {
 		r.log.Debugw("Initialized file rotator",
@@ -137,6 +173,8 @@ func NewFileRotator(filename string, options ...RotatorOption) (*Rotator, error)
 			"max_size_bytes", r.maxSizeBytes,
 			"max_backups", r.maxBackups,
 			"permissions", r.permissions,
+			"interval", r.interval,
+			"compress", r.compress,
 		)
 	}
 
@@ -157,16 +195,30 @@ func (r *Rotator) Write(data []byte) (int, error) {
 	}
 
 	if r.file == nil {
-		if err := r.openNew(); err != nil {
+		open := r.openNew
+		if !r.openTime.IsZero() {
+			// closed by Close, continue writing to the same file
+			open = r.reopen
+		}
+		if err := open(); err != nil {
 			return 0, err
 		}
-	} else if r.size+dataLen > r.maxSizeBytes {
+	}
+
+	if r.size+dataLen > r.maxSizeBytes {
 		if err := r.rotate(rotateReasonFileSize); err != nil {
 			return 0, err
 		}
 		if err := r.openFile(); err != nil {
 			return 0, err
 		}
+	} else if r.interval > 0 && time.Since(r.openTime) >= r.interval {
+		if err := r.rotate(rotateReasonInterval); err != nil {
+			return 0, err
+		}
+		if err := r.openFile(); err != nil {
+			return 0, err
+		}
 	}
 
 	n, err := r.file.Write(data)
@@ -193,18 +245,27 @@ func (r *Rotator) Rotate() error {
 	return r.rotate(rotateReasonManualTrigger)
 }
 
-// Close closes the currently open file.
+// Close closes the currently open file and waits for the compression of the
+// rotated file to finish. A later Write opens the file again and appends to
+// it.
 func (r *Rotator) Close() error {
 	r.mutex.Lock()
-	defer r.mutex.Unlock()
-	return r.closeFile()
+	err := r.closeFile()
+	r.mutex.Unlock()
+
+	r.compressing.Wait()
+	return err
 }
 
 func (r *Rotator) backupName(n uint) string {
 	if n == 0 {
 		return r.filename
 	}
-	return r.filename + "." + strconv.Itoa(int(n))
+	name := r.filename + "." + strconv.Itoa(int(n))
+	if r.compress {
+		name += ".gz"
+	}
+	return name
 }
 
 func (r *Rotator) dir() string {
@@ -228,8 +289,13 @@ func (r *Rotator) openNew() error {
 		return errors.Wrap(err, "failed to make directories for new file")
 	}
 
-	_, err = os.Stat(r.filename)
+	info, err := os.Stat(r.filename)
 	if err == nil {
+		if r.interval > 0 && time.Since(info.ModTime()) < r.interval {
+			// the file is not due for rotation yet, continue writing to it
+			r.openTime = info.ModTime()
+			return r.reopen()
+		}
 		if err = r.rotate(rotateReasonInitializing); err != nil {
 			return err
 		}
@@ -248,6 +314,29 @@ func (r *Rotator) openFile() error {
 	if err != nil {
 		return errors.Wrap(err, "failed to open new file")
 	}
+	r.openTime = time.Now()
+
+	return nil
+}
+
+// reopen opens the existing file in append mode, so that closing and
+// reopening the rotator doesn't trigger a rotation.
+func (r *Rotator) reopen() error {
+	err := os.MkdirAll(r.dir(), r.dirMode())
+	if err != nil {
+		return errors.Wrap(err, "failed to make directories for new file")
+	}
+
+	r.file, err = os.OpenFile(r.filename, os.O_CREATE|os.O_WRONLY|os.O_APPEND, r.permissions)
+	if err != nil {
+		return errors.Wrap(err, "failed to reopen file")
+	}
+	info, err := r.file.Stat()
+	if err != nil {
+		r.closeFile()
+		return errors.Wrap(err, "failed to stat reopened file")
+	}
+	r.size = uint(info.Size())
 
 	return nil
 }
@@ -286,10 +375,27 @@ func (r *Rotator) rotate(reason rotateReason) error {
 	if err := r.closeFile(); err != nil {
 		return errors.Wrap(err, "error file closing current file")
 	}
+	r.openTime = time.Time{}
+
+	// the previous rotated file must be compressed before shifting the backups
+	r.compressing.Wait()
+	pending := r.filename + ".1"
+	compress := r.compress && r.maxBackups > 0
+	if compress {
+		// left over if the process died while compressing
+		if _, err := os.Stat(pending); err == nil {
+			if err := r.compressFile(pending, r.backupName(1)); err != nil {
+				return errors.Wrap(err, "failed to rotate backups")
+			}
+		}
+	}
 
 	for i := r.maxBackups + 1; i > 0; i-- {
 		old := r.backupName(i - 1)
 		older := r.backupName(i)
+		if i == 1 && compress {
+			older = pending
+		}
 
 		if _, err := os.Stat(old); os.IsNotExist(err) {
 			continue
@@ -300,6 +406,7 @@ func (r *Rotator) rotate(reason rotateReason) error {
 		if err := os.Remove(older); err != nil && !os.IsNotExist(err) {
 			return errors.Wrap(err, "failed to rotate backups")
 		}
+
 		if err := os.Rename(old, older); err != nil {
 			return errors.Wrap(err, "failed to rotate backups")
 		} else if i == 1 {
@@ -307,8 +414,62 @@ func (r *Rotator) rotate(reason rotateReason) error {
 			if r.log != nil {
 				r.log.Debugw("Rotating file", "filename", old, "reason", reason)
 			}
+			if compress {
+				r.compressInBackground(pending, r.backupName(1))
+			}
 		}
 	}
 
 	return r.purgeOldBackups()
 }
+
+// compressInBackground compresses the rotated file src to dst without holding
+// the lock. Errors are logged, src is kept if the compression fails.
+func (r *Rotator) compressInBackground(src, dst string) {
+	r.compressing.Add(1)
+	go func() {
+		defer r.compressing.Done()
+		if err := r.compressFile(src, dst); err != nil && r.log != nil {
+			r.log.Debugw("Failed to compress rotated file", "filename", src, "error", err)
+		}
+	}()
+}
+
+// compressFile writes the gzip compressed contents of src to dst and then
+// removes src. The compressed file is synced before src is removed, so no data
+// is lost if the process dies during the rotation.
+func (r *Rotator) compressFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmp := dst + ".tmp"
+	out, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, r.permissions)
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(out)
+	_, err = io.Copy(gz, in)
+	if err == nil {
+		err = gz.Close()
+	}
+	if err == nil {
+		err = out.Sync()
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	if err := os.Rename(tmp, dst); err != nil {
+		return err
+	}
+	in.Close()
+	return os.Remove(src)
+}
@@ -18,12 +18,14 @@
 package file_test
 
 import (
+	"compress/gzip"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"sort"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -99,6 +101,173 @@ func TestFileRotatorConcurrently(t *testing.T) {
 	wg.Wait()
 }
 
+func TestFileRotatorCompress(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file_rotator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "sample.log")
+	r, err := file.NewFileRotator(filename, file.MaxBackups(2), file.Compress(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	// Close waits for the background compression
+	WriteMsg(t, r)
+	Rotate(t, r)
+	assert.NoError(t, r.Close())
+	AssertDirContents(t, dir, "sample.log.1.gz")
+
+	WriteMsg(t, r)
+	Rotate(t, r)
+	assert.NoError(t, r.Close())
+	AssertDirContents(t, dir, "sample.log.1.gz", "sample.log.2.gz")
+
+	// compressions in progress are waited for on rotation
+	WriteMsg(t, r)
+	Rotate(t, r)
+	WriteMsg(t, r)
+	Rotate(t, r)
+	assert.NoError(t, r.Close())
+	AssertDirContents(t, dir, "sample.log.1.gz", "sample.log.2.gz")
+
+	f, err := os.Open(filepath.Join(dir, "sample.log.1.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	contents, err := ioutil.ReadAll(gz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, logMessage, string(contents))
+}
+
+func TestFileRotatorInterval(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file_rotator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "sample.log")
+	r, err := file.NewFileRotator(filename, file.Interval(100*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	WriteMsg(t, r)
+	WriteMsg(t, r)
+	AssertDirContents(t, dir, "sample.log")
+
+	time.Sleep(150 * time.Millisecond)
+	WriteMsg(t, r)
+	AssertDirContents(t, dir, "sample.log", "sample.log.1")
+}
+
+func TestFileRotatorCompressLeftover(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file_rotator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// rotated file not compressed when the process died
+	filename := filepath.Join(dir, "sample.log")
+	if err := ioutil.WriteFile(filename+".1", []byte(logMessage), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := file.NewFileRotator(filename, file.MaxBackups(2), file.Compress(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	WriteMsg(t, r)
+	Rotate(t, r)
+	assert.NoError(t, r.Close())
+	AssertDirContents(t, dir, "sample.log.1.gz", "sample.log.2.gz")
+}
+
+func TestFileRotatorIntervalAppendsOnStartup(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file_rotator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "sample.log")
+	if err := ioutil.WriteFile(filename, []byte(logMessage), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// recent file, appended to
+	r, err := file.NewFileRotator(filename, file.Interval(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	WriteMsg(t, r)
+	assert.NoError(t, r.Close())
+	AssertDirContents(t, dir, "sample.log")
+
+	// file older than the interval, rotated
+	old := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(filename, old, old); err != nil {
+		t.Fatal(err)
+	}
+	r, err = file.NewFileRotator(filename, file.Interval(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	WriteMsg(t, r)
+	assert.NoError(t, r.Close())
+	AssertDirContents(t, dir, "sample.log", "sample.log.1")
+
+	contents, err := ioutil.ReadFile(filename + ".1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, logMessage+logMessage, string(contents))
+}
+
+func TestFileRotatorReopenAfterClose(t *testing.T) {
+	dir, err := ioutil.TempDir("", "file_rotator")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "sample.log")
+	r, err := file.NewFileRotator(filename, file.MaxSizeBytes(uint(3*len(logMessage))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	WriteMsg(t, r)
+	assert.NoError(t, r.Close())
+	WriteMsg(t, r)
+	AssertDirContents(t, dir, "sample.log")
+
+	contents, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, logMessage+logMessage, string(contents))
+
+	// the size of the reopened file is accounted for
+	WriteMsg(t, r)
+	WriteMsg(t, r)
+	AssertDirContents(t, dir, "sample.log", "sample.log.1")
+}
+
 func AssertDirContents(t *testing.T, dir string, files ...string) {
 	t.Helper()
 
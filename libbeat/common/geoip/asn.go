@@ -0,0 +1,45 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package geoip
+
+import (
+	"net"
+)
+
+// ASN holds the autonomous system stored for an IP address in a GeoIP2 or
+// GeoLite2 ASN database.
+type ASN struct {
+	Number       uint64
+	Organization string
+}
+
+// ASN returns the autonomous system record for ip, or nil if ip is not in the
+// database.
+func (r *Reader) ASN(ip net.IP) (*ASN, error) {
+	v, found, err := r.Lookup(ip)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	record, _ := v.(map[string]interface{})
+	asn := &ASN{
+		Organization: getString(record, "autonomous_system_organization"),
+	}
+	asn.Number, _ = record["autonomous_system_number"].(uint64)
+	return asn, nil
+}
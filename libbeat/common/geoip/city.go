@@ -0,0 +1,104 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package geoip
+
+import (
+	"net"
+)
+
+// defaultLanguage is used to select localized names.
+const defaultLanguage = "en"
+
+// City holds the location information stored for an IP address in a GeoIP2
+// or GeoLite2 City database. Fields not present in the database are empty.
+type City struct {
+	ContinentCode  string
+	ContinentName  string
+	CountryISOCode string
+	CountryName    string
+	RegionISOCode  string
+	RegionName     string
+	CityName       string
+	PostalCode     string
+	TimeZone       string
+	Location       *Location
+}
+
+// Location is a geographic coordinate.
+type Location struct {
+	Lat float64
+	Lon float64
+}
+
+// City returns the city record for ip, or nil if ip is not in the database.
+func (r *Reader) City(ip net.IP) (*City, error) {
+	v, found, err := r.Lookup(ip)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	record, _ := v.(map[string]interface{})
+	city := &City{
+		ContinentCode:  getString(record, "continent", "code"),
+		ContinentName:  getName(record, "continent"),
+		CountryISOCode: getString(record, "country", "iso_code"),
+		CountryName:    getName(record, "country"),
+		CityName:       getName(record, "city"),
+		PostalCode:     getString(record, "postal", "code"),
+		TimeZone:       getString(record, "location", "time_zone"),
+	}
+
+	// the first subdivision is the largest one, e.g. the state
+	if subdivisions, ok := record["subdivisions"].([]interface{}); ok && len(subdivisions) > 0 {
+		if sub, ok := subdivisions[0].(map[string]interface{}); ok {
+			city.RegionISOCode, _ = sub["iso_code"].(string)
+			city.RegionName = getName(sub)
+		}
+	}
+
+	if location, ok := record["location"].(map[string]interface{}); ok {
+		lat, latOK := location["latitude"].(float64)
+		lon, lonOK := location["longitude"].(float64)
+		if latOK && lonOK {
+			city.Location = &Location{Lat: lat, Lon: lon}
+		}
+	}
+
+	return city, nil
+}
+
+func getMap(m map[string]interface{}, path ...string) map[string]interface{} {
+	for _, key := range path {
+		next, ok := m[key].(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		m = next
+	}
+	return m
+}
+
+func getString(m map[string]interface{}, path ...string) string {
+	last := len(path) - 1
+	s, _ := getMap(m, path[:last]...)[path[last]].(string)
+	return s
+}
+
+func getName(m map[string]interface{}, path ...string) string {
+	return getString(getMap(m, path...), "names", defaultLanguage)
+}
@@ -0,0 +1,265 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package geoip
+
+import (
+	"encoding/binary"
+	"math"
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// Data types used in the MaxMind DB data section.
+const (
+	typeExtended  = 0
+	typePointer   = 1
+	typeString    = 2
+	typeDouble    = 3
+	typeBytes     = 4
+	typeUint16    = 5
+	typeUint32    = 6
+	typeMap       = 7
+	typeInt32     = 8
+	typeUint64    = 9
+	typeUint128   = 10
+	typeArray     = 11
+	typeContainer = 12
+	typeEndMarker = 13
+	typeBool      = 14
+	typeFloat     = 15
+)
+
+// maxDecodeDepth limits the nesting of maps and arrays, so that pointer
+// cycles in a corrupt data section can't recurse forever.
+const maxDecodeDepth = 512
+
+// decoder decodes values stored in a MaxMind DB data section. Pointers are
+// resolved relative to the start of buf.
+type decoder struct {
+	buf []byte
+}
+
+// decode decodes the value at offset and returns it together with the offset
+// of the next value. The returned value is one of string, float64, float32,
+// []byte, uint64, int32, *big.Int, bool, []interface{} or
+// map[string]interface{}.
+func (d *decoder) decode(offset uint) (interface{}, uint, error) {
+	return d.decodeAt(offset, 0)
+}
+
+// decodeAt decodes the value at offset, depth being the number of maps and
+// arrays the value is nested in.
+func (d *decoder) decodeAt(offset uint, depth int) (interface{}, uint, error) {
+	typeNum, size, offset, err := d.decodeCtrl(offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if typeNum == typePointer {
+		pointer, next, err := d.decodePointer(size, offset)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		// pointers to pointers are invalid, so a pointer always references a
+		// concrete value
+		typeNum, size, offset, err = d.decodeCtrl(pointer)
+		if err != nil {
+			return nil, 0, err
+		}
+		if typeNum == typePointer {
+			return nil, 0, errors.New("invalid pointer to pointer in data section")
+		}
+
+		value, _, err := d.decodeValue(typeNum, size, offset, depth)
+		return value, next, err
+	}
+
+	return d.decodeValue(typeNum, size, offset, depth)
+}
+
+func (d *decoder) decodeCtrl(offset uint) (byte, uint, uint, error) {
+	if offset >= uint(len(d.buf)) {
+		return 0, 0, 0, errors.New("unexpected end of data section")
+	}
+
+	ctrl := d.buf[offset]
+	offset++
+
+	typeNum := ctrl >> 5
+	if typeNum == typeExtended {
+		if offset >= uint(len(d.buf)) {
+			return 0, 0, 0, errors.New("unexpected end of data section")
+		}
+		typeNum = 7 + d.buf[offset]
+		offset++
+	}
+
+	size := uint(ctrl & 0x1f)
+	if typeNum == typePointer || size < 29 {
+		return typeNum, size, offset, nil
+	}
+
+	n := size - 28
+	if offset+n > uint(len(d.buf)) {
+		return 0, 0, 0, errors.New("unexpected end of data section")
+	}
+	extra := uint(readUint(d.buf[offset : offset+n]))
+	offset += n
+
+	switch size {
+	case 29:
+		size = 29 + extra
+	case 30:
+		size = 285 + extra
+	default:
+		size = 65821 + extra
+	}
+	return typeNum, size, offset, nil
+}
+
+func (d *decoder) decodePointer(size, offset uint) (uint, uint, error) {
+	n := ((size >> 3) & 0x3) + 1
+	if offset+n > uint(len(d.buf)) {
+		return 0, 0, errors.New("unexpected end of data section")
+	}
+
+	b := d.buf[offset : offset+n]
+	var pointer uint
+	switch n {
+	case 1:
+		pointer = (size&0x7)<<8 | uint(b[0])
+	case 2:
+		pointer = ((size&0x7)<<16 | uint(readUint(b))) + 2048
+	case 3:
+		pointer = ((size&0x7)<<24 | uint(readUint(b))) + 526336
+	default:
+		pointer = uint(readUint(b))
+	}
+	return pointer, offset + n, nil
+}
+
+func (d *decoder) decodeValue(typeNum byte, size, offset uint, depth int) (interface{}, uint, error) {
+	switch typeNum {
+	case typeMap, typeArray:
+		if depth >= maxDecodeDepth {
+			return nil, 0, errors.New("maximum nesting depth exceeded in data section")
+		}
+		// every entry takes at least one byte, so the size read from the
+		// data section can't exceed the remaining bytes
+		if size > uint(len(d.buf))-offset {
+			return nil, 0, errors.New("unexpected end of data section")
+		}
+		if typeNum == typeMap {
+			return d.decodeMap(size, offset, depth+1)
+		}
+		return d.decodeArray(size, offset, depth+1)
+	case typeBool:
+		if size > 1 {
+			return nil, 0, errors.Errorf("invalid size %v for boolean", size)
+		}
+		return size == 1, offset, nil
+	}
+
+	end := offset + size
+	if end > uint(len(d.buf)) {
+		return nil, 0, errors.New("unexpected end of data section")
+	}
+	b := d.buf[offset:end]
+
+	switch typeNum {
+	case typeString:
+		return string(b), end, nil
+	case typeBytes:
+		return append([]byte(nil), b...), end, nil
+	case typeDouble:
+		if size != 8 {
+			return nil, 0, errors.Errorf("invalid size %v for double", size)
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), end, nil
+	case typeFloat:
+		if size != 4 {
+			return nil, 0, errors.Errorf("invalid size %v for float", size)
+		}
+		return math.Float32frombits(binary.BigEndian.Uint32(b)), end, nil
+	case typeUint16, typeUint32, typeUint64:
+		maxSize := map[byte]uint{typeUint16: 2, typeUint32: 4, typeUint64: 8}[typeNum]
+		if size > maxSize {
+			return nil, 0, errors.Errorf("invalid size %v for unsigned integer", size)
+		}
+		return readUint(b), end, nil
+	case typeInt32:
+		if size > 4 {
+			return nil, 0, errors.Errorf("invalid size %v for int32", size)
+		}
+		return int32(uint32(readUint(b))), end, nil
+	case typeUint128:
+		if size > 16 {
+			return nil, 0, errors.Errorf("invalid size %v for uint128", size)
+		}
+		return new(big.Int).SetBytes(b), end, nil
+	default:
+		return nil, 0, errors.Errorf("unexpected data type %v in data section", typeNum)
+	}
+}
+
+func (d *decoder) decodeMap(size, offset uint, depth int) (interface{}, uint, error) {
+	m := make(map[string]interface{}, size)
+	for i := uint(0); i < size; i++ {
+		k, next, err := d.decodeAt(offset, depth)
+		if err != nil {
+			return nil, 0, err
+		}
+		key, ok := k.(string)
+		if !ok {
+			return nil, 0, errors.Errorf("invalid map key of type %T", k)
+		}
+
+		v, next, err := d.decodeAt(next, depth)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		m[key] = v
+		offset = next
+	}
+	return m, offset, nil
+}
+
+func (d *decoder) decodeArray(size, offset uint, depth int) (interface{}, uint, error) {
+	arr := make([]interface{}, size)
+	for i := range arr {
+		v, next, err := d.decodeAt(offset, depth)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		arr[i] = v
+		offset = next
+	}
+	return arr, offset, nil
+}
+
+func readUint(b []byte) uint64 {
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v
+}
@@ -0,0 +1,289 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package geoiptest provides helpers to create small MaxMind databases for
+// testing.
+package geoiptest
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+// Network assigns a record to all addresses in a CIDR block.
+type Network struct {
+	CIDR   string
+	Record map[string]interface{}
+}
+
+// Database describes a MaxMind database to build.
+type Database struct {
+	IPVersion    int    // 4 or 6, defaults to 6
+	RecordSize   int    // 24, 28 or 32, defaults to 28
+	DatabaseType string // defaults to GeoIP2-City
+	Networks     []Network
+}
+
+type record struct {
+	node int // index of child node, -1 if unset
+	data int // index of data entry, -1 if unset
+}
+
+type node [2]record
+
+// Build encodes the database in the MaxMind DB format. Networks must not
+// overlap.
+func (db Database) Build() ([]byte, error) {
+	ipVersion := db.IPVersion
+	if ipVersion == 0 {
+		ipVersion = 6
+	}
+	recordSize := db.RecordSize
+	if recordSize == 0 {
+		recordSize = 28
+	}
+	dbType := db.DatabaseType
+	if dbType == "" {
+		dbType = "GeoIP2-City"
+	}
+
+	nodes := []node{newNode()}
+	for i, n := range db.Networks {
+		ip, ipnet, err := net.ParseCIDR(n.CIDR)
+		if err != nil {
+			return nil, err
+		}
+
+		addr, prefix := ip.To16(), 0
+		ones, _ := ipnet.Mask.Size()
+		if ipv4 := ip.To4(); ipv4 != nil {
+			if ipVersion == 4 {
+				addr = ipv4
+			} else {
+				addr = append(make([]byte, 12), ipv4...)
+				prefix = 96
+			}
+		} else if ipVersion == 4 {
+			return nil, fmt.Errorf("IPv6 network %v in IPv4 database", n.CIDR)
+		}
+
+		current := 0
+		bits := prefix + ones
+		for bit := 0; bit < bits; bit++ {
+			b := (addr[bit/8] >> uint(7-bit%8)) & 1
+			rec := &nodes[current][b]
+			if rec.data >= 0 {
+				return nil, fmt.Errorf("network %v overlaps with another network", n.CIDR)
+			}
+
+			if bit == bits-1 {
+				if rec.node >= 0 {
+					return nil, fmt.Errorf("network %v overlaps with another network", n.CIDR)
+				}
+				rec.data = i
+				break
+			}
+
+			if rec.node < 0 {
+				nodes = append(nodes, newNode())
+				rec = &nodes[current][b]
+				rec.node = len(nodes) - 1
+			}
+			current = rec.node
+		}
+	}
+
+	var data []byte
+	offsets := make([]int, len(db.Networks))
+	for i, n := range db.Networks {
+		offsets[i] = len(data)
+		data = encode(data, n.Record)
+	}
+
+	nodeCount := len(nodes)
+	var tree []byte
+	for _, n := range nodes {
+		var values [2]uint32
+		for i, rec := range n {
+			switch {
+			case rec.node >= 0:
+				values[i] = uint32(rec.node)
+			case rec.data >= 0:
+				values[i] = uint32(nodeCount + 16 + offsets[rec.data])
+			default:
+				values[i] = uint32(nodeCount)
+			}
+		}
+		tree = appendNode(tree, recordSize, values)
+	}
+
+	buf := append(tree, make([]byte, 16)...)
+	buf = append(buf, data...)
+	buf = append(buf, "\xAB\xCD\xEFMaxMind.com"...)
+	buf = encode(buf, map[string]interface{}{
+		"node_count":                  uint32(nodeCount),
+		"record_size":                 uint16(recordSize),
+		"ip_version":                  uint16(ipVersion),
+		"database_type":               dbType,
+		"languages":                   []interface{}{"en"},
+		"binary_format_major_version": uint16(2),
+		"binary_format_minor_version": uint16(0),
+		"build_epoch":                 uint64(1500000000),
+		"description":                 map[string]interface{}{"en": "test database"},
+	})
+	return buf, nil
+}
+
+// WriteFile builds the database and writes it to a temporary file. The
+// returned function removes the file.
+func (db Database) WriteFile(t testing.TB) (string, func()) {
+	buf, err := db.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir("", "geoiptest")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(dir, "test.mmdb")
+	if err := ioutil.WriteFile(path, buf, 0644); err != nil {
+		os.RemoveAll(dir)
+		t.Fatal(err)
+	}
+	return path, func() { os.RemoveAll(dir) }
+}
+
+func newNode() node {
+	return node{{-1, -1}, {-1, -1}}
+}
+
+func appendNode(buf []byte, recordSize int, v [2]uint32) []byte {
+	switch recordSize {
+	case 24:
+		return append(buf,
+			byte(v[0]>>16), byte(v[0]>>8), byte(v[0]),
+			byte(v[1]>>16), byte(v[1]>>8), byte(v[1]))
+	case 28:
+		return append(buf,
+			byte(v[0]>>16), byte(v[0]>>8), byte(v[0]),
+			byte((v[0]>>20)&0xF0)|byte((v[1]>>24)&0x0F),
+			byte(v[1]>>16), byte(v[1]>>8), byte(v[1]))
+	default:
+		buf = append(buf, make([]byte, 8)...)
+		binary.BigEndian.PutUint32(buf[len(buf)-8:], v[0])
+		binary.BigEndian.PutUint32(buf[len(buf)-4:], v[1])
+		return buf
+	}
+}
+
+func encode(buf []byte, v interface{}) []byte {
+	switch v := v.(type) {
+	case string:
+		buf = appendCtrl(buf, 2, len(v))
+		return append(buf, v...)
+	case float64:
+		buf = appendCtrl(buf, 3, 8)
+		b := make([]byte, 8)
+		binary.BigEndian.PutUint64(b, math.Float64bits(v))
+		return append(buf, b...)
+	case []byte:
+		buf = appendCtrl(buf, 4, len(v))
+		return append(buf, v...)
+	case uint16:
+		return appendUint(buf, 5, uint64(v))
+	case uint32:
+		return appendUint(buf, 6, uint64(v))
+	case int:
+		return appendUint(buf, 6, uint64(v))
+	case uint64:
+		return appendUint(buf, 9, v)
+	case bool:
+		size := 0
+		if v {
+			size = 1
+		}
+		return appendCtrl(buf, 14, size)
+	case []interface{}:
+		buf = appendCtrl(buf, 11, len(v))
+		for _, item := range v {
+			buf = encode(buf, item)
+		}
+		return buf
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		buf = appendCtrl(buf, 7, len(v))
+		for _, k := range keys {
+			buf = encode(buf, k)
+			buf = encode(buf, v[k])
+		}
+		return buf
+	default:
+		panic(fmt.Sprintf("unsupported type %T", v))
+	}
+}
+
+func appendUint(buf []byte, typeNum int, v uint64) []byte {
+	var b []byte
+	for ; v > 0; v >>= 8 {
+		b = append([]byte{byte(v)}, b...)
+	}
+	buf = appendCtrl(buf, typeNum, len(b))
+	return append(buf, b...)
+}
+
+func appendCtrl(buf []byte, typeNum, size int) []byte {
+	first := typeNum
+	if typeNum > 7 {
+		first = 0
+	}
+
+	var extra []byte
+	switch {
+	case size < 29:
+	case size < 285:
+		extra = []byte{byte(size - 29)}
+		size = 29
+	case size < 65821:
+		s := size - 285
+		extra = []byte{byte(s >> 8), byte(s)}
+		size = 30
+	default:
+		s := size - 65821
+		extra = []byte{byte(s >> 16), byte(s >> 8), byte(s)}
+		size = 31
+	}
+
+	buf = append(buf, byte(first<<5|size))
+	if typeNum > 7 {
+		buf = append(buf, byte(typeNum-7))
+	}
+	return append(buf, extra...)
+}
@@ -0,0 +1,238 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package geoip implements a reader for MaxMind DB (.mmdb) files, the format
+// used by the GeoIP2 and GeoLite2 databases.
+package geoip
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+
+	"github.com/pkg/errors"
+)
+
+// metadataStartMarker separates the data section from the metadata section.
+var metadataStartMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+// dataSectionSeparatorSize is the number of zero bytes between the search
+// tree and the data section.
+const dataSectionSeparatorSize = 16
+
+// Metadata describes a MaxMind database.
+type Metadata struct {
+	NodeCount    uint
+	RecordSize   uint
+	IPVersion    uint
+	DatabaseType string
+	Languages    []string
+	BuildEpoch   uint64
+	Description  map[string]string
+}
+
+// Reader looks up IP addresses in a MaxMind database loaded into memory.
+// A Reader is safe for concurrent use.
+type Reader struct {
+	meta      Metadata
+	tree      []byte
+	data      decoder
+	nodeSize  uint
+	ipv4Start uint
+}
+
+// Open reads the MaxMind database at path.
+func Open(path string) (*Reader, error) {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read GeoIP database")
+	}
+
+	r, err := FromBytes(buf)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid GeoIP database %v", path)
+	}
+	return r, nil
+}
+
+// FromBytes creates a Reader from the contents of a MaxMind database.
+func FromBytes(buf []byte) (*Reader, error) {
+	idx := bytes.LastIndex(buf, metadataStartMarker)
+	if idx < 0 {
+		return nil, errors.New("metadata section not found")
+	}
+	metaStart := idx + len(metadataStartMarker)
+
+	meta, err := decodeMetadata(buf[metaStart:])
+	if err != nil {
+		return nil, err
+	}
+
+	switch meta.RecordSize {
+	case 24, 28, 32:
+	default:
+		return nil, errors.Errorf("unsupported record size %v", meta.RecordSize)
+	}
+	if meta.IPVersion != 4 && meta.IPVersion != 6 {
+		return nil, errors.Errorf("unsupported IP version %v", meta.IPVersion)
+	}
+
+	nodeSize := meta.RecordSize / 4
+	treeSize := meta.NodeCount * nodeSize
+	dataStart := treeSize + dataSectionSeparatorSize
+	if dataStart > uint(idx) {
+		return nil, errors.New("search tree exceeds database size")
+	}
+
+	r := &Reader{
+		meta:     meta,
+		tree:     buf[:treeSize],
+		data:     decoder{buf: buf[dataStart:idx]},
+		nodeSize: nodeSize,
+	}
+
+	// IPv4 addresses are stored in the ::/96 subtree of IPv6 databases.
+	if meta.IPVersion == 6 {
+		node := uint(0)
+		for i := 0; i < 96 && node < meta.NodeCount; i++ {
+			node = r.readNode(node, 0)
+		}
+		r.ipv4Start = node
+	}
+
+	return r, nil
+}
+
+// Metadata returns the metadata of the database.
+func (r *Reader) Metadata() Metadata {
+	return r.meta
+}
+
+// Lookup returns the raw record stored for ip. The returned boolean is false
+// if the database does not contain ip.
+func (r *Reader) Lookup(ip net.IP) (interface{}, bool, error) {
+	offset, found, err := r.lookupOffset(ip)
+	if err != nil || !found {
+		return nil, false, err
+	}
+
+	v, _, err := r.data.decode(offset)
+	if err != nil {
+		return nil, false, errors.Wrapf(err, "failed to decode record for %v", ip)
+	}
+	return v, true, nil
+}
+
+func (r *Reader) lookupOffset(ip net.IP) (uint, bool, error) {
+	var (
+		addr []byte
+		node uint
+	)
+
+	if ipv4 := ip.To4(); ipv4 != nil {
+		addr = ipv4
+		node = r.ipv4Start
+	} else if ipv6 := ip.To16(); ipv6 != nil {
+		if r.meta.IPVersion == 4 {
+			return 0, false, errors.Errorf("cannot look up IPv6 address %v in IPv4-only database", ip)
+		}
+		addr = ipv6
+	} else {
+		return 0, false, errors.Errorf("invalid IP address %v", ip)
+	}
+
+	nodeCount := r.meta.NodeCount
+	for i := uint(0); i < uint(len(addr))*8 && node < nodeCount; i++ {
+		bit := uint(addr[i>>3]>>(7-(i&7))) & 1
+		node = r.readNode(node, bit)
+	}
+
+	switch {
+	case node == nodeCount:
+		return 0, false, nil
+	case node > nodeCount:
+		offset := node - nodeCount - dataSectionSeparatorSize
+		if offset >= uint(len(r.data.buf)) {
+			return 0, false, errors.New("invalid record offset in search tree")
+		}
+		return offset, true, nil
+	default:
+		return 0, false, errors.New("invalid node in search tree")
+	}
+}
+
+func (r *Reader) readNode(node, bit uint) uint {
+	b := r.tree[node*r.nodeSize : (node+1)*r.nodeSize]
+	switch r.meta.RecordSize {
+	case 24:
+		return uint(readUint(b[bit*3 : bit*3+3]))
+	case 28:
+		if bit == 0 {
+			return uint(b[3]&0xF0)<<20 | uint(readUint(b[:3]))
+		}
+		return uint(b[3]&0x0F)<<24 | uint(readUint(b[4:7]))
+	default:
+		return uint(readUint(b[bit*4 : bit*4+4]))
+	}
+}
+
+func decodeMetadata(buf []byte) (Metadata, error) {
+	d := decoder{buf: buf}
+	v, _, err := d.decode(0)
+	if err != nil {
+		return Metadata{}, errors.Wrap(err, "failed to decode metadata")
+	}
+
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return Metadata{}, errors.New("metadata is not a map")
+	}
+
+	meta := Metadata{
+		NodeCount:   uint(getUint(m, "node_count")),
+		RecordSize:  uint(getUint(m, "record_size")),
+		IPVersion:   uint(getUint(m, "ip_version")),
+		BuildEpoch:  getUint(m, "build_epoch"),
+		Description: map[string]string{},
+	}
+	meta.DatabaseType, _ = m["database_type"].(string)
+
+	if langs, ok := m["languages"].([]interface{}); ok {
+		for _, l := range langs {
+			if s, ok := l.(string); ok {
+				meta.Languages = append(meta.Languages, s)
+			}
+		}
+	}
+	if desc, ok := m["description"].(map[string]interface{}); ok {
+		for lang, d := range desc {
+			if s, ok := d.(string); ok {
+				meta.Description[lang] = s
+			}
+		}
+	}
+
+	if major := getUint(m, "binary_format_major_version"); major != 2 {
+		return Metadata{}, errors.Errorf("unsupported binary format version %v", major)
+	}
+	return meta, nil
+}
+
+func getUint(m map[string]interface{}, key string) uint64 {
+	v, _ := m[key].(uint64)
+	return v
+}
@@ -0,0 +1,244 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package geoip
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/common/geoip/geoiptest"
+)
+
+var testNetworks = []geoiptest.Network{
+	{
+		CIDR: "81.2.69.0/24",
+		Record: map[string]interface{}{
+			"continent": map[string]interface{}{
+				"code":  "EU",
+				"names": map[string]interface{}{"en": "Europe"},
+			},
+			"country": map[string]interface{}{
+				"iso_code": "GB",
+				"names":    map[string]interface{}{"en": "United Kingdom"},
+			},
+			"subdivisions": []interface{}{
+				map[string]interface{}{
+					"iso_code": "ENG",
+					"names":    map[string]interface{}{"en": "England"},
+				},
+			},
+			"city": map[string]interface{}{
+				"names": map[string]interface{}{"en": "London"},
+			},
+			"postal": map[string]interface{}{"code": "SW1"},
+			"location": map[string]interface{}{
+				"latitude":  51.5142,
+				"longitude": -0.0931,
+				"time_zone": "Europe/London",
+			},
+		},
+	},
+	{
+		CIDR: "2001:db8::/32",
+		Record: map[string]interface{}{
+			"country": map[string]interface{}{
+				"iso_code": "SE",
+			},
+		},
+	},
+}
+
+func TestReaderRecordSizes(t *testing.T) {
+	for _, size := range []int{24, 28, 32} {
+		r := newTestReader(t, geoiptest.Database{RecordSize: size, Networks: testNetworks})
+
+		assert.EqualValues(t, size, r.Metadata().RecordSize)
+		assert.EqualValues(t, 6, r.Metadata().IPVersion)
+		assert.Equal(t, "GeoIP2-City", r.Metadata().DatabaseType)
+
+		v, found, err := r.Lookup(net.ParseIP("81.2.69.142"))
+		if assert.NoError(t, err) && assert.True(t, found, "record size %v", size) {
+			assert.Equal(t, "GB", v.(map[string]interface{})["country"].(map[string]interface{})["iso_code"])
+		}
+
+		v, found, err = r.Lookup(net.ParseIP("2001:db8::1"))
+		if assert.NoError(t, err) && assert.True(t, found, "record size %v", size) {
+			assert.Equal(t, "SE", v.(map[string]interface{})["country"].(map[string]interface{})["iso_code"])
+		}
+
+		_, found, err = r.Lookup(net.ParseIP("81.2.70.1"))
+		assert.NoError(t, err)
+		assert.False(t, found)
+	}
+}
+
+func TestReaderIPv4Database(t *testing.T) {
+	r := newTestReader(t, geoiptest.Database{IPVersion: 4, Networks: testNetworks[:1]})
+
+	_, found, err := r.Lookup(net.ParseIP("81.2.69.1"))
+	assert.NoError(t, err)
+	assert.True(t, found)
+
+	_, _, err = r.Lookup(net.ParseIP("2001:db8::1"))
+	assert.Error(t, err)
+}
+
+func TestReaderCity(t *testing.T) {
+	r := newTestReader(t, geoiptest.Database{Networks: testNetworks})
+
+	city, err := r.City(net.ParseIP("81.2.69.142"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, &City{
+			ContinentCode:  "EU",
+			ContinentName:  "Europe",
+			CountryISOCode: "GB",
+			CountryName:    "United Kingdom",
+			RegionISOCode:  "ENG",
+			RegionName:     "England",
+			CityName:       "London",
+			PostalCode:     "SW1",
+			TimeZone:       "Europe/London",
+			Location:       &Location{Lat: 51.5142, Lon: -0.0931},
+		}, city)
+	}
+
+	city, err = r.City(net.ParseIP("2001:db8::1"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, &City{CountryISOCode: "SE"}, city)
+	}
+
+	city, err = r.City(net.ParseIP("10.0.0.1"))
+	assert.NoError(t, err)
+	assert.Nil(t, city)
+}
+
+func TestReaderASN(t *testing.T) {
+	r := newTestReader(t, geoiptest.Database{
+		DatabaseType: "GeoLite2-ASN",
+		Networks: []geoiptest.Network{
+			{
+				CIDR: "81.2.69.0/24",
+				Record: map[string]interface{}{
+					"autonomous_system_number":       uint32(20712),
+					"autonomous_system_organization": "Andrews & Arnold Ltd",
+				},
+			},
+		},
+	})
+
+	asn, err := r.ASN(net.ParseIP("81.2.69.142"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, &ASN{Number: 20712, Organization: "Andrews & Arnold Ltd"}, asn)
+	}
+
+	asn, err = r.ASN(net.ParseIP("10.0.0.1"))
+	assert.NoError(t, err)
+	assert.Nil(t, asn)
+}
+
+func TestReaderInvalidDatabase(t *testing.T) {
+	_, err := FromBytes([]byte("not a database"))
+	assert.Error(t, err)
+
+	buf, err := geoiptest.Database{Networks: testNetworks}.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = FromBytes(buf[len(buf)/2:])
+	assert.Error(t, err)
+}
+
+func TestDecoderPointer(t *testing.T) {
+	d := decoder{buf: []byte{
+		0x43, 'f', 'o', 'o', // offset 0: string "foo"
+		0xe2,       // offset 4: map with 2 entries
+		0x20, 0x00, //         key: pointer to offset 0
+		0x43, 'b', 'a', 'r', //  value: string "bar"
+		0x43, 'b', 'a', 'z', //  key: string "baz"
+		0x20, 0x00, //         value: pointer to offset 0
+	}}
+
+	v, next, err := d.decode(4)
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]interface{}{"foo": "bar", "baz": "foo"}, v)
+		assert.EqualValues(t, len(d.buf), next)
+	}
+}
+
+func TestDecoderTypes(t *testing.T) {
+	long := strings.Repeat("x", 300)
+	tests := []struct {
+		buf      []byte
+		expected interface{}
+	}{
+		{[]byte{0xa2, 0x01, 0x02}, uint64(0x0102)},                      // uint16
+		{[]byte{0xc1, 0xff}, uint64(0xff)},                              // uint32
+		{[]byte{0x04, 0x01, 0xff, 0xff, 0xff, 0xff}, int32(-1)},         // int32
+		{[]byte{0x01, 0x02, 0x01}, uint64(1)},                           // uint64
+		{[]byte{0x01, 0x07}, true},                                      // bool
+		{[]byte{0x00, 0x07}, false},                                     // bool
+		{[]byte{0x68, 0x3f, 0xf0, 0, 0, 0, 0, 0, 0}, 1.0},               // double
+		{[]byte{0x01, 0x04, 0x43, 'a', 'b', 'c'}, []interface{}{"abc"}}, // array
+		{append([]byte{0x5e, 0x00, 0x0f}, long...), long},               // string with 2 byte size
+	}
+
+	for i, test := range tests {
+		d := decoder{buf: test.buf}
+		v, _, err := d.decode(0)
+		if assert.NoError(t, err, "test %v", i) {
+			assert.Equal(t, test.expected, v, "test %v", i)
+		}
+	}
+
+	d := decoder{buf: []byte{0x45, 'a'}}
+	_, _, err := d.decode(0)
+	assert.Error(t, err)
+}
+
+func TestDecoderInvalidContainers(t *testing.T) {
+	tests := map[string][]byte{
+		// array of 65821+0xffffff entries, without the data
+		"oversized array": {0x1f, 0x04, 0xff, 0xff, 0xff},
+		// map of 29+0xff entries, without the data
+		"oversized map": {0xfd, 0xff, 0x43, 'f', 'o', 'o'},
+		// map whose value is a pointer to the map itself
+		"pointer cycle": {0xe1, 0x41, 'a', 0x20, 0x00},
+	}
+
+	for name, buf := range tests {
+		d := decoder{buf: buf}
+		_, _, err := d.decode(0)
+		assert.Error(t, err, name)
+	}
+}
+
+func newTestReader(t *testing.T, db geoiptest.Database) *Reader {
+	buf, err := db.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := FromBytes(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
@@ -0,0 +1,96 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package jsontransform
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+// DecodeEvent reads the next JSON document from dec and replaces the fields
+// of event with it. The @timestamp and @metadata keys are moved to the event
+// timestamp and metadata, nested objects are converted to common.MapStr and
+// numbers to int64, float64 or string, like TransformNumbers does. The event
+// is not modified if the document is invalid. It returns false if the
+// document is null.
+func DecodeEvent(dec *json.Decoder, event *beat.Event) (bool, error) {
+	dec.UseNumber()
+
+	var doc map[string]interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return false, err
+	}
+	if doc == nil {
+		return false, nil
+	}
+
+	fields := transformValue(doc).(common.MapStr)
+	timestamp := event.Timestamp
+	meta := event.Meta
+
+	if v, found := fields["@timestamp"]; found {
+		delete(fields, "@timestamp")
+		s, ok := v.(string)
+		if !ok {
+			return false, errors.Errorf("unexpected @timestamp type %T", v)
+		}
+		ts, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to parse @timestamp")
+		}
+		timestamp = ts.UTC()
+	}
+
+	if v, found := fields["@metadata"]; found {
+		delete(fields, "@metadata")
+		m, ok := v.(common.MapStr)
+		if !ok {
+			return false, errors.Errorf("unexpected @metadata type %T", v)
+		}
+		meta = m
+	}
+
+	event.Timestamp = timestamp
+	event.Meta = meta
+	event.Fields = fields
+	return true, nil
+}
+
+func transformValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		m := make(common.MapStr, len(v))
+		for k, val := range v {
+			m[k] = transformValue(val)
+		}
+		return m
+	case []interface{}:
+		for i, val := range v {
+			v[i] = transformValue(val)
+		}
+		return v
+	case json.Number:
+		return transformNumber(v)
+	}
+	return v
+}
@@ -0,0 +1,107 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package jsontransform
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestDecodeEvent(t *testing.T) {
+	now := time.Date(2018, 9, 10, 11, 12, 13, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		input    string
+		ok       bool
+		err      bool
+		expected beat.Event
+	}{
+		{
+			name:  "fields",
+			input: `{"count":3,"ratio":0.5,"big":18446744073709551616,"nested":{"list":[1,{"a":"b"}]}}`,
+			ok:    true,
+			expected: beat.Event{
+				Timestamp: now,
+				Fields: common.MapStr{
+					"count":  int64(3),
+					"ratio":  0.5,
+					"big":    1.8446744073709552e19,
+					"nested": common.MapStr{"list": []interface{}{int64(1), common.MapStr{"a": "b"}}},
+				},
+			},
+		},
+		{
+			name:  "timestamp and metadata",
+			input: `{"@timestamp":"2018-09-10T13:12:13.5+02:00","@metadata":{"pipeline":"test"},"message":"hello"}`,
+			ok:    true,
+			expected: beat.Event{
+				Timestamp: time.Date(2018, 9, 10, 11, 12, 13, 5e8, time.UTC),
+				Meta:      common.MapStr{"pipeline": "test"},
+				Fields:    common.MapStr{"message": "hello"},
+			},
+		},
+		{
+			name:     "null",
+			input:    `null`,
+			expected: beat.Event{Timestamp: now, Fields: common.MapStr{"original": true}},
+		},
+		{
+			name:     "invalid timestamp",
+			input:    `{"@timestamp":"yesterday","message":"hello"}`,
+			err:      true,
+			expected: beat.Event{Timestamp: now, Fields: common.MapStr{"original": true}},
+		},
+		{
+			name:     "invalid metadata",
+			input:    `{"@metadata":[1],"message":"hello"}`,
+			err:      true,
+			expected: beat.Event{Timestamp: now, Fields: common.MapStr{"original": true}},
+		},
+		{
+			name:     "invalid JSON",
+			input:    `{"message":`,
+			err:      true,
+			expected: beat.Event{Timestamp: now, Fields: common.MapStr{"original": true}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			event := beat.Event{Timestamp: now, Fields: common.MapStr{"original": true}}
+
+			ok, err := DecodeEvent(json.NewDecoder(strings.NewReader(test.input)), &event)
+			if test.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.expected, event)
+		})
+	}
+}
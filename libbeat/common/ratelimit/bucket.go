@@ -0,0 +1,83 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package ratelimit provides a token bucket used to limit the rate of events.
+package ratelimit
+
+import (
+	"math"
+	"time"
+)
+
+// Bucket is a token bucket refilled at a constant rate up to its size. It is
+// not safe for concurrent use.
+type Bucket struct {
+	rate   float64
+	size   float64
+	tokens float64
+	last   time.Time
+}
+
+// NewBucket returns a full bucket refilled with rate tokens per second. If
+// size is 0, the bucket holds one second worth of tokens, and at least one.
+func NewBucket(rate, size float64) *Bucket {
+	if size <= 0 {
+		size = math.Max(1, math.Ceil(rate))
+	}
+	return &Bucket{rate: rate, size: size, tokens: size}
+}
+
+// Refill adds the tokens accumulated since the last refill. The first refill
+// only records the time, as new buckets start full.
+func (b *Bucket) Refill(now time.Time) {
+	if b.last.IsZero() {
+		b.last = now
+		return
+	}
+	elapsed := now.Sub(b.last)
+	if elapsed <= 0 {
+		return
+	}
+	b.last = now
+	b.tokens = math.Min(b.size, b.tokens+elapsed.Seconds()*b.rate)
+}
+
+// Tokens returns the number of tokens in the bucket. It is negative if tokens
+// have been borrowed.
+func (b *Bucket) Tokens() float64 {
+	return b.tokens
+}
+
+// Full reports whether the bucket holds as many tokens as its size.
+func (b *Bucket) Full() bool {
+	return b.tokens >= b.size
+}
+
+// Take takes a token from the bucket. If the bucket is empty, the token is
+// borrowed from the future.
+func (b *Bucket) Take() {
+	b.tokens--
+}
+
+// Delay returns the time until the borrowed tokens are paid back, 0 if no
+// token is borrowed.
+func (b *Bucket) Delay() time.Duration {
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
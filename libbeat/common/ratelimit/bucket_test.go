@@ -0,0 +1,70 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBucketSize(t *testing.T) {
+	assert.EqualValues(t, 3, NewBucket(2.5, 0).Tokens())
+	assert.EqualValues(t, 1, NewBucket(0.1, 0).Tokens())
+	assert.EqualValues(t, 5, NewBucket(2, 5).Tokens())
+}
+
+func TestBucketRefill(t *testing.T) {
+	now := time.Now()
+	b := NewBucket(2, 4)
+	b.Refill(now)
+	assert.True(t, b.Full())
+
+	for i := 0; i < 4; i++ {
+		b.Take()
+	}
+	assert.EqualValues(t, 0, b.Tokens())
+	assert.False(t, b.Full())
+
+	b.Refill(now.Add(time.Second))
+	assert.EqualValues(t, 2, b.Tokens())
+
+	// time going backwards is ignored
+	b.Refill(now)
+	assert.EqualValues(t, 2, b.Tokens())
+
+	// the bucket doesn't fill beyond its size
+	b.Refill(now.Add(time.Minute))
+	assert.EqualValues(t, 4, b.Tokens())
+	assert.True(t, b.Full())
+}
+
+func TestBucketDelay(t *testing.T) {
+	b := NewBucket(10, 1)
+	b.Refill(time.Now())
+	b.Take()
+	assert.Equal(t, time.Duration(0), b.Delay())
+
+	b.Take()
+	b.Take()
+	assert.EqualValues(t, -2, b.Tokens())
+	assert.Equal(t, 200*time.Millisecond, b.Delay())
+}
@@ -114,8 +114,11 @@ func init() {
 					"sendmsg",
 					"sendto",
 					"set_robust_list",
+					"setgid",
+					"setgroups",
 					"setitimer",
 					"setsockopt",
+					"setuid",
 					"shutdown",
 					"sigaltstack",
 					"socket",
@@ -65,6 +65,10 @@ func MustRegisterPolicy(p *seccomp.Policy) {
 // - Policy values from config
 // - Application registered policy
 // - Default policy (a simple blacklist)
+//
+// If seccomp.audit is set, the system calls that the policy denies are only
+// logged by the kernel, so that a policy can be rolled out without breaking
+// the Beat.
 func LoadFilter(c *common.Config) error {
 	// Bail out if seccomp.enabled=false.
 	if c != nil && !c.Enabled() {
@@ -132,5 +136,37 @@ func getPolicy(c *common.Config) (*seccomp.Policy, error) {
 		}
 	}
 
+	if c != nil && policy != nil {
+		settings := struct {
+			Audit bool `config:"audit"`
+		}{}
+		if err := c.Unpack(&settings); err != nil {
+			return nil, err
+		}
+		if settings.Audit {
+			policy = auditPolicy(policy)
+		}
+	}
+
 	return policy, nil
 }
+
+// auditPolicy returns a copy of p that logs the system calls instead of
+// denying them. The allowed system calls are unchanged.
+func auditPolicy(p *seccomp.Policy) *seccomp.Policy {
+	audit := &seccomp.Policy{
+		DefaultAction: auditAction(p.DefaultAction),
+	}
+	for _, group := range p.Syscalls {
+		group.Action = auditAction(group.Action)
+		audit.Syscalls = append(audit.Syscalls, group)
+	}
+	return audit
+}
+
+func auditAction(action seccomp.Action) seccomp.Action {
+	if action == seccomp.ActionAllow {
+		return action
+	}
+	return seccomp.ActionLog
+}
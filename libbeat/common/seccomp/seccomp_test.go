@@ -0,0 +1,76 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package seccomp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/go-seccomp-bpf"
+)
+
+func TestAuditPolicy(t *testing.T) {
+	policy := &seccomp.Policy{
+		DefaultAction: seccomp.ActionErrno,
+		Syscalls: []seccomp.SyscallGroup{
+			{Action: seccomp.ActionAllow, Names: []string{"read", "write"}},
+			{Action: seccomp.ActionKillProcess, Names: []string{"execve"}},
+		},
+	}
+
+	audit := auditPolicy(policy)
+	assert.Equal(t, &seccomp.Policy{
+		DefaultAction: seccomp.ActionLog,
+		Syscalls: []seccomp.SyscallGroup{
+			{Action: seccomp.ActionAllow, Names: []string{"read", "write"}},
+			{Action: seccomp.ActionLog, Names: []string{"execve"}},
+		},
+	}, audit)
+
+	// the audited policy is not modified
+	assert.Equal(t, seccomp.ActionErrno, policy.DefaultAction)
+	assert.Equal(t, seccomp.ActionKillProcess, policy.Syscalls[1].Action)
+}
+
+func TestGetPolicyAudit(t *testing.T) {
+	previous := registeredPolicy
+	defer func() { registeredPolicy = previous }()
+	registeredPolicy = &seccomp.Policy{
+		DefaultAction: seccomp.ActionErrno,
+		Syscalls: []seccomp.SyscallGroup{
+			{Action: seccomp.ActionAllow, Names: []string{"read"}},
+		},
+	}
+
+	policy, err := getPolicy(common.MustNewConfigFrom(map[string]interface{}{
+		"audit": true,
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, seccomp.ActionLog, policy.DefaultAction)
+	assert.Equal(t, seccomp.ActionAllow, policy.Syscalls[0].Action)
+	assert.Equal(t, seccomp.ActionErrno, registeredPolicy.DefaultAction)
+
+	policy, err = getPolicy(common.MustNewConfigFrom(map[string]interface{}{}))
+	require.NoError(t, err)
+	assert.Equal(t, seccomp.ActionErrno, policy.DefaultAction)
+}
@@ -23,4 +23,5 @@ const (
 	ERROR_STATUS        = "Error"
 	SERVER_ERROR_STATUS = "Server Error"
 	CLIENT_ERROR_STATUS = "Client Error"
+	TIMEOUT_STATUS      = "Timeout"
 )
@@ -0,0 +1,110 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package kerberos authenticates HTTP requests with SPNEGO, using the Kerberos
+// client of gokrb5.
+package kerberos
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/jcmturner/gokrb5/v8/client"
+	krbconfig "github.com/jcmturner/gokrb5/v8/config"
+	"github.com/jcmturner/gokrb5/v8/credentials"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+	"github.com/jcmturner/gokrb5/v8/spnego"
+	"github.com/pkg/errors"
+)
+
+// Client sets the SPNEGO Authorization header of HTTP requests.
+//
+// The Kerberos client logs in and requests the service ticket of the server
+// with the first request, and caches both for the later requests. With a
+// keytab, the tickets are renewed in the background before they expire.
+type Client struct {
+	spn    string
+	create func() (*client.Client, error)
+
+	mu   sync.Mutex
+	krb5 *client.Client
+}
+
+// NewClient loads the Kerberos configuration and credentials of config. The
+// service principal name defaults to spn if config.ServiceName is not set.
+func NewClient(config *Config, spn string) (*Client, error) {
+	krb5conf, err := krbconfig.Load(config.ConfigPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load the kerberos configuration")
+	}
+
+	var create func() (*client.Client, error)
+	switch config.AuthType {
+	case "", AuthKeytab:
+		kt, err := keytab.Load(config.KeytabPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load the kerberos keytab")
+		}
+		create = func() (*client.Client, error) {
+			return client.NewWithKeytab(config.Username, config.Realm, kt, krb5conf), nil
+		}
+	case AuthCCache:
+		cc, err := credentials.LoadCCache(config.CCachePath)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load the kerberos credential cache")
+		}
+		create = func() (*client.Client, error) {
+			return client.NewFromCCache(cc, krb5conf)
+		}
+	default:
+		return nil, errors.Errorf("unknown kerberos auth_type '%v'", config.AuthType)
+	}
+
+	if config.ServiceName != "" {
+		spn = config.ServiceName
+	}
+	return &Client{spn: spn, create: create}, nil
+}
+
+// SetHeader sets the SPNEGO Authorization header of req.
+func (c *Client) SetHeader(req *http.Request) error {
+	c.mu.Lock()
+	if c.krb5 == nil {
+		krb5, err := c.create()
+		if err != nil {
+			c.mu.Unlock()
+			return errors.Wrap(err, "failed to create the kerberos client")
+		}
+		c.krb5 = krb5
+	}
+	krb5 := c.krb5
+	c.mu.Unlock()
+
+	return spnego.SetSPNEGOHeader(krb5, req, c.spn)
+}
+
+// Close destroys the Kerberos sessions, which stops their renewal. The next
+// request creates new sessions.
+func (c *Client) Close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.krb5 != nil {
+		c.krb5.Destroy()
+		c.krb5 = nil
+	}
+}
@@ -0,0 +1,167 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package kerberos
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jcmturner/gokrb5/v8/iana/etypeID"
+	"github.com/jcmturner/gokrb5/v8/keytab"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestConfigValidate(t *testing.T) {
+	tests := map[string]struct {
+		config map[string]interface{}
+		valid  bool
+	}{
+		"disabled": {
+			config: map[string]interface{}{"enabled": false},
+			valid:  true,
+		},
+		"keytab": {
+			config: map[string]interface{}{
+				"config_path": "krb5.conf",
+				"keytab":      "beat.keytab",
+				"username":    "beat",
+				"realm":       "EXAMPLE.COM",
+			},
+			valid: true,
+		},
+		"ccache": {
+			config: map[string]interface{}{
+				"auth_type":   "ccache",
+				"config_path": "krb5.conf",
+				"ccache":      "/tmp/krb5cc",
+			},
+			valid: true,
+		},
+		"missing config_path": {
+			config: map[string]interface{}{
+				"keytab":   "beat.keytab",
+				"username": "beat",
+				"realm":    "EXAMPLE.COM",
+			},
+		},
+		"missing keytab": {
+			config: map[string]interface{}{
+				"config_path": "krb5.conf",
+				"username":    "beat",
+				"realm":       "EXAMPLE.COM",
+			},
+		},
+		"missing ccache": {
+			config: map[string]interface{}{
+				"auth_type":   "ccache",
+				"config_path": "krb5.conf",
+			},
+		},
+		"unknown auth_type": {
+			config: map[string]interface{}{
+				"auth_type":   "password",
+				"config_path": "krb5.conf",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := common.NewConfigFrom(test.config)
+			require.NoError(t, err)
+
+			var config Config
+			err = cfg.Unpack(&config)
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestNewClientMissingFiles(t *testing.T) {
+	_, err := NewClient(&Config{ConfigPath: "missing/krb5.conf"}, "HTTP/localhost")
+	assert.Error(t, err)
+
+	dir := writeTestFiles(t)
+	defer os.RemoveAll(dir)
+
+	_, err = NewClient(&Config{
+		ConfigPath: filepath.Join(dir, "krb5.conf"),
+		KeytabPath: filepath.Join(dir, "missing.keytab"),
+		Username:   "beat",
+		Realm:      "EXAMPLE.COM",
+	}, "HTTP/localhost")
+	assert.Error(t, err)
+}
+
+func TestSetHeaderLoginError(t *testing.T) {
+	dir := writeTestFiles(t)
+	defer os.RemoveAll(dir)
+
+	client, err := NewClient(&Config{
+		ConfigPath: filepath.Join(dir, "krb5.conf"),
+		KeytabPath: filepath.Join(dir, "beat.keytab"),
+		Username:   "beat",
+		Realm:      "EXAMPLE.COM",
+	}, "HTTP/localhost")
+	require.NoError(t, err)
+	defer client.Close()
+
+	// No KDC listens on the configured address, so logging in fails.
+	req, err := http.NewRequest("GET", "http://localhost:9200/", nil)
+	require.NoError(t, err)
+	assert.Error(t, client.SetHeader(req))
+	assert.Empty(t, req.Header.Get("Authorization"))
+}
+
+func writeTestFiles(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "kerberos")
+	require.NoError(t, err)
+
+	krb5conf := `[libdefaults]
+  default_realm = EXAMPLE.COM
+  dns_lookup_kdc = false
+  udp_preference_limit = 1
+
+[realms]
+  EXAMPLE.COM = {
+    kdc = 127.0.0.1:1
+  }
+`
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "krb5.conf"), []byte(krb5conf), 0600))
+
+	kt := keytab.New()
+	require.NoError(t, kt.AddEntry("beat", "EXAMPLE.COM", "secret", time.Now(), 1, etypeID.AES256_CTS_HMAC_SHA1_96))
+	b, err := kt.Marshal()
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "beat.keytab"), b, 0600))
+
+	return dir
+}
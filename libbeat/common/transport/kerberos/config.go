@@ -0,0 +1,72 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package kerberos
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Authentication types.
+const (
+	AuthKeytab = "keytab" // Log in with the keys of a keytab file.
+	AuthCCache = "ccache" // Use the tickets of a credential cache.
+)
+
+// Config defines the user configurable Kerberos options of a client.
+type Config struct {
+	Enabled     *bool  `config:"enabled"`
+	AuthType    string `config:"auth_type"`    // keytab or ccache, keytab by default.
+	ConfigPath  string `config:"config_path"`  // Path of the krb5.conf file.
+	KeytabPath  string `config:"keytab"`       // Path of the keytab file.
+	CCachePath  string `config:"ccache"`       // Path of the credential cache file.
+	Username    string `config:"username"`     // Principal name logging in with the keytab.
+	Realm       string `config:"realm"`        // Realm of the principal.
+	ServiceName string `config:"service_name"` // Principal name of the server.
+}
+
+// Validate checks that the settings required by the authentication type are
+// set.
+func (c *Config) Validate() error {
+	if !c.IsEnabled() {
+		return nil
+	}
+
+	if c.ConfigPath == "" {
+		return errors.New("kerberos config_path must be set")
+	}
+
+	switch c.AuthType {
+	case "", AuthKeytab:
+		if c.KeytabPath == "" || c.Username == "" || c.Realm == "" {
+			return errors.New("kerberos keytab, username and realm must be set with auth_type keytab")
+		}
+	case AuthCCache:
+		if c.CCachePath == "" {
+			return errors.New("kerberos ccache must be set with auth_type ccache")
+		}
+	default:
+		return fmt.Errorf("unknown kerberos auth_type '%v', must be one of %v or %v", c.AuthType, AuthKeytab, AuthCCache)
+	}
+	return nil
+}
+
+// IsEnabled returns true if the Kerberos options are set and not disabled.
+func (c *Config) IsEnabled() bool {
+	return c != nil && (c.Enabled == nil || *c.Enabled)
+}
@@ -0,0 +1,76 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package tlscommon
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCAPinning(t *testing.T) {
+	raw, err := ioutil.ReadFile("ca_test.pem")
+	require.NoError(t, err)
+	block, _ := pem.Decode(raw)
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+
+	chains := [][]*x509.Certificate{{cert}}
+	pin := Fingerprint(cert)
+
+	t.Run("matching pin", func(t *testing.T) {
+		callback := MakeCAPinCallback([]string{"bad", pin})
+		assert.NoError(t, callback(nil, chains))
+	})
+
+	t.Run("no matching pin", func(t *testing.T) {
+		callback := MakeCAPinCallback([]string{"bad"})
+		assert.Equal(t, ErrCAPinMissmatch, callback(nil, chains))
+	})
+
+	t.Run("no verified chain", func(t *testing.T) {
+		callback := MakeCAPinCallback([]string{pin})
+		assert.Equal(t, ErrCAPinMissmatch, callback(nil, nil))
+	})
+}
+
+func TestCAPinningConfig(t *testing.T) {
+	cfg, err := load(`
+    ca_sha256: ["hS2Zfe9JnjlpX8GTsdOkxhlKHuDXkS0zFAlSYHxkybM="]
+  `)
+	require.NoError(t, err)
+	tlsC, err := LoadTLSConfig(cfg)
+	require.NoError(t, err)
+	assert.NotNil(t, tlsC.BuildModuleConfig("localhost").VerifyPeerCertificate)
+
+	_, err = load(`ca_sha256: ["not a pin"]`)
+	assert.Error(t, err)
+
+	_, err = load(`
+    verification_mode: none
+    ca_sha256: ["hS2Zfe9JnjlpX8GTsdOkxhlKHuDXkS0zFAlSYHxkybM="]
+  `)
+	assert.Error(t, err)
+}
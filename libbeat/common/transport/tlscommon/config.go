@@ -19,6 +19,7 @@ package tlscommon
 
 import (
 	"crypto/tls"
+	"errors"
 
 	"github.com/joeshaw/multierror"
 )
@@ -33,6 +34,7 @@ type Config struct {
 	Certificate      CertificateConfig       `config:",inline"`
 	CurveTypes       []tlsCurveType          `config:"curve_types"`
 	Renegotiation    tlsRenegotiationSupport `config:"renegotiation"`
+	CASha256         []string                `config:"ca_sha256"`
 }
 
 // LoadTLSConfig will load a certificate from config with all TLS based keys
@@ -88,12 +90,19 @@ func LoadTLSConfig(config *Config) (*TLSConfig, error) {
 		CipherSuites:     cipherSuites,
 		CurvePreferences: curves,
 		Renegotiation:    tls.RenegotiationSupport(config.Renegotiation),
+		CASha256:         config.CASha256,
 	}, nil
 }
 
 // Validate values the TLSConfig struct making sure certificate sure we have both a certificate and
 // a key.
 func (c *Config) Validate() error {
+	if err := validateCAPins(c.CASha256); err != nil {
+		return err
+	}
+	if len(c.CASha256) > 0 && c.VerificationMode == VerifyNone {
+		return errors.New("ca_sha256 can not be used with verification_mode none")
+	}
 	return c.Certificate.Validate()
 }
 
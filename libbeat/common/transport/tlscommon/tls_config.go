@@ -64,6 +64,11 @@ type TLSConfig struct {
 	// ClientAuth controls how we want to verify certificate from a client, `none`, `optional` and
 	// `required`, default to required. Do not affect TCP client.
 	ClientAuth tls.ClientAuthType
+
+	// CASha256 is a list of base64 encoded SHA-256 pins of the public keys of
+	// certificates. If set, one of the certificates of the verified chain must
+	// match one of the pins.
+	CASha256 []string
 }
 
 // BuildModuleConfig takes the TLSConfig and transform it into a `tls.Config`.
@@ -79,6 +84,11 @@ func (c *TLSConfig) BuildModuleConfig(host string) *tls.Config {
 		logp.Warn("SSL/TLS verifications disabled.")
 	}
 
+	var verifyPeerCertificate verifyPeerCertFunc
+	if len(c.CASha256) > 0 {
+		verifyPeerCertificate = MakeCAPinCallback(c.CASha256)
+	}
+
 	return &tls.Config{
 		ServerName:         host,
 		MinVersion:         minVersion,
@@ -90,5 +100,7 @@ func (c *TLSConfig) BuildModuleConfig(host string) *tls.Config {
 		CipherSuites:       c.CipherSuites,
 		CurvePreferences:   c.CurvePreferences,
 		ClientAuth:         c.ClientAuth,
+
+		VerifyPeerCertificate: verifyPeerCertificate,
 	}
 }
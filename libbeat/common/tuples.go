@@ -162,6 +162,8 @@ type CmdlineTuple struct {
 	Src, Dst []byte
 	// Source and destination full command lines
 	SrcCommand, DstCommand []byte
+	// Source and destination process IDs, 0 if unknown
+	SrcPID, DstPID int
 }
 
 // Reverse returns a copy of the receiver with the source and destination fields
@@ -172,5 +174,7 @@ func (c *CmdlineTuple) Reverse() CmdlineTuple {
 		Dst:        c.Src,
 		SrcCommand: c.DstCommand,
 		DstCommand: c.SrcCommand,
+		SrcPID:     c.DstPID,
+		DstPID:     c.SrcPID,
 	}
 }
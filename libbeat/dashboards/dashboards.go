@@ -75,6 +75,16 @@ func ImportDashboards(
 		if !kibanaConfig.HasField("password") && password != "" {
 			kibanaConfig.SetString("password", -1, password)
 		}
+
+		// An API key or token is only inherited when no other credentials
+		// are set for Kibana, as they can't be combined.
+		if !kibanaConfig.HasField("username") && !kibanaConfig.HasField("api_key") && !kibanaConfig.HasField("bearer_token") {
+			for _, name := range []string{"api_key", "bearer_token"} {
+				if value, _ := esConfig.String(name, -1); value != "" {
+					kibanaConfig.SetString(name, -1, value)
+				}
+			}
+		}
 	}
 
 	var esLoader *ElasticsearchLoader
@@ -19,6 +19,7 @@ package kibana
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -36,10 +37,12 @@ import (
 )
 
 type Connection struct {
-	URL      string
-	Username string
-	Password string
-	Headers  map[string]string
+	URL         string
+	Username    string
+	Password    string
+	APIKey      string
+	BearerToken string
+	Headers     map[string]string
 
 	http    *http.Client
 	version string
@@ -127,9 +130,11 @@ func NewClientWithConfig(config *ClientConfig) (*Client, error) {
 
 	client := &Client{
 		Connection: Connection{
-			URL:      kibanaURL,
-			Username: username,
-			Password: password,
+			URL:         kibanaURL,
+			Username:    username,
+			Password:    password,
+			APIKey:      config.APIKey,
+			BearerToken: config.BearerToken,
 			http: &http.Client{
 				Transport: &http.Transport{
 					Dial:    dialer.Dial,
@@ -160,6 +165,12 @@ func (conn *Connection) Request(method, extraPath string,
 	if conn.Username != "" || conn.Password != "" {
 		req.SetBasicAuth(conn.Username, conn.Password)
 	}
+	if conn.APIKey != "" {
+		req.Header.Set("Authorization", "ApiKey "+base64.StdEncoding.EncodeToString([]byte(conn.APIKey)))
+	}
+	if conn.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+conn.BearerToken)
+	}
 
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Add("Accept", "application/json")
@@ -18,6 +18,8 @@
 package kibana
 
 import (
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/elastic/beats/libbeat/common/transport/tlscommon"
@@ -25,13 +27,15 @@ import (
 
 // ClientConfig to connect to Kibana
 type ClientConfig struct {
-	Protocol string            `config:"protocol"`
-	Host     string            `config:"host"`
-	Path     string            `config:"path"`
-	Username string            `config:"username"`
-	Password string            `config:"password"`
-	TLS      *tlscommon.Config `config:"ssl"`
-	Timeout  time.Duration     `config:"timeout"`
+	Protocol    string            `config:"protocol"`
+	Host        string            `config:"host"`
+	Path        string            `config:"path"`
+	Username    string            `config:"username"`
+	Password    string            `config:"password"`
+	APIKey      string            `config:"api_key"`      // API key, formatted as `id:api_key`.
+	BearerToken string            `config:"bearer_token"` // Token sent in the `Authorization: Bearer` header.
+	TLS         *tlscommon.Config `config:"ssl"`
+	Timeout     time.Duration     `config:"timeout"`
 }
 
 var (
@@ -45,3 +49,23 @@ var (
 		TLS:      nil,
 	}
 )
+
+func (c *ClientConfig) Validate() error {
+	n := 0
+	if c.Username != "" || c.Password != "" {
+		n++
+	}
+	if c.APIKey != "" {
+		n++
+	}
+	if c.BearerToken != "" {
+		n++
+	}
+	if n > 1 {
+		return errors.New("only one of username and password, api_key or bearer_token can be configured")
+	}
+	if c.APIKey != "" && !strings.Contains(c.APIKey, ":") {
+		return errors.New("api_key must be formatted as <id>:<api_key>")
+	}
+	return nil
+}
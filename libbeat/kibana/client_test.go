@@ -74,3 +74,21 @@ func TestSuccess(t *testing.T) {
 	assert.Equal(t, http.StatusOK, code)
 	assert.NoError(t, err)
 }
+
+func TestAPIKey(t *testing.T) {
+	kibanaTs := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+
+		assert.Equal(t, "ApiKey aWQ6a2V5", r.Header.Get("Authorization"))
+	}))
+	defer kibanaTs.Close()
+
+	conn := Connection{
+		URL:    kibanaTs.URL,
+		APIKey: "id:key",
+		http:   http.DefaultClient,
+	}
+	code, _, err := conn.Request(http.MethodGet, "", url.Values{}, nil, nil)
+	assert.Equal(t, http.StatusOK, code)
+	assert.NoError(t, err)
+}
@@ -17,6 +17,8 @@
 
 package logp
 
+import "time"
+
 // Config contains the configuration options for the logger. To create a Config
 // from a common.Config use logp/config.Build.
 type Config struct {
@@ -40,11 +42,12 @@ type Config struct {
 
 // FileConfig contains the configuration options for the file output.
 type FileConfig struct {
-	Path        string `config:"path"`
-	Name        string `config:"name"`
-	MaxSize     uint   `config:"rotateeverybytes" validate:"min=1"`
-	MaxBackups  uint   `config:"keepfiles" validate:"max=1024"`
-	Permissions uint32 `config:"permissions"`
+	Path        string        `config:"path"`
+	Name        string        `config:"name"`
+	MaxSize     uint          `config:"rotateeverybytes" validate:"min=1"`
+	MaxBackups  uint          `config:"keepfiles" validate:"max=1024"`
+	Permissions uint32        `config:"permissions"`
+	Interval    time.Duration `config:"interval" validate:"min=0"`
 }
 
 var defaultConfig = Config{
@@ -18,10 +18,16 @@
 package logp
 
 import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -232,3 +238,34 @@ func TestL(t *testing.T) {
 		assert.Equal(t, "warning 1", log.Message)
 	}
 }
+
+func TestJSONFileOutput(t *testing.T) {
+	dir, err := ioutil.TempDir("", "logp")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cfg := DefaultConfig()
+	cfg.Beat = "testbeat"
+	cfg.JSON = true
+	cfg.Files.Path = dir
+	cfg.Files.Interval = time.Hour
+
+	core, err := makeFileOutput(cfg)
+	require.NoError(t, err)
+
+	log := zap.New(core, zap.AddCaller()).Named("example")
+	log.Info("some message", zap.Int("x", 1))
+	require.NoError(t, log.Sync())
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "testbeat"))
+	require.NoError(t, err)
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &entry))
+	assert.Equal(t, "info", entry["level"])
+	assert.Equal(t, "example", entry["logger"])
+	assert.Equal(t, "some message", entry["message"])
+	assert.Contains(t, entry["caller"], "core_test.go")
+	assert.EqualValues(t, 1, entry["x"])
+	assert.Contains(t, entry, "timestamp")
+}
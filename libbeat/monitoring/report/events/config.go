@@ -0,0 +1,43 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package events
+
+import (
+	"errors"
+	"time"
+)
+
+type config struct {
+	Period time.Duration `config:"period"`
+	Index  string        `config:"index"`
+}
+
+var defaultConfig = config{
+	Period: 10 * time.Second,
+	Index:  ".monitoring-beats",
+}
+
+func (c *config) Validate() error {
+	if c.Period <= 0 {
+		return errors.New("monitoring events period must be greater than 0")
+	}
+	if c.Index == "" {
+		return errors.New("monitoring events index must not be empty")
+	}
+	return nil
+}
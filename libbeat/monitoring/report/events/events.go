@@ -0,0 +1,128 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package events reports the internal metrics of the beat as events, which
+// are published through the beat's own publisher pipeline and output.
+package events
+
+import (
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/monitoring"
+	"github.com/elastic/beats/libbeat/monitoring/report"
+)
+
+type reporter struct {
+	wg       sync.WaitGroup
+	done     chan struct{}
+	config   config
+	beatMeta common.MapStr
+	client   beat.Client
+	logger   *logp.Logger
+}
+
+// MakeReporter returns a new Reporter that periodically publishes the stats
+// metrics as events to the configured index. Events are dropped rather than
+// blocking if the pipeline is full.
+func MakeReporter(info beat.Info, pipeline beat.Pipeline, cfg *common.Config) (report.Reporter, error) {
+	config := defaultConfig
+	if cfg != nil {
+		if err := cfg.Unpack(&config); err != nil {
+			return nil, err
+		}
+	}
+
+	client, err := pipeline.ConnectWith(beat.ClientConfig{
+		PublishMode: beat.DropIfFull,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	r := &reporter{
+		done:     make(chan struct{}),
+		config:   config,
+		beatMeta: makeMeta(info),
+		client:   client,
+		logger:   logp.NewLogger("monitoring"),
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		r.snapshotLoop()
+	}()
+	return r, nil
+}
+
+func (r *reporter) Stop() {
+	close(r.done)
+	r.wg.Wait()
+	r.client.Close()
+}
+
+func (r *reporter) snapshotLoop() {
+	r.logger.Infof("Start publishing metrics events to %s every %v", r.config.Index, r.config.Period)
+	defer r.logger.Infof("Stop publishing metrics events.")
+
+	ticker := time.NewTicker(r.config.Period)
+	defer ticker.Stop()
+
+	for {
+		var ts time.Time
+
+		select {
+		case <-r.done:
+			return
+		case ts = <-ticker.C:
+		}
+
+		r.client.Publish(r.makeEvent(ts))
+	}
+}
+
+func (r *reporter) makeEvent(ts time.Time) beat.Event {
+	snapshot := monitoring.CollectStructSnapshot(
+		monitoring.GetNamespace("stats").GetRegistry(), monitoring.Full, false)
+
+	return beat.Event{
+		Timestamp: ts,
+		Meta: common.MapStr{
+			"index": r.config.Index,
+		},
+		Fields: common.MapStr{
+			"type":        "beats_stats",
+			"interval_ms": int64(r.config.Period / time.Millisecond),
+			"beat":        r.beatMeta,
+			"metrics":     common.MapStr(snapshot),
+		},
+	}
+}
+
+func makeMeta(info beat.Info) common.MapStr {
+	return common.MapStr{
+		"type":    info.Beat,
+		"version": info.Version,
+		"name":    info.Name,
+		"host":    info.Hostname,
+		"uuid":    info.UUID,
+	}
+}
@@ -0,0 +1,116 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/monitoring"
+)
+
+type testPipeline struct {
+	events chan beat.Event
+	config beat.ClientConfig
+}
+
+type testClient struct {
+	events chan beat.Event
+}
+
+func (p *testPipeline) Connect() (beat.Client, error) {
+	return p.ConnectWith(beat.ClientConfig{})
+}
+
+func (p *testPipeline) ConnectWith(config beat.ClientConfig) (beat.Client, error) {
+	p.config = config
+	return &testClient{events: p.events}, nil
+}
+
+func (p *testPipeline) SetACKHandler(beat.PipelineACKHandler) error { return nil }
+
+func (c *testClient) Publish(event beat.Event) { c.events <- event }
+
+func (c *testClient) PublishAll(events []beat.Event) {
+	for _, event := range events {
+		c.Publish(event)
+	}
+}
+
+func (c *testClient) Close() error { return nil }
+
+func TestReporterPublishesMetrics(t *testing.T) {
+	registry := monitoring.GetNamespace("stats").GetRegistry()
+	monitoring.NewInt(registry, "events_test.counter").Set(42)
+	defer registry.Remove("events_test")
+
+	pipeline := &testPipeline{events: make(chan beat.Event, 1)}
+	cfg := common.MustNewConfigFrom(map[string]interface{}{
+		"period": "10ms",
+		"index":  ".monitoring-test",
+	})
+	r, err := MakeReporter(beat.Info{Beat: "testbeat", Version: "7.0.0"}, pipeline, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var event beat.Event
+	select {
+	case event = <-pipeline.events:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no metrics event published")
+	}
+	go func() {
+		for range pipeline.events {
+		}
+	}()
+	r.Stop()
+
+	assert.Equal(t, beat.DropIfFull, pipeline.config.PublishMode)
+	assert.Equal(t, ".monitoring-test", event.Meta["index"])
+	assert.Equal(t, "beats_stats", event.Fields["type"])
+
+	v, err := event.Fields.GetValue("beat.type")
+	assert.NoError(t, err)
+	assert.Equal(t, "testbeat", v)
+
+	v, err = event.Fields.GetValue("metrics.events_test.counter")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), v)
+}
+
+func TestConfigValidate(t *testing.T) {
+	configs := map[string]map[string]interface{}{
+		"invalid period": {"period": "0s"},
+		"empty index":    {"index": ""},
+	}
+
+	for name, c := range configs {
+		t.Run(name, func(t *testing.T) {
+			config := defaultConfig
+			err := common.MustNewConfigFrom(c).Unpack(&config)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,134 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package amqp
+
+import (
+	"net"
+	"strconv"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/transport/tlscommon"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/outputs"
+	"github.com/elastic/beats/libbeat/outputs/codec"
+	"github.com/elastic/beats/libbeat/outputs/outil"
+	"github.com/elastic/beats/libbeat/outputs/transport"
+)
+
+var debugf = logp.MakeDebug("amqp")
+
+func init() {
+	outputs.RegisterType("amqp", makeAMQP)
+}
+
+func makeAMQP(
+	beat beat.Info,
+	observer outputs.Observer,
+	cfg *common.Config,
+) (outputs.Group, error) {
+	config := defaultConfig
+	if err := cfg.Unpack(&config); err != nil {
+		return outputs.Fail(err)
+	}
+
+	routingKey, err := outil.BuildSelectorFromConfig(cfg, outil.Settings{
+		Key:              "routing_key",
+		MultiKey:         "routing_keys",
+		EnableSingleOnly: true,
+		FailEmpty:        false,
+	})
+	if err != nil {
+		return outputs.Fail(err)
+	}
+
+	hosts, err := outputs.ReadHostList(cfg)
+	if err != nil {
+		return outputs.Fail(err)
+	}
+
+	tls, err := tlscommon.LoadTLSConfig(config.TLS)
+	if err != nil {
+		return outputs.Fail(err)
+	}
+
+	port := config.Port
+	if port == 0 {
+		port = defaultPort
+		if tls != nil {
+			port = defaultTLSPort
+		}
+	}
+
+	transp := &transport.Config{
+		Timeout: config.Timeout,
+		Proxy:   &config.Proxy,
+		TLS:     tls,
+		Stats:   observer,
+	}
+
+	settings := clientSettings{
+		Username:          config.Username,
+		Password:          config.Password,
+		VirtualHost:       config.VirtualHost,
+		Exchange:          config.Exchange,
+		ExchangeType:      config.ExchangeType,
+		ExchangeDeclare:   config.ExchangeDeclare,
+		ExchangeDurable:   config.ExchangeDurable,
+		RoutingKey:        routingKey,
+		PublisherConfirms: config.PublisherConfirms,
+		Persistent:        config.Persistent,
+		Timeout:           config.Timeout,
+		TLS:               tls,
+	}
+
+	dialer, err := transport.MakeDialer(transp)
+	if err != nil {
+		return outputs.Fail(err)
+	}
+
+	clients := make([]outputs.NetworkClient, len(hosts))
+	for i, host := range hosts {
+		enc, err := codec.CreateEncoder(beat, config.Codec)
+		if err != nil {
+			return outputs.Fail(err)
+		}
+
+		addr, err := hostAddress(host, port)
+		if err != nil {
+			return outputs.Fail(err)
+		}
+
+		client := newClient(dialer, addr, observer, settings, beat.Beat, enc)
+		clients[i] = outputs.WithBackoff(client, config.Backoff.Init, config.Backoff.Max)
+	}
+
+	return outputs.SuccessNet(config.LoadBalance, config.BulkMaxSize, config.MaxRetries, clients)
+}
+
+// hostAddress adds the port to host if it has none.
+func hostAddress(host string, port int) (string, error) {
+	if _, _, err := net.SplitHostPort(host); err == nil {
+		return host, nil
+	}
+	addr := net.JoinHostPort(host, strconv.Itoa(port))
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		return "", err
+	}
+	return addr, nil
+}
@@ -0,0 +1,386 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package amqp
+
+import (
+	"errors"
+	"net"
+	"time"
+
+	"github.com/streadway/amqp"
+
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/outputs"
+	"github.com/elastic/beats/libbeat/outputs/codec"
+	"github.com/elastic/beats/libbeat/outputs/outil"
+	"github.com/elastic/beats/libbeat/outputs/transport"
+	"github.com/elastic/beats/libbeat/publisher"
+	"github.com/elastic/beats/libbeat/testing"
+)
+
+const heartbeat = 10 * time.Second
+
+var (
+	errNacked         = errors.New("amqp server did not accept all messages")
+	errConfirmTimeout = errors.New("timeout waiting for amqp publisher confirms")
+	errChannelClosed  = errors.New("amqp channel closed")
+)
+
+type clientSettings struct {
+	Username          string
+	Password          string
+	VirtualHost       string
+	Exchange          string
+	ExchangeType      string
+	ExchangeDeclare   bool
+	ExchangeDurable   bool
+	RoutingKey        outil.Selector
+	PublisherConfirms bool
+	Persistent        bool
+	Timeout           time.Duration
+	TLS               *transport.TLSConfig
+}
+
+type client struct {
+	observer outputs.Observer
+	settings clientSettings
+	index    string
+	codec    codec.Codec
+	host     string
+	dialer   transport.Dialer
+
+	netConn     net.Conn // owned by conn, which closes it
+	conn        *amqp.Connection
+	channel     *amqp.Channel
+	confirms    chan amqp.Confirmation // nil if publisher confirms are disabled
+	deliveryTag uint64                 // tag of the last message published on the channel
+}
+
+// message is an encoded event ready to be published.
+type message struct {
+	routingKey string
+	publishing amqp.Publishing
+}
+
+func newClient(
+	dialer transport.Dialer,
+	host string,
+	observer outputs.Observer,
+	settings clientSettings,
+	index string,
+	codec codec.Codec,
+) *client {
+	return &client{
+		observer: observer,
+		settings: settings,
+		index:    index,
+		codec:    codec,
+		host:     host,
+		dialer:   dialer,
+	}
+}
+
+func (c *client) Connect() error {
+	debugf("connect to %v", c.host)
+	if c.netConn != nil {
+		c.abort()
+	}
+
+	netConn, err := c.dialer.Dial("tcp", c.host)
+	if err != nil {
+		return err
+	}
+	c.netConn = netConn
+
+	if err := c.init(); err != nil {
+		c.abort()
+		return err
+	}
+	return nil
+}
+
+func (c *client) init() error {
+	s := &c.settings
+
+	// The deadline bounds the handshake. It's cleared once the connection is
+	// open, then heartbeats detect dead connections.
+	if err := c.netConn.SetDeadline(time.Now().Add(s.Timeout)); err != nil {
+		return err
+	}
+	conn, err := amqp.Open(c.netConn, amqp.Config{
+		SASL:       []amqp.Authentication{&amqp.PlainAuth{Username: s.Username, Password: s.Password}},
+		Vhost:      s.VirtualHost,
+		Heartbeat:  heartbeat,
+		Locale:     "en_US",
+		Properties: amqp.Table{"product": c.index},
+	})
+	if err != nil {
+		return err
+	}
+	c.conn = conn
+
+	c.channel, err = conn.Channel()
+	if err != nil {
+		return err
+	}
+	if s.ExchangeDeclare {
+		err := c.channel.ExchangeDeclare(s.Exchange, s.ExchangeType, s.ExchangeDurable, false, false, false, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	c.deliveryTag = 0
+	if s.PublisherConfirms {
+		if err := c.channel.Confirm(false); err != nil {
+			return err
+		}
+		c.confirms = c.channel.NotifyPublish(make(chan amqp.Confirmation, 1))
+	}
+	return nil
+}
+
+func (c *client) Close() error {
+	debugf("close connection")
+	if c.conn == nil {
+		c.abort()
+		return nil
+	}
+
+	c.netConn.SetDeadline(time.Now().Add(c.settings.Timeout))
+	if err := c.conn.Close(); err != nil {
+		debugf("Failed to close the amqp connection gracefully: %v", err)
+	}
+	c.reset()
+	return nil
+}
+
+// abort closes a failed connection without the closing handshake.
+func (c *client) abort() {
+	if c.confirms != nil {
+		// Unblock the amqp reader if it is delivering a confirm, so it can
+		// shut down. The amqp library closes the channel on shutdown.
+		go func(confirms chan amqp.Confirmation) {
+			for range confirms {
+			}
+		}(c.confirms)
+	}
+	if c.netConn != nil {
+		c.netConn.Close()
+	}
+	c.reset()
+}
+
+func (c *client) reset() {
+	c.netConn = nil
+	c.conn = nil
+	c.channel = nil
+	c.confirms = nil
+}
+
+func (c *client) Publish(batch publisher.Batch) error {
+	events := batch.Events()
+	c.observer.NewBatch(len(events))
+
+	rest, err := c.publishEvents(events)
+	if err != nil && err != errNacked {
+		// The state of the channel is unknown, so a new connection is opened
+		// for the retries.
+		c.abort()
+	}
+	if len(rest) > 0 {
+		c.observer.Failed(len(rest))
+		batch.RetryEvents(rest)
+		return err
+	}
+
+	batch.ACK()
+	return err
+}
+
+func (c *client) Test(d testing.Driver) {
+	d.Run("amqp: "+c.host, func(d testing.Driver) {
+		d.Run("connection", func(d testing.Driver) {
+			netDialer := transport.TestNetDialer(d, c.settings.Timeout)
+			_, err := netDialer.Dial("tcp", c.host)
+			d.Fatal("dial up", err)
+		})
+
+		if c.settings.TLS == nil {
+			d.Warn("TLS", "secure connection disabled")
+		} else {
+			d.Run("TLS", func(d testing.Driver) {
+				netDialer := transport.NetDialer(c.settings.Timeout)
+				tlsDialer, err := transport.TestTLSDialer(d, netDialer, c.settings.TLS, c.settings.Timeout)
+				_, err = tlsDialer.Dial("tcp", c.host)
+				d.Fatal("dial up", err)
+			})
+		}
+
+		err := c.Connect()
+		d.Fatal("talk to server", err)
+		c.Close()
+	})
+}
+
+func (c *client) String() string {
+	return "amqp(" + c.host + ")"
+}
+
+// publishEvents publishes the events and returns the events to be retried.
+func (c *client) publishEvents(data []publisher.Event) ([]publisher.Event, error) {
+	if c.channel == nil {
+		return data, errors.New("amqp connection is not open")
+	}
+
+	okEvents, msgs := c.encodeEvents(data)
+	if len(okEvents) == 0 {
+		return nil, nil
+	}
+
+	if c.confirms == nil {
+		for i := range msgs {
+			if err := c.publish(&msgs[i]); err != nil {
+				logp.Err("Failed to publish %v events to amqp: %v", len(okEvents)-i, err)
+				c.observer.Acked(i)
+				return okEvents[i:], err
+			}
+		}
+		c.observer.Acked(len(okEvents))
+		return nil, nil
+	}
+
+	// The confirms are read while publishing, so the channel never blocks
+	// on confirms nobody reads.
+	first := c.deliveryTag + 1
+	acked := make([]bool, len(okEvents))
+	confirmed := make(chan error, 1)
+	go func() {
+		confirmed <- c.waitConfirms(acked, first)
+	}()
+
+	published := 0
+	var err error
+	for i := range msgs {
+		if err = c.publish(&msgs[i]); err != nil {
+			break
+		}
+		published++
+	}
+	c.deliveryTag += uint64(published)
+	if err != nil {
+		// The messages not published are never confirmed, closing the
+		// connection stops waitConfirms.
+		c.netConn.Close()
+	}
+	if confirmErr := <-confirmed; err == nil {
+		err = confirmErr
+	}
+
+	failed := okEvents[:0]
+	for i := range okEvents {
+		if !acked[i] {
+			failed = append(failed, okEvents[i])
+		}
+	}
+	if err == nil && len(failed) > 0 {
+		err = errNacked
+	}
+	if err != nil {
+		logp.Err("Failed to publish %v events to amqp: %v", len(failed), err)
+	}
+	c.observer.Acked(len(okEvents) - len(failed))
+	return failed, err
+}
+
+// encodeEvents encodes the events and returns the events that can be
+// published with their messages. Events that can't be encoded are dropped.
+func (c *client) encodeEvents(data []publisher.Event) ([]publisher.Event, []message) {
+	deliveryMode := amqp.Transient
+	if c.settings.Persistent {
+		deliveryMode = amqp.Persistent
+	}
+
+	okEvents := data[:0]
+	msgs := make([]message, 0, len(data))
+	dropped := 0
+	for i := range data {
+		event := &data[i]
+
+		routingKey := ""
+		if !c.settings.RoutingKey.IsEmpty() {
+			var err error
+			if routingKey, err = c.settings.RoutingKey.Select(&event.Content); err != nil {
+				logp.Err("Failed to set the routing key: %v", err)
+				dropped++
+				continue
+			}
+		}
+
+		serialized, err := c.codec.Encode(c.index, &event.Content)
+		if err != nil {
+			logp.Err("Failed to serialize the event: %v", err)
+			dropped++
+			continue
+		}
+
+		msgs = append(msgs, message{
+			routingKey: routingKey,
+			publishing: amqp.Publishing{
+				ContentType:  "application/json",
+				AppId:        c.index,
+				DeliveryMode: deliveryMode,
+				Timestamp:    event.Content.Timestamp,
+				// The codec reuses its buffer.
+				Body: append([]byte(nil), serialized...),
+			},
+		})
+		okEvents = append(okEvents, *event)
+	}
+	c.observer.Dropped(dropped)
+	return okEvents, msgs
+}
+
+func (c *client) publish(msg *message) error {
+	return c.channel.Publish(c.settings.Exchange, msg.routingKey, false, false, msg.publishing)
+}
+
+// waitConfirms reads the confirms of the messages published with the
+// delivery tags starting at first, and marks the acked messages. The confirms
+// are delivered in order of the delivery tags.
+func (c *client) waitConfirms(acked []bool, first uint64) error {
+	timer := time.NewTimer(c.settings.Timeout)
+	defer timer.Stop()
+
+	for range acked {
+		select {
+		case confirm, ok := <-c.confirms:
+			if !ok {
+				return errChannelClosed
+			}
+			i := confirm.DeliveryTag - first
+			if confirm.DeliveryTag < first || i >= uint64(len(acked)) {
+				return errors.New("unexpected amqp delivery tag")
+			}
+			acked[i] = confirm.Ack
+		case <-timer.C:
+			return errConfirmTimeout
+		}
+	}
+	return nil
+}
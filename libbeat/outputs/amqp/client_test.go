@@ -0,0 +1,453 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package amqp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/fmtstr"
+	"github.com/elastic/beats/libbeat/outputs"
+	"github.com/elastic/beats/libbeat/outputs/codec/format"
+	"github.com/elastic/beats/libbeat/outputs/outest"
+	"github.com/elastic/beats/libbeat/outputs/outil"
+	"github.com/elastic/beats/libbeat/outputs/transport"
+)
+
+// AMQP 0-9-1 frame types and the method IDs (class<<16 | method) used by the
+// fake broker.
+const (
+	frameMethod    = 1
+	frameHeader    = 2
+	frameBody      = 3
+	frameHeartbeat = 8
+	frameEnd       = 0xCE
+
+	connectionStart   = 10<<16 | 10
+	connectionStartOk = 10<<16 | 11
+	connectionTune    = 10<<16 | 30
+	connectionTuneOk  = 10<<16 | 31
+	connectionOpen    = 10<<16 | 40
+	connectionOpenOk  = 10<<16 | 41
+	connectionClose   = 10<<16 | 50
+	connectionCloseOk = 10<<16 | 51
+	channelOpen       = 20<<16 | 10
+	channelOpenOk     = 20<<16 | 11
+	channelClose      = 20<<16 | 40
+	channelCloseOk    = 20<<16 | 41
+	exchangeDeclare   = 40<<16 | 10
+	exchangeDeclareOk = 40<<16 | 11
+	basicPublish      = 60<<16 | 40
+	basicAck          = 60<<16 | 80
+	basicNack         = 60<<16 | 120
+	confirmSelect     = 85<<16 | 10
+	confirmSelectOk   = 85<<16 | 11
+
+	testFrameMax = 4096
+)
+
+type published struct {
+	exchange   string
+	routingKey string
+	body       string
+}
+
+// fakeBroker implements the server side of the AMQP methods used by the client.
+type fakeBroker struct {
+	t        *testing.T
+	listener net.Listener
+
+	// reply decides how to confirm the message with the given delivery tag:
+	// basicAck, basicNack or 0 to not confirm it.
+	reply func(tag uint64) uint32
+
+	auth      chan string
+	exchanges chan string
+	messages  chan published
+}
+
+func newFakeBroker(t *testing.T) *fakeBroker {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	b := &fakeBroker{
+		t:         t,
+		listener:  l,
+		reply:     func(uint64) uint32 { return basicAck },
+		auth:      make(chan string, 10),
+		exchanges: make(chan string, 10),
+		messages:  make(chan published, 100),
+	}
+	go b.serve()
+	return b
+}
+
+func (b *fakeBroker) Close() {
+	b.listener.Close()
+}
+
+func (b *fakeBroker) serve() {
+	for {
+		nc, err := b.listener.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer nc.Close()
+			b.handle(&brokerConn{r: bufio.NewReader(nc), w: nc})
+		}()
+	}
+}
+
+func (b *fakeBroker) handle(c *brokerConn) {
+	header := make([]byte, 8)
+	if _, err := io.ReadFull(c.r, header); err != nil || string(header) != "AMQP\x00\x00\x09\x01" {
+		return
+	}
+
+	c.writeMethod(0, connectionStart, func(a *args) {
+		a.octet(0)
+		a.octet(9)
+		a.long(0) // empty server properties
+		a.longstr("PLAIN")
+		a.longstr("en_US")
+	})
+	d, err := c.expect(connectionStartOk)
+	if err != nil {
+		return
+	}
+	d.skip(int(d.long())) // client properties
+	d.shortstr()
+	b.auth <- d.longstr()
+
+	c.writeMethod(0, connectionTune, func(a *args) {
+		a.short(0)
+		a.long(testFrameMax)
+		a.short(0)
+	})
+	if _, err := c.expect(connectionTuneOk); err != nil {
+		return
+	}
+	if _, err := c.expect(connectionOpen); err != nil {
+		return
+	}
+	c.writeMethod(0, connectionOpenOk, func(a *args) { a.shortstr("") })
+
+	confirms := false
+	var tag uint64
+	for {
+		channel, id, d, err := c.readMethod()
+		if err != nil {
+			return
+		}
+
+		switch id {
+		case channelOpen:
+			c.writeMethod(channel, channelOpenOk, func(a *args) { a.longstr("") })
+		case exchangeDeclare:
+			d.short()
+			name := d.shortstr()
+			b.exchanges <- name + ":" + d.shortstr()
+			c.writeMethod(channel, exchangeDeclareOk, nil)
+		case confirmSelect:
+			confirms = true
+			c.writeMethod(channel, confirmSelectOk, nil)
+		case basicPublish:
+			d.short()
+			msg := published{exchange: d.shortstr(), routingKey: d.shortstr()}
+			body, err := c.readContent()
+			if err != nil {
+				return
+			}
+			msg.body = body
+			b.messages <- msg
+
+			if confirms {
+				tag++
+				if reply := b.reply(tag); reply != 0 {
+					c.writeMethod(channel, reply, func(a *args) {
+						a.longlong(tag)
+						a.octet(0)
+					})
+				}
+			}
+		case channelClose:
+			c.writeMethod(channel, channelCloseOk, nil)
+		case connectionClose:
+			c.writeMethod(0, connectionCloseOk, nil)
+			return
+		default:
+			b.t.Errorf("unexpected method %v.%v", id>>16, id&0xffff)
+			return
+		}
+	}
+}
+
+type brokerConn struct {
+	r *bufio.Reader
+	w io.Writer
+}
+
+func (c *brokerConn) readFrame() (byte, uint16, []byte, error) {
+	var header [7]byte
+	if _, err := io.ReadFull(c.r, header[:]); err != nil {
+		return 0, 0, nil, err
+	}
+	payload := make([]byte, binary.BigEndian.Uint32(header[3:])+1)
+	if _, err := io.ReadFull(c.r, payload); err != nil {
+		return 0, 0, nil, err
+	}
+	if payload[len(payload)-1] != frameEnd {
+		return 0, 0, nil, errors.New("invalid frame end")
+	}
+	return header[0], binary.BigEndian.Uint16(header[1:]), payload[:len(payload)-1], nil
+}
+
+// readMethod returns the next method, skipping heartbeats.
+func (c *brokerConn) readMethod() (uint16, uint32, *argReader, error) {
+	for {
+		typ, channel, payload, err := c.readFrame()
+		if err != nil {
+			return 0, 0, nil, err
+		}
+		if typ == frameHeartbeat {
+			continue
+		}
+		if typ != frameMethod || len(payload) < 4 {
+			return 0, 0, nil, fmt.Errorf("unexpected frame type %v", typ)
+		}
+		return channel, binary.BigEndian.Uint32(payload), &argReader{buf: payload[4:]}, nil
+	}
+}
+
+func (c *brokerConn) expect(id uint32) (*argReader, error) {
+	_, got, d, err := c.readMethod()
+	if err == nil && got != id {
+		err = fmt.Errorf("expected method %v, got %v", id, got)
+	}
+	return d, err
+}
+
+// readContent reads the header and body frames of a published message.
+func (c *brokerConn) readContent() (string, error) {
+	_, _, payload, err := c.readFrame()
+	if err != nil {
+		return "", err
+	}
+	d := &argReader{buf: payload}
+	d.short() // class
+	d.short() // weight
+	size := d.longlong()
+
+	var body []byte
+	for uint64(len(body)) < size {
+		typ, _, payload, err := c.readFrame()
+		if err != nil {
+			return "", err
+		}
+		if typ != frameBody {
+			return "", fmt.Errorf("unexpected frame type %v", typ)
+		}
+		body = append(body, payload...)
+	}
+	return string(body), nil
+}
+
+func (c *brokerConn) writeMethod(channel uint16, id uint32, fn func(*args)) {
+	a := &args{}
+	a.long(id)
+	if fn != nil {
+		fn(a)
+	}
+
+	frame := []byte{frameMethod, byte(channel >> 8), byte(channel), 0, 0, 0, 0}
+	binary.BigEndian.PutUint32(frame[3:], uint32(a.Len()))
+	frame = append(append(frame, a.Bytes()...), frameEnd)
+	c.w.Write(frame)
+}
+
+// args encodes method arguments.
+type args struct{ bytes.Buffer }
+
+func (a *args) octet(v byte)      { a.WriteByte(v) }
+func (a *args) short(v uint16)    { binary.Write(a, binary.BigEndian, v) }
+func (a *args) long(v uint32)     { binary.Write(a, binary.BigEndian, v) }
+func (a *args) longlong(v uint64) { binary.Write(a, binary.BigEndian, v) }
+func (a *args) shortstr(s string) { a.octet(byte(len(s))); a.WriteString(s) }
+func (a *args) longstr(s string)  { a.long(uint32(len(s))); a.WriteString(s) }
+
+// argReader decodes method arguments. Reads past the end return zero values.
+type argReader struct{ buf []byte }
+
+func (d *argReader) next(n int) []byte {
+	if n > len(d.buf) {
+		d.buf = nil
+		return make([]byte, n)
+	}
+	b := d.buf[:n]
+	d.buf = d.buf[n:]
+	return b
+}
+
+func (d *argReader) skip(n int)       { d.next(n) }
+func (d *argReader) short() uint16    { return binary.BigEndian.Uint16(d.next(2)) }
+func (d *argReader) long() uint32     { return binary.BigEndian.Uint32(d.next(4)) }
+func (d *argReader) longlong() uint64 { return binary.BigEndian.Uint64(d.next(8)) }
+func (d *argReader) shortstr() string { return string(d.next(int(d.next(1)[0]))) }
+func (d *argReader) longstr() string  { return string(d.next(int(d.long()))) }
+
+func newTestClient(t *testing.T, b *fakeBroker, settings clientSettings) *client {
+	settings.Username = "user"
+	settings.Password = "secret"
+	settings.VirtualHost = "/"
+	settings.Exchange = "beats"
+	settings.ExchangeType = "topic"
+	if settings.Timeout == 0 {
+		settings.Timeout = time.Second
+	}
+
+	enc := format.New(fmtstr.MustCompileEvent("%{[message]}"))
+	dialer := transport.NetDialer(time.Second)
+	c := newClient(dialer, b.listener.Addr().String(), outputs.NewNilObserver(), settings, "testbeat", enc)
+	require.NoError(t, c.Connect())
+	return c
+}
+
+func newTestBatch(messages ...string) *outest.Batch {
+	events := make([]beat.Event, len(messages))
+	for i, msg := range messages {
+		events[i] = beat.Event{
+			Timestamp: time.Now(),
+			Fields:    common.MapStr{"message": msg, "type": "t" + msg},
+		}
+	}
+	return outest.NewBatch(events...)
+}
+
+func TestPublishWithConfirms(t *testing.T) {
+	b := newFakeBroker(t)
+	defer b.Close()
+
+	routingKey, err := outil.BuildSelectorFromConfig(common.MustNewConfigFrom(map[string]interface{}{
+		"routing_key": "%{[type]}",
+	}), outil.Settings{Key: "routing_key", EnableSingleOnly: true})
+	require.NoError(t, err)
+
+	c := newTestClient(t, b, clientSettings{
+		ExchangeDeclare:   true,
+		RoutingKey:        routingKey,
+		PublisherConfirms: true,
+	})
+	defer c.Close()
+
+	assert.Equal(t, "\x00user\x00secret", <-b.auth)
+	assert.Equal(t, "beats:topic", <-b.exchanges)
+
+	large := string(make([]byte, 3*testFrameMax))
+	batch := newTestBatch("a", "b", large)
+	require.NoError(t, c.Publish(batch))
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+
+	assert.Equal(t, published{"beats", "ta", "a"}, <-b.messages)
+	assert.Equal(t, published{"beats", "tb", "b"}, <-b.messages)
+	assert.Equal(t, large, (<-b.messages).body)
+
+	// Delivery tags continue on the next batch.
+	batch = newTestBatch("c")
+	require.NoError(t, c.Publish(batch))
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+	assert.Equal(t, published{"beats", "tc", "c"}, <-b.messages)
+}
+
+func TestPublishRetriesNacked(t *testing.T) {
+	b := newFakeBroker(t)
+	defer b.Close()
+	b.reply = func(tag uint64) uint32 {
+		if tag == 2 {
+			return basicNack
+		}
+		return basicAck
+	}
+
+	c := newTestClient(t, b, clientSettings{PublisherConfirms: true})
+	defer c.Close()
+
+	batch := newTestBatch("a", "b", "c")
+	assert.Equal(t, errNacked, c.Publish(batch))
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchRetryEvents, batch.Signals[0].Tag)
+	require.Len(t, batch.Signals[0].Events, 1)
+	assert.Equal(t, "b", batch.Signals[0].Events[0].Content.Fields["message"])
+}
+
+func TestPublishWithoutConfirms(t *testing.T) {
+	b := newFakeBroker(t)
+	defer b.Close()
+
+	c := newTestClient(t, b, clientSettings{})
+	defer c.Close()
+
+	batch := newTestBatch("a")
+	require.NoError(t, c.Publish(batch))
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+	assert.Equal(t, published{"beats", "", "a"}, <-b.messages)
+}
+
+func TestPublishRetriesUnconfirmed(t *testing.T) {
+	b := newFakeBroker(t)
+	defer b.Close()
+	b.reply = func(tag uint64) uint32 {
+		if tag == 2 {
+			return 0
+		}
+		return basicAck
+	}
+
+	c := newTestClient(t, b, clientSettings{PublisherConfirms: true, Timeout: 200 * time.Millisecond})
+	defer c.Close()
+
+	batch := newTestBatch("a", "b")
+	assert.Equal(t, errConfirmTimeout, c.Publish(batch))
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchRetryEvents, batch.Signals[0].Tag)
+	require.Len(t, batch.Signals[0].Events, 1)
+	assert.Equal(t, "b", batch.Signals[0].Events[0].Content.Fields["message"])
+
+	// The connection is dropped, because later confirms wouldn't match the
+	// delivery tags anymore. The retry uses a new connection.
+	assert.Nil(t, c.conn)
+	require.NoError(t, c.Connect())
+	batch = newTestBatch("b")
+	require.NoError(t, c.Publish(batch))
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+}
@@ -0,0 +1,88 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package amqp
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/elastic/beats/libbeat/common/transport/tlscommon"
+	"github.com/elastic/beats/libbeat/outputs/codec"
+	"github.com/elastic/beats/libbeat/outputs/transport"
+)
+
+type amqpConfig struct {
+	Port              int                   `config:"port"`
+	Username          string                `config:"username"`
+	Password          string                `config:"password"`
+	VirtualHost       string                `config:"virtual_host"`
+	Exchange          string                `config:"exchange" validate:"required"`
+	ExchangeType      string                `config:"exchange_type"`
+	ExchangeDeclare   bool                  `config:"exchange_declare"`
+	ExchangeDurable   bool                  `config:"exchange_durable"`
+	PublisherConfirms bool                  `config:"publisher_confirms"`
+	Persistent        bool                  `config:"persistent"`
+	LoadBalance       bool                  `config:"loadbalance"`
+	Timeout           time.Duration         `config:"timeout"`
+	BulkMaxSize       int                   `config:"bulk_max_size"`
+	MaxRetries        int                   `config:"max_retries"`
+	TLS               *tlscommon.Config     `config:"ssl"`
+	Proxy             transport.ProxyConfig `config:",inline"`
+	Codec             codec.Config          `config:"codec"`
+	Backoff           backoff               `config:"backoff"`
+}
+
+type backoff struct {
+	Init time.Duration
+	Max  time.Duration
+}
+
+const (
+	defaultPort    = 5672
+	defaultTLSPort = 5671
+)
+
+var (
+	defaultConfig = amqpConfig{
+		Username:          "guest",
+		Password:          "guest",
+		VirtualHost:       "/",
+		ExchangeType:      "topic",
+		ExchangeDeclare:   false,
+		ExchangeDurable:   true,
+		PublisherConfirms: true,
+		Persistent:        true,
+		LoadBalance:       true,
+		Timeout:           30 * time.Second,
+		BulkMaxSize:       2048,
+		MaxRetries:        3,
+		Backoff: backoff{
+			Init: 1 * time.Second,
+			Max:  60 * time.Second,
+		},
+	}
+)
+
+func (c *amqpConfig) Validate() error {
+	switch c.ExchangeType {
+	case "direct", "fanout", "topic", "headers":
+	default:
+		return fmt.Errorf("amqp exchange type %v not supported", c.ExchangeType)
+	}
+	return nil
+}
@@ -136,3 +136,39 @@ func TestOneHost503Resp(t *testing.T) {
 		t.Errorf("Should return <503 Service Unavailable> instead of %v", err)
 	}
 }
+
+func TestGetLicense(t *testing.T) {
+	logp.TestingSetup(logp.WithSelectors("elasticsearch"))
+
+	server := ElasticsearchMock(200, []byte(`{"license":{"status":"active","type":"basic"}}`))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	if err := client.Connect(); err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+
+	license, err := client.getLicense()
+	if err != nil {
+		t.Fatalf("getLicense() returns error: %v", err)
+	}
+	if license.Type != "basic" || license.Status != "active" {
+		t.Errorf("Unexpected license: %+v", license)
+	}
+}
+
+func TestGetLicenseUnavailable(t *testing.T) {
+	logp.TestingSetup(logp.WithSelectors("elasticsearch"))
+
+	server := ElasticsearchMock(400, []byte(`{"error":"no handler found"}`))
+	defer server.Close()
+
+	client := newTestClient(server.URL)
+	if err := client.Connect(); err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+
+	if _, err := client.getLicense(); err == nil {
+		t.Errorf("getLicense() should return error.")
+	}
+}
@@ -19,6 +19,7 @@ package elasticsearch
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -29,6 +30,8 @@ import (
 	"time"
 
 	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/transport/kerberos"
 	"github.com/elastic/beats/libbeat/logp"
 	"github.com/elastic/beats/libbeat/outputs"
 	"github.com/elastic/beats/libbeat/outputs/outil"
@@ -40,7 +43,8 @@ import (
 // Client is an elasticsearch client.
 type Client struct {
 	Connection
-	tlsConfig *transport.TLSConfig
+	tlsConfig      *transport.TLSConfig
+	kerberosConfig *kerberos.Config
 
 	index    outil.Selector
 	pipeline *outil.Selector
@@ -56,16 +60,27 @@ type Client struct {
 	// additional configs
 	compressionLevel int
 	proxyURL         *url.URL
+	proxyLocal       bool
 
 	observer outputs.Observer
+
+	// deadLetter receives the events that can not be indexed, if enabled.
+	deadLetter *deadLetterQueue
+
+	// ilm sets up the ILM policy and the rollover alias on connect, if enabled.
+	ilm *ilmManager
 }
 
 // ClientSettings contains the settings for a client.
 type ClientSettings struct {
 	URL                string
 	Proxy              *url.URL
+	ProxyLocalResolve  bool
 	TLS                *transport.TLSConfig
 	Username, Password string
+	APIKey             string
+	BearerToken        string
+	Kerberos           *kerberos.Config
 	EscapeHTML         bool
 	Parameters         map[string]string
 	Headers            map[string]string
@@ -80,12 +95,15 @@ type connectCallback func(client *Client) error
 
 // Connection manages the connection for a given client.
 type Connection struct {
-	URL      string
-	Username string
-	Password string
-	Headers  map[string]string
+	URL         string
+	Username    string
+	Password    string
+	APIKey      string
+	BearerToken string
+	Headers     map[string]string
 
 	http              *http.Client
+	kerberos          *kerberos.Client // Sets the SPNEGO header if Kerberos is enabled.
 	onConnectCallback func() error
 
 	encoder bodyEncoder
@@ -138,9 +156,19 @@ func NewClient(
 	s ClientSettings,
 	onConnect *callbacksRegistry,
 ) (*Client, error) {
+	// SOCKS5 proxies are used by the dialer, HTTP proxies by the HTTP client.
 	proxy := http.ProxyFromEnvironment
+	var proxyConfig *transport.ProxyConfig
 	if s.Proxy != nil {
-		proxy = http.ProxyURL(s.Proxy)
+		if s.Proxy.Scheme == "socks5" {
+			proxy = nil
+			proxyConfig = &transport.ProxyConfig{
+				URL:          s.Proxy.String(),
+				LocalResolve: s.ProxyLocalResolve,
+			}
+		} else {
+			proxy = http.ProxyURL(s.Proxy)
+		}
 	}
 
 	pipeline := s.Pipeline
@@ -163,10 +191,12 @@ func NewClient(
 
 	logp.Info("Elasticsearch url: %s", s.URL)
 
-	// TODO: add socks5 proxy support
 	var dialer, tlsDialer transport.Dialer
 
-	dialer = transport.NetDialer(s.Timeout)
+	dialer, err = transport.ProxyDialer(proxyConfig, transport.NetDialer(s.Timeout), s.Timeout)
+	if err != nil {
+		return nil, err
+	}
 	tlsDialer, err = transport.TLSDialer(dialer, s.TLS, s.Timeout)
 	if err != nil {
 		return nil, err
@@ -177,6 +207,14 @@ func NewClient(
 		tlsDialer = transport.StatsDialer(tlsDialer, st)
 	}
 
+	var krb5 *kerberos.Client
+	if s.Kerberos.IsEnabled() {
+		krb5, err = kerberos.NewClient(s.Kerberos, "HTTP/"+u.Hostname())
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	params := s.Parameters
 	bulkRequ, err := newBulkRequest(s.URL, "", "", params, nil)
 	if err != nil {
@@ -196,10 +234,12 @@ func NewClient(
 
 	client := &Client{
 		Connection: Connection{
-			URL:      s.URL,
-			Username: s.Username,
-			Password: s.Password,
-			Headers:  s.Headers,
+			URL:         s.URL,
+			Username:    s.Username,
+			Password:    s.Password,
+			APIKey:      s.APIKey,
+			BearerToken: s.BearerToken,
+			Headers:     s.Headers,
 			http: &http.Client{
 				Transport: &http.Transport{
 					Dial:    dialer.Dial,
@@ -208,18 +248,21 @@ func NewClient(
 				},
 				Timeout: s.Timeout,
 			},
-			encoder: encoder,
+			kerberos: krb5,
+			encoder:  encoder,
 		},
-		tlsConfig: s.TLS,
-		index:     s.Index,
-		pipeline:  pipeline,
-		params:    params,
-		timeout:   s.Timeout,
+		tlsConfig:      s.TLS,
+		kerberosConfig: s.Kerberos,
+		index:          s.Index,
+		pipeline:       pipeline,
+		params:         params,
+		timeout:        s.Timeout,
 
 		bulkRequ: bulkRequ,
 
 		compressionLevel: compression,
 		proxyURL:         s.Proxy,
+		proxyLocal:       s.ProxyLocalResolve,
 		observer:         s.Observer,
 	}
 
@@ -235,6 +278,12 @@ func NewClient(
 				}
 			}
 		}
+
+		// The Beat template is loaded by the callbacks, before the write
+		// index is created.
+		if client.ilm != nil {
+			return client.ilm.setup(client)
+		}
 		return nil
 	}
 
@@ -250,17 +299,21 @@ func (client *Client) Clone() *Client {
 
 	c, _ := NewClient(
 		ClientSettings{
-			URL:              client.URL,
-			Index:            client.index,
-			Pipeline:         client.pipeline,
-			Proxy:            client.proxyURL,
-			TLS:              client.tlsConfig,
-			Username:         client.Username,
-			Password:         client.Password,
-			Parameters:       nil, // XXX: do not pass params?
-			Headers:          client.Headers,
-			Timeout:          client.http.Timeout,
-			CompressionLevel: client.compressionLevel,
+			URL:               client.URL,
+			Index:             client.index,
+			Pipeline:          client.pipeline,
+			Proxy:             client.proxyURL,
+			ProxyLocalResolve: client.proxyLocal,
+			TLS:               client.tlsConfig,
+			Username:          client.Username,
+			Password:          client.Password,
+			APIKey:            client.APIKey,
+			BearerToken:       client.BearerToken,
+			Kerberos:          client.kerberosConfig,
+			Parameters:        nil, // XXX: do not pass params?
+			Headers:           client.Headers,
+			Timeout:           client.http.Timeout,
+			CompressionLevel:  client.compressionLevel,
 		},
 		nil, // XXX: do not pass connection callback?
 	)
@@ -270,6 +323,9 @@ func (client *Client) Clone() *Client {
 func (client *Client) Publish(batch publisher.Batch) error {
 	events := batch.Events()
 	rest, err := client.publishEvents(events)
+	if len(rest) > 0 && client.deadLetter != nil {
+		rest = client.deadLetter.retry(rest)
+	}
 	if len(rest) == 0 {
 		batch.ACK()
 	} else {
@@ -331,7 +387,13 @@ func (client *Client) publishEvents(
 		stats.fails = len(failedEvents)
 	} else {
 		client.json.init(result.raw)
-		failedEvents, stats = bulkCollectPublishFails(&client.json, data)
+		var onRejected func(*publisher.Event, int, []byte) bool
+		if client.deadLetter != nil {
+			onRejected = func(event *publisher.Event, status int, msg []byte) bool {
+				return client.deadLetter.rejected(event, status, string(msg))
+			}
+		}
+		failedEvents, stats = collectPublishFails(&client.json, data, onRejected)
 	}
 
 	failed := len(failedEvents)
@@ -463,6 +525,17 @@ func getIndex(event *beat.Event, index outil.Selector) (string, error) {
 func bulkCollectPublishFails(
 	reader *jsonReader,
 	data []publisher.Event,
+) ([]publisher.Event, bulkResultStats) {
+	return collectPublishFails(reader, data, nil)
+}
+
+// collectPublishFails is like bulkCollectPublishFails, additionally passing
+// the events that can not be indexed to onRejected, if set. Events are retried
+// if onRejected returns true.
+func collectPublishFails(
+	reader *jsonReader,
+	data []publisher.Event,
+	onRejected func(event *publisher.Event, status int, msg []byte) bool,
 ) ([]publisher.Event, bulkResultStats) {
 	if err := reader.expectDict(); err != nil {
 		logp.Err("Failed to parse bulk response: expected JSON object")
@@ -523,6 +596,11 @@ func bulkCollectPublishFails(
 			} else {
 				// hard failure, don't collect
 				logp.Warn("Cannot index event %#v (status=%v): %s", data[i], status, msg)
+				if onRejected != nil && onRejected(&data[i], status, msg) {
+					stats.fails++
+					failed = append(failed, data[i])
+					continue
+				}
 				stats.nonIndexable++
 				continue
 			}
@@ -667,13 +745,62 @@ func (client *Client) Test(d testing.Driver) {
 		err = client.Connect()
 		d.Fatal("talk to server", err)
 		d.Info("version", client.version)
+
+		license, err := client.getLicense()
+		if err != nil {
+			d.Warn("license", err.Error())
+		} else if license.Status != "active" {
+			d.Warn("license", fmt.Sprintf("%v license is %v", license.Type, license.Status))
+		} else {
+			d.Info("license", license.Type)
+		}
 	})
 }
 
+type esLicense struct {
+	Type   string `json:"type"`
+	Status string `json:"status"`
+}
+
+// getLicense returns the license of the cluster. It is not available with the
+// open source distribution of Elasticsearch.
+func (conn *Connection) getLicense() (*esLicense, error) {
+	path := "/_xpack/license"
+	if version, err := common.NewVersion(conn.version); err == nil && version.Major >= 7 {
+		path = "/_license"
+	}
+
+	_, body, err := conn.Request("GET", path, "", nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the license: %v", err)
+	}
+
+	var resp struct {
+		License *esLicense `json:"license"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse the license: %v", err)
+	}
+	if resp.License == nil {
+		return nil, errors.New("no license returned")
+	}
+	return resp.License, nil
+}
+
 func (client *Client) String() string {
 	return "elasticsearch(" + client.Connection.URL + ")"
 }
 
+// Close closes the connection and the dead letter file, if any.
+func (client *Client) Close() error {
+	if client.deadLetter != nil {
+		if err := client.deadLetter.close(); err != nil {
+			logp.Err("Failed to close dead letter file: %v", err)
+		}
+	}
+	return client.Connection.Close()
+}
+
 // Connect connects the client.
 func (conn *Connection) Connect() error {
 	var err error
@@ -721,6 +848,9 @@ func (conn *Connection) Ping() (string, error) {
 
 // Close closes a connection.
 func (conn *Connection) Close() error {
+	if conn.kerberos != nil {
+		conn.kerberos.Close()
+	}
 	return nil
 }
 
@@ -761,7 +891,7 @@ func (conn *Connection) execRequest(
 ) (int, []byte, error) {
 	req, err := http.NewRequest(method, url, body)
 	if err != nil {
-		logp.Warn("Failed to create request", err)
+		logp.Warn("Failed to create request: %v", err)
 		return 0, nil, err
 	}
 	if body != nil {
@@ -775,6 +905,12 @@ func (conn *Connection) execHTTPRequest(req *http.Request) (int, []byte, error)
 	if conn.Username != "" || conn.Password != "" {
 		req.SetBasicAuth(conn.Username, conn.Password)
 	}
+	if conn.APIKey != "" {
+		req.Header.Set("Authorization", "ApiKey "+base64.StdEncoding.EncodeToString([]byte(conn.APIKey)))
+	}
+	if conn.BearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+conn.BearerToken)
+	}
 
 	for name, value := range conn.Headers {
 		req.Header.Add(name, value)
@@ -788,6 +924,12 @@ func (conn *Connection) execHTTPRequest(req *http.Request) (int, []byte, error)
 		req.Host = host
 	}
 
+	if conn.kerberos != nil {
+		if err := conn.kerberos.SetHeader(req); err != nil {
+			return 0, nil, fmt.Errorf("failed to authenticate with kerberos: %v", err)
+		}
+	}
+
 	resp, err := conn.http.Do(req)
 	if err != nil {
 		return 0, nil, err
@@ -336,6 +336,52 @@ func TestClientWithHeaders(t *testing.T) {
 	assert.Equal(t, 2, requestCount)
 }
 
+func TestClientAuthorization(t *testing.T) {
+	tests := map[string]struct {
+		settings ClientSettings
+		expected string
+	}{
+		"basic auth": {
+			settings: ClientSettings{Username: "elastic", Password: "changeme"},
+			expected: "Basic ZWxhc3RpYzpjaGFuZ2VtZQ==",
+		},
+		"api key": {
+			settings: ClientSettings{APIKey: "id:key"},
+			expected: "ApiKey aWQ6a2V5",
+		},
+		"bearer token": {
+			settings: ClientSettings{BearerToken: "token"},
+			expected: "Bearer token",
+		},
+		"none": {},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var authorization string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				authorization = r.Header.Get("Authorization")
+			}))
+			defer ts.Close()
+
+			settings := test.settings
+			settings.URL = ts.URL
+			client, err := NewClient(settings, nil)
+			if !assert.NoError(t, err) {
+				return
+			}
+
+			client.Ping()
+			assert.Equal(t, test.expected, authorization)
+
+			// The credentials must survive cloning, used by the topology support.
+			authorization = ""
+			client.Clone().Ping()
+			assert.Equal(t, test.expected, authorization)
+		})
+	}
+}
+
 func TestAddToURL(t *testing.T) {
 	type Test struct {
 		url      string
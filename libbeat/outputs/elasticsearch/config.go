@@ -18,27 +18,40 @@
 package elasticsearch
 
 import (
+	"errors"
+	"strings"
 	"time"
 
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/transport/kerberos"
 	"github.com/elastic/beats/libbeat/common/transport/tlscommon"
 )
 
 type elasticsearchConfig struct {
-	Protocol         string            `config:"protocol"`
-	Path             string            `config:"path"`
-	Params           map[string]string `config:"parameters"`
-	Headers          map[string]string `config:"headers"`
-	Username         string            `config:"username"`
-	Password         string            `config:"password"`
-	ProxyURL         string            `config:"proxy_url"`
-	LoadBalance      bool              `config:"loadbalance"`
-	CompressionLevel int               `config:"compression_level" validate:"min=0, max=9"`
-	EscapeHTML       bool              `config:"escape_html"`
-	TLS              *tlscommon.Config `config:"ssl"`
-	BulkMaxSize      int               `config:"bulk_max_size"`
-	MaxRetries       int               `config:"max_retries"`
-	Timeout          time.Duration     `config:"timeout"`
-	Backoff          Backoff           `config:"backoff"`
+	Protocol          string            `config:"protocol"`
+	Path              string            `config:"path"`
+	Params            map[string]string `config:"parameters"`
+	Headers           map[string]string `config:"headers"`
+	Username          string            `config:"username"`
+	Password          string            `config:"password"`
+	APIKey            string            `config:"api_key"`      // API key, formatted as `id:api_key`.
+	BearerToken       string            `config:"bearer_token"` // Token sent in the `Authorization: Bearer` header.
+	Kerberos          *kerberos.Config  `config:"kerberos"`     // Authenticate requests with SPNEGO.
+	ProxyURL          string            `config:"proxy_url"`
+	ProxyLocalResolve bool              `config:"proxy_use_local_resolver"`
+	LoadBalance       bool              `config:"loadbalance"`
+	Sniffing          bool              `config:"sniffing"`
+	SniffingInterval  time.Duration     `config:"sniffing_interval" validate:"positive,nonzero"`
+	Worker            int               `config:"worker"`
+	CompressionLevel  int               `config:"compression_level" validate:"min=0, max=9"`
+	EscapeHTML        bool              `config:"escape_html"`
+	TLS               *tlscommon.Config `config:"ssl"`
+	BulkMaxSize       int               `config:"bulk_max_size"`
+	MaxRetries        int               `config:"max_retries"`
+	Timeout           time.Duration     `config:"timeout"`
+	Backoff           Backoff           `config:"backoff"`
+	DeadLetter        *common.Config    `config:"dead_letter"` // Write events that can not be indexed to a file.
+	ILM               *common.Config    `config:"ilm"`         // Index through a rollover alias managed by an ILM policy.
 }
 
 type Backoff struct {
@@ -64,6 +77,8 @@ var (
 		EscapeHTML:       true,
 		TLS:              nil,
 		LoadBalance:      true,
+		SniffingInterval: 5 * time.Minute,
+		Worker:           1,
 		Backoff: Backoff{
 			Init: 1 * time.Second,
 			Max:  60 * time.Second,
@@ -72,6 +87,26 @@ var (
 )
 
 func (c *elasticsearchConfig) Validate() error {
+	n := 0
+	if c.Username != "" || c.Password != "" {
+		n++
+	}
+	if c.APIKey != "" {
+		n++
+	}
+	if c.BearerToken != "" {
+		n++
+	}
+	if c.Kerberos.IsEnabled() {
+		n++
+	}
+	if n > 1 {
+		return errors.New("only one of username and password, api_key, bearer_token or kerberos can be configured")
+	}
+	if c.APIKey != "" && !strings.Contains(c.APIKey, ":") {
+		return errors.New("api_key must be formatted as <id>:<api_key>")
+	}
+
 	if c.ProxyURL != "" {
 		if _, err := parseProxyURL(c.ProxyURL); err != nil {
 			return err
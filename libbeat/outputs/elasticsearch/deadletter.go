@@ -0,0 +1,224 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/file"
+	"github.com/elastic/beats/libbeat/logp"
+	jsoncodec "github.com/elastic/beats/libbeat/outputs/codec/json"
+	"github.com/elastic/beats/libbeat/paths"
+	"github.com/elastic/beats/libbeat/publisher"
+)
+
+type deadLetterConfig struct {
+	Index         string `config:"index"`
+	Path          string `config:"path"`
+	Filename      string `config:"filename"`
+	RotateEveryKb uint   `config:"rotate_every_kb" validate:"min=1"`
+	NumberOfFiles uint   `config:"number_of_files"`
+	Permissions   uint32 `config:"permissions"`
+}
+
+var defaultDeadLetterConfig = deadLetterConfig{
+	Filename:      "elasticsearch",
+	RotateEveryKb: 10 * 1024,
+	NumberOfFiles: 7,
+	Permissions:   0600,
+}
+
+func (c *deadLetterConfig) Validate() error {
+	if c.NumberOfFiles < 2 || c.NumberOfFiles > file.MaxBackupsLimit {
+		return fmt.Errorf("The number_of_files to keep should be between 2 and %v",
+			file.MaxBackupsLimit)
+	}
+	return nil
+}
+
+// deadLetterQueue writes the events that can not be indexed to a file, so
+// they are not lost. Events rejected by Elasticsearch are written right away.
+// Events failing with temporary errors are written once their batch failed
+// more than maxRetries times, unless they must be sent with guarantees.
+//
+// If an alternate index is configured, rejected events are indexed into it
+// instead, with the original event encoded in the message field. Events the
+// alternate index rejects are written to the file.
+//
+// The queue is shared by all clients of the output, as a batch can be retried
+// by another client.
+type deadLetterQueue struct {
+	maxRetries     int
+	index          string
+	alternateIndex string
+
+	mutex   sync.Mutex
+	rotator *file.Rotator
+	encoder *jsoncodec.Encoder
+}
+
+// deadLetter is a line of the dead letter file.
+type deadLetter struct {
+	Timestamp common.Time     `json:"@timestamp"`
+	Status    int             `json:"status,omitempty"`
+	Error     string          `json:"error"`
+	Event     json.RawMessage `json:"event"`
+}
+
+func newDeadLetterQueue(info beat.Info, cfg *common.Config, maxRetries int) (*deadLetterQueue, error) {
+	config := defaultDeadLetterConfig
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	path := config.Path
+	if path == "" {
+		path = paths.Resolve(paths.Data, "dead_letter")
+	}
+	filename := filepath.Join(path, config.Filename)
+
+	rotator, err := file.NewFileRotator(filename,
+		file.MaxSizeBytes(config.RotateEveryKb*1024),
+		file.MaxBackups(config.NumberOfFiles),
+		file.Permissions(os.FileMode(config.Permissions)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	logp.Info("Elasticsearch dead letter events are written to %v", filename)
+
+	if config.Index != "" {
+		logp.Info("Elasticsearch dead letter events are indexed into %v", config.Index)
+	}
+
+	return &deadLetterQueue{
+		maxRetries:     maxRetries,
+		index:          info.Beat,
+		alternateIndex: config.Index,
+		rotator:        rotator,
+		encoder:        jsoncodec.New(false, true, info.Version),
+	}, nil
+}
+
+// rejected handles an event Elasticsearch refused to index. If an alternate
+// index is configured, the event is replaced by a dead letter event for that
+// index and rejected returns true, so the event is retried. Otherwise the event
+// is written to the file.
+func (q *deadLetterQueue) rejected(event *publisher.Event, status int, msg string) bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if q.alternateIndex != "" && !isDeadLetterEvent(&event.Content) {
+		if content, err := q.deadLetterEvent(&event.Content, status, msg); err == nil {
+			event.Content = content
+			return true
+		}
+	}
+
+	q.write(&event.Content, status, msg)
+	return false
+}
+
+// retry records a failed publish attempt of the events. It writes the events
+// that exhausted their retries and returns the events to be retried.
+func (q *deadLetterQueue) retry(events []publisher.Event) []publisher.Event {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	rest := events[:0]
+	for i := range events {
+		events[i].Attempts++
+		if q.maxRetries < 0 || events[i].Attempts <= q.maxRetries || events[i].Guaranteed() {
+			rest = append(rest, events[i])
+			continue
+		}
+		q.write(&events[i].Content, 0, fmt.Sprintf("failed after %v retries", q.maxRetries))
+	}
+	return rest
+}
+
+// close closes the dead letter file. It is reopened by the next write.
+func (q *deadLetterQueue) close() error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return q.rotator.Close()
+}
+
+// deadLetterEvent creates the event indexed into the alternate index. The
+// original event is stored as a string, so it can not cause mapping errors.
+func (q *deadLetterQueue) deadLetterEvent(event *beat.Event, status int, msg string) (beat.Event, error) {
+	serialized, err := q.encoder.Encode(q.index, event)
+	if err != nil {
+		logp.Err("Failed to encode dead letter event: %v", err)
+		return beat.Event{}, err
+	}
+
+	return beat.Event{
+		Timestamp: time.Now(),
+		Meta: common.MapStr{
+			"index":       q.alternateIndex,
+			"pipeline":    "",
+			"dead_letter": true,
+		},
+		Fields: common.MapStr{
+			"message": string(serialized),
+			"error": common.MapStr{
+				"code":    status,
+				"message": msg,
+			},
+		},
+	}, nil
+}
+
+func isDeadLetterEvent(event *beat.Event) bool {
+	if event.Meta == nil {
+		return false
+	}
+	_, exists := event.Meta["dead_letter"]
+	return exists
+}
+
+func (q *deadLetterQueue) write(event *beat.Event, status int, msg string) {
+	serialized, err := q.encoder.Encode(q.index, event)
+	if err != nil {
+		logp.Err("Failed to encode dead letter event: %v", err)
+		return
+	}
+
+	line, err := json.Marshal(deadLetter{
+		Timestamp: common.Time(time.Now()),
+		Status:    status,
+		Error:     msg,
+		Event:     json.RawMessage(serialized),
+	})
+	if err != nil {
+		logp.Err("Failed to encode dead letter event: %v", err)
+		return
+	}
+
+	if _, err := q.rotator.Write(append(line, '\n')); err != nil {
+		logp.Err("Failed to write dead letter event: %v", err)
+	}
+}
@@ -0,0 +1,199 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package elasticsearch
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/outputs"
+	"github.com/elastic/beats/libbeat/outputs/outest"
+	"github.com/elastic/beats/libbeat/outputs/outil"
+	"github.com/elastic/beats/libbeat/publisher"
+)
+
+func newTestDeadLetterQueue(t *testing.T, maxRetries int) (*deadLetterQueue, string) {
+	dir, err := ioutil.TempDir("", "dead_letter")
+	require.NoError(t, err)
+
+	cfg := common.MustNewConfigFrom(map[string]interface{}{"path": dir})
+	q, err := newDeadLetterQueue(beat.Info{Beat: "testbeat", Version: "1.0.0"}, cfg, maxRetries)
+	require.NoError(t, err)
+	return q, dir
+}
+
+func readDeadLetters(t *testing.T, dir string) []deadLetter {
+	f, err := os.Open(filepath.Join(dir, "elasticsearch"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	require.NoError(t, err)
+	defer f.Close()
+
+	var letters []deadLetter
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var letter deadLetter
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &letter))
+		letters = append(letters, letter)
+	}
+	return letters
+}
+
+func eventMessage(t *testing.T, letter deadLetter) interface{} {
+	var event map[string]interface{}
+	require.NoError(t, json.Unmarshal(letter.Event, &event))
+	return event["message"]
+}
+
+func TestDeadLetterRetry(t *testing.T) {
+	q, dir := newTestDeadLetterQueue(t, 2)
+	defer os.RemoveAll(dir)
+
+	batch := outest.NewBatch(
+		beat.Event{Fields: common.MapStr{"message": "a"}},
+		beat.Event{Fields: common.MapStr{"message": "b"}},
+	)
+	events := batch.Events()
+	events[1].Flags = publisher.GuaranteedSend
+
+	assert.Len(t, q.retry(events), 2)
+	assert.Len(t, q.retry(events), 2)
+	assert.Empty(t, readDeadLetters(t, dir))
+
+	// After max_retries, only the guaranteed events are retried.
+	rest := q.retry(events)
+	require.Len(t, rest, 1)
+	assert.Equal(t, "b", rest[0].Content.Fields["message"])
+
+	letters := readDeadLetters(t, dir)
+	require.Len(t, letters, 1)
+	assert.Equal(t, "failed after 2 retries", letters[0].Error)
+	assert.Equal(t, "a", eventMessage(t, letters[0]))
+	assert.Equal(t, 3, rest[0].Attempts)
+}
+
+func TestDeadLetterRejected(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"items": [
+			{"index": {"status": 201}},
+			{"index": {"status": 400, "error": {"type": "mapper_parsing_exception"}}},
+			{"index": {"status": 429}}
+		]}`)
+	}))
+	defer ts.Close()
+
+	q, dir := newTestDeadLetterQueue(t, 0)
+	defer os.RemoveAll(dir)
+
+	client, err := NewClient(ClientSettings{
+		URL:      ts.URL,
+		Index:    outil.MakeSelector(outil.ConstSelectorExpr("test")),
+		Observer: outputs.NewNilObserver(),
+	}, nil)
+	require.NoError(t, err)
+	client.deadLetter = q
+
+	batch := outest.NewBatch(
+		beat.Event{Fields: common.MapStr{"message": "ok"}},
+		beat.Event{Fields: common.MapStr{"message": "rejected"}},
+		beat.Event{Fields: common.MapStr{"message": "too many"}},
+	)
+	assert.Error(t, client.Publish(batch))
+
+	// With max_retries 0, the failed event is written right away too.
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+
+	letters := readDeadLetters(t, dir)
+	require.Len(t, letters, 2)
+	assert.Equal(t, 400, letters[0].Status)
+	assert.Equal(t, `{"type": "mapper_parsing_exception"}`, letters[0].Error)
+	assert.Equal(t, "rejected", eventMessage(t, letters[0]))
+	assert.Equal(t, "too many", eventMessage(t, letters[1]))
+
+	// Closing the client closes the file, later events are appended to it.
+	require.NoError(t, client.Close())
+	q.rejected(&publisher.Event{Content: beat.Event{Fields: common.MapStr{"message": "later"}}}, 400, "error")
+	assert.Len(t, readDeadLetters(t, dir), 3)
+}
+
+func TestDeadLetterIndex(t *testing.T) {
+	var requests []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, string(body))
+		status := 400
+		if len(requests) > 1 {
+			status = 201
+		}
+		fmt.Fprintf(w, `{"items": [{"index": {"status": %d, "error": "mapping"}}]}`, status)
+	}))
+	defer ts.Close()
+
+	dir, err := ioutil.TempDir("", "dead_letter")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	cfg := common.MustNewConfigFrom(map[string]interface{}{"path": dir, "index": "failed"})
+	q, err := newDeadLetterQueue(beat.Info{Beat: "testbeat", Version: "1.0.0"}, cfg, 3)
+	require.NoError(t, err)
+
+	client, err := NewClient(ClientSettings{
+		URL:      ts.URL,
+		Index:    outil.MakeSelector(outil.ConstSelectorExpr("test")),
+		Observer: outputs.NewNilObserver(),
+	}, nil)
+	require.NoError(t, err)
+	client.deadLetter = q
+
+	batch := outest.NewBatch(beat.Event{Fields: common.MapStr{"message": "rejected"}})
+	assert.Error(t, client.Publish(batch))
+
+	// The rejected event is retried as a dead letter event.
+	require.Len(t, batch.Signals, 1)
+	require.Equal(t, outest.BatchRetryEvents, batch.Signals[0].Tag)
+	events := batch.Signals[0].Events
+	require.Len(t, events, 1)
+	assert.True(t, isDeadLetterEvent(&events[0].Content))
+
+	retry := outest.NewBatch(events[0].Content)
+	assert.NoError(t, client.Publish(retry))
+	require.Len(t, retry.Signals, 1)
+	assert.Equal(t, outest.BatchACK, retry.Signals[0].Tag)
+
+	require.Len(t, requests, 2)
+	assert.Contains(t, requests[1], `"_index":"failed-`)
+	assert.Contains(t, requests[1], `"message":"{\"@timestamp\"`)
+	assert.Contains(t, requests[1], `"code":400`)
+	assert.Empty(t, readDeadLetters(t, dir))
+}
@@ -104,16 +104,37 @@ func makeES(
 		cfg.SetInt("bulk_max_size", -1, defaultBulkSize)
 	}
 
-	if !cfg.HasField("index") {
-		pattern := fmt.Sprintf("%v-%v-%%{+yyyy.MM.dd}", beat.IndexPrefix, beat.Version)
-		cfg.SetString("index", -1, pattern)
-	}
-
 	config := defaultConfig
 	if err := cfg.Unpack(&config); err != nil {
 		return outputs.Fail(err)
 	}
 
+	// With ILM, events are indexed through the rollover alias instead of
+	// daily indices. Indices selected by other rules would not be rolled
+	// over, so index and indices are ignored.
+	var ilm *ilmManager
+	indicesKey := "indices"
+	if config.ILM.Enabled() {
+		var err error
+		ilm, err = newILMManager(beat, config.ILM)
+		if err != nil {
+			return outputs.Fail(err)
+		}
+		if cfg.HasField("index") {
+			logp.Warn("ILM is enabled, the index setting is ignored in favor of the rollover alias %v", ilm.alias)
+		}
+		if cfg.HasField("indices") {
+			logp.Warn("ILM is enabled, the indices setting is ignored in favor of the rollover alias %v", ilm.alias)
+		}
+		cfg.SetString("index", -1, ilm.alias)
+		indicesKey = ""
+	}
+
+	if !cfg.HasField("index") {
+		pattern := fmt.Sprintf("%v-%v-%%{+yyyy.MM.dd}", beat.IndexPrefix, beat.Version)
+		cfg.SetString("index", -1, pattern)
+	}
+
 	hosts, err := outputs.ReadHostList(cfg)
 	if err != nil {
 		return outputs.Fail(err)
@@ -121,7 +142,7 @@ func makeES(
 
 	index, err := outil.BuildSelectorFromConfig(cfg, outil.Settings{
 		Key:              "index",
-		MultiKey:         "indices",
+		MultiKey:         indicesKey,
 		EnableSingleOnly: true,
 		FailEmpty:        true,
 	})
@@ -162,39 +183,101 @@ func makeES(
 		params = nil
 	}
 
-	clients := make([]outputs.NetworkClient, len(hosts))
-	for i, host := range hosts {
+	// With a dead letter file, the clients drop events after max_retries,
+	// writing them to the file, so the pipeline must retry indefinitely.
+	var deadLetter *deadLetterQueue
+	maxRetries := config.MaxRetries
+	if config.DeadLetter.Enabled() {
+		deadLetter, err = newDeadLetterQueue(beat, config.DeadLetter, config.MaxRetries)
+		if err != nil {
+			return outputs.Fail(err)
+		}
+		maxRetries = -1
+	}
+
+	newClient := func(host string) (*Client, error) {
 		esURL, err := common.MakeURL(config.Protocol, config.Path, host, 9200)
 		if err != nil {
 			logp.Err("Invalid host param set: %s, Error: %v", host, err)
-			return outputs.Fail(err)
+			return nil, err
 		}
 
-		var client outputs.NetworkClient
-		client, err = NewClient(ClientSettings{
-			URL:              esURL,
-			Index:            index,
-			Pipeline:         pipeline,
-			Proxy:            proxyURL,
-			TLS:              tlsConfig,
-			Username:         config.Username,
-			Password:         config.Password,
-			Parameters:       params,
-			Headers:          config.Headers,
-			Timeout:          config.Timeout,
-			CompressionLevel: config.CompressionLevel,
-			Observer:         observer,
-			EscapeHTML:       config.EscapeHTML,
+		esClient, err := NewClient(ClientSettings{
+			URL:               esURL,
+			Index:             index,
+			Pipeline:          pipeline,
+			Proxy:             proxyURL,
+			ProxyLocalResolve: config.ProxyLocalResolve,
+			TLS:               tlsConfig,
+			Username:          config.Username,
+			Password:          config.Password,
+			APIKey:            config.APIKey,
+			BearerToken:       config.BearerToken,
+			Kerberos:          config.Kerberos,
+			Parameters:        params,
+			Headers:           config.Headers,
+			Timeout:           config.Timeout,
+			CompressionLevel:  config.CompressionLevel,
+			Observer:          observer,
+			EscapeHTML:        config.EscapeHTML,
 		}, &connectCallbackRegistry)
 		if err != nil {
-			return outputs.Fail(err)
+			return nil, err
 		}
+		esClient.deadLetter = deadLetter
+		esClient.ilm = ilm
+		return esClient, nil
+	}
 
-		client = outputs.WithBackoff(client, config.Backoff.Init, config.Backoff.Max)
-		clients[i] = client
+	var clients []outputs.NetworkClient
+	if config.Sniffing {
+		sniffer := newSniffer(hosts, config.SniffingInterval, func(host string) (*Client, error) {
+			esURL, err := common.MakeURL(config.Protocol, config.Path, host, 9200)
+			if err != nil {
+				return nil, err
+			}
+			client, err := NewClient(ClientSettings{
+				URL:               esURL,
+				Proxy:             proxyURL,
+				ProxyLocalResolve: config.ProxyLocalResolve,
+				TLS:               tlsConfig,
+				Username:          config.Username,
+				Password:          config.Password,
+				APIKey:            config.APIKey,
+				BearerToken:       config.BearerToken,
+				Kerberos:          config.Kerberos,
+				Headers:           config.Headers,
+				Timeout:           config.Timeout,
+			}, nil)
+			if err != nil {
+				return nil, err
+			}
+			if err := client.Connect(); err != nil {
+				client.Close()
+				return nil, err
+			}
+			return client, nil
+		})
+
+		// The number of clients is set by the nodes sniffed on startup, the
+		// sniffer moves them to the current nodes afterwards.
+		clients = make([]outputs.NetworkClient, sniffer.numHosts()*config.Worker)
+		for i := range clients {
+			client := newSniffClient(i, sniffer, newClient)
+			clients[i] = outputs.WithBackoff(client, config.Backoff.Init, config.Backoff.Max)
+		}
+	} else {
+		clients = make([]outputs.NetworkClient, len(hosts))
+		for i, host := range hosts {
+			esClient, err := newClient(host)
+			if err != nil {
+				return outputs.Fail(err)
+			}
+			clients[i] = outputs.WithBackoff(esClient, config.Backoff.Init, config.Backoff.Max)
+		}
 	}
 
-	return outputs.SuccessNet(config.LoadBalance, config.BulkMaxSize, config.MaxRetries, clients)
+	return outputs.SuccessNet(config.LoadBalance, config.BulkMaxSize, maxRetries, clients)
 }
 
 // NewConnectedClient creates a new Elasticsearch client based on the given config.
@@ -264,15 +347,19 @@ func NewElasticsearchClients(cfg *common.Config) ([]Client, error) {
 		}
 
 		client, err := NewClient(ClientSettings{
-			URL:              esURL,
-			Proxy:            proxyURL,
-			TLS:              tlsConfig,
-			Username:         config.Username,
-			Password:         config.Password,
-			Parameters:       params,
-			Headers:          config.Headers,
-			Timeout:          config.Timeout,
-			CompressionLevel: config.CompressionLevel,
+			URL:               esURL,
+			Proxy:             proxyURL,
+			ProxyLocalResolve: config.ProxyLocalResolve,
+			TLS:               tlsConfig,
+			Username:          config.Username,
+			Password:          config.Password,
+			APIKey:            config.APIKey,
+			BearerToken:       config.BearerToken,
+			Kerberos:          config.Kerberos,
+			Parameters:        params,
+			Headers:           config.Headers,
+			Timeout:           config.Timeout,
+			CompressionLevel:  config.CompressionLevel,
 		}, nil)
 		if err != nil {
 			return clients, err
@@ -20,6 +20,10 @@ package elasticsearch
 import (
 	"fmt"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/common"
 )
 
 func TestConnectCallbacksManagement(t *testing.T) {
@@ -37,3 +41,48 @@ func TestConnectCallbacksManagement(t *testing.T) {
 		t.Fatalf("third callback cannot be retrieved")
 	}
 }
+
+func TestConfigCredentials(t *testing.T) {
+	tests := map[string]struct {
+		config map[string]interface{}
+		valid  bool
+	}{
+		"basic auth":   {map[string]interface{}{"username": "elastic", "password": "changeme"}, true},
+		"api key":      {map[string]interface{}{"api_key": "id:key"}, true},
+		"bearer token": {map[string]interface{}{"bearer_token": "token"}, true},
+		"api key without id": {
+			map[string]interface{}{"api_key": "key"}, false,
+		},
+		"api key and basic auth": {
+			map[string]interface{}{"api_key": "id:key", "username": "elastic"}, false,
+		},
+		"api key and bearer token": {
+			map[string]interface{}{"api_key": "id:key", "bearer_token": "token"}, false,
+		},
+		"kerberos": {
+			map[string]interface{}{"kerberos": map[string]interface{}{
+				"config_path": "krb5.conf", "keytab": "beat.keytab", "username": "beat", "realm": "EXAMPLE.COM",
+			}}, true,
+		},
+		"kerberos and basic auth": {
+			map[string]interface{}{"username": "elastic", "kerberos": map[string]interface{}{
+				"config_path": "krb5.conf", "keytab": "beat.keytab", "username": "beat", "realm": "EXAMPLE.COM",
+			}}, false,
+		},
+		"disabled kerberos and basic auth": {
+			map[string]interface{}{"username": "elastic", "kerberos.enabled": false}, true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := defaultConfig
+			err := common.MustNewConfigFrom(test.config).Unpack(&config)
+			if test.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
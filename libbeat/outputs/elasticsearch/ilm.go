@@ -0,0 +1,197 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/paths"
+)
+
+type ilmConfig struct {
+	RolloverAlias string `config:"rollover_alias"`
+	Pattern       string `config:"pattern"`
+	PolicyName    string `config:"policy_name"`
+	PolicyFile    string `config:"policy_file"`
+}
+
+var defaultILMConfig = ilmConfig{
+	Pattern: "000001",
+}
+
+// defaultILMPolicy rolls the write index over once it is 50GB or 30 days old.
+var defaultILMPolicy = common.MapStr{
+	"policy": common.MapStr{
+		"phases": common.MapStr{
+			"hot": common.MapStr{
+				"actions": common.MapStr{
+					"rollover": common.MapStr{
+						"max_size": "50gb",
+						"max_age":  "30d",
+					},
+				},
+			},
+		},
+	},
+}
+
+// ILM requires Elasticsearch 6.6 or newer.
+var ilmMinVersion = &common.Version{Major: 6, Minor: 6}
+
+// ilmManager sets up index lifecycle management when a client connects. The
+// events are indexed through the rollover alias, which points to the current
+// write index. The indices matching the alias are managed by the policy.
+type ilmManager struct {
+	alias      string
+	pattern    string
+	policyName string
+	policy     common.MapStr
+}
+
+func newILMManager(info beat.Info, cfg *common.Config) (*ilmManager, error) {
+	config := defaultILMConfig
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	name := fmt.Sprintf("%v-%v", info.IndexPrefix, info.Version)
+	m := &ilmManager{
+		alias:      config.RolloverAlias,
+		pattern:    config.Pattern,
+		policyName: config.PolicyName,
+		policy:     defaultILMPolicy,
+	}
+	if m.alias == "" {
+		m.alias = name
+	}
+	if m.policyName == "" {
+		m.policyName = info.IndexPrefix
+	}
+
+	if config.PolicyFile != "" {
+		path := paths.Resolve(paths.Config, config.PolicyFile)
+		contents, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ILM policy file %v: %v", path, err)
+		}
+		var policy common.MapStr
+		if err := json.Unmarshal(contents, &policy); err != nil {
+			return nil, fmt.Errorf("failed to parse ILM policy file %v: %v", path, err)
+		}
+		m.policy = policy
+	}
+	return m, nil
+}
+
+// setup creates the policy, the template assigning the policy to new indices
+// and the first write index with the rollover alias, if they do not exist yet.
+func (m *ilmManager) setup(client *Client) error {
+	version, err := common.NewVersion(client.GetVersion())
+	if err != nil {
+		return fmt.Errorf("failed to parse the Elasticsearch version: %v", err)
+	}
+	if version.LessThan(ilmMinVersion) {
+		return fmt.Errorf("ILM requires Elasticsearch %v or newer, connected to %v",
+			ilmMinVersion, version)
+	}
+
+	if err := m.ensurePolicy(client); err != nil {
+		return err
+	}
+	if err := m.loadTemplate(client); err != nil {
+		return err
+	}
+	return m.ensureAlias(client)
+}
+
+func (m *ilmManager) ensurePolicy(client *Client) error {
+	path := "/_ilm/policy/" + m.policyName
+	status, _, err := client.Request("GET", path, "", nil, nil)
+	if status == 200 {
+		return nil
+	}
+	if status != 404 {
+		return fmt.Errorf("failed to check ILM policy %v: %v", m.policyName, err)
+	}
+
+	if _, err := client.LoadJSON(path, m.policy); err != nil {
+		return fmt.Errorf("failed to create ILM policy %v: %v", m.policyName, err)
+	}
+	logp.Info("ILM policy %v created", m.policyName)
+	return nil
+}
+
+// loadTemplate installs a template with a higher order than the Beat
+// template, so it can be combined with it or with a custom one.
+func (m *ilmManager) loadTemplate(client *Client) error {
+	name := m.alias + "-ilm"
+	template := common.MapStr{
+		"order":          2,
+		"index_patterns": []string{m.alias + "-*"},
+		"settings": common.MapStr{
+			"index.lifecycle.name":           m.policyName,
+			"index.lifecycle.rollover_alias": m.alias,
+		},
+	}
+	if _, err := client.LoadJSON("/_template/"+name, template); err != nil {
+		return fmt.Errorf("failed to load ILM template %v: %v", name, err)
+	}
+	return nil
+}
+
+func (m *ilmManager) ensureAlias(client *Client) error {
+	exists, err := m.aliasExists(client)
+	if err != nil || exists {
+		return err
+	}
+
+	index := m.alias + "-" + m.pattern
+	body := common.MapStr{
+		"aliases": common.MapStr{
+			m.alias: common.MapStr{
+				"is_write_index": true,
+			},
+		},
+	}
+	if _, err := client.LoadJSON("/"+index, body); err != nil {
+		// Another client or Beat may have created the index in the meantime.
+		if exists, _ := m.aliasExists(client); exists {
+			return nil
+		}
+		return fmt.Errorf("failed to create write index %v: %v", index, err)
+	}
+	logp.Info("Write index %v created with alias %v", index, m.alias)
+	return nil
+}
+
+func (m *ilmManager) aliasExists(client *Client) (bool, error) {
+	status, _, err := client.Request("HEAD", "/_alias/"+m.alias, "", nil, nil)
+	switch status {
+	case 200:
+		return true, nil
+	case 404:
+		return false, nil
+	default:
+		return false, fmt.Errorf("failed to check alias %v: %v", m.alias, err)
+	}
+}
@@ -0,0 +1,140 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package elasticsearch
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/outputs"
+)
+
+// ilmMock answers the ILM setup requests, with the policy and the alias
+// initially missing unless present is set.
+func ilmMock(version string, present bool) (*httptest.Server, *[]string) {
+	var mutex sync.Mutex
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		if r.URL.Path == "/" {
+			w.Write([]byte(`{"version":{"number":"` + version + `"}}`))
+			return
+		}
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method != "PUT" && !present {
+			w.WriteHeader(http.StatusNotFound)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	return server, &requests
+}
+
+func newTestILMClient(t *testing.T, url string) *Client {
+	info := beat.Info{Beat: "testbeat", IndexPrefix: "testbeat", Version: "7.0.0"}
+	ilm, err := newILMManager(info, common.NewConfig())
+	require.NoError(t, err)
+
+	client := newTestClient(url)
+	client.ilm = ilm
+	return client
+}
+
+func TestILMSetup(t *testing.T) {
+	server, requests := ilmMock("6.6.0", false)
+	defer server.Close()
+
+	client := newTestILMClient(t, server.URL)
+	require.NoError(t, client.Connect())
+
+	assert.Equal(t, []string{
+		"GET /_ilm/policy/testbeat",
+		"PUT /_ilm/policy/testbeat",
+		"PUT /_template/testbeat-7.0.0-ilm",
+		"HEAD /_alias/testbeat-7.0.0",
+		"PUT /testbeat-7.0.0-000001",
+	}, *requests)
+}
+
+func TestILMSetupExisting(t *testing.T) {
+	server, requests := ilmMock("6.6.0", true)
+	defer server.Close()
+
+	client := newTestILMClient(t, server.URL)
+	require.NoError(t, client.Connect())
+
+	assert.Equal(t, []string{
+		"GET /_ilm/policy/testbeat",
+		"PUT /_template/testbeat-7.0.0-ilm",
+		"HEAD /_alias/testbeat-7.0.0",
+	}, *requests)
+}
+
+func TestILMSetupUnsupportedVersion(t *testing.T) {
+	server, requests := ilmMock("6.5.4", false)
+	defer server.Close()
+
+	client := newTestILMClient(t, server.URL)
+	assert.Error(t, client.Connect())
+	assert.Empty(t, *requests)
+}
+
+func TestILMConfig(t *testing.T) {
+	info := beat.Info{Beat: "testbeat", IndexPrefix: "testbeat", Version: "7.0.0"}
+	cfg := common.MustNewConfigFrom(map[string]interface{}{
+		"rollover_alias": "custom",
+		"policy_name":    "custom-policy",
+		"pattern":        "00001",
+	})
+	ilm, err := newILMManager(info, cfg)
+	require.NoError(t, err)
+
+	assert.Equal(t, "custom", ilm.alias)
+	assert.Equal(t, "custom-policy", ilm.policyName)
+	assert.Equal(t, "00001", ilm.pattern)
+	assert.Equal(t, defaultILMPolicy, ilm.policy)
+}
+
+func TestILMIgnoresIndices(t *testing.T) {
+	info := beat.Info{Beat: "testbeat", IndexPrefix: "testbeat", Version: "7.0.0"}
+	cfg := common.MustNewConfigFrom(map[string]interface{}{
+		"hosts":       []string{"localhost:9200"},
+		"ilm.enabled": true,
+		"indices": []map[string]interface{}{
+			{"index": "custom-%{[type]}"},
+		},
+	})
+	group, err := makeES(info, outputs.NewNilObserver(), cfg)
+	require.NoError(t, err)
+
+	require.Len(t, group.Clients, 1)
+	client := group.Clients[0].(interface{ Client() outputs.NetworkClient }).Client().(*Client)
+	index, err := client.index.Select(&beat.Event{Fields: common.MapStr{"type": "test"}})
+	require.NoError(t, err)
+	assert.Equal(t, "testbeat-7.0.0", index)
+}
@@ -0,0 +1,330 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/publisher"
+	"github.com/elastic/beats/libbeat/testing"
+)
+
+// nodesInfo is the part of the response of the _nodes/http API used for
+// sniffing.
+type nodesInfo struct {
+	Nodes map[string]struct {
+		Roles []string `json:"roles"`
+		HTTP  struct {
+			PublishAddress string `json:"publish_address"`
+		} `json:"http"`
+	} `json:"nodes"`
+}
+
+// sniffer keeps the list of nodes of the cluster up to date. While clients are
+// connected, it sniffs the nodes periodically in the background, and again when
+// a client fails.
+type sniffer struct {
+	seeds    []string
+	interval time.Duration
+	connect  func(host string) (*Client, error)
+	trigger  chan struct{}
+
+	mutex sync.Mutex
+	hosts []string
+	refs  int
+	done  chan struct{}
+}
+
+// sniffClient publishes to the node of its slot in the sniffed nodes, switching
+// to another node when the slot is assigned a new one.
+type sniffClient struct {
+	slot      int
+	sniffer   *sniffer
+	newClient func(host string) (*Client, error)
+
+	host    string
+	client  *Client
+	started bool
+}
+
+// newSniffer sniffs the nodes of the cluster from the seed hosts. The seed hosts
+// are used if no node could be sniffed.
+func newSniffer(seeds []string, interval time.Duration, connect func(host string) (*Client, error)) *sniffer {
+	seeds = uniqueHosts(seeds)
+	hosts := sniffHosts(seeds, connect)
+	if len(hosts) == 0 {
+		logp.Warn("No Elasticsearch node sniffed, using the configured hosts")
+		hosts = seeds
+	}
+
+	return &sniffer{
+		seeds:    seeds,
+		interval: interval,
+		connect:  connect,
+		trigger:  make(chan struct{}, 1),
+		hosts:    hosts,
+	}
+}
+
+func (s *sniffer) numHosts() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return len(s.hosts)
+}
+
+// host returns the node assigned to a client slot.
+func (s *sniffer) host(slot int) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.hosts[slot%len(s.hosts)]
+}
+
+// start starts sniffing in the background, if no other client did already.
+func (s *sniffer) start() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.refs++
+	if s.refs == 1 {
+		s.done = make(chan struct{})
+		go s.run(s.done)
+	}
+}
+
+// stop stops sniffing in the background once all clients are closed.
+func (s *sniffer) stop() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.refs--
+	if s.refs == 0 {
+		close(s.done)
+	}
+}
+
+// sniffNow asks for the nodes to be sniffed again, after a failure. Requests
+// made while the nodes are sniffed are merged.
+func (s *sniffer) sniffNow() {
+	select {
+	case s.trigger <- struct{}{}:
+	default:
+	}
+}
+
+func (s *sniffer) run(done <-chan struct{}) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		case <-s.trigger:
+		}
+		s.sniff()
+	}
+}
+
+// sniff updates the nodes, asking the current nodes first and the seed hosts
+// next. The current nodes are kept if no node could be sniffed.
+func (s *sniffer) sniff() {
+	s.mutex.Lock()
+	current := s.hosts
+	s.mutex.Unlock()
+
+	hosts := sniffHosts(uniqueHosts(append(append([]string{}, current...), s.seeds...)), s.connect)
+	if len(hosts) == 0 {
+		logp.Warn("No Elasticsearch node sniffed, keeping the nodes %v", current)
+		return
+	}
+
+	s.mutex.Lock()
+	s.hosts = hosts
+	s.mutex.Unlock()
+}
+
+func newSniffClient(slot int, sniffer *sniffer, newClient func(host string) (*Client, error)) *sniffClient {
+	return &sniffClient{
+		slot:      slot,
+		sniffer:   sniffer,
+		newClient: newClient,
+	}
+}
+
+func (c *sniffClient) Connect() error {
+	if !c.started {
+		c.sniffer.start()
+		c.started = true
+	}
+	return c.connect(c.sniffer.host(c.slot))
+}
+
+func (c *sniffClient) connect(host string) error {
+	if c.client != nil {
+		// Only close the connection, the dead letter file is shared by all
+		// clients.
+		c.client.Connection.Close()
+		c.client = nil
+	}
+
+	client, err := c.newClient(host)
+	if err == nil {
+		err = client.Connect()
+	}
+	if err != nil {
+		c.sniffer.sniffNow()
+		return err
+	}
+
+	c.host = host
+	c.client = client
+	return nil
+}
+
+func (c *sniffClient) Publish(batch publisher.Batch) error {
+	if host := c.sniffer.host(c.slot); host != c.host || c.client == nil {
+		logp.Info("Moving Elasticsearch client from %v to %v", c.host, host)
+		if err := c.connect(host); err != nil {
+			batch.Retry()
+			return err
+		}
+	}
+
+	err := c.client.Publish(batch)
+	if err != nil {
+		c.sniffer.sniffNow()
+	}
+	return err
+}
+
+func (c *sniffClient) Close() error {
+	if c.started {
+		c.sniffer.stop()
+		c.started = false
+	}
+	if c.client == nil {
+		return nil
+	}
+
+	err := c.client.Close()
+	c.client = nil
+	return err
+}
+
+func (c *sniffClient) Test(d testing.Driver) {
+	client, err := c.newClient(c.sniffer.host(c.slot))
+	d.Fatal("elasticsearch client", err)
+	client.Test(d)
+}
+
+func (c *sniffClient) String() string {
+	return "elasticsearch(sniffed node " + c.sniffer.host(c.slot) + ")"
+}
+
+func uniqueHosts(hosts []string) []string {
+	seen := make(map[string]bool, len(hosts))
+	unique := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		if !seen[host] {
+			seen[host] = true
+			unique = append(unique, host)
+		}
+	}
+	return unique
+}
+
+// sniffHosts asks the hosts for the nodes of the cluster, returning the HTTP
+// publish address of all nodes but dedicated master nodes. No host is returned
+// if no host could be sniffed.
+func sniffHosts(hosts []string, connect func(host string) (*Client, error)) []string {
+	for _, host := range hosts {
+		client, err := connect(host)
+		if err != nil {
+			logp.Warn("Failed to sniff nodes from %v: %v", host, err)
+			continue
+		}
+
+		sniffed, err := client.sniffNodes()
+		client.Close()
+		if err != nil {
+			logp.Warn("Failed to sniff nodes from %v: %v", host, err)
+			continue
+		}
+		if len(sniffed) > 0 {
+			logp.Info("Sniffed Elasticsearch nodes: %v", sniffed)
+			return sniffed
+		}
+	}
+
+	return nil
+}
+
+func (client *Client) sniffNodes() ([]string, error) {
+	status, body, err := client.Request("GET", "/_nodes/http", "", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if status != 200 {
+		return nil, fmt.Errorf("unexpected status code %v", status)
+	}
+
+	var info nodesInfo
+	if err := json.Unmarshal(body, &info); err != nil {
+		return nil, err
+	}
+
+	var hosts []string
+	for _, node := range info.Nodes {
+		if isDedicatedMaster(node.Roles) {
+			continue
+		}
+		if host := publishHost(node.HTTP.PublishAddress); host != "" {
+			hosts = append(hosts, host)
+		}
+	}
+	return hosts, nil
+}
+
+func isDedicatedMaster(roles []string) bool {
+	return len(roles) == 1 && roles[0] == "master"
+}
+
+// publishHost returns the host of a publish address. Publish addresses are
+// either ip:port or hostname/ip:port, in which case the hostname is used so
+// TLS certificates can be verified.
+func publishHost(address string) string {
+	i := strings.IndexByte(address, '/')
+	if i < 0 {
+		return address
+	}
+
+	hostname, ip := address[:i], address[i+1:]
+	if hostname == "" {
+		return ip
+	}
+	if j := strings.LastIndexByte(ip, ':'); j >= 0 {
+		return hostname + ip[j:]
+	}
+	return hostname
+}
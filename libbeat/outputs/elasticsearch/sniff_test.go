@@ -0,0 +1,172 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package elasticsearch
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/outputs/outest"
+	"github.com/elastic/beats/libbeat/outputs/outil"
+)
+
+func TestPublishHost(t *testing.T) {
+	tests := map[string]string{
+		"10.0.0.1:9200":           "10.0.0.1:9200",
+		"es1.local/10.0.0.1:9200": "es1.local:9200",
+		"/10.0.0.1:9200":          "10.0.0.1:9200",
+		"es1.local/[::1]:9200":    "es1.local:9200",
+		"":                        "",
+	}
+
+	for address, expected := range tests {
+		assert.Equal(t, expected, publishHost(address), address)
+	}
+}
+
+func TestSniffHosts(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/_nodes/http" {
+			fmt.Fprintln(w, `{"version": {"number": "6.4.0"}}`)
+			return
+		}
+		fmt.Fprintln(w, `{"nodes": {
+			"a": {"roles": ["master", "data", "ingest"], "http": {"publish_address": "10.0.0.1:9200"}},
+			"b": {"roles": ["data"], "http": {"publish_address": "es2.local/10.0.0.2:9200"}},
+			"c": {"roles": ["master"], "http": {"publish_address": "10.0.0.3:9200"}}
+		}}`)
+	}))
+	defer ts.Close()
+
+	connect := func(host string) (*Client, error) {
+		if host == "down:9200" {
+			return nil, errors.New("connection refused")
+		}
+		client, err := NewClient(ClientSettings{URL: ts.URL}, nil)
+		if err != nil {
+			return nil, err
+		}
+		return client, client.Connect()
+	}
+
+	hosts := sniffHosts([]string{"down:9200", "up:9200"}, connect)
+	sort.Strings(hosts)
+	assert.Equal(t, []string{"10.0.0.1:9200", "es2.local:9200"}, hosts)
+
+	hosts = sniffHosts([]string{"down:9200"}, connect)
+	assert.Empty(t, hosts)
+}
+
+func TestSnifferUpdatesClients(t *testing.T) {
+	var mutex sync.Mutex
+	var nodes []string
+	setNodes := func(hosts ...string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		nodes = hosts
+	}
+
+	var bulkHosts []string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		switch r.URL.Path {
+		case "/_nodes/http":
+			fmt.Fprint(w, `{"nodes": {`)
+			for i, node := range nodes {
+				if i > 0 {
+					fmt.Fprint(w, ",")
+				}
+				fmt.Fprintf(w, `"%d": {"roles": ["data"], "http": {"publish_address": "%s"}}`, i, node)
+			}
+			fmt.Fprint(w, `}}`)
+		case "/_bulk":
+			bulkHosts = append(bulkHosts, r.Host)
+			fmt.Fprintln(w, `{"items": []}`)
+		default:
+			fmt.Fprintln(w, `{"version": {"number": "6.4.0"}}`)
+		}
+	})
+	ts1 := httptest.NewServer(handler)
+	defer ts1.Close()
+	ts2 := httptest.NewServer(handler)
+	defer ts2.Close()
+	host1 := strings.TrimPrefix(ts1.URL, "http://")
+	host2 := strings.TrimPrefix(ts2.URL, "http://")
+
+	newClient := func(host string) (*Client, error) {
+		return NewClient(ClientSettings{
+			URL:   "http://" + host,
+			Index: outil.MakeSelector(outil.ConstSelectorExpr("test")),
+		}, nil)
+	}
+	connect := func(host string) (*Client, error) {
+		client, err := newClient(host)
+		if err != nil {
+			return nil, err
+		}
+		return client, client.Connect()
+	}
+
+	setNodes(host1)
+	sniffer := newSniffer([]string{host1, host1}, time.Hour, connect)
+	assert.Equal(t, 1, sniffer.numHosts())
+
+	client := newSniffClient(0, sniffer, newClient)
+	require.NoError(t, client.Connect())
+	defer client.Close()
+	assert.Equal(t, host1, client.host)
+
+	// A failure triggers sniffing in the background, moving the client to
+	// the new node with the next batch.
+	setNodes(host2)
+	sniffer.sniffNow()
+	for i := 0; sniffer.host(0) != host2; i++ {
+		require.True(t, i < 100, "nodes not sniffed again")
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	event := beat.Event{Fields: common.MapStr{"message": "test"}}
+	require.NoError(t, client.Publish(outest.NewBatch(event)))
+	assert.Equal(t, host2, client.host)
+
+	mutex.Lock()
+	assert.Equal(t, []string{host2}, bulkHosts)
+	mutex.Unlock()
+
+	// The nodes are kept if none can be sniffed.
+	ts1.Close()
+	ts2.Close()
+	sniffer.sniff()
+	assert.Equal(t, host2, sniffer.host(0))
+}
@@ -81,7 +81,7 @@ func parseProxyURL(raw string) (*url.URL, error) {
 	}
 
 	url, err := url.Parse(raw)
-	if err == nil && strings.HasPrefix(url.Scheme, "http") {
+	if err == nil && (strings.HasPrefix(url.Scheme, "http") || url.Scheme == "socks5") {
 		return url, err
 	}
 
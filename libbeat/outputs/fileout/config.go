@@ -19,18 +19,22 @@ package fileout
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/elastic/beats/libbeat/common/file"
 	"github.com/elastic/beats/libbeat/outputs/codec"
 )
 
 type config struct {
-	Path          string       `config:"path"`
-	Filename      string       `config:"filename"`
-	RotateEveryKb uint         `config:"rotate_every_kb" validate:"min=1"`
-	NumberOfFiles uint         `config:"number_of_files"`
-	Codec         codec.Config `config:"codec"`
-	Permissions   uint32       `config:"permissions"`
+	Path           string        `config:"path"`
+	Filename       string        `config:"filename"`
+	RotateEveryKb  uint          `config:"rotate_every_kb" validate:"min=1"`
+	RotateInterval time.Duration `config:"rotate_interval" validate:"min=0"`
+	NumberOfFiles  uint          `config:"number_of_files"`
+	Compress       bool          `config:"compress"`
+	Fsync          bool          `config:"fsync"`
+	Codec          codec.Config  `config:"codec"`
+	Permissions    uint32        `config:"permissions"`
 }
 
 var (
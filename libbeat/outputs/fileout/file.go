@@ -40,6 +40,7 @@ type fileOutput struct {
 	observer outputs.Observer
 	rotator  *file.Rotator
 	codec    codec.Codec
+	fsync    bool
 }
 
 // makeFileout instantiates a new file output instance.
@@ -82,6 +83,8 @@ func (out *fileOutput) init(beat beat.Info, c config) error {
 		path,
 		file.MaxSizeBytes(c.RotateEveryKb*1024),
 		file.MaxBackups(c.NumberOfFiles),
+		file.Interval(c.RotateInterval),
+		file.Compress(c.Compress),
 		file.Permissions(os.FileMode(c.Permissions)),
 		file.WithLogger(logp.NewLogger("rotator").With(logp.Namespace("rotator"))),
 	)
@@ -93,10 +96,12 @@ func (out *fileOutput) init(beat beat.Info, c config) error {
 	if err != nil {
 		return err
 	}
+	out.fsync = c.Fsync
 
 	logp.Info("Initialized file output. "+
-		"path=%v max_size_bytes=%v max_backups=%v permissions=%v",
-		path, c.RotateEveryKb*1024, c.NumberOfFiles, os.FileMode(c.Permissions))
+		"path=%v max_size_bytes=%v max_backups=%v rotate_interval=%v compress=%v fsync=%v permissions=%v",
+		path, c.RotateEveryKb*1024, c.NumberOfFiles, c.RotateInterval, c.Compress, c.Fsync,
+		os.FileMode(c.Permissions))
 
 	return nil
 }
@@ -147,6 +152,13 @@ func (out *fileOutput) Publish(
 		st.WriteBytes(len(serializedEvent) + 1)
 	}
 
+	if out.fsync {
+		if err := out.rotator.Sync(); err != nil {
+			st.WriteError(err)
+			logp.Warn("Syncing file failed with: %v", err)
+		}
+	}
+
 	st.Dropped(dropped)
 	st.Acked(len(events) - dropped)
 
@@ -0,0 +1,233 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package httpout
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/outputs"
+	"github.com/elastic/beats/libbeat/outputs/codec"
+	"github.com/elastic/beats/libbeat/outputs/transport"
+	"github.com/elastic/beats/libbeat/publisher"
+)
+
+// clientSettings contains the settings for a client.
+type clientSettings struct {
+	URL              string
+	Proxy            *url.URL
+	TLS              *transport.TLSConfig
+	Headers          map[string]string
+	Username         string
+	Password         string
+	BearerToken      string
+	CompressionLevel int
+	Timeout          time.Duration
+	Observer         outputs.Observer
+}
+
+// client POSTs each batch of events to the URL as a JSON array.
+type client struct {
+	url         string
+	headers     map[string]string
+	username    string
+	password    string
+	bearerToken string
+	index       string
+	codec       codec.Codec
+	observer    outputs.Observer
+	http        *http.Client
+
+	compressionLevel int
+	buf              bytes.Buffer
+}
+
+func newClient(info beat.Info, s clientSettings, enc codec.Codec) (*client, error) {
+	proxy := http.ProxyFromEnvironment
+	if s.Proxy != nil {
+		proxy = http.ProxyURL(s.Proxy)
+	}
+
+	dialer := transport.NetDialer(s.Timeout)
+	tlsDialer, err := transport.TLSDialer(dialer, s.TLS, s.Timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if st := s.Observer; st != nil {
+		dialer = transport.StatsDialer(dialer, st)
+		tlsDialer = transport.StatsDialer(tlsDialer, st)
+	}
+
+	return &client{
+		url:         s.URL,
+		headers:     s.Headers,
+		username:    s.Username,
+		password:    s.Password,
+		bearerToken: s.BearerToken,
+		index:       info.Beat,
+		codec:       enc,
+		observer:    s.Observer,
+		http: &http.Client{
+			Transport: &http.Transport{
+				Dial:    dialer.Dial,
+				DialTLS: tlsDialer.Dial,
+				Proxy:   proxy,
+			},
+			Timeout: s.Timeout,
+		},
+		compressionLevel: s.CompressionLevel,
+	}, nil
+}
+
+// Connect is a no-op, the connections are opened by each request.
+func (c *client) Connect() error {
+	return nil
+}
+
+func (c *client) Close() error {
+	if t, ok := c.http.Transport.(*http.Transport); ok {
+		t.CloseIdleConnections()
+	}
+	return nil
+}
+
+func (c *client) String() string {
+	return "http(" + c.url + ")"
+}
+
+func (c *client) Publish(batch publisher.Batch) error {
+	events := batch.Events()
+	c.observer.NewBatch(len(events))
+
+	okEvents, err := c.encode(events)
+	if err != nil {
+		// The body could not be compressed, retrying would fail again.
+		logp.Err("Failed to encode the events: %v", err)
+		c.observer.Dropped(len(events))
+		batch.Drop()
+		return nil
+	}
+	c.observer.Dropped(len(events) - len(okEvents))
+	if len(okEvents) == 0 {
+		batch.ACK()
+		return nil
+	}
+
+	status, err := c.send()
+	switch {
+	case err != nil:
+	case status < 300:
+		c.observer.Acked(len(okEvents))
+		batch.ACK()
+		return nil
+	case status == http.StatusTooManyRequests || status >= 500:
+		err = fmt.Errorf("http request failed with status %v", status)
+	default:
+		// The request can not succeed by retrying it.
+		logp.Err("Dropping %v events, http request failed with status %v", len(okEvents), status)
+		c.observer.Dropped(len(okEvents))
+		batch.ACK()
+		return nil
+	}
+
+	logp.Err("Failed to publish events: %v", err)
+	c.observer.Failed(len(okEvents))
+	batch.RetryEvents(okEvents)
+	return err
+}
+
+// encode writes the request body for the events that can be serialized and
+// returns these events.
+func (c *client) encode(data []publisher.Event) ([]publisher.Event, error) {
+	c.buf.Reset()
+
+	var w io.Writer = &c.buf
+	var gz *gzip.Writer
+	if c.compressionLevel > 0 {
+		var err error
+		if gz, err = gzip.NewWriterLevel(&c.buf, c.compressionLevel); err != nil {
+			return nil, err
+		}
+		w = gz
+	}
+
+	okEvents := data[:0]
+	w.Write([]byte{'['})
+	for i := range data {
+		serialized, err := c.codec.Encode(c.index, &data[i].Content)
+		if err != nil {
+			logp.Err("Failed to serialize the event: %v", err)
+			continue
+		}
+
+		if len(okEvents) > 0 {
+			w.Write([]byte{','})
+		}
+		w.Write(serialized)
+		okEvents = append(okEvents, data[i])
+	}
+	w.Write([]byte{']'})
+
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			return nil, err
+		}
+	}
+	return okEvents, nil
+}
+
+// send POSTs the encoded body and returns the HTTP status.
+func (c *client) send() (int, error) {
+	req, err := http.NewRequest("POST", c.url, bytes.NewReader(c.buf.Bytes()))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	if c.compressionLevel > 0 {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	if c.username != "" || c.password != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	if c.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+c.bearerToken)
+	}
+	for name, value := range c.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// Read the body to reuse the connection.
+	io.Copy(ioutil.Discard, resp.Body)
+	return resp.StatusCode, nil
+}
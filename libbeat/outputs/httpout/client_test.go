@@ -0,0 +1,179 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package httpout
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/outputs"
+	jsoncodec "github.com/elastic/beats/libbeat/outputs/codec/json"
+	"github.com/elastic/beats/libbeat/outputs/outest"
+)
+
+type request struct {
+	header http.Header
+	events []common.MapStr
+}
+
+func newTestServer(t *testing.T, status int, requests chan<- request) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gz, err := gzip.NewReader(r.Body)
+			require.NoError(t, err)
+			body = gz
+		}
+
+		var events []common.MapStr
+		require.NoError(t, json.NewDecoder(body).Decode(&events))
+		requests <- request{header: r.Header, events: events}
+		w.WriteHeader(status)
+	}))
+}
+
+func newTestClient(t *testing.T, s clientSettings) *client {
+	s.Timeout = time.Second
+	s.Observer = outputs.NewNilObserver()
+	c, err := newClient(beat.Info{Beat: "testbeat"}, s, jsoncodec.New(false, true, "1.0.0"))
+	require.NoError(t, err)
+	return c
+}
+
+func newTestBatch() *outest.Batch {
+	return outest.NewBatch(
+		beat.Event{Timestamp: time.Now(), Fields: common.MapStr{"message": "first"}},
+		beat.Event{Timestamp: time.Now(), Fields: common.MapStr{"message": "second"}},
+	)
+}
+
+func TestPublish(t *testing.T) {
+	tests := map[string]struct {
+		settings clientSettings
+		check    func(t *testing.T, header http.Header)
+	}{
+		"plain": {
+			settings: clientSettings{Headers: map[string]string{"X-Test": "value"}},
+			check: func(t *testing.T, header http.Header) {
+				assert.Equal(t, "application/json", header.Get("Content-Type"))
+				assert.Equal(t, "value", header.Get("X-Test"))
+				assert.Empty(t, header.Get("Authorization"))
+			},
+		},
+		"gzip": {
+			settings: clientSettings{CompressionLevel: 5},
+			check: func(t *testing.T, header http.Header) {
+				assert.Equal(t, "gzip", header.Get("Content-Encoding"))
+			},
+		},
+		"basic auth": {
+			settings: clientSettings{Username: "user", Password: "secret"},
+			check: func(t *testing.T, header http.Header) {
+				assert.Equal(t, "Basic dXNlcjpzZWNyZXQ=", header.Get("Authorization"))
+			},
+		},
+		"bearer token": {
+			settings: clientSettings{BearerToken: "token"},
+			check: func(t *testing.T, header http.Header) {
+				assert.Equal(t, "Bearer token", header.Get("Authorization"))
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			requests := make(chan request, 1)
+			server := newTestServer(t, http.StatusOK, requests)
+			defer server.Close()
+
+			test.settings.URL = server.URL
+			c := newTestClient(t, test.settings)
+			defer c.Close()
+
+			batch := newTestBatch()
+			require.NoError(t, c.Publish(batch))
+			require.Len(t, batch.Signals, 1)
+			assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+
+			req := <-requests
+			test.check(t, req.header)
+			require.Len(t, req.events, 2)
+			assert.Equal(t, "first", req.events[0]["message"])
+			assert.Equal(t, "second", req.events[1]["message"])
+			assert.Equal(t, "testbeat", req.events[0]["@metadata"].(map[string]interface{})["beat"])
+		})
+	}
+}
+
+func TestPublishFailures(t *testing.T) {
+	tests := map[string]struct {
+		status int
+		signal outest.BatchSignalTag
+		err    bool
+	}{
+		"too many requests": {http.StatusTooManyRequests, outest.BatchRetryEvents, true},
+		"server error":      {http.StatusServiceUnavailable, outest.BatchRetryEvents, true},
+		"bad request":       {http.StatusBadRequest, outest.BatchACK, false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			requests := make(chan request, 1)
+			server := newTestServer(t, test.status, requests)
+			defer server.Close()
+
+			c := newTestClient(t, clientSettings{URL: server.URL})
+			defer c.Close()
+
+			batch := newTestBatch()
+			err := c.Publish(batch)
+			if test.err {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			require.Len(t, batch.Signals, 1)
+			assert.Equal(t, test.signal, batch.Signals[0].Tag)
+			<-requests
+		})
+	}
+}
+
+func TestPublishConnectionError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	c := newTestClient(t, clientSettings{URL: server.URL})
+	batch := newTestBatch()
+	assert.Error(t, c.Publish(batch))
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchRetryEvents, batch.Signals[0].Tag)
+	assert.Len(t, batch.Signals[0].Events, 2)
+}
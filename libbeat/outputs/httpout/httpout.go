@@ -0,0 +1,79 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package httpout
+
+import (
+	"net/url"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/transport/tlscommon"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/outputs"
+	jsoncodec "github.com/elastic/beats/libbeat/outputs/codec/json"
+)
+
+func init() {
+	outputs.RegisterType("http", makeHTTP)
+}
+
+func makeHTTP(
+	beat beat.Info,
+	observer outputs.Observer,
+	cfg *common.Config,
+) (outputs.Group, error) {
+	config := defaultConfig
+	if err := cfg.Unpack(&config); err != nil {
+		return outputs.Fail(err)
+	}
+
+	tls, err := tlscommon.LoadTLSConfig(config.TLS)
+	if err != nil {
+		return outputs.Fail(err)
+	}
+
+	var proxy *url.URL
+	if config.ProxyURL != "" {
+		if proxy, err = parseProxyURL(config.ProxyURL); err != nil {
+			return outputs.Fail(err)
+		}
+	}
+
+	logp.Info("HTTP output url: %s", config.URL)
+
+	client, err := newClient(beat, clientSettings{
+		URL:              config.URL,
+		Proxy:            proxy,
+		TLS:              tls,
+		Headers:          config.Headers,
+		Username:         config.Username,
+		Password:         config.Password,
+		BearerToken:      config.BearerToken,
+		CompressionLevel: config.CompressionLevel,
+		Timeout:          config.Timeout,
+		Observer:         observer,
+	}, jsoncodec.New(false, config.EscapeHTML, beat.Version))
+	if err != nil {
+		return outputs.Fail(err)
+	}
+
+	clients := []outputs.NetworkClient{
+		outputs.WithBackoff(client, config.Backoff.Init, config.Backoff.Max),
+	}
+	return outputs.SuccessNet(false, config.BulkMaxSize, config.MaxRetries, clients)
+}
@@ -23,11 +23,12 @@ import (
 	"github.com/garyburd/redigo/redis"
 
 	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/outputs"
 	"github.com/elastic/beats/libbeat/publisher"
 )
 
 type backoffClient struct {
-	client *client
+	client outputs.NetworkClient
 
 	reason failReason
 
@@ -49,7 +50,7 @@ const (
 	failOther
 )
 
-func newBackoffClient(client *client, init, max time.Duration) *backoffClient {
+func newBackoffClient(client outputs.NetworkClient, init, max time.Duration) *backoffClient {
 	done := make(chan struct{})
 	backoff := common.NewBackoff(done, init, max)
 	return &backoffClient{
@@ -19,6 +19,7 @@ package redis
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
 	"strconv"
 	"time"
@@ -55,6 +56,10 @@ type client struct {
 	publish  publishFn
 	codec    codec.Codec
 	timeout  time.Duration
+
+	// requireMaster makes Connect fail if the server is not a master, e.g.
+	// because a sentinel failover is in progress.
+	requireMaster bool
 }
 
 type redisDataType uint16
@@ -100,7 +105,10 @@ func (c *client) Connect() error {
 		}
 	}()
 
-	if err = initRedisConn(conn, c.password, c.db); err == nil {
+	if err = initRedisConn(conn, c.password, c.db); err == nil && c.requireMaster {
+		err = checkMasterRole(conn)
+	}
+	if err == nil {
 		c.publish, err = c.makePublish(conn)
 	}
 	return err
@@ -126,6 +134,20 @@ func initRedisConn(c redis.Conn, pwd string, db int) error {
 	return nil
 }
 
+func checkMasterRole(c redis.Conn) error {
+	role, err := redis.Values(c.Do("ROLE"))
+	if err != nil {
+		return err
+	}
+	if len(role) == 0 {
+		return errors.New("empty ROLE reply")
+	}
+	if name, _ := redis.String(role[0], nil); name != "master" {
+		return fmt.Errorf("redis server role is %v, not master", name)
+	}
+	return nil
+}
+
 func (c *client) Close() error {
 	debugf("close connection")
 	return c.Client.Close()
@@ -0,0 +1,463 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package redis
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/outputs"
+	"github.com/elastic/beats/libbeat/outputs/codec"
+	"github.com/elastic/beats/libbeat/outputs/outil"
+	"github.com/elastic/beats/libbeat/outputs/transport"
+	"github.com/elastic/beats/libbeat/publisher"
+)
+
+const (
+	clusterSlots = 16384
+
+	// clusterMaxRedirects is the number of times events are redirected to
+	// another node within one publish attempt.
+	clusterMaxRedirects = 5
+)
+
+var errTooManyRedirects = errors.New("too many redis cluster redirects")
+
+// clusterClient publishes to a Redis Cluster. Each event is sent to the node
+// serving the hash slot of its key. The slot table is loaded from the nodes
+// on connect, and reloaded when a node redirects an event with MOVED.
+type clusterClient struct {
+	observer outputs.Observer
+	transp   *transport.Config
+	timeout  time.Duration
+	password string
+	seeds    []string
+	key      outil.Selector
+	command  string
+	index    string
+	codec    codec.Codec
+
+	slots []slotRange
+	nodes map[string]redis.Conn
+}
+
+// slotRange is a range of hash slots served by a master node.
+type slotRange struct {
+	start, end int
+	addr       string
+}
+
+// clusterMessage is an event being published to the cluster.
+type clusterMessage struct {
+	event  publisher.Event
+	key    string
+	value  []byte
+	addr   string // Redirect target. The slot table is used if empty.
+	asking bool   // The redirect is an ASK redirect.
+}
+
+func newClusterClient(
+	observer outputs.Observer,
+	seeds []string,
+	port int,
+	transp *transport.Config,
+	password string,
+	key outil.Selector,
+	dataType redisDataType,
+	index string,
+	codec codec.Codec,
+) *clusterClient {
+	addrs := make([]string, len(seeds))
+	for i, seed := range seeds {
+		if _, _, err := net.SplitHostPort(seed); err != nil {
+			seed = net.JoinHostPort(seed, strconv.Itoa(port))
+		}
+		addrs[i] = seed
+	}
+
+	command := "RPUSH"
+	if dataType == redisChannelType {
+		command = "PUBLISH"
+	}
+
+	return &clusterClient{
+		observer: observer,
+		transp:   transp,
+		timeout:  transp.Timeout,
+		password: password,
+		seeds:    addrs,
+		key:      key,
+		command:  command,
+		index:    index,
+		codec:    codec,
+		nodes:    map[string]redis.Conn{},
+	}
+}
+
+func (c *clusterClient) Connect() error {
+	debugf("connect to cluster")
+	return c.refreshSlots()
+}
+
+func (c *clusterClient) Close() error {
+	debugf("close cluster connections")
+	var lastErr error
+	for addr, conn := range c.nodes {
+		if err := conn.Close(); err != nil {
+			lastErr = err
+		}
+		delete(c.nodes, addr)
+	}
+	return lastErr
+}
+
+func (c *clusterClient) Publish(batch publisher.Batch) error {
+	events := batch.Events()
+	c.observer.NewBatch(len(events))
+
+	okEvents, serialized := serializeEvents(nil, 0, events, c.index, c.codec)
+	c.observer.Dropped(len(events) - len(okEvents))
+
+	msgs := make([]clusterMessage, 0, len(okEvents))
+	dropped := 0
+	for i := range okEvents {
+		key, err := c.key.Select(&okEvents[i].Content)
+		if err != nil {
+			logp.Err("Failed to set redis key: %v", err)
+			dropped++
+			continue
+		}
+		msgs = append(msgs, clusterMessage{
+			event: okEvents[i],
+			key:   key,
+			value: serialized[i].([]byte),
+		})
+	}
+	c.observer.Dropped(dropped)
+
+	failed, err := c.publishMessages(msgs)
+	c.observer.Acked(len(msgs) - len(failed))
+	if len(failed) > 0 {
+		c.observer.Failed(len(failed))
+		rest := make([]publisher.Event, len(failed))
+		for i := range failed {
+			rest[i] = failed[i].event
+		}
+		batch.RetryEvents(rest)
+		return err
+	}
+
+	batch.ACK()
+	return nil
+}
+
+// publishMessages sends the messages to the nodes serving their slots, and
+// follows redirects. It returns the messages that could not be published.
+func (c *clusterClient) publishMessages(msgs []clusterMessage) ([]clusterMessage, error) {
+	var failed []clusterMessage
+	var lastErr error
+
+	for attempt := 0; len(msgs) > 0; attempt++ {
+		if attempt > clusterMaxRedirects {
+			return append(failed, msgs...), errTooManyRedirects
+		}
+
+		// Group messages by node, keeping the order of the events per node.
+		var addrs []string
+		byNode := map[string][]clusterMessage{}
+		for _, msg := range msgs {
+			addr := msg.addr
+			if addr == "" {
+				var err error
+				if addr, err = c.slotAddr(keySlot(msg.key)); err != nil {
+					failed = append(failed, msg)
+					lastErr = err
+					continue
+				}
+			}
+			if _, found := byNode[addr]; !found {
+				addrs = append(addrs, addr)
+			}
+			byNode[addr] = append(byNode[addr], msg)
+		}
+
+		var redirected []clusterMessage
+		moved := false
+		for _, addr := range addrs {
+			redir, fail, wasMoved, err := c.publishNode(addr, byNode[addr])
+			redirected = append(redirected, redir...)
+			failed = append(failed, fail...)
+			moved = moved || wasMoved
+			if err != nil {
+				lastErr = err
+			}
+		}
+
+		if moved {
+			if err := c.refreshSlots(); err != nil {
+				logp.Err("Failed to reload redis cluster slots: %v", err)
+			}
+		}
+		msgs = redirected
+	}
+
+	return failed, lastErr
+}
+
+// publishNode pipelines the messages to a node. It returns the messages
+// redirected to other nodes and the messages that failed.
+func (c *clusterClient) publishNode(
+	addr string,
+	msgs []clusterMessage,
+) (redirected, failed []clusterMessage, moved bool, err error) {
+	conn, err := c.node(addr)
+	if err != nil {
+		return nil, msgs, false, err
+	}
+
+	var lastErr error
+	for _, msg := range msgs {
+		if msg.asking {
+			if err := conn.Send("ASKING"); err != nil {
+				c.closeNode(addr)
+				return nil, msgs, false, err
+			}
+		}
+		if err := conn.Send(c.command, msg.key, msg.value); err != nil {
+			c.closeNode(addr)
+			return nil, msgs, false, err
+		}
+	}
+	if err := conn.Flush(); err != nil {
+		c.closeNode(addr)
+		return nil, msgs, false, err
+	}
+
+	for i, msg := range msgs {
+		if msg.asking {
+			if _, err := conn.Receive(); err != nil {
+				if _, ok := err.(redis.Error); !ok {
+					c.closeNode(addr)
+					return redirected, append(failed, msgs[i:]...), moved, err
+				}
+			}
+		}
+
+		_, err = conn.Receive()
+		if err == nil {
+			continue
+		}
+
+		redisErr, ok := err.(redis.Error)
+		if !ok {
+			logp.Err("Failed to %v multiple events to %v with %v", c.command, addr, err)
+			c.closeNode(addr)
+			return redirected, append(failed, msgs[i:]...), moved, err
+		}
+
+		if target, isMoved, ok := parseRedirect(redisErr); ok {
+			debugf("%v redirected to %v (moved=%v)", msg.key, target, isMoved)
+			msg.addr, msg.asking = target, !isMoved
+			redirected = append(redirected, msg)
+			moved = moved || isMoved
+			continue
+		}
+
+		logp.Err("Failed to %v event to %v with %v", c.command, addr, err)
+		failed = append(failed, msg)
+		lastErr = err
+	}
+
+	return redirected, failed, moved, lastErr
+}
+
+// parseRedirect parses MOVED and ASK errors: "MOVED <slot> <addr>".
+func parseRedirect(err redis.Error) (addr string, moved bool, ok bool) {
+	parts := strings.Fields(string(err))
+	if len(parts) != 3 {
+		return "", false, false
+	}
+	switch parts[0] {
+	case "MOVED":
+		return parts[2], true, true
+	case "ASK":
+		return parts[2], false, true
+	}
+	return "", false, false
+}
+
+// node returns the connection to a node, connecting if required.
+func (c *clusterClient) node(addr string) (redis.Conn, error) {
+	if conn, found := c.nodes[addr]; found {
+		return conn, nil
+	}
+
+	tc, err := transport.NewClient(c.transp, "tcp", addr, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := tc.Connect(); err != nil {
+		return nil, err
+	}
+
+	conn := redis.NewConn(tc, c.timeout, c.timeout)
+	if err := initRedisConn(conn, c.password, 0); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	c.nodes[addr] = conn
+	return conn, nil
+}
+
+func (c *clusterClient) closeNode(addr string) {
+	if conn, found := c.nodes[addr]; found {
+		conn.Close()
+		delete(c.nodes, addr)
+	}
+}
+
+// refreshSlots loads the slot table from the first node answering. Known
+// nodes are asked before the configured hosts.
+func (c *clusterClient) refreshSlots() error {
+	var candidates []string
+	seen := map[string]bool{}
+	for _, r := range c.slots {
+		if !seen[r.addr] {
+			seen[r.addr] = true
+			candidates = append(candidates, r.addr)
+		}
+	}
+	for _, seed := range c.seeds {
+		if !seen[seed] {
+			seen[seed] = true
+			candidates = append(candidates, seed)
+		}
+	}
+
+	var lastErr error
+	for _, addr := range candidates {
+		conn, err := c.node(addr)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+
+		slots, err := parseClusterSlots(conn.Do("CLUSTER", "SLOTS"))
+		if err != nil {
+			if _, ok := err.(redis.Error); !ok {
+				c.closeNode(addr)
+			}
+			lastErr = err
+			continue
+		}
+		for i := range slots {
+			if host, port, _ := net.SplitHostPort(slots[i].addr); host == "" {
+				// Nodes may report an empty IP for themselves.
+				queried, _, _ := net.SplitHostPort(addr)
+				slots[i].addr = net.JoinHostPort(queried, port)
+			}
+		}
+
+		c.slots = slots
+		return nil
+	}
+	return fmt.Errorf("failed to load redis cluster slots: %v", lastErr)
+}
+
+// parseClusterSlots parses the reply to CLUSTER SLOTS. Each entry contains
+// the first and last slot of a range, followed by the master IP and port, and
+// the replicas. The returned ranges are sorted.
+func parseClusterSlots(reply interface{}, err error) ([]slotRange, error) {
+	entries, err := redis.Values(reply, err)
+	if err != nil {
+		return nil, err
+	}
+
+	slots := make([]slotRange, 0, len(entries))
+	for _, entry := range entries {
+		fields, err := redis.Values(entry, nil)
+		if err != nil || len(fields) < 3 {
+			return nil, fmt.Errorf("invalid CLUSTER SLOTS entry %v", entry)
+		}
+
+		start, err1 := redis.Int(fields[0], nil)
+		end, err2 := redis.Int(fields[1], nil)
+		master, err3 := redis.Values(fields[2], nil)
+		if err1 != nil || err2 != nil || err3 != nil || len(master) < 2 {
+			return nil, fmt.Errorf("invalid CLUSTER SLOTS entry %v", entry)
+		}
+		ip, err1 := redis.String(master[0], nil)
+		port, err2 := redis.Int(master[1], nil)
+		if err1 != nil || err2 != nil {
+			return nil, fmt.Errorf("invalid CLUSTER SLOTS entry %v", entry)
+		}
+
+		addr := net.JoinHostPort(ip, strconv.Itoa(port))
+		slots = append(slots, slotRange{start: start, end: end, addr: addr})
+	}
+
+	sort.Slice(slots, func(i, j int) bool { return slots[i].start < slots[j].start })
+	return slots, nil
+}
+
+func (c *clusterClient) slotAddr(slot int) (string, error) {
+	i := sort.Search(len(c.slots), func(i int) bool { return c.slots[i].end >= slot })
+	if i < len(c.slots) && c.slots[i].start <= slot {
+		return c.slots[i].addr, nil
+	}
+	return "", fmt.Errorf("no redis cluster node serves slot %v", slot)
+}
+
+func (c *clusterClient) String() string {
+	return "redis(cluster " + strings.Join(c.seeds, ",") + ")"
+}
+
+// keySlot returns the hash slot of a key. If the key contains a hash tag,
+// like {user1000}.following, only the tag is hashed.
+func keySlot(key string) int {
+	if start := strings.IndexByte(key, '{'); start >= 0 {
+		if end := strings.IndexByte(key[start+1:], '}'); end > 0 {
+			key = key[start+1 : start+1+end]
+		}
+	}
+	return int(crc16(key) % clusterSlots)
+}
+
+// crc16 computes the CRC16-CCITT (XMODEM) checksum used by Redis Cluster.
+func crc16(s string) uint16 {
+	var crc uint16
+	for i := 0; i < len(s); i++ {
+		crc ^= uint16(s[i]) << 8
+		for j := 0; j < 8; j++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
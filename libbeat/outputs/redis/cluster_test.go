@@ -0,0 +1,274 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package redis
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/outputs"
+	"github.com/elastic/beats/libbeat/outputs/codec/json"
+	"github.com/elastic/beats/libbeat/outputs/outest"
+	"github.com/elastic/beats/libbeat/outputs/outil"
+	"github.com/elastic/beats/libbeat/outputs/transport"
+)
+
+func TestKeySlot(t *testing.T) {
+	assert.Equal(t, uint16(0x31C3), crc16("123456789"))
+
+	assert.Equal(t, 12739, keySlot("123456789"))
+	assert.Equal(t, keySlot("user1000"), keySlot("{user1000}.following"))
+	assert.Equal(t, keySlot("user1000"), keySlot("foo{user1000}{bar}"))
+	// empty hash tags are ignored
+	assert.Equal(t, int(crc16("{}.a")%clusterSlots), keySlot("{}.a"))
+}
+
+func TestParseClusterSlots(t *testing.T) {
+	reply := []interface{}{
+		[]interface{}{int64(5461), int64(16383), []interface{}{[]byte("10.0.0.2"), int64(7001), []byte("id2")}},
+		[]interface{}{int64(0), int64(5460),
+			[]interface{}{[]byte("10.0.0.1"), int64(7000), []byte("id1")},
+			[]interface{}{[]byte("10.0.0.3"), int64(7003), []byte("id3")},
+		},
+	}
+
+	slots, err := parseClusterSlots(reply, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []slotRange{
+		{start: 0, end: 5460, addr: "10.0.0.1:7000"},
+		{start: 5461, end: 16383, addr: "10.0.0.2:7001"},
+	}, slots)
+
+	c := &clusterClient{slots: slots}
+	addr, err := c.slotAddr(5460)
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.1:7000", addr)
+	addr, err = c.slotAddr(5461)
+	assert.NoError(t, err)
+	assert.Equal(t, "10.0.0.2:7001", addr)
+
+	c.slots = slots[1:]
+	_, err = c.slotAddr(0)
+	assert.Error(t, err)
+}
+
+func TestParseRedirect(t *testing.T) {
+	addr, moved, ok := parseRedirect(redis.Error("MOVED 3999 127.0.0.1:6381"))
+	assert.True(t, ok)
+	assert.True(t, moved)
+	assert.Equal(t, "127.0.0.1:6381", addr)
+
+	addr, moved, ok = parseRedirect(redis.Error("ASK 3999 127.0.0.1:6381"))
+	assert.True(t, ok)
+	assert.False(t, moved)
+	assert.Equal(t, "127.0.0.1:6381", addr)
+
+	_, _, ok = parseRedirect(redis.Error("OOM command not allowed"))
+	assert.False(t, ok)
+}
+
+// keyForSlots returns a key whose slot is within [start, end].
+func keyForSlots(start, end int) string {
+	for i := 0; ; i++ {
+		key := fmt.Sprintf("key%d", i)
+		if slot := keySlot(key); slot >= start && slot <= end {
+			return key
+		}
+	}
+}
+
+func newTestClusterClient(t *testing.T, seeds ...string) *clusterClient {
+	key, err := outil.BuildSelectorFromConfig(common.MustNewConfigFrom(map[string]interface{}{
+		"key": "%{[key]}",
+	}), outil.Settings{Key: "key", MultiKey: "keys", EnableSingleOnly: true, FailEmpty: true})
+	require.NoError(t, err)
+
+	transp := &transport.Config{Timeout: time.Second}
+	return newClusterClient(outputs.NewNilObserver(), seeds, 6379, transp, "",
+		key, redisListType, "test", json.New(false, false, "1.0.0"))
+}
+
+func pushedKeys(s *fakeServer) []string {
+	var keys []string
+	for _, cmd := range s.received() {
+		if cmd[0] == "RPUSH" {
+			keys = append(keys, cmd[1])
+		}
+	}
+	return keys
+}
+
+func TestClusterPublish(t *testing.T) {
+	var a, b *fakeServer
+	var migrated int32
+	keyA, keyB, keyMoved := keyForSlots(0, 8191), keyForSlots(8192, 16383), keyForSlots(8192, 16383)+"x"
+	for keySlot(keyMoved) < 8192 {
+		keyMoved += "x"
+	}
+
+	slotsReply := func() interface{} {
+		hostA, portA := splitAddr(a.addr())
+		hostB, portB := splitAddr(b.addr())
+		if atomic.LoadInt32(&migrated) == 0 {
+			// A claims all slots until the redirect.
+			return []interface{}{[]interface{}{0, 16383, []interface{}{hostA, portA}}}
+		}
+		return []interface{}{
+			[]interface{}{0, 8191, []interface{}{hostA, portA}},
+			[]interface{}{8192, 16383, []interface{}{hostB, portB}},
+		}
+	}
+
+	a = newFakeServer(t, func(args []string) interface{} {
+		switch args[0] {
+		case "CLUSTER":
+			return slotsReply()
+		case "RPUSH":
+			if keySlot(args[1]) >= 8192 {
+				atomic.StoreInt32(&migrated, 1)
+				return redis.Error(fmt.Sprintf("MOVED %d %s", keySlot(args[1]), b.addr()))
+			}
+			return 1
+		}
+		return "PONG"
+	})
+	defer a.close()
+	b = newFakeServer(t, func(args []string) interface{} {
+		switch args[0] {
+		case "CLUSTER":
+			return slotsReply()
+		case "RPUSH":
+			if keySlot(args[1]) < 8192 {
+				return redis.Error(fmt.Sprintf("MOVED %d %s", keySlot(args[1]), a.addr()))
+			}
+			return 1
+		}
+		return "PONG"
+	})
+	defer b.close()
+
+	c := newTestClusterClient(t, a.addr())
+	require.NoError(t, c.Connect())
+	defer c.Close()
+
+	batch := outest.NewBatch(
+		beat.Event{Fields: common.MapStr{"key": keyA}},
+		beat.Event{Fields: common.MapStr{"key": keyB}},
+	)
+	require.NoError(t, c.Publish(batch))
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+
+	// The moved event is published to B, and the slot table is reloaded.
+	assert.Equal(t, []string{keyA, keyB}, pushedKeys(a))
+	assert.Equal(t, []string{keyB}, pushedKeys(b))
+	assert.Len(t, c.slots, 2)
+
+	// Later events are sent to the right node directly.
+	batch = outest.NewBatch(
+		beat.Event{Fields: common.MapStr{"key": keyMoved}},
+		beat.Event{Fields: common.MapStr{"key": keyA}},
+	)
+	require.NoError(t, c.Publish(batch))
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+	assert.Equal(t, []string{keyA, keyB, keyA}, pushedKeys(a))
+	assert.Equal(t, []string{keyB, keyMoved}, pushedKeys(b))
+}
+
+func TestClusterPublishAsk(t *testing.T) {
+	var a, b *fakeServer
+	a = newFakeServer(t, func(args []string) interface{} {
+		switch args[0] {
+		case "CLUSTER":
+			host, port := splitAddr(a.addr())
+			return []interface{}{[]interface{}{0, 16383, []interface{}{host, port}}}
+		case "RPUSH":
+			return redis.Error(fmt.Sprintf("ASK %d %s", keySlot(args[1]), b.addr()))
+		}
+		return "PONG"
+	})
+	defer a.close()
+
+	asking := false
+	b = newFakeServer(t, func(args []string) interface{} {
+		switch args[0] {
+		case "ASKING":
+			asking = true
+			return "OK"
+		case "RPUSH":
+			if !asking {
+				return redis.Error("MOVED 0 " + a.addr())
+			}
+			asking = false
+			return 1
+		}
+		return "PONG"
+	})
+	defer b.close()
+
+	c := newTestClusterClient(t, a.addr())
+	require.NoError(t, c.Connect())
+	defer c.Close()
+
+	batch := outest.NewBatch(beat.Event{Fields: common.MapStr{"key": "k"}})
+	require.NoError(t, c.Publish(batch))
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+	assert.Equal(t, []string{"k"}, pushedKeys(b))
+
+	// ASK redirects don't change the slot table.
+	assert.Equal(t, a.addr(), c.slots[0].addr)
+}
+
+func TestClusterPublishErrors(t *testing.T) {
+	var a *fakeServer
+	a = newFakeServer(t, func(args []string) interface{} {
+		switch args[0] {
+		case "CLUSTER":
+			host, port := splitAddr(a.addr())
+			return []interface{}{[]interface{}{0, 16383, []interface{}{host, port}}}
+		case "RPUSH":
+			if args[1] == "fail" {
+				return redis.Error("OOM command not allowed")
+			}
+			return 1
+		}
+		return "PONG"
+	})
+	defer a.close()
+
+	c := newTestClusterClient(t, a.addr())
+	require.NoError(t, c.Connect())
+	defer c.Close()
+
+	batch := outest.NewBatch(
+		beat.Event{Fields: common.MapStr{"key": "ok"}},
+		beat.Event{Fields: common.MapStr{"key": "fail"}},
+	)
+	assert.Error(t, c.Publish(batch))
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchRetryEvents, batch.Signals[0].Tag)
+	require.Len(t, batch.Signals[0].Events, 1)
+	assert.Equal(t, "fail", batch.Signals[0].Events[0].Content.Fields["key"])
+}
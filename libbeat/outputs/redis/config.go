@@ -18,6 +18,7 @@
 package redis
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -32,6 +33,7 @@ type redisConfig struct {
 	Key         string                `config:"key"`
 	Port        int                   `config:"port"`
 	LoadBalance bool                  `config:"loadbalance"`
+	Worker      int                   `config:"worker"`
 	Timeout     time.Duration         `config:"timeout"`
 	BulkMaxSize int                   `config:"bulk_max_size"`
 	MaxRetries  int                   `config:"max_retries"`
@@ -41,6 +43,13 @@ type redisConfig struct {
 	Db          int                   `config:"db"`
 	DataType    string                `config:"datatype"`
 	Backoff     backoff               `config:"backoff"`
+	Sentinel    *sentinelConfig       `config:"sentinel"` // Discover the master from the sentinels in hosts.
+	Cluster     bool                  `config:"cluster"`  // Publish to the nodes of a Redis Cluster.
+}
+
+type sentinelConfig struct {
+	MasterName string `config:"master_name" validate:"required"`
+	Password   string `config:"password"`
 }
 
 type backoff struct {
@@ -52,6 +61,7 @@ var (
 	defaultConfig = redisConfig{
 		Port:        6379,
 		LoadBalance: true,
+		Worker:      1,
 		Timeout:     5 * time.Second,
 		BulkMaxSize: 2048,
 		MaxRetries:  3,
@@ -72,5 +82,12 @@ func (c *redisConfig) Validate() error {
 		return fmt.Errorf("redis data type %v not supported", c.DataType)
 	}
 
+	if c.Sentinel != nil && c.Cluster {
+		return errors.New("sentinel and cluster can not be used together")
+	}
+	if c.Cluster && c.Db != 0 {
+		return errors.New("db can not be set when cluster is enabled")
+	}
+
 	return nil
 }
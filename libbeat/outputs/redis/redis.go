@@ -109,6 +109,38 @@ func makeRedis(
 		Stats:   observer,
 	}
 
+	if config.Sentinel != nil {
+		// hosts lists the sentinels, each worker connects to the master on
+		// its own. Workers share the load, as they all publish to the master.
+		sentinels := uniqueHosts(hosts)
+		clients := make([]outputs.NetworkClient, config.Worker)
+		for i := range clients {
+			enc, err := codec.CreateEncoder(beat, config.Codec)
+			if err != nil {
+				return outputs.Fail(err)
+			}
+
+			sc := newSentinelClient(sentinels, *config.Sentinel, transp, func(conn *transport.Client) *client {
+				return newClient(conn, observer, config.Timeout,
+					config.Password, config.Db, key, dataType, config.Index, enc)
+			})
+			clients[i] = newBackoffClient(sc, config.Backoff.Init, config.Backoff.Max)
+		}
+		return outputs.SuccessNet(true, config.BulkMaxSize, config.MaxRetries, clients)
+	}
+
+	if config.Cluster {
+		enc, err := codec.CreateEncoder(beat, config.Codec)
+		if err != nil {
+			return outputs.Fail(err)
+		}
+
+		cc := newClusterClient(observer, hosts, config.Port, transp,
+			config.Password, key, dataType, config.Index, enc)
+		clients := []outputs.NetworkClient{newBackoffClient(cc, config.Backoff.Init, config.Backoff.Max)}
+		return outputs.SuccessNet(false, config.BulkMaxSize, config.MaxRetries, clients)
+	}
+
 	clients := make([]outputs.NetworkClient, len(hosts))
 	for i, host := range hosts {
 		enc, err := codec.CreateEncoder(beat, config.Codec)
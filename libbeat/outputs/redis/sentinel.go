@@ -0,0 +1,171 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package redis
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/garyburd/redigo/redis"
+
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/outputs/transport"
+	"github.com/elastic/beats/libbeat/publisher"
+)
+
+const defaultSentinelPort = 26379
+
+// sentinelClient publishes to the master monitored by Redis Sentinel. The
+// master address is looked up on every connect, so the client follows the
+// master after a failover: publishing to a demoted master fails, which
+// closes the connection, and the next connect discovers the new master.
+type sentinelClient struct {
+	sentinels  []string
+	masterName string
+	password   string
+	transp     *transport.Config
+
+	// makeClient creates the client publishing to the master.
+	makeClient func(conn *transport.Client) *client
+
+	client *client
+}
+
+func newSentinelClient(
+	sentinels []string,
+	config sentinelConfig,
+	transp *transport.Config,
+	makeClient func(conn *transport.Client) *client,
+) *sentinelClient {
+	return &sentinelClient{
+		// copy the sentinels, they are reordered on discovery
+		sentinels:  append([]string(nil), sentinels...),
+		masterName: config.MasterName,
+		password:   config.Password,
+		transp:     transp,
+		makeClient: makeClient,
+	}
+}
+
+// uniqueHosts removes the duplicates added to hosts for the workers.
+func uniqueHosts(hosts []string) []string {
+	seen := make(map[string]bool, len(hosts))
+	unique := make([]string, 0, len(hosts))
+	for _, host := range hosts {
+		if !seen[host] {
+			seen[host] = true
+			unique = append(unique, host)
+		}
+	}
+	return unique
+}
+
+func (s *sentinelClient) Connect() error {
+	addr, err := s.discoverMaster()
+	if err != nil {
+		return err
+	}
+
+	debugf("connect to master %v at %v", s.masterName, addr)
+	conn, err := transport.NewClient(s.transp, "tcp", addr, 0)
+	if err != nil {
+		return err
+	}
+
+	c := s.makeClient(conn)
+	c.requireMaster = true
+	if err := c.Connect(); err != nil {
+		c.Close()
+		return err
+	}
+	s.client = c
+	return nil
+}
+
+// discoverMaster asks the sentinels for the current master address. The
+// first sentinel answering is moved to the front of the list, so it is asked
+// first next time.
+func (s *sentinelClient) discoverMaster() (string, error) {
+	var lastErr error
+	for i, sentinel := range s.sentinels {
+		addr, err := s.queryMaster(sentinel)
+		if err != nil {
+			logp.Warn("Failed to get redis master %v from sentinel %v: %v", s.masterName, sentinel, err)
+			lastErr = err
+			continue
+		}
+
+		if i > 0 {
+			copy(s.sentinels[1:i+1], s.sentinels[:i])
+			s.sentinels[0] = sentinel
+		}
+		return addr, nil
+	}
+	return "", fmt.Errorf("no sentinel knows the redis master %v: %v", s.masterName, lastErr)
+}
+
+func (s *sentinelClient) queryMaster(sentinel string) (string, error) {
+	tc, err := transport.NewClient(s.transp, "tcp", sentinel, defaultSentinelPort)
+	if err != nil {
+		return "", err
+	}
+	if err := tc.Connect(); err != nil {
+		return "", err
+	}
+	defer tc.Close()
+
+	conn := redis.NewConn(tc, s.transp.Timeout, s.transp.Timeout)
+	if s.password != "" {
+		if _, err := conn.Do("AUTH", s.password); err != nil {
+			return "", err
+		}
+	}
+
+	reply, err := redis.Strings(conn.Do("SENTINEL", "get-master-addr-by-name", s.masterName))
+	if err == redis.ErrNil {
+		return "", fmt.Errorf("unknown master")
+	}
+	if err != nil {
+		return "", err
+	}
+	if len(reply) != 2 {
+		return "", fmt.Errorf("unexpected reply %v", reply)
+	}
+	return net.JoinHostPort(reply[0], reply[1]), nil
+}
+
+func (s *sentinelClient) Close() error {
+	if s.client == nil {
+		return nil
+	}
+	err := s.client.Close()
+	s.client = nil
+	return err
+}
+
+func (s *sentinelClient) Publish(batch publisher.Batch) error {
+	if s.client == nil {
+		batch.Retry()
+		return transport.ErrNotConnected
+	}
+	return s.client.Publish(batch)
+}
+
+func (s *sentinelClient) String() string {
+	return fmt.Sprintf("redis(sentinel master=%v)", s.masterName)
+}
@@ -0,0 +1,156 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package redis
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/outputs"
+	"github.com/elastic/beats/libbeat/outputs/codec/json"
+	"github.com/elastic/beats/libbeat/outputs/outest"
+	"github.com/elastic/beats/libbeat/outputs/outil"
+	"github.com/elastic/beats/libbeat/outputs/transport"
+)
+
+func newFakeRedis(t *testing.T, role string) *fakeServer {
+	return newFakeServer(t, func(args []string) interface{} {
+		switch args[0] {
+		case "ROLE":
+			return []interface{}{[]byte(role)}
+		case "INFO":
+			return []byte("redis_version:4.0.11\r\n")
+		case "RPUSH":
+			if role != "master" {
+				return redis.Error("READONLY You can't write against a read only slave.")
+			}
+			return 1
+		}
+		return "PONG"
+	})
+}
+
+// newFakeSentinel returns a sentinel reporting the address returned by
+// master for mymaster.
+func newFakeSentinel(t *testing.T, master func() string) *fakeServer {
+	return newFakeServer(t, func(args []string) interface{} {
+		if args[0] == "SENTINEL" && len(args) == 3 && args[2] == "mymaster" {
+			host, port, _ := net.SplitHostPort(master())
+			return []interface{}{[]byte(host), []byte(port)}
+		}
+		return nil
+	})
+}
+
+func newTestSentinelClient(t *testing.T, sentinels ...string) *sentinelClient {
+	key, err := outil.BuildSelectorFromConfig(common.MustNewConfigFrom(map[string]interface{}{
+		"key": "events",
+	}), outil.Settings{Key: "key", MultiKey: "keys", EnableSingleOnly: true, FailEmpty: true})
+	require.NoError(t, err)
+
+	transp := &transport.Config{Timeout: time.Second}
+	return newSentinelClient(sentinels, sentinelConfig{MasterName: "mymaster"}, transp,
+		func(conn *transport.Client) *client {
+			return newClient(conn, outputs.NewNilObserver(), time.Second, "", 0, key,
+				redisListType, "test", json.New(false, false, "1.0.0"))
+		})
+}
+
+func TestSentinelFailover(t *testing.T) {
+	primary := newFakeRedis(t, "master")
+	defer primary.close()
+	replica := newFakeRedis(t, "slave")
+	defer replica.close()
+
+	master := primary.addr()
+	sentinel := newFakeSentinel(t, func() string { return master })
+	defer sentinel.close()
+
+	// The first sentinel is down.
+	down := newFakeSentinel(t, nil)
+	down.close()
+
+	c := newTestSentinelClient(t, down.addr(), sentinel.addr())
+	require.NoError(t, c.Connect())
+	assert.Equal(t, []string{sentinel.addr(), down.addr()}, c.sentinels)
+
+	batch := outest.NewBatch(beat.Event{Fields: common.MapStr{"message": "a"}})
+	require.NoError(t, c.Publish(batch))
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+	assert.NoError(t, c.Close())
+
+	// Connecting to a server that is not a master fails.
+	master = replica.addr()
+	assert.Error(t, c.Connect())
+
+	// The new master is used once the failover is complete.
+	replica.close()
+	promoted := newFakeRedis(t, "master")
+	defer promoted.close()
+	master = promoted.addr()
+	require.NoError(t, c.Connect())
+	defer c.Close()
+
+	batch = outest.NewBatch(beat.Event{Fields: common.MapStr{"message": "b"}})
+	require.NoError(t, c.Publish(batch))
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+
+	var pushed []string
+	for _, cmd := range promoted.received() {
+		if cmd[0] == "RPUSH" {
+			pushed = append(pushed, cmd[1])
+		}
+	}
+	assert.Equal(t, []string{"events"}, pushed)
+}
+
+func TestSentinelUnknownMaster(t *testing.T) {
+	sentinel := newFakeServer(t, func(args []string) interface{} { return nil })
+	defer sentinel.close()
+
+	c := newTestSentinelClient(t, sentinel.addr())
+	assert.Error(t, c.Connect())
+
+	batch := outest.NewBatch(beat.Event{Fields: common.MapStr{"message": "a"}})
+	assert.Error(t, c.Publish(batch))
+	assert.Equal(t, outest.BatchRetry, batch.Signals[0].Tag)
+}
+
+func TestSentinelWorkers(t *testing.T) {
+	cfg := common.MustNewConfigFrom(map[string]interface{}{
+		"hosts":                []string{"sentinel1", "sentinel2"},
+		"worker":               3,
+		"key":                  "events",
+		"sentinel.master_name": "mymaster",
+	})
+	group, err := makeRedis(beat.Info{}, outputs.NewNilObserver(), cfg)
+	require.NoError(t, err)
+
+	require.Len(t, group.Clients, 3)
+	for _, c := range group.Clients {
+		sc := c.(*backoffClient).client.(*sentinelClient)
+		assert.Equal(t, []string{"sentinel1", "sentinel2"}, sc.sentinels)
+	}
+}
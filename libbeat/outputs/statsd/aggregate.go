@@ -0,0 +1,91 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package statsd
+
+import (
+	"time"
+)
+
+// aggregator aggregates samples in between flushes. Counters are summed up
+// and gauges keep the last value. Timers are reported as the count, min, max
+// and mean of the samples.
+type aggregator struct {
+	counters map[string]float64
+	gauges   map[string]float64
+	timers   map[string]*timerStats
+}
+
+type timerStats struct {
+	count         int
+	sum, min, max float64
+}
+
+func newAggregator() *aggregator {
+	a := &aggregator{}
+	a.reset()
+	return a
+}
+
+func (a *aggregator) add(s sample) {
+	switch s.typ {
+	case counter:
+		a.counters[s.name] += s.value
+	case gauge:
+		a.gauges[s.name] = s.value
+	case timer:
+		t := a.timers[s.name]
+		if t == nil {
+			a.timers[s.name] = &timerStats{count: 1, sum: s.value, min: s.value, max: s.value}
+			return
+		}
+		t.count++
+		t.sum += s.value
+		if s.value < t.min {
+			t.min = s.value
+		}
+		if s.value > t.max {
+			t.max = s.value
+		}
+	}
+}
+
+// samples returns the aggregated samples, timestamped with ts.
+func (a *aggregator) samples(ts time.Time) []sample {
+	var samples []sample
+	for name, value := range a.counters {
+		samples = append(samples, sample{name: name, typ: counter, value: value, timestamp: ts})
+	}
+	for name, value := range a.gauges {
+		samples = append(samples, sample{name: name, typ: gauge, value: value, timestamp: ts})
+	}
+	for name, t := range a.timers {
+		samples = append(samples,
+			sample{name: name + ".count", typ: counter, value: float64(t.count), timestamp: ts},
+			sample{name: name + ".min", typ: gauge, value: t.min, timestamp: ts},
+			sample{name: name + ".max", typ: gauge, value: t.max, timestamp: ts},
+			sample{name: name + ".mean", typ: gauge, value: t.sum / float64(t.count), timestamp: ts},
+		)
+	}
+	return samples
+}
+
+func (a *aggregator) reset() {
+	a.counters = map[string]float64{}
+	a.gauges = map[string]float64{}
+	a.timers = map[string]*timerStats{}
+}
@@ -0,0 +1,240 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package statsd
+
+import (
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/outputs"
+	"github.com/elastic/beats/libbeat/outputs/transport"
+	"github.com/elastic/beats/libbeat/publisher"
+)
+
+type client struct {
+	*transport.Client
+	observer outputs.Observer
+	timeout  time.Duration
+	protocol protocol
+	prefix   string
+	metrics  []metric
+
+	// stream is true for TCP connections, which send all lines in one write.
+	// UDP connections send packets of up to maxPacketSize bytes.
+	stream        bool
+	maxPacketSize int
+
+	// mutex protects the connection and the aggregator, as aggregated samples
+	// are sent by the flush loop.
+	mutex    sync.Mutex
+	agg      *aggregator
+	interval time.Duration
+	done     chan struct{}
+	wg       sync.WaitGroup
+
+	buf []byte
+}
+
+type clientSettings struct {
+	protocol          protocol
+	prefix            string
+	metrics           []metric
+	timeout           time.Duration
+	stream            bool
+	maxPacketSize     int
+	aggregateInterval time.Duration
+}
+
+func newClient(tc *transport.Client, observer outputs.Observer, s clientSettings) *client {
+	c := &client{
+		Client:        tc,
+		observer:      observer,
+		timeout:       s.timeout,
+		protocol:      s.protocol,
+		prefix:        s.prefix,
+		metrics:       s.metrics,
+		stream:        s.stream,
+		maxPacketSize: s.maxPacketSize,
+		interval:      s.aggregateInterval,
+	}
+	if s.aggregateInterval > 0 {
+		c.agg = newAggregator()
+	}
+	return c
+}
+
+func (c *client) Connect() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.Client.Connect(); err != nil {
+		return err
+	}
+
+	if c.agg != nil && c.done == nil {
+		c.done = make(chan struct{})
+		c.wg.Add(1)
+		go c.flushLoop(c.done)
+	}
+	return nil
+}
+
+func (c *client) Close() error {
+	c.mutex.Lock()
+	done := c.done
+	c.done = nil
+	c.mutex.Unlock()
+
+	if done != nil {
+		close(done)
+		c.wg.Wait()
+		c.flush()
+	}
+
+	debugf("close connection")
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.Client.Close()
+}
+
+func (c *client) Publish(batch publisher.Batch) error {
+	events := batch.Events()
+	c.observer.NewBatch(len(events))
+
+	var samples []sample
+	dropped := 0
+	for i := range events {
+		event := &events[i].Content
+
+		n := len(samples)
+		for j := range c.metrics {
+			s, ok, err := c.metrics[j].sample(c.prefix, event)
+			if err != nil {
+				logp.Err("Failed to create metric name for field '%v': %v", c.metrics[j].field, err)
+				continue
+			}
+			if ok {
+				samples = append(samples, s)
+			}
+		}
+		if len(samples) == n {
+			dropped++
+		}
+	}
+	c.observer.Dropped(dropped)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.agg != nil {
+		for _, s := range samples {
+			c.agg.add(s)
+		}
+		c.observer.Acked(len(events) - dropped)
+		batch.ACK()
+		return nil
+	}
+
+	if err := c.send(samples); err != nil {
+		logp.Err("Failed to send metrics: %v", err)
+		c.observer.Failed(len(events) - dropped)
+		batch.Retry()
+		return err
+	}
+
+	c.observer.Acked(len(events) - dropped)
+	batch.ACK()
+	return nil
+}
+
+func (c *client) String() string {
+	return "statsd(" + c.Client.String() + ")"
+}
+
+func (c *client) flushLoop(done chan struct{}) {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			c.flush()
+		}
+	}
+}
+
+// flush sends the aggregated samples. The samples are kept, and aggregated
+// further, if they can not be sent.
+func (c *client) flush() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	samples := c.agg.samples(time.Now())
+	if len(samples) == 0 {
+		return
+	}
+
+	if !c.Client.IsConnected() {
+		if err := c.Client.Connect(); err != nil {
+			logp.Err("Failed to connect to flush metrics: %v", err)
+			return
+		}
+	}
+
+	if err := c.send(samples); err != nil {
+		logp.Err("Failed to flush metrics: %v", err)
+		c.Client.Close()
+		return
+	}
+	c.agg.reset()
+}
+
+// send writes the samples to the connection. The caller must hold the mutex.
+func (c *client) send(samples []sample) error {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	if err := c.Client.SetWriteDeadline(time.Now().Add(c.timeout)); err != nil {
+		return err
+	}
+
+	buf := c.buf[:0]
+	for _, s := range samples {
+		n := len(buf)
+		buf = appendLine(buf, c.protocol, s)
+		if c.stream || n == 0 || len(buf) <= c.maxPacketSize {
+			continue
+		}
+
+		// The line does not fit into the packet, send the packet first.
+		if _, err := c.Client.Write(buf[:n]); err != nil {
+			return err
+		}
+		buf = append(buf[:0], buf[n:]...)
+	}
+	c.buf = buf
+
+	_, err := c.Client.Write(buf)
+	return err
+}
@@ -0,0 +1,149 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package statsd
+
+import (
+	"bufio"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/outputs"
+	"github.com/elastic/beats/libbeat/outputs/outest"
+	"github.com/elastic/beats/libbeat/outputs/transport"
+)
+
+func newTestClient(t *testing.T, network, host string, s clientSettings) *client {
+	conn, err := transport.NewClient(&transport.Config{Timeout: time.Second}, network, host, 0)
+	require.NoError(t, err)
+
+	s.timeout = time.Second
+	s.stream = network == "tcp"
+	if s.maxPacketSize == 0 {
+		s.maxPacketSize = 1432
+	}
+	s.metrics = []metric{
+		{field: "responsetime", typ: timer},
+		{field: "bytes_in", typ: counter},
+	}
+	c := newClient(conn, outputs.NewNilObserver(), s)
+	require.NoError(t, c.Connect())
+	return c
+}
+
+func newTestBatch(responsetimes ...int) *outest.Batch {
+	events := make([]beat.Event, len(responsetimes))
+	for i, rt := range responsetimes {
+		events[i] = beat.Event{
+			Timestamp: time.Unix(1537873200, 0),
+			Fields:    common.MapStr{"responsetime": rt, "bytes_in": 10},
+		}
+	}
+	return outest.NewBatch(events...)
+}
+
+func readPacket(t *testing.T, l net.PacketConn) string {
+	buf := make([]byte, 65536)
+	l.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := l.ReadFrom(buf)
+	require.NoError(t, err)
+	return string(buf[:n])
+}
+
+func TestPublishUDP(t *testing.T) {
+	l, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	c := newTestClient(t, "udp", l.LocalAddr().String(), clientSettings{maxPacketSize: 40})
+	defer c.Close()
+
+	batch := newTestBatch(5, 7)
+	require.NoError(t, c.Publish(batch))
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+
+	// Lines are packed into packets of up to max_packet_size bytes.
+	assert.Equal(t, "responsetime:5|ms\nbytes_in:10|c\n", readPacket(t, l))
+	assert.Equal(t, "responsetime:7|ms\nbytes_in:10|c\n", readPacket(t, l))
+}
+
+func TestPublishGraphiteTCP(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	c := newTestClient(t, "tcp", l.Addr().String(), clientSettings{
+		protocol: graphiteProtocol,
+		prefix:   "web.",
+	})
+	defer c.Close()
+
+	conn, err := l.Accept()
+	require.NoError(t, err)
+	defer conn.Close()
+
+	batch := newTestBatch(5)
+	require.NoError(t, c.Publish(batch))
+
+	r := bufio.NewReader(conn)
+	for _, expected := range []string{
+		"web.responsetime 5 1537873200\n",
+		"web.bytes_in 10 1537873200\n",
+	} {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		assert.Equal(t, expected, line)
+	}
+}
+
+func TestPublishAggregated(t *testing.T) {
+	l, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	c := newTestClient(t, "udp", l.LocalAddr().String(), clientSettings{
+		aggregateInterval: time.Hour,
+	})
+
+	for _, batch := range []*outest.Batch{newTestBatch(5, 15), newTestBatch(10)} {
+		require.NoError(t, c.Publish(batch))
+		require.Len(t, batch.Signals, 1)
+		assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+	}
+
+	// Close flushes the aggregated metrics.
+	require.NoError(t, c.Close())
+
+	lines := strings.Split(strings.TrimSpace(readPacket(t, l)), "\n")
+	assert.ElementsMatch(t, []string{
+		"bytes_in:30|c",
+		"responsetime.count:3|c",
+		"responsetime.min:5|g",
+		"responsetime.max:15|g",
+		"responsetime.mean:10|g",
+	}, lines)
+}
@@ -0,0 +1,97 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package statsd
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elastic/beats/libbeat/common/fmtstr"
+)
+
+type statsdConfig struct {
+	Protocol          string         `config:"protocol"`
+	Network           string         `config:"network"`
+	Port              int            `config:"port"`
+	Prefix            string         `config:"prefix"`
+	Metrics           []metricConfig `config:"metrics" validate:"required"`
+	AggregateInterval time.Duration  `config:"aggregate_interval" validate:"min=0"`
+	MaxPacketSize     int            `config:"max_packet_size" validate:"min=1"`
+	LoadBalance       bool           `config:"loadbalance"`
+	Timeout           time.Duration  `config:"timeout"`
+	BulkMaxSize       int            `config:"bulk_max_size"`
+	MaxRetries        int            `config:"max_retries" validate:"min=-1"`
+	Backoff           backoff        `config:"backoff"`
+}
+
+type metricConfig struct {
+	Field string                    `config:"field" validate:"required"`
+	Name  *fmtstr.EventFormatString `config:"name"`
+	Type  string                    `config:"type"`
+}
+
+type backoff struct {
+	Init time.Duration
+	Max  time.Duration
+}
+
+const (
+	defaultStatsdPort   = 8125
+	defaultGraphitePort = 2003
+)
+
+var (
+	defaultConfig = statsdConfig{
+		Protocol:      "statsd",
+		MaxPacketSize: 1432,
+		LoadBalance:   true,
+		Timeout:       5 * time.Second,
+		BulkMaxSize:   2048,
+		MaxRetries:    3,
+		Backoff: backoff{
+			Init: 1 * time.Second,
+			Max:  60 * time.Second,
+		},
+	}
+)
+
+func (c *statsdConfig) Validate() error {
+	switch c.Protocol {
+	case "statsd", "graphite":
+	default:
+		return fmt.Errorf("protocol %v not supported", c.Protocol)
+	}
+
+	switch c.Network {
+	case "", "udp", "tcp":
+	default:
+		return fmt.Errorf("network %v not supported", c.Network)
+	}
+
+	if len(c.Metrics) == 0 {
+		return errors.New("no metrics configured")
+	}
+	for _, m := range c.Metrics {
+		if _, err := parseMetricType(m.Type); err != nil {
+			return fmt.Errorf("invalid type of metric for field '%v': %v", m.Field, err)
+		}
+	}
+
+	return nil
+}
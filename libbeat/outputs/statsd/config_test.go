@@ -0,0 +1,59 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package statsd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestValidate(t *testing.T) {
+	metrics := []map[string]interface{}{{"field": "responsetime", "type": "timer"}}
+
+	tests := map[string]struct {
+		settings map[string]interface{}
+		valid    bool
+	}{
+		"metrics":          {map[string]interface{}{"metrics": metrics}, true},
+		"graphite":         {map[string]interface{}{"metrics": metrics, "protocol": "graphite"}, true},
+		"no metrics":       {map[string]interface{}{}, false},
+		"unknown protocol": {map[string]interface{}{"metrics": metrics, "protocol": "influx"}, false},
+		"unknown network":  {map[string]interface{}{"metrics": metrics, "network": "unix"}, false},
+		"unknown type": {map[string]interface{}{
+			"metrics": []map[string]interface{}{{"field": "responsetime", "type": "histogram"}},
+		}, false},
+		"no field": {map[string]interface{}{
+			"metrics": []map[string]interface{}{{"type": "timer"}},
+		}, false},
+	}
+
+	for name, test := range tests {
+		config := defaultConfig
+		err := common.MustNewConfigFrom(test.settings).Unpack(&config)
+		if test.valid {
+			assert.NoError(t, err, name)
+		} else {
+			assert.Error(t, err, name)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package statsd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common/fmtstr"
+)
+
+type metricType uint8
+
+const (
+	gauge metricType = iota
+	counter
+	timer
+)
+
+type protocol uint8
+
+const (
+	statsdProtocol protocol = iota
+	graphiteProtocol
+)
+
+// metric maps a numeric event field to a metric.
+type metric struct {
+	field string
+	name  *fmtstr.EventFormatString
+	typ   metricType
+}
+
+// sample is a value of a metric.
+type sample struct {
+	name      string
+	typ       metricType
+	value     float64
+	timestamp time.Time
+}
+
+// nameReplacer replaces the characters used as separators by StatsD and
+// Graphite in metric names.
+var nameReplacer = strings.NewReplacer(" ", "_", "\t", "_", "\n", "_", ":", "_", "|", "_", "@", "_")
+
+func parseMetricType(s string) (metricType, error) {
+	switch s {
+	case "", "gauge":
+		return gauge, nil
+	case "counter":
+		return counter, nil
+	case "timer":
+		return timer, nil
+	}
+	return gauge, fmt.Errorf("unknown metric type '%v'", s)
+}
+
+func newMetrics(configs []metricConfig) ([]metric, error) {
+	metrics := make([]metric, len(configs))
+	for i, config := range configs {
+		typ, err := parseMetricType(config.Type)
+		if err != nil {
+			return nil, err
+		}
+		metrics[i] = metric{field: config.Field, name: config.Name, typ: typ}
+	}
+	return metrics, nil
+}
+
+// sample returns the sample of the metric for the event. It returns false if
+// the event has no numeric value for the field.
+func (m *metric) sample(prefix string, event *beat.Event) (sample, bool, error) {
+	v, err := event.Fields.GetValue(m.field)
+	if err != nil {
+		return sample{}, false, nil
+	}
+	value, ok := toFloat(v)
+	if !ok {
+		return sample{}, false, nil
+	}
+
+	name := m.field
+	if m.name != nil {
+		if name, err = m.name.Run(event); err != nil {
+			return sample{}, false, err
+		}
+	}
+
+	return sample{
+		name:      nameReplacer.Replace(prefix + name),
+		typ:       m.typ,
+		value:     value,
+		timestamp: event.Timestamp,
+	}, true, nil
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	case float32:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
+
+// appendLine appends the sample as a line, including the newline, in the
+// format of the protocol.
+func appendLine(buf []byte, p protocol, s sample) []byte {
+	buf = append(buf, s.name...)
+	switch p {
+	case graphiteProtocol:
+		buf = append(buf, ' ')
+		buf = strconv.AppendFloat(buf, s.value, 'f', -1, 64)
+		buf = append(buf, ' ')
+		buf = strconv.AppendInt(buf, s.timestamp.Unix(), 10)
+	default:
+		buf = append(buf, ':')
+		buf = strconv.AppendFloat(buf, s.value, 'f', -1, 64)
+		switch s.typ {
+		case counter:
+			buf = append(buf, "|c"...)
+		case timer:
+			buf = append(buf, "|ms"...)
+		default:
+			buf = append(buf, "|g"...)
+		}
+	}
+	return append(buf, '\n')
+}
@@ -0,0 +1,133 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package statsd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/fmtstr"
+)
+
+func TestMetricSample(t *testing.T) {
+	ts := time.Unix(1537873200, 0)
+	event := &beat.Event{
+		Timestamp: ts,
+		Fields: common.MapStr{
+			"type":         "http",
+			"responsetime": 42,
+			"bytes_in":     uint64(1024),
+			"path":         "/index.html",
+		},
+	}
+
+	tests := []struct {
+		name     string
+		metric   metric
+		expected sample
+		ok       bool
+	}{
+		{
+			"field name",
+			metric{field: "bytes_in", typ: counter},
+			sample{name: "beat.bytes_in", typ: counter, value: 1024, timestamp: ts},
+			true,
+		},
+		{
+			"format string name",
+			metric{field: "responsetime", name: fmtstr.MustCompileEvent("%{[type]}.response time"), typ: timer},
+			sample{name: "beat.http.response_time", typ: timer, value: 42, timestamp: ts},
+			true,
+		},
+		{
+			"missing field",
+			metric{field: "bytes_out"},
+			sample{},
+			false,
+		},
+		{
+			"not numeric",
+			metric{field: "path"},
+			sample{},
+			false,
+		},
+	}
+
+	for _, test := range tests {
+		s, ok, err := test.metric.sample("beat.", event)
+		require.NoError(t, err, test.name)
+		assert.Equal(t, test.ok, ok, test.name)
+		assert.Equal(t, test.expected, s, test.name)
+	}
+}
+
+func TestAppendLine(t *testing.T) {
+	ts := time.Unix(1537873200, 0)
+	tests := []struct {
+		protocol protocol
+		sample   sample
+		expected string
+	}{
+		{statsdProtocol, sample{name: "a", typ: counter, value: 3}, "a:3|c\n"},
+		{statsdProtocol, sample{name: "a", typ: gauge, value: 0.5}, "a:0.5|g\n"},
+		{statsdProtocol, sample{name: "a", typ: timer, value: 12.25}, "a:12.25|ms\n"},
+		{graphiteProtocol, sample{name: "a.b", typ: timer, value: 12, timestamp: ts}, "a.b 12 1537873200\n"},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.expected, string(appendLine(nil, test.protocol, test.sample)))
+	}
+}
+
+func TestAggregator(t *testing.T) {
+	a := newAggregator()
+	for _, s := range []sample{
+		{name: "c", typ: counter, value: 1},
+		{name: "c", typ: counter, value: 2},
+		{name: "g", typ: gauge, value: 1},
+		{name: "g", typ: gauge, value: 5},
+		{name: "t", typ: timer, value: 10},
+		{name: "t", typ: timer, value: 30},
+		{name: "t", typ: timer, value: 20},
+	} {
+		a.add(s)
+	}
+
+	values := map[string]float64{}
+	for _, s := range a.samples(time.Now()) {
+		values[s.name] = s.value
+	}
+	assert.Equal(t, map[string]float64{
+		"c":       3,
+		"g":       5,
+		"t.count": 3,
+		"t.min":   10,
+		"t.max":   30,
+		"t.mean":  20,
+	}, values)
+
+	a.reset()
+	assert.Empty(t, a.samples(time.Now()))
+}
@@ -0,0 +1,92 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package statsd
+
+import (
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/outputs"
+	"github.com/elastic/beats/libbeat/outputs/transport"
+)
+
+var debugf = logp.MakeDebug("statsd")
+
+func init() {
+	outputs.RegisterType("statsd", makeStatsd)
+}
+
+func makeStatsd(
+	beat beat.Info,
+	observer outputs.Observer,
+	cfg *common.Config,
+) (outputs.Group, error) {
+	config := defaultConfig
+	if err := cfg.Unpack(&config); err != nil {
+		return outputs.Fail(err)
+	}
+
+	hosts, err := outputs.ReadHostList(cfg)
+	if err != nil {
+		return outputs.Fail(err)
+	}
+
+	metrics, err := newMetrics(config.Metrics)
+	if err != nil {
+		return outputs.Fail(err)
+	}
+
+	proto, network, port := statsdProtocol, "udp", defaultStatsdPort
+	if config.Protocol == "graphite" {
+		proto, network, port = graphiteProtocol, "tcp", defaultGraphitePort
+	}
+	if config.Network != "" {
+		network = config.Network
+	}
+	if config.Port != 0 {
+		port = config.Port
+	}
+
+	settings := clientSettings{
+		protocol:          proto,
+		prefix:            config.Prefix,
+		metrics:           metrics,
+		timeout:           config.Timeout,
+		stream:            network == "tcp",
+		maxPacketSize:     config.MaxPacketSize,
+		aggregateInterval: config.AggregateInterval,
+	}
+
+	transp := &transport.Config{
+		Timeout: config.Timeout,
+		Stats:   observer,
+	}
+
+	clients := make([]outputs.NetworkClient, len(hosts))
+	for i, host := range hosts {
+		conn, err := transport.NewClient(transp, network, host, port)
+		if err != nil {
+			return outputs.Fail(err)
+		}
+
+		client := newClient(conn, observer, settings)
+		clients[i] = outputs.WithBackoff(client, config.Backoff.Init, config.Backoff.Max)
+	}
+
+	return outputs.SuccessNet(config.LoadBalance, config.BulkMaxSize, config.MaxRetries, clients)
+}
@@ -0,0 +1,161 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package syslog
+
+import (
+	"bytes"
+	"strconv"
+	"time"
+
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/outputs"
+	"github.com/elastic/beats/libbeat/outputs/transport"
+	"github.com/elastic/beats/libbeat/publisher"
+)
+
+// maxUDPMessageSize is the largest payload of an UDP datagram over IPv4.
+const maxUDPMessageSize = 65507
+
+type framing uint8
+
+const (
+	octetCounting framing = iota
+	nonTransparent
+)
+
+type client struct {
+	*transport.Client
+	observer  outputs.Observer
+	formatter *formatter
+	timeout   time.Duration
+
+	// stream is true for TCP connections, which send all messages of a
+	// batch in one write using framing to delimit the messages. UDP
+	// connections send one message per datagram.
+	stream  bool
+	framing framing
+
+	msg bytes.Buffer
+	buf bytes.Buffer
+}
+
+func newClient(
+	tc *transport.Client,
+	observer outputs.Observer,
+	timeout time.Duration,
+	stream bool,
+	framing framing,
+	formatter *formatter,
+) *client {
+	return &client{
+		Client:    tc,
+		observer:  observer,
+		formatter: formatter,
+		timeout:   timeout,
+		stream:    stream,
+		framing:   framing,
+	}
+}
+
+func (c *client) Close() error {
+	debugf("close connection")
+	return c.Client.Close()
+}
+
+func (c *client) Publish(batch publisher.Batch) error {
+	events := batch.Events()
+	c.observer.NewBatch(len(events))
+
+	rest, err := c.publishEvents(events)
+	if len(rest) > 0 {
+		c.observer.Failed(len(rest))
+		batch.RetryEvents(rest)
+		return err
+	}
+
+	batch.ACK()
+	return err
+}
+
+func (c *client) String() string {
+	return "syslog(" + c.Client.String() + ")"
+}
+
+// publishEvents sends the events and returns the events to be retried.
+func (c *client) publishEvents(data []publisher.Event) ([]publisher.Event, error) {
+	if err := c.Client.SetWriteDeadline(time.Now().Add(c.timeout)); err != nil {
+		return data, err
+	}
+
+	c.buf.Reset()
+	okEvents := data[:0]
+	dropped := 0
+	for i := range data {
+		event := &data[i]
+
+		c.msg.Reset()
+		if err := c.formatter.format(&c.msg, &event.Content); err != nil {
+			logp.Err("Failed to serialize the event: %v", err)
+			dropped++
+			continue
+		}
+
+		if c.stream {
+			c.frame(&c.buf, c.msg.Bytes())
+			okEvents = append(okEvents, *event)
+			continue
+		}
+
+		if c.msg.Len() > maxUDPMessageSize {
+			logp.Warn("Dropping event of %v bytes, exceeding the maximum UDP message size", c.msg.Len())
+			dropped++
+			continue
+		}
+		if _, err := c.Client.Write(c.msg.Bytes()); err != nil {
+			logp.Err("Failed to send syslog message: %v", err)
+			c.observer.Dropped(dropped)
+			c.observer.Acked(len(okEvents))
+			return data[i:], err
+		}
+		okEvents = append(okEvents, *event)
+	}
+	c.observer.Dropped(dropped)
+
+	if c.buf.Len() > 0 {
+		if _, err := c.Client.Write(c.buf.Bytes()); err != nil {
+			logp.Err("Failed to send syslog messages: %v", err)
+			return okEvents, err
+		}
+	}
+
+	c.observer.Acked(len(okEvents))
+	return nil, nil
+}
+
+// frame appends msg to buf, using the framing of RFC 6587.
+func (c *client) frame(buf *bytes.Buffer, msg []byte) {
+	switch c.framing {
+	case octetCounting:
+		buf.WriteString(strconv.Itoa(len(msg)))
+		buf.WriteByte(' ')
+		buf.Write(msg)
+	case nonTransparent:
+		buf.Write(msg)
+		buf.WriteByte('\n')
+	}
+}
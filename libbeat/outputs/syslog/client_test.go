@@ -0,0 +1,151 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package syslog
+
+import (
+	"bufio"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/outputs"
+	"github.com/elastic/beats/libbeat/outputs/outest"
+	"github.com/elastic/beats/libbeat/outputs/transport"
+)
+
+func newTestClient(t *testing.T, network, host string, framing framing) *client {
+	conn, err := transport.NewClient(&transport.Config{Timeout: time.Second}, network, host, 0)
+	require.NoError(t, err)
+
+	f := newTestFormatter(t, nil)
+	c := newClient(conn, outputs.NewNilObserver(), time.Second, network == "tcp", framing, f)
+	require.NoError(t, c.Connect())
+	return c
+}
+
+func newTestBatch(messages ...string) *outest.Batch {
+	events := make([]beat.Event, len(messages))
+	for i, msg := range messages {
+		events[i] = beat.Event{Timestamp: time.Now(), Fields: common.MapStr{"message": msg}}
+	}
+	return outest.NewBatch(events...)
+}
+
+// messageBody returns the MSG part of a RFC 5424 message without structured data.
+func messageBody(t *testing.T, msg string) string {
+	parts := strings.SplitN(msg, " ", 8)
+	require.Len(t, parts, 8)
+	return parts[7]
+}
+
+func TestPublishTCP(t *testing.T) {
+	tests := map[string]framing{
+		"octet_counting":  octetCounting,
+		"non_transparent": nonTransparent,
+	}
+
+	for name, framing := range tests {
+		t.Run(name, func(t *testing.T) {
+			l, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			defer l.Close()
+
+			c := newTestClient(t, "tcp", l.Addr().String(), framing)
+			defer c.Close()
+
+			conn, err := l.Accept()
+			require.NoError(t, err)
+			defer conn.Close()
+
+			batch := newTestBatch("first", "second line")
+			require.NoError(t, c.Publish(batch))
+			require.Len(t, batch.Signals, 1)
+			assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+
+			r := bufio.NewReader(conn)
+			for _, expected := range []string{"first", "second line"} {
+				var msg string
+				if framing == octetCounting {
+					length, err := r.ReadString(' ')
+					require.NoError(t, err)
+					n, err := strconv.Atoi(strings.TrimSpace(length))
+					require.NoError(t, err)
+					buf := make([]byte, n)
+					_, err = r.Read(buf)
+					require.NoError(t, err)
+					msg = string(buf)
+				} else {
+					msg, err = r.ReadString('\n')
+					require.NoError(t, err)
+					msg = strings.TrimSuffix(msg, "\n")
+				}
+				assert.Equal(t, expected, messageBody(t, msg))
+			}
+		})
+	}
+}
+
+func TestPublishUDP(t *testing.T) {
+	l, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+
+	c := newTestClient(t, "udp", l.LocalAddr().String(), octetCounting)
+	defer c.Close()
+
+	batch := newTestBatch("first", "second", strings.Repeat("x", maxUDPMessageSize))
+	require.NoError(t, c.Publish(batch))
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchACK, batch.Signals[0].Tag)
+
+	// The oversized message is dropped.
+	buf := make([]byte, maxUDPMessageSize)
+	for _, expected := range []string{"first", "second"} {
+		l.SetReadDeadline(time.Now().Add(time.Second))
+		n, _, err := l.ReadFrom(buf)
+		require.NoError(t, err)
+		assert.Equal(t, expected, messageBody(t, string(buf[:n])))
+	}
+}
+
+func TestPublishRetryOnError(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	c := newTestClient(t, "tcp", l.Addr().String(), octetCounting)
+	conn, err := l.Accept()
+	require.NoError(t, err)
+	conn.Close()
+	l.Close()
+	c.Close()
+
+	batch := newTestBatch("first", "second")
+	assert.Error(t, c.Publish(batch))
+	require.Len(t, batch.Signals, 1)
+	assert.Equal(t, outest.BatchRetryEvents, batch.Signals[0].Tag)
+	assert.Len(t, batch.Signals[0].Events, 2)
+}
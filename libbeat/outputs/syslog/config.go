@@ -0,0 +1,105 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package syslog
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elastic/beats/libbeat/common/transport/tlscommon"
+	"github.com/elastic/beats/libbeat/outputs/codec"
+)
+
+type syslogConfig struct {
+	Network         string            `config:"network"`
+	Port            int               `config:"port"`
+	LoadBalance     bool              `config:"loadbalance"`
+	Timeout         time.Duration     `config:"timeout"`
+	BulkMaxSize     int               `config:"bulk_max_size"`
+	MaxRetries      int               `config:"max_retries" validate:"min=-1"`
+	TLS             *tlscommon.Config `config:"ssl"`
+	Codec           codec.Config      `config:"codec"`
+	Framing         string            `config:"framing"`
+	Facility        string            `config:"facility"`
+	Severity        string            `config:"severity"`
+	FacilityField   string            `config:"facility_field"`
+	SeverityField   string            `config:"severity_field"`
+	SeverityMapping map[string]string `config:"severity_mapping"`
+	AppName         string            `config:"app_name"`
+	Backoff         backoff           `config:"backoff"`
+}
+
+type backoff struct {
+	Init time.Duration
+	Max  time.Duration
+}
+
+const (
+	defaultPort    = 514
+	defaultTLSPort = 6514
+)
+
+var (
+	defaultConfig = syslogConfig{
+		Network:     "udp",
+		LoadBalance: true,
+		Timeout:     5 * time.Second,
+		BulkMaxSize: 2048,
+		MaxRetries:  3,
+		Framing:     "octet_counting",
+		Facility:    "user",
+		Severity:    "info",
+		Backoff: backoff{
+			Init: 1 * time.Second,
+			Max:  60 * time.Second,
+		},
+	}
+)
+
+func (c *syslogConfig) Validate() error {
+	switch c.Network {
+	case "udp", "tcp":
+	default:
+		return fmt.Errorf("syslog network %v not supported", c.Network)
+	}
+
+	if c.Network == "udp" && c.TLS.IsEnabled() {
+		return errors.New("ssl can not be used with network udp")
+	}
+
+	switch c.Framing {
+	case "octet_counting", "non_transparent":
+	default:
+		return fmt.Errorf("syslog framing %v not supported", c.Framing)
+	}
+
+	if _, err := parseFacility(c.Facility); err != nil {
+		return err
+	}
+	if _, err := parseSeverity(c.Severity); err != nil {
+		return err
+	}
+	for value, severity := range c.SeverityMapping {
+		if _, err := parseSeverity(severity); err != nil {
+			return fmt.Errorf("invalid severity_mapping for '%v': %v", value, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package syslog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestValidate(t *testing.T) {
+	tests := map[string]struct {
+		settings map[string]interface{}
+		valid    bool
+	}{
+		"defaults":        {map[string]interface{}{}, true},
+		"tcp with tls":    {map[string]interface{}{"network": "tcp", "ssl.verification_mode": "none"}, true},
+		"udp with tls":    {map[string]interface{}{"ssl.verification_mode": "none"}, false},
+		"unknown network": {map[string]interface{}{"network": "unix"}, false},
+		"unknown framing": {map[string]interface{}{"framing": "none"}, false},
+		"bad facility":    {map[string]interface{}{"facility": "local8"}, false},
+		"bad severity":    {map[string]interface{}{"severity": "8"}, false},
+		"bad mapping":     {map[string]interface{}{"severity_mapping": map[string]interface{}{"x": "y"}}, false},
+	}
+
+	for name, test := range tests {
+		config := defaultConfig
+		err := common.MustNewConfigFrom(test.settings).Unpack(&config)
+		if test.valid {
+			assert.NoError(t, err, name)
+		} else {
+			assert.Error(t, err, name)
+		}
+	}
+}
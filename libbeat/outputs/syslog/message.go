@@ -0,0 +1,209 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package syslog
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/outputs/codec"
+)
+
+// rfc5424Time is the TIMESTAMP format of RFC 5424, which allows at most 6
+// digits of fractional seconds.
+const rfc5424Time = "2006-01-02T15:04:05.999999Z07:00"
+
+// Maximum lengths of the header fields, as defined in RFC 5424.
+const (
+	maxHostnameLen = 255
+	maxAppNameLen  = 48
+	maxProcIDLen   = 128
+)
+
+var facilities = map[string]int{
+	"kern":     0,
+	"user":     1,
+	"mail":     2,
+	"daemon":   3,
+	"auth":     4,
+	"syslog":   5,
+	"lpr":      6,
+	"news":     7,
+	"uucp":     8,
+	"cron":     9,
+	"authpriv": 10,
+	"ftp":      11,
+	"ntp":      12,
+	"security": 13,
+	"console":  14,
+	"clock":    15,
+	"local0":   16,
+	"local1":   17,
+	"local2":   18,
+	"local3":   19,
+	"local4":   20,
+	"local5":   21,
+	"local6":   22,
+	"local7":   23,
+}
+
+var severities = map[string]int{
+	"emerg":         0,
+	"emergency":     0,
+	"alert":         1,
+	"crit":          2,
+	"critical":      2,
+	"err":           3,
+	"error":         3,
+	"warning":       4,
+	"warn":          4,
+	"notice":        5,
+	"info":          6,
+	"informational": 6,
+	"debug":         7,
+}
+
+func parseFacility(s string) (int, error) {
+	return parseCode("facility", facilities, 23, s)
+}
+
+func parseSeverity(s string) (int, error) {
+	return parseCode("severity", severities, 7, s)
+}
+
+// parseCode accepts a name (case insensitive) or the numerical code.
+func parseCode(kind string, names map[string]int, max int, s string) (int, error) {
+	if code, found := names[strings.ToLower(s)]; found {
+		return code, nil
+	}
+	code, err := strconv.Atoi(s)
+	if err != nil || code < 0 || code > max {
+		return 0, fmt.Errorf("invalid syslog %v '%v'", kind, s)
+	}
+	return code, nil
+}
+
+// formatter creates RFC 5424 messages from events. The MSG part is the event
+// encoded with the configured codec.
+type formatter struct {
+	index    string
+	codec    codec.Codec
+	hostname string
+	appName  string
+	procID   string
+
+	facility        int
+	severity        int
+	facilityField   string
+	severityField   string
+	severityMapping map[string]string
+}
+
+func newFormatter(info beat.Info, config *syslogConfig, codec codec.Codec) *formatter {
+	// The configuration is validated, so the defaults can be parsed.
+	facility, _ := parseFacility(config.Facility)
+	severity, _ := parseSeverity(config.Severity)
+
+	appName := config.AppName
+	if appName == "" {
+		appName = info.Beat
+	}
+
+	return &formatter{
+		index:           info.Beat,
+		codec:           codec,
+		hostname:        headerField(info.Hostname, maxHostnameLen),
+		appName:         headerField(appName, maxAppNameLen),
+		procID:          headerField(strconv.Itoa(os.Getpid()), maxProcIDLen),
+		facility:        facility,
+		severity:        severity,
+		facilityField:   config.FacilityField,
+		severityField:   config.SeverityField,
+		severityMapping: config.SeverityMapping,
+	}
+}
+
+// format appends the message for event to buf.
+func (f *formatter) format(buf *bytes.Buffer, event *beat.Event) error {
+	msg, err := f.codec.Encode(f.index, event)
+	if err != nil {
+		return err
+	}
+
+	pri := f.eventFacility(event)*8 + f.eventSeverity(event)
+	fmt.Fprintf(buf, "<%d>1 %s %s %s %s - - ",
+		pri, event.Timestamp.Format(rfc5424Time), f.hostname, f.appName, f.procID)
+	buf.Write(msg)
+	return nil
+}
+
+func (f *formatter) eventFacility(event *beat.Event) int {
+	if value, ok := fieldString(event, f.facilityField); ok {
+		if facility, err := parseFacility(value); err == nil {
+			return facility
+		}
+		debugf("Ignoring invalid facility '%v' in field %v", value, f.facilityField)
+	}
+	return f.facility
+}
+
+func (f *formatter) eventSeverity(event *beat.Event) int {
+	if value, ok := fieldString(event, f.severityField); ok {
+		if mapped, found := f.severityMapping[value]; found {
+			value = mapped
+		}
+		if severity, err := parseSeverity(value); err == nil {
+			return severity
+		}
+		debugf("Ignoring invalid severity '%v' in field %v", value, f.severityField)
+	}
+	return f.severity
+}
+
+func fieldString(event *beat.Event, field string) (string, bool) {
+	if field == "" {
+		return "", false
+	}
+	value, err := event.GetValue(field)
+	if err != nil || value == nil {
+		return "", false
+	}
+	return fmt.Sprint(value), true
+}
+
+// headerField removes the characters not allowed in RFC 5424 header fields
+// and truncates s to max bytes. Empty values are replaced by the NILVALUE.
+func headerField(s string, max int) string {
+	field := strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return -1
+		}
+		return r
+	}, s)
+	if len(field) > max {
+		field = field[:max]
+	}
+	if field == "" {
+		return "-"
+	}
+	return field
+}
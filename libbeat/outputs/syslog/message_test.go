@@ -0,0 +1,118 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package syslog
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/fmtstr"
+	"github.com/elastic/beats/libbeat/outputs/codec/format"
+)
+
+func newTestFormatter(t *testing.T, settings map[string]interface{}) *formatter {
+	cfg := common.MustNewConfigFrom(settings)
+	config := defaultConfig
+	require.NoError(t, cfg.Unpack(&config))
+
+	info := beat.Info{Beat: "testbeat", Hostname: "my host"}
+	enc := format.New(fmtstr.MustCompileEvent("%{[message]}"))
+	return newFormatter(info, &config, enc)
+}
+
+func formatEvent(t *testing.T, f *formatter, fields common.MapStr) string {
+	var buf bytes.Buffer
+	err := f.format(&buf, &beat.Event{
+		Timestamp: time.Date(2018, 6, 12, 10, 20, 30, 123456789, time.UTC),
+		Fields:    fields,
+	})
+	require.NoError(t, err)
+	return buf.String()
+}
+
+func TestFormat(t *testing.T) {
+	f := newTestFormatter(t, nil)
+	msg := formatEvent(t, f, common.MapStr{"message": "hello"})
+
+	expected := fmt.Sprintf("<14>1 2018-06-12T10:20:30.123456Z myhost testbeat %d - - hello", os.Getpid())
+	assert.Equal(t, expected, msg)
+}
+
+func TestFormatPriority(t *testing.T) {
+	f := newTestFormatter(t, map[string]interface{}{
+		"facility":       "local3",
+		"severity":       "notice",
+		"facility_field": "log.facility",
+		"severity_field": "status",
+		"severity_mapping": map[string]interface{}{
+			"Error": "err",
+		},
+	})
+
+	tests := []struct {
+		fields   common.MapStr
+		expected string
+	}{
+		{common.MapStr{}, "<157>"},
+		{common.MapStr{"status": "OK"}, "<157>"},
+		{common.MapStr{"status": "Error"}, "<155>"},
+		{common.MapStr{"status": "warn"}, "<156>"},
+		{common.MapStr{"status": 2}, "<154>"},
+		{common.MapStr{"log": common.MapStr{"facility": "auth"}}, "<37>"},
+		{common.MapStr{"log": common.MapStr{"facility": 99}}, "<157>"},
+	}
+
+	for _, test := range tests {
+		test.fields["message"] = "hello"
+		msg := formatEvent(t, f, test.fields)
+		assert.Equal(t, test.expected, msg[:len(test.expected)], "fields: %v", test.fields)
+	}
+}
+
+func TestParseCode(t *testing.T) {
+	code, err := parseSeverity("WARNING")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, code)
+
+	code, err = parseFacility("23")
+	assert.NoError(t, err)
+	assert.Equal(t, 23, code)
+
+	_, err = parseFacility("24")
+	assert.Error(t, err)
+
+	_, err = parseSeverity("verbose")
+	assert.Error(t, err)
+}
+
+func TestHeaderField(t *testing.T) {
+	assert.Equal(t, "-", headerField("", maxAppNameLen))
+	assert.Equal(t, "-", headerField(" \t", maxAppNameLen))
+	assert.Equal(t, "myapp", headerField("my app", maxAppNameLen))
+	assert.Equal(t, "abc", headerField("abcdef", 3))
+}
@@ -46,7 +46,7 @@ type Config struct {
 func MakeDialer(c *Config) (Dialer, error) {
 	var err error
 	dialer := NetDialer(c.Timeout)
-	dialer, err = ProxyDialer(c.Proxy, dialer)
+	dialer, err = ProxyDialer(c.Proxy, dialer, c.Timeout)
 	if err != nil {
 		return nil, err
 	}
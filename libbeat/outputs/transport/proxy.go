@@ -18,8 +18,13 @@
 package transport
 
 import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
 	"net"
+	"net/http"
 	"net/url"
+	"time"
 
 	"golang.org/x/net/proxy"
 
@@ -27,16 +32,32 @@ import (
 )
 
 // ProxyConfig holds the configuration information required to proxy
-// connections through a SOCKS5 proxy server.
+// connections through a SOCKS5 or HTTP proxy server.
 type ProxyConfig struct {
-	// URL of the SOCKS proxy. Scheme must be socks5. Username and password can be
-	// embedded in the URL.
+	// URL of the proxy. Scheme must be socks5 or http. HTTP proxies are used
+	// with the CONNECT method. Username and password can be embedded in the URL.
 	URL string `config:"proxy_url"`
 
-	// Resolve names locally instead of on the SOCKS server.
+	// Resolve names locally instead of on the proxy server.
 	LocalResolve bool `config:"proxy_use_local_resolver"`
 }
 
+// httpProxyDialer establishes connections through an HTTP proxy using the
+// CONNECT method.
+type httpProxyDialer struct {
+	url     *url.URL
+	forward Dialer
+	timeout time.Duration // limits the CONNECT handshake, if set
+}
+
+// proxyConn is a connection established through an HTTP proxy. Reads go
+// through the reader used to parse the response to the CONNECT request, so no
+// data buffered by the reader is lost.
+type proxyConn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
 func (c *ProxyConfig) Validate() error {
 	if c.URL == "" {
 		return nil
@@ -46,14 +67,17 @@ func (c *ProxyConfig) Validate() error {
 	if err != nil {
 		return err
 	}
-	if _, err := proxy.FromURL(url, nil); err != nil {
+	if _, err := proxyFromURL(url, nil, 0); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func ProxyDialer(config *ProxyConfig, forward Dialer) (Dialer, error) {
+// ProxyDialer returns a dialer connecting through the configured proxy, or
+// forward if no proxy is configured. The timeout limits the CONNECT request of
+// HTTP proxies, so that a proxy that never replies doesn't block the dialer.
+func ProxyDialer(config *ProxyConfig, forward Dialer, timeout time.Duration) (Dialer, error) {
 	if config == nil || config.URL == "" {
 		return forward, nil
 	}
@@ -63,7 +87,7 @@ func ProxyDialer(config *ProxyConfig, forward Dialer) (Dialer, error) {
 		return nil, err
 	}
 
-	if _, err := proxy.FromURL(url, nil); err != nil {
+	if _, err := proxyFromURL(url, nil, 0); err != nil {
 		return nil, err
 	}
 
@@ -85,15 +109,93 @@ func ProxyDialer(config *ProxyConfig, forward Dialer) (Dialer, error) {
 			}
 		} else {
 			// Do not resolve the address locally. It will be resolved on the
-			// SOCKS server. The beat will have no control over the randomization
+			// proxy server. The beat will have no control over the randomization
 			// of the IP used when multiple IPs are returned by DNS.
 			addresses = []string{host}
 		}
 
-		dialer, err := proxy.FromURL(url, forward)
+		dialer, err := proxyFromURL(url, forward, timeout)
 		if err != nil {
 			return nil, err
 		}
 		return DialWith(dialer, network, host, addresses, port)
 	}), nil
 }
+
+func proxyFromURL(url *url.URL, forward Dialer, timeout time.Duration) (Dialer, error) {
+	if url.Scheme == "http" {
+		if url.Host == "" {
+			return nil, fmt.Errorf("missing host in proxy URL '%v'", url)
+		}
+		return &httpProxyDialer{url: url, forward: forward, timeout: timeout}, nil
+	}
+
+	var d proxy.Dialer
+	if forward != nil {
+		d = forward
+	}
+	return proxy.FromURL(url, d)
+}
+
+func (d *httpProxyDialer) Dial(network, address string) (net.Conn, error) {
+	switch network {
+	case "tcp", "tcp4", "tcp6":
+	default:
+		return nil, fmt.Errorf("network '%v' not supported by HTTP proxy", network)
+	}
+
+	proxyAddress := d.url.Host
+	if d.url.Port() == "" {
+		proxyAddress = net.JoinHostPort(d.url.Hostname(), "80")
+	}
+	conn, err := d.forward.Dial("tcp", proxyAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &http.Request{
+		Method: "CONNECT",
+		URL:    &url.URL{Opaque: address},
+		Host:   address,
+		Header: http.Header{},
+	}
+	if user := d.url.User; user != nil {
+		password, _ := user.Password()
+		auth := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+auth)
+	}
+	if d.timeout > 0 {
+		if err := conn.SetDeadline(time.Now().Add(d.timeout)); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	if err := req.Write(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(reader, req)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("proxy connect to %v failed: %v", address, resp.Status)
+	}
+	if d.timeout > 0 {
+		if err := conn.SetDeadline(time.Time{}); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return &proxyConn{Conn: conn, reader: reader}, nil
+}
+
+func (c *proxyConn) Read(b []byte) (int, error) {
+	return c.reader.Read(b)
+}
@@ -20,8 +20,11 @@
 package transptest
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"net"
+	"net/http"
 	"testing"
 	"time"
 
@@ -63,15 +66,70 @@ func newSOCKS5Proxy(t *testing.T) (net.Listener, transport.ProxyConfig) {
 	return l, config
 }
 
+// newHTTPProxy starts a new HTTP proxy server supporting the CONNECT method
+// that listens on localhost.
+func newHTTPProxy(t *testing.T) (net.Listener, transport.ProxyConfig) {
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go serveHTTPConnect(conn)
+		}
+	}()
+
+	config := transport.ProxyConfig{URL: fmt.Sprintf("http://%s", l.Addr().String())}
+	return l, config
+}
+
+func serveHTTPConnect(conn net.Conn) {
+	defer conn.Close()
+
+	req, err := http.ReadRequest(bufio.NewReader(conn))
+	if err != nil || req.Method != "CONNECT" {
+		return
+	}
+
+	target, err := net.Dial("tcp", req.Host)
+	if err != nil {
+		fmt.Fprintf(conn, "HTTP/1.1 502 Bad Gateway\r\n\r\n")
+		return
+	}
+	defer target.Close()
+	fmt.Fprintf(conn, "HTTP/1.1 200 Connection established\r\n\r\n")
+
+	go io.Copy(target, conn)
+	io.Copy(conn, target)
+}
+
+func newProxies(t *testing.T) (map[string]*transport.ProxyConfig, func()) {
+	socksListener, socksConfig := newSOCKS5Proxy(t)
+	httpListener, httpConfig := newHTTPProxy(t)
+	proxies := map[string]*transport.ProxyConfig{
+		"socks5": &socksConfig,
+		"http":   &httpConfig,
+	}
+	return proxies, func() {
+		socksListener.Close()
+		httpListener.Close()
+	}
+}
+
 func TestTransportReconnectsOnConnect(t *testing.T) {
-	l, config := newSOCKS5Proxy(t)
-	defer l.Close()
+	proxies, closeProxies := newProxies(t)
+	defer closeProxies()
 
 	certName := "ca_test"
 	timeout := 2 * time.Second
 	GenCertForTestingPurpose(t, "127.0.0.1", certName, "")
 
-	testServer(t, &config, func(t *testing.T, makeServer MockServerFactory, proxy *transport.ProxyConfig) {
+	testServer(t, proxies, func(t *testing.T, makeServer MockServerFactory, proxy *transport.ProxyConfig) {
 		server := makeServer(t, timeout, certName, proxy)
 		defer server.Close()
 
@@ -102,8 +160,8 @@ func TestTransportReconnectsOnConnect(t *testing.T) {
 }
 
 func TestTransportFailConnectUnknownAddress(t *testing.T) {
-	l, config := newSOCKS5Proxy(t)
-	defer l.Close()
+	proxies, closeProxies := newProxies(t)
+	defer closeProxies()
 
 	certName := "ca_test"
 	GenCertForTestingPurpose(t, "127.0.0.1", certName, "")
@@ -125,7 +183,9 @@ func TestTransportFailConnectUnknownAddress(t *testing.T) {
 	factoryTests := func(f TransportFactory) func(*testing.T) {
 		return func(t *testing.T) {
 			t.Run("connect", run(f, nil))
-			t.Run("socks5", run(f, &config))
+			for name, proxy := range proxies {
+				t.Run(name, run(f, proxy))
+			}
 		}
 	}
 
@@ -135,14 +195,14 @@ func TestTransportFailConnectUnknownAddress(t *testing.T) {
 }
 
 func TestTransportClosedOnWriteReadError(t *testing.T) {
-	l, config := newSOCKS5Proxy(t)
-	defer l.Close()
+	proxies, closeProxies := newProxies(t)
+	defer closeProxies()
 
 	certName := "ca_test"
 	timeout := 2 * time.Second
 	GenCertForTestingPurpose(t, "127.0.0.1", certName, "")
 
-	testServer(t, &config, func(t *testing.T, makeServer MockServerFactory, proxy *transport.ProxyConfig) {
+	testServer(t, proxies, func(t *testing.T, makeServer MockServerFactory, proxy *transport.ProxyConfig) {
 		server := makeServer(t, timeout, certName, proxy)
 		defer server.Close()
 
@@ -159,17 +219,50 @@ func TestTransportClosedOnWriteReadError(t *testing.T) {
 	})
 }
 
-func testServer(t *testing.T, config *transport.ProxyConfig, run func(*testing.T, MockServerFactory, *transport.ProxyConfig)) {
+func TestTransportHTTPProxyConnectTimeout(t *testing.T) {
+	// proxy accepting connections without ever replying to CONNECT
+	l, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	transp, err := transport.NewClient(&transport.Config{
+		Proxy:   &transport.ProxyConfig{URL: fmt.Sprintf("http://%s", l.Addr().String())},
+		Timeout: 100 * time.Millisecond,
+	}, "tcp", "localhost:9200", 0)
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+
+	start := time.Now()
+	err = transp.Connect()
+	assert.Error(t, err)
+	assert.True(t, time.Since(start) < 5*time.Second, "connect took %v", time.Since(start))
+}
+
+func testServer(t *testing.T, proxies map[string]*transport.ProxyConfig, run func(*testing.T, MockServerFactory, *transport.ProxyConfig)) {
 	runner := func(f MockServerFactory, c *transport.ProxyConfig) func(t *testing.T) {
 		return func(t *testing.T) {
-			run(t, f, config)
+			run(t, f, c)
 		}
 	}
 
 	factoryTests := func(f MockServerFactory) func(t *testing.T) {
 		return func(t *testing.T) {
 			t.Run("connect", runner(f, nil))
-			t.Run("socks5", runner(f, config))
+			for name, proxy := range proxies {
+				t.Run(name, runner(f, proxy))
+			}
 		}
 	}
 
@@ -0,0 +1,271 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package actions
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+type anonymizeFields struct {
+	config anonymizeFieldsConfig
+}
+
+type anonymizeFieldsConfig struct {
+	Fields        []anonymizeRule `config:"fields"`
+	IgnoreMissing bool            `config:"ignore_missing"`
+	FailOnError   bool            `config:"fail_on_error"`
+}
+
+type anonymizeRule struct {
+	Field       string          `config:"field"`
+	Method      anonymizeMethod `config:"method"`
+	Key         string          `config:"key"`         // HMAC key used by hash.
+	Replacement *string         `config:"replacement"` // Value used by redact.
+	Length      int             `config:"length"`      // Characters kept by truncate.
+	IPv4Mask    *int            `config:"ipv4_mask"`   // Prefix length kept by mask_ip.
+	IPv6Mask    *int            `config:"ipv6_mask"`   // Prefix length kept by mask_ip.
+}
+
+type anonymizeMethod string
+
+const (
+	anonymizeHash     anonymizeMethod = "hash"
+	anonymizeRedact   anonymizeMethod = "redact"
+	anonymizeTruncate anonymizeMethod = "truncate"
+	anonymizeMaskIP   anonymizeMethod = "mask_ip"
+)
+
+const (
+	defaultRedactReplacement = "REDACTED"
+	defaultIPv4Mask          = 24
+	defaultIPv6Mask          = 64
+)
+
+// Unpack unpacks a string to an anonymizeMethod.
+func (m *anonymizeMethod) Unpack(v string) error {
+	switch method := anonymizeMethod(strings.ToLower(v)); method {
+	case anonymizeHash, anonymizeRedact, anonymizeTruncate, anonymizeMaskIP:
+		*m = method
+		return nil
+	}
+	return errors.Errorf("invalid anonymize method '%v' (valid values are: hash, redact, truncate, mask_ip)", v)
+}
+
+// Validate checks the rule is complete and sets the defaults of the
+// method-specific options.
+func (r *anonymizeRule) Validate() error {
+	if r.Field == "" {
+		return errors.New("field is required")
+	}
+
+	switch r.Method {
+	case "":
+		return errors.Errorf("method is required for field '%v'", r.Field)
+	case anonymizeTruncate:
+		if r.Length <= 0 {
+			return errors.Errorf("length must be greater than 0 for field '%v'", r.Field)
+		}
+	case anonymizeMaskIP:
+		if r.IPv4Mask == nil {
+			mask := defaultIPv4Mask
+			r.IPv4Mask = &mask
+		}
+		if r.IPv6Mask == nil {
+			mask := defaultIPv6Mask
+			r.IPv6Mask = &mask
+		}
+		if *r.IPv4Mask < 0 || *r.IPv4Mask > 32 {
+			return errors.Errorf("ipv4_mask must be between 0 and 32 for field '%v'", r.Field)
+		}
+		if *r.IPv6Mask < 0 || *r.IPv6Mask > 128 {
+			return errors.Errorf("ipv6_mask must be between 0 and 128 for field '%v'", r.Field)
+		}
+	}
+	return nil
+}
+
+func init() {
+	processors.RegisterPlugin("anonymize_fields",
+		configChecked(newAnonymizeFields,
+			requireFields("fields"),
+			allowedFields("fields", "ignore_missing", "fail_on_error", "when")))
+}
+
+func newAnonymizeFields(c *common.Config) (processors.Processor, error) {
+	config := anonymizeFieldsConfig{
+		IgnoreMissing: false,
+		FailOnError:   true,
+	}
+	err := c.Unpack(&config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack the anonymize_fields configuration: %s", err)
+	}
+
+	return &anonymizeFields{config: config}, nil
+}
+
+// Run anonymizes the configured fields. The event is never reverted, as that
+// would publish the personal data: values that can't be anonymized are
+// redacted, and if fail_on_error is set the event is dropped.
+func (f *anonymizeFields) Run(event *beat.Event) (*beat.Event, error) {
+	var missing error
+	for _, rule := range f.config.Fields {
+		err := f.anonymizeField(rule, event.Fields)
+		if err == nil {
+			continue
+		}
+		if errors.Cause(err) == common.ErrKeyNotFound {
+			// A missing field has nothing to anonymize, so the event is kept.
+			if missing == nil {
+				missing = err
+			}
+			continue
+		}
+		if f.config.FailOnError {
+			logp.Debug("anonymize_fields", "Failed to anonymize fields, dropping the event: %s", err)
+			return nil, err
+		}
+	}
+
+	return event, missing
+}
+
+func (f *anonymizeFields) anonymizeField(rule anonymizeRule, fields common.MapStr) error {
+	value, err := fields.GetValue(rule.Field)
+	if err != nil {
+		// Ignore ErrKeyNotFound errors
+		if f.config.IgnoreMissing && errors.Cause(err) == common.ErrKeyNotFound {
+			return nil
+		}
+		return errors.Wrapf(err, "could not fetch value for key: %s", rule.Field)
+	}
+
+	value, applyErr := rule.apply(value)
+	if applyErr != nil {
+		value = rule.replacement()
+	}
+
+	_, err = fields.Put(rule.Field, value)
+	if err != nil {
+		return fmt.Errorf("could not put value: %s: %v, %+v", rule.Field, value, err)
+	}
+	if applyErr != nil {
+		return fmt.Errorf("could not anonymize key: %s, Error: %s", rule.Field, applyErr)
+	}
+	return nil
+}
+
+func (r *anonymizeRule) apply(value interface{}) (interface{}, error) {
+	switch r.Method {
+	case anonymizeHash:
+		return r.hash(value), nil
+	case anonymizeRedact:
+		return r.replacement(), nil
+	case anonymizeTruncate:
+		s, ok := value.(string)
+		if !ok {
+			return nil, errors.Errorf("unexpected type %T, truncate requires a string", value)
+		}
+		return truncateString(s, r.Length), nil
+	case anonymizeMaskIP:
+		s, ok := value.(string)
+		if !ok {
+			return nil, errors.Errorf("unexpected type %T, mask_ip requires a string", value)
+		}
+		return r.maskIP(s)
+	}
+	return nil, errors.Errorf("unknown method '%v'", r.Method)
+}
+
+// replacement returns the value used by redact, which also replaces the values
+// that can't be anonymized by the other methods.
+func (r *anonymizeRule) replacement() string {
+	if r.Replacement != nil {
+		return *r.Replacement
+	}
+	return defaultRedactReplacement
+}
+
+// hash returns the hex encoded SHA-256 of the value. When a key is configured
+// HMAC-SHA256 is used instead, so values can't be recovered by hashing a list
+// of candidates.
+func (r *anonymizeRule) hash(value interface{}) string {
+	var s string
+	switch v := value.(type) {
+	case string:
+		s = v
+	default:
+		s = fmt.Sprint(v)
+	}
+
+	if r.Key == "" {
+		sum := sha256.Sum256([]byte(s))
+		return hex.EncodeToString(sum[:])
+	}
+	h := hmac.New(sha256.New, []byte(r.Key))
+	h.Write([]byte(s))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func (r *anonymizeRule) maskIP(s string) (string, error) {
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return "", errors.Errorf("'%v' is not a valid IP address", s)
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return ip4.Mask(net.CIDRMask(*r.IPv4Mask, 32)).String(), nil
+	}
+	return ip.Mask(net.CIDRMask(*r.IPv6Mask, 128)).String(), nil
+}
+
+// truncateString returns the first n characters of s. Multi-byte characters
+// are never split.
+func truncateString(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	i := 0
+	for pos := range s {
+		if i == n {
+			return s[:pos]
+		}
+		i++
+	}
+	return s
+}
+
+func (f *anonymizeFields) String() string {
+	fields := make([]string, len(f.config.Fields))
+	for i, rule := range f.config.Fields {
+		fields[i] = rule.Field + ":" + string(rule.Method)
+	}
+	return "anonymize_fields=" + strings.Join(fields, ", ")
+}
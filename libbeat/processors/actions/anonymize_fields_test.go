@@ -0,0 +1,229 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package actions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestAnonymizeFields(t *testing.T) {
+	var tests = []struct {
+		description string
+		config      map[string]interface{}
+		Input       common.MapStr
+		Output      common.MapStr
+		error       bool
+	}{
+		{
+			description: "mask ipv4 and ipv6 addresses",
+			config: map[string]interface{}{
+				"fields": []map[string]interface{}{
+					{"field": "client_ip", "method": "mask_ip"},
+					{"field": "ip", "method": "mask_ip", "ipv6_mask": 48},
+				},
+			},
+			Input: common.MapStr{
+				"client_ip": "192.168.10.42",
+				"ip":        "2001:db8:85a3:1234::8a2e:370:7334",
+			},
+			Output: common.MapStr{
+				"client_ip": "192.168.10.0",
+				"ip":        "2001:db8:85a3::",
+			},
+		},
+		{
+			description: "mask of 0 bits",
+			config: map[string]interface{}{
+				"fields": []map[string]interface{}{
+					{"field": "client_ip", "method": "mask_ip", "ipv4_mask": 0},
+					{"field": "ip", "method": "mask_ip", "ipv6_mask": 0},
+				},
+			},
+			Input: common.MapStr{
+				"client_ip": "192.168.10.42",
+				"ip":        "2001:db8:85a3:1234::8a2e:370:7334",
+			},
+			Output: common.MapStr{
+				"client_ip": "0.0.0.0",
+				"ip":        "::",
+			},
+		},
+		{
+			description: "hash with and without key",
+			config: map[string]interface{}{
+				"fields": []map[string]interface{}{
+					{"field": "user", "method": "hash", "key": "secret"},
+					{"field": "http.request.path", "method": "hash"},
+				},
+			},
+			Input: common.MapStr{
+				"user": "alice",
+				"http": common.MapStr{"request": common.MapStr{"path": "/"}},
+			},
+			Output: common.MapStr{
+				"user": "4360c67bc81025114044578d7c4e8e0f02fd0cae99f22d603390e8f9dc9888f8",
+				"http": common.MapStr{"request": common.MapStr{
+					"path": "8a5edab282632443219e051e4ade2d1d5bbc671c781051bf1437897cbdfea0f1",
+				}},
+			},
+		},
+		{
+			description: "redact and truncate",
+			config: map[string]interface{}{
+				"fields": []map[string]interface{}{
+					{"field": "password", "method": "redact"},
+					{"field": "token", "method": "redact", "replacement": ""},
+					{"field": "query", "method": "truncate", "length": 3},
+				},
+			},
+			Input: common.MapStr{
+				"password": "hunter2",
+				"token":    12345,
+				"query":    "héllo",
+			},
+			Output: common.MapStr{
+				"password": "REDACTED",
+				"token":    "",
+				"query":    "hél",
+			},
+		},
+		{
+			description: "missing field is ignored",
+			config: map[string]interface{}{
+				"fields": []map[string]interface{}{
+					{"field": "user", "method": "hash"},
+				},
+				"ignore_missing": true,
+			},
+			Input: common.MapStr{
+				"other": "value",
+			},
+			Output: common.MapStr{
+				"other": "value",
+			},
+		},
+		{
+			description: "invalid ip drops the event",
+			config: map[string]interface{}{
+				"fields": []map[string]interface{}{
+					{"field": "password", "method": "redact"},
+					{"field": "client_ip", "method": "mask_ip"},
+				},
+			},
+			Input: common.MapStr{
+				"password":  "hunter2",
+				"client_ip": "localhost",
+			},
+			Output: nil,
+			error:  true,
+		},
+		{
+			description: "missing field keeps the event",
+			config: map[string]interface{}{
+				"fields": []map[string]interface{}{
+					{"field": "user", "method": "hash"},
+					{"field": "password", "method": "redact"},
+				},
+			},
+			Input: common.MapStr{
+				"password": "hunter2",
+			},
+			Output: common.MapStr{
+				"password": "REDACTED",
+			},
+			error: true,
+		},
+		{
+			description: "values that can't be anonymized are redacted when fail_on_error is disabled",
+			config: map[string]interface{}{
+				"fields": []map[string]interface{}{
+					{"field": "client_ip", "method": "mask_ip"},
+					{"field": "query", "method": "truncate", "length": 3, "replacement": "-"},
+					{"field": "password", "method": "redact"},
+				},
+				"fail_on_error": false,
+			},
+			Input: common.MapStr{
+				"password":  "hunter2",
+				"client_ip": "localhost",
+				"query":     42,
+			},
+			Output: common.MapStr{
+				"password":  "REDACTED",
+				"client_ip": "REDACTED",
+				"query":     "-",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			cfg, err := common.NewConfigFrom(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			p, err := newAnonymizeFields(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			event, err := p.Run(&beat.Event{Fields: test.Input})
+			if test.error {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			if test.Output == nil {
+				assert.Nil(t, event)
+				return
+			}
+			if assert.NotNil(t, event) {
+				assert.Equal(t, test.Output, event.Fields)
+			}
+		})
+	}
+}
+
+func TestAnonymizeFieldsInvalidConfig(t *testing.T) {
+	configs := map[string][]map[string]interface{}{
+		"missing method": {{"field": "user"}},
+		"unknown method": {{"field": "user", "method": "rot13"}},
+		"missing length": {{"field": "user", "method": "truncate"}},
+		"invalid mask":   {{"field": "ip", "method": "mask_ip", "ipv4_mask": 33}},
+		"negative mask":  {{"field": "ip", "method": "mask_ip", "ipv6_mask": -1}},
+		"missing field":  {{"method": "hash"}},
+	}
+
+	for name, fields := range configs {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := common.NewConfigFrom(map[string]interface{}{"fields": fields})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = newAnonymizeFields(cfg)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,297 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package actions
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+type convert struct {
+	config convertConfig
+}
+
+type convertConfig struct {
+	Fields        []convertField `config:"fields"`
+	IgnoreMissing bool           `config:"ignore_missing"`
+	FailOnError   bool           `config:"fail_on_error"`
+}
+
+type convertField struct {
+	From string   `config:"from"`
+	To   string   `config:"to"`
+	Type dataType `config:"type"`
+}
+
+type dataType string
+
+const (
+	typeString  dataType = "string"
+	typeInteger dataType = "integer"
+	typeFloat   dataType = "float"
+	typeBoolean dataType = "boolean"
+	typeIP      dataType = "ip"
+)
+
+// Unpack unpacks a string to a dataType. long and double are accepted as
+// aliases of integer and float, like in the Elasticsearch mapping.
+func (t *dataType) Unpack(v string) error {
+	switch dt := dataType(strings.ToLower(v)); dt {
+	case typeString, typeInteger, typeFloat, typeBoolean, typeIP:
+		*t = dt
+	case "long":
+		*t = typeInteger
+	case "double":
+		*t = typeFloat
+	default:
+		return errors.Errorf("invalid conversion type '%v' (valid values are: string, integer, long, float, double, boolean, ip)", v)
+	}
+	return nil
+}
+
+// Validate checks the field names and type are set.
+func (f *convertField) Validate() error {
+	if f.From == "" {
+		return errors.New("from is required")
+	}
+	if f.Type == "" {
+		return errors.Errorf("type is required for field '%v'", f.From)
+	}
+	return nil
+}
+
+func init() {
+	processors.RegisterPlugin("convert",
+		configChecked(newConvert,
+			requireFields("fields"),
+			allowedFields("fields", "ignore_missing", "fail_on_error", "when")))
+}
+
+func newConvert(c *common.Config) (processors.Processor, error) {
+	config := convertConfig{
+		IgnoreMissing: false,
+		FailOnError:   true,
+	}
+	err := c.Unpack(&config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack the convert configuration: %s", err)
+	}
+
+	return &convert{config: config}, nil
+}
+
+func (f *convert) Run(event *beat.Event) (*beat.Event, error) {
+	var backup common.MapStr
+	// Creates a copy of the event to revert in case of failure
+	if f.config.FailOnError {
+		backup = event.Fields.Clone()
+	}
+
+	for _, field := range f.config.Fields {
+		err := f.convertField(field, event.Fields)
+		if err != nil && f.config.FailOnError {
+			logp.Debug("convert", "Failed to convert fields, revert to old event: %s", err)
+			event.Fields = backup
+			return event, err
+		}
+	}
+
+	return event, nil
+}
+
+func (f *convert) convertField(field convertField, fields common.MapStr) error {
+	value, err := fields.GetValue(field.From)
+	if err != nil {
+		// Ignore ErrKeyNotFound errors
+		if f.config.IgnoreMissing && errors.Cause(err) == common.ErrKeyNotFound {
+			return nil
+		}
+		return fmt.Errorf("could not fetch value for key: %s, Error: %s", field.From, err)
+	}
+
+	converted, err := convertValue(value, field.Type)
+	if err != nil {
+		return fmt.Errorf("could not convert key: %s to %s, Error: %s", field.From, field.Type, err)
+	}
+
+	to := field.To
+	if to == "" {
+		to = field.From
+	}
+	_, err = fields.Put(to, converted)
+	if err != nil {
+		return fmt.Errorf("could not put value: %s: %v, %+v", to, converted, err)
+	}
+	return nil
+}
+
+func convertValue(value interface{}, t dataType) (interface{}, error) {
+	switch t {
+	case typeString:
+		return toString(value), nil
+	case typeInteger:
+		return toInteger(value)
+	case typeFloat:
+		return toFloat(value)
+	case typeBoolean:
+		return toBoolean(value)
+	case typeIP:
+		return toIP(value)
+	}
+	return nil, errors.Errorf("unknown type '%v'", t)
+}
+
+func toString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprint(value)
+}
+
+func toInteger(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case string:
+		n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return 0, errors.Errorf("'%v' is not an integer", v)
+		}
+		return n, nil
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case float32:
+		return floatToInteger(float64(v))
+	case float64:
+		return floatToInteger(v)
+	case uint64:
+		if v > math.MaxInt64 {
+			return 0, errors.Errorf("%v overflows a long", v)
+		}
+		return int64(v), nil
+	}
+
+	if n, ok := common.TryToInt(value); ok {
+		return int64(n), nil
+	}
+	return 0, errors.Errorf("unexpected type %T", value)
+}
+
+// floatToInteger only converts floats without fractional part, so values are
+// never silently rounded.
+func floatToInteger(f float64) (int64, error) {
+	// float64(math.MaxInt64) is rounded to 2^63, which doesn't fit in an int64.
+	if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, errors.Errorf("%v can not be converted to an integer without loss", f)
+	}
+	return int64(f), nil
+}
+
+func toFloat(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, errors.Errorf("'%v' is not a number", v)
+		}
+		return f, nil
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case uint64:
+		return float64(v), nil
+	}
+
+	if n, ok := common.TryToInt(value); ok {
+		return float64(n), nil
+	}
+	return 0, errors.Errorf("unexpected type %T", value)
+}
+
+func toBoolean(value interface{}) (bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		b, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return false, errors.Errorf("'%v' is not a boolean", v)
+		}
+		return b, nil
+	case float32:
+		return v != 0, nil
+	case float64:
+		return v != 0, nil
+	case uint64:
+		return v != 0, nil
+	}
+
+	if n, ok := common.TryToInt(value); ok {
+		return n != 0, nil
+	}
+	return false, errors.Errorf("unexpected type %T", value)
+}
+
+// toIP validates and normalizes an IP address. Integers are interpreted as
+// IPv4 addresses in network byte order.
+func toIP(value interface{}) (string, error) {
+	if s, ok := value.(string); ok {
+		ip := net.ParseIP(strings.TrimSpace(s))
+		if ip == nil {
+			return "", errors.Errorf("'%v' is not an IP address", s)
+		}
+		return ip.String(), nil
+	}
+
+	n, err := toInteger(value)
+	if err != nil {
+		return "", err
+	}
+	if n < 0 || n > math.MaxUint32 {
+		return "", errors.Errorf("%v is not an IPv4 address", n)
+	}
+	ip := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(ip, uint32(n))
+	return ip.String(), nil
+}
+
+func (f *convert) String() string {
+	fields := make([]string, len(f.config.Fields))
+	for i, field := range f.config.Fields {
+		fields[i] = fmt.Sprintf("%v:%v", field.From, field.Type)
+	}
+	return "convert=" + strings.Join(fields, ", ")
+}
@@ -0,0 +1,237 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package actions
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestConvert(t *testing.T) {
+	var tests = []struct {
+		description string
+		config      map[string]interface{}
+		Input       common.MapStr
+		Output      common.MapStr
+		error       bool
+	}{
+		{
+			description: "convert in place",
+			config: map[string]interface{}{
+				"fields": []map[string]interface{}{
+					{"from": "status", "type": "integer"},
+					{"from": "duration", "type": "float"},
+					{"from": "secure", "type": "boolean"},
+					{"from": "port", "type": "string"},
+					{"from": "ratio", "type": "string"},
+				},
+			},
+			Input: common.MapStr{
+				"status":   "200",
+				"duration": "1.5",
+				"secure":   "true",
+				"port":     uint16(80),
+				"ratio":    0.25,
+			},
+			Output: common.MapStr{
+				"status":   int64(200),
+				"duration": 1.5,
+				"secure":   true,
+				"port":     "80",
+				"ratio":    "0.25",
+			},
+		},
+		{
+			description: "convert to other fields",
+			config: map[string]interface{}{
+				"fields": []map[string]interface{}{
+					{"from": "raw.addr", "to": "ip", "type": "ip"},
+					{"from": "raw.count", "to": "count", "type": "long"},
+					{"from": "raw.count", "to": "enabled", "type": "boolean"},
+					{"from": "raw.count", "to": "count_float", "type": "double"},
+				},
+			},
+			Input: common.MapStr{
+				"raw": common.MapStr{"addr": uint32(3232235777), "count": 3},
+			},
+			Output: common.MapStr{
+				"raw":         common.MapStr{"addr": uint32(3232235777), "count": 3},
+				"ip":          "192.168.1.1",
+				"count":       int64(3),
+				"enabled":     true,
+				"count_float": float64(3),
+			},
+		},
+		{
+			description: "normalize ip strings",
+			config: map[string]interface{}{
+				"fields": []map[string]interface{}{
+					{"from": "ip", "type": "ip"},
+				},
+			},
+			Input: common.MapStr{
+				"ip": "2001:0db8:0000:0000:0000:0000:0000:0001",
+			},
+			Output: common.MapStr{
+				"ip": "2001:db8::1",
+			},
+		},
+		{
+			description: "failure reverts the event",
+			config: map[string]interface{}{
+				"fields": []map[string]interface{}{
+					{"from": "status", "type": "integer"},
+					{"from": "duration", "type": "integer"},
+				},
+			},
+			Input: common.MapStr{
+				"status":   "200",
+				"duration": 1.5,
+			},
+			Output: common.MapStr{
+				"status":   "200",
+				"duration": 1.5,
+			},
+			error: true,
+		},
+		{
+			description: "failure is ignored when fail_on_error is disabled",
+			config: map[string]interface{}{
+				"fields": []map[string]interface{}{
+					{"from": "status", "type": "integer"},
+					{"from": "secure", "type": "boolean"},
+				},
+				"fail_on_error": false,
+			},
+			Input: common.MapStr{
+				"status": "OK",
+				"secure": "false",
+			},
+			Output: common.MapStr{
+				"status": "OK",
+				"secure": false,
+			},
+		},
+		{
+			description: "missing field is ignored",
+			config: map[string]interface{}{
+				"fields": []map[string]interface{}{
+					{"from": "status", "type": "integer"},
+				},
+				"ignore_missing": true,
+			},
+			Input: common.MapStr{
+				"other": "value",
+			},
+			Output: common.MapStr{
+				"other": "value",
+			},
+		},
+		{
+			description: "missing field is an error",
+			config: map[string]interface{}{
+				"fields": []map[string]interface{}{
+					{"from": "status", "type": "integer"},
+				},
+			},
+			Input: common.MapStr{
+				"other": "value",
+			},
+			Output: common.MapStr{
+				"other": "value",
+			},
+			error: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			cfg, err := common.NewConfigFrom(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			p, err := newConvert(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			event, err := p.Run(&beat.Event{Fields: test.Input})
+			if test.error {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.Output, event.Fields)
+		})
+	}
+}
+
+func TestFloatToInteger(t *testing.T) {
+	var tests = []struct {
+		value    float64
+		expected int64
+		error    bool
+	}{
+		{value: -1, expected: -1},
+		{value: 1 << 62, expected: 1 << 62},
+		{value: math.MinInt64, expected: math.MinInt64},
+		{value: math.Nextafter(math.MaxInt64, 0), expected: 1<<63 - 1024},
+		{value: math.MaxInt64, error: true},
+		{value: math.Nextafter(math.MinInt64, math.Inf(-1)), error: true},
+		{value: 1.5, error: true},
+		{value: math.Inf(1), error: true},
+		{value: math.NaN(), error: true},
+	}
+
+	for _, test := range tests {
+		n, err := floatToInteger(test.value)
+		if test.error {
+			assert.Error(t, err, "%v", test.value)
+			continue
+		}
+		if assert.NoError(t, err, "%v", test.value) {
+			assert.Equal(t, test.expected, n, "%v", test.value)
+		}
+	}
+}
+
+func TestConvertInvalidConfig(t *testing.T) {
+	configs := map[string][]map[string]interface{}{
+		"missing type": {{"from": "status"}},
+		"unknown type": {{"from": "status", "type": "date"}},
+		"missing from": {{"type": "integer"}},
+	}
+
+	for name, fields := range configs {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := common.NewConfigFrom(map[string]interface{}{"fields": fields})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = newConvert(cfg)
+			assert.Error(t, err)
+		})
+	}
+}
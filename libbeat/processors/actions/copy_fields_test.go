@@ -0,0 +1,216 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package actions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestCopyFieldsRun(t *testing.T) {
+	var tests = []struct {
+		description   string
+		Fields        []fromTo
+		IgnoreMissing bool
+		FailOnError   bool
+		Input         common.MapStr
+		Output        common.MapStr
+		error         bool
+	}{
+		{
+			description: "simple field copy",
+			Fields: []fromTo{
+				{
+					From: "a",
+					To:   "b",
+				},
+			},
+			Input: common.MapStr{
+				"a": "c",
+			},
+			Output: common.MapStr{
+				"a": "c",
+				"b": "c",
+			},
+			IgnoreMissing: false,
+			FailOnError:   true,
+			error:         false,
+		},
+		{
+			description: "copy nested field into another namespace",
+			Fields: []fromTo{
+				{
+					From: "http.request.headers",
+					To:   "headers",
+				},
+			},
+			Input: common.MapStr{
+				"http": common.MapStr{
+					"request": common.MapStr{
+						"headers": common.MapStr{"host": "example.com"},
+					},
+				},
+			},
+			Output: common.MapStr{
+				"http": common.MapStr{
+					"request": common.MapStr{
+						"headers": common.MapStr{"host": "example.com"},
+					},
+				},
+				"headers": common.MapStr{"host": "example.com"},
+			},
+			IgnoreMissing: false,
+			FailOnError:   true,
+			error:         false,
+		},
+		{
+			description: "target field exists, event is reverted",
+			Fields: []fromTo{
+				{
+					From: "a",
+					To:   "c",
+				},
+				{
+					From: "a",
+					To:   "b",
+				},
+			},
+			Input: common.MapStr{
+				"a": 1,
+				"b": 2,
+			},
+			Output: common.MapStr{
+				"a": 1,
+				"b": 2,
+			},
+			IgnoreMissing: false,
+			FailOnError:   true,
+			error:         true,
+		},
+		{
+			description: "target field exists, copy continues because fail_on_error is false",
+			Fields: []fromTo{
+				{
+					From: "a",
+					To:   "b",
+				},
+				{
+					From: "a",
+					To:   "c",
+				},
+			},
+			Input: common.MapStr{
+				"a": 1,
+				"b": 2,
+			},
+			Output: common.MapStr{
+				"a": 1,
+				"b": 2,
+				"c": 1,
+			},
+			IgnoreMissing: false,
+			FailOnError:   false,
+			error:         false,
+		},
+		{
+			description: "missing field is ignored",
+			Fields: []fromTo{
+				{
+					From: "x",
+					To:   "y",
+				},
+			},
+			Input: common.MapStr{
+				"a": 1,
+			},
+			Output: common.MapStr{
+				"a": 1,
+			},
+			IgnoreMissing: true,
+			FailOnError:   true,
+			error:         false,
+		},
+		{
+			description: "missing field fails",
+			Fields: []fromTo{
+				{
+					From: "x",
+					To:   "y",
+				},
+			},
+			Input: common.MapStr{
+				"a": 1,
+			},
+			Output: common.MapStr{
+				"a": 1,
+			},
+			IgnoreMissing: false,
+			FailOnError:   true,
+			error:         true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			f := &copyFields{
+				config: copyFieldsConfig{
+					Fields:        test.Fields,
+					IgnoreMissing: test.IgnoreMissing,
+					FailOnError:   test.FailOnError,
+				},
+			}
+			event := &beat.Event{
+				Fields: test.Input,
+			}
+
+			newEvent, err := f.Run(event)
+			if !test.error {
+				assert.Nil(t, err)
+			} else {
+				assert.NotNil(t, err)
+			}
+
+			assert.Equal(t, test.Output, newEvent.Fields)
+		})
+	}
+}
+
+func TestCopyFieldsClonesObjects(t *testing.T) {
+	f := &copyFields{
+		config: copyFieldsConfig{
+			Fields:      []fromTo{{From: "a", To: "b"}},
+			FailOnError: true,
+		},
+	}
+	event := &beat.Event{
+		Fields: common.MapStr{
+			"a": common.MapStr{"x": 1},
+		},
+	}
+
+	newEvent, err := f.Run(event)
+	assert.NoError(t, err)
+
+	newEvent.Fields.Put("b.x", 2)
+	v, _ := newEvent.Fields.GetValue("a.x")
+	assert.Equal(t, 1, v)
+}
@@ -125,7 +125,7 @@ func (f *decodeJSONFields) Run(event *beat.Event) (*beat.Event, error) {
 	}
 
 	if len(errs) > 0 {
-		return event, fmt.Errorf(strings.Join(errs, ", "))
+		return event, fmt.Errorf("%s", strings.Join(errs, ", "))
 	}
 	return event, nil
 }
@@ -29,8 +29,8 @@ import (
 
 var fields = [1]string{"msg"}
 var testConfig, _ = common.NewConfigFrom(map[string]interface{}{
-	"fields":       fields,
-	"processArray": false,
+	"fields":        fields,
+	"process_array": false,
 })
 
 func TestMissingKey(t *testing.T) {
@@ -199,6 +199,61 @@ func TestTargetRootOption(t *testing.T) {
 	assert.Equal(t, expected.String(), actual.String())
 }
 
+func TestOverwriteKeysOption(t *testing.T) {
+	input := common.MapStr{
+		"msg":      "{\"pipeline\":\"eu1\",\"level\":\"info\"}",
+		"pipeline": "us1",
+	}
+
+	for _, overwrite := range []bool{false, true} {
+		testConfig, _ = common.NewConfigFrom(map[string]interface{}{
+			"fields":         fields,
+			"target":         "",
+			"overwrite_keys": overwrite,
+		})
+
+		actual := getActualValue(t, testConfig, input.Clone())
+
+		pipeline := "us1"
+		if overwrite {
+			pipeline = "eu1"
+		}
+		expected := common.MapStr{
+			"msg":      "{\"pipeline\":\"eu1\",\"level\":\"info\"}",
+			"pipeline": pipeline,
+			"level":    "info",
+		}
+
+		assert.Equal(t, expected.String(), actual.String())
+	}
+}
+
+func TestProcessArrayOption(t *testing.T) {
+	input := common.MapStr{
+		"msg": "[\"{\\\"a\\\":1}\", 2]",
+	}
+
+	for _, processArray := range []bool{false, true} {
+		testConfig, _ = common.NewConfigFrom(map[string]interface{}{
+			"fields":        fields,
+			"max_depth":     2,
+			"process_array": processArray,
+		})
+
+		actual := getActualValue(t, testConfig, input.Clone())
+
+		var first interface{} = "{\"a\":1}"
+		if processArray {
+			first = map[string]interface{}{"a": 1}
+		}
+		expected := common.MapStr{
+			"msg": []interface{}{first, 2},
+		}
+
+		assert.Equal(t, expected.String(), actual.String())
+	}
+}
+
 func getActualValue(t *testing.T, config *common.Config, input common.MapStr) common.MapStr {
 	logp.TestingSetup()
 
@@ -0,0 +1,104 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package actions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+func TestDropEvent(t *testing.T) {
+	p, err := newDropEvent(common.NewConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{"type": "http"}})
+	assert.NoError(t, err)
+	assert.Nil(t, event)
+}
+
+func TestDropEventWhen(t *testing.T) {
+	cfg, err := common.NewConfigFrom(map[string]interface{}{
+		"when.equals.http.request.path": "/health",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	procs, err := processors.New(processors.PluginConfig{{"drop_event": cfg}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var tests = []struct {
+		description string
+		Input       common.MapStr
+		Dropped     bool
+	}{
+		{
+			description: "matching event is dropped",
+			Input: common.MapStr{
+				"type": "http",
+				"http": common.MapStr{"request": common.MapStr{"path": "/health"}},
+			},
+			Dropped: true,
+		},
+		{
+			description: "other events are kept",
+			Input: common.MapStr{
+				"type": "http",
+				"http": common.MapStr{"request": common.MapStr{"path": "/index.html"}},
+			},
+			Dropped: false,
+		},
+		{
+			description: "events without the field are kept",
+			Input: common.MapStr{
+				"type": "dns",
+			},
+			Dropped: false,
+		},
+	}
+
+	for _, test := range tests {
+		event := procs.Run(&beat.Event{Fields: test.Input})
+		if test.Dropped {
+			assert.Nil(t, event, test.description)
+		} else {
+			assert.NotNil(t, event, test.description)
+		}
+	}
+}
+
+func TestDropEventUnexpectedOption(t *testing.T) {
+	cfg, err := common.NewConfigFrom(map[string]interface{}{
+		"fields": []string{"a"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = processors.New(processors.PluginConfig{{"drop_event": cfg}})
+	assert.Error(t, err)
+}
@@ -47,18 +47,26 @@ func newDropFields(c *common.Config) (processors.Processor, error) {
 	}
 
 	/* remove read only fields */
-	for _, readOnly := range processors.MandatoryExportedFields {
-		for i, field := range config.Fields {
-			if readOnly == field {
-				config.Fields = append(config.Fields[:i], config.Fields[i+1:]...)
-			}
+	fields := make([]string, 0, len(config.Fields))
+	for _, field := range config.Fields {
+		if !isMandatoryField(field) {
+			fields = append(fields, field)
 		}
 	}
 
-	f := &dropFields{Fields: config.Fields}
+	f := &dropFields{Fields: fields}
 	return f, nil
 }
 
+func isMandatoryField(field string) bool {
+	for _, readOnly := range processors.MandatoryExportedFields {
+		if readOnly == field {
+			return true
+		}
+	}
+	return false
+}
+
 func (f *dropFields) Run(event *beat.Event) (*beat.Event, error) {
 	var errors []string
 
@@ -71,7 +79,7 @@ func (f *dropFields) Run(event *beat.Event) (*beat.Event, error) {
 	}
 
 	if len(errors) > 0 {
-		return event, fmt.Errorf(strings.Join(errors, ", "))
+		return event, fmt.Errorf("%s", strings.Join(errors, ", "))
 	}
 	return event, nil
 }
@@ -0,0 +1,119 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package actions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestDropFields(t *testing.T) {
+	var tests = []struct {
+		description string
+		Fields      []string
+		Input       common.MapStr
+		Output      common.MapStr
+		error       bool
+	}{
+		{
+			description: "drop top level field",
+			Fields:      []string{"a"},
+			Input: common.MapStr{
+				"a": "x",
+				"b": "y",
+			},
+			Output: common.MapStr{
+				"b": "y",
+			},
+		},
+		{
+			description: "drop nested field without flattening the event",
+			Fields:      []string{"http.request.headers.cookie"},
+			Input: common.MapStr{
+				"http": common.MapStr{
+					"request": common.MapStr{
+						"headers": common.MapStr{
+							"cookie": "secret",
+							"host":   "example.com",
+						},
+					},
+				},
+			},
+			Output: common.MapStr{
+				"http": common.MapStr{
+					"request": common.MapStr{
+						"headers": common.MapStr{
+							"host": "example.com",
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "missing field reports an error",
+			Fields:      []string{"a", "c"},
+			Input: common.MapStr{
+				"a": "x",
+				"b": "y",
+			},
+			Output: common.MapStr{
+				"b": "y",
+			},
+			error: true,
+		},
+	}
+
+	for _, test := range tests {
+		p := dropFields{
+			Fields: test.Fields,
+		}
+
+		event := &beat.Event{
+			Fields: test.Input,
+		}
+
+		newEvent, err := p.Run(event)
+		if test.error {
+			assert.Error(t, err, test.description)
+		} else {
+			assert.NoError(t, err, test.description)
+		}
+
+		assert.Equal(t, test.Output, newEvent.Fields, test.description)
+	}
+}
+
+func TestDropFieldsKeepsMandatoryFields(t *testing.T) {
+	cfg, err := common.NewConfigFrom(map[string]interface{}{
+		"fields": []string{"type", "type", "a"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := newDropFields(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"a"}, p.(*dropFields).Fields)
+}
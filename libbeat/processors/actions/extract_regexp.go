@@ -0,0 +1,152 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package actions
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+type extractRegexp struct {
+	config   extractRegexpConfig
+	patterns []*regexp.Regexp
+}
+
+type extractRegexpConfig struct {
+	Field         string   `config:"field" validate:"required"`
+	Patterns      []string `config:"patterns" validate:"required"`
+	TargetPrefix  string   `config:"target_prefix"`
+	OverwriteKeys bool     `config:"overwrite_keys"`
+	IgnoreMissing bool     `config:"ignore_missing"`
+	TagOnFailure  []string `config:"tag_on_failure"`
+}
+
+func init() {
+	processors.RegisterPlugin("extract_regexp",
+		configChecked(newExtractRegexp,
+			requireFields("field", "patterns"),
+			allowedFields("field", "patterns", "target_prefix", "overwrite_keys",
+				"ignore_missing", "tag_on_failure", "when")))
+}
+
+func newExtractRegexp(c *common.Config) (processors.Processor, error) {
+	config := extractRegexpConfig{
+		TagOnFailure: []string{"_extract_regexp_failure"},
+	}
+	err := c.Unpack(&config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack the extract_regexp configuration: %s", err)
+	}
+
+	patterns := make([]*regexp.Regexp, len(config.Patterns))
+	for i, pattern := range config.Patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile extract_regexp pattern '%s': %s", pattern, err)
+		}
+
+		named := false
+		for _, name := range re.SubexpNames() {
+			if name != "" {
+				named = true
+				break
+			}
+		}
+		if !named {
+			return nil, fmt.Errorf("extract_regexp pattern '%s' has no named capture group", pattern)
+		}
+
+		patterns[i] = re
+	}
+
+	return &extractRegexp{config: config, patterns: patterns}, nil
+}
+
+func (f *extractRegexp) Run(event *beat.Event) (*beat.Event, error) {
+	err := f.extract(event)
+	if err != nil {
+		common.AddTags(event.Fields, f.config.TagOnFailure)
+	}
+	return event, err
+}
+
+func (f *extractRegexp) extract(event *beat.Event) error {
+	v, err := event.GetValue(f.config.Field)
+	if err != nil {
+		if f.config.IgnoreMissing && errors.Cause(err) == common.ErrKeyNotFound {
+			return nil
+		}
+		return fmt.Errorf("could not fetch value for key: %s, Error: %s", f.config.Field, err)
+	}
+
+	text, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("field %s is not a string", f.config.Field)
+	}
+
+	for _, re := range f.patterns {
+		match := re.FindStringSubmatchIndex(text)
+		if match == nil {
+			continue
+		}
+
+		captures := common.MapStr{}
+		for i, name := range re.SubexpNames() {
+			if name == "" || match[2*i] < 0 {
+				continue
+			}
+			captures[name] = text[match[2*i]:match[2*i+1]]
+		}
+		return f.putCaptures(event, captures)
+	}
+
+	return fmt.Errorf("no pattern matched field %s", f.config.Field)
+}
+
+func (f *extractRegexp) putCaptures(event *beat.Event, captures common.MapStr) error {
+	prefix := f.config.TargetPrefix
+	if prefix != "" {
+		prefix += "."
+	}
+
+	if !f.config.OverwriteKeys {
+		for name := range captures {
+			if exists, _ := event.Fields.HasKey(prefix + name); exists {
+				return fmt.Errorf("target field %s already exists, drop or rename this field first", prefix+name)
+			}
+		}
+	}
+
+	for name, value := range captures {
+		if _, err := event.PutValue(prefix+name, value); err != nil {
+			return fmt.Errorf("could not put value: %s: %v, %+v", prefix+name, value, err)
+		}
+	}
+	return nil
+}
+
+func (f *extractRegexp) String() string {
+	return "extract_regexp=[field=" + f.config.Field + ", patterns=" + strings.Join(f.config.Patterns, ", ") + "]"
+}
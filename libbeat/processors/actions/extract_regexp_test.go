@@ -0,0 +1,195 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package actions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestExtractRegexp(t *testing.T) {
+	var tests = []struct {
+		description string
+		config      map[string]interface{}
+		Input       common.MapStr
+		Output      common.MapStr
+		error       bool
+	}{
+		{
+			description: "extract named captures",
+			config: map[string]interface{}{
+				"field":    "path",
+				"patterns": []string{`^/api/(?P<tenant>[^/]+)/requests/(?P<request_id>\d+)`},
+			},
+			Input: common.MapStr{
+				"path": "/api/acme/requests/42",
+			},
+			Output: common.MapStr{
+				"path":       "/api/acme/requests/42",
+				"tenant":     "acme",
+				"request_id": "42",
+			},
+		},
+		{
+			description: "patterns are tried in order",
+			config: map[string]interface{}{
+				"field": "path",
+				"patterns": []string{
+					`^/v2/(?P<tenant>[^/]+)`,
+					`^/(?P<tenant>[^/]+)/(?P<page>[^/]+)`,
+					`^/(?P<fallback>.*)`,
+				},
+				"target_prefix": "http.extracted",
+			},
+			Input: common.MapStr{
+				"path": "/acme/index",
+			},
+			Output: common.MapStr{
+				"path": "/acme/index",
+				"http": common.MapStr{
+					"extracted": common.MapStr{
+						"tenant": "acme",
+						"page":   "index",
+					},
+				},
+			},
+		},
+		{
+			description: "optional groups that did not participate are skipped",
+			config: map[string]interface{}{
+				"field":    "path",
+				"patterns": []string{`^/(?P<tenant>\w+)(?:/(?P<page>\w+))?$`},
+			},
+			Input: common.MapStr{
+				"path": "/acme",
+			},
+			Output: common.MapStr{
+				"path":   "/acme",
+				"tenant": "acme",
+			},
+		},
+		{
+			description: "no match adds failure tag",
+			config: map[string]interface{}{
+				"field":    "path",
+				"patterns": []string{`^/api/(?P<tenant>\w+)`},
+			},
+			Input: common.MapStr{
+				"path": "/other",
+			},
+			Output: common.MapStr{
+				"path": "/other",
+				"tags": []string{"_extract_regexp_failure"},
+			},
+			error: true,
+		},
+		{
+			description: "existing keys are not overwritten",
+			config: map[string]interface{}{
+				"field":          "path",
+				"patterns":       []string{`^/(?P<tenant>\w+)`},
+				"tag_on_failure": []string{"failed"},
+			},
+			Input: common.MapStr{
+				"path":   "/acme",
+				"tenant": "other",
+			},
+			Output: common.MapStr{
+				"path":   "/acme",
+				"tenant": "other",
+				"tags":   []string{"failed"},
+			},
+			error: true,
+		},
+		{
+			description: "existing keys are overwritten with overwrite_keys",
+			config: map[string]interface{}{
+				"field":          "path",
+				"patterns":       []string{`^/(?P<tenant>\w+)`},
+				"overwrite_keys": true,
+			},
+			Input: common.MapStr{
+				"path":   "/acme",
+				"tenant": "other",
+			},
+			Output: common.MapStr{
+				"path":   "/acme",
+				"tenant": "acme",
+			},
+		},
+		{
+			description: "missing field is ignored",
+			config: map[string]interface{}{
+				"field":          "path",
+				"patterns":       []string{`^/(?P<tenant>\w+)`},
+				"ignore_missing": true,
+			},
+			Input: common.MapStr{
+				"other": "/acme",
+			},
+			Output: common.MapStr{
+				"other": "/acme",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			cfg, err := common.NewConfigFrom(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			p, err := newExtractRegexp(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			event, err := p.Run(&beat.Event{Fields: test.Input})
+			if test.error {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.Output, event.Fields)
+		})
+	}
+}
+
+func TestExtractRegexpInvalidConfig(t *testing.T) {
+	for _, patterns := range [][]string{
+		{`(?P<open`},
+		{`^/api/\w+`},
+		{},
+	} {
+		cfg, err := common.NewConfigFrom(map[string]interface{}{
+			"field":    "path",
+			"patterns": patterns,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = newExtractRegexp(cfg)
+		assert.Error(t, err, "%v", patterns)
+	}
+}
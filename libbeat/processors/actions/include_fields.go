@@ -83,7 +83,7 @@ func (f *includeFields) Run(event *beat.Event) (*beat.Event, error) {
 
 	event.Fields = filtered
 	if len(errs) > 0 {
-		return event, fmt.Errorf(strings.Join(errs, ", "))
+		return event, fmt.Errorf("%s", strings.Join(errs, ", "))
 	}
 	return event, nil
 }
@@ -0,0 +1,217 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package actions
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+type kv struct {
+	config  kvConfig
+	include map[string]bool
+	exclude map[string]bool
+}
+
+type kvConfig struct {
+	Field         string   `config:"field" validate:"required"`
+	FieldSplit    string   `config:"field_split"`
+	ValueSplit    string   `config:"value_split"`
+	TargetPrefix  string   `config:"target_prefix"`
+	TrimKey       string   `config:"trim_key"`
+	TrimValue     string   `config:"trim_value"`
+	IncludeKeys   []string `config:"include_keys"`
+	ExcludeKeys   []string `config:"exclude_keys"`
+	OverwriteKeys bool     `config:"overwrite_keys"`
+	IgnoreMissing bool     `config:"ignore_missing"`
+	TagOnFailure  []string `config:"tag_on_failure"`
+}
+
+func init() {
+	processors.RegisterPlugin("kv",
+		configChecked(newKV,
+			requireFields("field"),
+			allowedFields("field", "field_split", "value_split", "target_prefix",
+				"trim_key", "trim_value", "include_keys", "exclude_keys",
+				"overwrite_keys", "ignore_missing", "tag_on_failure", "when")))
+}
+
+func newKV(c *common.Config) (processors.Processor, error) {
+	config := kvConfig{
+		FieldSplit:   " ",
+		ValueSplit:   "=",
+		TagOnFailure: []string{"_kv_parse_failure"},
+	}
+	err := c.Unpack(&config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack the kv configuration: %s", err)
+	}
+	if config.FieldSplit == "" || config.ValueSplit == "" {
+		return nil, fmt.Errorf("kv field_split and value_split must not be empty")
+	}
+
+	return &kv{
+		config:  config,
+		include: stringSet(config.IncludeKeys),
+		exclude: stringSet(config.ExcludeKeys),
+	}, nil
+}
+
+func stringSet(values []string) map[string]bool {
+	if len(values) == 0 {
+		return nil
+	}
+
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[v] = true
+	}
+	return set
+}
+
+func (f *kv) Run(event *beat.Event) (*beat.Event, error) {
+	err := f.decode(event)
+	if err != nil {
+		common.AddTags(event.Fields, f.config.TagOnFailure)
+	}
+	return event, err
+}
+
+func (f *kv) decode(event *beat.Event) error {
+	v, err := event.GetValue(f.config.Field)
+	if err != nil {
+		if f.config.IgnoreMissing && errors.Cause(err) == common.ErrKeyNotFound {
+			return nil
+		}
+		return fmt.Errorf("could not fetch value for key: %s, Error: %s", f.config.Field, err)
+	}
+
+	text, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("field %s is not a string", f.config.Field)
+	}
+
+	pairs := f.split(text)
+
+	prefix := f.config.TargetPrefix
+	if prefix != "" {
+		prefix += "."
+	}
+
+	var errs []string
+	for _, p := range pairs {
+		key := prefix + p.key
+		if !f.config.OverwriteKeys {
+			if exists, _ := event.Fields.HasKey(key); exists {
+				errs = append(errs, fmt.Sprintf("target field %s already exists", key))
+				continue
+			}
+		}
+
+		if _, err := event.PutValue(key, p.value); err != nil {
+			errs = append(errs, fmt.Sprintf("could not put value: %s: %v, %+v", key, p.value, err))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+type kvPair struct {
+	key   string
+	value string
+}
+
+// split scans text for key/value pairs. Values can be enclosed in double or
+// single quotes to include the field separator. Tokens without a value
+// separator are skipped.
+func (f *kv) split(text string) []kvPair {
+	var (
+		pairs      []kvPair
+		fieldSplit = f.config.FieldSplit
+		valueSplit = f.config.ValueSplit
+	)
+
+	for len(text) > 0 {
+		if strings.HasPrefix(text, fieldSplit) {
+			text = text[len(fieldSplit):]
+			continue
+		}
+
+		end := strings.Index(text, fieldSplit)
+		sep := strings.Index(text, valueSplit)
+		if sep < 0 || (end >= 0 && end < sep) {
+			// token without value
+			if end < 0 {
+				break
+			}
+			text = text[end:]
+			continue
+		}
+
+		key := text[:sep]
+		text = text[sep+len(valueSplit):]
+
+		var value string
+		value, text = f.scanValue(text)
+
+		key = strings.Trim(key, f.config.TrimKey)
+		value = strings.Trim(value, f.config.TrimValue)
+		if key == "" || !f.keep(key) {
+			continue
+		}
+		pairs = append(pairs, kvPair{key, value})
+	}
+	return pairs
+}
+
+// scanValue returns the value at the start of text and the remaining text.
+func (f *kv) scanValue(text string) (string, string) {
+	if len(text) > 0 && (text[0] == '"' || text[0] == '\'') {
+		quote := text[0]
+		if end := strings.IndexByte(text[1:], quote); end >= 0 {
+			return text[1 : end+1], text[end+2:]
+		}
+	}
+
+	end := strings.Index(text, f.config.FieldSplit)
+	if end < 0 {
+		return text, ""
+	}
+	return text[:end], text[end:]
+}
+
+func (f *kv) keep(key string) bool {
+	if f.include != nil && !f.include[key] {
+		return false
+	}
+	return !f.exclude[key]
+}
+
+func (f *kv) String() string {
+	return fmt.Sprintf("kv=[field=%v, field_split=%q, value_split=%q, target_prefix=%v]",
+		f.config.Field, f.config.FieldSplit, f.config.ValueSplit, f.config.TargetPrefix)
+}
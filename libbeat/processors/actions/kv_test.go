@@ -0,0 +1,164 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package actions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestKV(t *testing.T) {
+	var tests = []struct {
+		description string
+		config      map[string]interface{}
+		Input       common.MapStr
+		Output      common.MapStr
+		error       bool
+	}{
+		{
+			description: "default separators with quoted values",
+			config: map[string]interface{}{
+				"field": "msg",
+			},
+			Input: common.MapStr{
+				"msg": `a=1 b=2  c="quoted val" d='single quoted' flag`,
+			},
+			Output: common.MapStr{
+				"msg": `a=1 b=2  c="quoted val" d='single quoted' flag`,
+				"a":   "1",
+				"b":   "2",
+				"c":   "quoted val",
+				"d":   "single quoted",
+			},
+		},
+		{
+			description: "query string with target prefix",
+			config: map[string]interface{}{
+				"field":         "http.request.query",
+				"field_split":   "&",
+				"value_split":   "=",
+				"target_prefix": "http.request.params",
+			},
+			Input: common.MapStr{
+				"http": common.MapStr{
+					"request": common.MapStr{"query": "q=beats&page=2&empty="},
+				},
+			},
+			Output: common.MapStr{
+				"http": common.MapStr{
+					"request": common.MapStr{
+						"query": "q=beats&page=2&empty=",
+						"params": common.MapStr{
+							"q":     "beats",
+							"page":  "2",
+							"empty": "",
+						},
+					},
+				},
+			},
+		},
+		{
+			description: "trim keys and values",
+			config: map[string]interface{}{
+				"field":       "msg",
+				"field_split": ",",
+				"value_split": ":",
+				"trim_key":    " ",
+				"trim_value":  " []",
+			},
+			Input: common.MapStr{
+				"msg": "user: [alice] , id: 7",
+			},
+			Output: common.MapStr{
+				"msg":  "user: [alice] , id: 7",
+				"user": "alice",
+				"id":   "7",
+			},
+		},
+		{
+			description: "include and exclude keys",
+			config: map[string]interface{}{
+				"field":         "msg",
+				"include_keys":  []string{"a", "b"},
+				"exclude_keys":  []string{"b"},
+				"target_prefix": "kv",
+			},
+			Input: common.MapStr{
+				"msg": "a=1 b=2 c=3",
+			},
+			Output: common.MapStr{
+				"msg": "a=1 b=2 c=3",
+				"kv":  common.MapStr{"a": "1"},
+			},
+		},
+		{
+			description: "existing keys are not overwritten",
+			config: map[string]interface{}{
+				"field": "msg",
+			},
+			Input: common.MapStr{
+				"msg": "msg=other a=1",
+			},
+			Output: common.MapStr{
+				"msg":  "msg=other a=1",
+				"a":    "1",
+				"tags": []string{"_kv_parse_failure"},
+			},
+			error: true,
+		},
+		{
+			description: "missing field",
+			config: map[string]interface{}{
+				"field":          "msg",
+				"ignore_missing": true,
+			},
+			Input: common.MapStr{
+				"other": "a=1",
+			},
+			Output: common.MapStr{
+				"other": "a=1",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			cfg, err := common.NewConfigFrom(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			p, err := newKV(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			event, err := p.Run(&beat.Event{Fields: test.Input})
+			if test.error {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.Output, event.Fields)
+		})
+	}
+}
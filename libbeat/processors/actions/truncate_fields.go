@@ -0,0 +1,284 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+type truncateFields struct {
+	config truncateFieldsConfig
+}
+
+type truncateFieldsConfig struct {
+	Fields        []string `config:"fields"`
+	MaxBytes      int      `config:"max_bytes"`
+	MaxEventBytes int      `config:"max_event_bytes"`
+	MaxDepth      int      `config:"max_depth"`
+	TagOnTruncate []string `config:"tag_on_truncate"`
+}
+
+func init() {
+	processors.RegisterPlugin("truncate_fields",
+		configChecked(newTruncateFields,
+			allowedFields("fields", "max_bytes", "max_event_bytes", "max_depth", "tag_on_truncate", "when")))
+}
+
+func newTruncateFields(c *common.Config) (processors.Processor, error) {
+	config := truncateFieldsConfig{
+		TagOnTruncate: []string{"truncated"},
+	}
+	err := c.Unpack(&config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack the truncate_fields configuration: %s", err)
+	}
+
+	if len(config.Fields) > 0 && config.MaxBytes <= 0 {
+		return nil, errors.New("truncate_fields: max_bytes must be greater than 0 when fields are set")
+	}
+	if config.MaxBytes < 0 || config.MaxEventBytes < 0 || config.MaxDepth < 0 {
+		return nil, errors.New("truncate_fields: limits must not be negative")
+	}
+	if len(config.Fields) == 0 && config.MaxEventBytes == 0 && config.MaxDepth == 0 {
+		return nil, errors.New("truncate_fields: one of fields, max_event_bytes or max_depth must be set")
+	}
+
+	return &truncateFields{config: config}, nil
+}
+
+func (f *truncateFields) Run(event *beat.Event) (*beat.Event, error) {
+	truncated := false
+
+	if f.config.MaxDepth > 0 {
+		if limitDepth(event.Fields, 1, f.config.MaxDepth) {
+			truncated = true
+		}
+	}
+
+	for _, field := range f.config.Fields {
+		value, err := event.Fields.GetValue(field)
+		if err != nil {
+			continue
+		}
+		if value, changed := truncateValue(value, f.config.MaxBytes); changed {
+			event.Fields.Put(field, value)
+			truncated = true
+		}
+	}
+
+	if truncated {
+		common.AddTags(event.Fields, f.config.TagOnTruncate)
+	}
+
+	if f.config.MaxEventBytes > 0 {
+		return event, f.limitSize(event, truncated)
+	}
+	return event, nil
+}
+
+// truncateValue truncates a string, or the strings of an array, to at most n
+// bytes. Arrays are copied, so arrays shared with other events are not
+// modified. Other values are left unchanged.
+func truncateValue(v interface{}, n int) (interface{}, bool) {
+	switch v := v.(type) {
+	case string:
+		if len(v) <= n {
+			return v, false
+		}
+		return truncateBytes(v, n), true
+	case []string:
+		var values []string
+		for i, s := range v {
+			if len(s) <= n {
+				continue
+			}
+			if values == nil {
+				values = append([]string(nil), v...)
+			}
+			values[i] = truncateBytes(s, n)
+		}
+		return values, values != nil
+	case []interface{}:
+		var values []interface{}
+		for i, elem := range v {
+			s, ok := elem.(string)
+			if !ok || len(s) <= n {
+				continue
+			}
+			if values == nil {
+				values = append([]interface{}(nil), v...)
+			}
+			values[i] = truncateBytes(s, n)
+		}
+		return values, values != nil
+	}
+	return v, false
+}
+
+// truncateBytes returns s cut to at most n bytes. Multi-byte characters are
+// never split, so the result is valid UTF-8 if s is.
+func truncateBytes(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// limitDepth removes objects found at maxDepth, so no field is nested deeper
+// than maxDepth levels. It reports whether any object was removed.
+func limitDepth(m map[string]interface{}, depth, maxDepth int) bool {
+	removed := false
+	for k, v := range m {
+		child, ok := toMap(v)
+		if !ok {
+			continue
+		}
+		if depth >= maxDepth {
+			delete(m, k)
+			removed = true
+			continue
+		}
+		if limitDepth(child, depth+1, maxDepth) {
+			removed = true
+		}
+	}
+	return removed
+}
+
+// limitSize truncates the longest strings of the event until its JSON
+// encoded size, including @timestamp and @metadata, is at most
+// max_event_bytes. Tags are added before the first string is truncated, so
+// they are included in the size. The event is encoded once, then the size is
+// updated with the difference of each truncated string.
+func (f *truncateFields) limitSize(event *beat.Event, tagged bool) error {
+	size, err := eventSize(event)
+	if err != nil {
+		return err
+	}
+	if size <= f.config.MaxEventBytes {
+		return nil
+	}
+
+	if !tagged {
+		common.AddTags(event.Fields, f.config.TagOnTruncate)
+		if size, err = eventSize(event); err != nil {
+			return err
+		}
+	}
+
+	for size > f.config.MaxEventBytes {
+		parent, key, s := longestString(event.Fields)
+		if s == "" {
+			return errors.Errorf("event size %d exceeds max_event_bytes %d", size, f.config.MaxEventBytes)
+		}
+		n := len(s) - (size - f.config.MaxEventBytes)
+		if n < 0 {
+			n = 0
+		}
+		truncated := truncateBytes(s, n)
+		parent[key] = truncated
+
+		// Removing a byte removes at least one byte of the encoded string, so
+		// the size decreases on every iteration.
+		before, err := jsonSize(s)
+		if err != nil {
+			return err
+		}
+		after, err := jsonSize(truncated)
+		if err != nil {
+			return err
+		}
+		size -= before - after
+	}
+	return nil
+}
+
+// eventSize returns the size of the event encoded as JSON document, with the
+// @timestamp and @metadata fields added by the outputs.
+func eventSize(event *beat.Event) (int, error) {
+	doc := make(common.MapStr, len(event.Fields)+2)
+	for k, v := range event.Fields {
+		doc[k] = v
+	}
+	doc["@timestamp"] = common.Time(event.Timestamp)
+	if len(event.Meta) > 0 {
+		doc["@metadata"] = event.Meta
+	}
+	return jsonSize(doc)
+}
+
+func jsonSize(v interface{}) (int, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to compute the event size")
+	}
+	return len(encoded), nil
+}
+
+// longestString returns the longest string value stored in m or in any of its
+// nested objects, together with the object and key holding it.
+func longestString(m map[string]interface{}) (map[string]interface{}, string, string) {
+	var (
+		parent  map[string]interface{}
+		key     string
+		longest string
+	)
+	for k, v := range m {
+		switch v := v.(type) {
+		case string:
+			if len(v) > len(longest) {
+				parent, key, longest = m, k, v
+			}
+		default:
+			child, ok := toMap(v)
+			if !ok {
+				continue
+			}
+			if p, k, s := longestString(child); len(s) > len(longest) {
+				parent, key, longest = p, k, s
+			}
+		}
+	}
+	return parent, key, longest
+}
+
+func toMap(v interface{}) (map[string]interface{}, bool) {
+	switch m := v.(type) {
+	case common.MapStr:
+		return m, true
+	case map[string]interface{}:
+		return m, true
+	}
+	return nil, false
+}
+
+func (f *truncateFields) String() string {
+	return fmt.Sprintf("truncate_fields=[fields=%v, max_bytes=%d, max_event_bytes=%d, max_depth=%d]",
+		f.config.Fields, f.config.MaxBytes, f.config.MaxEventBytes, f.config.MaxDepth)
+}
@@ -0,0 +1,207 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package actions
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestTruncateFields(t *testing.T) {
+	var tests = []struct {
+		description string
+		config      map[string]interface{}
+		Input       common.MapStr
+		Output      common.MapStr
+	}{
+		{
+			description: "truncate to max bytes without splitting characters",
+			config: map[string]interface{}{
+				"fields":    []string{"query", "http.response.body", "status", "missing"},
+				"max_bytes": 5,
+			},
+			Input: common.MapStr{
+				"query":  "SELECT 1",
+				"status": 200,
+				"http":   common.MapStr{"response": common.MapStr{"body": "abcdé"}},
+			},
+			Output: common.MapStr{
+				"query":  "SELEC",
+				"status": 200,
+				"http":   common.MapStr{"response": common.MapStr{"body": "abcd"}},
+				"tags":   []string{"truncated"},
+			},
+		},
+		{
+			description: "truncate strings in arrays",
+			config: map[string]interface{}{
+				"fields":    []string{"names", "values"},
+				"max_bytes": 3,
+			},
+			Input: common.MapStr{
+				"names":  []string{"alice", "bob"},
+				"values": []interface{}{"abcdef", 12345, "ab"},
+			},
+			Output: common.MapStr{
+				"names":  []string{"ali", "bob"},
+				"values": []interface{}{"abc", 12345, "ab"},
+				"tags":   []string{"truncated"},
+			},
+		},
+		{
+			description: "short values are not tagged",
+			config: map[string]interface{}{
+				"fields":    []string{"query"},
+				"max_bytes": 10,
+			},
+			Input: common.MapStr{
+				"query": "SELECT 1",
+			},
+			Output: common.MapStr{
+				"query": "SELECT 1",
+			},
+		},
+		{
+			description: "limit depth",
+			config: map[string]interface{}{
+				"max_depth":       2,
+				"tag_on_truncate": []string{"too_deep"},
+			},
+			Input: common.MapStr{
+				"a": common.MapStr{
+					"b": map[string]interface{}{"c": 1},
+					"d": 2,
+				},
+				"e": 3,
+			},
+			Output: common.MapStr{
+				"a":    common.MapStr{"d": 2},
+				"e":    3,
+				"tags": []string{"too_deep"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			cfg, err := common.NewConfigFrom(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			p, err := newTruncateFields(cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			event, err := p.Run(&beat.Event{Fields: test.Input})
+			assert.NoError(t, err)
+			assert.Equal(t, test.Output, event.Fields)
+		})
+	}
+}
+
+func TestTruncateFieldsMaxEventBytes(t *testing.T) {
+	cfg, err := common.NewConfigFrom(map[string]interface{}{
+		"max_event_bytes": 200,
+		"tag_on_truncate": []string{"truncated"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := newTruncateFields(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	event, err := p.Run(&beat.Event{
+		Timestamp: time.Now(),
+		Meta:      common.MapStr{"pipeline": "mysql"},
+		Fields: common.MapStr{
+			"status": "OK",
+			"mysql": common.MapStr{
+				"query": strings.Repeat("é", 200),
+			},
+			"body": strings.Repeat("<", 100),
+		},
+	})
+	assert.NoError(t, err)
+
+	doc := event.Fields.Clone()
+	doc["@timestamp"] = common.Time(event.Timestamp)
+	doc["@metadata"] = event.Meta
+	encoded, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, len(encoded) <= 200, "event size %d", len(encoded))
+	assert.Equal(t, "OK", event.Fields["status"])
+	assert.Equal(t, []string{"truncated"}, event.Fields["tags"])
+
+	query, _ := event.Fields.GetValue("mysql.query")
+	assert.True(t, utf8.ValidString(query.(string)))
+}
+
+func TestTruncateFieldsMaxEventBytesTooSmall(t *testing.T) {
+	cfg, err := common.NewConfigFrom(map[string]interface{}{
+		"max_event_bytes": 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := newTruncateFields(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = p.Run(&beat.Event{Fields: common.MapStr{
+		"status": 200,
+		"method": "GET",
+	}})
+	assert.Error(t, err)
+}
+
+func TestTruncateFieldsInvalidConfig(t *testing.T) {
+	configs := map[string]map[string]interface{}{
+		"no limits":         {},
+		"missing max_bytes": {"fields": []string{"query"}},
+		"negative depth":    {"max_depth": -1},
+	}
+
+	for name, config := range configs {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := common.NewConfigFrom(config)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = newTruncateFields(cfg)
+			assert.Error(t, err)
+		})
+	}
+}
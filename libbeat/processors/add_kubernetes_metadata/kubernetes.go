@@ -20,6 +20,7 @@ package add_kubernetes_metadata
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/elastic/beats/libbeat/beat"
@@ -68,8 +69,9 @@ func newKubernetesAnnotator(cfg *common.Config) (processors.Processor, error) {
 	//Load default indexer configs
 	if config.DefaultIndexers.Enabled == true {
 		Indexing.RLock()
-		for key, cfg := range Indexing.GetDefaultIndexerConfigs() {
-			config.Indexers = append(config.Indexers, map[string]common.Config{key: cfg})
+		defaults := Indexing.GetDefaultIndexerConfigs()
+		for _, key := range sortedConfigKeys(defaults) {
+			config.Indexers = append(config.Indexers, map[string]common.Config{key: defaults[key]})
 		}
 		Indexing.RUnlock()
 	}
@@ -77,8 +79,9 @@ func newKubernetesAnnotator(cfg *common.Config) (processors.Processor, error) {
 	//Load default matcher configs
 	if config.DefaultMatchers.Enabled == true {
 		Indexing.RLock()
-		for key, cfg := range Indexing.GetDefaultMatcherConfigs() {
-			config.Matchers = append(config.Matchers, map[string]common.Config{key: cfg})
+		defaults := Indexing.GetDefaultMatcherConfigs()
+		for _, key := range sortedConfigKeys(defaults) {
+			config.Matchers = append(config.Matchers, map[string]common.Config{key: defaults[key]})
 		}
 		Indexing.RUnlock()
 	}
@@ -103,7 +106,7 @@ func newKubernetesAnnotator(cfg *common.Config) (processors.Processor, error) {
 
 	config.Host = kubernetes.DiscoverKubernetesNode(config.Host, config.InCluster, client)
 
-	logp.Debug("kubernetes", "Using host ", config.Host)
+	logp.Debug("kubernetes", "Using host %s", config.Host)
 	logp.Debug("kubernetes", "Initializing watcher")
 
 	watcher, err := kubernetes.NewWatcher(client, &kubernetes.Pod{}, kubernetes.WatchOptions{
@@ -112,7 +115,7 @@ func newKubernetesAnnotator(cfg *common.Config) (processors.Processor, error) {
 		Namespace:   config.Namespace,
 	})
 	if err != nil {
-		logp.Err("kubernetes: Couldn't create watcher for %t", &kubernetes.Pod{})
+		logp.Err("kubernetes: Couldn't create watcher for %T", &kubernetes.Pod{})
 		return nil, err
 	}
 
@@ -144,12 +147,12 @@ func newKubernetesAnnotator(cfg *common.Config) (processors.Processor, error) {
 }
 
 func (k *kubernetesAnnotator) Run(event *beat.Event) (*beat.Event, error) {
-	index := k.matchers.MetadataIndex(event.Fields)
-	if index == "" {
-		return event, nil
+	var metadata common.MapStr
+	for _, index := range k.matchers.MetadataIndexes(event.Fields) {
+		if metadata = k.cache.get(index); metadata != nil {
+			break
+		}
 	}
-
-	metadata := k.cache.get(index)
 	if metadata == nil {
 		return event, nil
 	}
@@ -175,6 +178,12 @@ func (k *kubernetesAnnotator) removePod(pod *kubernetes.Pod) {
 	}
 }
 
+// Close stops the pod watcher.
+func (k *kubernetesAnnotator) Close() error {
+	k.watcher.Stop()
+	return nil
+}
+
 func (*kubernetesAnnotator) String() string {
 	return "add_kubernetes_metadata"
 }
@@ -185,3 +194,14 @@ func validate(config kubeAnnotatorConfig) error {
 	}
 	return nil
 }
+
+// sortedConfigKeys returns the names of the default plugin configs in a stable
+// order, so matchers are always tried in the same order.
+func sortedConfigKeys(configs map[string]common.Config) []string {
+	keys := make([]string, 0, len(configs))
+	for key := range configs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -86,3 +86,41 @@ func TestAnnotatorDeepUpdate(t *testing.T) {
 		},
 	}, event.Fields)
 }
+
+// Test the next index is tried when the first one is not in the cache
+func TestAnnotatorFallbackIndex(t *testing.T) {
+	cfg := common.MustNewConfigFrom(map[string]interface{}{
+		"lookup_fields": []string{"ip", "client_ip"},
+	})
+	matcher, err := NewFieldMatcher(*cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	processor := kubernetesAnnotator{
+		cache: newCache(10 * time.Second),
+		matchers: &Matchers{
+			matchers: []Matcher{matcher},
+		},
+	}
+
+	processor.cache.set("10.0.0.2", common.MapStr{
+		"pod": common.MapStr{"name": "client"},
+	})
+
+	event, err := processor.Run(&beat.Event{
+		Fields: common.MapStr{
+			"ip":        "10.0.0.1",
+			"client_ip": "10.0.0.2",
+		},
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, common.MapStr{
+		"ip":        "10.0.0.1",
+		"client_ip": "10.0.0.2",
+		"kubernetes": common.MapStr{
+			"pod": common.MapStr{"name": "client"},
+		},
+	}, event.Fields)
+}
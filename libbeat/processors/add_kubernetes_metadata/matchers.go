@@ -42,6 +42,17 @@ type Matcher interface {
 	MetadataIndex(event common.MapStr) string
 }
 
+// MultiIndexMatcher is a Matcher that can return several candidate indexes for
+// an event, in order of preference. This allows falling back to other fields
+// when the first index is not known, for example when the destination of a
+// connection is not a pod but its source is.
+type MultiIndexMatcher interface {
+	Matcher
+
+	// MetadataIndexes returns all the index strings to try for the given event
+	MetadataIndexes(event common.MapStr) []string
+}
+
 type Matchers struct {
 	sync.RWMutex
 	matchers []Matcher
@@ -89,6 +100,25 @@ func (m *Matchers) MetadataIndex(event common.MapStr) string {
 	return ""
 }
 
+// MetadataIndexes returns the index strings of all matchers, in order. Matchers
+// implementing MultiIndexMatcher can return more than one index.
+func (m *Matchers) MetadataIndexes(event common.MapStr) []string {
+	m.RLock()
+	defer m.RUnlock()
+	var indexes []string
+	for _, matcher := range m.matchers {
+		if multi, ok := matcher.(MultiIndexMatcher); ok {
+			indexes = append(indexes, multi.MetadataIndexes(event)...)
+			continue
+		}
+
+		if index := matcher.MetadataIndex(event); index != "" {
+			indexes = append(indexes, index)
+		}
+	}
+	return indexes
+}
+
 func (m *Matchers) Empty() bool {
 	m.RLock()
 	defer m.RUnlock()
@@ -134,6 +164,22 @@ func (f *FieldMatcher) MetadataIndex(event common.MapStr) string {
 	return ""
 }
 
+// MetadataIndexes returns the values of all lookup fields found in the event
+func (f *FieldMatcher) MetadataIndexes(event common.MapStr) []string {
+	var indexes []string
+	for _, field := range f.MatchFields {
+		keyIface, err := event.GetValue(field)
+		if err == nil {
+			key, ok := keyIface.(string)
+			if ok {
+				indexes = append(indexes, key)
+			}
+		}
+	}
+
+	return indexes
+}
+
 type FieldFormatMatcher struct {
 	Codec codec.Codec
 }
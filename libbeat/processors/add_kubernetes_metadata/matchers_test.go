@@ -102,3 +102,31 @@ func TestFieldFormatMatcher(t *testing.T) {
 	out = matcher.MetadataIndex(event)
 	assert.Equal(t, "foo/bar", out)
 }
+
+func TestMatchersMetadataIndexes(t *testing.T) {
+	formatCfg := common.MustNewConfigFrom(map[string]interface{}{
+		"format": "%{[ip]}:%{[port]}",
+	})
+	formatMatcher, err := NewFieldFormatMatcher(*formatCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fieldsCfg := common.MustNewConfigFrom(map[string]interface{}{
+		"lookup_fields": []string{"ip", "client_ip"},
+	})
+	fieldMatcher, err := NewFieldMatcher(*fieldsCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	matchers := &Matchers{matchers: []Matcher{formatMatcher, fieldMatcher}}
+
+	event := common.MapStr{
+		"ip":        "10.0.0.1",
+		"port":      80,
+		"client_ip": "10.0.0.2",
+	}
+	assert.Equal(t, []string{"10.0.0.1:80", "10.0.0.1", "10.0.0.2"}, matchers.MetadataIndexes(event))
+	assert.Equal(t, "10.0.0.1:80", matchers.MetadataIndex(event))
+}
@@ -0,0 +1,235 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package communityid implements a processor adding the Community ID flow
+// hash to events. The hash identifies a flow by its addresses, ports and
+// transport protocol, so events about the same flow can be correlated across
+// tools supporting it, like Zeek and Suricata.
+package communityid
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+func init() {
+	processors.RegisterPlugin("community_id", newCommunityID)
+}
+
+type processor struct {
+	Config
+}
+
+func newCommunityID(cfg *common.Config) (processors.Processor, error) {
+	c := defaultConfig
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, errors.Wrap(err, "fail to unpack the community_id configuration")
+	}
+	return &processor{Config: c}, nil
+}
+
+// Run adds the flow hash to the event. Events missing the fields of the flow
+// tuple are not modified.
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	f, ok, err := p.buildFlow(event)
+	if err != nil || !ok {
+		return event, err
+	}
+
+	id, err := communityID(p.Seed, f)
+	if err != nil {
+		return event, err
+	}
+
+	if _, err := event.PutValue(p.Target, id); err != nil {
+		return event, errors.Wrapf(err, "failed to set %v", p.Target)
+	}
+	return event, nil
+}
+
+// buildFlow reads the flow tuple from the event. The transport defaults to
+// ICMP if the ICMP type is set, and to TCP otherwise.
+func (p *processor) buildFlow(event *beat.Event) (flow, bool, error) {
+	var f flow
+
+	srcIP, ok, err := p.getIP(event, p.Fields.SourceIP)
+	if err != nil || !ok {
+		return f, false, err
+	}
+	dstIP, ok, err := p.getIP(event, p.Fields.DestinationIP)
+	if err != nil || !ok {
+		return f, false, err
+	}
+	f.srcIP, f.dstIP = srcIP, dstIP
+
+	icmpType, hasICMPType := p.getValue(event, p.Fields.ICMPType)
+	if transport, found := p.getValue(event, p.Fields.Transport); found {
+		if f.proto, err = parseTransport(transport); err != nil {
+			return f, false, err
+		}
+	} else if hasICMPType {
+		f.proto = protoICMP
+	} else {
+		f.proto = protoTCP
+	}
+	if f.proto == protoICMP && srcIP.To4() == nil {
+		f.proto = protoICMPv6
+	}
+
+	var srcPortField, dstPortField string
+	switch f.proto {
+	case protoTCP, protoUDP, protoSCTP:
+		srcPortField, dstPortField = p.Fields.SourcePort, p.Fields.DestinationPort
+	case protoICMP, protoICMPv6:
+		if !hasICMPType {
+			return f, false, nil
+		}
+		if f.srcPort, err = toUint16(icmpType); err != nil {
+			return f, false, errors.Wrap(err, "invalid ICMP type")
+		}
+		dstPortField = p.Fields.ICMPCode
+	default:
+		// Other protocols are hashed without ports.
+		return f, true, nil
+	}
+
+	if srcPortField != "" {
+		v, found := p.getValue(event, srcPortField)
+		if !found {
+			return f, false, nil
+		}
+		if f.srcPort, err = toUint16(v); err != nil {
+			return f, false, errors.Wrap(err, "invalid source port")
+		}
+	}
+	if v, found := p.getValue(event, dstPortField); found {
+		if f.dstPort, err = toUint16(v); err != nil {
+			return f, false, errors.Wrap(err, "invalid destination port")
+		}
+	} else if f.proto != protoICMP && f.proto != protoICMPv6 {
+		return f, false, nil
+	}
+	f.hasPorts = true
+	return f, true, nil
+}
+
+func (p *processor) getValue(event *beat.Event, field string) (interface{}, bool) {
+	if field == "" {
+		return nil, false
+	}
+	v, err := event.GetValue(field)
+	if err != nil || v == nil {
+		return nil, false
+	}
+	return v, true
+}
+
+func (p *processor) getIP(event *beat.Event, field string) (net.IP, bool, error) {
+	v, found := p.getValue(event, field)
+	if !found {
+		return nil, false, nil
+	}
+
+	switch ip := v.(type) {
+	case net.IP:
+		return ip, true, nil
+	case string:
+		if parsed := net.ParseIP(ip); parsed != nil {
+			return parsed, true, nil
+		}
+	}
+	return nil, false, errors.Errorf("invalid IP address '%v' in field %v", v, field)
+}
+
+// parseTransport parses a transport protocol name or IANA number.
+func parseTransport(v interface{}) (uint8, error) {
+	if s, ok := v.(string); ok {
+		switch strings.ToLower(s) {
+		case "icmp":
+			return protoICMP, nil
+		case "tcp":
+			return protoTCP, nil
+		case "udp":
+			return protoUDP, nil
+		case "icmpv6", "ipv6-icmp":
+			return protoICMPv6, nil
+		case "sctp":
+			return protoSCTP, nil
+		}
+	}
+
+	n, err := toUint16(v)
+	if err != nil || n > 255 {
+		return 0, errors.Errorf("unknown transport '%v'", v)
+	}
+	return uint8(n), nil
+}
+
+func toUint16(v interface{}) (uint16, error) {
+	var n int64
+	switch v := v.(type) {
+	case uint8:
+		n = int64(v)
+	case uint16:
+		n = int64(v)
+	case uint32:
+		n = int64(v)
+	case uint64:
+		n = int64(v)
+	case uint:
+		n = int64(v)
+	case int8:
+		n = int64(v)
+	case int16:
+		n = int64(v)
+	case int32:
+		n = int64(v)
+	case int64:
+		n = v
+	case int:
+		n = int64(v)
+	case float64:
+		n = int64(v)
+		if float64(n) != v {
+			return 0, errors.Errorf("'%v' is not an integer", v)
+		}
+	case string:
+		var err error
+		if n, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return 0, err
+		}
+	default:
+		return 0, errors.Errorf("unexpected type %T", v)
+	}
+
+	if n < 0 || n > 65535 {
+		return 0, errors.Errorf("'%v' is out of range", v)
+	}
+	return uint16(n), nil
+}
+
+func (p *processor) String() string {
+	return fmt.Sprintf("community_id=[target=%v, seed=%v, fields=%+v]", p.Target, p.Seed, p.Fields)
+}
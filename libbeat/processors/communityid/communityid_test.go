@@ -0,0 +1,183 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package communityid
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestCommunityID(t *testing.T) {
+	// Values from the Community ID specification baseline.
+	tests := []struct {
+		name string
+		seed uint16
+		flow flow
+		id   string
+	}{
+		{
+			name: "tcp",
+			flow: flow{srcIP: net.ParseIP("128.232.110.120"), dstIP: net.ParseIP("66.35.250.204"),
+				srcPort: 34855, dstPort: 80, proto: protoTCP, hasPorts: true},
+			id: "1:LQU9qZlK+B5F3KDmev6m5PMibrg=",
+		},
+		{
+			name: "tcp reversed",
+			flow: flow{srcIP: net.ParseIP("66.35.250.204"), dstIP: net.ParseIP("128.232.110.120"),
+				srcPort: 80, dstPort: 34855, proto: protoTCP, hasPorts: true},
+			id: "1:LQU9qZlK+B5F3KDmev6m5PMibrg=",
+		},
+		{
+			name: "tcp with seed",
+			seed: 1,
+			flow: flow{srcIP: net.ParseIP("128.232.110.120"), dstIP: net.ParseIP("66.35.250.204"),
+				srcPort: 34855, dstPort: 80, proto: protoTCP, hasPorts: true},
+			id: "1:3V71V58M3Ksw/yuFALMcW0LAHvc=",
+		},
+		{
+			name: "udp",
+			flow: flow{srcIP: net.ParseIP("192.168.1.52"), dstIP: net.ParseIP("8.8.8.8"),
+				srcPort: 54585, dstPort: 53, proto: protoUDP, hasPorts: true},
+			id: "1:d/FP5EW3wiY1vCndhwleRRKHowQ=",
+		},
+		{
+			name: "icmp echo request",
+			flow: flow{srcIP: net.ParseIP("192.168.0.89"), dstIP: net.ParseIP("192.168.0.1"),
+				srcPort: 8, dstPort: 0, proto: protoICMP, hasPorts: true},
+			id: "1:X0snYXpgwiv9TZtqg64sgzUn6Dk=",
+		},
+		{
+			name: "icmp echo reply",
+			flow: flow{srcIP: net.ParseIP("192.168.0.1"), dstIP: net.ParseIP("192.168.0.89"),
+				srcPort: 0, dstPort: 0, proto: protoICMP, hasPorts: true},
+			id: "1:X0snYXpgwiv9TZtqg64sgzUn6Dk=",
+		},
+	}
+
+	for _, test := range tests {
+		id, err := communityID(test.seed, test.flow)
+		if assert.NoError(t, err, test.name) {
+			assert.Equal(t, test.id, id, test.name)
+		}
+	}
+}
+
+func TestCommunityIDMixedFamilies(t *testing.T) {
+	_, err := communityID(0, flow{srcIP: net.ParseIP("10.0.0.1"), dstIP: net.ParseIP("::1"), proto: protoTCP})
+	assert.Error(t, err)
+}
+
+func newTestProcessor(t *testing.T, settings map[string]interface{}) *processor {
+	p, err := newCommunityID(common.MustNewConfigFrom(settings))
+	require.NoError(t, err)
+	return p.(*processor)
+}
+
+func TestRunTransaction(t *testing.T) {
+	p := newTestProcessor(t, map[string]interface{}{})
+
+	tests := []struct {
+		name   string
+		fields common.MapStr
+		id     interface{}
+	}{
+		{
+			name: "tcp by default",
+			fields: common.MapStr{
+				"client_ip": "128.232.110.120", "client_port": uint16(34855),
+				"ip": "66.35.250.204", "port": uint16(80),
+			},
+			id: "1:LQU9qZlK+B5F3KDmev6m5PMibrg=",
+		},
+		{
+			name: "udp",
+			fields: common.MapStr{
+				"client_ip": "192.168.1.52", "client_port": 54585,
+				"ip": "8.8.8.8", "port": 53, "transport": "udp",
+			},
+			id: "1:d/FP5EW3wiY1vCndhwleRRKHowQ=",
+		},
+		{
+			name: "icmp",
+			fields: common.MapStr{
+				"client_ip": "192.168.0.89", "ip": "192.168.0.1",
+				"icmp": common.MapStr{"request": common.MapStr{"type": uint8(8), "code": uint8(0)}},
+			},
+			id: "1:X0snYXpgwiv9TZtqg64sgzUn6Dk=",
+		},
+		{
+			name:   "missing port",
+			fields: common.MapStr{"client_ip": "192.168.1.52", "ip": "8.8.8.8", "port": 53},
+			id:     nil,
+		},
+		{
+			name:   "missing ip",
+			fields: common.MapStr{"client_ip": "192.168.1.52", "client_port": 54585, "port": 53},
+			id:     nil,
+		},
+	}
+
+	for _, test := range tests {
+		event, err := p.Run(&beat.Event{Fields: test.fields})
+		if !assert.NoError(t, err, test.name) {
+			continue
+		}
+		id, _ := event.GetValue("network.community_id")
+		assert.Equal(t, test.id, id, test.name)
+	}
+}
+
+func TestRunFlow(t *testing.T) {
+	p := newTestProcessor(t, map[string]interface{}{
+		"fields": map[string]interface{}{
+			"source_ip":        "source.ip",
+			"source_port":      "source.port",
+			"destination_ip":   "dest.ip",
+			"destination_port": "dest.port",
+		},
+		"target": "community_id",
+	})
+
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{
+		"source":    common.MapStr{"ip": "192.168.1.52", "port": uint16(54585)},
+		"dest":      common.MapStr{"ip": "8.8.8.8", "port": uint16(53)},
+		"transport": "udp",
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, "1:d/FP5EW3wiY1vCndhwleRRKHowQ=", event.Fields["community_id"])
+}
+
+func TestRunInvalid(t *testing.T) {
+	p := newTestProcessor(t, map[string]interface{}{})
+
+	for _, fields := range []common.MapStr{
+		{"client_ip": "not an ip", "client_port": 1, "ip": "8.8.8.8", "port": 53},
+		{"client_ip": "10.0.0.1", "client_port": 1, "ip": "8.8.8.8", "port": 70000},
+		{"client_ip": "10.0.0.1", "client_port": 1, "ip": "8.8.8.8", "port": 53, "transport": "quic"},
+	} {
+		event, err := p.Run(&beat.Event{Fields: fields.Clone()})
+		assert.Error(t, err)
+		assert.Equal(t, fields, event.Fields)
+	}
+}
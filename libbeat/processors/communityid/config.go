@@ -0,0 +1,50 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package communityid
+
+// Config defines the configuration options for the community_id processor.
+type Config struct {
+	Fields FieldsConfig `config:"fields"`
+	Target string       `config:"target" validate:"nonzero"` // Field the hash is written to.
+	Seed   uint16       `config:"seed"`                      // Seed shared by all sensors whose IDs are compared.
+}
+
+// FieldsConfig defines the event fields the flow tuple is read from.
+type FieldsConfig struct {
+	SourceIP        string `config:"source_ip" validate:"nonzero"`
+	SourcePort      string `config:"source_port"`
+	DestinationIP   string `config:"destination_ip" validate:"nonzero"`
+	DestinationPort string `config:"destination_port"`
+	Transport       string `config:"transport"`
+	ICMPType        string `config:"icmp_type"`
+	ICMPCode        string `config:"icmp_code"`
+}
+
+// The default fields are the fields of packetbeat transactions.
+var defaultConfig = Config{
+	Fields: FieldsConfig{
+		SourceIP:        "client_ip",
+		SourcePort:      "client_port",
+		DestinationIP:   "ip",
+		DestinationPort: "port",
+		Transport:       "transport",
+		ICMPType:        "icmp.request.type",
+		ICMPCode:        "icmp.request.code",
+	},
+	Target: "network.community_id",
+}
@@ -0,0 +1,125 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package communityid
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"net"
+
+	"github.com/pkg/errors"
+)
+
+// IANA protocol numbers.
+const (
+	protoICMP   uint8 = 1
+	protoTCP    uint8 = 6
+	protoUDP    uint8 = 17
+	protoICMPv6 uint8 = 58
+	protoSCTP   uint8 = 132
+)
+
+// Request and response ICMP types. Flows with these types are hashed like
+// bidirectional flows, using the type of the request and the response as
+// ports. Other ICMP messages are one-way, using their type and code as ports.
+var (
+	icmpEquivalents = map[uint16]uint16{
+		8: 0, 0: 8, // echo
+		13: 14, 14: 13, // timestamp
+		15: 16, 16: 15, // information
+		10: 9, 9: 10, // router solicitation and advertisement
+		17: 18, 18: 17, // address mask
+	}
+	icmpv6Equivalents = map[uint16]uint16{
+		128: 129, 129: 128, // echo
+		130: 131, 131: 130, // multicast listener query and report
+		133: 134, 134: 133, // router solicitation and advertisement
+		135: 136, 136: 135, // neighbor solicitation and advertisement
+		139: 140, 140: 139, // node information query and response
+		144: 145, 145: 144, // home agent address discovery
+	}
+)
+
+// flow is the tuple identifying a network flow. For ICMP flows the ports
+// are the ICMP type and code.
+type flow struct {
+	srcIP, dstIP     net.IP
+	srcPort, dstPort uint16
+	proto            uint8
+	hasPorts         bool
+}
+
+// communityID computes the version 1 Community ID flow hash, as specified by
+// https://github.com/corelight/community-id-spec. The hash is the base64
+// encoded SHA1 of the seed, the addresses, the protocol number, a padding byte
+// and, if set, the ports, all in network byte order. The endpoints are ordered
+// so both directions of a flow get the same hash, except for one-way ICMP
+// messages.
+func communityID(seed uint16, f flow) (string, error) {
+	srcIP, dstIP := f.srcIP.To4(), f.dstIP.To4()
+	if srcIP == nil || dstIP == nil {
+		srcIP, dstIP = f.srcIP.To16(), f.dstIP.To16()
+		if srcIP == nil || dstIP == nil || f.srcIP.To4() != nil || f.dstIP.To4() != nil {
+			return "", errors.New("source and destination IP must be valid addresses of the same family")
+		}
+	}
+
+	srcPort, dstPort := f.srcPort, f.dstPort
+	oneWay := false
+	if f.hasPorts && (f.proto == protoICMP || f.proto == protoICMPv6) {
+		equivalents := icmpEquivalents
+		if f.proto == protoICMPv6 {
+			equivalents = icmpv6Equivalents
+		}
+		if reply, found := equivalents[srcPort]; found {
+			dstPort = reply
+		} else {
+			oneWay = true
+		}
+	}
+
+	if !oneWay && !isOrdered(srcIP, dstIP, srcPort, dstPort) {
+		srcIP, dstIP = dstIP, srcIP
+		srcPort, dstPort = dstPort, srcPort
+	}
+
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.BigEndian, seed)
+	buf.Write(srcIP)
+	buf.Write(dstIP)
+	buf.Write([]byte{f.proto, 0})
+	if f.hasPorts {
+		binary.Write(&buf, binary.BigEndian, srcPort)
+		binary.Write(&buf, binary.BigEndian, dstPort)
+	}
+
+	sum := sha1.Sum(buf.Bytes())
+	return "1:" + base64.StdEncoding.EncodeToString(sum[:]), nil
+}
+
+func isOrdered(srcIP, dstIP net.IP, srcPort, dstPort uint16) bool {
+	switch bytes.Compare(srcIP, dstIP) {
+	case -1:
+		return true
+	case 0:
+		return srcPort < dstPort
+	}
+	return false
+}
@@ -172,3 +172,36 @@ func BenchmarkDissect(b *testing.B) {
 		}
 	})
 }
+
+// BenchmarkDissectVersusRegexp extracts the same keys from the same message
+// with dissect and with an equivalent regular expression.
+func BenchmarkDissectVersusRegexp(b *testing.B) {
+	msg := "10.0.0.1 - GET /index.html?q=search HTTP/1.1 200 512"
+
+	b.Run("dissect", func(b *testing.B) {
+		d, err := New("%{client} - %{method} %{path} %{version} %{status} %{bytes}")
+		if !assert.NoError(b, err) {
+			return
+		}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			results, _ = d.Dissect(msg)
+		}
+	})
+
+	b.Run("regexp", func(b *testing.B) {
+		re := regexp.MustCompile(`^(?P<client>\S+) - (?P<method>\S+) (?P<path>\S+) (?P<version>\S+) (?P<status>\S+) (?P<bytes>.*)$`)
+		names := re.SubexpNames()
+		b.ReportAllocs()
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			m := re.FindStringSubmatch(msg)
+			r := make(Map, len(names)-1)
+			for i := 1; i < len(m); i++ {
+				r[names[i]] = m[i]
+			}
+			results = r
+		}
+	})
+}
@@ -194,7 +194,10 @@ func (c PTRLookupCache) LookupPTR(ip string) (*PTR, error) {
 
 	ptr, err = c.resolver.LookupPTR(ip)
 	if err != nil {
-		c.failure.set(now, ip, &cachedError{err})
+		// Rejected lookups were never sent, so there is no result to cache.
+		if err != ErrTooManyLookups {
+			c.failure.set(now, ip, &cachedError{err})
+		}
 		return nil, err
 	}
 
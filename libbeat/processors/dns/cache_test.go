@@ -99,3 +99,23 @@ func TestCache(t *testing.T) {
 		assert.EqualValues(t, 3, c.stats.Miss.Get()) // Cache miss.
 	}
 }
+
+type rejectingResolver struct{}
+
+func (r *rejectingResolver) LookupPTR(ip string) (*PTR, error) {
+	return nil, ErrTooManyLookups
+}
+
+func TestCacheIgnoresRejectedLookups(t *testing.T) {
+	c, err := NewPTRLookupCache(
+		monitoring.NewRegistry(),
+		defaultConfig.CacheConfig,
+		&rejectingResolver{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = c.LookupPTR(gatewayIP)
+	assert.Equal(t, ErrTooManyLookups, err)
+	assert.Empty(t, c.failure.data)
+}
@@ -30,13 +30,14 @@ import (
 // Config defines the configuration options for the DNS processor.
 type Config struct {
 	CacheConfig
-	Nameservers  []string      `config:"nameservers"`              // Required on Windows. /etc/resolv.conf is used if none are given.
-	Timeout      time.Duration `conifg:"timeout"`                  // Per request timeout (with 2 nameservers the total timeout would be 2x).
-	Type         string        `config:"type" validate:"required"` // Reverse is the only supported type currently.
-	Action       FieldAction   `config:"action"`                   // Append or replace (defaults to append) when target exists.
-	TagOnFailure []string      `config:"tag_on_failure"`           // Tags to append when a failure occurs.
-	Fields       common.MapStr `config:"fields"`                   // Mapping of source fields to target fields.
-	reverseFlat  map[string]string
+	Nameservers          []string      `config:"nameservers"`                             // Required on Windows. /etc/resolv.conf is used if none are given.
+	Timeout              time.Duration `config:"timeout"`                                 // Per request timeout (with 2 nameservers the total timeout would be 2x).
+	MaxConcurrentLookups int           `config:"max_concurrent_lookups" validate:"min=0"` // Lookups in flight at once, 0 means unlimited.
+	Type                 string        `config:"type" validate:"required"`                // Reverse is the only supported type currently.
+	Action               FieldAction   `config:"action"`                                  // Append or replace (defaults to append) when target exists.
+	TagOnFailure         []string      `config:"tag_on_failure"`                          // Tags to append when a failure occurs.
+	Fields               common.MapStr `config:"fields"`                                  // Mapping of source fields to target fields.
+	reverseFlat          map[string]string
 }
 
 // FieldAction defines the behavior when the target field exists.
@@ -62,10 +62,14 @@ func newDNSProcessor(cfg *common.Config) (processors.Processor, error) {
 	)
 
 	log.Debugf("DNS processor config: %+v", c)
+	var resolver PTRResolver
 	resolver, err := NewMiekgResolver(metrics, c.Timeout, c.Nameservers...)
 	if err != nil {
 		return nil, err
 	}
+	if c.MaxConcurrentLookups > 0 {
+		resolver = NewLimitedResolver(metrics, c.MaxConcurrentLookups, resolver)
+	}
 
 	cache, err := NewPTRLookupCache(metrics.NewRegistry("cache"), c.CacheConfig, resolver)
 	if err != nil {
@@ -131,6 +135,6 @@ func setFieldValue(action FieldAction, event *beat.Event, key string, value stri
 }
 
 func (p processor) String() string {
-	return fmt.Sprintf("dns=[timeout=%v, nameservers=[%v], action=%v, type=%v, fields=[%+v]",
-		p.Timeout, strings.Join(p.Nameservers, ","), p.Action, p.Type, p.reverseFlat)
+	return fmt.Sprintf("dns=[timeout=%v, nameservers=[%v], max_concurrent_lookups=%v, action=%v, type=%v, fields=[%+v]",
+		p.Timeout, strings.Join(p.Nameservers, ","), p.MaxConcurrentLookups, p.Action, p.Type, p.reverseFlat)
 }
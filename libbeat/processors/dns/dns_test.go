@@ -21,6 +21,7 @@ import (
 	"strconv"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -153,7 +154,8 @@ func TestDNSProcessorRunInParallel(t *testing.T) {
 					},
 				})
 				if err != nil {
-					t.Fatal(err)
+					t.Error(err)
+					return
 				}
 			}
 		}()
@@ -161,3 +163,28 @@ func TestDNSProcessorRunInParallel(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestDNSProcessorConfig(t *testing.T) {
+	cfg, err := common.NewConfigFrom(map[string]interface{}{
+		"type":                   "reverse",
+		"fields":                 map[string]string{"source.ip": "source.hostname"},
+		"nameservers":            []string{"192.0.2.1"},
+		"timeout":                "2s",
+		"max_concurrent_lookups": 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := newDNSProcessor(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dnsProcessor := p.(*processor)
+	assert.Equal(t, 2*time.Second, dnsProcessor.Timeout)
+	assert.Equal(t, 10, dnsProcessor.MaxConcurrentLookups)
+
+	cache := dnsProcessor.resolver.(*PTRLookupCache)
+	assert.IsType(t, &LimitedResolver{}, cache.resolver)
+}
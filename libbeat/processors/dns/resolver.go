@@ -207,3 +207,40 @@ func (res *MiekgResolver) getOrCreateNameserverStats(ns string) *nameserverStats
 
 	return stats
 }
+
+// ErrTooManyLookups is returned by a LimitedResolver when the maximum number of
+// concurrent lookups is reached.
+var ErrTooManyLookups = errors.New("too many concurrent dns lookups")
+
+// LimitedResolver is a PTRResolver that bounds the number of lookups in flight.
+// Lookups exceeding the limit fail immediately with ErrTooManyLookups instead
+// of waiting, so that a slow nameserver cannot stall the pipeline.
+type LimitedResolver struct {
+	resolver PTRResolver
+	slots    chan struct{}
+	rejected *monitoring.Int
+}
+
+// NewLimitedResolver returns a new LimitedResolver allowing up to limit
+// concurrent lookups against resolver.
+func NewLimitedResolver(reg *monitoring.Registry, limit int, resolver PTRResolver) *LimitedResolver {
+	return &LimitedResolver{
+		resolver: resolver,
+		slots:    make(chan struct{}, limit),
+		rejected: monitoring.NewInt(reg, "rejected"),
+	}
+}
+
+// LookupPTR performs a reverse lookup on the given IP address if the limit of
+// concurrent lookups is not reached.
+func (res *LimitedResolver) LookupPTR(ip string) (*PTR, error) {
+	select {
+	case res.slots <- struct{}{}:
+	default:
+		res.rejected.Inc()
+		return nil, ErrTooManyLookups
+	}
+	defer func() { <-res.slots }()
+
+	return res.resolver.LookupPTR(ip)
+}
@@ -98,3 +98,44 @@ func FakeDNSHandler(w dns.ResponseWriter, msg *dns.Msg) {
 	}
 	w.WriteMsg(m)
 }
+
+type blockingResolver struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (r *blockingResolver) LookupPTR(ip string) (*PTR, error) {
+	r.started <- struct{}{}
+	<-r.release
+	return &PTR{Host: "blocked.example.com", TTL: 60}, nil
+}
+
+func TestLimitedResolverLookupPTR(t *testing.T) {
+	blocking := &blockingResolver{
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	res := NewLimitedResolver(monitoring.NewRegistry(), 1, blocking)
+
+	done := make(chan error)
+	go func() {
+		_, err := res.LookupPTR("192.0.2.1")
+		done <- err
+	}()
+	<-blocking.started
+
+	// The only slot is in use, so the lookup fails without blocking.
+	_, err := res.LookupPTR("192.0.2.2")
+	assert.Equal(t, ErrTooManyLookups, err)
+	assert.EqualValues(t, 1, res.rejected.Get())
+
+	close(blocking.release)
+	assert.NoError(t, <-done)
+
+	// The slot has been released.
+	go func() { <-blocking.started }()
+	ptr, err := res.LookupPTR("192.0.2.2")
+	if assert.NoError(t, err) {
+		assert.Equal(t, "blocked.example.com", ptr.Host)
+	}
+}
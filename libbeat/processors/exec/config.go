@@ -0,0 +1,37 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package exec
+
+import (
+	"time"
+)
+
+// Config defines the configuration options for the exec processor.
+type Config struct {
+	Command      string        `config:"command" validate:"required"` // Program to run.
+	Args         []string      `config:"args"`                        // Arguments passed to the program.
+	Timeout      time.Duration `config:"timeout" validate:"positive,nonzero"`
+	RestartDelay time.Duration `config:"restart_delay" validate:"min=0"` // Wait between restarts of the program.
+	MaxLineSize  int           `config:"max_line_size" validate:"positive,nonzero"`
+}
+
+var defaultConfig = Config{
+	Timeout:      5 * time.Second,
+	RestartDelay: time.Second,
+	MaxLineSize:  10 * 1024 * 1024,
+}
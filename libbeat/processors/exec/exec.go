@@ -0,0 +1,188 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package exec implements a processor that sends events to an external
+// program and replaces them with the program's response.
+//
+// Events are written to the program's stdin as JSON documents, one per line,
+// including @timestamp and @metadata. For each event the program must write
+// one line to its stdout: a JSON object replacing the event, or null to drop
+// the event. The program must write exactly one line per event: any output
+// written while no event is pending means the responses no longer match the
+// events, so the program is stopped. The program is restarted if it crashes,
+// is out of step or doesn't respond within the timeout.
+//
+// Events are sent one at a time and the pipeline waits for each response.
+// Sending batches of events to the program is not supported, because
+// processors are run on single events.
+package exec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/atomic"
+	"github.com/elastic/beats/libbeat/common/jsontransform"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/monitoring"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+const logName = "processor.exec"
+
+// instanceID is used to assign each instance a unique monitoring namespace.
+var instanceID = atomic.MakeUint32(0)
+
+func init() {
+	processors.RegisterPlugin("exec", newExec)
+}
+
+type processor struct {
+	Config
+	log *logp.Logger
+
+	mu        sync.Mutex // Serializes the communication with the program.
+	proc      *process
+	nextStart time.Time // The program is not restarted before nextStart.
+	closed    bool
+
+	starts   *monitoring.Int
+	failures *monitoring.Int
+}
+
+func newExec(cfg *common.Config) (processors.Processor, error) {
+	c := defaultConfig
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, errors.Wrap(err, "fail to unpack the exec configuration")
+	}
+
+	var (
+		id      = int(instanceID.Inc())
+		log     = logp.NewLogger(logName).With("instance_id", id)
+		metrics = monitoring.Default.NewRegistry(logName+"."+strconv.Itoa(id), monitoring.DoNotReport)
+	)
+
+	return &processor{
+		Config:   c,
+		log:      log,
+		starts:   monitoring.NewInt(metrics, "starts"),
+		failures: monitoring.NewInt(metrics, "failures"),
+	}, nil
+}
+
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	line, err := encodeEvent(event)
+	if err != nil {
+		return event, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return event, errors.New("exec processor is closed")
+	}
+
+	if p.proc != nil && p.proc.exited() {
+		// The program crashed or was out of step since the last event.
+		p.failures.Inc()
+		p.log.Errorf("Restarting %v: program exited", p.Command)
+		p.proc.stop()
+		p.proc = nil
+	}
+
+	if p.proc == nil {
+		if time.Now().Before(p.nextStart) {
+			return event, errors.Errorf("%v is restarting", p.Command)
+		}
+		proc, err := startProcess(p.Config, p.log)
+		if err != nil {
+			p.nextStart = time.Now().Add(p.RestartDelay)
+			return event, err
+		}
+		p.starts.Inc()
+		p.proc = proc
+	}
+
+	response, err := p.proc.roundTrip(line, p.Timeout)
+	if err != nil {
+		// The program state is unknown, so it's restarted.
+		p.failures.Inc()
+		p.log.Errorf("Stopping %v: %v", p.Command, err)
+		p.proc.stop()
+		p.proc = nil
+		p.nextStart = time.Now().Add(p.RestartDelay)
+		return event, err
+	}
+
+	return decodeEvent(event, response)
+}
+
+// Close stops the program.
+func (p *processor) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.closed = true
+	if p.proc != nil {
+		p.proc.stop()
+		p.proc = nil
+	}
+	return nil
+}
+
+func (p *processor) String() string {
+	return fmt.Sprintf("exec=[command=%v, args=%v, timeout=%v]", p.Command, p.Args, p.Timeout)
+}
+
+func encodeEvent(event *beat.Event) ([]byte, error) {
+	doc := make(common.MapStr, len(event.Fields)+2)
+	for k, v := range event.Fields {
+		doc[k] = v
+	}
+	doc["@timestamp"] = common.Time(event.Timestamp)
+	if len(event.Meta) > 0 {
+		doc["@metadata"] = event.Meta
+	}
+
+	line, err := json.Marshal(doc)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode event")
+	}
+	return line, nil
+}
+
+// decodeEvent replaces the event with the program response.
+func decodeEvent(event *beat.Event, response []byte) (*beat.Event, error) {
+	dec := json.NewDecoder(bytes.NewReader(response))
+	ok, err := jsontransform.DecodeEvent(dec, event)
+	if err != nil {
+		return event, errors.Wrap(err, "invalid program response")
+	}
+	if !ok {
+		return nil, nil
+	}
+	return event, nil
+}
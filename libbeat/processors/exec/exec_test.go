@@ -0,0 +1,176 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package exec
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+// TestHelperProcess isn't a real test. It's used as the external program by
+// the other tests.
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
+		return
+	}
+	defer os.Exit(0)
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		var doc map[string]interface{}
+		if err := json.Unmarshal(scanner.Bytes(), &doc); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		switch doc["action"] {
+		case "drop":
+			fmt.Println("null")
+			continue
+		case "sleep":
+			time.Sleep(time.Minute)
+		case "crash":
+			os.Exit(2)
+		case "twice":
+			fmt.Println("{}")
+		}
+
+		doc["processed"] = true
+		doc["count"] = 3
+		out, _ := json.Marshal(doc)
+		fmt.Println(string(out))
+	}
+}
+
+func newTestExec(t *testing.T, timeout time.Duration) *processor {
+	os.Setenv("GO_WANT_HELPER_PROCESS", "1")
+	c := common.MustNewConfigFrom(map[string]interface{}{
+		"command":       os.Args[0],
+		"args":          []string{"-test.run=TestHelperProcess", "--"},
+		"timeout":       timeout,
+		"restart_delay": 0,
+	})
+	p, err := newExec(c)
+	require.NoError(t, err)
+	return p.(*processor)
+}
+
+func TestExec(t *testing.T) {
+	p := newTestExec(t, 5*time.Second)
+	defer p.Close()
+
+	ts := time.Date(2018, 7, 1, 10, 0, 0, 0, time.UTC)
+	event, err := p.Run(&beat.Event{
+		Timestamp: ts,
+		Meta:      common.MapStr{"pipeline": "x"},
+		Fields:    common.MapStr{"message": "hello", "action": "add"},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, event)
+
+	assert.Equal(t, ts, event.Timestamp)
+	assert.Equal(t, common.MapStr{"pipeline": "x"}, event.Meta)
+	assert.Equal(t, common.MapStr{
+		"message":   "hello",
+		"action":    "add",
+		"processed": true,
+		"count":     int64(3),
+	}, event.Fields)
+}
+
+func TestExecDrop(t *testing.T) {
+	p := newTestExec(t, 5*time.Second)
+	defer p.Close()
+
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{"action": "drop"}})
+	assert.NoError(t, err)
+	assert.Nil(t, event)
+}
+
+func TestExecTimeout(t *testing.T) {
+	p := newTestExec(t, 100*time.Millisecond)
+	defer p.Close()
+
+	fields := common.MapStr{"action": "sleep"}
+	event, err := p.Run(&beat.Event{Fields: fields})
+	assert.Equal(t, errTimeout, err)
+	require.NotNil(t, event)
+	assert.Equal(t, common.MapStr{"action": "sleep"}, event.Fields)
+	assert.Nil(t, p.proc)
+	assert.EqualValues(t, 1, p.failures.Get())
+
+	// The program is restarted for the next event.
+	event, err = p.Run(&beat.Event{Fields: common.MapStr{"action": "add"}})
+	require.NoError(t, err)
+	assert.Equal(t, true, event.Fields["processed"])
+	assert.EqualValues(t, 2, p.starts.Get())
+}
+
+func TestExecRestart(t *testing.T) {
+	p := newTestExec(t, 5*time.Second)
+	defer p.Close()
+
+	_, err := p.Run(&beat.Event{Fields: common.MapStr{"action": "crash"}})
+	assert.Equal(t, errExited, err)
+
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{"action": "add"}})
+	require.NoError(t, err)
+	assert.Equal(t, true, event.Fields["processed"])
+	assert.EqualValues(t, 2, p.starts.Get())
+}
+
+func TestExecClosed(t *testing.T) {
+	p := newTestExec(t, 5*time.Second)
+	assert.NoError(t, p.Close())
+
+	_, err := p.Run(&beat.Event{Fields: common.MapStr{"action": "add"}})
+	assert.Error(t, err)
+}
+
+func TestExecOutOfStep(t *testing.T) {
+	p := newTestExec(t, 5*time.Second)
+	defer p.Close()
+
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{"action": "twice"}})
+	require.NoError(t, err)
+	assert.Equal(t, common.MapStr{}, event.Fields)
+
+	// The second line is read while no event is pending, so the program is
+	// killed instead of answering the next event with it.
+	select {
+	case <-p.proc.done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("program not stopped after writing an extra line")
+	}
+
+	event, err = p.Run(&beat.Event{Fields: common.MapStr{"action": "add"}})
+	require.NoError(t, err)
+	assert.Equal(t, common.MapStr{"action": "add", "processed": true, "count": int64(3)}, event.Fields)
+	assert.EqualValues(t, 2, p.starts.Get())
+	assert.EqualValues(t, 1, p.failures.Get())
+}
@@ -0,0 +1,183 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package exec
+
+import (
+	"bufio"
+	"io"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+var (
+	errExited  = errors.New("program exited")
+	errTimeout = errors.New("timeout waiting for program response")
+)
+
+// process is a running instance of the external program. Events are written
+// to its stdin and the responses read from its stdout, one JSON document per
+// line. Only one request is pending at a time, so a line read while no
+// request is pending means the program is out of step with its input. The
+// program is killed in that case.
+type process struct {
+	cmd   *exec.Cmd
+	stdin io.WriteCloser
+	done  chan struct{} // Closed when the program stops writing to stdout.
+
+	mu      sync.Mutex
+	pending chan []byte // Receives the response to the pending request, nil if there is none.
+}
+
+func startProcess(config Config, log *logp.Logger) (*process, error) {
+	cmd := exec.Command(config.Command, config.Args...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, errors.Wrapf(err, "failed to start %v", config.Command)
+	}
+	log.Infof("Started %v (pid=%v)", config.Command, cmd.Process.Pid)
+
+	p := &process{
+		cmd:   cmd,
+		stdin: stdin,
+		done:  make(chan struct{}),
+	}
+
+	go func() {
+		defer close(p.done)
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 64*1024), config.MaxLineSize)
+		for scanner.Scan() {
+			line := append([]byte(nil), scanner.Bytes()...)
+			if !p.respond(line) {
+				log.Errorf("Stopping %v: output without a pending event", config.Command)
+				cmd.Process.Kill()
+				return
+			}
+		}
+		if err := scanner.Err(); err != nil {
+			log.Errorf("Failed to read from %v: %v", config.Command, err)
+		}
+	}()
+
+	go func() {
+		scanner := bufio.NewScanner(stderr)
+		for scanner.Scan() {
+			log.Warnf("%v: %s", config.Command, scanner.Bytes())
+		}
+	}()
+
+	return p, nil
+}
+
+// respond passes a line read from stdout to the pending request. It returns
+// false if there is no pending request.
+func (p *process) respond(line []byte) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.pending == nil {
+		return false
+	}
+	p.pending <- line
+	p.pending = nil
+	return true
+}
+
+// exited reports whether the program has stopped writing to stdout, because
+// it exited or because it was killed for being out of step.
+func (p *process) exited() bool {
+	select {
+	case <-p.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// roundTrip sends a line to the program and waits for the response line.
+func (p *process) roundTrip(line []byte, timeout time.Duration) ([]byte, error) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	response := make(chan []byte, 1)
+	p.mu.Lock()
+	p.pending = response
+	p.mu.Unlock()
+	defer func() {
+		p.mu.Lock()
+		p.pending = nil
+		p.mu.Unlock()
+	}()
+
+	// Writes block when the program doesn't read its input, so they are
+	// bounded by the timeout too. Killing the program unblocks them.
+	written := make(chan error, 1)
+	go func() {
+		_, err := p.stdin.Write(append(line, '\n'))
+		written <- err
+	}()
+
+	select {
+	case err := <-written:
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to write to program")
+		}
+	case <-timer.C:
+		return nil, errTimeout
+	}
+
+	select {
+	case line := <-response:
+		return line, nil
+	case <-p.done:
+		// The response may have been read just before stdout was closed.
+		select {
+		case line := <-response:
+			return line, nil
+		default:
+			return nil, errExited
+		}
+	case <-timer.C:
+		return nil, errTimeout
+	}
+}
+
+// stop kills the program and waits for it to exit.
+func (p *process) stop() {
+	p.stdin.Close()
+	p.cmd.Process.Kill()
+	<-p.done
+	p.cmd.Wait()
+}
@@ -0,0 +1,94 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fingerprint
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"strings"
+
+	"github.com/OneOfOne/xxhash"
+	"github.com/pkg/errors"
+)
+
+// Config defines the configuration options for the fingerprint processor.
+type Config struct {
+	Fields        []string     `config:"fields" validate:"required"` // Fields included in the fingerprint.
+	TargetField   string       `config:"target_field"`               // Field the fingerprint is written to.
+	Method        hashMethod   `config:"method"`                     // Hash function.
+	Encoding      encodingType `config:"encoding"`                   // Encoding of the hash value.
+	IgnoreMissing bool         `config:"ignore_missing"`             // Skip missing fields instead of failing.
+}
+
+type hashMethod struct {
+	name string
+	new  func() hash.Hash
+}
+
+var hashMethods = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+	"sha384": sha512.New384,
+	"sha512": sha512.New,
+	"xxhash": func() hash.Hash { return xxhash.New64() },
+}
+
+// Unpack unpacks a string to a hashMethod.
+func (m *hashMethod) Unpack(v string) error {
+	name := strings.ToLower(v)
+	h, found := hashMethods[name]
+	if !found {
+		return errors.Errorf("invalid fingerprint method '%v' (valid values are: md5, sha1, sha256, sha384, sha512, xxhash)", v)
+	}
+	*m = hashMethod{name, h}
+	return nil
+}
+
+type encodingType struct {
+	name   string
+	encode func([]byte) string
+}
+
+var encodings = map[string]func([]byte) string{
+	"hex":       hex.EncodeToString,
+	"base64":    base64.StdEncoding.EncodeToString,
+	"base64url": base64.RawURLEncoding.EncodeToString,
+}
+
+// Unpack unpacks a string to an encodingType.
+func (e *encodingType) Unpack(v string) error {
+	name := strings.ToLower(v)
+	enc, found := encodings[name]
+	if !found {
+		return errors.Errorf("invalid fingerprint encoding '%v' (valid values are: hex, base64, base64url)", v)
+	}
+	*e = encodingType{name, enc}
+	return nil
+}
+
+var defaultConfig = Config{
+	TargetField: "fingerprint",
+	Method:      hashMethod{"sha256", sha256.New},
+	Encoding:    encodingType{"hex", hex.EncodeToString},
+}
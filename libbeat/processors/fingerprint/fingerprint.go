@@ -0,0 +1,115 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package fingerprint implements a processor that computes a stable hash over
+// a set of event fields.
+package fingerprint
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+const metadataPrefix = "@metadata."
+
+func init() {
+	processors.RegisterPlugin("fingerprint", newFingerprint)
+}
+
+type fingerprint struct {
+	Config
+	fields []string
+}
+
+func newFingerprint(cfg *common.Config) (processors.Processor, error) {
+	c := defaultConfig
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, errors.Wrap(err, "fail to unpack the fingerprint configuration")
+	}
+
+	// Sort the fields, so the result does not depend on the configured order.
+	fields := append([]string(nil), c.Fields...)
+	sort.Strings(fields)
+
+	return &fingerprint{Config: c, fields: fields}, nil
+}
+
+func (p *fingerprint) Run(event *beat.Event) (*beat.Event, error) {
+	h := p.Method.new()
+	for _, field := range p.fields {
+		v, err := event.GetValue(field)
+		if err != nil {
+			if p.IgnoreMissing && errors.Cause(err) == common.ErrKeyNotFound {
+				continue
+			}
+			return event, errors.Wrapf(err, "failed to compute fingerprint for field %v", field)
+		}
+
+		if err := writeField(h, field, v); err != nil {
+			return event, err
+		}
+	}
+
+	value := p.Encoding.encode(h.Sum(nil))
+	if strings.HasPrefix(p.TargetField, metadataPrefix) {
+		if event.Meta == nil {
+			event.Meta = common.MapStr{}
+		}
+		_, err := event.Meta.Put(p.TargetField[len(metadataPrefix):], value)
+		return event, err
+	}
+
+	_, err := event.PutValue(p.TargetField, value)
+	return event, err
+}
+
+// writeField writes the field name and value, delimited by '|', so that values
+// moving between fields change the hash.
+func writeField(w io.Writer, field string, v interface{}) error {
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = v
+	case time.Time:
+		s = v.UTC().Format(time.RFC3339Nano)
+	case common.Time:
+		s = time.Time(v).UTC().Format(time.RFC3339Nano)
+	case common.MapStr:
+		s = v.String()
+	case map[string]interface{}:
+		s = common.MapStr(v).String()
+	default:
+		s = fmt.Sprint(v)
+	}
+
+	_, err := fmt.Fprintf(w, "|%v|%v", field, s)
+	return err
+}
+
+func (p *fingerprint) String() string {
+	return fmt.Sprintf("fingerprint=[method=%v, encoding=%v, fields=%v, target_field=%v]",
+		p.Method.name, p.Encoding.name, strings.Join(p.fields, ","), p.TargetField)
+}
@@ -0,0 +1,149 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package fingerprint
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func newTestFingerprint(t *testing.T, settings map[string]interface{}) *fingerprint {
+	cfg, err := common.NewConfigFrom(settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := newFingerprint(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p.(*fingerprint)
+}
+
+func testEvent() *beat.Event {
+	return &beat.Event{
+		Fields: common.MapStr{
+			"client_ip": "10.0.0.1",
+			"method":    "GET",
+			"http":      common.MapStr{"code": 200},
+		},
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	p := newTestFingerprint(t, map[string]interface{}{
+		"fields": []string{"method", "client_ip"},
+	})
+
+	event, err := p.Run(testEvent())
+	if assert.NoError(t, err) {
+		v, _ := event.GetValue("fingerprint")
+		assert.Equal(t, "b32308c1c1235875e2f7514eeb5eabde4aa43fc56c911a398095d030ca67d42a", v)
+	}
+}
+
+func TestFingerprintMethodAndEncoding(t *testing.T) {
+	p := newTestFingerprint(t, map[string]interface{}{
+		"fields":   []string{"client_ip", "method"},
+		"method":   "md5",
+		"encoding": "base64",
+	})
+
+	event, err := p.Run(testEvent())
+	if assert.NoError(t, err) {
+		v, _ := event.GetValue("fingerprint")
+		assert.Equal(t, "O5rt9WWW+Jx8p68ep1Hf4A==", v)
+	}
+}
+
+func TestFingerprintIsStable(t *testing.T) {
+	p := newTestFingerprint(t, map[string]interface{}{
+		"fields": []string{"client_ip", "http"},
+		"method": "xxhash",
+	})
+
+	first, err := p.Run(testEvent())
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := p.Run(testEvent())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, first.Fields["fingerprint"], second.Fields["fingerprint"])
+	assert.Len(t, first.Fields["fingerprint"], 16)
+
+	changed := testEvent()
+	changed.Fields.Put("http.code", 404)
+	changed, err = p.Run(changed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotEqual(t, first.Fields["fingerprint"], changed.Fields["fingerprint"])
+}
+
+func TestFingerprintMissingField(t *testing.T) {
+	p := newTestFingerprint(t, map[string]interface{}{
+		"fields": []string{"client_ip", "missing"},
+	})
+	event, err := p.Run(testEvent())
+	assert.Error(t, err)
+	assert.NotContains(t, event.Fields, "fingerprint")
+
+	p = newTestFingerprint(t, map[string]interface{}{
+		"fields":         []string{"client_ip", "method", "missing"},
+		"ignore_missing": true,
+	})
+	event, err = p.Run(testEvent())
+	if assert.NoError(t, err) {
+		assert.Equal(t, "b32308c1c1235875e2f7514eeb5eabde4aa43fc56c911a398095d030ca67d42a", event.Fields["fingerprint"])
+	}
+}
+
+func TestFingerprintMetadataTarget(t *testing.T) {
+	p := newTestFingerprint(t, map[string]interface{}{
+		"fields":       []string{"client_ip", "method"},
+		"target_field": "@metadata.id",
+	})
+
+	event, err := p.Run(testEvent())
+	if assert.NoError(t, err) {
+		assert.Equal(t, "b32308c1c1235875e2f7514eeb5eabde4aa43fc56c911a398095d030ca67d42a", event.Meta["id"])
+		assert.NotContains(t, event.Fields, "@metadata")
+	}
+}
+
+func TestFingerprintInvalidConfig(t *testing.T) {
+	for _, settings := range []map[string]interface{}{
+		{"fields": []string{"a"}, "method": "crc32"},
+		{"fields": []string{"a"}, "encoding": "base32"},
+		{"method": "sha1"},
+	} {
+		cfg, err := common.NewConfigFrom(settings)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = newFingerprint(cfg)
+		assert.Error(t, err, "%v", settings)
+	}
+}
@@ -0,0 +1,49 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package geoip
+
+import (
+	"errors"
+	"time"
+)
+
+// Config defines the configuration options for the geoip processor.
+type Config struct {
+	Database     string        `config:"database"`                    // Path to the GeoIP2 or GeoLite2 City database.
+	ASNDatabase  string        `config:"asn_database"`                // Path to the GeoIP2 or GeoLite2 ASN database.
+	Field        string        `config:"field"`                       // Field containing the IP address to look up.
+	Target       string        `config:"target"`                      // Prefix of the fields written to the event.
+	CacheSize    int           `config:"cache_size" validate:"min=0"` // Maximum number of cached lookups per database, 0 disables caching.
+	ReloadPeriod time.Duration `config:"reload_period"`               // How often the databases are checked for changes, 0 disables reloading.
+	TagOnFailure []string      `config:"tag_on_failure"`              // Tags to append when a lookup fails.
+}
+
+var defaultConfig = Config{
+	Field:        "client_ip",
+	Target:       "geo",
+	CacheSize:    10000,
+	ReloadPeriod: time.Minute,
+}
+
+// Validate checks that at least one database is configured.
+func (c *Config) Validate() error {
+	if c.Database == "" && c.ASNDatabase == "" {
+		return errors.New("database or asn_database must be set")
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package geoip
+
+import (
+	"net"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/elastic/beats/libbeat/common/geoip"
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+// lookupFunc reads the record of an IP address, for example the city or the
+// autonomous system.
+type lookupFunc func(r *geoip.Reader, ip net.IP) (interface{}, error)
+
+func lookupCity(r *geoip.Reader, ip net.IP) (interface{}, error) {
+	return r.City(ip)
+}
+
+func lookupASN(r *geoip.Reader, ip net.IP) (interface{}, error) {
+	return r.ASN(ip)
+}
+
+// database looks up IP addresses in a MaxMind database file. Every
+// reloadPeriod, a background goroutine checks the file for changes and
+// reopens it if it was replaced, so updated databases are used without
+// restarting the Beat. Lookups never wait for a reload: the new reader and
+// an empty cache are swapped in atomically once the file has been read.
+type database struct {
+	path         string
+	reloadPeriod time.Duration
+	lookup       lookupFunc
+	log          *logp.Logger
+	cacheSize    int
+
+	state atomic.Value // *databaseState
+
+	// Only accessed by reloadIfChanged.
+	modTime time.Time
+	size    int64
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// databaseState is a reader and the cache of its lookups.
+type databaseState struct {
+	reader *geoip.Reader
+
+	mutex sync.Mutex
+	cache *lruCache // nil if caching is disabled
+}
+
+func openDatabase(
+	path string,
+	reloadPeriod time.Duration,
+	cacheSize int,
+	lookup lookupFunc,
+	log *logp.Logger,
+) (*database, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := geoip.Open(path)
+	if err != nil {
+		return nil, err
+	}
+
+	db := &database{
+		path:         path,
+		reloadPeriod: reloadPeriod,
+		lookup:       lookup,
+		log:          log,
+		cacheSize:    cacheSize,
+		modTime:      info.ModTime(),
+		size:         info.Size(),
+		done:         make(chan struct{}),
+	}
+	db.state.Store(db.newState(reader))
+	log.Debugf("GeoIP database %v metadata: %+v", path, reader.Metadata())
+
+	if reloadPeriod > 0 {
+		db.wg.Add(1)
+		go db.reloadLoop()
+	}
+	return db, nil
+}
+
+func (db *database) newState(reader *geoip.Reader) *databaseState {
+	state := &databaseState{reader: reader}
+	if db.cacheSize > 0 {
+		state.cache = newLRUCache(db.cacheSize)
+	}
+	return state
+}
+
+// get returns the record of ip, which is a nil pointer if ip is not in the
+// database.
+func (db *database) get(ip net.IP) (interface{}, error) {
+	key := string(ip.To16())
+	state := db.state.Load().(*databaseState)
+
+	if state.cache != nil {
+		state.mutex.Lock()
+		v, found := state.cache.get(key)
+		state.mutex.Unlock()
+		if found {
+			return v, nil
+		}
+	}
+
+	v, err := db.lookup(state.reader, ip)
+	if err != nil {
+		return nil, err
+	}
+
+	if state.cache != nil {
+		state.mutex.Lock()
+		state.cache.add(key, v)
+		state.mutex.Unlock()
+	}
+	return v, nil
+}
+
+// close stops checking the database file for changes.
+func (db *database) close() {
+	close(db.done)
+	db.wg.Wait()
+}
+
+func (db *database) reloadLoop() {
+	defer db.wg.Done()
+
+	ticker := time.NewTicker(db.reloadPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-db.done:
+			return
+		case <-ticker.C:
+			db.reloadIfChanged()
+		}
+	}
+}
+
+// reloadIfChanged reopens the database if the file changed since it was
+// opened. If the new file can't be read, the current database is kept.
+func (db *database) reloadIfChanged() {
+	info, err := os.Stat(db.path)
+	if err != nil {
+		db.log.Warnf("Failed to check GeoIP database %v for changes: %v", db.path, err)
+		return
+	}
+	if info.ModTime().Equal(db.modTime) && info.Size() == db.size {
+		return
+	}
+
+	reader, err := geoip.Open(db.path)
+	if err != nil {
+		db.log.Warnf("Failed to reload GeoIP database, keeping the current one: %v", err)
+		return
+	}
+
+	db.state.Store(db.newState(reader))
+	db.modTime = info.ModTime()
+	db.size = info.Size()
+	db.log.Infof("GeoIP database %v reloaded", db.path)
+}
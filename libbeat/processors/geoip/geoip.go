@@ -0,0 +1,181 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package geoip implements a processor that enriches events with the
+// geographic location and the autonomous system of an IP address, using
+// MaxMind GeoIP2 or GeoLite2 City and ASN databases.
+package geoip
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/geoip"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+const logName = "processor.geoip"
+
+func init() {
+	processors.RegisterPlugin("geoip", newGeoIPProcessor)
+}
+
+type processor struct {
+	Config
+	city *database // nil if no City database is configured
+	asn  *database // nil if no ASN database is configured
+	log  *logp.Logger
+}
+
+func newGeoIPProcessor(cfg *common.Config) (processors.Processor, error) {
+	c := defaultConfig
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, errors.Wrap(err, "fail to unpack the geoip configuration")
+	}
+
+	log := logp.NewLogger(logName)
+	log.Debugf("geoip processor config: %+v", c)
+
+	p := &processor{Config: c, log: log}
+	var err error
+	if c.Database != "" {
+		p.city, err = openDatabase(c.Database, c.ReloadPeriod, c.CacheSize, lookupCity, log)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to open the GeoIP City database")
+		}
+	}
+	if c.ASNDatabase != "" {
+		p.asn, err = openDatabase(c.ASNDatabase, c.ReloadPeriod, c.CacheSize, lookupASN, log)
+		if err != nil {
+			p.Close()
+			return nil, errors.Wrap(err, "failed to open the GeoIP ASN database")
+		}
+	}
+	return p, nil
+}
+
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	v, err := event.GetValue(p.Field)
+	if err != nil {
+		return event, nil
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		return event, nil
+	}
+
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return event, nil
+	}
+
+	fields := common.MapStr{}
+	for _, db := range []*database{p.city, p.asn} {
+		if db == nil {
+			continue
+		}
+
+		record, err := db.get(ip)
+		if err != nil {
+			p.log.Debugf("GeoIP lookup failed: %v", err)
+			common.AddTags(event.Fields, p.TagOnFailure)
+			return event, fmt.Errorf("geoip lookup of %v value '%v' failed: %v", p.Field, s, err)
+		}
+
+		switch record := record.(type) {
+		case *geoip.City:
+			addCityFields(fields, record)
+		case *geoip.ASN:
+			addASNFields(fields, record)
+		}
+	}
+
+	if err := p.putFields(event, fields); err != nil {
+		return event, errors.Wrapf(err, "failed to add geoip fields to %v", p.Target)
+	}
+	return event, nil
+}
+
+func addCityFields(fields common.MapStr, city *geoip.City) {
+	if city == nil {
+		return
+	}
+
+	put := func(key, value string) {
+		if value != "" {
+			fields[key] = value
+		}
+	}
+
+	put("continent_name", city.ContinentName)
+	put("country_iso_code", city.CountryISOCode)
+	put("region_name", city.RegionName)
+	put("city_name", city.CityName)
+	if city.Location != nil {
+		fields["location"] = common.MapStr{
+			"lat": city.Location.Lat,
+			"lon": city.Location.Lon,
+		}
+	}
+}
+
+func addASNFields(fields common.MapStr, asn *geoip.ASN) {
+	if asn == nil {
+		return
+	}
+
+	if asn.Number != 0 {
+		fields["asn"] = asn.Number
+	}
+	if asn.Organization != "" {
+		fields["organization_name"] = asn.Organization
+	}
+}
+
+func (p *processor) putFields(event *beat.Event, fields common.MapStr) error {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	if p.Target == "" {
+		event.Fields.DeepUpdate(fields)
+		return nil
+	}
+	_, err := event.PutValue(p.Target, fields)
+	return err
+}
+
+// Close stops the reloading of the databases.
+func (p *processor) Close() error {
+	for _, db := range []*database{p.city, p.asn} {
+		if db != nil {
+			db.close()
+		}
+	}
+	return nil
+}
+
+func (p *processor) String() string {
+	return fmt.Sprintf("geoip=[database=%v, asn_database=%v, field=%v, target=%v, cache_size=%v]",
+		p.Database, p.ASNDatabase, p.Field, p.Target, p.CacheSize)
+}
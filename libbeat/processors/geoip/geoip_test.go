@@ -0,0 +1,286 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package geoip
+
+import (
+	"io/ioutil"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/geoip"
+	"github.com/elastic/beats/libbeat/common/geoip/geoiptest"
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+var testDatabase = geoiptest.Database{
+	Networks: []geoiptest.Network{
+		{
+			CIDR: "81.2.69.0/24",
+			Record: map[string]interface{}{
+				"continent": map[string]interface{}{
+					"names": map[string]interface{}{"en": "Europe"},
+				},
+				"country": map[string]interface{}{
+					"iso_code": "GB",
+				},
+				"subdivisions": []interface{}{
+					map[string]interface{}{
+						"names": map[string]interface{}{"en": "England"},
+					},
+				},
+				"city": map[string]interface{}{
+					"names": map[string]interface{}{"en": "London"},
+				},
+				"location": map[string]interface{}{
+					"latitude":  51.5142,
+					"longitude": -0.0931,
+				},
+			},
+		},
+	},
+}
+
+func newTestProcessor(t *testing.T, settings map[string]interface{}) (*processor, func()) {
+	path, cleanup := testDatabase.WriteFile(t)
+	settings["database"] = path
+
+	cfg, err := common.NewConfigFrom(settings)
+	if err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+
+	p, err := newGeoIPProcessor(cfg)
+	if err != nil {
+		cleanup()
+		t.Fatal(err)
+	}
+	return p.(*processor), func() {
+		p.(*processor).Close()
+		cleanup()
+	}
+}
+
+func TestGeoIP(t *testing.T) {
+	p, cleanup := newTestProcessor(t, map[string]interface{}{})
+	defer cleanup()
+
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{"client_ip": "81.2.69.142"}})
+	if assert.NoError(t, err) {
+		assert.Equal(t, common.MapStr{
+			"client_ip": "81.2.69.142",
+			"geo": common.MapStr{
+				"continent_name":   "Europe",
+				"country_iso_code": "GB",
+				"region_name":      "England",
+				"city_name":        "London",
+				"location": common.MapStr{
+					"lat": 51.5142,
+					"lon": -0.0931,
+				},
+			},
+		}, event.Fields)
+	}
+}
+
+func TestGeoIPFieldAndTarget(t *testing.T) {
+	p, cleanup := newTestProcessor(t, map[string]interface{}{
+		"field":  "dst.ip",
+		"target": "dst.geo",
+	})
+	defer cleanup()
+
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{"dst": common.MapStr{"ip": "81.2.69.1"}}})
+	if assert.NoError(t, err) {
+		v, err := event.GetValue("dst.geo.country_iso_code")
+		assert.NoError(t, err)
+		assert.Equal(t, "GB", v)
+	}
+}
+
+func TestGeoIPUnknownAddress(t *testing.T) {
+	p, cleanup := newTestProcessor(t, map[string]interface{}{})
+	defer cleanup()
+
+	for _, fields := range []common.MapStr{
+		{"client_ip": "10.1.1.1"},
+		{"client_ip": "not an ip"},
+		{"client_ip": 17},
+		{"other": "81.2.69.1"},
+	} {
+		expected := fields.Clone()
+		event, err := p.Run(&beat.Event{Fields: fields})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, event.Fields)
+	}
+}
+
+func TestGeoIPMissingDatabase(t *testing.T) {
+	cfg, err := common.NewConfigFrom(map[string]interface{}{"database": "/does/not/exist.mmdb"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = newGeoIPProcessor(cfg)
+	assert.Error(t, err)
+}
+
+func TestGeoIPNoDatabase(t *testing.T) {
+	_, err := newGeoIPProcessor(common.NewConfig())
+	assert.Error(t, err)
+}
+
+var testASNDatabase = geoiptest.Database{
+	DatabaseType: "GeoLite2-ASN",
+	Networks: []geoiptest.Network{
+		{
+			CIDR: "81.2.69.0/24",
+			Record: map[string]interface{}{
+				"autonomous_system_number":       uint32(20712),
+				"autonomous_system_organization": "Andrews & Arnold Ltd",
+			},
+		},
+	},
+}
+
+func TestGeoIPASN(t *testing.T) {
+	path, cleanup := testASNDatabase.WriteFile(t)
+	defer cleanup()
+
+	p, cleanupCity := newTestProcessor(t, map[string]interface{}{"asn_database": path})
+	defer cleanupCity()
+
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{"client_ip": "81.2.69.142"}})
+	if assert.NoError(t, err) {
+		geo, err := event.GetValue("geo")
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(20712), geo.(common.MapStr)["asn"])
+		assert.Equal(t, "Andrews & Arnold Ltd", geo.(common.MapStr)["organization_name"])
+		assert.Equal(t, "London", geo.(common.MapStr)["city_name"])
+	}
+}
+
+var manchesterDatabase = geoiptest.Database{
+	Networks: []geoiptest.Network{
+		{
+			CIDR: "81.2.69.0/24",
+			Record: map[string]interface{}{
+				"city": map[string]interface{}{
+					"names": map[string]interface{}{"en": "Manchester"},
+				},
+			},
+		},
+	},
+}
+
+func TestDatabaseReload(t *testing.T) {
+	path, cleanup := testDatabase.WriteFile(t)
+	defer cleanup()
+
+	db, err := openDatabase(path, 0, 10, lookupCity, logp.NewLogger(logName))
+	require.NoError(t, err)
+	defer db.close()
+
+	ip := net.ParseIP("81.2.69.142")
+	record, err := db.get(ip)
+	require.NoError(t, err)
+	assert.Equal(t, "London", record.(*geoip.City).CityName)
+
+	// A database that can't be read keeps the current one.
+	require.NoError(t, ioutil.WriteFile(path, []byte("invalid"), 0644))
+	db.reloadIfChanged()
+	record, err = db.get(ip)
+	require.NoError(t, err)
+	assert.Equal(t, "London", record.(*geoip.City).CityName)
+
+	buf, err := manchesterDatabase.Build()
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path, buf, 0644))
+
+	// The cached London record is dropped with the old database.
+	db.reloadIfChanged()
+	record, err = db.get(ip)
+	require.NoError(t, err)
+	assert.Equal(t, "Manchester", record.(*geoip.City).CityName)
+}
+
+func TestDatabaseReloadInBackground(t *testing.T) {
+	path, cleanup := testDatabase.WriteFile(t)
+	defer cleanup()
+
+	db, err := openDatabase(path, 10*time.Millisecond, 10, lookupCity, logp.NewLogger(logName))
+	require.NoError(t, err)
+
+	buf, err := manchesterDatabase.Build()
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path, buf, 0644))
+
+	ip := net.ParseIP("81.2.69.142")
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		record, err := db.get(ip)
+		require.NoError(t, err)
+		if record.(*geoip.City).CityName == "Manchester" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("database not reloaded")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// After close, changes are no longer picked up.
+	db.close()
+	buf, err = testDatabase.Build()
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(path, buf, 0644))
+	time.Sleep(50 * time.Millisecond)
+	record, err := db.get(ip)
+	require.NoError(t, err)
+	assert.Equal(t, "Manchester", record.(*geoip.City).CityName)
+}
+
+func TestLRUCache(t *testing.T) {
+	cache := newLRUCache(2)
+	cache.add("a", 1)
+	cache.add("b", 2)
+
+	// a becomes the most recently used entry, so b is evicted.
+	v, found := cache.get("a")
+	assert.True(t, found)
+	assert.Equal(t, 1, v)
+	cache.add("c", 3)
+
+	_, found = cache.get("b")
+	assert.False(t, found)
+	for key, expected := range map[string]int{"a": 1, "c": 3} {
+		v, found := cache.get(key)
+		assert.True(t, found)
+		assert.Equal(t, expected, v)
+	}
+
+	cache.clear()
+	_, found = cache.get("a")
+	assert.False(t, found)
+}
@@ -0,0 +1,103 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package processors
+
+import (
+	"sync"
+	"time"
+
+	"github.com/rcrowley/go-metrics"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/monitoring"
+	"github.com/elastic/beats/libbeat/monitoring/adapter"
+)
+
+// processorMetrics holds the metrics of a processor type. They are shared by
+// all the instances of the processor.
+type processorMetrics struct {
+	in      *monitoring.Int // Events received.
+	out     *monitoring.Int // Events returned.
+	dropped *monitoring.Int // Events dropped.
+	errors  *monitoring.Int // Errors returned.
+	latency metrics.Sample  // Time spent processing an event, in nanoseconds.
+}
+
+var (
+	metricsRegistry = monitoring.Default.NewRegistry("processors", monitoring.DoNotReport)
+
+	metricsMutex sync.Mutex
+	allMetrics   = map[string]*processorMetrics{}
+)
+
+// getMetrics returns the metrics of the processor type with the given name,
+// creating them on first use.
+func getMetrics(name string) *processorMetrics {
+	metricsMutex.Lock()
+	defer metricsMutex.Unlock()
+
+	if m, found := allMetrics[name]; found {
+		return m
+	}
+
+	reg := metricsRegistry.NewRegistry(name)
+	m := &processorMetrics{
+		in:      monitoring.NewInt(reg, "events.in"),
+		out:     monitoring.NewInt(reg, "events.out"),
+		dropped: monitoring.NewInt(reg, "events.dropped"),
+		errors:  monitoring.NewInt(reg, "errors"),
+		latency: metrics.NewUniformSample(1028),
+	}
+	adapter.NewGoMetrics(reg, "latency", adapter.Accept).
+		Register("ns", metrics.NewHistogram(m.latency))
+	allMetrics[name] = m
+	return m
+}
+
+// instrumentedProcessor updates the metrics of its processor type on each
+// event.
+type instrumentedProcessor struct {
+	Processor
+	metrics *processorMetrics
+}
+
+func newInstrumentedProcessor(name string, p Processor) *instrumentedProcessor {
+	return &instrumentedProcessor{Processor: p, metrics: getMetrics(name)}
+}
+
+func (p *instrumentedProcessor) Run(event *beat.Event) (*beat.Event, error) {
+	p.metrics.in.Inc()
+	start := time.Now()
+	event, err := p.Processor.Run(event)
+	p.metrics.latency.Update(int64(time.Since(start)))
+
+	if err != nil {
+		p.metrics.errors.Inc()
+	}
+	if event == nil {
+		p.metrics.dropped.Inc()
+	} else {
+		p.metrics.out.Inc()
+	}
+	return event, err
+}
+
+// Close closes the wrapped processor if it implements Closer.
+func (p *instrumentedProcessor) Close() error {
+	return Close(p.Processor)
+}
@@ -0,0 +1,63 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package processors
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/monitoring"
+)
+
+type testProcessor struct{}
+
+func (testProcessor) Run(event *beat.Event) (*beat.Event, error) {
+	switch event.Fields["action"] {
+	case "drop":
+		return nil, nil
+	case "fail":
+		return event, errors.New("failed")
+	}
+	return event, nil
+}
+
+func (testProcessor) String() string { return "test" }
+
+func TestInstrumentedProcessor(t *testing.T) {
+	p := newInstrumentedProcessor("test_metrics", testProcessor{})
+	assert.Equal(t, "test", p.String())
+
+	for _, action := range []string{"pass", "pass", "drop", "fail"} {
+		p.Run(&beat.Event{Fields: common.MapStr{"action": action}})
+	}
+
+	// Instances of the same processor type share their metrics
+	other := newInstrumentedProcessor("test_metrics", testProcessor{})
+	other.Run(&beat.Event{Fields: common.MapStr{"action": "pass"}})
+
+	snapshot := monitoring.CollectFlatSnapshot(metricsRegistry.GetRegistry("test_metrics"), monitoring.Full, false)
+	assert.Equal(t, int64(5), snapshot.Ints["events.in"])
+	assert.Equal(t, int64(4), snapshot.Ints["events.out"])
+	assert.Equal(t, int64(1), snapshot.Ints["events.dropped"])
+	assert.Equal(t, int64(1), snapshot.Ints["errors"])
+	assert.Equal(t, int64(5), snapshot.Ints["latency.ns.count"])
+}
@@ -59,7 +59,7 @@ func New(config PluginConfig) (*Processors, error) {
 				return nil, err
 			}
 
-			procs.add(plugin)
+			procs.add(newInstrumentedProcessor(processorName, plugin))
 		}
 	}
 
@@ -0,0 +1,74 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ratelimit
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Config defines the configuration options for the rate_limit processor.
+type Config struct {
+	EventsPerSecond    float64 `config:"events_per_second"`     // Global rate limit. 0 disables the global limit.
+	Burst              int     `config:"burst"`                 // Global bucket size. Defaults to events_per_second.
+	KeyField           string  `config:"key_field"`             // Event field used to group events for the per-key limit.
+	KeyEventsPerSecond float64 `config:"key_events_per_second"` // Per-key rate limit. 0 disables the per-key limit.
+	KeyBurst           int     `config:"key_burst"`             // Per-key bucket size. Defaults to key_events_per_second.
+	MaxKeys            int     `config:"max_keys"`              // Maximum number of keys tracked.
+	Action             string  `config:"action"`                // drop or tag.
+	Tag                string  `config:"tag"`                   // Tag added to limited events when action is tag.
+}
+
+const (
+	actionDrop = "drop"
+	actionTag  = "tag"
+)
+
+// Validate validates the data contained in the config.
+func (c *Config) Validate() error {
+	if c.EventsPerSecond < 0 || c.KeyEventsPerSecond < 0 {
+		return errors.New("events_per_second and key_events_per_second must be >= 0")
+	}
+	if c.EventsPerSecond == 0 && c.KeyEventsPerSecond == 0 {
+		return errors.New("one of events_per_second or key_events_per_second must be set")
+	}
+	if c.KeyEventsPerSecond > 0 && c.KeyField == "" {
+		return errors.New("key_field is required when key_events_per_second is set")
+	}
+	if c.Burst < 0 || c.KeyBurst < 0 {
+		return errors.New("burst and key_burst must be >= 0")
+	}
+	if c.MaxKeys <= 0 {
+		return errors.New("max_keys must be > 0")
+	}
+	switch c.Action {
+	case actionDrop:
+	case actionTag:
+		if c.Tag == "" {
+			return errors.New("tag must not be empty")
+		}
+	default:
+		return errors.Errorf("unknown action '%v', must be drop or tag", c.Action)
+	}
+	return nil
+}
+
+var defaultConfig = Config{
+	MaxKeys: 10000,
+	Action:  actionDrop,
+	Tag:     "rate_limited",
+}
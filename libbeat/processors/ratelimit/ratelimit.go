@@ -0,0 +1,197 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package ratelimit implements a processor limiting the rate of published
+// events using token buckets.
+//
+// A global bucket limits the rate of all events passing the processor, and
+// a bucket per value of key_field limits the rate of each key. Events
+// exceeding the limits are dropped or tagged.
+package ratelimit
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/atomic"
+	"github.com/elastic/beats/libbeat/common/ratelimit"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/monitoring"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+const logName = "processor.rate_limit"
+
+// overflowKey groups all events whose key did not fit into the key table.
+const overflowKey = "\x00overflow"
+
+// instanceID is used to assign each instance a unique monitoring namespace.
+var instanceID = atomic.MakeUint32(0)
+
+func init() {
+	processors.RegisterPlugin("rate_limit", newRateLimitProcessor)
+}
+
+type processor struct {
+	Config
+	log *logp.Logger
+
+	allowed *monitoring.Int
+	limited *monitoring.Int
+
+	mutex     sync.Mutex
+	now       func() time.Time
+	global    *ratelimit.Bucket
+	keys      map[string]*ratelimit.Bucket
+	lastSweep time.Time
+}
+
+func newRateLimitProcessor(cfg *common.Config) (processors.Processor, error) {
+	c := defaultConfig
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, errors.Wrap(err, "fail to unpack the rate_limit configuration")
+	}
+
+	var (
+		id      = int(instanceID.Inc())
+		log     = logp.NewLogger(logName).With("instance_id", id)
+		metrics = monitoring.Default.NewRegistry(logName+"."+strconv.Itoa(id), monitoring.DoNotReport)
+	)
+
+	log.Debugf("rate_limit processor config: %+v", c)
+	return newProcessor(c, log, metrics), nil
+}
+
+func newProcessor(c Config, log *logp.Logger, metrics *monitoring.Registry) *processor {
+	p := &processor{
+		Config:  c,
+		log:     log,
+		allowed: monitoring.NewInt(metrics, "allowed"),
+		limited: monitoring.NewInt(metrics, "limited"),
+		now:     time.Now,
+		keys:    map[string]*ratelimit.Bucket{},
+	}
+	if c.EventsPerSecond > 0 {
+		p.global = ratelimit.NewBucket(c.EventsPerSecond, float64(c.Burst))
+	}
+	return p
+}
+
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	if p.allow(p.key(event)) {
+		p.allowed.Inc()
+		return event, nil
+	}
+
+	p.limited.Inc()
+	if p.Action == actionDrop {
+		return nil, nil
+	}
+	if err := common.AddTags(event.Fields, []string{p.Tag}); err != nil {
+		return event, errors.Wrap(err, "failed to tag rate limited event")
+	}
+	return event, nil
+}
+
+// allow reports whether an event with the given key is within the limits. A
+// token is only taken from the buckets if both allow the event.
+func (p *processor) allow(key string) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	now := p.now()
+
+	var keyBucket *ratelimit.Bucket
+	if p.KeyEventsPerSecond > 0 {
+		keyBucket = p.keyBucket(key, now)
+		keyBucket.Refill(now)
+		if keyBucket.Tokens() < 1 {
+			return false
+		}
+	}
+
+	if p.global != nil {
+		p.global.Refill(now)
+		if p.global.Tokens() < 1 {
+			return false
+		}
+		p.global.Take()
+	}
+
+	if keyBucket != nil {
+		keyBucket.Take()
+	}
+	return true
+}
+
+// keyBucket returns the bucket for key, creating it if required. When the
+// key table is full, buckets that have been refilled completely are removed
+// as they don't limit their keys anymore. If no bucket can be removed, all
+// new keys share a single overflow bucket.
+func (p *processor) keyBucket(key string, now time.Time) *ratelimit.Bucket {
+	if b, found := p.keys[key]; found {
+		return b
+	}
+
+	if len(p.keys) >= p.MaxKeys && now.Sub(p.lastSweep) >= time.Second {
+		p.lastSweep = now
+		for k, b := range p.keys {
+			b.Refill(now)
+			if b.Full() {
+				delete(p.keys, k)
+			}
+		}
+		p.log.Debugf("Removed idle keys, %v keys left", len(p.keys))
+	}
+
+	if len(p.keys) >= p.MaxKeys {
+		key = overflowKey
+		if b, found := p.keys[key]; found {
+			return b
+		}
+	}
+
+	b := ratelimit.NewBucket(p.KeyEventsPerSecond, float64(p.KeyBurst))
+	p.keys[key] = b
+	return b
+}
+
+func (p *processor) key(event *beat.Event) string {
+	if p.KeyField == "" {
+		return ""
+	}
+
+	v, err := event.GetValue(p.KeyField)
+	if err != nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+func (p *processor) String() string {
+	return fmt.Sprintf("rate_limit=[events_per_second=%v, key_field=%v, key_events_per_second=%v, action=%v]",
+		p.EventsPerSecond, p.KeyField, p.KeyEventsPerSecond, p.Action)
+}
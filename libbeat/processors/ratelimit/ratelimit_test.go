@@ -0,0 +1,165 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/monitoring"
+)
+
+func newTestProcessor(t *testing.T, settings map[string]interface{}) (*processor, *time.Time) {
+	cfg, err := common.NewConfigFrom(settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := defaultConfig
+	if err := cfg.Unpack(&c); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Unix(1000, 0)
+	p := newProcessor(c, logp.NewLogger(logName), monitoring.NewRegistry())
+	p.now = func() time.Time { return now }
+	return p, &now
+}
+
+func runEvents(p *processor, key string, n int) int {
+	allowed := 0
+	for i := 0; i < n; i++ {
+		event := &beat.Event{Fields: common.MapStr{"client_ip": key}}
+		out, err := p.Run(event)
+		if err == nil && out != nil {
+			if _, tagged := out.Fields["tags"]; !tagged {
+				allowed++
+			}
+		}
+	}
+	return allowed
+}
+
+func TestRateLimitGlobal(t *testing.T) {
+	p, now := newTestProcessor(t, map[string]interface{}{
+		"events_per_second": 10,
+		"burst":             20,
+	})
+
+	// the burst is allowed at once, then the rate applies
+	assert.Equal(t, 20, runEvents(p, "a", 100))
+	*now = now.Add(500 * time.Millisecond)
+	assert.Equal(t, 5, runEvents(p, "b", 100))
+
+	// the bucket doesn't fill beyond the burst
+	*now = now.Add(time.Hour)
+	assert.Equal(t, 20, runEvents(p, "a", 100))
+
+	assert.EqualValues(t, 45, p.allowed.Get())
+	assert.EqualValues(t, 255, p.limited.Get())
+}
+
+func TestRateLimitPerKey(t *testing.T) {
+	p, now := newTestProcessor(t, map[string]interface{}{
+		"key_field":             "client_ip",
+		"key_events_per_second": 5,
+	})
+
+	assert.Equal(t, 5, runEvents(p, "busy", 100))
+	assert.Equal(t, 3, runEvents(p, "quiet", 3))
+
+	*now = now.Add(time.Second)
+	assert.Equal(t, 5, runEvents(p, "busy", 100))
+}
+
+func TestRateLimitGlobalAndPerKey(t *testing.T) {
+	p, _ := newTestProcessor(t, map[string]interface{}{
+		"events_per_second":     8,
+		"key_field":             "client_ip",
+		"key_events_per_second": 5,
+	})
+
+	assert.Equal(t, 5, runEvents(p, "a", 100))
+	assert.Equal(t, 3, runEvents(p, "b", 100))
+	assert.Equal(t, 0, runEvents(p, "c", 100))
+
+	// keys limited by the global bucket keep their tokens
+	assert.EqualValues(t, 2, p.keys["b"].Tokens())
+	assert.EqualValues(t, 5, p.keys["c"].Tokens())
+}
+
+func TestRateLimitMaxKeys(t *testing.T) {
+	p, now := newTestProcessor(t, map[string]interface{}{
+		"key_field":             "client_ip",
+		"key_events_per_second": 1,
+		"max_keys":              2,
+	})
+
+	assert.Equal(t, 1, runEvents(p, "a", 2))
+	assert.Equal(t, 1, runEvents(p, "b", 2))
+
+	// new keys share the overflow bucket
+	assert.Equal(t, 1, runEvents(p, "c", 2))
+	assert.Equal(t, 0, runEvents(p, "d", 2))
+
+	// idle keys are removed
+	*now = now.Add(2 * time.Second)
+	assert.Equal(t, 1, runEvents(p, "e", 2))
+	assert.Contains(t, p.keys, "e")
+	assert.NotContains(t, p.keys, "a")
+}
+
+func TestRateLimitTag(t *testing.T) {
+	p, _ := newTestProcessor(t, map[string]interface{}{
+		"events_per_second": 1,
+		"action":            "tag",
+	})
+
+	out, err := p.Run(&beat.Event{Fields: common.MapStr{}})
+	assert.NoError(t, err)
+	assert.Equal(t, common.MapStr{}, out.Fields)
+
+	out, err = p.Run(&beat.Event{Fields: common.MapStr{}})
+	assert.NoError(t, err)
+	assert.Equal(t, common.MapStr{"tags": []string{"rate_limited"}}, out.Fields)
+}
+
+func TestRateLimitConfig(t *testing.T) {
+	tests := map[string]map[string]interface{}{
+		"no limit":        {},
+		"missing key":     {"key_events_per_second": 1},
+		"negative rate":   {"events_per_second": -1},
+		"unknown action":  {"events_per_second": 1, "action": "delay"},
+		"empty tag":       {"events_per_second": 1, "action": "tag", "tag": ""},
+		"invalid maxkeys": {"events_per_second": 1, "max_keys": 0},
+	}
+
+	for name, settings := range tests {
+		cfg, err := common.NewConfigFrom(settings)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = newRateLimitProcessor(cfg)
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,264 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package processors
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/joeshaw/multierror"
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/monitoring"
+	"github.com/elastic/beats/libbeat/paths"
+)
+
+var (
+	processorsReloads       = monitoring.NewInt(nil, "libbeat.processors.reloads")
+	processorsReloadsFailed = monitoring.NewInt(nil, "libbeat.processors.reloads_failed")
+)
+
+// Closer is implemented by processors holding resources, like connections or
+// goroutines, that must be released when the processor is not used anymore.
+type Closer interface {
+	Close() error
+}
+
+// Close closes p if it implements Closer.
+func Close(p Processor) error {
+	if c, ok := p.(Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// CloseList closes the processor list l if it implements Closer, like the
+// Processors list does.
+func CloseList(l beat.ProcessorList) error {
+	if c, ok := l.(Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+// Close closes all processors of the list implementing Closer.
+func (procs *Processors) Close() error {
+	if procs == nil {
+		return nil
+	}
+
+	var errs multierror.Errors
+	for _, p := range procs.List {
+		if err := Close(p); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs.Err()
+}
+
+// Reloadable is a Processor running a list of processors that can be replaced
+// at runtime.
+type Reloadable struct {
+	mu    sync.RWMutex
+	procs *Processors
+}
+
+// NewReloadable returns a Reloadable running procs until the next reload.
+func NewReloadable(procs *Processors) *Reloadable {
+	return &Reloadable{procs: procs}
+}
+
+// Reload creates the processors from config and swaps them in. Events being
+// processed by the old processors are finished before they are closed. On
+// error the old processors are kept.
+func (r *Reloadable) Reload(config PluginConfig) error {
+	procs, err := New(config)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	old := r.procs
+	r.procs = procs
+	r.mu.Unlock()
+
+	return old.Close()
+}
+
+// Run runs the event through the current processors.
+func (r *Reloadable) Run(event *beat.Event) (*beat.Event, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.procs.Run(event), nil
+}
+
+// Close closes the current processors.
+func (r *Reloadable) Close() error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.procs.Close()
+}
+
+func (r *Reloadable) String() string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return "reloadable=[" + r.procs.String() + "]"
+}
+
+// ReloadConfig configures loading the processors from a file that is watched
+// for changes.
+type ReloadConfig struct {
+	Enabled bool          `config:"enabled"`
+	Path    string        `config:"path"`   // If relative, it is relative to ${path.config}.
+	Period  time.Duration `config:"period"` // How often the file is checked for changes.
+}
+
+// DefaultReloadConfig is the default ReloadConfig.
+var DefaultReloadConfig = ReloadConfig{
+	Enabled: false,
+	Path:    "processors.yml",
+	Period:  10 * time.Second,
+}
+
+// Watcher reloads a Reloadable from the processors section of a file, when the
+// file changes or the process receives SIGHUP.
+type Watcher struct {
+	reloadable *Reloadable
+	path       string
+	period     time.Duration
+
+	modTime time.Time
+	size    int64
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// NewWatcher creates a Watcher for the given configuration. The processors
+// are loaded from the file once, so invalid configurations are reported on
+// startup.
+func NewWatcher(config ReloadConfig) (*Watcher, error) {
+	path := config.Path
+	if !filepath.IsAbs(path) {
+		path = paths.Resolve(paths.Config, path)
+	}
+
+	if config.Period <= 0 {
+		return nil, errors.New("processors_reload.period must be greater than 0")
+	}
+
+	w := &Watcher{
+		reloadable: NewReloadable(&Processors{}),
+		path:       path,
+		period:     config.Period,
+		done:       make(chan struct{}),
+	}
+	if _, err := w.check(true); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// Processor returns the processor running the processors of the watched file.
+func (w *Watcher) Processor() *Reloadable {
+	return w.reloadable
+}
+
+// Start starts watching the file.
+func (w *Watcher) Start() {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		defer signal.Stop(signals)
+
+		ticker := time.NewTicker(w.period)
+		defer ticker.Stop()
+
+		for {
+			force := false
+			select {
+			case <-w.done:
+				return
+			case <-ticker.C:
+			case <-signals:
+				logp.Info("Received SIGHUP, reloading processors from %v", w.path)
+				force = true
+			}
+
+			if reloaded, err := w.check(force); err != nil {
+				processorsReloadsFailed.Inc()
+				logp.Err("Failed to reload processors from %v, keeping the previous processors: %v", w.path, err)
+			} else if reloaded {
+				processorsReloads.Inc()
+				logp.Info("Reloaded processors from %v: %v", w.path, w.reloadable)
+			}
+		}
+	}()
+}
+
+// Stop stops watching the file and closes the processors.
+func (w *Watcher) Stop() {
+	close(w.done)
+	w.wg.Wait()
+	if err := w.reloadable.Close(); err != nil {
+		logp.Err("Failed to close processors: %v", err)
+	}
+}
+
+// check reloads the processors if the file changed since the last reload, or
+// if force is set. It reports whether the processors were reloaded.
+func (w *Watcher) check(force bool) (bool, error) {
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to read processors file")
+	}
+	if !force && info.ModTime().Equal(w.modTime) && info.Size() == w.size {
+		return false, nil
+	}
+
+	// The file is not checked again until it changes, even if it's invalid.
+	w.modTime, w.size = info.ModTime(), info.Size()
+
+	cfg, err := common.LoadFile(w.path)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to load processors file")
+	}
+
+	config := struct {
+		Processors PluginConfig `config:"processors"`
+	}{}
+	if err := cfg.Unpack(&config); err != nil {
+		return false, errors.Wrap(err, "failed to unpack processors file")
+	}
+
+	if err := w.reloadable.Reload(config.Processors); err != nil {
+		return false, fmt.Errorf("error initializing processors: %v", err)
+	}
+	return true, nil
+}
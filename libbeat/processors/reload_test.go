@@ -0,0 +1,163 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package processors
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+// tagProcessor adds its tag to events and records when it's closed.
+type tagProcessor struct {
+	tag    string
+	closed bool
+}
+
+func init() {
+	RegisterPlugin("test_tag", func(cfg *common.Config) (Processor, error) {
+		config := struct {
+			Tag string `config:"tag"`
+		}{}
+		if err := cfg.Unpack(&config); err != nil {
+			return nil, err
+		}
+		p := &tagProcessor{tag: config.Tag}
+		createdTagProcessors = append(createdTagProcessors, p)
+		return p, nil
+	})
+}
+
+var createdTagProcessors []*tagProcessor
+
+func (p *tagProcessor) Run(event *beat.Event) (*beat.Event, error) {
+	event.Fields["tag"] = p.tag
+	return event, nil
+}
+
+func (p *tagProcessor) Close() error {
+	p.closed = true
+	return nil
+}
+
+func (p *tagProcessor) String() string { return "test_tag=" + p.tag }
+
+func tagConfig(t *testing.T, tag string) PluginConfig {
+	cfg, err := common.NewConfigFrom(map[string]interface{}{"tag": tag})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return PluginConfig{{"test_tag": cfg}}
+}
+
+func TestReloadable(t *testing.T) {
+	createdTagProcessors = nil
+
+	procs, err := New(tagConfig(t, "a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := NewReloadable(procs)
+
+	event, err := r.Run(&beat.Event{Fields: common.MapStr{}})
+	assert.NoError(t, err)
+	assert.Equal(t, "a", event.Fields["tag"])
+
+	assert.NoError(t, r.Reload(tagConfig(t, "b")))
+	event, _ = r.Run(&beat.Event{Fields: common.MapStr{}})
+	assert.Equal(t, "b", event.Fields["tag"])
+
+	// The old processor is closed, the new one only when closing r
+	if assert.Len(t, createdTagProcessors, 2) {
+		assert.True(t, createdTagProcessors[0].closed)
+		assert.False(t, createdTagProcessors[1].closed)
+		assert.NoError(t, r.Close())
+		assert.True(t, createdTagProcessors[1].closed)
+	}
+
+	// Invalid configurations keep the current processors
+	invalid := PluginConfig{{"unknown_processor": common.NewConfig()}}
+	assert.Error(t, r.Reload(invalid))
+	event, _ = r.Run(&beat.Event{Fields: common.MapStr{}})
+	assert.Equal(t, "b", event.Fields["tag"])
+}
+
+func TestWatcher(t *testing.T) {
+	dir, err := ioutil.TempDir("", "processors")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "processors.yml")
+	write := func(content string, modTime time.Time) {
+		if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	start := time.Now().Add(-time.Hour)
+	write("processors:\n- test_tag.tag: a\n", start)
+
+	config := DefaultReloadConfig
+	config.Path = path
+	w, err := NewWatcher(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	run := func() interface{} {
+		event, _ := w.Processor().Run(&beat.Event{Fields: common.MapStr{}})
+		return event.Fields["tag"]
+	}
+	assert.Equal(t, "a", run())
+
+	// Unchanged file is not reloaded
+	reloaded, err := w.check(false)
+	assert.NoError(t, err)
+	assert.False(t, reloaded)
+
+	write("processors:\n- test_tag.tag: b\n", start.Add(time.Minute))
+	reloaded, err = w.check(false)
+	assert.NoError(t, err)
+	assert.True(t, reloaded)
+	assert.Equal(t, "b", run())
+
+	// Invalid files keep the current processors
+	write("processors:\n- unknown_processor: {}\n", start.Add(2*time.Minute))
+	_, err = w.check(false)
+	assert.Error(t, err)
+	assert.Equal(t, "b", run())
+}
+
+func TestWatcherMissingFile(t *testing.T) {
+	config := DefaultReloadConfig
+	config.Path = filepath.Join(os.TempDir(), "does-not-exist", "processors.yml")
+	_, err := NewWatcher(config)
+	assert.Error(t, err)
+}
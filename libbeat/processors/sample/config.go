@@ -0,0 +1,56 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sample
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Config defines the configuration options for the sample processor.
+type Config struct {
+	Rate            float64       `config:"rate"`              // Fraction of events to keep when not sampling per key.
+	EventsPerSecond int           `config:"events_per_second"` // Events kept per key and second. Enables adaptive sampling.
+	KeyField        string        `config:"key_field"`         // Event field used to group events in adaptive mode.
+	MaxKeys         int           `config:"max_keys"`          // Maximum number of keys tracked per period.
+	Period          time.Duration `config:"period"`            // Length of a sampling period in adaptive mode.
+}
+
+// Validate validates the data contained in the config.
+func (c *Config) Validate() error {
+	if c.Rate <= 0 || c.Rate > 1 {
+		return errors.Errorf("sample rate must be > 0 and <= 1, but got %v", c.Rate)
+	}
+	if c.EventsPerSecond < 0 {
+		return errors.Errorf("events_per_second must be >= 0")
+	}
+	if c.MaxKeys <= 0 {
+		return errors.Errorf("max_keys must be > 0")
+	}
+	if c.Period <= 0 {
+		return errors.Errorf("period must be > 0")
+	}
+	return nil
+}
+
+var defaultConfig = Config{
+	Rate:    1,
+	MaxKeys: 10000,
+	Period:  time.Second,
+}
@@ -0,0 +1,176 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package sample implements a processor that reduces the number of published
+// events.
+//
+// With a fixed rate each event is kept with the configured probability. With
+// events_per_second set, the processor samples adaptively: events are grouped
+// by the value of key_field and up to events_per_second events are kept for
+// each key within a period. Low volume keys are captured in full, while high
+// volume keys are down-sampled.
+package sample
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/atomic"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/monitoring"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+const logName = "processor.sample"
+
+// overflowKey groups all events whose key did not fit into the key table.
+const overflowKey = "\x00overflow"
+
+// instanceID is used to assign each instance a unique monitoring namespace.
+var instanceID = atomic.MakeUint32(0)
+
+func init() {
+	processors.RegisterPlugin("sample", newSampleProcessor)
+}
+
+type processor struct {
+	Config
+	log *logp.Logger
+
+	kept    *monitoring.Int
+	dropped *monitoring.Int
+
+	mutex       sync.Mutex
+	rand        *rand.Rand
+	now         func() time.Time
+	limit       int
+	periodStart time.Time
+	counts      map[string]int
+}
+
+func newSampleProcessor(cfg *common.Config) (processors.Processor, error) {
+	c := defaultConfig
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, errors.Wrap(err, "fail to unpack the sample configuration")
+	}
+
+	var (
+		id      = int(instanceID.Inc())
+		log     = logp.NewLogger(logName).With("instance_id", id)
+		metrics = monitoring.Default.NewRegistry(logName+"."+strconv.Itoa(id), monitoring.DoNotReport)
+	)
+
+	log.Debugf("sample processor config: %+v", c)
+	return newProcessor(c, log, metrics), nil
+}
+
+func newProcessor(c Config, log *logp.Logger, metrics *monitoring.Registry) *processor {
+	limit := int(float64(c.EventsPerSecond) * c.Period.Seconds())
+	if c.EventsPerSecond > 0 && limit < 1 {
+		limit = 1
+	}
+
+	return &processor{
+		Config:  c,
+		log:     log,
+		kept:    monitoring.NewInt(metrics, "kept"),
+		dropped: monitoring.NewInt(metrics, "dropped"),
+		rand:    rand.New(rand.NewSource(time.Now().UnixNano())),
+		now:     time.Now,
+		limit:   limit,
+		counts:  map[string]int{},
+	}
+}
+
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	var keep bool
+	if p.EventsPerSecond > 0 {
+		keep = p.sampleByKey(p.key(event))
+	} else {
+		keep = p.sampleByRate()
+	}
+
+	if !keep {
+		p.dropped.Inc()
+		return nil, nil
+	}
+	p.kept.Inc()
+	return event, nil
+}
+
+func (p *processor) sampleByRate() bool {
+	if p.Rate >= 1 {
+		return true
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.rand.Float64() < p.Rate
+}
+
+func (p *processor) sampleByKey(key string) bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	now := p.now()
+	if now.Sub(p.periodStart) >= p.Period || now.Before(p.periodStart) {
+		p.periodStart = now
+		p.counts = map[string]int{}
+	}
+
+	count, found := p.counts[key]
+	if !found && len(p.counts) >= p.MaxKeys {
+		key = overflowKey
+		count = p.counts[key]
+	}
+
+	if count >= p.limit {
+		return false
+	}
+	p.counts[key] = count + 1
+	return true
+}
+
+func (p *processor) key(event *beat.Event) string {
+	if p.KeyField == "" {
+		return ""
+	}
+
+	v, err := event.GetValue(p.KeyField)
+	if err != nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+func (p *processor) String() string {
+	if p.EventsPerSecond > 0 {
+		return fmt.Sprintf("sample=[events_per_second=%v, key_field=%v, period=%v]",
+			p.EventsPerSecond, p.KeyField, p.Period)
+	}
+	return fmt.Sprintf("sample=[rate=%v]", p.Rate)
+}
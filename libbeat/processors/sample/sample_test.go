@@ -0,0 +1,133 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sample
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/monitoring"
+)
+
+func newTestProcessor(t *testing.T, settings map[string]interface{}) *processor {
+	cfg, err := common.NewConfigFrom(settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := defaultConfig
+	if err := cfg.Unpack(&c); err != nil {
+		t.Fatal(err)
+	}
+	return newProcessor(c, logp.NewLogger(logName), monitoring.NewRegistry())
+}
+
+func runEvents(p *processor, key string, n int) int {
+	kept := 0
+	for i := 0; i < n; i++ {
+		event := &beat.Event{Fields: common.MapStr{"http": common.MapStr{"host": key}}}
+		out, err := p.Run(event)
+		if err == nil && out != nil {
+			kept++
+		}
+	}
+	return kept
+}
+
+func TestSampleFixedRate(t *testing.T) {
+	p := newTestProcessor(t, map[string]interface{}{"rate": 1})
+	assert.Equal(t, 100, runEvents(p, "a", 100))
+
+	p = newTestProcessor(t, map[string]interface{}{"rate": 0.5})
+	kept := runEvents(p, "a", 10000)
+	assert.InDelta(t, 5000, kept, 500)
+	assert.EqualValues(t, kept, p.kept.Get())
+	assert.EqualValues(t, 10000-kept, p.dropped.Get())
+}
+
+func TestSampleAdaptive(t *testing.T) {
+	now := time.Unix(1000, 0)
+	p := newTestProcessor(t, map[string]interface{}{
+		"events_per_second": 10,
+		"key_field":         "http.host",
+	})
+	p.now = func() time.Time { return now }
+
+	// high volume keys are down-sampled, low volume keys are fully kept
+	assert.Equal(t, 10, runEvents(p, "busy", 100))
+	assert.Equal(t, 3, runEvents(p, "quiet", 3))
+
+	// counts are reset in the next period
+	now = now.Add(time.Second)
+	assert.Equal(t, 10, runEvents(p, "busy", 100))
+
+	assert.EqualValues(t, 23, p.kept.Get())
+	assert.EqualValues(t, 180, p.dropped.Get())
+}
+
+func TestSampleAdaptivePeriod(t *testing.T) {
+	now := time.Unix(1000, 0)
+	p := newTestProcessor(t, map[string]interface{}{
+		"events_per_second": 10,
+		"period":            "10s",
+	})
+	p.now = func() time.Time { return now }
+
+	assert.Equal(t, 100, runEvents(p, "a", 200))
+	now = now.Add(5 * time.Second)
+	assert.Equal(t, 0, runEvents(p, "a", 10))
+}
+
+func TestSampleAdaptiveMaxKeys(t *testing.T) {
+	now := time.Unix(1000, 0)
+	p := newTestProcessor(t, map[string]interface{}{
+		"events_per_second": 1,
+		"key_field":         "http.host",
+		"max_keys":          2,
+	})
+	p.now = func() time.Time { return now }
+
+	assert.Equal(t, 1, runEvents(p, "a", 5))
+	assert.Equal(t, 1, runEvents(p, "b", 5))
+
+	// further keys share one overflow bucket
+	assert.Equal(t, 1, runEvents(p, "c", 5))
+	assert.Equal(t, 0, runEvents(p, "d", 5))
+}
+
+func TestSampleConfigValidate(t *testing.T) {
+	for _, settings := range []map[string]interface{}{
+		{"rate": 0},
+		{"rate": 1.5},
+		{"events_per_second": -1},
+		{"max_keys": 0},
+	} {
+		cfg, err := common.NewConfigFrom(settings)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = newSampleProcessor(cfg)
+		assert.Error(t, err, "%v", settings)
+	}
+}
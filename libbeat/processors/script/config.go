@@ -0,0 +1,43 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package script
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Config defines the configuration options for the script processor.
+type Config struct {
+	Source  string        `config:"source"`                   // Inline JavaScript source.
+	File    string        `config:"file"`                     // Path of the JavaScript file.
+	Timeout time.Duration `config:"timeout" validate:"min=0"` // Maximum run time per event, 0 disables it.
+}
+
+var defaultConfig = Config{
+	Timeout: time.Second,
+}
+
+// Validate checks that the script is configured once.
+func (c *Config) Validate() error {
+	if (c.Source == "") == (c.File == "") {
+		return errors.New("exactly one of source and file must be set")
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package script
+
+import (
+	"math"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/robertkrimen/otto"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+// newEventObject creates the JavaScript object passed to the process
+// function. Its methods read and modify event. Drop sets dropped.
+func newEventObject(vm *otto.Otto, event *beat.Event, dropped *bool) (*otto.Object, error) {
+	obj, err := vm.Object("({})")
+	if err != nil {
+		return nil, err
+	}
+
+	methods := map[string]func(call otto.FunctionCall) otto.Value{
+		// Get returns the value of a field, or undefined if the field
+		// doesn't exist.
+		"Get": func(call otto.FunctionCall) otto.Value {
+			v, err := event.GetValue(call.Argument(0).String())
+			if err != nil {
+				return otto.UndefinedValue()
+			}
+			value, err := call.Otto.ToValue(v)
+			throwOnError(call, err)
+			return value
+		},
+
+		// Put sets the value of a field, creating the parent objects as
+		// needed.
+		"Put": func(call otto.FunctionCall) otto.Value {
+			v, err := call.Argument(1).Export()
+			throwOnError(call, err)
+			_, err = event.PutValue(call.Argument(0).String(), normalize(v))
+			throwOnError(call, err)
+			return otto.UndefinedValue()
+		},
+
+		// Delete removes a field. Deleting a missing field is not an error.
+		"Delete": func(call otto.FunctionCall) otto.Value {
+			err := event.Delete(call.Argument(0).String())
+			if errors.Cause(err) != common.ErrKeyNotFound {
+				throwOnError(call, err)
+			}
+			return otto.UndefinedValue()
+		},
+
+		// Drop drops the event once the process function returns.
+		"Drop": func(call otto.FunctionCall) otto.Value {
+			*dropped = true
+			return otto.UndefinedValue()
+		},
+
+		// Tag appends a tag to the tags of the event.
+		"Tag": func(call otto.FunctionCall) otto.Value {
+			err := common.AddTags(event.Fields, []string{call.Argument(0).String()})
+			throwOnError(call, err)
+			return otto.UndefinedValue()
+		},
+	}
+	for name, method := range methods {
+		if err := obj.Set(name, method); err != nil {
+			return nil, err
+		}
+	}
+	return obj, nil
+}
+
+// normalize converts the objects exported from the interpreter to MapStr and
+// the whole numbers to int64. The interpreter stores numbers as integers or
+// floats depending on how they were computed, but JavaScript doesn't
+// distinguish them.
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		m := make(common.MapStr, len(v))
+		for k, value := range v {
+			m[k] = normalize(value)
+		}
+		return m
+	case []interface{}:
+		for i, value := range v {
+			v[i] = normalize(value)
+		}
+		return v
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32:
+		return rv.Int()
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return int64(rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		// whole numbers are only exact up to 2^53
+		if f := rv.Float(); f == math.Trunc(f) && math.Abs(f) <= 1<<53 {
+			return int64(f)
+		}
+	}
+	return v
+}
+
+// throwOnError throws err as a JavaScript Error, which the script can catch.
+func throwOnError(call otto.FunctionCall, err error) {
+	if err != nil {
+		panic(call.Otto.MakeCustomError("Error", err.Error()))
+	}
+}
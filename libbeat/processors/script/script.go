@@ -0,0 +1,180 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package script implements a processor running a JavaScript function on
+// every event, using the otto interpreter.
+//
+// The script must define a function named process. It is called with an event
+// object for every event, and the changes made through the object are applied
+// to the event. The script is compiled and run once when the processor is
+// created, so syntax errors and a missing process function are reported at
+// startup.
+//
+// The interpreter is not safe for concurrent use, so the events are processed
+// one at a time. A script running longer than the timeout is interrupted and
+// the interpreter is recreated for the next event, as an interrupted script
+// may leave it in any state.
+package script
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/robertkrimen/otto"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/paths"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+// errTimeout is raised in the interpreter to stop a script running longer
+// than the timeout.
+var errTimeout = errors.New("script timeout")
+
+func init() {
+	processors.RegisterPlugin("script", newScript)
+}
+
+type processor struct {
+	Config
+	name   string // Name of the script in error messages.
+	script *otto.Script
+
+	mu      sync.Mutex // Serializes the runs of the script.
+	vm      *otto.Otto
+	process otto.Value
+}
+
+func newScript(cfg *common.Config) (processors.Processor, error) {
+	c := defaultConfig
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, errors.Wrap(err, "fail to unpack the script configuration")
+	}
+
+	name, src := "inline.js", c.Source
+	if c.File != "" {
+		name = paths.Resolve(paths.Config, c.File)
+		data, err := ioutil.ReadFile(name)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read the script file")
+		}
+		src = string(data)
+	}
+
+	script, err := otto.New().Compile(name, src)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to compile %v", name)
+	}
+
+	p := &processor{Config: c, name: name, script: script}
+	if err := p.init(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// init creates the interpreter and runs the script, which defines the
+// process function.
+func (p *processor) init() error {
+	vm := otto.New()
+	err := p.run(vm, func() error {
+		_, err := vm.Run(p.script)
+		return err
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to run %v", p.name)
+	}
+
+	process, err := vm.Get("process")
+	if err != nil {
+		return err
+	}
+	if !process.IsFunction() {
+		return errors.Errorf("%v must define a process function", p.name)
+	}
+
+	p.vm, p.process = vm, process
+	return nil
+}
+
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.vm == nil {
+		if err := p.init(); err != nil {
+			return event, err
+		}
+	}
+
+	var dropped bool
+	obj, err := newEventObject(p.vm, event, &dropped)
+	if err != nil {
+		return event, err
+	}
+
+	err = p.run(p.vm, func() error {
+		_, err := p.process.Call(otto.UndefinedValue(), obj)
+		return err
+	})
+	if err != nil {
+		if errors.Cause(err) == errTimeout {
+			p.vm = nil
+		}
+		return event, errors.Wrapf(err, "failed to process the event with %v", p.name)
+	}
+
+	if dropped {
+		return nil, nil
+	}
+	return event, nil
+}
+
+// run runs fn, which must run JavaScript code in vm, and stops the code once
+// the timeout is exceeded.
+func (p *processor) run(vm *otto.Otto, fn func() error) (err error) {
+	if p.Timeout <= 0 {
+		return fn()
+	}
+
+	// Every run uses a new channel, so that a timer firing after its run
+	// completed can't interrupt a later run.
+	interrupt := make(chan func(), 1)
+	vm.Interrupt = interrupt
+	timer := time.AfterFunc(p.Timeout, func() {
+		interrupt <- func() { panic(errTimeout) }
+	})
+	defer timer.Stop()
+
+	defer func() {
+		if r := recover(); r != nil {
+			if r != errTimeout {
+				panic(r)
+			}
+			err = errors.Wrapf(errTimeout, "timed out after %v", p.Timeout)
+		}
+	}()
+	return fn()
+}
+
+func (p *processor) String() string {
+	return fmt.Sprintf("script=[name=%v, timeout=%v]", p.name, p.Timeout)
+}
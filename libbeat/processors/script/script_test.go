@@ -0,0 +1,172 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package script
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+func newTestScript(t *testing.T, settings map[string]interface{}) processors.Processor {
+	p, err := newScript(common.MustNewConfigFrom(settings))
+	require.NoError(t, err)
+	return p
+}
+
+func TestScriptEventAPI(t *testing.T) {
+	p := newTestScript(t, map[string]interface{}{
+		"source": `
+			function process(event) {
+				var msg = event.Get("message");
+				event.Put("http.request.size", msg.length);
+				event.Put("ratio", msg.length / 2);
+				event.Put("copy", event.Get("nested"));
+				event.Delete("secret");
+				event.Delete("missing");
+				if (event.Get("missing") === undefined) {
+					event.Tag("scripted");
+				}
+			}`,
+	})
+
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{
+		"message": "hello",
+		"secret":  "hunter2",
+		"nested":  common.MapStr{"a": "b"},
+		"tags":    []string{"input"},
+	}})
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	assert.Equal(t, common.MapStr{
+		"message": "hello",
+		"http":    common.MapStr{"request": common.MapStr{"size": int64(5)}},
+		"ratio":   2.5,
+		"nested":  common.MapStr{"a": "b"},
+		"copy":    common.MapStr{"a": "b"},
+		"tags":    []string{"input", "scripted"},
+	}, event.Fields)
+}
+
+func TestScriptDrop(t *testing.T) {
+	p := newTestScript(t, map[string]interface{}{
+		"source": `function process(event) { if (event.Get("level") == "debug") event.Drop(); }`,
+	})
+
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{"level": "debug"}})
+	assert.NoError(t, err)
+	assert.Nil(t, event)
+
+	event, err = p.Run(&beat.Event{Fields: common.MapStr{"level": "error"}})
+	assert.NoError(t, err)
+	assert.NotNil(t, event)
+}
+
+func TestScriptError(t *testing.T) {
+	p := newTestScript(t, map[string]interface{}{
+		"source": `
+			function process(event) {
+				event.Put("before", true);
+				try {
+					event.Put("message.text", "x");
+				} catch (e) {
+					event.Put("caught", true);
+				}
+				throw new Error("failure");
+			}`,
+	})
+
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{"message": "hello"}})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "failure")
+	}
+	// the event is published with the changes made before the error
+	if assert.NotNil(t, event) {
+		assert.Equal(t, common.MapStr{"message": "hello", "before": true, "caught": true}, event.Fields)
+	}
+}
+
+func TestScriptTimeout(t *testing.T) {
+	p := newTestScript(t, map[string]interface{}{
+		"source": `
+			var runs = 0;
+			function process(event) {
+				runs++;
+				event.Put("runs", runs);
+				if (event.Get("loop")) {
+					while (true) {}
+				}
+			}`,
+		"timeout": 50 * time.Millisecond,
+	})
+
+	start := time.Now()
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{"loop": true}})
+	assert.Error(t, err)
+	assert.NotNil(t, event)
+	assert.True(t, time.Since(start) < 5*time.Second)
+
+	// the interpreter is recreated after a timeout
+	event, err = p.Run(&beat.Event{Fields: common.MapStr{}})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), event.Fields["runs"])
+}
+
+func TestScriptFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "script")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "process.js")
+	err = ioutil.WriteFile(path, []byte(`function process(event) { event.Put("file", true); }`), 0600)
+	require.NoError(t, err)
+
+	p := newTestScript(t, map[string]interface{}{"file": path})
+	event, err := p.Run(&beat.Event{Fields: common.MapStr{}})
+	require.NoError(t, err)
+	assert.Equal(t, true, event.Fields["file"])
+}
+
+func TestScriptInvalidConfig(t *testing.T) {
+	configs := map[string]map[string]interface{}{
+		"no script":          {},
+		"source and file":    {"source": "function process(event) {}", "file": "process.js"},
+		"syntax error":       {"source": "function process(event) {"},
+		"missing process":    {"source": "function run(event) {}"},
+		"missing file":       {"file": "/nonexistent/process.js"},
+		"negative timeout":   {"source": "function process(event) {}", "timeout": "-1s"},
+		"exception at start": {"source": "throw new Error('init'); function process(event) {}"},
+		"process not a func": {"source": "var process = 1;"},
+	}
+
+	for name, settings := range configs {
+		_, err := newScript(common.MustNewConfigFrom(settings))
+		assert.Error(t, err, name)
+	}
+}
@@ -0,0 +1,77 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package timestamp
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Config defines the configuration options for the timestamp processor.
+type Config struct {
+	Field         string    `config:"field" validate:"required"`   // Field containing the timestamp.
+	TargetField   string    `config:"target_field"`                // Field the parsed time is written to.
+	Layouts       []string  `config:"layouts" validate:"required"` // Layouts tried in order.
+	Timezone      *location `config:"timezone"`                    // Timezone of timestamps without offset.
+	IgnoreMissing bool      `config:"ignore_missing"`              // Skip events without field.
+	IgnoreFailure bool      `config:"ignore_failure"`              // Don't report parse failures.
+}
+
+var defaultConfig = Config{
+	TargetField: "@timestamp",
+}
+
+// Validate checks that at least one layout is configured.
+func (c *Config) Validate() error {
+	if len(c.Layouts) == 0 {
+		return errors.New("at least one layout is required")
+	}
+	return nil
+}
+
+type location struct {
+	*time.Location
+}
+
+var offsetRegexp = regexp.MustCompile(`^([+-])(\d{2}):?(\d{2})$`)
+
+// Unpack unpacks a timezone name, like Europe/Paris, or a fixed offset, like
+// +0200 or -05:00.
+func (l *location) Unpack(v string) error {
+	if m := offsetRegexp.FindStringSubmatch(v); m != nil {
+		hours, _ := strconv.Atoi(m[2])
+		minutes, _ := strconv.Atoi(m[3])
+		offset := hours*3600 + minutes*60
+		if m[1] == "-" {
+			offset = -offset
+		}
+		l.Location = time.FixedZone(fmt.Sprintf("UTC%v", v), offset)
+		return nil
+	}
+
+	loc, err := time.LoadLocation(v)
+	if err != nil {
+		return errors.Wrapf(err, "invalid timezone '%v'", v)
+	}
+	l.Location = loc
+	return nil
+}
@@ -0,0 +1,141 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package timestamp implements a processor that parses a time from a field
+// and uses it as the event timestamp.
+package timestamp
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+const (
+	layoutUnix   = "UNIX"
+	layoutUnixMS = "UNIX_MS"
+)
+
+func init() {
+	processors.RegisterPlugin("timestamp", newTimestamp)
+}
+
+type processor struct {
+	Config
+	loc *time.Location
+}
+
+func newTimestamp(cfg *common.Config) (processors.Processor, error) {
+	c := defaultConfig
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, errors.Wrap(err, "fail to unpack the timestamp configuration")
+	}
+
+	loc := time.UTC
+	if c.Timezone != nil {
+		loc = c.Timezone.Location
+	}
+
+	return &processor{Config: c, loc: loc}, nil
+}
+
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	v, err := event.GetValue(p.Field)
+	if err != nil {
+		if p.IgnoreMissing && errors.Cause(err) == common.ErrKeyNotFound {
+			return event, nil
+		}
+		return event, errors.Wrapf(err, "failed to get time field %v", p.Field)
+	}
+
+	ts, err := p.parse(v)
+	if err != nil {
+		if p.IgnoreFailure {
+			return event, nil
+		}
+		return event, errors.Wrapf(err, "failed to parse time field %v", p.Field)
+	}
+
+	if p.TargetField == "@timestamp" {
+		event.Timestamp = ts
+		return event, nil
+	}
+
+	_, err = event.PutValue(p.TargetField, common.Time(ts))
+	return event, err
+}
+
+// parse tries all layouts in order and returns the first time that can be
+// parsed, in UTC.
+func (p *processor) parse(v interface{}) (time.Time, error) {
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = v
+	case time.Time:
+		return v.UTC(), nil
+	case common.Time:
+		return time.Time(v).UTC(), nil
+	default:
+		s = fmt.Sprint(v)
+	}
+
+	for _, layout := range p.Layouts {
+		var ts time.Time
+		var err error
+		switch layout {
+		case layoutUnix:
+			ts, err = parseUnix(s, time.Second)
+		case layoutUnixMS:
+			ts, err = parseUnix(s, time.Millisecond)
+		default:
+			ts, err = time.ParseInLocation(layout, s, p.loc)
+		}
+		if err == nil {
+			return ts.UTC(), nil
+		}
+	}
+	return time.Time{}, errors.Errorf("'%v' does not match any of the layouts %v", s, p.Layouts)
+}
+
+// parseUnix parses a number of units since the epoch. Fractions are allowed.
+func parseUnix(s string, unit time.Duration) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(0, 0).Add(time.Duration(n) * unit), nil
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return time.Time{}, errors.Errorf("'%v' is not a number", s)
+	}
+	sec, frac := math.Modf(f * float64(unit) / float64(time.Second))
+	return time.Unix(int64(sec), int64(frac*float64(time.Second))), nil
+}
+
+func (p *processor) String() string {
+	return fmt.Sprintf("timestamp=[field=%v, target_field=%v, layouts=%v, timezone=%v]",
+		p.Field, p.TargetField, p.Layouts, p.loc)
+}
@@ -0,0 +1,197 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package timestamp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestTimestamp(t *testing.T) {
+	captured := time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	var tests = []struct {
+		description string
+		config      map[string]interface{}
+		value       interface{}
+		expected    time.Time
+	}{
+		{
+			description: "rfc3339 with offset",
+			config:      map[string]interface{}{"layouts": []string{time.RFC3339Nano}},
+			value:       "2018-08-29T14:03:12.345+02:00",
+			expected:    time.Date(2018, 8, 29, 12, 3, 12, 345000000, time.UTC),
+		},
+		{
+			description: "second layout matches",
+			config: map[string]interface{}{
+				"layouts": []string{time.RFC3339, "02/Jan/2006:15:04:05 -0700"},
+			},
+			value:    "29/Aug/2018:14:03:12 -0500",
+			expected: time.Date(2018, 8, 29, 19, 3, 12, 0, time.UTC),
+		},
+		{
+			description: "fixed offset timezone",
+			config: map[string]interface{}{
+				"layouts":  []string{"2006-01-02 15:04:05"},
+				"timezone": "+09:00",
+			},
+			value:    "2018-08-29 09:00:00",
+			expected: time.Date(2018, 8, 29, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			description: "named timezone",
+			config: map[string]interface{}{
+				"layouts":  []string{"2006-01-02 15:04:05"},
+				"timezone": "Europe/Paris",
+			},
+			value:    "2018-01-15 13:00:00",
+			expected: time.Date(2018, 1, 15, 12, 0, 0, 0, time.UTC),
+		},
+		{
+			description: "unix seconds",
+			config:      map[string]interface{}{"layouts": []string{"UNIX"}},
+			value:       1535551392.5,
+			expected:    time.Date(2018, 8, 29, 14, 3, 12, 500000000, time.UTC),
+		},
+		{
+			description: "unix milliseconds",
+			config:      map[string]interface{}{"layouts": []string{"UNIX_MS"}},
+			value:       "1535551392123",
+			expected:    time.Date(2018, 8, 29, 14, 3, 12, 123000000, time.UTC),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			test.config["field"] = "ts"
+			p, err := newTimestamp(common.MustNewConfigFrom(test.config))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			event, err := p.Run(&beat.Event{
+				Timestamp: captured,
+				Fields:    common.MapStr{"ts": test.value},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.expected, event.Timestamp)
+			assert.Equal(t, time.UTC, event.Timestamp.Location())
+		})
+	}
+}
+
+func TestTimestampTargetField(t *testing.T) {
+	p, err := newTimestamp(common.MustNewConfigFrom(map[string]interface{}{
+		"field":        "ts",
+		"target_field": "start_time",
+		"layouts":      []string{time.RFC3339},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	captured := time.Now()
+	event, err := p.Run(&beat.Event{
+		Timestamp: captured,
+		Fields:    common.MapStr{"ts": "2018-08-29T14:03:12Z"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, captured, event.Timestamp)
+	assert.Equal(t, common.Time(time.Date(2018, 8, 29, 14, 3, 12, 0, time.UTC)), event.Fields["start_time"])
+}
+
+func TestTimestampFailures(t *testing.T) {
+	captured := time.Now()
+
+	var tests = []struct {
+		description string
+		config      map[string]interface{}
+		fields      common.MapStr
+		error       bool
+	}{
+		{
+			description: "missing field",
+			fields:      common.MapStr{},
+			error:       true,
+		},
+		{
+			description: "ignore missing field",
+			config:      map[string]interface{}{"ignore_missing": true},
+			fields:      common.MapStr{},
+		},
+		{
+			description: "no layout matches",
+			fields:      common.MapStr{"ts": "yesterday"},
+			error:       true,
+		},
+		{
+			description: "ignore failure",
+			config:      map[string]interface{}{"ignore_failure": true},
+			fields:      common.MapStr{"ts": "yesterday"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.description, func(t *testing.T) {
+			config := map[string]interface{}{
+				"field":   "ts",
+				"layouts": []string{time.RFC3339, "UNIX"},
+			}
+			for k, v := range test.config {
+				config[k] = v
+			}
+			p, err := newTimestamp(common.MustNewConfigFrom(config))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			event, err := p.Run(&beat.Event{Timestamp: captured, Fields: test.fields})
+			if test.error {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, captured, event.Timestamp)
+		})
+	}
+}
+
+func TestTimestampInvalidConfig(t *testing.T) {
+	configs := map[string]map[string]interface{}{
+		"missing layouts":  {"field": "ts"},
+		"missing field":    {"layouts": []string{time.RFC3339}},
+		"invalid timezone": {"field": "ts", "layouts": []string{time.RFC3339}, "timezone": "Mars/Olympus"},
+	}
+
+	for name, config := range configs {
+		t.Run(name, func(t *testing.T) {
+			_, err := newTimestamp(common.MustNewConfigFrom(config))
+			assert.Error(t, err)
+		})
+	}
+}
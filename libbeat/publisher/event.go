@@ -41,6 +41,10 @@ type Batch interface {
 type Event struct {
 	Content beat.Event
 	Flags   EventFlags
+
+	// Attempts counts the failed attempts of an output to publish the event.
+	// It is kept when the event is retried.
+	Attempts int
 }
 
 // EventFlags provides additional flags/option types  for used with the outputs.
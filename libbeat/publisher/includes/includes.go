@@ -23,12 +23,16 @@ import (
 	_ "github.com/elastic/beats/libbeat/publisher/queue/spool"
 
 	// load supported output plugins
+	_ "github.com/elastic/beats/libbeat/outputs/amqp"
 	_ "github.com/elastic/beats/libbeat/outputs/console"
 	_ "github.com/elastic/beats/libbeat/outputs/elasticsearch"
 	_ "github.com/elastic/beats/libbeat/outputs/fileout"
+	_ "github.com/elastic/beats/libbeat/outputs/httpout"
 	_ "github.com/elastic/beats/libbeat/outputs/kafka"
 	_ "github.com/elastic/beats/libbeat/outputs/logstash"
 	_ "github.com/elastic/beats/libbeat/outputs/redis"
+	_ "github.com/elastic/beats/libbeat/outputs/statsd"
+	_ "github.com/elastic/beats/libbeat/outputs/syslog"
 
 	// load support output codec
 	_ "github.com/elastic/beats/libbeat/outputs/codec/format"
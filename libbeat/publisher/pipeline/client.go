@@ -22,6 +22,7 @@ import (
 
 	"github.com/elastic/beats/libbeat/beat"
 	"github.com/elastic/beats/libbeat/common/atomic"
+	"github.com/elastic/beats/libbeat/processors"
 	"github.com/elastic/beats/libbeat/publisher"
 	"github.com/elastic/beats/libbeat/publisher/queue"
 )
@@ -45,6 +46,10 @@ type client struct {
 	isOpen atomic.Bool
 
 	eventer beat.ClientEventer
+
+	// clientProcessor is the Processor of the client config. It is closed
+	// with the client, the global processors are closed by the pipeline.
+	clientProcessor beat.ProcessorList
 }
 
 func (c *client) PublishAll(events []beat.Event) {
@@ -160,6 +165,10 @@ func (c *client) Close() error {
 		}
 	}
 
+	if err := processors.CloseList(c.clientProcessor); err != nil {
+		log.Errorf("client: failed to close processors: %v", err)
+	}
+
 	c.onClosed()
 	return nil
 }
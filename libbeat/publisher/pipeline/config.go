@@ -31,9 +31,14 @@ type Config struct {
 	// Event processing configurations
 	common.EventMetadata `config:",inline"`      // Fields and tags to add to each event.
 	Processors           processors.PluginConfig `config:"processors"`
+	ProcessorsReload     *common.Config          `config:"processors_reload"` // Load processors from a watched file.
 
 	// Event queue
 	Queue common.ConfigNamespace `config:"queue"`
+
+	// Outputs configures multiple outputs, each with its own queue and an
+	// optional condition. Can not be used together with the output setting.
+	Outputs []*common.Config `config:"outputs"`
 }
 
 // validateClientConfig checks a ClientConfig can be used with (*Pipeline).ConnectWith.
@@ -0,0 +1,391 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pipeline
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/conditions"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/processors"
+)
+
+// Group publishes every event to a set of pipelines. Each pipeline has its own
+// queue and output. Events are ACKed to the beat once all pipelines have ACKed
+// them, so a blocked output blocks publishing to all outputs.
+//
+// The annotations and processors are applied once by the group, before the
+// events are copied to the pipelines. The pipelines only apply the condition
+// of their output.
+type Group struct {
+	beatInfo   beat.Info
+	logger     *logp.Logger
+	processors pipelineProcessors
+	pipelines  []*Pipeline
+
+	mutex     sync.Mutex
+	handler   *beat.PipelineACKHandler
+	connected bool
+}
+
+type groupClient struct {
+	mutex      sync.Mutex
+	logger     *logp.Logger
+	processors beat.Processor
+	// clientProcessor is the Processor of the client config, closed with
+	// the client.
+	clientProcessor beat.ProcessorList
+	clients         []beat.Client
+	acker           *groupACK
+}
+
+// groupDroppedEvent replaces the Private field of the events dropped by the
+// group processors. These events are still published to the pipelines, to be
+// dropped by their outputFilter, so every pipeline ACKs every event of a
+// client in order.
+type groupDroppedEvent struct{}
+
+// groupACK combines the ACKs of the pipeline clients. Each pipeline ACKs the
+// events of a client in publish order, including the events dropped by
+// processors, so an event is ACKed by all pipelines once each pipeline ACKed at
+// least as many events.
+type groupACK struct {
+	mutex   sync.Mutex
+	acked   []int         // per pipeline count of ACKed events not reported yet
+	pending []interface{} // Private field of the published events not reported yet
+
+	count   func(int)
+	events  func([]interface{})
+	last    func(interface{})
+	handler *beat.PipelineACKHandler
+}
+
+// outputFilter drops the events not matching the condition of an output, if
+// any, and the events dropped by the group processors.
+type outputFilter struct {
+	condition conditions.Condition
+}
+
+// NewGroup creates a Group publishing to pipelines. The annotations and
+// processors of settings are applied by the group, the pipelines must be
+// created without them. The Group takes ownership of the pipelines and closes
+// them on Close.
+func NewGroup(beatInfo beat.Info, settings Settings, pipelines []*Pipeline) *Group {
+	return &Group{
+		beatInfo:   beatInfo,
+		logger:     logp.NewLogger("publish"),
+		processors: makePipelineProcessors(settings.Annotations, settings.Processors, settings.Disabled),
+		pipelines:  pipelines,
+	}
+}
+
+// SetACKHandler sets a global ACK handler on all events published to the group.
+// SetACKHandler must be called before any connection is made.
+func (g *Group) SetACKHandler(handler beat.PipelineACKHandler) error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.connected {
+		return errors.New("can not set ack handler on already active pipeline")
+	}
+	if handler.ACKCount == nil && handler.ACKEvents == nil && handler.ACKLastEvents == nil {
+		g.handler = nil
+	} else {
+		g.handler = &handler
+	}
+	return nil
+}
+
+// Connect creates a new client with default settings.
+func (g *Group) Connect() (beat.Client, error) {
+	return g.ConnectWith(beat.ClientConfig{})
+}
+
+// ConnectWith creates a client connected to all pipelines of the group.
+func (g *Group) ConnectWith(cfg beat.ClientConfig) (beat.Client, error) {
+	if err := validateClientConfig(&cfg); err != nil {
+		return nil, err
+	}
+
+	g.mutex.Lock()
+	g.connected = true
+	handler := g.handler
+	g.mutex.Unlock()
+
+	// Events dropped if the queue is full can not be accounted for, so
+	// DropIfFull clients are not reported to the global ACK handler.
+	if cfg.PublishMode == beat.DropIfFull {
+		handler = nil
+	}
+
+	c := &groupClient{
+		logger:          g.logger,
+		processors:      newProcessorPipeline(g.beatInfo, g.processors, cfg),
+		clientProcessor: cfg.Processor,
+	}
+	if cfg.ACKCount != nil || cfg.ACKEvents != nil || cfg.ACKLastEvent != nil || handler != nil {
+		c.acker = &groupACK{
+			acked:   make([]int, len(g.pipelines)),
+			count:   cfg.ACKCount,
+			events:  cfg.ACKEvents,
+			last:    cfg.ACKLastEvent,
+			handler: handler,
+		}
+	}
+
+	for i, p := range g.pipelines {
+		// The events are processed by the group already.
+		pipelineCfg := cfg
+		pipelineCfg.SkipNormalization = true
+		pipelineCfg.Meta = nil
+		pipelineCfg.Fields = nil
+		pipelineCfg.EventMetadata = common.EventMetadata{}
+		pipelineCfg.DynamicFields = nil
+		pipelineCfg.Processor = nil
+		pipelineCfg.ACKCount = nil
+		pipelineCfg.ACKEvents = nil
+		pipelineCfg.ACKLastEvent = nil
+		if i > 0 {
+			// Report the client events only once.
+			pipelineCfg.Events = nil
+		}
+		if c.acker != nil {
+			i := i
+			pipelineCfg.ACKCount = func(n int) { c.acker.ack(i, n) }
+		}
+
+		client, err := p.ConnectWith(pipelineCfg)
+		if err != nil {
+			c.Close()
+			return nil, err
+		}
+		c.clients = append(c.clients, client)
+	}
+
+	return c, nil
+}
+
+// Close closes all pipelines of the group.
+// Note: clients must be closed before calling Close.
+func (g *Group) Close() error {
+	var err error
+	for _, p := range g.pipelines {
+		if closeErr := p.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+func (c *groupClient) Publish(e beat.Event) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.publish(e)
+}
+
+func (c *groupClient) PublishAll(events []beat.Event) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for _, e := range events {
+		c.publish(e)
+	}
+}
+
+func (c *groupClient) publish(e beat.Event) {
+	if c.acker != nil {
+		c.acker.add(e.Private)
+	}
+
+	event, err := c.processors.Run(&e)
+	if err != nil {
+		c.logger.Errorf("Failed to publish event: %v", err)
+	}
+	if event != nil {
+		e = *event
+	} else {
+		e.Private = groupDroppedEvent{}
+	}
+
+	// Outputs may modify events, every pipeline but the last gets a copy.
+	last := len(c.clients) - 1
+	for i, client := range c.clients {
+		if i == last {
+			client.Publish(e)
+		} else {
+			client.Publish(copyEvent(e))
+		}
+	}
+}
+
+// Close closes the clients concurrently, so WaitClose applies once and not per
+// pipeline.
+func (c *groupClient) Close() error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(c.clients))
+	for i, client := range c.clients {
+		wg.Add(1)
+		go func(i int, client beat.Client) {
+			defer wg.Done()
+			errs[i] = client.Close()
+		}(i, client)
+	}
+	wg.Wait()
+
+	if err := processors.CloseList(c.clientProcessor); err != nil {
+		c.logger.Errorf("Failed to close the client processors: %v", err)
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func copyEvent(e beat.Event) beat.Event {
+	if e.Fields != nil {
+		e.Fields = e.Fields.Clone()
+	}
+	if e.Meta != nil {
+		e.Meta = e.Meta.Clone()
+	}
+	return e
+}
+
+func (a *groupACK) add(private interface{}) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.pending = append(a.pending, private)
+}
+
+// ack records n events being ACKed by the pipeline i. The callbacks are run
+// while holding the lock, so the events are reported in order.
+func (a *groupACK) ack(i, n int) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	a.acked[i] += n
+	min := a.acked[0]
+	for _, acked := range a.acked[1:] {
+		if acked < min {
+			min = acked
+		}
+	}
+	if min == 0 {
+		return
+	}
+
+	for i := range a.acked {
+		a.acked[i] -= min
+	}
+	events := a.pending[:min]
+	a.pending = a.pending[min:]
+
+	lastEvent := events[len(events)-1]
+	switch {
+	case a.count != nil:
+		a.count(min)
+	case a.events != nil:
+		a.events(events)
+	case a.last != nil:
+		a.last(lastEvent)
+	}
+
+	if h := a.handler; h != nil {
+		switch {
+		case h.ACKCount != nil:
+			h.ACKCount(min)
+		case h.ACKEvents != nil:
+			h.ACKEvents(events)
+		case h.ACKLastEvents != nil:
+			h.ACKLastEvents([]interface{}{lastEvent})
+		}
+	}
+}
+
+func (f *outputFilter) Run(event *beat.Event) (*beat.Event, error) {
+	if _, dropped := event.Private.(groupDroppedEvent); dropped {
+		return nil, nil
+	}
+	if f.condition != nil && !f.condition.Check(event) {
+		return nil, nil
+	}
+	return event, nil
+}
+
+func (f *outputFilter) String() string {
+	if f.condition == nil {
+		return "output_condition=none"
+	}
+	return "output_condition=" + f.condition.String()
+}
+
+// groupOutputConfig is an entry of the outputs setting.
+type groupOutputConfig struct {
+	output common.ConfigNamespace
+	queue  common.ConfigNamespace
+	when   conditions.Condition
+}
+
+// readGroupOutputConfig reads an entry of the outputs setting. Besides the
+// output, entries can have a when condition and their own queue settings.
+func readGroupOutputConfig(cfg *common.Config) (groupOutputConfig, error) {
+	var config struct {
+		Queue common.ConfigNamespace `config:"queue"`
+		When  *conditions.Config     `config:"when"`
+	}
+	if err := cfg.Unpack(&config); err != nil {
+		return groupOutputConfig{}, err
+	}
+
+	c := groupOutputConfig{queue: config.Queue}
+	if config.When != nil {
+		var err error
+		if c.when, err = conditions.NewCondition(config.When); err != nil {
+			return groupOutputConfig{}, err
+		}
+	}
+
+	output := common.NewConfig()
+	for _, name := range cfg.GetFields() {
+		if name == "queue" || name == "when" {
+			continue
+		}
+		child, err := cfg.Child(name, -1)
+		if err != nil {
+			return groupOutputConfig{}, err
+		}
+		if err := output.SetChild(name, -1, child); err != nil {
+			return groupOutputConfig{}, err
+		}
+	}
+	if err := c.output.Unpack(output); err != nil {
+		return groupOutputConfig{}, err
+	}
+	if !c.output.IsSet() {
+		return groupOutputConfig{}, errors.New("no output configured in outputs entry")
+	}
+
+	return c, nil
+}
@@ -0,0 +1,296 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package pipeline
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/atomic"
+	"github.com/elastic/beats/libbeat/outputs"
+	"github.com/elastic/beats/libbeat/processors"
+	"github.com/elastic/beats/libbeat/publisher"
+	_ "github.com/elastic/beats/libbeat/publisher/queue/memqueue"
+)
+
+// recordingClients holds the clients of the test-recorder outputs by id.
+var recordingClients = struct {
+	sync.Mutex
+	clients map[string]*recordingClient
+}{clients: map[string]*recordingClient{}}
+
+// recordingClient records the events published to a test-recorder output.
+type recordingClient struct {
+	mutex  sync.Mutex
+	events []beat.Event
+}
+
+func init() {
+	outputs.RegisterType("test-recorder", func(_ beat.Info, _ outputs.Observer, cfg *common.Config) (outputs.Group, error) {
+		settings := struct {
+			ID string `config:"id"`
+		}{}
+		if err := cfg.Unpack(&settings); err != nil {
+			return outputs.Fail(err)
+		}
+
+		client := &recordingClient{}
+		recordingClients.Lock()
+		recordingClients.clients[settings.ID] = client
+		recordingClients.Unlock()
+		return outputs.Success(10, 0, client)
+	})
+}
+
+func (c *recordingClient) Publish(batch publisher.Batch) error {
+	c.mutex.Lock()
+	for _, event := range batch.Events() {
+		c.events = append(c.events, event.Content)
+	}
+	c.mutex.Unlock()
+	batch.ACK()
+	return nil
+}
+
+func (c *recordingClient) Close() error   { return nil }
+func (c *recordingClient) String() string { return "test-recorder" }
+
+func (c *recordingClient) Events() []beat.Event {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]beat.Event(nil), c.events...)
+}
+
+// countingProcessor counts the events it processes and drops the events
+// having the drop field.
+type countingProcessor struct {
+	count atomic.Int
+}
+
+func (p *countingProcessor) Run(event *beat.Event) (*beat.Event, error) {
+	p.count.Inc()
+	if _, drop := event.Fields["drop"]; drop {
+		return nil, nil
+	}
+	event.Fields["processed"] = true
+	return event, nil
+}
+
+func (p *countingProcessor) String() string { return "counting" }
+
+// closingProcessor counts how often it is closed.
+type closingProcessor struct {
+	countingProcessor
+	closed atomic.Int
+}
+
+func (p *closingProcessor) Close() error {
+	p.closed.Inc()
+	return nil
+}
+
+func TestGroupACK(t *testing.T) {
+	var (
+		counts []int
+		events [][]interface{}
+	)
+	acker := &groupACK{
+		acked: make([]int, 2),
+		count: func(n int) { counts = append(counts, n) },
+		handler: &beat.PipelineACKHandler{
+			ACKEvents: func(evts []interface{}) { events = append(events, evts) },
+		},
+	}
+	for i := 0; i < 4; i++ {
+		acker.add(i)
+	}
+
+	acker.ack(0, 3)
+	assert.Empty(t, counts, "events must be ACKed by all pipelines")
+
+	acker.ack(1, 2)
+	acker.ack(1, 2)
+	acker.ack(0, 1)
+	assert.Equal(t, []int{2, 1, 1}, counts)
+	assert.Equal(t, [][]interface{}{{0, 1}, {2}, {3}}, events)
+	assert.Empty(t, acker.pending)
+}
+
+func TestReadGroupOutputConfig(t *testing.T) {
+	cfg := common.MustNewConfigFrom(map[string]interface{}{
+		"elasticsearch.hosts":     []string{"localhost:9200"},
+		"when.equals.type":        "log",
+		"queue.mem.events":        1024,
+		"queue.mem.flush.timeout": "1s",
+	})
+
+	c, err := readGroupOutputConfig(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "elasticsearch", c.output.Name())
+	assert.Equal(t, "mem", c.queue.Name())
+	require.NotNil(t, c.when)
+
+	assert.True(t, c.when.Check(&beat.Event{Fields: common.MapStr{"type": "log"}}))
+	assert.False(t, c.when.Check(&beat.Event{Fields: common.MapStr{"type": "metric"}}))
+}
+
+func TestReadGroupOutputConfigErrors(t *testing.T) {
+	tests := map[string]map[string]interface{}{
+		"no output": {
+			"when.equals.type": "log",
+		},
+		"multiple outputs": {
+			"elasticsearch.hosts": []string{"localhost:9200"},
+			"logstash.hosts":      []string{"localhost:5044"},
+		},
+	}
+
+	for name, settings := range tests {
+		_, err := readGroupOutputConfig(common.MustNewConfigFrom(settings))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestOutputFilter(t *testing.T) {
+	c, err := readGroupOutputConfig(common.MustNewConfigFrom(map[string]interface{}{
+		"console.enabled":  true,
+		"when.equals.type": "log",
+	}))
+	require.NoError(t, err)
+
+	filter := &outputFilter{condition: c.when}
+	event, err := filter.Run(&beat.Event{Fields: common.MapStr{"type": "log"}})
+	assert.NoError(t, err)
+	assert.NotNil(t, event)
+
+	event, err = filter.Run(&beat.Event{Fields: common.MapStr{"type": "metric"}})
+	assert.NoError(t, err)
+	assert.Nil(t, event)
+	// without condition, only the events dropped by the group are filtered
+	filter = &outputFilter{}
+	event, err = filter.Run(&beat.Event{Fields: common.MapStr{"type": "metric"}})
+	assert.NoError(t, err)
+	assert.NotNil(t, event)
+
+	event, err = filter.Run(&beat.Event{Fields: common.MapStr{"type": "log"}, Private: groupDroppedEvent{}})
+	assert.NoError(t, err)
+	assert.Nil(t, event)
+}
+
+func TestGroupProcessorsRunOnce(t *testing.T) {
+	config := Config{
+		Queue: queueNamespace(t, "mem", map[string]interface{}{"events": 32, "flush.min_events": 0}),
+		Outputs: []*common.Config{
+			common.MustNewConfigFrom(map[string]interface{}{"test-recorder.id": "all"}),
+			common.MustNewConfigFrom(map[string]interface{}{
+				"test-recorder.id": "logs",
+				"when.equals.type": "log",
+			}),
+		},
+	}
+	counter := &countingProcessor{}
+	settings := Settings{
+		Processors: &processors.Processors{List: []processors.Processor{counter}},
+		Annotations: Annotations{
+			Event: common.EventMetadata{Tags: []string{"group"}},
+		},
+	}
+
+	group, err := loadGroup(beat.Info{}, Monitors{}, config, settings)
+	require.NoError(t, err)
+	defer group.Close()
+
+	acked := atomic.MakeInt(0)
+	client, err := group.ConnectWith(beat.ClientConfig{
+		ACKCount: func(n int) { acked.Add(n) },
+	})
+	require.NoError(t, err)
+
+	client.PublishAll([]beat.Event{
+		{Timestamp: time.Now(), Fields: common.MapStr{"type": "log"}},
+		{Timestamp: time.Now(), Fields: common.MapStr{"type": "metric"}},
+		{Timestamp: time.Now(), Fields: common.MapStr{"type": "log", "drop": true}},
+	})
+
+	for start := time.Now(); acked.Load() < 3; time.Sleep(10 * time.Millisecond) {
+		if time.Since(start) > 5*time.Second {
+			t.Fatalf("events not ACKed, got %v ACKs", acked.Load())
+		}
+	}
+	require.NoError(t, client.Close())
+
+	// the processors run once per event, not once per output
+	assert.Equal(t, 3, counter.count.Load())
+
+	recordingClients.Lock()
+	all, logs := recordingClients.clients["all"], recordingClients.clients["logs"]
+	recordingClients.Unlock()
+
+	require.Len(t, all.Events(), 2)
+	require.Len(t, logs.Events(), 1)
+	for _, event := range append(all.Events(), logs.Events()...) {
+		assert.Equal(t, true, event.Fields["processed"])
+		assert.Equal(t, []string{"group"}, event.Fields["tags"])
+	}
+	assert.Equal(t, "log", logs.Events()[0].Fields["type"])
+}
+
+func TestGroupClientClosesProcessors(t *testing.T) {
+	config := Config{
+		Queue: queueNamespace(t, "mem", map[string]interface{}{"events": 32, "flush.min_events": 0}),
+		Outputs: []*common.Config{
+			common.MustNewConfigFrom(map[string]interface{}{"test-recorder.id": "first"}),
+			common.MustNewConfigFrom(map[string]interface{}{"test-recorder.id": "second"}),
+		},
+	}
+	global := &closingProcessor{}
+	settings := Settings{
+		Processors: &processors.Processors{List: []processors.Processor{global}},
+	}
+
+	group, err := loadGroup(beat.Info{}, Monitors{}, config, settings)
+	require.NoError(t, err)
+	defer group.Close()
+
+	local := &closingProcessor{}
+	client, err := group.ConnectWith(beat.ClientConfig{
+		Processor: &processors.Processors{List: []processors.Processor{local}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, client.Close())
+
+	// the client processors are closed once, the global processors are
+	// left to the pipeline
+	assert.Equal(t, 1, local.closed.Load())
+	assert.Equal(t, 0, global.closed.Load())
+}
+
+func queueNamespace(t *testing.T, name string, settings map[string]interface{}) common.ConfigNamespace {
+	var ns common.ConfigNamespace
+	cfg := common.MustNewConfigFrom(map[string]interface{}{name: settings})
+	require.NoError(t, cfg.Unpack(&ns))
+	return ns
+}
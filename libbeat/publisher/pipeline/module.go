@@ -21,6 +21,8 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/elastic/beats/libbeat/beat"
 	"github.com/elastic/beats/libbeat/common"
@@ -50,6 +52,13 @@ func init() {
 	flag.BoolVar(&publishDisabled, "N", false, "Disable actual publishing for testing")
 }
 
+// Publisher is the publisher pipeline created by Load. It is a Pipeline, or a
+// Group of pipelines if multiple outputs are configured.
+type Publisher interface {
+	beat.Pipeline
+	Close() error
+}
+
 // Load uses a Config object to create a new complete Pipeline instance with
 // configured queue and outputs.
 func Load(
@@ -57,7 +66,7 @@ func Load(
 	monitors Monitors,
 	config Config,
 	outcfg common.ConfigNamespace,
-) (*Pipeline, error) {
+) (Publisher, error) {
 	log := monitors.Logger
 	if log == nil {
 		log = logp.L()
@@ -67,9 +76,9 @@ func Load(
 		log.Info("Dry run mode. All output types except the file based one are disabled.")
 	}
 
-	processors, err := processors.New(config.Processors)
+	processors, watcher, err := LoadProcessors(config)
 	if err != nil {
-		return nil, fmt.Errorf("error initializing processors: %v", err)
+		return nil, err
 	}
 
 	name := beatInfo.Name
@@ -93,12 +102,34 @@ func Load(
 		},
 	}
 
+	if len(config.Outputs) > 0 && !publishDisabled {
+		if outcfg.IsSet() {
+			return nil, errors.New("output and outputs can not be configured together")
+		}
+
+		group, err := loadGroup(beatInfo, monitors, config, settings)
+		if err != nil {
+			return nil, err
+		}
+
+		if watcher != nil {
+			group.pipelines[0].processorsWatcher = watcher
+			watcher.Start()
+		} else {
+			group.pipelines[0].globalProcessors = processors
+		}
+
+		log.Info("Beat name: %s", name)
+		return group, nil
+	}
+
 	queueBuilder, err := createQueueBuilder(config.Queue, monitors)
 	if err != nil {
 		return nil, err
 	}
 
-	out, err := loadOutput(beatInfo, monitors, outcfg)
+	loader := newOutputLoader(beatInfo, monitors)
+	out, err := loader.load(outcfg)
 	if err != nil {
 		return nil, err
 	}
@@ -107,17 +138,147 @@ func Load(
 	if err != nil {
 		return nil, err
 	}
+	p.outputLoader = loader
+
+	if watcher != nil {
+		p.processorsWatcher = watcher
+		watcher.Start()
+	} else {
+		p.globalProcessors = processors
+	}
 
 	log.Info("Beat name: %s", name)
 	return p, err
 }
 
+// loadGroup creates a pipeline per entry of the outputs setting. The metrics
+// of each pipeline and output are reported under outputs.<index>.
+func loadGroup(
+	beatInfo beat.Info,
+	monitors Monitors,
+	config Config,
+	settings Settings,
+) (*Group, error) {
+	var metrics *monitoring.Registry
+	if monitors.Metrics != nil {
+		metrics = monitors.Metrics.NewRegistry("outputs")
+	}
+
+	var (
+		pipelines []*Pipeline
+		names     []string
+	)
+	fail := func(err error) (*Group, error) {
+		for _, p := range pipelines {
+			p.Close()
+		}
+		return nil, err
+	}
+
+	for i, cfg := range config.Outputs {
+		outConfig, err := readGroupOutputConfig(cfg)
+		if err != nil {
+			return fail(fmt.Errorf("error reading outputs entry %d: %v", i, err))
+		}
+
+		outMonitors := Monitors{Logger: monitors.Logger}
+		if metrics != nil {
+			outMonitors.Metrics = metrics.NewRegistry(strconv.Itoa(i))
+		}
+
+		queueConfig := config.Queue
+		if outConfig.queue.IsSet() {
+			queueConfig = outConfig.queue
+		}
+		queueBuilder, err := createQueueBuilder(queueConfig, outMonitors)
+		if err != nil {
+			return fail(err)
+		}
+
+		out, err := loadOutput(beatInfo, outMonitors, outConfig.output)
+		if err != nil {
+			return fail(err)
+		}
+
+		// The group applies the annotations and processors, the pipeline
+		// only filters the events of its output.
+		outSettings := settings
+		outSettings.Annotations = Annotations{}
+		outSettings.Processors = &processors.Processors{
+			List: []processors.Processor{&outputFilter{condition: outConfig.when}},
+		}
+
+		p, err := New(beatInfo, outMonitors.Metrics, queueBuilder, out, outSettings)
+		if err != nil {
+			return fail(err)
+		}
+		pipelines = append(pipelines, p)
+		names = append(names, outConfig.output.Name())
+	}
+
+	if monitors.Telemetry != nil {
+		telemetry := monitors.Telemetry.NewRegistry("output")
+		monitoring.NewString(telemetry, "name").Set(strings.Join(names, ","))
+	}
+
+	return NewGroup(beatInfo, settings, pipelines), nil
+}
+
+// LoadProcessors creates the global processors. If processors_reload is
+// enabled, the processors are loaded from a file and a watcher reloading them
+// on changes is returned. The watcher is not started.
+func LoadProcessors(config Config) (*processors.Processors, *processors.Watcher, error) {
+	if !config.ProcessorsReload.Enabled() {
+		procs, err := processors.New(config.Processors)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error initializing processors: %v", err)
+		}
+		return procs, nil, nil
+	}
+
+	if len(config.Processors) > 0 {
+		return nil, nil, errors.New("processors can not be configured when processors_reload is enabled, add them to the processors file instead")
+	}
+
+	reloadConfig := processors.DefaultReloadConfig
+	if err := config.ProcessorsReload.Unpack(&reloadConfig); err != nil {
+		return nil, nil, fmt.Errorf("error reading processors_reload settings: %v", err)
+	}
+
+	watcher, err := processors.NewWatcher(reloadConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error initializing processors: %v", err)
+	}
+	procs := &processors.Processors{List: []processors.Processor{watcher.Processor()}}
+	return procs, watcher, nil
+}
+
 func loadOutput(
 	beatInfo beat.Info,
 	monitors Monitors,
 	outcfg common.ConfigNamespace,
 ) (outputs.Group, error) {
-	log := monitors.Logger
+	return newOutputLoader(beatInfo, monitors).load(outcfg)
+}
+
+// outputLoader creates the outputs of a pipeline. The output metrics are
+// registered once and shared by all outputs loaded, so the output can be
+// reloaded.
+type outputLoader struct {
+	beatInfo beat.Info
+	monitors Monitors
+
+	stats     outputs.Observer
+	typ       *monitoring.String
+	telemetry *monitoring.String
+}
+
+func newOutputLoader(beatInfo beat.Info, monitors Monitors) *outputLoader {
+	return &outputLoader{beatInfo: beatInfo, monitors: monitors}
+}
+
+func (l *outputLoader) load(outcfg common.ConfigNamespace) (outputs.Group, error) {
+	log := l.monitors.Logger
 	if log == nil {
 		log = logp.L()
 	}
@@ -132,28 +293,26 @@ func loadOutput(
 		return outputs.Fail(errors.New(msg))
 	}
 
-	// TODO: add support to unload/reassign outStats on output reloading
-
-	var (
-		metrics  *monitoring.Registry
-		outStats outputs.Observer
-	)
-	if monitors.Metrics != nil {
-		metrics = monitors.Metrics.NewRegistry("output")
-		outStats = outputs.NewStats(metrics)
+	if l.monitors.Metrics != nil && l.typ == nil {
+		metrics := l.monitors.Metrics.NewRegistry("output")
+		l.stats = outputs.NewStats(metrics)
+		l.typ = monitoring.NewString(metrics, "type")
 	}
 
-	out, err := outputs.Load(beatInfo, outStats, outcfg.Name(), outcfg.Config())
+	out, err := outputs.Load(l.beatInfo, l.stats, outcfg.Name(), outcfg.Config())
 	if err != nil {
 		return outputs.Fail(err)
 	}
 
-	if metrics != nil {
-		monitoring.NewString(metrics, "type").Set(outcfg.Name())
+	if l.typ != nil {
+		l.typ.Set(outcfg.Name())
 	}
-	if monitors.Telemetry != nil {
-		telemetry := monitors.Telemetry.NewRegistry("output")
-		monitoring.NewString(telemetry, "name").Set(outcfg.Name())
+	if l.monitors.Telemetry != nil {
+		if l.telemetry == nil {
+			telemetry := l.monitors.Telemetry.NewRegistry("output")
+			l.telemetry = monitoring.NewString(telemetry, "name")
+		}
+		l.telemetry.Set(outcfg.Name())
 	}
 
 	return out, nil
@@ -0,0 +1,94 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/monitoring"
+	"github.com/elastic/beats/libbeat/outputs"
+)
+
+func init() {
+	for _, name := range []string{"test-a", "test-b"} {
+		outputs.RegisterType(name, func(_ beat.Info, _ outputs.Observer, cfg *common.Config) (outputs.Group, error) {
+			settings := struct {
+				BatchSize int `config:"bulk_max_size" validate:"min=1"`
+			}{BatchSize: 1}
+			if err := cfg.Unpack(&settings); err != nil {
+				return outputs.Fail(err)
+			}
+			return outputs.Group{BatchSize: settings.BatchSize}, nil
+		})
+	}
+}
+
+func TestOutputLoaderReload(t *testing.T) {
+	metrics := monitoring.NewRegistry()
+	telemetry := monitoring.NewRegistry()
+	loader := newOutputLoader(beat.Info{}, Monitors{Metrics: metrics, Telemetry: telemetry})
+
+	out, err := loader.load(outputNamespace(t, "test-a", 10))
+	require.NoError(t, err)
+	assert.Equal(t, 10, out.BatchSize)
+
+	// metrics are registered once and reused when loading a new output
+	out, err = loader.load(outputNamespace(t, "test-b", 20))
+	require.NoError(t, err)
+	assert.Equal(t, 20, out.BatchSize)
+
+	snapshot := monitoring.CollectFlatSnapshot(metrics, monitoring.Full, false)
+	assert.Equal(t, "test-b", snapshot.Strings["output.type"])
+	snapshot = monitoring.CollectFlatSnapshot(telemetry, monitoring.Full, false)
+	assert.Equal(t, "test-b", snapshot.Strings["output.name"])
+
+	_, err = loader.load(outputNamespace(t, "test-a", 0))
+	assert.Error(t, err)
+	snapshot = monitoring.CollectFlatSnapshot(metrics, monitoring.Full, false)
+	assert.Equal(t, "test-b", snapshot.Strings["output.type"])
+}
+
+func outputNamespace(t *testing.T, name string, batchSize int) common.ConfigNamespace {
+	var ns common.ConfigNamespace
+	cfg := common.MustNewConfigFrom(map[string]interface{}{
+		name: map[string]interface{}{"bulk_max_size": batchSize},
+	})
+	require.NoError(t, cfg.Unpack(&ns))
+	return ns
+}
+
+func TestLoadProcessorsReloadConflict(t *testing.T) {
+	var config Config
+	err := common.MustNewConfigFrom(map[string]interface{}{
+		"processors":                []map[string]interface{}{{"drop_event": nil}},
+		"processors_reload.enabled": true,
+	}).Unpack(&config)
+	require.NoError(t, err)
+
+	_, _, err = LoadProcessors(config)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "processors_reload is enabled")
+	}
+}
@@ -73,6 +73,17 @@ type Pipeline struct {
 	eventSema  *sema
 
 	processors pipelineProcessors
+
+	// processorsWatcher reloads the global processors, if enabled.
+	processorsWatcher *processors.Watcher
+
+	// globalProcessors are closed with the pipeline if they are not
+	// reloaded. The watcher closes reloaded processors.
+	globalProcessors *processors.Processors
+
+	// outputLoader creates the output on reload. It is only set if the
+	// pipeline has been created by Load.
+	outputLoader *outputLoader
 }
 
 type pipelineProcessors struct {
@@ -285,10 +296,32 @@ func (p *Pipeline) Close() error {
 		log.Error("pipeline queue shutdown error: ", err)
 	}
 
+	if p.processorsWatcher != nil {
+		p.processorsWatcher.Stop()
+	}
+	if err := p.globalProcessors.Close(); err != nil {
+		log.Error("processors shutdown error: ", err)
+	}
+
 	p.observer.cleanup()
 	return nil
 }
 
+// LoadOutput creates a new output from outcfg, sharing the output metrics
+// of the pipeline. The output is not used before being passed to SetOutput.
+func (p *Pipeline) LoadOutput(outcfg common.ConfigNamespace) (outputs.Group, error) {
+	if p.outputLoader == nil {
+		return outputs.Group{}, errors.New("pipeline does not support reloading the output")
+	}
+	return p.outputLoader.load(outcfg)
+}
+
+// SetOutput replaces the output of the pipeline. Events not yet ACKed by the
+// old output are retried on the new output.
+func (p *Pipeline) SetOutput(out outputs.Group) {
+	p.output.Set(out)
+}
+
 // Connect creates a new client with default settings
 func (p *Pipeline) Connect() (beat.Client, error) {
 	return p.ConnectWith(beat.ClientConfig{})
@@ -360,15 +393,16 @@ func (p *Pipeline) ConnectWith(cfg beat.ClientConfig) (beat.Client, error) {
 
 	producer := p.queue.Producer(producerCfg)
 	client := &client{
-		pipeline:     p,
-		isOpen:       atomic.MakeBool(true),
-		eventer:      cfg.Events,
-		processors:   processors,
-		producer:     producer,
-		acker:        acker,
-		eventFlags:   eventFlags,
-		canDrop:      canDrop,
-		reportEvents: reportEvents,
+		pipeline:        p,
+		isOpen:          atomic.MakeBool(true),
+		eventer:         cfg.Events,
+		processors:      processors,
+		clientProcessor: cfg.Processor,
+		producer:        producer,
+		acker:           acker,
+		eventFlags:      eventFlags,
+		canDrop:         canDrop,
+		reportEvents:    reportEvents,
 	}
 
 	p.observer.clientConnected()
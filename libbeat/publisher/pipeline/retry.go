@@ -149,6 +149,9 @@ func (r *retryer) loop() {
 				countRetry = len(batch.events)
 				countDropped = countFailed - countRetry
 				r.observer.eventsDropped(countDropped)
+				if countDropped > 0 {
+					log.Warnf("Dropped %v events after exceeding the maximum number of retries", countDropped)
+				}
 			}
 
 			if len(batch.events) == 0 {
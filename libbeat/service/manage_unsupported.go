@@ -0,0 +1,39 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !windows
+
+package service
+
+import (
+	"errors"
+	"time"
+)
+
+var errUnsupported = errors.New("service management is only supported on Windows")
+
+// Install is only supported on Windows.
+func Install(options ServiceOptions) error { return errUnsupported }
+
+// Uninstall is only supported on Windows.
+func Uninstall(name string, timeout time.Duration) error { return errUnsupported }
+
+// Start is only supported on Windows.
+func Start(name string) error { return errUnsupported }
+
+// Stop is only supported on Windows.
+func Stop(name string, timeout time.Duration) error { return errUnsupported }
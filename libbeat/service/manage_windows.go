@@ -0,0 +1,189 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package service
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+// Install registers the running executable as a Windows service that is
+// started automatically.
+func Install(options ServiceOptions) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to get the path of the executable: %v", err)
+	}
+
+	cmdline := []string{windows.EscapeArg(exe)}
+	for _, arg := range options.Args {
+		cmdline = append(cmdline, windows.EscapeArg(arg))
+	}
+
+	name, err := windows.UTF16PtrFromString(options.Name)
+	if err != nil {
+		return err
+	}
+	displayName, err := windows.UTF16PtrFromString(options.DisplayName)
+	if err != nil {
+		return err
+	}
+	path, err := windows.UTF16PtrFromString(strings.Join(cmdline, " "))
+	if err != nil {
+		return err
+	}
+
+	m, err := openManager(windows.SC_MANAGER_CREATE_SERVICE)
+	if err != nil {
+		return err
+	}
+	defer windows.CloseServiceHandle(m)
+
+	if s, err := openService(m, options.Name, windows.SERVICE_QUERY_STATUS); err == nil {
+		windows.CloseServiceHandle(s)
+		return fmt.Errorf("service %s already exists", options.Name)
+	}
+
+	s, err := windows.CreateService(m, name, displayName,
+		windows.SERVICE_ALL_ACCESS,
+		windows.SERVICE_WIN32_OWN_PROCESS,
+		windows.SERVICE_AUTO_START,
+		windows.SERVICE_ERROR_NORMAL,
+		path, nil, nil, nil, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create service %s: %v", options.Name, err)
+	}
+	return windows.CloseServiceHandle(s)
+}
+
+// Uninstall stops the service if it is running and removes it.
+func Uninstall(name string, timeout time.Duration) error {
+	m, err := openManager(windows.SC_MANAGER_CONNECT)
+	if err != nil {
+		return err
+	}
+	defer windows.CloseServiceHandle(m)
+
+	s, err := openService(m, name, deleteAccess|windows.SERVICE_STOP|windows.SERVICE_QUERY_STATUS)
+	if err != nil {
+		return err
+	}
+	defer windows.CloseServiceHandle(s)
+
+	if err := stopService(s, name, timeout); err != nil {
+		return err
+	}
+	if err := windows.DeleteService(s); err != nil {
+		return fmt.Errorf("failed to delete service %s: %v", name, err)
+	}
+	return nil
+}
+
+// Start starts the service.
+func Start(name string) error {
+	m, err := openManager(windows.SC_MANAGER_CONNECT)
+	if err != nil {
+		return err
+	}
+	defer windows.CloseServiceHandle(m)
+
+	s, err := openService(m, name, windows.SERVICE_START)
+	if err != nil {
+		return err
+	}
+	defer windows.CloseServiceHandle(s)
+
+	if err := windows.StartService(s, 0, nil); err != nil {
+		return fmt.Errorf("failed to start service %s: %v", name, err)
+	}
+	return nil
+}
+
+// Stop requests the service to stop and waits until it is stopped, or until
+// timeout expires.
+func Stop(name string, timeout time.Duration) error {
+	m, err := openManager(windows.SC_MANAGER_CONNECT)
+	if err != nil {
+		return err
+	}
+	defer windows.CloseServiceHandle(m)
+
+	s, err := openService(m, name, windows.SERVICE_STOP|windows.SERVICE_QUERY_STATUS)
+	if err != nil {
+		return err
+	}
+	defer windows.CloseServiceHandle(s)
+
+	return stopService(s, name, timeout)
+}
+
+// deleteAccess is the DELETE standard access right required to remove a
+// service.
+const deleteAccess = 0x10000
+
+func openManager(access uint32) (windows.Handle, error) {
+	m, err := windows.OpenSCManager(nil, nil, access)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to the service manager: %v", err)
+	}
+	return m, nil
+}
+
+func openService(m windows.Handle, name string, access uint32) (windows.Handle, error) {
+	serviceName, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return 0, err
+	}
+	s, err := windows.OpenService(m, serviceName, access)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open service %s: %v", name, err)
+	}
+	return s, nil
+}
+
+func stopService(s windows.Handle, name string, timeout time.Duration) error {
+	var status windows.SERVICE_STATUS
+	if err := windows.QueryServiceStatus(s, &status); err != nil {
+		return fmt.Errorf("failed to query the status of service %s: %v", name, err)
+	}
+	if status.CurrentState == windows.SERVICE_STOPPED {
+		return nil
+	}
+
+	if status.CurrentState != windows.SERVICE_STOP_PENDING {
+		if err := windows.ControlService(s, windows.SERVICE_CONTROL_STOP, &status); err != nil {
+			return fmt.Errorf("failed to stop service %s: %v", name, err)
+		}
+	}
+
+	deadline := time.Now().Add(timeout)
+	for status.CurrentState != windows.SERVICE_STOPPED {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timeout waiting for service %s to stop", name)
+		}
+		time.Sleep(300 * time.Millisecond)
+		if err := windows.QueryServiceStatus(s, &status); err != nil {
+			return fmt.Errorf("failed to query the status of service %s: %v", name, err)
+		}
+	}
+	return nil
+}
@@ -42,6 +42,10 @@ import (
 // the service shut downs gracefully.
 func HandleSignals(stopFunction func(), cancel context.CancelFunc) {
 	var callback sync.Once
+	stop := func() {
+		notifySystemdStopping()
+		stopFunction()
+	}
 
 	// On ^C or SIGTERM, gracefully stop the sniffer
 	sigc := make(chan os.Signal, 1)
@@ -50,16 +54,57 @@ func HandleSignals(stopFunction func(), cancel context.CancelFunc) {
 		<-sigc
 		logp.Debug("service", "Received sigterm/sigint, stopping")
 		cancel()
-		callback.Do(stopFunction)
+		callback.Do(stop)
 	}()
 
 	// Handle the Windows service events
 	go ProcessWindowsControlEvents(func() {
 		logp.Debug("service", "Received svc stop/shutdown request")
-		callback.Do(stopFunction)
+		callback.Do(stop)
 	})
 }
 
+// HandleReload manages the OS signals and Windows service requests that ask
+// the service/daemon to reload its configuration. The reloadFunction is
+// called on every SIGHUP or Windows service parameter change request.
+func HandleReload(reloadFunction func()) {
+	reloadHandler.Lock()
+	reloadHandler.fn = reloadFunction
+	reloadHandler.Unlock()
+
+	sigc := make(chan os.Signal, 1)
+	notifyReload(sigc)
+	go func() {
+		for range sigc {
+			logp.Debug("service", "Received sighup, reloading config")
+			requestReload()
+		}
+	}()
+}
+
+var reloadHandler struct {
+	sync.Mutex
+	fn func()
+}
+
+// requestReload invokes the function registered by HandleReload.
+func requestReload() {
+	reloadHandler.Lock()
+	fn := reloadHandler.fn
+	reloadHandler.Unlock()
+
+	if fn != nil {
+		fn()
+	}
+}
+
+// ServiceOptions configures the service created by Install.
+type ServiceOptions struct {
+	Name        string   // Service name, used to start and stop the service.
+	DisplayName string   // Name shown in the service manager.
+	Args        []string // Command line arguments of the beat.
+}
+
 // cmdline flags
 var memprofile, cpuprofile, httpprof *string
 var cpuOut *os.File
@@ -148,6 +193,8 @@ func Cleanup() {
 
 		debugMemStats()
 	}
+
+	notifyStopped()
 }
 
 func debugMemStats() {
@@ -19,6 +19,20 @@
 
 package service
 
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
 // ProcessWindowsControlEvents is not used on non-windows platforms.
 func ProcessWindowsControlEvents(stopCallback func()) {
 }
+
+// notifyStopped does nothing on non-windows platforms.
+func notifyStopped() {}
+
+// notifyReload relays SIGHUP to c.
+func notifyReload(c chan<- os.Signal) {
+	signal.Notify(c, syscall.SIGHUP)
+}
@@ -19,6 +19,7 @@ package service
 
 import (
 	"os"
+	"sync"
 	"syscall"
 	"time"
 
@@ -28,33 +29,94 @@ import (
 	"github.com/elastic/beats/libbeat/logp"
 )
 
-type beatService struct{}
+// stopTimeout is how long the service waits for the beat to stop after a
+// stop request before reporting the stopped state anyway.
+const stopTimeout = 30 * time.Second
+
+var (
+	// beatStopped is closed by Cleanup when the beat finished running.
+	beatStopped     = make(chan struct{})
+	beatStoppedOnce sync.Once
+
+	// serviceDone is closed when the service handler returned, after the
+	// stopped state was reported to the service manager. It is nil if the
+	// handler was not started.
+	serviceDone struct {
+		sync.Mutex
+		ch chan struct{}
+	}
+)
+
+type beatService struct {
+	stop func()
+}
 
 // Execute runs the beat service with the arguments and manages changes that
 // occur in the environment or runtime that may affect the beat.
 func (m *beatService) Execute(args []string, r <-chan svc.ChangeRequest, changes chan<- svc.Status) (ssec bool, errno uint32) {
-	const cmdsAccepted = svc.AcceptStop | svc.AcceptShutdown
+	const cmdsAccepted = svc.AcceptStop | svc.AcceptShutdown | svc.AcceptParamChange
 	changes <- svc.Status{State: svc.StartPending}
 	changes <- svc.Status{State: svc.Running, Accepts: cmdsAccepted}
 
 loop:
-	for c := range r {
-		switch c.Cmd {
-		case svc.Interrogate:
-			changes <- c.CurrentStatus
-			// Testing deadlock from https://code.google.com/p/winsvc/issues/detail?id=4
-			time.Sleep(100 * time.Millisecond)
-			changes <- c.CurrentStatus
-		case svc.Stop, svc.Shutdown:
+	for {
+		select {
+		case <-beatStopped:
+			logp.Debug("service", "Beat stopped, stopping the service")
 			break loop
-		default:
-			logp.Err("Unexpected control request: $%d. Ignored.", c)
+		case c := <-r:
+			switch c.Cmd {
+			case svc.Interrogate:
+				changes <- c.CurrentStatus
+				// Testing deadlock from https://code.google.com/p/winsvc/issues/detail?id=4
+				time.Sleep(100 * time.Millisecond)
+				changes <- c.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				// report the stop as pending until the beat finished running
+				changes <- svc.Status{State: svc.StopPending, WaitHint: uint32(stopTimeout / time.Millisecond)}
+				m.stop()
+				select {
+				case <-beatStopped:
+				case <-time.After(stopTimeout):
+					logp.Warn("Timeout waiting for the beat to stop")
+				}
+				break loop
+			case svc.ParamChange:
+				logp.Debug("service", "Received svc param change request, reloading config")
+				go requestReload()
+			default:
+				logp.Err("Unexpected control request: $%d. Ignored.", c)
+			}
 		}
 	}
 	changes <- svc.Status{State: svc.StopPending}
 	return
 }
 
+// notifyStopped signals the service handler that the beat finished running,
+// and waits for the handler to report the stopped state to the service
+// manager.
+func notifyStopped() {
+	beatStoppedOnce.Do(func() { close(beatStopped) })
+
+	serviceDone.Lock()
+	done := serviceDone.ch
+	serviceDone.Unlock()
+	if done == nil {
+		return
+	}
+
+	select {
+	case <-done:
+	case <-time.After(stopTimeout):
+	}
+}
+
+// notifyReload does nothing on Windows. Reloading is requested through the
+// service control manager instead.
+func notifyReload(c chan<- os.Signal) {
+}
+
 // couldNotConnect is the errno for ERROR_FAILED_SERVICE_CONTROLLER_CONNECT.
 const couldNotConnect syscall.Errno = 1063
 
@@ -64,6 +126,12 @@ const couldNotConnect syscall.Errno = 1063
 // stopCallback function is called when the Stop/Shutdown
 // request is received.
 func ProcessWindowsControlEvents(stopCallback func()) {
+	done := make(chan struct{})
+	defer close(done)
+	serviceDone.Lock()
+	serviceDone.ch = done
+	serviceDone.Unlock()
+
 	isInteractive, err := svc.IsAnInteractiveSession()
 	if err != nil {
 		logp.Err("IsAnInteractiveSession: %v", err)
@@ -76,10 +144,8 @@ func ProcessWindowsControlEvents(stopCallback func()) {
 		run = debug.Run
 	}
 
-	err = run(os.Args[0], &beatService{})
-
+	err = run(os.Args[0], &beatService{stop: stopCallback})
 	if err == nil {
-		stopCallback()
 		return
 	}
 
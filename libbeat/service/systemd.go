@@ -0,0 +1,135 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package service
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+// The systemd notification protocol is implemented natively: the state is
+// sent as a datagram to the unix socket given by NOTIFY_SOCKET. All functions
+// do nothing if the process is not started by systemd with Type=notify or
+// WatchdogSec set.
+
+var readyOnce sync.Once
+
+var watchdogChecks struct {
+	sync.Mutex
+	checks []func() error
+}
+
+// NotifyReady tells systemd that the beat finished its startup. Only the
+// first call sends the notification.
+func NotifyReady() {
+	readyOnce.Do(func() {
+		if err := sdNotify("READY=1"); err != nil {
+			logp.Warn("Failed to notify systemd: %v", err)
+		}
+	})
+}
+
+// notifySystemdStopping tells systemd that the beat is shutting down.
+func notifySystemdStopping() {
+	if err := sdNotify("STOPPING=1"); err != nil {
+		logp.Warn("Failed to notify systemd: %v", err)
+	}
+}
+
+// WatchdogTimeout returns the watchdog timeout configured by systemd, or 0
+// if the watchdog is not enabled for the process.
+func WatchdogTimeout() time.Duration {
+	usec, err := strconv.ParseInt(os.Getenv("WATCHDOG_USEC"), 10, 64)
+	if err != nil || usec <= 0 {
+		return 0
+	}
+	if pid := os.Getenv("WATCHDOG_PID"); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return 0
+	}
+	return time.Duration(usec) * time.Microsecond
+}
+
+// AddWatchdogCheck registers a function checking that the beat is not hung.
+// The systemd watchdog is only kept alive while all checks return nil, so
+// systemd restarts the beat if a check keeps failing.
+func AddWatchdogCheck(check func() error) {
+	watchdogChecks.Lock()
+	defer watchdogChecks.Unlock()
+	watchdogChecks.checks = append(watchdogChecks.checks, check)
+}
+
+// StartWatchdog keeps the systemd watchdog alive by notifying it at half the
+// watchdog timeout. It does nothing if the watchdog is not enabled.
+func StartWatchdog() {
+	timeout := WatchdogTimeout()
+	if timeout == 0 {
+		return
+	}
+
+	logp.Info("Systemd watchdog enabled with a timeout of %v", timeout)
+	go func() {
+		ticker := time.NewTicker(timeout / 2)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := checkWatchdog(); err != nil {
+				logp.Err("Not notifying the systemd watchdog: %v", err)
+				continue
+			}
+			if err := sdNotify("WATCHDOG=1"); err != nil {
+				logp.Warn("Failed to notify the systemd watchdog: %v", err)
+			}
+		}
+	}()
+}
+
+func checkWatchdog() error {
+	watchdogChecks.Lock()
+	checks := watchdogChecks.checks
+	watchdogChecks.Unlock()
+
+	for _, check := range checks {
+		if err := check(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sdNotify sends state to the systemd notification socket.
+func sdNotify(state string) error {
+	socket := os.Getenv("NOTIFY_SOCKET")
+	if socket == "" {
+		return nil
+	}
+
+	// a leading @ for abstract sockets is handled by the net package
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return fmt.Errorf("failed to connect to %s: %v", socket, err)
+	}
+	defer conn.Close()
+
+	_, err = conn.Write([]byte(state))
+	return err
+}
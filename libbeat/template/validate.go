@@ -0,0 +1,251 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package template
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+// FieldError reports a field of an event that doesn't match the fields
+// definition.
+type FieldError struct {
+	Field  string
+	Reason string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("field %v: %v", e.Field, e.Reason)
+}
+
+// Validator checks events against the fields definition of a Beat. It reports
+// fields that are not defined, which are mapped dynamically, and values that
+// don't match the type of their field.
+type Validator struct {
+	fields map[string]*common.Field
+}
+
+// NewValidator creates a Validator from the contents of a fields.yml file.
+func NewValidator(data []byte) (*Validator, error) {
+	fields, err := loadYamlByte(data)
+	if err != nil {
+		return nil, err
+	}
+
+	v := &Validator{fields: map[string]*common.Field{}}
+	v.addFields("", fields)
+	return v, nil
+}
+
+func (v *Validator) addFields(prefix string, fields common.Fields) {
+	for i := range fields {
+		field := &fields[i]
+		key := field.Name
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		// a field redefined in a later file takes precedence, as in the template
+		v.fields[key] = field
+		if field.Type == "group" {
+			v.addFields(key, field.Fields)
+		}
+	}
+}
+
+// Validate returns the fields of the event that don't match the fields
+// definition, sorted by field name.
+func (v *Validator) Validate(event common.MapStr) []FieldError {
+	var errs []FieldError
+	v.validateMap("", event, &errs)
+	sort.Slice(errs, func(i, j int) bool { return errs[i].Field < errs[j].Field })
+	return errs
+}
+
+func (v *Validator) validateMap(prefix string, m map[string]interface{}, errs *[]FieldError) {
+	for k, value := range m {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		v.validateValue(key, value, errs)
+	}
+}
+
+func (v *Validator) validateValue(key string, value interface{}, errs *[]FieldError) {
+	if value == nil {
+		return
+	}
+
+	field := v.lookup(key)
+	if field == nil {
+		if objects, ok := toMaps(value); ok {
+			for _, m := range objects {
+				v.validateMap(key, m, errs)
+			}
+			return
+		}
+		*errs = append(*errs, FieldError{Field: key, Reason: "not defined"})
+		return
+	}
+
+	switch field.Type {
+	case "group":
+		objects, ok := toMaps(value)
+		if !ok {
+			*errs = append(*errs, FieldError{Field: key, Reason: fmt.Sprintf("expected an object, got %T", value)})
+			return
+		}
+		for _, m := range objects {
+			v.validateMap(key, m, errs)
+		}
+	case "object", "nested", "array", "alias":
+		// the content is mapped dynamically
+	default:
+		if !matchesType(field.Type, value) {
+			typ := field.Type
+			if typ == "" {
+				typ = "keyword"
+			}
+			*errs = append(*errs, FieldError{Field: key, Reason: fmt.Sprintf("expected a %v value, got %T", typ, value)})
+		}
+	}
+}
+
+// lookup returns the definition of key. Fields under an object field are
+// covered by the object field.
+func (v *Validator) lookup(key string) *common.Field {
+	if field, found := v.fields[key]; found {
+		return field
+	}
+	for i := strings.LastIndexByte(key, '.'); i > 0; i = strings.LastIndexByte(key[:i], '.') {
+		if field, found := v.fields[key[:i]]; found && field.Type == "object" {
+			return field
+		}
+	}
+	return nil
+}
+
+func toMap(value interface{}) (map[string]interface{}, bool) {
+	switch m := value.(type) {
+	case common.MapStr:
+		return m, true
+	case map[string]interface{}:
+		return m, true
+	}
+	return nil, false
+}
+
+// toMaps returns the objects of an object or of a list of objects.
+func toMaps(value interface{}) ([]map[string]interface{}, bool) {
+	if m, ok := toMap(value); ok {
+		return []map[string]interface{}{m}, true
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice {
+		return nil, false
+	}
+	objects := make([]map[string]interface{}, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		m, ok := toMap(rv.Index(i).Interface())
+		if !ok {
+			return nil, false
+		}
+		objects = append(objects, m)
+	}
+	return objects, true
+}
+
+// matchesType checks that a value can be indexed into a field of the given
+// type. Lists are checked element by element.
+func matchesType(typ string, value interface{}) bool {
+	rv := reflect.ValueOf(value)
+	if !isBytes(rv) && (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) {
+		for i := 0; i < rv.Len(); i++ {
+			if elem := rv.Index(i).Interface(); elem != nil && !matchesType(typ, elem) {
+				return false
+			}
+		}
+		return true
+	}
+
+	switch typ {
+	case "", "keyword", "text":
+		return isScalar(rv) || isBytes(rv) || isStringer(value, rv)
+	case "long", "integer", "short", "byte":
+		return isInteger(rv)
+	case "float", "double", "half_float", "scaled_float":
+		return isInteger(rv) || rv.Kind() == reflect.Float32 || rv.Kind() == reflect.Float64
+	case "boolean":
+		return rv.Kind() == reflect.Bool
+	case "ip":
+		switch v := value.(type) {
+		case net.IP:
+			return true
+		case string:
+			return net.ParseIP(v) != nil
+		}
+		return isBytes(rv) && net.ParseIP(string(rv.Bytes())) != nil
+	case "date":
+		switch value.(type) {
+		case time.Time, common.Time, string:
+			return true
+		}
+		return isInteger(rv)
+	case "geo_point":
+		_, isMap := toMap(value)
+		return isMap || rv.Kind() == reflect.String
+	default:
+		return true
+	}
+}
+
+// isBytes checks for byte slices, like net.IP or common.NetString, which are
+// encoded as strings.
+func isBytes(rv reflect.Value) bool {
+	return rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8
+}
+
+func isStringer(value interface{}, rv reflect.Value) bool {
+	_, ok := value.(fmt.Stringer)
+	return ok && rv.Kind() != reflect.Map
+}
+
+func isScalar(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.String, reflect.Bool, reflect.Float32, reflect.Float64:
+		return true
+	}
+	return isInteger(rv)
+}
+
+func isInteger(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
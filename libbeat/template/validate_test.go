@@ -0,0 +1,101 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package template
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+const validatorTestFields = `
+- key: test
+  title: Test
+  fields:
+    - name: type
+      type: keyword
+    - name: bytes
+      type: long
+    - name: client_ip
+      type: ip
+    - name: http
+      type: group
+      fields:
+        - name: code
+          type: long
+        - name: headers
+          type: object
+        - name: request.duration
+          type: float
+    - name: answers
+      type: group
+      fields:
+        - name: name
+    - name: start
+      type: date
+`
+
+func TestValidatorValidEvent(t *testing.T) {
+	v, err := NewValidator([]byte(validatorTestFields))
+	require.NoError(t, err)
+
+	errs := v.Validate(common.MapStr{
+		"type":      "http",
+		"bytes":     uint64(42),
+		"client_ip": net.ParseIP("10.0.0.1"),
+		"start":     common.Time(time.Now()),
+		"http": common.MapStr{
+			"code":    200,
+			"headers": common.MapStr{"content-type": "text/html"},
+			"request": common.MapStr{"duration": 1.5},
+		},
+		"answers": []common.MapStr{{"name": "example.com"}},
+	})
+	assert.Empty(t, errs)
+}
+
+func TestValidatorInvalidEvent(t *testing.T) {
+	v, err := NewValidator([]byte(validatorTestFields))
+	require.NoError(t, err)
+
+	errs := v.Validate(common.MapStr{
+		"type":      common.MapStr{"name": "http"},
+		"bytes":     "42",
+		"client_ip": "not an ip",
+		"http": common.MapStr{
+			"code":    []interface{}{200, "OK"},
+			"unknown": "value",
+		},
+		"answers": "example.com",
+	})
+	assert.Equal(t, []FieldError{
+		{Field: "answers", Reason: "expected an object, got string"},
+		{Field: "bytes", Reason: "expected a long value, got string"},
+		{Field: "client_ip", Reason: "expected a ip value, got string"},
+		{Field: "http.code", Reason: "expected a long value, got []interface {}"},
+		{Field: "http.unknown", Reason: "not defined"},
+		{Field: "type", Reason: "expected a keyword value, got common.MapStr"},
+	}, errs)
+}
@@ -68,6 +68,11 @@ func GetPromEventsFromMetricFamily(mf *dto.MetricFamily) []PromEvent {
 			value["value"] = gauge.GetValue()
 		}
 
+		untyped := metric.GetUntyped()
+		if untyped != nil {
+			value["value"] = untyped.GetValue()
+		}
+
 		summary := metric.GetSummary()
 		if summary != nil {
 			value["sum"] = summary.GetSampleSum()
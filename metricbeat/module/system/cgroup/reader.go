@@ -0,0 +1,102 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package cgroup reads the metrics and limits of the cgroup of processes, from
+// the cgroup v1 hierarchies or the cgroup v2 unified hierarchy.
+package cgroup
+
+import (
+	"regexp"
+
+	"github.com/elastic/beats/libbeat/common"
+	cgroupv1 "github.com/elastic/gosigar/cgroup"
+)
+
+// ErrCgroupsMissing indicates that cgroups are not supported by the OS, or
+// that an invalid rootfs path was given.
+var ErrCgroupsMissing = cgroupv1.ErrCgroupsMissing
+
+// containerIDRegexp matches the IDs of the containers run by Docker,
+// containerd or CRI-O, like in /docker/<id>, /kubepods/<pod>/<id> or
+// /system.slice/docker-<id>.scope.
+var containerIDRegexp = regexp.MustCompile(`[0-9a-f]{64}`)
+
+// Stats are the metrics and limits of the cgroup of a process.
+type Stats struct {
+	// Path of the cgroup, relative to the mountpoint of the hierarchy. It is
+	// empty when the cgroup v1 controllers of the process don't share a
+	// common path.
+	Path string
+
+	// Fields holds the metrics, in the same layout for cgroup v1 and v2.
+	Fields common.MapStr
+}
+
+// ContainerID returns the ID of the container the cgroup belongs to, or an
+// empty string if the process is not running in a container.
+func (s *Stats) ContainerID() string {
+	ids := containerIDRegexp.FindAllString(s.Path, -1)
+	if len(ids) == 0 {
+		return ""
+	}
+	return ids[len(ids)-1]
+}
+
+// Reader reads the cgroup metrics and limits of processes.
+type Reader struct {
+	v1 *cgroupv1.Reader
+	v2 *v2Reader
+}
+
+// NewReader creates a Reader for the cgroup hierarchies mounted under
+// rootfsMountpoint. The root cgroups are ignored.
+func NewReader(rootfsMountpoint string) (*Reader, error) {
+	v1, err := cgroupv1.NewReader(rootfsMountpoint, true)
+	if err != nil && err != cgroupv1.ErrCgroupsMissing {
+		return nil, err
+	}
+
+	v2, err := newV2Reader(rootfsMountpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if v1 == nil && v2 == nil {
+		return nil, ErrCgroupsMissing
+	}
+	return &Reader{v1: v1, v2: v2}, nil
+}
+
+// GetStatsForProcess returns the metrics and limits of the cgroup of a process.
+// It returns nil if the process is in a root cgroup. On hosts mixing both
+// versions, the cgroup v1 controllers are preferred.
+func (r *Reader) GetStatsForProcess(pid int) (*Stats, error) {
+	if r.v1 != nil {
+		stats, err := r.v1.GetStatsForProcess(pid)
+		if err != nil {
+			return nil, err
+		}
+		if stats != nil {
+			return &Stats{Path: stats.Path, Fields: cgroupStatsToMap(stats)}, nil
+		}
+	}
+
+	if r.v2 != nil {
+		return r.v2.getStatsForProcess(pid)
+	}
+	return nil, nil
+}
@@ -0,0 +1,140 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cgroup
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+const containerID = "b29faf21b7eff959f64b4192c34d5d67a707fe8561e9eaa608cb27693fba4242"
+
+func TestContainerID(t *testing.T) {
+	tests := map[string]string{
+		"/docker/" + containerID:                                   containerID,
+		"/kubepods/burstable/pod5f9c2b3e/" + containerID:           containerID,
+		"/system.slice/docker-" + containerID + ".scope":           containerID,
+		"/kubepods.slice/crio-" + containerID + ".scope/container": containerID,
+		"/user.slice/user-1000.slice/session-2.scope":              "",
+		"/": "",
+	}
+
+	for path, expected := range tests {
+		stats := Stats{Path: path}
+		assert.Equal(t, expected, stats.ContainerID(), path)
+	}
+}
+
+// writeFiles creates the files under root, with their content.
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+func TestV2Reader(t *testing.T) {
+	root, err := ioutil.TempDir("", "cgroup")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	cgroupPath := "/system.slice/docker-" + containerID + ".scope"
+	dir := filepath.Join("sys/fs/cgroup", cgroupPath)
+	writeFiles(t, root, map[string]string{
+		"proc/self/mountinfo": "30 23 0:26 / " + filepath.Join(root, "sys/fs/cgroup") +
+			" rw,nosuid,nodev,noexec,relatime shared:4 - cgroup2 cgroup2 rw\n",
+		"proc/10/cgroup": "0::" + cgroupPath + "\n",
+		"proc/1/cgroup":  "0::/\n",
+
+		filepath.Join(dir, "cpu.stat"):            "usage_usec 2000\nuser_usec 1500\nsystem_usec 500\nnr_periods 10\nnr_throttled 2\nthrottled_usec 300\n",
+		filepath.Join(dir, "cpu.max"):             "50000 100000\n",
+		filepath.Join(dir, "cpu.weight"):          "100\n",
+		filepath.Join(dir, "memory.current"):      "4096\n",
+		filepath.Join(dir, "memory.max"):          "max\n",
+		filepath.Join(dir, "memory.events"):       "low 0\nhigh 0\nmax 3\noom 0\noom_kill 0\n",
+		filepath.Join(dir, "memory.stat"):         "anon 1024\nfile 2048\nfile_mapped 512\npgfault 7\npgmajfault 1\n",
+		filepath.Join(dir, "memory.swap.current"): "0\n",
+		filepath.Join(dir, "io.stat"):             "8:0 rbytes=100 wbytes=200 rios=1 wios=2 dbytes=0 dios=0\n8:16 rbytes=1 wbytes=0 rios=1 wios=0\n",
+	})
+
+	reader, err := NewReader(root)
+	require.NoError(t, err)
+	require.Nil(t, reader.v1)
+	require.NotNil(t, reader.v2)
+
+	stats, err := reader.GetStatsForProcess(1)
+	assert.NoError(t, err)
+	assert.Nil(t, stats, "root cgroup must be ignored")
+
+	stats, err = reader.GetStatsForProcess(10)
+	require.NoError(t, err)
+	require.NotNil(t, stats)
+	assert.Equal(t, cgroupPath, stats.Path)
+	assert.Equal(t, containerID, stats.ContainerID())
+
+	expected := map[string]interface{}{
+		"id":                             "docker-" + containerID + ".scope",
+		"path":                           cgroupPath,
+		"cpuacct.total.ns":               uint64(2000000),
+		"cpuacct.stats.user.ns":          uint64(1500000),
+		"cpuacct.stats.system.ns":        uint64(500000),
+		"cpu.cfs.quota.us":               uint64(50000),
+		"cpu.cfs.period.us":              uint64(100000),
+		"cpu.cfs.shares":                 uint64(2597),
+		"cpu.stats.periods":              uint64(10),
+		"cpu.stats.throttled.periods":    uint64(2),
+		"cpu.stats.throttled.ns":         uint64(300000),
+		"memory.mem.usage.bytes":         uint64(4096),
+		"memory.mem.failures":            uint64(3),
+		"memory.stats.rss.bytes":         uint64(1024),
+		"memory.stats.cache.bytes":       uint64(2048),
+		"memory.stats.mapped_file.bytes": uint64(512),
+		"memory.stats.page_faults":       uint64(7),
+		"memory.stats.major_page_faults": uint64(1),
+		"memory.stats.swap.bytes":        uint64(0),
+		"blkio.total.bytes":              uint64(301),
+		"blkio.total.ios":                uint64(4),
+	}
+	for key, value := range expected {
+		v, err := stats.Fields.GetValue(key)
+		if assert.NoError(t, err, key) {
+			assert.Equal(t, value, v, key)
+		}
+	}
+
+	// No limit is reported when memory.max is "max".
+	_, err = stats.Fields.GetValue("memory.mem.limit")
+	assert.Equal(t, common.ErrKeyNotFound, err)
+}
+
+func TestNewReaderCgroupsMissing(t *testing.T) {
+	root, err := ioutil.TempDir("", "cgroup")
+	require.NoError(t, err)
+	defer os.RemoveAll(root)
+
+	_, err = NewReader(root)
+	assert.Equal(t, ErrCgroupsMissing, err)
+}
@@ -15,18 +15,18 @@
 // specific language governing permissions and limitations
 // under the License.
 
-package process
+package cgroup
 
 import (
 	"strconv"
 
 	"github.com/elastic/beats/libbeat/common"
-	"github.com/elastic/gosigar/cgroup"
+	cgroupv1 "github.com/elastic/gosigar/cgroup"
 )
 
 // cgroupStatsToMap returns a MapStr containing the data from the stats object.
 // If stats is nil then nil is returned.
-func cgroupStatsToMap(stats *cgroup.Stats) common.MapStr {
+func cgroupStatsToMap(stats *cgroupv1.Stats) common.MapStr {
 	if stats == nil {
 		return nil
 	}
@@ -59,7 +59,7 @@ func cgroupStatsToMap(stats *cgroup.Stats) common.MapStr {
 
 // cgroupCPUToMapStr returns a MapStr containing CPUSubsystem data. If the
 // cpu parameter is nil then nil is returned.
-func cgroupCPUToMapStr(cpu *cgroup.CPUSubsystem) common.MapStr {
+func cgroupCPUToMapStr(cpu *cgroupv1.CPUSubsystem) common.MapStr {
 	if cpu == nil {
 		return nil
 	}
@@ -97,7 +97,7 @@ func cgroupCPUToMapStr(cpu *cgroup.CPUSubsystem) common.MapStr {
 // cgroupCPUAccountingToMapStr returns a MapStr containing
 // CPUAccountingSubsystem data. If the cpuacct parameter is nil then nil is
 // returned.
-func cgroupCPUAccountingToMapStr(cpuacct *cgroup.CPUAccountingSubsystem) common.MapStr {
+func cgroupCPUAccountingToMapStr(cpuacct *cgroupv1.CPUAccountingSubsystem) common.MapStr {
 	if cpuacct == nil {
 		return nil
 	}
@@ -127,12 +127,12 @@ func cgroupCPUAccountingToMapStr(cpuacct *cgroup.CPUAccountingSubsystem) common.
 
 // cgroupMemoryToMapStr returns a MapStr containing MemorySubsystem data. If the
 // memory parameter is nil then nil is returned.
-func cgroupMemoryToMapStr(memory *cgroup.MemorySubsystem) common.MapStr {
+func cgroupMemoryToMapStr(memory *cgroupv1.MemorySubsystem) common.MapStr {
 	if memory == nil {
 		return nil
 	}
 
-	addMemData := func(key string, m common.MapStr, data cgroup.MemoryData) {
+	addMemData := func(key string, m common.MapStr, data cgroupv1.MemoryData) {
 		m[key] = common.MapStr{
 			"failures": data.FailCount,
 			"limit": common.MapStr{
@@ -203,7 +203,7 @@ func cgroupMemoryToMapStr(memory *cgroup.MemorySubsystem) common.MapStr {
 
 // cgroupBlockIOToMapStr returns a MapStr containing BlockIOSubsystem data.
 // If the blockIO parameter is nil then nil is returned.
-func cgroupBlockIOToMapStr(blockIO *cgroup.BlockIOSubsystem) common.MapStr {
+func cgroupBlockIOToMapStr(blockIO *cgroupv1.BlockIOSubsystem) common.MapStr {
 	if blockIO == nil {
 		return nil
 	}
@@ -0,0 +1,263 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cgroup
+
+import (
+	"bufio"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+// v2Reader reads the metrics of the cgroup v2 unified hierarchy.
+// Ref: https://www.kernel.org/doc/Documentation/cgroup-v2.txt
+type v2Reader struct {
+	rootfsMountpoint string
+	mountpoint       string // Mountpoint of the unified hierarchy (e.g. /sys/fs/cgroup).
+}
+
+// newV2Reader returns a reader for the unified hierarchy, or nil if it is not
+// mounted.
+func newV2Reader(rootfsMountpoint string) (*v2Reader, error) {
+	if rootfsMountpoint == "" {
+		rootfsMountpoint = "/"
+	}
+
+	mountpoint, err := v2Mountpoint(rootfsMountpoint)
+	if err != nil || mountpoint == "" {
+		return nil, err
+	}
+	return &v2Reader{rootfsMountpoint: rootfsMountpoint, mountpoint: mountpoint}, nil
+}
+
+// v2Mountpoint returns the first cgroup2 mountpoint under the rootfs.
+func v2Mountpoint(rootfsMountpoint string) (string, error) {
+	f, err := os.Open(filepath.Join(rootfsMountpoint, "proc", "self", "mountinfo"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		// Example:
+		// 30 23 0:26 / /sys/fs/cgroup rw,nosuid,nodev,noexec,relatime - cgroup2 cgroup2 rw
+		fields := strings.Fields(sc.Text())
+		for i, field := range fields {
+			if field != "-" {
+				continue
+			}
+			if i+1 < len(fields) && fields[i+1] == "cgroup2" && len(fields) > 4 &&
+				strings.HasPrefix(fields[4], rootfsMountpoint) {
+				return fields[4], nil
+			}
+			break
+		}
+	}
+	return "", sc.Err()
+}
+
+// path returns the path of the cgroup of the process in the unified
+// hierarchy, from the "0::<path>" line of /proc/<pid>/cgroup.
+func (r *v2Reader) path(pid int) (string, error) {
+	content, err := ioutil.ReadFile(filepath.Join(r.rootfsMountpoint, "proc", strconv.Itoa(pid), "cgroup"))
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.HasPrefix(line, "0::") {
+			return line[3:], nil
+		}
+	}
+	return "", nil
+}
+
+func (r *v2Reader) getStatsForProcess(pid int) (*Stats, error) {
+	path, err := r.path(pid)
+	if err != nil || path == "" || path == "/" {
+		return nil, err
+	}
+
+	dir := filepath.Join(r.mountpoint, path)
+	if _, err := os.Stat(dir); err != nil {
+		return nil, err
+	}
+
+	meta := func(m common.MapStr) common.MapStr {
+		m["id"] = filepath.Base(path)
+		m["path"] = path
+		return m
+	}
+
+	fields := meta(common.MapStr{})
+
+	// cpu.stat reports the usage even when the cpu controller is not enabled
+	// for the cgroup, cpu.max only exists when it is.
+	cpuStat := readKeyValues(dir, "cpu.stat")
+	if len(cpuStat) > 0 {
+		fields["cpuacct"] = meta(common.MapStr{
+			"total": common.MapStr{
+				"ns": cpuStat["usage_usec"] * 1000,
+			},
+			"stats": common.MapStr{
+				"user": common.MapStr{
+					"ns": cpuStat["user_usec"] * 1000,
+				},
+				"system": common.MapStr{
+					"ns": cpuStat["system_usec"] * 1000,
+				},
+			},
+		})
+	}
+	if max, err := ioutil.ReadFile(filepath.Join(dir, "cpu.max")); err == nil {
+		cpu := meta(common.MapStr{
+			"stats": common.MapStr{
+				"periods": cpuStat["nr_periods"],
+				"throttled": common.MapStr{
+					"periods": cpuStat["nr_throttled"],
+					"ns":      cpuStat["throttled_usec"] * 1000,
+				},
+			},
+		})
+
+		// Format: $MAX $PERIOD, where $MAX can be "max" for no limit.
+		cfs := common.MapStr{}
+		if v := strings.Fields(string(max)); len(v) == 2 {
+			if quota, err := strconv.ParseUint(v[0], 10, 64); err == nil {
+				cfs["quota"] = common.MapStr{"us": quota}
+			}
+			if period, err := strconv.ParseUint(v[1], 10, 64); err == nil {
+				cfs["period"] = common.MapStr{"us": period}
+			}
+		}
+		if weight, err := readUint(dir, "cpu.weight"); err == nil {
+			// Convert the weight in [1, 10000] to the cgroup v1 shares in
+			// [2, 262144], as done by the container runtimes.
+			cfs["shares"] = 2 + ((weight-1)*262142)/9999
+		}
+		cpu["cfs"] = cfs
+		fields["cpu"] = cpu
+	}
+
+	if usage, err := readUint(dir, "memory.current"); err == nil {
+		mem := common.MapStr{
+			"usage": common.MapStr{
+				"bytes": usage,
+			},
+			"failures": readKeyValues(dir, "memory.events")["max"],
+		}
+		// memory.max is "max" when there is no limit.
+		if limit, err := readUint(dir, "memory.max"); err == nil {
+			mem["limit"] = common.MapStr{"bytes": limit}
+		}
+
+		stat := readKeyValues(dir, "memory.stat")
+		stats := common.MapStr{
+			"page_faults":       stat["pgfault"],
+			"major_page_faults": stat["pgmajfault"],
+		}
+		for name, key := range map[string]string{
+			"active_anon":   "active_anon",
+			"active_file":   "active_file",
+			"inactive_anon": "inactive_anon",
+			"inactive_file": "inactive_file",
+			"unevictable":   "unevictable",
+			"rss":           "anon",
+			"rss_huge":      "anon_thp",
+			"cache":         "file",
+			"mapped_file":   "file_mapped",
+		} {
+			stats[name] = common.MapStr{"bytes": stat[key]}
+		}
+		if swap, err := readUint(dir, "memory.swap.current"); err == nil {
+			stats["swap"] = common.MapStr{"bytes": swap}
+		}
+
+		fields["memory"] = meta(common.MapStr{
+			"mem":   mem,
+			"stats": stats,
+		})
+	}
+
+	if ioStat, err := ioutil.ReadFile(filepath.Join(dir, "io.stat")); err == nil {
+		var totalBytes, totalIOs uint64
+		// Format: $MAJ:$MIN rbytes=$N wbytes=$N rios=$N wios=$N ...
+		for _, line := range strings.Split(string(ioStat), "\n") {
+			for _, field := range strings.Fields(line) {
+				kv := strings.SplitN(field, "=", 2)
+				if len(kv) != 2 {
+					continue
+				}
+				v, err := strconv.ParseUint(kv[1], 10, 64)
+				if err != nil {
+					continue
+				}
+				switch kv[0] {
+				case "rbytes", "wbytes":
+					totalBytes += v
+				case "rios", "wios":
+					totalIOs += v
+				}
+			}
+		}
+		fields["blkio"] = meta(common.MapStr{
+			"total": common.MapStr{
+				"bytes": totalBytes,
+				"ios":   totalIOs,
+			},
+		})
+	}
+
+	return &Stats{Path: path, Fields: fields}, nil
+}
+
+func readUint(dir, file string) (uint64, error) {
+	content, err := ioutil.ReadFile(filepath.Join(dir, file))
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseUint(string(bytes.TrimSpace(content)), 10, 64)
+}
+
+// readKeyValues reads a flat keyed file, like cpu.stat, returning an empty
+// map if the file can't be read.
+func readKeyValues(dir, file string) map[string]uint64 {
+	values := map[string]uint64{}
+	content, err := ioutil.ReadFile(filepath.Join(dir, file))
+	if err != nil {
+		return values
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		if v, err := strconv.ParseUint(fields[1], 10, 64); err == nil {
+			values[fields[0]] = v
+		}
+	}
+	return values
+}
@@ -0,0 +1,135 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build linux
+
+package container
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/metric/system/process"
+	"github.com/elastic/beats/metricbeat/mb"
+	"github.com/elastic/beats/metricbeat/mb/parse"
+	"github.com/elastic/beats/metricbeat/module/system"
+	"github.com/elastic/beats/metricbeat/module/system/cgroup"
+)
+
+var debugf = logp.MakeDebug("system.container")
+
+func init() {
+	mb.Registry.MustAddMetricSet("system", "container", New,
+		mb.WithHostParser(parse.EmptyHostParser),
+	)
+}
+
+// MetricSet reports the cgroup metrics of each container.
+type MetricSet struct {
+	mb.BaseMetricSet
+	cgroup *cgroup.Reader
+}
+
+// New creates and returns a new MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	systemModule, ok := base.Module().(*system.Module)
+	if !ok {
+		return nil, fmt.Errorf("unexpected module type")
+	}
+
+	reader, err := cgroup.NewReader(systemModule.HostFS)
+	if err != nil {
+		return nil, errors.Wrap(err, "error initializing cgroup reader")
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		cgroup:        reader,
+	}, nil
+}
+
+// Fetch returns an event for each container with a process on the host.
+func (m *MetricSet) Fetch() ([]common.MapStr, error) {
+	pids, err := process.Pids()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch the list of PIDs")
+	}
+
+	containers := map[string]*container{}
+	var ids []string
+	for _, pid := range pids {
+		stats, err := m.cgroup.GetStatsForProcess(pid)
+		if err != nil {
+			debugf("error getting cgroups stats for pid=%d, %v", pid, err)
+			continue
+		}
+		if stats == nil {
+			continue
+		}
+
+		id := stats.ContainerID()
+		if id == "" {
+			continue
+		}
+
+		c, found := containers[id]
+		if !found {
+			c = &container{}
+			containers[id] = c
+			ids = append(ids, id)
+		}
+		c.add(id, stats)
+	}
+
+	events := make([]common.MapStr, 0, len(ids))
+	for _, id := range ids {
+		c := containers[id]
+		events = append(events, common.MapStr{
+			"id":        id,
+			"processes": c.processes,
+			"cgroup":    c.stats.Fields,
+		})
+	}
+	return events, nil
+}
+
+// container aggregates the processes of a container.
+type container struct {
+	processes int
+	stats     *cgroup.Stats
+}
+
+// add counts a process of the container. The container reports the metrics of
+// its own cgroup, which include all its processes, in preference to the
+// metrics of the nested cgroups some processes can be in.
+func (c *container) add(id string, stats *cgroup.Stats) {
+	c.processes++
+	if c.stats == nil || (!isContainerCgroup(c.stats, id) && isContainerCgroup(stats, id)) {
+		c.stats = stats
+	}
+}
+
+// isContainerCgroup returns true if the stats are those of the cgroup
+// created for the container, named after its ID.
+func isContainerCgroup(stats *cgroup.Stats, id string) bool {
+	return strings.Contains(path.Base(stats.Path), id)
+}
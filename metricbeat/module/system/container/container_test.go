@@ -0,0 +1,44 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build linux
+
+package container
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/metricbeat/module/system/cgroup"
+)
+
+const containerID = "b29faf21b7eff959f64b4192c34d5d67a707fe8561e9eaa608cb27693fba4242"
+
+func TestContainerPrefersItsOwnCgroup(t *testing.T) {
+	nested := &cgroup.Stats{Path: "/docker/" + containerID + "/init.scope"}
+	own := &cgroup.Stats{Path: "/docker/" + containerID}
+
+	c := &container{}
+	c.add(containerID, nested)
+	assert.Equal(t, nested, c.stats)
+
+	c.add(containerID, own)
+	c.add(containerID, nested)
+	assert.Equal(t, own, c.stats)
+	assert.Equal(t, 3, c.processes)
+}
@@ -31,5 +31,5 @@ func init() {
 
 // Asset returns asset data
 func Asset() string {
-	return "eJzsXd1vIzeSf5+/ouDDIvadrRnPJtmcHw6YxBucgSRjjD3YBe4OGqq7JHHNJjskWxrlrz/wo7+71d1S62OC8UN2x5aKvyoWi1XFYvEGXnBzB2qjNEavADTVDO/g4sn+4uIVQIgqkDTWVPA7+K9XAADuj6A00YmCCLWkgboGRl8Qfnr8CISHEGEk5AYSRRZ4DXpJNBCJEAjGMNAYwlyKCPQSQcQoiaZ84VFMXgGopZB6Ggg+p4s70DLBVwASGRKFd7AgrwDmFFmo7iygG+AkwgIb5kdvYvNZKZLY/6aBFfPzyX3tEwSCa0K5AiYCwjy1lL+J/3xx3OLYgZCY/bJp9C0ICihuDJ0CFCNPjwDmQgIBRfmCoR0PxBwIRAnT1H6vIMH0pyy09KfKRJERGpZ+nbLCBF9U/rCFG/NjoP9kUPEkmqHMUZU++W/wiDJArskCVSOgRKGcxIFuhKUCwjCczpkg1Q/MhYyIvoPY0R8G/nmJ6RfJwgrasKNphKBi5Boot8BAxSTAFt5KHGgavKhxRGvAkUgkXO8JzOvLOQr3BSVHNoSLEQXcKeEB6DgN8PwkLDgwsb6JJRWS6g3EUgSoFKo+3BxN0ruipCE7Q5lbVD2AH0+RewASa0L1GcqSgwEGl4JDSNXLVT8+jmkjhuGTv5+fkBXKFQ2Ma2ZcuiXhITP/WBIZro03R7lGKZNYd65H+fvxRD8aaiXm+kuaF4N3Nw5PPTc7INdI2PnNDOVA+UqwhGsiN84EzDY2zllRqRPC7DfWS8rQ/na5iY1IlJC1wdZEleQl9BJlugUKOal94d2KUEZmDEFwtjGb50dOP/cS5DHt4vkKKIvl4mSvUC6Ik1o0abgyEbPaLzozYd6YE+Vis3SiLHWIJSrvfdkZEEpP3IcFv+Fm/TD6B1bDRCisDAVryhgsyQpNgEo+0yiJYEVYYhfNp9s3b/4C/+6G+2Rp14jl45ToEiaRhBvQ5MXoB1WeKuVaAAkCq3bOtqzqRBuwGCh/6tAU3vN6ikBd18huRAIB4W7SiiLPkjcLiUSjNL/gTm7ws5CAn0kUM7wGOoe/1sg6lTJfJxq+f/MXA+3a6JVTLp/2mARxMkml+clpzwzh9ofWyflzhbB/riDxyw2//izRzhfktX71yxs4/OrdjuPdaqHPVJDGF0QFjm27oz6EDK3iPLz/h7FCbU7Jb7ln1Ms/MZ7UWYpgaJr6bBkZutGfJyN77fbnyVL/Lf9M8e+w758nJ6Nv/l8Um7t6AOfJ5JfqBpybNPt4AddpIkRhmAo5z9nY4LqB94rH8FzL7n0pJ9PnfKb7ZZyCnuFh4lkfwp36KGT3HfHUyHfd5L6ePRRlYvSUildVUQw5fjAkCucP5p/w8D4rI+tZg5f+DD+jMP9tnM8X3KyFrB4c+PzxHaiQ3A6fbsueGbJL2VBSwqZu8xwAryeEb5QfIS13g+clVRCRDXChYYZGOVY0dNs4YSwXeo2mz9F3MCSRhBN74DHi4rGeUsHDMIMYlTEzZFRGJYHR8HnC2KYD31pSjQcHaEfZEaGV4Gyj+5+opa5g05d2AG/JWBhl2PCewy+UJ5/dERetDgUVP1BhoIX0lOxhT8yo1zQORKkkMpKxnwJF/7B+6He3b3vN4OkFZHBo5OPIKCXWU0w1qt1is2pl9p0Dqn1EmYkJAsFD5bc3b1bsiu01sSeD6NZsp7N4aIDNGENh9sGH1++7AZrobWJnW+LvCSo9iVAuUE1jlFOFQSP2pgizA3z1qN4ucz+kAjumPSUHx4k7sV2jRPg9wQRD0MIuhhBXtDO28Ww5FTkuX3bMQzNWmq+jTlSOnipVQ1/gc4cJOu7MjMuJnRHPwJbdZgQ2fsz328z3rWGu+u7NW1r31ByRIzc9h2Qp1TOyWkzNzjguP2SFkizQ7bmXlKeb9ZVh0XDUU/P6WQBrOw7MiR0DGPKFXh6ECWLC18NgL4TMcyFz2I1rXQsT25hQGMN+yF3YjNPW3X1/NXIjOEaMOhW3+SvL1MPr9+POxyxRm/G4eWzOGIeJNM7JekmDZZmFdmN8OSM8XNNQLyHRlNE/iBnWCiH/1NUE7t3HFdGJdB8RQZAYh9nVauWldgoCJpSd+nL1XCqSOWVYuosHO+UxcjK1asr8T2MUVZI0N3OkKMemLGyeOR/aSJNwSHgs6YoyNJ6PzZZT7oaZNEJ30zcdmHIZlFYpFvndwafXIa5em7/efmpEZMY9ABRDowoFP+tvm0HYFOA0FrQl97AzFkvYrEFLuyabZjRWWw8YyBj6wEWIymiLWdX2N/VcXgGSxJNq+3atNuimY0utIC+JuIvQrNyPmgkpyG67xBKFx01lmQEHwjv90WsF9LaT00wVldlg9trHvEo5SoWtrLCJpbl5slhIXJAsOU8YcyanUm6ff3Xv+wS7pmd/K5sfjwbmIqnGG6Xls8eyfm4wey365oZqcO+3aX3zzNYZR2XnJ+caQhGoWozVIHXYboG3iqILfQ1nk18JXojWAlbWQBWgWSwnA2hXagfAJnN8PITO7F1aoDFLlJXpVd0ZZoKE+5gP4/wbGml0s+eCv7i9GGqEzZ8oX0znxATld8bpH2aIfynAzwIPRpSGiPJEY/MavvjunJB+57G2GJyL27NCe9sAtxm3LYo6lU406AKENDsl7VfsVGfnVFPRrDBjcHQy7WrRqj15sh9q5uiw1xhr1tn1KdrLvXMkaikK3wFphPTE0cIOu6853N0dbY4Xbnw0W2wvWEeMal18ltcaWY/Kz3kWC7kaEJc2CwUqWwpCecCSMPtwILg7d55tUncyIMESFRBe979myXyOUsGlwixW9aIhgU4Im1TckLMPx3pNrONtN3+9juSdpZb3KMPQVrIZyXV58Vu95cYVAUfwSD1DBXkWdPBBg0RvDJXL+VKjRMgDhBnqNfq7uF6l7TlrMVfjZ6jxmrb5qX4SQoyRhyq1vO+fXJ4sEhIhRE0oU9cQWzMIwRKDlyxGLujwpxaVgNPHUF7czUv+QdsMOWFBwmwgPyNmWgqyyApXqLarnyqt0jOiAs3GoW2kkduH1B5You+f/mlIUgUEVBJVrVI6sZSTQNNVPq/vOfyD8lCs1bX/Pv5eX21etCKbK//1vnPVYnOgj92BTtvTc+bqNojUlk5XHeGaVM1NuyGKJc7p5zu4+B/L1v9VfZ5yfsNsFpZK7ksY94EqTQNlHAerT/54x+AotVlMVawpg9mdjjhxMJ0z01eVTmVrrTcyDO+pzFRml3cQ7/mt1KSf4FMulskC49rN2BMsVgMELJKTr9PG4ucaa80Tkud7Cwz5k5NYCHbu6/bXgrdHORBmwjQzRTk7Y6yNDgb2WhPlqoCSXrUWX8BIdmgc1cldxZ2VyDiycrV7anocRlIUMEu0jeqa9GkgZyqRxr07LWNihTIQUUQHL40Q5yRhuunUpTcPe6zveze8qyGbC9kIPruignot5MurKrwheR9Po5D48b8pXrYp9WxO/24vSM0rZxvHu2eDejkwFWhTbFXwfS7diEQf9VS6enPAxLxd9Vv8pAglBki7a+eMIGMSvKA+TEmEp91TYIdDIjMkPQVD+QSlFPIwYnGk/ZVAh4jyRY+5OhYmhTzsRkT5JJQijmu75jiIKA9EZIui/NzlBZV+2B4SOyRAkeiF2A6wmKqlCghbk019J3pjnPd7ItfGg+Qh/Ph0DzMMSKLQp06MLyAxFlLnpyPt1ytTAfiLo3vtR55GYT/yvzGbEQmJJtfF5wSui+80VB45gGPsR33SxZ6B8k4DpSvERB9oUEu6UuF3IRPOKV9cNKOJR3s8oQgkpi0FNfGBxiMSud4y7OIgw7pgum3QIAoZ5SNP9TxhDIy3S3h4Y8i78FALM/lSO+fdwbv25zZmFeuGRDiRiySyGXaFMZHEL//GEha64ELilMzECu/g7Ztvf2hkOVEox19TKdU042yLjK09DGx/z7DI9QQe8k81YAEICPf3m0PUKCPKMbz25ZTIQtfK01ukjNI3dcvKkwglDYCGyDWdU5Rw+fHh/qqcnrZl6I6wP0ZT24iGIiLeUlPlPTfDIVHwyf3tf1PGPrWo3bpZ1XcWf5BIu7qML210KaTS3nXZpPORSf65wKuyXQPqSldoKuDOFJq5QL5q5ELM/oW1XIb75XRPPpGvqBTcLAlYEUkNStW+vFyDYbNJNd3kLfH5s0T88en+2jHstrH3T/DPlgks9XKG8RKAPz1+vFExBnROg2LmL877QAzN7bV244Gu+LlnQrWhNYYu7njb2vRUwbqeStZhOhDarEezAetSp4ryAJ32eEPcJuvuHpBw4nx2pTuJ9xWzubCcZqU3SRxab+RBF5xURSPKiPQnQo3D/sWMkgmyOEBIVczIJvdStYjTvTBtT1LvRNEs3JbOWl+UhHFVCn3LlIuhQaEzea36Ka8+snuOBkl4/QjCMy1FBG/qt6iqIt7SCguObBeaW2RVATudOCReV6mwdXq3yNNYj6bbhzm6sB5UDEFnMK3TDuepEG3HIDM0tni4jaf+PWtc3OHG0P2oa7/r2q9OlOnNNSBt2+Sj2KK4l2SLCkilTnYOZdB/QGX9XHhCDU/0D5xUlmEDQyIIkpi6wyvr1frPXH549+vVdlbPzzKPx59aEnkqJbRjh03MJKr1altzPLFDFdvPlGH2GSG9h5RmctympdCrkzuNoKrgSs8b2pq5uiSzd3kve2yTIWLke20KlZt8ZRkoS7/3TsBoRPVEifng49y+CiLm2o2SHvp3QM98ikaSpVipQDsg3ATgwdI4G2HVzyEaCN/YXalLFEtSC/XGEoUhfShRFGgbUdgGezMESdKuqVII3RIeNi28nZfkr+k1Pu7xqLwphxvJNlOx1/ety03Ui6vXi7D8Rmr647+V3Q+UmGeXay7GkihPSC1pbKsc6nkWwW+MODxlK0CFpQGs/LpSC91LvZYkhI7sSQ8Bg9emh3sbqhhNEvaSpONGAVFKBNSmsdZUL13CyIi52bN32S3bOoF/o4GkVB/uXarCN6xKqRfST/6JmkaqZLblGK0oopjo5eGEZKin1YJej2xZEl1h5dcqmbko4xvlrpu62+2DRGZHO4bQ6hkd2LpmB0gsiJNcFqCCJYYJQ/cKM7G959yFGKJesuIOv44aab5z30nts+BaCsa8ZVuLLFWcDSXVNfz085M1IB+em4mavytNeOjApJ0P2QbmhMqclLczsRTGXlDBCWtIIVrp2Ms8LpOaBVVpZXg6jVkZ8xrpYqkn8OG5AKORrkTCfIRWAaVQq8JrXI3xZ6M/Cnn34/IEWCH7uxRpnxQCC7pCbnxPKrYVSLUbM+gyaNBjvUJVAx/u02xMVXu2AmgxFztBaF4E5udxF7PRSq3JnGxlMpiriZ+wxloo6HJIhrBqx7Fz4RuyRzSQIm0IaKuIxBokLhJGpNkVW0k5kXyjUjuhhdVliUokMkAFaikSFlq/BLNisQEy+T0RmhxeJM+Ve02tgnELmbDmewIWUmomSXGNyoSn61Nw9GsTLomCEOfUuX3tUi4qR9stpybp2VDt0LJ7x2111AKlzxbaYzWflEFj8LKFZPEUDV4r0VKHoNRpLIl1UsiWp4OF3jq2SzJOvFCc+x0lyp7ivQWj9HSxbH2xvypeqc94vWbrsl2+LeuVqh0WqtQTmXAbap2DMGxyW/AFKnvTSFOeiET5NddKmPJKiFJexO5Vz2ap9RSTuxTrYBxaTHlpqzc1toBxRZiyRqe0YMyiKJuYduNmlrYVBTISb6+/rrOul1JozTA8uhCMrqi2WZ25q4AeG1xaJmnDY6HpT1r5vHbHusa2p+VQeokbL6DPS5LYnim27fl8q10qmDuj1aUZcvkAKsHuhX3Nf1Xi/OBbaJafTpv2uX56l5QDJ1yUGhH6lZbNR4eD0TRP/WImEmzJAveKm3wUlHZAa6gZS3++etPZz4m9aX8+exyvsfiyUUHRS1fcjQkoxs8d+j5ojbvnDsfhNeOlCD4DbgskIhG21b8340vfMTwawkt3YHs1BGqMsjnDAtvLhtKfUvnQ/pqVcVmznhnbggOSYGk/WtGwLds3Vd0qtvVoFoZZT3//zKeFXe3tVwN6IAM63FBGGE3sCVrrwTD0WaFdJ4sDGC+2Y/GHe7NNa/rrMu1A0NctyhmOyOfzYXqJWVaw2J9jbM7dedc5cp2nXtwmU+4vYbhNy5BN3N6+fZJgiVcuTGnIV7sX+XPPPVF99wcjvTmhLDl8PqV81usjF8vQMutq4Y79LitzegVr0u5FSbNd9I7YIozU+txswxLTFh9pQXHJUNg2IfauvGtT6NdQK70x11YmrHO1K7UyG7MZ14VVEsoWR2JfYZ29KUozSV7hqkKzk709TzK2AVLrczJB1cVmJ7SV4mVt1q2xGmiUXs7VX/ElogdzW17O32+piqDTfWmlOlwyZ29MxLwin20Goj1LuIvheDlP16U6b2P6Lob2VAfxWZqKko0wm8zzT49Zg8N6P/khjJ6raSjahCrHDTaiIzm2m/W0YvoS7IQXVlVONYPRJaWdPY1MWudpNKoT2X5YNdy/cAlL1yhwSrhobpLRWwAj6so7LvgmEonKPVAb64Lg4BsbMiRK30gMkGu2ubGr7fKXDx/bBcSo0qUbvlE8V3CplhFGV9dDjVFJeCZKP7LwfqYMb2YkeMmL03Ph/PLhY8buDlxZWR+Zn0ezQdiBx56jJUVJZLCkAWFTJ6rpeZnGYto4i8RS2N57yho+FOyEs33tJ7ejiEutz1NaeUTWW26tJMvy3E1uacvVL8eSZk1ii+aitPLaA9zqitxJUicwm+2SajaojTLaQTsiEscYnhfHT/6RcMftjYMI/n/swzvtpnhcmxOTBU5ta7ejF8kYG0Gy2xXl6FRLuligxNB8YlsCzEIfqA//EnL6BfBtgXYwDhe/mk9duH8qWBoV4vndFZ8McG2Y2cbeYdFiW/jrGpTYVhH2ck1Ii7c7emqUmramXQ5QeGabQZr/2uozUeioTt2lPPcOV++SyyIfImnLNR6WEZEUgrR9Wdl2IbcXK8fYFi/zljOScOXbJGV9hq+ugYv2vO+4jqtUampGPhup/VbpayjmQDJBNsprWOnMmsRnw+tTduyx4+wlHFc00GR2Rjv+r4V0bN7USWLACI0w7MVpyuWMvdAmGz6gXOZHJoJiJ9WvVTJjV8nsUCTjqgnPRWOr70E6w2NtzRyldNk+97CLf0pzZpXKvZncHsC0H9YMEhMVx6m6zAXw8Pp92mtScFvmb6TtKuQM+7szbuuwMb9a72uP3UPIjAab1paWE5VEESk/sUU1wzt49P7lU/0DQ3tfehKlrsuFi9GowPdtbH4M7OCPcvV6eKvQjDeDLfzL61W8hRsnjrGRkBQanHuBDcFCw/5PqPcGYogOQqEYYnwIkaSEh6HRY7a1LYBxdAdh+UNEMzr+DDmyg5Ak/IWLdTUC2x9KjqFwt2KJrperiUxYyL/xjo2WFFfGOEoTt3hEdTsmCd3r/dbK90/dVve3QutPtx80z5BNbVG9mY7cYTele+OmpAcSE9+npykjKUzxRQqXwnODbBn/kPbejlCQQ/FVsGZM1pEZF1QhgLPEi3iWgoUt0vFI1IYHB7ByHorftT0al5+S9pqJGbe+ZpUIXnCvR9uff3r0VFQ+ntOV/fwG1++Vimbb1/FyQ9pofZg4/1usnRG0/NjDYMqptl1SJvAolKIz5i8QKy9YN8511jm9ntQV0ua8kXe3n5+TiNZaT/RjOF59O4zZd2EobZ8OO2YHMPtS64Q29/+p/TpDdfufbydvJm8nt0YIb9+8ub17c//jD3fvfvz7/d0P3/31+7u722Ggf7Evxj48AnHofTrN9w0hHB4eV9+awR4eV99nH+rDWyxk7+f9M/7evt0FvhmqA5PESGg8A4F/sEBGlrjn7igi9wz0l7nxxXZZgX/7/ubt7e3N7e3fbv76/YSvJ/4vk0DU3i7rwPz4/AEkBkKGDW2a0AOFh8f0DU0x08Q2hFhRAhJXKFX9mOXhEZgQL62xeUUMqFk4jVmipmJQM/n88Zxd2bdNsedzDHxOJr5huEKW9ii/xOdf7q9Sf8jLwkyaO8wWHO37qVWqjMyQlR4pcM3XDbX/uLVe8MVciMmMyMlCMMIXEyEXkwsj34viL2r5tawft6GR9nZPmy4b8hCICH2DM8IBoxmGIYYQiDhrZm4IVAnbLyy1ju9ev46TGaOBSuZz+tni6K3LU/sMynh+6d8NOf+hWcqmu8mezYnVQK9u4GvCOhCnWZDxXmrI05DpAZ31T3zLvHyf7wnMd3w+TICRtpO+tGUYLiH39g0ES+LqHd2LuF2vVWdQx3oCYuso+HnEaOfdTAmW6HIvNvyMQeKS/9sh2fvMoynOx1xz7M2yNMPT2CqzHc/hwtFuVGX/vj21+OQ83Ya/75tZFHPXYjSNDWieT/SNunvGAfXGSTt3nXznXtjgLq6opne72jTa49p6hJz6ZK6fUcPfe+SoDTArrXZ0xQ6kPpg4HJZsCOs3qcZ50cFo3UDNkCaO3H1umrtr7d3qsBvYNnDQQ3mgx6T1BAtFRepG3U+hDoDPQOujYEk4qoJ9vD9TBdsObBs4OEMFq/+fbD7jSvu2wXvMJ0fiU7mOt/ponB/I70R7JqF8S6JJNPjZy/SrlT9THid6mn4oooxR36hjmEo/W48w5dX2NMtJTV79fwAAAP//N1fWLA=="
+	return "eJzsXXtv5DaS/9+fgvBiEfvOVsazSTbnPw6YxBucgWTHGM9gF7g72GypuptriVRIqns6n/5QfOjVlFrql3tyWRubcT+Kv/qxWCwWydI1eYHVLVErpSE7I0QzncItOX80L5yfEZKAiiXLNRP8lvznGSGE2DeJ0lQXimSgJYvVFUnZC5AfHz4RyhOSQSbkihSKzuCK6DnVhEogsUhTiDUkZCpFRvQciMhBUs34zKGIzghRcyH1Uyz4lM1uiZYFnBEiIQWq4JbM6BkhUwZpom4NoGvCaQY1NfBFvcrxs1IUuXsloAr+PtuvPZNYcE0ZVyQVMU2dNK9f5D5fb7fetvsyyPKdEIQeGPj7XEqpoUGSYiPEYzEMpyxjWhExNSyWX1QNgbLgHKkV3HxqLpSOyMc5rExvSKCuI5z8xQ0R0je2eGvaKRTgdxtiFc3AMUGo8gxGuRQxKBVZAc+eMkJqnTcBTWuvt+msU8qSxsuezxdYLYVsv9fDKv5+nAO5v1tjq4IYROAUAhUEkgo+G4+CF9kEJCIppZfdxPgoeHHbtLqNbgC4X7qNy0jrIa+7LxtsUj1fe7O/VwfA9rzmVM+JFnXAElKq2QJaL6tiYg32K0UyUXCdC8Z1B9cNvvOCxrGOtNA0jbjqVCZgGUM1QdHGi2qWAWGccMqFgljwRKFPUEUGCZmsCE1Toql6UaXVGKWHqRHFUxX9WghNo+JwalDDrrEbp0zGYllqMxWSLOcsnq/pEpTpei+mHMcLSQqJQ0ZwIDlIJpIRmrsvHET1ByO7V+m5WBIJsyKlMl3V+u6rEB5CaIxeFY0Y7UKCEoWMQeEsWaQJmQCRQFOctDQMZQEnbxXpuRRap5AcyJjnOPTQEgwVSYETveDkomnWlzVLGGoFc7oAMgHgpNKh/Hyn5jYqiTLIIhOZRJOVhtGaT4XMqL4lXV8ewowZ5vUgCYd0NSE0hrThyzR2OU5HEyK8no5zIBn9zLIiq3mCQoH00aFXCxWM0yLB8TxlKZCYxnO4DEo1oWTdSjxJGNDgKFhCgkOlUBCRe02YInSigGuynAPvEAkS8INc2ImPCO6lLt4OoHySvjDhZoVXYxtbrwUXBgfRknI1BSktKRjPmXgPHe4kFfELSWDB0JlMVkGxHSY5khQmRlOyhcr3X7/3qwnBFc4KaMWQYHeiwruq6jWLhYSzTQFXD34XLl+jnFqcj87dB/joEClRjM9wNAgJOJ9QkhWpZuZ7bnUSDLFnOwbYgR7Z0BsI/UdEZe2vQtX45J/IA8gYuKYzUEFA6ByiPNZBWCqmKSRP01RQfRYeP7mVPw68CR5LYEh0GX6pHB0H4wYYUTmNoUO3hgaaxS9qP9QiuMp57gDML9FOkNwXkBzSMVrskeCNDI9Ax1kMp8ew4CQVy+tcMiGZrkUZQ7Q5GtPbomRJeoKcG1Tl17qBH8+QBwASS8r0CXLJCQIjF4KThKmXy2F6HI/asfjkr6dHsgK5YDEG4BgjzilPUvxjTmWyxMiacQ1SFrneOB7lr8ez6r2hVmKqv6R+QbzbafjafbMFcg00Pb2eYZwwvhBpwTWVK+sCJjabs2BSFy6Nt5zjghZfna9ypESJ+haB/VlS1eBL6DlIPwWKtUQrIe8WlKV0kgIRPF3hAucTZ58HEXk0AzhpgjwncV7stJSL86K2hMMcnO12m1/baXWGy7x9dlS1UEaARjrJJSgXfTX2hwgX/JqjZ0vZb7Cela9GhiJLlqY2H0fLfM+CpoUZNM83b978mfybWcOqZyN7TVjVTkMuTXF/akU0fcEBxJSTyrgWmBM1Zmf9/mI9VxjAglCqLml84/exNCXv+XqKQF2tiV2JwqTRkbiafFXt084kUA0SX+CWN/KTkAQ+0yxP4YqwKfnLmljTx2ZjkWry3Zs/IzTc+zVJWih3CTH97Nl8ttYzAXLzfWfntBZ/X/gS9ve1SPxyl1+/l9XO73o18f8gLv8jut1PdGu3X06RSIwFQRGrtplR75MUjOHcv/8HeqFSbEP+n8jfq8hoUHyCkdSpBynl94NquDn+ZBUZO9GfpiI7zfYn2jeDp/wTxb/FvH+amux98v+i1Nw2AjhNJb/UMODU2BwSBVz5RIiyB/x0I2djFtcB3ct/4O+fyMe17N6XsjN9zLzk2Fn8aNh2mpiPx+DgufZ4kLaYPo8Gbu8z4msj33aSOxruk563PCe4mc3ETtsPKKK2/4B/kvv35TGygddttt+jwP8P9mf4OL/LH98SldCb8d1t1MMmK9BBVAoko+mTnTxHwBsI4StjD6w8e4i7GkyRjK4IFxrPY+dSLFhSndMvSV+T6XL0GxTCjZAoRlsParPd4NGt45PYCF4xwAw/mowqzLnzaZGmqw34lpJpODhA08qWCFG54EnoToB9Z3K3AG/E4EH9Fmzcs/mZ8eKz3eJi7aZIKw5UEGshnSSz2ZOnzFkaJ1TZ+yH2U0Sx30wc+u3N2w38GG5PgCDEoYHvhyMvbCBNa1I304a9EOG8M5S0LYjJWJoyf33ELsucW8HWN028yAG8HkTT/CaMTBwaYBhjInBGv//6/WaAmMONkO9Iwq8FKB1lIGegnnKQTwriIPbQCnMD+PZWPTZJXJN411bO7C45mq7gCW7QarIECeTXAgp7yh8Hgz3rPkwt00dH1su0eWjFGv111I6q0DOl1tDX9Czl9unR7KDj9sx+NTE94hTomW32oMYP1Xxbxr5rmKNBU1qfQrZrjqiRafCgKnk7o4vZE86M+9WHLkBidgwl470vP1lf+uvFAy2v0rNPF+M7DqyJaYOkwGd6fhAlKGY2DoO9tmTGK0cl7BBY/GsCuPRZQDIMOX6WxfDUObvvrIBrwWZZ0Zzq07y9WIrXwvbaH5NCrfanTbWx28hjuOvN9n50Q4VO9ORiQnmyZImek0KzlP1mr9siCdWnLiNyZz+uqPY3ckUcF1KZK5KNo3aKxKnAAhCidXrOU4LXNRtlN7bLY1Ri1k5TVm/t41Al9bmZYAcGgs2w/xyVL7B55qppZJNyUvBcsgVLAUM6ky3392GjIHTbfU8jUy5DMaLYxiG/W/L8dQKLrzHpcvMcRIT9fAAoKLYNBT7rb8IgzN2AJ1PCYb9YjGBcIBjZa9yE0RhrHWpbWyxkUD7hIqluqZpX1nN5NUgSYCiiQ1h7v1VPJcDTvlmr8SUBtiHNLCCHItqRNdNWnbt+xgoFx01lYYMj4b3+1msLdIV1/R8e+VThBHPWxjxmHnMmZSXVprLaJOZz83Q2kzCjZXKepqmtRtM6bl99dcepb/v07N+b7sehIVNRtNcbvi1j0jsM6/bVfiOpr6lAeN9n9eGeXVcclOmfSmuSiKoWWB/rdYgBD9xLxSb0G6zQ/1gSsfH2GGgDxMHyagCx8U0AQ+74eAgNOHJhgOZpoQyntR1mjzIVNDnbZGQ9rWLwjzL86mbHAX9+c34WoqvHCeNbjM+ephQz6LcY9J+NIu3nGvxy4ZFSpUnGeKEhCiP99pSQfuuwqg6wNyeF9iYAN4wbjxdFr2UTDcwWMElYuUs67LDTujrfnoI6ZQ/sQ6Obk1DpZl86mQ+dnw1026Ni+/5rjGdtKLbo1C7++dmKWEtRuHJWe0hPHG3ZUas/ViEOQjrqcuMTTrGDYAViqsOuz6qzRti0A1mthewZEJs2SwRgMTE8PotlzcoP18s3unDSVDpTeLpqrelJMZ2CVORCgY8+I0cNjfEIU9QKQ4I8ndJybFDHWt2CcNtDdQCSd0aa7wAkwxVBg7Vosz0uW2+3KA3ZUq8RbjLEAcrUFKrxWbPBe00kOGeIW3eYUEMjAh5jtUS9BHcX15m02UCu52pcDwWvaeNv+5MkgRxwQ9153vePNk+W4f3jBDRlqboiucnSkngO8Uu5Rq7ZcK1ScCfpr7SGcnSHh7wtLxjTNC5Ss5CfUOyWGhflwRWmcdJQTFVVdWsyg02blUblH7w/MKdh3j/+kzDTOiWqyNpeyXcs4zQ2BXB9v77n5B+MJ2Kprtz34df10eaoFWVfua8P7asOnzPI72z2PQN7bt0H0bWh06GL10MtaT7YEeUSpuzzLTn/b+NO//f8rAeymSxcPVsfS2D4wJTG3JApXFxt7yAO37Wmbqk3Mdc9GxxZW61XXkxXygw1pa42Dw3YRCPj8L6Wmyr98ji4JzpSi2HEey3mxQzytZuxrzBYEQgxSF59nAYPPw/skCrfW1PI7ZzkQqQd3XEy4/aXWrTHTO1XWxu7ps4+xsYGBXYaE81TAbVuQKUO7If2YzpVqLi1EWEgKxeQvK4iHgWZFNqs6kL2NFIzVUgM715XMbEAGYssY6OHRgJTWqQ6tOtyjPF9Z5u3x9sxExcC77Fy0EshX842TQs97T47GbXEj3ulftmmUbPZv2+udk1bexvj80IjD32UxxRAz0emAj/OA+CHXLoRhQ46/E5j6DOEISBLOzYCCNYx2wCR8VdFKCEGtvnsHBKZ0/gF9GCgo8A42QMJOxwSWSIZSAzjEUgp5GFosaLdlUCLiPHZBkjYV8fCpIAnmxExHiVS5DkkB0HEeCwycyjK9V11oNI1O4CxQwIUhZ6JfoD1VC1mUNIlXbX7j5A3GLzfUbnECJIn5IfHOzKBmOLzsGzqBGMBCbmQutod6b5e6QlwF0d3mo+cjNp85F7Bm580oZpe1R8ncFV/JJt77ajz0YAe9Ao0Z5p6m5gfOVCjRnTrhN+5eyjXeRhNvreHJ9SB5CzpaO5A7VGJ+2bdzc4O0qxdTHc1Gmd4y3zPXY03CfHqaEZ5co3izfoOM2xKU6mJruBduX0bHMWhxxBROSsyk2FXkFNJ3fAPHmFhMy4kPNGJWMAtefvmm++DKuPx3/2PKS/VZ5zxb+sPY1PfM6lrHZH76lMBLARLhrr7zQlokBnjkJj6njbjaEt5Oo9USgo804oXGUgWE5YA12zKQJKLT/d3l830tDmGbgW7bTTVJzQRGXWeGr9nYkvsGHw8oX3vf7xiz1GwD+Jlsl/640Ka0YXLD7SlhElz12Xl+6Nk/mNNV9xLSVdn3Tsvwu0phLUAvmh912ohJv+CtVyGffFpRz2BL5gUHIcEWVDJMA+puodXZL6Ek1ToJm9Dz58kwA+Pd1dWYTuNvX8k/+zowLwIqr5zAvDHh0/XKoeYTVlcz/zlVR2IJqLw5DmoGk+vix3QIT2lMWp90F+mpw3WJCIjEzAdCG1ZoxnB2tSpYrhpaZyzc8RdXLeBnl4+u+yC8m50oy+MpuXRmyJPTDRyr2tBqmIZS6l0O0LBZv+MrZRE1htImMpTuqqiVC1yPxf68iQuXt1IbkdlrS+KYVg0lr71n+bSoFaZ3EkMnT5CFpkmkvL1LQinNF7XfLN+i6pNsVsznIJfCJfIagO2A+6QeHXzgaih7u3hE71H6PZhhS5ZX1SMQYfXDpe+wrkn0VQMwqbrq+Heo2j9k1UDjN3cGDsfbZrvNs1Xr5TprSzAl21yq9g63XPaYwJSqWDS71joP4AycS55BE0e2W8QtYZhQCG8jZljURe8z4pRrfvMxYd3v9TOe4VUPT3PvD/91Jw2Hrx4zG40bSchZYpGdb463mmy3QhfR/ET5v/8Z4R0EZLP5Nh8lgJnTuZFnL2qUHoaKGtmQmoTUbsoe98uQ+TAx/ZWg4dmYm/a5EBhJTM+eCYwz3WNsG7dTpB6DERMdfX0WL0ZehlTBEV6hdqy8bEdEyDxHIONpKU+oZpQvjKz0iYq8MleB6ICRR+KippspAKXylhwTVJfNVUK0Qrtjva0/LIoh3t2MBZTMdf3kQJ8ZLEZlCSD5jNS/f/ct/wAxsKQZXZ5LcSYU+UEqTnLMWCj63kWwa+RDifZELhycn1ZFNfo4uYKN2QaL70lS6bnwW4pv+WSFkiwtVVVuiXTkOmfTamLza6EdZtqOGsxwlrv78xSCE/UCTwq4vRXWCRMxMykyRwRTJmn1K93Hf7Y7JkpzcC/0oR6qfd3NhUyWTWk19Jb7hE4Qan22dVVi50U5VTPD0cSSvenEV3nm2NPeLa0+bIqJnYV85Wy11nt7flRlJnWjkFa8+lfm33CCMbivKi4ICqeQ1JgWgxXMu6B/nhgIPAk8zBX7+x3vP8XXEss7mnsSi9FmYoum5Lqivz406OZ4T98DAvF95WmeIAbwfjKiumKTCmTlSjnx3Ip0B8xwWkaSFHir70AhT0F1aLNnzz33Vgek14Cm811RD58rMEIypVAU7cCbIFSuINcPe0ruL6lum9mqU7vOBtGkt1dDV+HhZIZWwDH4JiJ2qouILfLmW10aEPG65oF3t/5bE/benoBdLiLrSCEBwH+PGzjNjqlhdxJr5LxVEWuwwrVq21HwDNGVdOO6QtX8D1jsRS+4CAOr7lYEgmzIqUSZ8VOUZaSr5T3E1qYoSRBiULiA+zVXBRpYuIeKA+jjeDk10JoenhKPrbuTXUSY70LTUOHXR0k7yapNxgco7LgfnwKDm5skguqSAJT3J3qetQ//jaMo7bq3MieWQoemrt3WMBawwyky0aabTuX9AF0eOVAMnjqDq9TaBWIucG3RmtUy8b7xhLnHTvF4gP9DAhl60NkhTK7hG8xpJyz2bwe7fbSK/UJj1dHUY+D6hqvTG0xUKWOJBYgyuAkyEBfjQ2BMjeZNOOFKJQbc52CGW8tgZqD2DyAsoO1gTRh+tMP5EPTVB2dda4Gh6hc0FQZp9MYMDgomi6mU6wZ2oYKSGmuBluIVV3PpdA6heToJKCtqK5enWDAV2IjF0ZJpq465fqT1Ut77wl9uz9upeewsk+Yhc9zWpiaLLgsENNev1RzdzjzNHoIo+Y5MEnMXHi5JeP80GRX+W9fFNDW68NKh5xyP0Iva9No1R+dUrv7qYcHz0GcFzSO9W7rJrcK8hXWXMogOmt9549o+oSiabf/e3CTb274NQ3dL8vKzEBp7b0Bypi+tGMcj0XsS9dSlzr4Eji2RDKR1HZZB+BzO8XHQ3hhN4Qvx0DFjaC86EUYPJYUPJ60u2WVWq55z1JtwQnQeG4IaVlYp1iTlNroLnq3fkd6T3e/zaWdMcHzhwM9mAMd7ygzyCKzQ9e58TxohG7auRyheL3ci9s8nNQeLub8p1f+wlc4uBytcEY/n47ScyizgqXqkOxdczMMT1LrKvViJ5lm/QpyUR1zxnV7p0hTg+LSpFGrSaHGGqYfapF7oYbOD2g3U8rS4vD5lOZeslu5GIXmZdUM05HkotWnl2RJu9Dh1QkkZ+iKLYNMLU/NN+AGsy0h4g8sOz4MToL3VO1dfHPA3Y+hTnn7HFtqeeJ+pRphjjOcjNfJajicTsG7k3XyrshnkpzBtUkzFtcp7yAOSC1PyQW1B5vp0E6JF2u9bpzVSKf0cqrxijuCerCw5eX045Y2BRvDl06p45k5eWcipi0T6XMQnYK3chwvpxm6vBwwdkHZTzrOT9JVOBoMNLQ68vHHB18wrSrYVgoZo+ipuoZSZUjWNA74iE6Zu3hPYw9fgp9wZLV5WnMYm1jaOtIo2TpNp9HuyO7NqvHxhU2o2kKET5SLcBGOwQTs0VbeccFXGW5jlhGoWevisVVXOBEvxutrLAfBdbq6NjPwxc8fPnUTlDKlGzeIs3yKRVznGWSXV2OdUYM8XKUfmTw8eX49wVIK5eH3ipyfP3wq1d1CK8P1kfV5wAnCNLzvPpozkFTGcxbT9MlS9XRarrGeNi739D1sFz2VBSVqfsL6vu6d273QpZanyVa1IhvMW6fIJp/b8cb4l+ZJGQ+4i8bI6xS7NiLLT45h6hXcZjdTYYca5GgL68goVrg5LY0f3UPIrbbXFiJx/0GkqtsVdwrdih2s/PhkSsdtzcu2h2Qw9KI+KHfBpg8qtWSzGUg81GKK2HVKNdBH2sO/hHz6AvTO6L+E3KA4Of8FP3Vu/8R7nzleASvvxrhkgC3znOKJITw72ykUn0VsvmdKUZirL/iQs9EWpZ4YPxqt2JVYtgcvfzGuhRtV7g6guV9knvMFcgs9RKFfRRFR1BZpu6rSd+H32K6vc1p0W2/oGSTlypVhKusYX15hodFOsV3ecrs5Qyr1hC2fDGuVkRhh+A9aEhnka5S+2A0no+tjue2xZe8VHB8Lq7HmzqmFzsb5V0WjJMQpZRkkgzT1Wk7SFyZ2Oy7zQyrieqXW6Kz16T9Oyex4SiZ81a9XF3ua8FQstv28SYPL+uYpSAzNzDOba4/qnBijSnDwdTZOejZrRtHExNYkbUkAPnHc1bIUWF4SJPoHe0IOj15vr7g5hw3V1X139hhns1ykLK4V+/ckOEmRKrKMNg7PaabxSdUPLr58XP9A0E30kOJEeF9RrvcrbVxdyPDDxrYpphmq89/Zrxv6s92PFWwDl6k1vBUOp9iekFSzuCdsDBaWpLB3ICh0FAqVAuSHoMQLHodG77Nsbg2MlTsKy28im7D995AVOwpJwV+4WPK9Q6kw1O5W4FkprBVLYryBiZfWzSVMLRks0DlKXLc4RNFZG6qkLNnFN7W+/9ple/9eKy1q54NwD5nUFtOrpz1X8PVyr43cIUhwfe93U/ZkMDWLcSk820hP+4f096bxGg/1p46FMZlAZr+gKkqs8DqeuUgT1YtErXgMyaGgCF5HY5YouKnLOMF2o7M2KCWwzvkuoxY3nK0Unw/DTWRjK9FOo9nWk2WCjxhSZXlrX8h9HJ3/JZaGPquPOWzGONOmSkpEHoRSDO/bmQvEtoSNb+eqrMy+vpEkpMl5A99c3n5KM5autlI4X3wzTtl3SSLx/r1tcwMwvNybRiwPQmN5F6qb/3gbvYneRjc4d7x98+bm9s3dD9/fvvvhb3e333/7l+9ub2/Ggf4ZcZD7B0ItepdOc3VDKCf3D4tvsLH7h8V35YdKMT26YemPoHaBUVnq9/btNvCxqQ18S8iEhhMg/IMBsmfGnXZHodwpMJxzjNqDqDaMwL9+d/325ub65uav13/5LuLLyL0TxSKLxmF++PiBSIiFTAJlmsABJfcP/hmdYoK7s5CQBcPKCQuQqh0AEOzCVIiXIh9GA+g0ecLt4SfBYRs+tlYfzzjBdAqxy8nk1yksIPU10C/g4893lz4eclxgp9mz/FgqIxPrJyJTOoG08RAELMIOBKX9+41ZCJ9PhYgmVEYzkVI+i4ScRefI73n9hbYyVb1vlOFrx/uizigea2WBK6BGOcECaQk+GD4WeVksHXdp2oLNF+Za57dff50Xk5TFqphO2WeDo/xwXyciLU/mCS4jenCDcf4NxbkunHg17U32sk+MBTpzI+5MWMVbELFbkET7exJElYZ0sm184kryVfP8QGCuonQQ3M4LDF+u+sIcw0B4grx9Q+I5tecdEe37x8uhUPf1iIneVuDzHlrwP+8mSqSFbtZig88QFzb5X34hCAkvgEV7M5xPleWg4DLDUzOlIXgOtxzdjMoDcRbemVp8NO+TwPu7ZhbF1BS/dAjKLCl6JFcIfOA6YL1wUhjbAA7fYYVCwTlOLWLtcnkIRB2I2a5de9fDcfWMAu9vAOWBGba60VU4ysXE4bCUTZi4SZ2FYOg432e/4Dpy+74JV9fqBjSCi35gfeCGGM+QThsI1gM2hrQZ9TCDOgA+hDbEwIokPxvanxuaR1o+3Z2ogX26+z0Z2Po/PMIib5VvC7PXA+TZiqg9GC70UDrXkJuJdkxCuZJEUaaGBhd+39ZXM2q9zXhe6Cf/oYylKXOFOs5GdQiuOt4/el0Zb4iKzv5vAPytHGM="
 }
@@ -31,7 +31,7 @@ import (
 	"github.com/elastic/beats/metricbeat/mb"
 	"github.com/elastic/beats/metricbeat/mb/parse"
 	"github.com/elastic/beats/metricbeat/module/system"
-	"github.com/elastic/gosigar/cgroup"
+	"github.com/elastic/beats/metricbeat/module/system/cgroup"
 )
 
 var debugf = logp.MakeDebug("system.process")
@@ -81,7 +81,7 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 
 		if config.Cgroups == nil || *config.Cgroups {
 			debugf("process cgroup data collection is enabled, using hostfs='%v'", systemModule.HostFS)
-			m.cgroup, err = cgroup.NewReader(systemModule.HostFS, true)
+			m.cgroup, err = cgroup.NewReader(systemModule.HostFS)
 			if err != nil {
 				if err == cgroup.ErrCgroupsMissing {
 					logp.Warn("cgroup data collection will be disabled: %v", err)
@@ -116,8 +116,8 @@ func (m *MetricSet) Fetch() ([]common.MapStr, error) {
 				continue
 			}
 
-			if statsMap := cgroupStatsToMap(stats); statsMap != nil {
-				proc["cgroup"] = statsMap
+			if stats != nil {
+				proc["cgroup"] = stats.Fields
 			}
 		}
 	}
@@ -35,6 +35,7 @@ import (
 	"github.com/elastic/beats/packetbeat/config"
 	"github.com/elastic/beats/packetbeat/decoder"
 	"github.com/elastic/beats/packetbeat/flows"
+	"github.com/elastic/beats/packetbeat/privileges"
 	"github.com/elastic/beats/packetbeat/procs"
 	"github.com/elastic/beats/packetbeat/protos"
 	"github.com/elastic/beats/packetbeat/protos/icmp"
@@ -57,6 +58,20 @@ type packetbeat struct {
 	pipeline beat.Pipeline
 	transPub *publish.TransactionPublisher
 	flows    *flows.Flows
+
+	// protocol analyzers of the running workers, updated when the ports of
+	// the protocols are changed on reload
+	analyzersMutex sync.Mutex
+	analyzers      []portsUpdater
+	flushers       []protos.Flusher
+
+	// ready reports packetbeat as ready to the service manager
+	ready func()
+}
+
+// portsUpdater is implemented by the TCP and UDP analyzers.
+type portsUpdater interface {
+	UpdatePorts() error
 }
 
 type flags struct {
@@ -64,6 +79,7 @@ type flags struct {
 	loop       *int
 	oneAtAtime *bool
 	topSpeed   *bool
+	speed      *float64
 	dumpfile   *string
 }
 
@@ -75,23 +91,14 @@ func init() {
 		loop:       flag.Int("l", 1, "Loop file. 0 - loop forever"),
 		oneAtAtime: flag.Bool("O", false, "Read packets one at a time (press Enter)"),
 		topSpeed:   flag.Bool("t", false, "Read packets as fast as possible, without sleeping"),
+		speed:      flag.Float64("speed", 1, "Speed factor of the file replay, 2 replays twice as fast"),
 		dumpfile:   flag.String("dump", "", "Write all captured packets to this libpcap file"),
 	}
 }
 
 func New(b *beat.Beat, rawConfig *common.Config) (beat.Beater, error) {
-	config := config.Config{
-		Interfaces: config.InterfacesConfig{
-			File:       *cmdLineArgs.file,
-			Loop:       *cmdLineArgs.loop,
-			TopSpeed:   *cmdLineArgs.topSpeed,
-			OneAtATime: *cmdLineArgs.oneAtAtime,
-			Dumpfile:   *cmdLineArgs.dumpfile,
-		},
-	}
-	err := rawConfig.Unpack(&config)
+	config, err := readConfig(rawConfig)
 	if err != nil {
-		logp.Err("fails to read the beat config: %v, %v", err, config)
 		return nil, err
 	}
 
@@ -107,6 +114,28 @@ func New(b *beat.Beat, rawConfig *common.Config) (beat.Beater, error) {
 	return pb, nil
 }
 
+func readConfig(rawConfig *common.Config) (config.Config, error) {
+	config := config.Config{
+		Interfaces: config.InterfacesConfig{
+			File:        *cmdLineArgs.file,
+			Loop:        *cmdLineArgs.loop,
+			TopSpeed:    *cmdLineArgs.topSpeed,
+			ReplaySpeed: *cmdLineArgs.speed,
+			OneAtATime:  *cmdLineArgs.oneAtAtime,
+			Dumpfile:    *cmdLineArgs.dumpfile,
+		},
+		TCP: config.TCPConfig{
+			ReassemblyWindow: tcp.DefaultReassemblyWindow,
+		},
+	}
+	err := rawConfig.Unpack(&config)
+	if err != nil {
+		logp.Err("fails to read the beat config: %v, %v", err, config)
+		return config, err
+	}
+	return config, nil
+}
+
 // init packetbeat components
 func (pb *packetbeat) init(b *beat.Beat) error {
 	var err error
@@ -128,11 +157,32 @@ func (pb *packetbeat) init(b *beat.Beat) error {
 		b.Publisher,
 		pb.config.IgnoreOutgoing,
 		pb.config.Interfaces.File == "",
+		pb.config.PublishQueue,
+		pb.config.ECS,
+		pb.config.RateLimit,
 	)
 	if err != nil {
 		return err
 	}
 
+	if pb.config.ShutdownTimeout > 0 {
+		pb.transPub.SetWaitClose(pb.config.ShutdownTimeout)
+	}
+
+	if pb.config.ValidateFields {
+		logp.Warn("Validating the published events against the fields definition, this slows down publishing")
+		if err := pb.transPub.ValidateFields(b.Fields); err != nil {
+			return fmt.Errorf("failed to load the fields definition: %v", err)
+		}
+	}
+
+	if timeout := service.WatchdogTimeout(); timeout > 0 {
+		// stop notifying the watchdog if publishing events hangs
+		service.AddWatchdogCheck(func() error {
+			return pb.transPub.CheckStalled(timeout / 2)
+		})
+	}
+
 	logp.Debug("main", "Initializing protocol plugins")
 	err = protos.Protos.Init(false, pb.transPub, cfg.Protocols, cfg.ProtocolsList)
 	if err != nil {
@@ -147,23 +197,35 @@ func (pb *packetbeat) init(b *beat.Beat) error {
 }
 
 func (pb *packetbeat) setupSniffer() error {
-	config := &pb.config
-
-	icmp, err := pb.icmpConfig()
+	filter, err := bpfFilter(&pb.config)
 	if err != nil {
 		return err
 	}
 
+	pb.sniff, err = sniffer.New(false, filter, pb.createWorker, pb.config.Interfaces)
+	return err
+}
+
+// bpfFilter returns the configured BPF filter. If none is configured, the
+// filter is generated from the ports of the enabled protocols, extended by
+// the optional extra expression.
+func bpfFilter(config *config.Config) (string, error) {
+	icmp, err := icmpConfig(config)
+	if err != nil {
+		return "", err
+	}
+
 	withVlans := config.Interfaces.WithVlans
 	withICMP := icmp.Enabled()
 
 	filter := config.Interfaces.BpfFilter
 	if filter == "" && !config.Flows.IsEnabled() {
 		filter = protos.Protos.BpfFilter(withVlans, withICMP)
+		if extra := config.Interfaces.ExtraBpfFilter; extra != "" {
+			filter = fmt.Sprintf("(%s) or (%s)", filter, extra)
+		}
 	}
-
-	pb.sniff, err = sniffer.New(false, filter, pb.createWorker, config.Interfaces)
-	return err
+	return filter, nil
 }
 
 func (pb *packetbeat) setupFlows() error {
@@ -202,11 +264,18 @@ func (pb *packetbeat) Run(b *beat.Beat) error {
 		}
 	}()
 
-	defer pb.transPub.Stop()
+	defer pb.shutdown()
 
-	timeout := pb.config.ShutdownTimeout
-	if timeout > 0 {
-		defer time.Sleep(timeout)
+	// open the capture device before dropping the privileges required to
+	// open it
+	if err := pb.sniff.Open(); err != nil {
+		return err
+	}
+	if err := privileges.Drop(pb.config.Runtime); err != nil {
+		return err
+	}
+	if pb.ready != nil {
+		pb.ready()
 	}
 
 	if pb.flows != nil {
@@ -239,6 +308,131 @@ func (pb *packetbeat) Run(b *beat.Beat) error {
 	return nil
 }
 
+// shutdown publishes the transactions in progress and the events still
+// buffered, waiting for the output to acknowledge them, for up to
+// shutdown_timeout. Without timeout, the events not yet published are dropped.
+func (pb *packetbeat) shutdown() {
+	defer pb.transPub.Stop()
+
+	timeout := pb.config.ShutdownTimeout
+	if timeout <= 0 {
+		return
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+
+		pb.analyzersMutex.Lock()
+		for _, flusher := range pb.flushers {
+			flusher.Flush()
+		}
+		pb.analyzersMutex.Unlock()
+		protos.Protos.Flush()
+
+		pb.transPub.Drain()
+	}()
+
+	logp.Info("Publishing the pending events, waiting up to %v", timeout)
+	select {
+	case <-done:
+		logp.Info("Pending events published")
+	case <-time.After(timeout):
+		logp.Warn("Shutdown timeout of %v reached, dropping the events not yet published", timeout)
+	}
+}
+
+// SetReadyCallback sets the function reporting packetbeat as ready, which is
+// called once the capture device is open.
+func (pb *packetbeat) SetReadyCallback(ready func()) {
+	pb.ready = ready
+}
+
+// Reload applies the BPF filter and the ports of the enabled protocols from
+// a new configuration. Other settings are only applied on restart. If the new
+// ports or filter are invalid, the running configuration is kept.
+func (pb *packetbeat) Reload(rawConfig *common.Config) error {
+	config, err := readConfig(rawConfig)
+	if err != nil {
+		return err
+	}
+
+	ports, err := protocolPorts(&config)
+	if err != nil {
+		return err
+	}
+
+	oldPorts := map[protos.Protocol][]int{}
+	for proto := range ports {
+		oldPorts[proto] = protos.Protos.GetPorts(proto)
+	}
+	if err := protos.Protos.SetPorts(ports); err != nil {
+		return err
+	}
+
+	filter, err := bpfFilter(&config)
+	if err == nil {
+		err = pb.sniff.SetFilter(filter)
+	}
+	if err != nil {
+		// restoring the ports validated before can not fail
+		protos.Protos.SetPorts(oldPorts)
+		return err
+	}
+
+	pb.analyzersMutex.Lock()
+	defer pb.analyzersMutex.Unlock()
+	for _, analyzer := range pb.analyzers {
+		if err := analyzer.UpdatePorts(); err != nil {
+			logp.Err("Failed to update the protocol ports: %v", err)
+		}
+	}
+	return nil
+}
+
+// protocolPorts returns the ports configured for the enabled protocols.
+// Protocols without ports setting are not included.
+func protocolPorts(config *config.Config) (map[protos.Protocol][]int, error) {
+	ports := map[protos.Protocol][]int{}
+	add := func(name string, cfg *common.Config) error {
+		if name == "icmp" || !cfg.Enabled() || !cfg.HasField("ports") {
+			return nil
+		}
+
+		proto := protos.Lookup(name)
+		if proto == protos.UnknownProtocol {
+			return fmt.Errorf("unknown protocol plugin: %v", name)
+		}
+
+		settings := struct {
+			Ports []int `config:"ports"`
+		}{}
+		if err := cfg.Unpack(&settings); err != nil {
+			return err
+		}
+		ports[proto] = settings.Ports
+		return nil
+	}
+
+	for name, cfg := range config.Protocols {
+		if err := add(name, cfg); err != nil {
+			return nil, err
+		}
+	}
+	for _, cfg := range config.ProtocolsList {
+		info := struct {
+			Type string `config:"type" validate:"required"`
+		}{}
+		if err := cfg.Unpack(&info); err != nil {
+			return nil, err
+		}
+		if err := add(info.Type, cfg); err != nil {
+			return nil, err
+		}
+	}
+	return ports, nil
+}
+
 // Called by the Beat stop function
 func (pb *packetbeat) Stop() {
 	logp.Info("Packetbeat send stop signal")
@@ -248,7 +442,7 @@ func (pb *packetbeat) Stop() {
 func (pb *packetbeat) createWorker(dl layers.LinkType) (sniffer.Worker, error) {
 	var icmp4 icmp.ICMPv4Processor
 	var icmp6 icmp.ICMPv6Processor
-	cfg, err := pb.icmpConfig()
+	cfg, err := icmpConfig(&pb.config)
 	if err != nil {
 		return nil, err
 	}
@@ -265,9 +459,13 @@ func (pb *packetbeat) createWorker(dl layers.LinkType) (sniffer.Worker, error) {
 
 		icmp4 = icmp
 		icmp6 = icmp
+
+		pb.analyzersMutex.Lock()
+		pb.flushers = append(pb.flushers, icmp)
+		pb.analyzersMutex.Unlock()
 	}
 
-	tcp, err := tcp.NewTCP(&protos.Protos)
+	tcp, err := tcp.NewTCP(&protos.Protos, pb.config.TCP)
 	if err != nil {
 		return nil, err
 	}
@@ -281,17 +479,23 @@ func (pb *packetbeat) createWorker(dl layers.LinkType) (sniffer.Worker, error) {
 	if err != nil {
 		return nil, err
 	}
+	worker.SetTunnels(pb.transPub.Tunnels())
+
+	pb.analyzersMutex.Lock()
+	pb.analyzers = append(pb.analyzers, tcp, udp)
+	pb.flushers = append(pb.flushers, tcp, udp)
+	pb.analyzersMutex.Unlock()
 
 	return worker, nil
 }
 
-func (pb *packetbeat) icmpConfig() (*common.Config, error) {
+func icmpConfig(config *config.Config) (*common.Config, error) {
 	var icmp *common.Config
-	if pb.config.Protocols["icmp"].Enabled() {
-		icmp = pb.config.Protocols["icmp"]
+	if config.Protocols["icmp"].Enabled() {
+		icmp = config.Protocols["icmp"]
 	}
 
-	for _, cfg := range pb.config.ProtocolsList {
+	for _, cfg := range config.ProtocolsList {
 		info := struct {
 			Type string `config:"type" validate:"required"`
 		}{}
@@ -28,25 +28,43 @@ import (
 type Config struct {
 	Interfaces      InterfacesConfig          `config:"interfaces"`
 	Flows           *Flows                    `config:"flows"`
+	TCP             TCPConfig                 `config:"tcp"`
 	Protocols       map[string]*common.Config `config:"protocols"`
 	ProtocolsList   []*common.Config          `config:"protocols"`
 	Procs           procs.ProcsConfig         `config:"procs"`
 	IgnoreOutgoing  bool                      `config:"ignore_outgoing"`
+	PublishQueue    *common.Config            `config:"publish_queue"`
+	ECS             *common.Config            `config:"ecs"`
+	RateLimit       *common.Config            `config:"rate_limit"`
+	Runtime         RuntimeConfig             `config:"runtime"`
+	ValidateFields  bool                      `config:"validate_fields"`
 	ShutdownTimeout time.Duration             `config:"shutdown_timeout"`
 }
 
+// RuntimeConfig sets the user and group packetbeat switches to after opening
+// the capture device.
+type RuntimeConfig struct {
+	User  string `config:"user"`
+	Group string `config:"group"`
+}
+
 type InterfacesConfig struct {
-	Device       string `config:"device"`
-	Type         string `config:"type"`
-	File         string `config:"file"`
-	WithVlans    bool   `config:"with_vlans"`
-	BpfFilter    string `config:"bpf_filter"`
-	Snaplen      int    `config:"snaplen"`
-	BufferSizeMb int    `config:"buffer_size_mb"`
-	TopSpeed     bool
-	Dumpfile     string
-	OneAtATime   bool
-	Loop         int
+	Device         string `config:"device"`
+	Type           string `config:"type"`
+	File           string `config:"file"`
+	WithVlans      bool   `config:"with_vlans"`
+	BpfFilter      string `config:"bpf_filter"`
+	ExtraBpfFilter string `config:"extra_bpf_filter"`
+	Snaplen        int    `config:"snaplen"`
+	BufferSizeMb   int    `config:"buffer_size_mb"`
+	FanoutGroup    *int   `config:"fanout_group"`
+	ClusterID      *int   `config:"cluster_id"`
+	Workers        int    `config:"workers"`
+	TopSpeed       bool
+	ReplaySpeed    float64
+	Dumpfile       string
+	OneAtATime     bool
+	Loop           int
 }
 
 type Flows struct {
@@ -57,11 +75,16 @@ type Flows struct {
 	Processors    processors.PluginConfig `config:"processors"`
 }
 
+type TCPConfig struct {
+	ReassemblyWindow int `config:"reassembly_window"`
+}
+
 type ProtocolCommon struct {
 	Ports              []int         `config:"ports"`
 	SendRequest        bool          `config:"send_request"`
 	SendResponse       bool          `config:"send_response"`
 	TransactionTimeout time.Duration `config:"transaction_timeout"`
+	MaxTransactions    int           `config:"max_transactions"`
 }
 
 func (f *Flows) IsEnabled() bool {
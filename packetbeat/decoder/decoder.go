@@ -21,11 +21,13 @@ import (
 	"fmt"
 
 	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/monitoring"
 	"github.com/elastic/beats/packetbeat/flows"
 	"github.com/elastic/beats/packetbeat/protos"
 	"github.com/elastic/beats/packetbeat/protos/icmp"
 	"github.com/elastic/beats/packetbeat/protos/tcp"
 	"github.com/elastic/beats/packetbeat/protos/udp"
+	"github.com/elastic/beats/packetbeat/publish"
 
 	"github.com/tsg/gopacket"
 	"github.com/tsg/gopacket/layers"
@@ -33,6 +35,11 @@ import (
 
 var debugf = logp.MakeDebug("decoder")
 
+var (
+	decodeErrors  = monitoring.NewInt(nil, "decoder.decode_errors")
+	processErrors = monitoring.NewInt(nil, "decoder.process_errors")
+)
+
 type Decoder struct {
 	decoders         map[gopacket.LayerType]gopacket.DecodingLayer
 	linkLayerDecoder gopacket.DecodingLayer
@@ -40,16 +47,23 @@ type Decoder struct {
 
 	sll       layers.LinuxSLL
 	lo        layers.Loopback
-	eth       layers.Ethernet
-	d1q       [2]layers.Dot1Q
-	ip4       [2]layers.IPv4
-	ip6       [2]layers.IPv6
+	eth       ethernet
+	d1q       [2]dot1q
+	ip4       [2]ipv4
+	ip6       [2]ipv6
+	ip6Ext    ipv6Extension
+	ip6Frag   ipv6Fragment
+	gre       gre
+	vxlan     vxlan
 	icmp4     layers.ICMPv4
 	icmp6     layers.ICMPv6
 	tcp       layers.TCP
-	udp       layers.UDP
+	udp       udpTunnel
 	truncated bool
 
+	tunnel  tunnelState
+	tunnels *publish.Tunnels
+
 	stD1Q, stIP4, stIP6 multiLayer
 
 	icmp4Proc icmp.ICMPv4Processor
@@ -107,6 +121,8 @@ func New(
 		&d.lo,              // loopback on OS X
 		&d.stD1Q,           // VLAN
 		&d.stIP4, &d.stIP6, // IP
+		&d.ip6Ext, &d.ip6Frag, // IPv6 extension headers
+		&d.gre, &d.vxlan, // tunnels
 		&d.icmp4, &d.icmp6, // ICMP
 		&d.tcp, &d.udp, // TCP/UDP
 	}
@@ -131,6 +147,12 @@ func New(
 	return &d, nil
 }
 
+// SetTunnels sets the table the tunnels of decapsulated packets are recorded
+// in.
+func (d *Decoder) SetTunnels(t *publish.Tunnels) {
+	d.tunnels = t
+}
+
 func (d *Decoder) SetTruncated() {
 	d.truncated = true
 }
@@ -151,6 +173,7 @@ func (d *Decoder) OnPacket(data []byte, ci *gopacket.CaptureInfo) {
 	defer logp.Recover("packet decoding failed")
 
 	d.truncated = false
+	d.tunnel.reset()
 
 	current := d.linkLayerDecoder
 	currentType := d.linkLayerType
@@ -171,6 +194,7 @@ func (d *Decoder) OnPacket(data []byte, ci *gopacket.CaptureInfo) {
 	for len(data) > 0 {
 		err := current.DecodeFromBytes(data, d)
 		if err != nil {
+			decodeErrors.Add(1)
 			logp.Info("packet decode failed with: %v", err)
 			break
 		}
@@ -180,6 +204,7 @@ func (d *Decoder) OnPacket(data []byte, ci *gopacket.CaptureInfo) {
 
 		processed, err = d.process(&packet, currentType)
 		if err != nil {
+			processErrors.Add(1)
 			logp.Info("Error processing packet: %v", err)
 			break
 		}
@@ -233,6 +258,7 @@ func (d *Decoder) process(
 		debugf("IPv4 packet")
 		ip4 := &d.ip4[d.stIP4.i]
 		d.stIP4.next()
+		d.tunnel.onIP(packet)
 
 		if withFlow {
 			d.flowID.AddIPv4(ip4.SrcIP, ip4.DstIP)
@@ -246,6 +272,7 @@ func (d *Decoder) process(
 		debugf("IPv6 packet")
 		ip6 := &d.ip6[d.stIP6.i]
 		d.stIP6.next()
+		d.tunnel.onIP(packet)
 
 		if withFlow {
 			d.flowID.AddIPv6(ip6.SrcIP, ip6.DstIP)
@@ -255,6 +282,22 @@ func (d *Decoder) process(
 		packet.Tuple.DstIP = ip6.DstIP
 		packet.Tuple.IPLength = 16
 
+	case layers.LayerTypeGRE:
+		debugf("GRE packet")
+		if d.tunnel.tunnel.Type == "" {
+			d.tunnel.tunnel.Type = "gre"
+			d.tunnel.tunnel.ID = d.gre.key
+			d.tunnel.tunnel.HasID = d.gre.hasKey
+		}
+
+	case layerTypeVXLAN:
+		debugf("VXLAN packet")
+		if d.tunnel.tunnel.Type == "" {
+			d.tunnel.tunnel.Type = "vxlan"
+			d.tunnel.tunnel.ID = d.vxlan.vni
+			d.tunnel.tunnel.HasID = true
+		}
+
 	case layers.LayerTypeICMPv4:
 		debugf("ICMPv4 packet")
 		d.onICMPv4(packet)
@@ -267,6 +310,10 @@ func (d *Decoder) process(
 
 	case layers.LayerTypeUDP:
 		debugf("UDP packet")
+		if d.udp.DstPort == vxlanPort {
+			// continue with the encapsulated packet
+			return false, nil
+		}
 		d.onUDP(packet)
 		return true, nil
 
@@ -283,6 +330,7 @@ func (d *Decoder) onICMPv4(packet *protos.Packet) {
 	if d.icmp4Proc != nil {
 		packet.Payload = d.icmp4.Payload
 		packet.Tuple.ComputeHashables()
+		d.recordTunnel(packet)
 		d.icmp4Proc.ProcessICMPv4(d.flowID, &d.icmp4, packet)
 	}
 }
@@ -291,6 +339,7 @@ func (d *Decoder) onICMPv6(packet *protos.Packet) {
 	if d.icmp6Proc != nil {
 		packet.Payload = d.icmp6.Payload
 		packet.Tuple.ComputeHashables()
+		d.recordTunnel(packet)
 		d.icmp6Proc.ProcessICMPv6(d.flowID, &d.icmp6, packet)
 	}
 }
@@ -308,6 +357,7 @@ func (d *Decoder) onUDP(packet *protos.Packet) {
 	packet.Tuple.DstPort = dst
 	packet.Payload = d.udp.Payload
 	packet.Tuple.ComputeHashables()
+	d.recordTunnel(packet)
 
 	d.udpProc.Process(id, packet)
 }
@@ -331,5 +381,12 @@ func (d *Decoder) onTCP(packet *protos.Packet) {
 		return
 	}
 	packet.Tuple.ComputeHashables()
+	d.recordTunnel(packet)
 	d.tcpProc.Process(id, &d.tcp, packet)
 }
+
+func (d *Decoder) recordTunnel(packet *protos.Packet) {
+	if d.tunnels != nil && d.tunnel.outerIP {
+		d.tunnels.Record(packet.Tuple.SrcIP, packet.Tuple.DstIP, &d.tunnel.tunnel)
+	}
+}
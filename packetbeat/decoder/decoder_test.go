@@ -20,12 +20,14 @@
 package decoder
 
 import (
+	"net"
 	"strings"
 	"testing"
 
 	"github.com/elastic/beats/libbeat/logp"
 	"github.com/elastic/beats/packetbeat/flows"
 	"github.com/elastic/beats/packetbeat/protos"
+	"github.com/elastic/beats/packetbeat/publish"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/tsg/gopacket"
@@ -197,6 +199,229 @@ func TestDecodePacketData_ipv6Udp(t *testing.T) {
 	assert.NotEqual(t, -1, strings.Index(string(p.Data()), string(udp.pkt.Payload)))
 }
 
+type ipv6TestExtension struct {
+	proto  layers.IPProtocol
+	header []byte // next header field is filled in by ipv6UDPWithExtensions
+}
+
+// ipv6UDPWithExtensions builds an Ethernet/IPv6/UDP packet from
+// 2001:db8::1.5353 to 2001:db8::2.53 with the given extension headers chained
+// between the IPv6 and UDP headers.
+func ipv6UDPWithExtensions(extensions ...ipv6TestExtension) []byte {
+	payload := []byte{0x14, 0xe9, 0x00, 0x35, 0x00, 0x0c, 0x00, 0x00, 'p', 'i', 'n', 'g'}
+	nextHeader := layers.IPProtocolUDP
+	for i := len(extensions) - 1; i >= 0; i-- {
+		header := append([]byte{byte(nextHeader)}, extensions[i].header[1:]...)
+		payload = append(header, payload...)
+		nextHeader = extensions[i].proto
+	}
+
+	packet := []byte{
+		0x00, 0x0c, 0x29, 0xce, 0xd1, 0x9e, 0x00, 0x0c, 0x29, 0x7e, 0xec, 0xa4, 0x86, 0xdd,
+		0x60, 0x00, 0x00, 0x00, byte(len(payload) >> 8), byte(len(payload)), byte(nextHeader), 0x40,
+	}
+	packet = append(packet, net.ParseIP("2001:db8::1").To16()...)
+	packet = append(packet, net.ParseIP("2001:db8::2").To16()...)
+	return append(packet, payload...)
+}
+
+var (
+	ipv6HopByHopPadding = ipv6TestExtension{layers.IPProtocolIPv6HopByHop,
+		[]byte{0, 0, 0x01, 0x04, 0, 0, 0, 0}}
+	ipv6DestinationPadding = ipv6TestExtension{layers.IPProtocolIPv6Destination,
+		[]byte{0, 0, 0x01, 0x04, 0, 0, 0, 0}}
+	ipv6RoutingNoSegments = ipv6TestExtension{layers.IPProtocolIPv6Routing,
+		append([]byte{0, 2, 0, 0, 0, 0, 0, 0}, net.ParseIP("2001:db8::3").To16()...)}
+	ipv6AtomicFragment = ipv6TestExtension{layers.IPProtocolIPv6Fragment,
+		[]byte{0, 0, 0x00, 0x00, 0, 0, 0, 1}}
+	ipv6FirstFragment = ipv6TestExtension{layers.IPProtocolIPv6Fragment,
+		[]byte{0, 0, 0x00, 0x01, 0, 0, 0, 1}}
+)
+
+// Test that DecodePacket follows IPv6 extension header chains and invokes the
+// UDP processor.
+func TestDecodePacketData_ipv6Extensions(t *testing.T) {
+	data := ipv6UDPWithExtensions(
+		ipv6HopByHopPadding,
+		ipv6DestinationPadding,
+		ipv6RoutingNoSegments,
+		ipv6AtomicFragment,
+	)
+	d, _, udp := newTestDecoder(t)
+	d.OnPacket(data, &gopacket.CaptureInfo{Length: len(data), CaptureLength: len(data)})
+
+	assert.NotNil(t, udp.pkt, "UDP packet not received")
+	assert.Equal(t, "2001:db8::1", udp.pkt.Tuple.SrcIP.String())
+	assert.Equal(t, uint16(5353), udp.pkt.Tuple.SrcPort)
+	assert.Equal(t, "2001:db8::2", udp.pkt.Tuple.DstIP.String())
+	assert.Equal(t, uint16(53), udp.pkt.Tuple.DstPort)
+	assert.Equal(t, "ping", string(udp.pkt.Payload))
+}
+
+// Test that fragmented IPv6 packets are not passed to the UDP processor.
+func TestDecodePacketData_ipv6Fragment(t *testing.T) {
+	data := ipv6UDPWithExtensions(ipv6FirstFragment)
+	d, _, udp := newTestDecoder(t)
+	d.OnPacket(data, &gopacket.CaptureInfo{Length: len(data), CaptureLength: len(data)})
+
+	assert.Nil(t, udp.pkt)
+}
+
+// Test that truncated IPv6 extension headers are rejected.
+func TestDecodePacketData_ipv6TruncatedExtension(t *testing.T) {
+	data := ipv6UDPWithExtensions(ipv6RoutingNoSegments)
+	data[14+40+1] = 8 // claim a header longer than the packet
+	d, _, udp := newTestDecoder(t)
+	d.OnPacket(data, &gopacket.CaptureInfo{Length: len(data), CaptureLength: len(data)})
+
+	assert.Nil(t, udp.pkt)
+}
+
+// Test that packets failing to decode are counted.
+func TestDecodePacketData_decodeErrors(t *testing.T) {
+	data := testEthernet(layers.EthernetTypeIPv4, nil)[:10]
+	d, _, udp := newTestDecoder(t)
+	before := decodeErrors.Get()
+	d.OnPacket(data, &gopacket.CaptureInfo{Length: len(data), CaptureLength: len(data)})
+
+	assert.Nil(t, udp.pkt)
+	assert.Equal(t, before+1, decodeErrors.Get())
+}
+
+func testEthernet(typ layers.EthernetType, payload []byte) []byte {
+	header := []byte{
+		0x00, 0x0c, 0x29, 0xce, 0xd1, 0x9e, 0x00, 0x0c, 0x29, 0x7e, 0xec, 0xa4,
+		byte(typ >> 8), byte(typ),
+	}
+	return append(header, payload...)
+}
+
+func testDot1Q(vlan uint16, typ layers.EthernetType, payload []byte) []byte {
+	header := []byte{byte(vlan >> 8), byte(vlan), byte(typ >> 8), byte(typ)}
+	return append(header, payload...)
+}
+
+func testIPv4(proto layers.IPProtocol, src, dst string, payload []byte) []byte {
+	length := 20 + len(payload)
+	header := []byte{0x45, 0x00, byte(length >> 8), byte(length), 0, 0, 0, 0, 64, byte(proto), 0, 0}
+	header = append(header, net.ParseIP(src).To4()...)
+	header = append(header, net.ParseIP(dst).To4()...)
+	return append(header, payload...)
+}
+
+func testUDP(src, dst uint16, payload []byte) []byte {
+	length := 8 + len(payload)
+	header := []byte{byte(src >> 8), byte(src), byte(dst >> 8), byte(dst), byte(length >> 8), byte(length), 0, 0}
+	return append(header, payload...)
+}
+
+func testVXLAN(vni uint32, payload []byte) []byte {
+	header := []byte{0x08, 0, 0, 0, byte(vni >> 16), byte(vni >> 8), byte(vni), 0}
+	return append(header, payload...)
+}
+
+func testGRE(typ layers.EthernetType, key uint32, payload []byte) []byte {
+	header := []byte{0x20, 0, byte(typ >> 8), byte(typ), byte(key >> 24), byte(key >> 16), byte(key >> 8), byte(key)}
+	return append(header, payload...)
+}
+
+// inner packet of all tunnel tests: 10.0.0.1.5353 > 10.0.0.2.53
+func testInnerIPv4UDP() []byte {
+	return testIPv4(layers.IPProtocolUDP, "10.0.0.1", "10.0.0.2", testUDP(5353, 53, []byte("ping")))
+}
+
+// Test that DecodePacket decapsulates tunneled packets, invokes the UDP
+// processor with the inner packet and records the outer addresses.
+func TestDecodePacketData_tunnels(t *testing.T) {
+	tests := []struct {
+		name   string
+		packet []byte
+		tunnel publish.Tunnel
+	}{
+		{
+			name: "vxlan",
+			packet: testEthernet(layers.EthernetTypeIPv4,
+				testIPv4(layers.IPProtocolUDP, "192.168.0.1", "192.168.0.2",
+					testUDP(40000, vxlanPort,
+						testVXLAN(4242,
+							testEthernet(layers.EthernetTypeIPv4, testInnerIPv4UDP()))))),
+			tunnel: publish.Tunnel{Type: "vxlan", ID: 4242, HasID: true},
+		},
+		{
+			name: "gre with key",
+			packet: testEthernet(layers.EthernetTypeIPv4,
+				testIPv4(layers.IPProtocolGRE, "192.168.0.1", "192.168.0.2",
+					testGRE(layers.EthernetTypeIPv4, 7, testInnerIPv4UDP()))),
+			tunnel: publish.Tunnel{Type: "gre", ID: 7, HasID: true},
+		},
+		{
+			name: "gre transparent ethernet bridging",
+			packet: testEthernet(layers.EthernetTypeIPv4,
+				testIPv4(layers.IPProtocolGRE, "192.168.0.1", "192.168.0.2",
+					testGRE(ethernetTypeTEB, 8,
+						testEthernet(layers.EthernetTypeIPv4, testInnerIPv4UDP())))),
+			tunnel: publish.Tunnel{Type: "gre", ID: 8, HasID: true},
+		},
+		{
+			name: "ip in ip",
+			packet: testEthernet(layers.EthernetTypeIPv4,
+				testIPv4(ipProtocolIPv4, "192.168.0.1", "192.168.0.2", testInnerIPv4UDP())),
+			tunnel: publish.Tunnel{Type: "ipip"},
+		},
+		{
+			name: "vxlan in qinq",
+			packet: testEthernet(ethernetTypeQinQ,
+				testDot1Q(100, layers.EthernetTypeDot1Q,
+					testDot1Q(200, layers.EthernetTypeIPv4,
+						testIPv4(layers.IPProtocolUDP, "192.168.0.1", "192.168.0.2",
+							testUDP(40000, vxlanPort,
+								testVXLAN(1,
+									testEthernet(layers.EthernetTypeIPv4, testInnerIPv4UDP()))))))),
+			tunnel: publish.Tunnel{Type: "vxlan", ID: 1, HasID: true},
+		},
+	}
+
+	for _, test := range tests {
+		d, _, udp := newTestDecoder(t)
+		tunnels := publish.NewTunnels()
+		d.SetTunnels(tunnels)
+		d.OnPacket(test.packet, &gopacket.CaptureInfo{Length: len(test.packet), CaptureLength: len(test.packet)})
+
+		if !assert.NotNil(t, udp.pkt, test.name) {
+			continue
+		}
+		assert.Equal(t, "10.0.0.1", udp.pkt.Tuple.SrcIP.String(), test.name)
+		assert.Equal(t, uint16(5353), udp.pkt.Tuple.SrcPort, test.name)
+		assert.Equal(t, "10.0.0.2", udp.pkt.Tuple.DstIP.String(), test.name)
+		assert.Equal(t, uint16(53), udp.pkt.Tuple.DstPort, test.name)
+		assert.Equal(t, "ping", string(udp.pkt.Payload), test.name)
+
+		tunnel := tunnels.Lookup(net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.2"))
+		if assert.NotNil(t, tunnel, test.name) {
+			assert.Equal(t, test.tunnel.Type, tunnel.Type, test.name)
+			assert.Equal(t, test.tunnel.ID, tunnel.ID, test.name)
+			assert.Equal(t, test.tunnel.HasID, tunnel.HasID, test.name)
+			assert.Equal(t, "192.168.0.1", tunnel.SrcIP.String(), test.name)
+			assert.Equal(t, "192.168.0.2", tunnel.DstIP.String(), test.name)
+		}
+	}
+}
+
+// Test that DecodePacket decodes packets with 802.1ad (QinQ) VLAN tags.
+func TestDecodePacketData_qinq(t *testing.T) {
+	data := testEthernet(ethernetTypeQinQ,
+		testDot1Q(100, layers.EthernetTypeDot1Q,
+			testDot1Q(200, layers.EthernetTypeIPv4, testInnerIPv4UDP())))
+	d, _, udp := newTestDecoder(t)
+	d.OnPacket(data, &gopacket.CaptureInfo{Length: len(data), CaptureLength: len(data)})
+
+	if assert.NotNil(t, udp.pkt, "UDP packet not received") {
+		assert.Equal(t, "10.0.0.1", udp.pkt.Tuple.SrcIP.String())
+		assert.Equal(t, "10.0.0.2", udp.pkt.Tuple.DstIP.String())
+		assert.Equal(t, "ping", string(udp.pkt.Payload))
+	}
+}
+
 // Creates a new TestDecoder that handles ethernet packets.
 func newTestDecoder(t *testing.T) (*Decoder, *TestTCPProcessor, *TestUDPProcessor) {
 	icmp4Layer := &TestIcmp4Processor{}
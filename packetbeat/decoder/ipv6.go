@@ -0,0 +1,107 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package decoder
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/tsg/gopacket"
+	"github.com/tsg/gopacket/layers"
+)
+
+var (
+	errIPv6ExtensionTooShort = errors.New("IPv6 extension header too short")
+
+	layerClassIPv6Options = gopacket.NewLayerClass([]gopacket.LayerType{
+		layers.LayerTypeIPv6HopByHop,
+		layers.LayerTypeIPv6Routing,
+		layers.LayerTypeIPv6Destination,
+	})
+)
+
+type ipv6 struct {
+	layers.IPv6
+}
+
+func (ip6 *ipv6) NextLayerType() gopacket.LayerType {
+	if ip6.HopByHop != nil {
+		return ipLayerType(ip6.HopByHop.NextHeader)
+	}
+	return ipLayerType(ip6.NextHeader)
+}
+
+// ipv6Extension skips IPv6 extension headers sharing the generic
+// next-header/length layout (hop-by-hop, routing and destination options).
+// Chained headers are decoded one after another by the same instance.
+type ipv6Extension struct {
+	layers.BaseLayer
+	nextHeader layers.IPProtocol
+}
+
+func (e *ipv6Extension) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 8 {
+		return errIPv6ExtensionTooShort
+	}
+	length := int(data[1])*8 + 8
+	if length > len(data) {
+		return errIPv6ExtensionTooShort
+	}
+	e.nextHeader = layers.IPProtocol(data[0])
+	e.BaseLayer = layers.BaseLayer{Contents: data[:length], Payload: data[length:]}
+	return nil
+}
+
+func (e *ipv6Extension) CanDecode() gopacket.LayerClass {
+	return layerClassIPv6Options
+}
+
+func (e *ipv6Extension) NextLayerType() gopacket.LayerType {
+	return ipLayerType(e.nextHeader)
+}
+
+// ipv6Fragment decodes the IPv6 fragment header. Only unfragmented (atomic)
+// payloads are passed on to the next layer, as packets are not reassembled.
+type ipv6Fragment struct {
+	layers.BaseLayer
+	nextHeader layers.IPProtocol
+	offset     uint16
+	more       bool
+}
+
+func (f *ipv6Fragment) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 8 {
+		return errIPv6ExtensionTooShort
+	}
+	f.nextHeader = layers.IPProtocol(data[0])
+	f.offset = binary.BigEndian.Uint16(data[2:4]) >> 3
+	f.more = data[3]&0x1 != 0
+	f.BaseLayer = layers.BaseLayer{Contents: data[:8], Payload: data[8:]}
+	return nil
+}
+
+func (f *ipv6Fragment) CanDecode() gopacket.LayerClass {
+	return layers.LayerTypeIPv6Fragment
+}
+
+func (f *ipv6Fragment) NextLayerType() gopacket.LayerType {
+	if f.offset != 0 || f.more {
+		return gopacket.LayerTypeFragment
+	}
+	return ipLayerType(f.nextHeader)
+}
@@ -0,0 +1,223 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package decoder
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/tsg/gopacket"
+	"github.com/tsg/gopacket/layers"
+
+	"github.com/elastic/beats/packetbeat/protos"
+	"github.com/elastic/beats/packetbeat/publish"
+)
+
+const (
+	vxlanPort = 4789
+
+	ethernetTypeQinQ       layers.EthernetType = 0x88a8
+	ethernetTypeQinQLegacy layers.EthernetType = 0x9100
+
+	// ethernetTypeTEB is the transparent ethernet bridging protocol, used
+	// for ethernet frames carried in GRE.
+	ethernetTypeTEB layers.EthernetType = 0x6558
+
+	ipProtocolIPv4 layers.IPProtocol = 4
+)
+
+var (
+	errGRETooShort       = errors.New("GRE header too short")
+	errGREUnsupported    = errors.New("unsupported GRE version or routing")
+	errVXLANTooShort     = errors.New("VXLAN header too short")
+	errVXLANInvalidFlags = errors.New("VXLAN header without valid VNI flag")
+	layerTypeVXLAN       = gopacket.RegisterLayerType(2000, gopacket.LayerTypeMetadata{Name: "VXLAN"})
+)
+
+// ethernetLayerType returns the layer type for an EtherType, adding the
+// encapsulations missing from the vendored gopacket metadata.
+func ethernetLayerType(typ layers.EthernetType) gopacket.LayerType {
+	switch typ {
+	case ethernetTypeQinQ, ethernetTypeQinQLegacy:
+		return layers.LayerTypeDot1Q
+	case ethernetTypeTEB:
+		return layers.LayerTypeEthernet
+	}
+	return typ.LayerType()
+}
+
+// ipLayerType returns the layer type of the header following an IP or IPv6
+// extension header. The vendored gopacket metadata has no entry for IPv4
+// encapsulated in IP and maps destination options to the fragment layer type,
+// so both are corrected here.
+func ipLayerType(proto layers.IPProtocol) gopacket.LayerType {
+	switch proto {
+	case ipProtocolIPv4:
+		return layers.LayerTypeIPv4
+	case layers.IPProtocolIPv6Destination:
+		return layers.LayerTypeIPv6Destination
+	}
+	return proto.LayerType()
+}
+
+// The wrappers below only change how the next layer is selected, so
+// encapsulated traffic is passed to the right decoding layer.
+
+type ethernet struct {
+	layers.Ethernet
+}
+
+func (eth *ethernet) NextLayerType() gopacket.LayerType {
+	return ethernetLayerType(eth.EthernetType)
+}
+
+type dot1q struct {
+	layers.Dot1Q
+}
+
+func (d *dot1q) NextLayerType() gopacket.LayerType {
+	return ethernetLayerType(d.Type)
+}
+
+type ipv4 struct {
+	layers.IPv4
+}
+
+func (ip4 *ipv4) NextLayerType() gopacket.LayerType {
+	if ip4.Flags&layers.IPv4MoreFragments != 0 || ip4.FragOffset != 0 {
+		return gopacket.LayerTypeFragment
+	}
+	return ipLayerType(ip4.Protocol)
+}
+
+type udpTunnel struct {
+	layers.UDP
+}
+
+func (u *udpTunnel) NextLayerType() gopacket.LayerType {
+	if u.DstPort == vxlanPort {
+		return layerTypeVXLAN
+	}
+	return u.UDP.NextLayerType()
+}
+
+// gre decodes GRE (RFC 2784 and RFC 2890) headers. The vendored gopacket
+// decoder always assumes all optional fields to be present.
+type gre struct {
+	layers.BaseLayer
+	protocol layers.EthernetType
+	key      uint32
+	hasKey   bool
+}
+
+func (g *gre) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 4 {
+		return errGRETooShort
+	}
+
+	flags, version := data[0], data[1]&0x7
+	if version != 0 || flags&0x40 != 0 {
+		return errGREUnsupported
+	}
+
+	length := 4
+	if flags&0x80 != 0 { // checksum present
+		length += 4
+	}
+	g.hasKey = flags&0x20 != 0
+	if g.hasKey {
+		length += 4
+	}
+	if flags&0x10 != 0 { // sequence number present
+		length += 4
+	}
+	if len(data) < length {
+		return errGRETooShort
+	}
+
+	if g.hasKey {
+		off := length - 4
+		if flags&0x10 != 0 {
+			off -= 4
+		}
+		g.key = binary.BigEndian.Uint32(data[off:])
+	}
+	g.protocol = layers.EthernetType(binary.BigEndian.Uint16(data[2:4]))
+	g.BaseLayer = layers.BaseLayer{Contents: data[:length], Payload: data[length:]}
+	return nil
+}
+
+func (g *gre) CanDecode() gopacket.LayerClass {
+	return layers.LayerTypeGRE
+}
+
+func (g *gre) NextLayerType() gopacket.LayerType {
+	return ethernetLayerType(g.protocol)
+}
+
+// vxlan decodes VXLAN (RFC 7348) headers.
+type vxlan struct {
+	layers.BaseLayer
+	vni uint32
+}
+
+func (v *vxlan) DecodeFromBytes(data []byte, df gopacket.DecodeFeedback) error {
+	if len(data) < 8 {
+		return errVXLANTooShort
+	}
+	if data[0]&0x08 == 0 {
+		return errVXLANInvalidFlags
+	}
+	v.vni = binary.BigEndian.Uint32(data[4:8]) >> 8
+	v.BaseLayer = layers.BaseLayer{Contents: data[:8], Payload: data[8:]}
+	return nil
+}
+
+func (v *vxlan) CanDecode() gopacket.LayerClass {
+	return layerTypeVXLAN
+}
+
+func (v *vxlan) NextLayerType() gopacket.LayerType {
+	return layers.LayerTypeEthernet
+}
+
+// tunnelState collects the encapsulation seen while decoding a packet.
+type tunnelState struct {
+	tunnel  publish.Tunnel
+	outerIP bool // an outer IP header has been decoded
+}
+
+func (s *tunnelState) reset() {
+	*s = tunnelState{}
+}
+
+// onIP is called for every IP header of the packet, before the packet tuple
+// is updated with the header's addresses.
+func (s *tunnelState) onIP(packet *protos.Packet) {
+	if packet.Tuple.IPLength == 0 || s.outerIP {
+		return
+	}
+
+	// first encapsulated IP header, the current tuple holds the outer addresses
+	s.outerIP = true
+	s.tunnel.SrcIP = packet.Tuple.SrcIP
+	s.tunnel.DstIP = packet.Tuple.DstIP
+	if s.tunnel.Type == "" {
+		s.tunnel.Type = "ipip"
+	}
+}
@@ -80,14 +80,16 @@ func NewFlows(pub Reporter, config *config.Flows) (*Flows, error) {
 	}, nil
 }
 
+// Lock suppresses the flow stats snapshots while a packet is processed. It can
+// be held by several packet workers at once.
 func (f *Flows) Lock() {
 	debugf("lock flows")
-	f.table.Lock()
+	f.table.RLock()
 }
 
 func (f *Flows) Unlock() {
 	debugf("unlock flows")
-	f.table.Unlock()
+	f.table.RUnlock()
 }
 
 func (f *Flows) Get(id *FlowID) *Flow {
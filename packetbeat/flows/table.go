@@ -31,9 +31,11 @@ import (
 // Note: FlowTables will not be released, as it's assumed different kind of
 //       flow tables is limited by network patterns
 type flowMetaTable struct {
-	sync.Mutex
+	// held shared by the packet workers and exclusively by the flows worker
+	sync.RWMutex
 
-	table map[flowIDMeta]*flowTable // used by producer worker only
+	tableMutex sync.Mutex                // protects table between the packet workers
+	table      map[flowIDMeta]*flowTable // used by producer workers only
 
 	tables flowTableList
 
@@ -65,12 +67,14 @@ type flowList struct {
 }
 
 func (t *flowMetaTable) get(id *FlowID, counter *counterReg) Flow {
+	t.tableMutex.Lock()
 	sub := t.table[id.flowIDMeta]
 	if sub == nil {
 		sub = &flowTable{table: make(map[string]*biFlow)}
 		t.table[id.flowIDMeta] = sub
 		t.tables.append(sub)
 	}
+	t.tableMutex.Unlock()
 	return sub.get(id, counter)
 }
 
@@ -31,5 +31,5 @@ func init() {
 
 // Asset returns asset data
 func Asset() string {
-	return "eJzsfWt3Gzey4Pf8ChztnBP5LEn5Fd87Pju7q0hyojuWrEjyZDI799BgN0jiqhvoAGhRzO7+9z0oPBroRvMh0o6zV/6QiGR3VQEoVBVQryG6I8u3KONlydk3CCmqCvIWnbjPOZGZoJWinL1F//0bBP9u50QSNKWkyCXKOFOYMpRjhRGe8FohNSeIsHsqOCsJU4gytJjTbK5/sCCUwEziTMNFXKBpwRdogSXKcKVqQfLRN8gieAtvDBHDJXmLJBH3RFggSeKAPHga8SmQYt5Bao6V+TuHrwMSRt9ESLKCEqbGu+KijCqK1Vp0+h2ake0QFXxGM1y4lx83uv1g3XSctFqP7PwK4TwXRMptVs+8P+WixOotyrnSxDCucP/wdydmxbC3oUcQXKyl5jxCbzFTNmujRlQijCrBH5YDpOZUmk3k4djNKuE9LuiMMlzYKQmGO/IvvOMC/Xh7ezXQo0HkAZdVQQbwejA75EEJnOlBTgUvEdZ4pnRWCzwpiIel4aA5wTkRAzRZopxMcV0o9Onvw3dcLLDISa7/+mRnSP/7yAqNoBmKHmFOpQacDxBVCBcLvJRojvXI73FRkwHCLNc/lVhlcyI9ME31J7/+n2BIjDMzX3YWZHv1Tjfhphnh6SXUbPQD4edXiDIDESSeWU7zskOolhV5i2aC1w5SKABDpAXPAI7/wb9M+LjilKngF7tmb9H/LvRwvnsxQIWm7M//N3ioh+3cRjAjcGgd+eFUOsZBt9FK4XtMi4gJ9D/OiiWiU7TktWYCygjC0QNzpSr59uhosViMSIGlotko40ezmubkiLAj+50kWGTzo6qoZ5TJoxJLRcRRLSmbDSmbEamGsDCjuSqL/2UGcSV4RqTk4t8RcExFK1JoCigL1NMeyOgScA7f2MmsHB3IvPfvWg0C6eg9n0mF5TzNahUXar3oKvCSCPQa6afdelmUe5Ve8OJmJPlHNSGKZ7xAtdQig4sODVrgMa6QrEhGp1RvdTUnDcOrrAL2krIujbEQsXqdVy0yl9UGmk4/5SYrFKqHkewz4vBiefPT+wG6JjmVA7121x8vnun/H2hb5kCzU4YlgNNfeLEiyK81FSTXU1eTmMo9Le0+tKQGuJ1psBEJSYbeDdWm1ocgBcFyAy6QfKoWWBD3Rqj7tc0D/++q3lEAhGpDlqEJMWKelyVViOaaPTCSpMRM0QzdEyENndYKB0YZk3sCAtya4gfvtG18pr88SBvkm5jjYGBTJUkx7TOtD6TCQo0VLclBpJJyrEiae2OO+uWXX34ZXlwMT09vf/zx7cXF25ubUUmLgv6jvT9fPn/x3fD5i+HL17cvXr99/ubt8+9Gz//lxT822KK0tObHlAqpUIWzO6K8DIFhalNgQghDkpA2Fxxokf2HGWPJpUKCZNo6s0xP8q3HPNVG3hr7kuU0w4pIrZaBAbV41XPlPjHAA4IZ4Onfp7iQllLHtG4KtXCSCDNEmSKiJLneooZUqfSf2gZo01nwxZjm6yhVRGj8hqNzNMF6TjjTnM+IEdglUdjuAJY3Rm2E7b7AbB0qRgQswd/eH196YxeUFmWIEbXg4s4uRxs8rxUR4/VIbkjGtbW6La74BMlr4Y9yXTuyB/WV4BURipLmeANw0JxLtcYELXG2mfl4Y0BeHJ/4QWGJqOW3XJ8Dop2s+dezdlYLobkPWO+bDhHe5F5DQ7OQ51f3r90otyYngrmWtPHjrPSD189H//LiuwEa/svr0fMXLw52ttJpNTYj/hSe8OCNxk5HUgnKZvEQjSpxuq7Aiqo6J7CnCs5m5pMkFRZu7jAoO5yYELMfNl2xzq541MJFIDfkKUfnV7N8nqD1ixiBNAu630Wk1f2bR2y5N19oy92/eeyqvfGr9mZfm+7+zde07TZdt9TG2375dtl4X9EiBiR9BZsvOB2uW0SzXHBAZHU5IWJ/Oldbb7K7MIG1sYa4D5P/IJlCC6rmjq8U1y8oysz0g2VXEixrQcrwSg4lDJKQNkbU2FpIY8WVN3pjWvXEt35YQS6wiIblZpJPnRX2TS8Rk6Uin5cEwPBNywzUk7i7ERguxT4twdMA7tdkDobj/U9kE+phfxWqaReLcNu1i4X0Frz19ZqF69bx6zUKv+DG+4qMCiDmq9l8u9mFX3z7fUXrGJD0u2/BzU3DUAl//fZhyF+KO3PxyT7c1D4M8dKsrNbfrp5cXCGa+3tH+GxuWIP19g4cf+O6FjCHb3CBbk+uwptamnvvB/hSOt6P28APt6sTJAqU6PhCYlOaCkOhHUHSKbD2Mn0xJ2pOOu5NLRQom/Ca5eiQlFTZTWfCO541kya04Os+18QOPBuhv+GiJt7fRJl9a4QuuYuw8Buk4lLSSUHGECcRGfOUBR94rVoXzAqrWq4etpZ5czqbo4Lck8K+knCnGum4wEu9pTNeVrUiEODhIQF1KCcVYblEnDmnHziNB2hil1MQWRfKRn6UBLNQY1Jm3teiqnEbAoQeV+zaKfrw1+DDmRBcBJ9vTGhO++sTE1pjvo6mtCRqztfsmlvrPcQsP7onYnJkXkpOahOpA8EyVEZWkn0RvKiHP5zdDtDVhxv934+3JlxGcsTZMxPmc/PT+xAI0qjR4c3Z+7OT24EH+fHq9Pj2bIBOz96f6f83UDqe18g/scq1bcPL3BvGwwukhNtHkCkREimeGLWHpwn/eP0eVVjNUV1pZjOKViokCyzn6PDomQHgXft06l+jEn06qiUR8ujFp0EE1VPXPPPJANLyRktLOeg8qJaVHlqxjJZF4UlhfNstm4Fxhaa0KGx8BC6KaAa0mmh7nPRAHyGtNFqYo7aQWjXLbpriQDHNN9EUNM+GA9WP3pHl0GxzqbhwTze717x1R9o+wl9rIpbRHccdWS642GAjwataQGI0r0usB4hzIMs4d8NhUm2A6Dn3qzZpFk1yvZu05VbQO4I+/XB2iyyrjE0s0P/QxP5FabPQQLXRIlSFHNqGYzaYVr8QRQcQtQoRZuIsvPaiC1zKaEIUedggikSzCAETT+CSKCJkvMxam2JhAhi0qNBqRQ80eD5a+9u5oFM1vL46ab/dvGHGpRrsrcEwrsgaJXNBpMQzYkFdgaE1IVg5fR7Gn9WyhqXzQY9ES2FUehCBpAY3dSWID6oUeAG8bCGG0XtW1c5JUU3rwhjGgteTgsg55xpCE9Ih8KIxZq7hQzs+sGu2OPzhbgRaeiI37GxuyQV61fRTXi+2tqzjECzNAcDq4QUVzVY4xFVVUHsyMoFJnBVLK1cnlGGxbOB78LxuZl6QShBJmIqOV2kGEURWnEmy95EasL/3UCNDODzgBPbwRfA1OgysY/lsG8s4hI4EKUwAFU8FNaU5zsyYouUG6mWh1VdW8OwOYlu0GFSc3zn7ryCKrIqm0pYbyaj0ljOCiBsJVxIyDp+Fk1N8SKnqcR+ZGvbJ1cetqerDZc51dE3IB4SzxSe1Ni+gS65C60fS30jbuOnyo5VsqCBspuYDOEO7s4/5zuE5v0KB8NNnMhOXnZpN84ULgLKOh+6o9Znh8cM27PTHGnfOZMBYnTfXRHgBv+E7oi0sa5soF+do4/zB8kMzek9YIyUaOFaAeRPFR9Nef7xAh4LgYqhtiGHJGVVcUDZ7BmenDDdnPVxIjub4nphDFyhFi36o+NASos8gNXOTvpgThk4vb0JrzeN2UZI5lRm/J2K5bidngvudnLpd2MsUu8ur1u2D4lqRE6mtUyrnZggRs5nJ30Iw9Q6n4Djf61i0KNdnSzMIDZ7koy5beEibsgcFDkElvtOMqNUiZYirOWlmJtMGvtaWC1IUj56RnJePnJRztmIQ5uwFl2vpmfNgTj9ctGbvnCFFROkF08+v0CW+pzPD+Le01Obh8dV5+riZ0+mUCMIygiZELbQl8Snn5YlZqPeA44zln/RR2b/YeeJGYQF2vrMHtH3bWADfm0+JmTlxdq7J5oP3nN73iRhwACoKG9Zpz5E9l2AawEj/uYFgD8KzNYWac3J/uc1n3uoeuVwmeApeC49ERNlEjCaFyMQEUJt2xIx2MGdHK3gAJpyt9BEiBGZ4Yc6lspjs87ccUEV0DPRv5m5ff/zUurvsp2vUnTSHcYMbM0cb1naYqgVr7vh4RYRJ85JLqUiJeJBIaQgP5k7UjMHZo0ON3gW/cbZJBoR98nNSY8Pe1xNjH3RsBewMiz8jjAgb8k+lYeV2nPf/1EORCpfV4wK9g+e8E+m4ntVSoZdv1By9fP7izQC9ePn21Xdvv3s1evXq5WazCyQZFerjpmGDCJJxkUMSrB9fO9kGz9Ycj4/FhCqhTyL6WTNb9riq+b0iwiwUZjl8CBRbI8mWFelEkGvpEM0jBzeN/cp8GG9xIeNllZbeUbqgRdaigAT3qhvHtsCta+vko/kX5zm17gh9rg9zdQBPkwPYF/NihJn/PnEUXUFWQ5qFM+ogyHjehd7yCq2FroF0QQepUKjnEm0j6IZNXP54wes8SB/XH/Vh+J7mYJ8rnGOF02rrwv5qrnSy6FWp16oRQTjPx/DA2IFs8uh6tZh+dARvjRzY9sYm2Zrdexmot5jCEbqyHgNnQWNBNMABmmUEMsNyOqMKFzwjuJ2aEdBGmVSYZWSDDAjzIDo/dSRpJYJKnM0pa2/dFIb1msnjCPX6ZljsA+OAz/w8q5ejkuS0LldjvzAgony8zZBbM4cWVC3HgcprMgPlkGCphi/WpJwdB4AQaMSgiAGVhhwqGzW3guVANvpV9aTYX4YPm7OefUXT8gPns4KYndaPXZDZWlV7Dc+sG5/d6DnP7mD/2J1+6j4ngJvfTEQAynhRkCZF3Pym96ycc6HGRgO8NSlF3yCEWTbnwuEb+l3ecx/lyUJbJVJbnUDEiOa7ycSPjP5akwYgonlKqnt0ZUp9bIUx5AsA5w+FhgBtSExqWigUHlq7pATC4JGUnHic4N9ZgavAE1J0o0MiWwKttifW0HIOM2HweKa1UayWZX80nxJAzrUxEDCqPWjHoqfhTf39Ws4MImg358vd1+RHe6zorsaeON0IiASTY5HNqSKZqsUexhCBQ4dkNBuhh399M37zeoCwKAeoqrIBKmkln3VJ4XJUFVhpk343Sj7cIAfI0pARprgcoHpSM1UP0IKynC96iIhPPI+nwScMJ3BMcUmDy57HojBg7CAFyedYDVBOJhSzAZoKQiYyXzPaOyIYKXaj5DZx3vxWIgO6fx6iYGKDdtP44vdUQqDI+dXQRvER2UUQB7s/YmAOzRyLfIEFaZANvL/y4vgkpMFJsbt6oodvXKRWlv01/C6BtvndG+GxRd0ARaEkW62Um5fWir+IaLSVEKx4vgflFMxAZQNgUvGT+ajeVTAGmK54jj6en3YR6f/KCmf7G1QDsYtMn//2OoMaYs8UbqraN0NkoKESV11MmLmaFXtDF4BM49ynuRTgzSLLaRXaPRiMSbwGrpUwuPy1ClzNxxc/XXV8yvpLV5Yls9dX7sYmLQIsVLTV5hekKpbDHa9BgFaABHchSHGEmbniGSBJS1pgob+cK1WlMfrD2uvnr7vLY15pXfo8RtuRB4XIQ1UEAeFAZSK+OiuwlMOErNp8Wt5hWmg0NiYQICYwmZ/3iur8NIGHPGRzzPZ5HHIQVyAb7uEazIKyN2HND55pppj5SFoUBW1JSe+76CecFwSzDU86U+PgyDkEC2aCYNUM/ejXmtSpCchbRdl2wu1DhBzY9fjJQ1bU+xu9p4A1kFEfblwrPsxJQdSesAcADVJzsV8z8DKlNPJwgWlXWjwKeVAQEGIVNReYQAofyWO2XUqKcCbrkoihwhvu5POcMEWn1IYl2LsGADJA97igOYRdOCeZTRLRzMBIkeJDUtB7IpYtCrYVMLd+EoZ6U80YycG5YREPvaZy+JDCs6Swg5v4YcZr1l2f7ehpglx8qKGdFuCRgb1ng0WbEPQbETyKO9D/GFkUy2FOsgILkpsXU0LaL+R+CXdgwRGOezeU4LU+oA3vyI4nTxvW6QAGgb8o3j44u9v77sk5MQHX5KEimUI4u2N8UZB8ZuPjpkHUaJqsgme4e+Td27aWhOUNM9nNHcaxzHGcW1fVLqBFzUmZSomcDo2U2o3oUyP7XEHHXsFHp0NSVqrLJbtgA4gJZMCtO1pkbrPaCAUn/GSzjUNxdz/nPlYVRQaiFTu7znOTUmRj44gPevOVvypB7imvZbFEHqvhFSojYFwgzCCgx1cg7crDulC02tVOOG52koe4aidhMatdwGuMdpuD3QeXaxb4eT1kML7MxEheOhUpR+jEuMn5NIJ1j4We0yjhIponzHKsuNiRs5v19QCdLEztptIWVNsN6bW1nTw4ryTTikYRpvZgN1+cX5w1wWt9trM+VR3BiaifFsIynsdp0rvS40AmZsBGiq5nzcd7L5waNKhsGgOkzqyyoMrUKXm7wxNnw4oISSVMwuELqGQafvPyWSrtWFAuaEKqb252uBE7UAP0XG/NPyc5UECgOuUsdSjdasDHQQxxALcR9Kmjtz3u8x1R2zx5xe3VhOLJY1JFRTrP/VEc1cDzlzdh2VeUMIX3OcdOWa2cXx8etZ8he3ApVLtLMYdlWdm8ti4WiEzddRpP9MFe28QQKUVT1hWuqv2hCcPrAZsL8cFSYpYLHNwQnrjvOteE/hd0//ro1XYXhiEmtD7e6zxIzWpyvRsCmiuCvEk0sID6rx/DjKo0ER1COig3rUvQ1Sz9GNdjbac0rKIgpKLrpYwpSRQl6BCTCNtsZ113EU+LJq6xi7bLxUnM7zQQYN4lXKFy2zNARE7wNmqpBMHlrrjRscFjU9ENUL154FSHXX8FCenszTo5oQilLuDY515Ef7e5omhWY4GZInCSs5Z/81grfcaMGoeQzRXD3/tngLeZa+vRHzPnqrWJyYaGnEotT2p9DDXHJpypGhfdoMc2SSZlZyc+PIaEzhkRTc6dD0aPEoImPF+6v80aHmL7B5WooCW1iXEvv3tz8T2izL7/bJTcyWF68iaT2c1G++m9TQYyl0QB60BimtvsSfMkSo5E2wutWDaiLyW1LPNaeAPH47WJ+YAy/0j6EHnYO99K+/iTkHsSck9C7vMJuXQNI1N55XE7/5QoTAsZmGo+0cSA3XZLt2z5Ry1vJI7qonsv0Ro/X/Tv5dQMbDILQZOQTYYf0sPqctxDE1rHUh3SrtvM1LgFNA5kfzU+DSp7Vi0msCQKrySub9I61J3wsuJQ8X/q1spFNqVJWD2DIZF3ZNmOzUkT289USZI/sGLpZw1PFRGQjPNDwSe4GMP1jhzrE9LAFT0BMlrRtH1Uq057pS9PclDdZS29fYpwJ3qvTDZGXKfDVnEwXwA3e1VS2kiL4PH1lGe8GLfdbGnqV2y1DukrtlvGi7pkEkliI5Jt0J7LFsdQ0SKvM5cJt2orhiOp7shybKF/3sFc/dWPgrKcPBjnrJ7EpLBrkYlnlM3G0DBk3xxjyh418E3ZTVOVwCSXmkZGc14XuTYvXEm8nz6eXf9ydPb3s5OPt2emWIQebu3A2XsGJSi5JwG75WZNg8Jlxo9OpVnPfm2zQi5tpeSsk8HzWRAx42UODDpoJpNgJm9WZnNS4nEneCembSNteNtMiuLauAxB99tSmynHXgI3mcAOqV0Wd1lKBo/mkXte3JN8tUZco2y2pgvy/s03E6g8qE+Pfln1ilr6VpO1SpvshyajKzYmqONd2SdF4TaQmOYIT6dG0hq06JDQpoCjJnxgrmE14gGa1gz875Dlh2czQWZakmiIz9ZNs5iR/Y0KoGmxakTVwbuPlye35x8uD6Ah3fEPP1yf/XB8e3YwaLyw3iG6mtBWuOuOk0/8lB3F07WaCCx6LYatifjAiCvBC139cDb3c2G28iGWcA2jPySWsXF+kQrHjv2YqEdJvqvrs6vj67NdZZ4jbkz7JmXrievIPYfDmiPnp6sXUZBfx/s7BiQ2cnPj8HQc+H1JfjoOxNQ/HQeejgO7HwdQ667/80pTny7mon0tlckjgfn3JFifBOuTYEVPgvUrF6xJl4asq4oL1bHne2L80Po4v85URI0B9FEYGi7XlS0wZirHeDocE5pYcFvYz/m8Ml6aAnw48othhj5c6YPfTXOASI4W12quuafTSgVt7shJBSXbwHVbWUu28Jga8Wbs8S+oJNkcMypLPYxaduRbWrdESXEdjlzNr6mrMav7pjXUq8VSRpdk58cNzVxoHq0l6fGQLbDQgi/tHd8wFgBKFlvcDt7ARL/zLKuFSTb62fwC8h6KfoCGThIVN2nearGh9QaqasgpaHHmsfP9gicW6BMkI/TeljdrSlZCFDWi5obx+uyH85vbs2twPf4eTr+OEG1qIvYf+9dcd26I+jYI4If4U0jb0nToP0mm6D2B0NDEDSOa8qLgi6gqFkSUWlZhZHEkSMnvobt/vmIsQW2S/U0i5IjTasXFSdysKMa6id87jVKD/WKX1Zavc+vFDQpoG0TIJaB2YT1dWT9dWT9dWT9dWX/GK+se7R+1JgppWav9G/PI1U9w1WLAEG/K1t+2oo3aMVqYIfs+FGQINRmO23MALDawXaDgUMlccj+pDAuXXDRlsEu8tPC2tSVaNR/iudk0IDAYlR17KrKyl4ZSprBsbVNEU/goQvZhWDWUqKCC41ZkWM26u6Z2KtqVhjBlNbovbqKWBcH5OOPMZEVl7UDfTeeqQ2dXQQdIbDs1S39wJaEEnc1Mkme4LRKTilqZDTTttkJbh4qtulTRRplsH+5xoU8FkPoEZm53oGvIBwCfnXZBIAfGkr8ggqA7xheuYYAZha/h7PMucO4ycUE2khwdSsoyEHs1a0qw+7VqIi38Yj5bu35wsvpS6zfH9yDim0TePBzzGmInBc/u2qUNPuuCLeZcknYGPxQytXwP1yTZHC6NtmW+haAqKmybHtA2O//UGnaRWQ4Hfo3LbnRaavOuXjfbOVZ4bKdoJYHdLOF+As8VtFZ0LlaYZrstsERY3tlCmeAr0DvAnmWjMgApavd9mgBp708PLtoZU2ihZ024NSTt9SSxB3qkKtUePfjdDVQzI9aiukwpSlhdjjXttSB7M2s3VB7koSKCQlMNjCwN+lwGcpRkddOxbSOR5KZ+r8sc3hJut8RYzECg7G9Wtzwt9JPtSz7Ps+r+dZD1efrjydX9607Kp/k6yvDsq9bsIKKtasIFzQrG26e7/p9o9sK2wuenA32GwSznpePBTOsRZm/YojfNXefA+CmiZrymA6q5AddaRkqe0bZLxVdxCdNRpe8Xg0NYrcbh5ro10XLbdNfpTMiqLPx4Ni79xrOwEClwpQdo7BdL04TMcNDoPfu1ppIaF2Cs4gVhZIELZwglaG57Jx+xhDYZSpjWFvFKKO7bmaM5X8BPmj/d8hiLNAJHoRFeVSzRcIjsHQr0vJIKcYEmguNcf0jW5NNIx1tVn70NqmQ1neObHgM9dVlckavtkLV43zRCcyi98yacIMi606h8nllDVAzLt9vUrxSSW0sZS3Sw5LU4CPvip3hXo9vjaOwEhmPxA3QpauY4UkvS6QiFoPTWg0JSkcpV7ZpwrqQSuFrBz4IUeLnrMABIOJiEjLGOUJyFDrcI0iEdkRHCZgoMSNtYvJ91H1HX+NbT9K1EF8cnnuhD05RPLXgKoV1w9ogyqt0JC/Wu29lB3yl/leQqAo3QR+NdjiDpifrw7t3Ztd7n+sPxyV9XVSni1ThZmLRLvq9mo1kIhMvmY/OXOJW5VTo0MMxRUwskBzI1y3NedaUrZb0FlOLqb/rtZhf5Ozxgv7ng9WyeQmkrWrfPR49cWncWcmCb9nWQ38gzXCBG1IKLO3R4pqU1I2oQgXmvH7rFxd0AEZWlpsk43jvEti+XVmVB29lJnQpX2W6eM+KCdmsmxk2OL6fhZilaqAmBoulQtwfquLtejIMOMD6dEuGraA5QTrKCMjLQZA0Qw3f6t4JgSQY2hqd9P9HEkNguz2MLbFzQjndxY/d3athU+k5sjWS0Yq4Rjm6HNO0sO6Ds7JG81cstbG2dHKOFDdp3bMVdcoR05W36ZoOjgTHoBnWoB3t6fnPy4W9n18/AyCwKvujAi/WFexv0INbDVDSrCyxCVTMh3rboi5CxqtqX8NnL0LuqWxtu9zSvcRFpceOKm2OWFzYMqwNrdcyLt+A+29I5xjLC0+PzAzQRI9aR0YHklamsJ6w3hqPED2N9fho7wSPpb2nBk7hXe/RYSvxAy7p0eeWRuHHmVc+ANEMvaFFYQxJnGan6BgcxN+s4bJ/iIxAeUJGLW0OhWLpSVd0DoP53T1ju3Bsm0i4UJFAzNQA9Qn+D5yUqcddpkM05N+FbOZlSFkh3i8VEIjWzIq0ReE+6wILN3aJJmB6uHo4r8dREZnaAmeR07EcBnc6t5jIBqQ1R4H+D6nlkNXd7hR7R18MQOS8xZW1rcY+8ELO5QWfMStM7sX1i6AADL4AgkhdwUe7aeUp0T7GxoQxMqE9+A41M+sbK5NjIun1JpnhAVo52Bo5RYZuSBKR2oBnSLZCwn2zrcJgcmmFkt7GXlM3GNuYxOdJkAMWa0R5HhoBmRtPNtVlqxVHNcDmhs9pUSd1oh0OlEczqKYZyNMb74Xk4aoHq5V0HmO2iZkvb8KmCl406MJEYeiPmtVRiCV4JLhStIRYSwCcVvKVwQrSgl6PWUdxFjIAhcf3uBL3688vvemNftcIZl1i2bdHHc56BiTTMVQdw3tQLZ4noEmvh99Bdqwxa+4/5dCqJGkuS7U0T2q7bBrKd1lhY2J+iG5tvu2tvJ4Iyf7cGDfBOOBc5ZRA29pFRvalwgW41zsOPtyd9ZrbgtdqT5QU3DgBulUxo7DPbQNu80h2nW8mNrBhYtX0LO1iw9VJOb4Z/ffOv4ePd0Wwn35iq9jyalIbqWRTapPVf3l51+e9RErvVwDoezp61Louaq64gqjl1jeFMOrZstL9d/4hjWPvuG9kbpeuznz6e3dw2p7SeUxlGMBbDjqn7SBSdkkbQ/R43kfZVsTQEwRXWs4EzPe0DtUz4llpq0SzH0laO8sTQtu0OtwV955JOS+RmJVodch65Es1pv/Gx2C68TVhamgxkkqtDCwHWVX9xefzXpjxt2JQbrHjrcOxGDB2vMjU88NOzk/fnl2fNWYl3AHk/BXj959Flr72OyZ2+gWifDa4pwPvyOXdHvIENtzBFxD0ujHrzTiK4UyhTEQk1U7SINoXAzPiTfI+D67PLs5/PL3+AJrJ9B3tBJhQuff//GPH355en64Y84VyNp7Qgn/Fo5Pad4o2ljAGzRtyEP32rP35rTKQEdzcXybaquY958je68Ks9EDSdhpkMfc6XN12H8+XNcKvCwjnbvgvhTu2vtFVyenmDKpzdaRuwOS37ZjXWu1MJPhO4NKay7//f0QUmgw3gBsCoRBmvKPF9fxIBlv3Oiw3ob0of2vM9Vq0NcUcZlGQz8Yl21ZNFLIDFTOYflaHrlgs60wYxF77pjFja2xUYHGVmeBG4VNVSf7kOOYQ9zucRrtWcC6qw2rkb1THMEmRgWV1qXFBYBcuRm1t5715lyFGw7FxTty8jnCliQ0ClSpduNwMTJKuhOunY23yfY3iLOYELJYvu3gWn2gxGGKO3OWn3zjO4lNhgKDmRdOc2KmvWyWldKkimZOhV1KZGLWRNWjEZZsR+BorlCF33T4e7Xewdrk+KHOc4yu3/HDzpyLRDhGDIHG4XAwkSgfTk9Q4gm5PsTmvinEq97l9ovQCX7HOIa0mLoZYw9Cnzd7Q+nrp3OErUTNtm+bi3uPK+xgNpkxCIRYVU6LsXL22StM9l1ob+goi2+DO1U1fUg14p752E18ZGLUG8JyXp5Yez6+sP18lmS0YatQyRNVolFG7GX6lXgpJ8hM6nzanQ3LvYdqUSMc6GlaCsG6iZzbHAmTaK0eGE6NPWq5dwsTbh9wS9ePnmGVy+aSnEJQkfx4I09XNbcdVYIiIzXGk9rY9FL567krsSHf7z9PT02Qh9j7M7JAsMFYC1tvq15pApI4h7uaUA8UQOUIaFoNDyDFZQmtxobe2jKSG5eR8u+YXNLPynGqB/CngugvdPFiWNJpdvsViMZpzPCjLKeKofmF/Glh+7m5RsPc6CZFzksrV4KdzHx8fHKxC2c7cTQSbYOAe3wnp+uQInUUU+ropajjlbOVoCyXUmZ6EamkwMy7qH5Pb96TOkoSDOiElGgr7F8XJ3fCb6vf/6AgzfgynnowkWoxkvMJuNuJiNDrSmOAi/aNtPBPnCLLk+B5ZB19jb96e2OIA5lDBEygmBjt8Zr1xeVgTQANNPz5Wq3h4dQfO4TNbTKX0AClLzi0v8m149PqrvUnFqTC42apa0SloyhIXAS7f/Ta5ZTiFqE2vbEPxTJsIV8CFpG+L5ktKTZceu6jU5LM2d2iOPsfrD1ATJa5ERz7uu+bI36D7lTI4s8k9G5IU5fC3yVgratmh1DgRftiQkBVVEgFxNLrD9o0deOGI2FRfAZK2RdylKEnLx9370mwsPreR2ICIlTvwcqGJzxohvDgKvgLVqEstU4iWaEJThbN7STxMy1VKHhilWOZUZFrnWpP8ggrtAmJJg1lhOMBOJIFjGVYNqlN4DvfPQslnXWQCaBOulakL4zchHNgIOM19NiEr3RkXiYGfvemi88W7RQ5h+dbv022MYJZ9ZXjXx+P7g5wQWyN+2ZDYTu5ri30laNQR4idUG2vMgsDM38QKW3SjLilqrqHayb2zitWMsruBWZUJwMlC6QfyVSMyAoC8gNS9vVpPw+0pO35jzi+24phXoI7dcQ/LvtOUaAtZsuc6DX2rLNYi/ki0XEPR7bbmAhK9lyz0ZLMFc/FGNFl6pUbeZVYelzjQr2eeSvHLw/CANPO/2OV1z1xV2MA9y9cAFrVn65uykZyDkQY3FqmuqswdFWE6ajDlbP6QtBpthfX98+rez65uewdV51Q6cXS/Ebb9kLr6V6OPpFarwsuA4RxoQOqTMXNg9a1pm6vN04MP68fb2quPE0l9u58WyUFHSjdUq25LqjKkx7qkpZmckGzfOXJVSAQ7udtDCio2JmobtYhm4x7XK0xNgJcoo/RAWpOOncEs9/Hh93kGlp0zZeqdOWLksRPs6TAXUwfFe0rB/tnN8KY4+PQwXi8VQwxrWojABtPmndHfBVR339lKhsjuvx6jEVWhewVhwZWIhwz7V0htUqfan5t/PJobfDMP0M6xckz9va0wKApfA7rGmc1wqLtWSAIaEXoWoO5V3QYJQWvoyRJJoBlDd+yEERk9ZYpleAb2myelfF+LSLowUbpbNejmm9tqGDR9XbbZE8aMO4YCtx0OAQqn7+vnrnuygucCd4OmVeMwbvZguuUJTXrO+ZJU/zlbpuq/Nv532Sgca9M/cba90YE6WX3Sv+JAGq11pVoba9fzkoqtdzSrpn7ZrQW1ho61CRTYwx1ptQ4GwVO/QiktJJwUZG/3S3rqvW5/fpCSIkS3dgLiNEjKP0bwuMYOSV6AYQdt567Rfbplfkjmgq2yr26COkinA2gs7mXu7KWwjvnrl7Weart5oHP/TIyfMlX7umzEL/ZFTFpjazbYrSQmnrWDrXdivOtvP/ZCnLdyezRdgQFttQLeTHpWC3D3sOTo8XES18ikJUyZnyWxpCGXKMIMylhPKsFgeRLCmXCDz/XCCJckH6ECLwAMT7UselPuaC3RgS/KYH6FuGHyOAHYJW7NlCsr2MB8CL4zA945qLnwJIfuDRB8u3/+ycvfCc3tcHUeS8Qn7HN1Gq9nnoJx2ulFz4KNFB5IoU4R0RlTC92pWspl6XmVQrEgrVDj1mlLAEKyWxt26YjPztnr77mHOzoKysJoa4LlmGH63+1DldkK7Tb0Po/VdyF4w8YhOO1NkI0m31Re7swTcqfhcx1EYe+g27MfLv15++PnyYIAO3nOcH8Q58gc3iguifzwlBVHw1wmvmSJC/6kP2Pr/NwWenChRAJTrjycCLwr9RBsWVhIer7OMSPjzHab6Lah5W6v5wdY64j/nJLUHleJoMGBJWRV8iTBz1qQkA30oFwSqO8Ycbvk2Baekpp5pZEpYiQehW4eSxMA+uYke+QU0R5tPgULwWFLVKfx7kNowjkvEPnL1XQhiq1JsW1Z6aXCYmlk94DTBZjcbkbg7sW050pREgds/BlWY+6ft9yYjnAxjyG9lg21NCKBYPyG/LyluUvCve6fBAHWnXCPBfI4BdFkoIlUVAYSTc1sJrtTKf+gxmGW4n9TZHdnVlWmh2MZD4Y2CHV23LkdnMo1o3H2vanFV46KJ6Iyiff3cNNW6IwiHneXolXQR3el6UNvNYnC/ZtfdB7FRFlO/BZlmne/Isju34DnfnD6Xe6phRYsstfbXyhmcIZvYs3snx89UUx+qIQUd0qm76lo1SeDVtxcuO65l49z3ZYhq1j2RNB1LE3kyNgzaDSLnRIK3UxKWgz2TY4UHxsnos/5LCiX5Vx8mvvwwY4n0mcdpRVt6gI/kMm0AvXlt65vkbrjSND2y7gmfHbyO3exC/B4UOgGy2Y4Af+Y6HjEuxreo/fA6xbdUxDSoc8LZkmyO8XCSnhIhVqY2fM0UminMSZFI5Nlum2XmLIUoywTcPh3lxP6FAP5ac4syqiguPiMdFoPVXN69ur2quidiwiVVy12NEiDEtyGxoujAgz9wEmcFLQIvxq1GLjuYJcFdC140zWy8zjrQFoBEo9HoAHzMB4WoUaZPyea7lZrVEGyCRsbtQKNHmSMm/sTVntJC/VtZ4AnSJ2dJZ+zbDSYwJ1LthRoNCC6aONuRJFwrXvJEwudWa2qocrBQCT3PbDo0kOR+8iQh8lAJ0xQC2jaaEt1tt1/i7kUqzPLJ8uDwL8+fDdCBLPji4PAvL/Tf0JBISnpPDg7/8vLZwF3Raf6yGbbTFgIvxuBSztzdrpitdJnm7dauc+EEQCMTclvVuVey3NE1RdZ2+pI8VIom6tpuyetYYc0tVCxd8J2PuYv1eWdmYzpDwOfTeOn/26vnKMdLaVOSQmy2nZ1t1HLA+MLcvZFCEkTjE6fNY55IXtSKoI+MPnRoPnz1cjihKydOFoRU48T5b0uZpcEgSUwTYspQSTPBfdkluzu+LUQ9zszto3lltdwIzbU9adDW+Q4qm7jffK79ivlivF1M+BHJqDcmAkkJkBPIwnQtRFtbMxFr3LnfdNHGLnJgvZH+a00Ttw+7jEKtuJui0vbvUYKAjwYEMdCw8orCHg+xHNeM7n7lc3J8gw4zXlZYkCFm+VAucPUsqufgd/HKg9yXI8hMG9xDgfA5Ob4xPktUVzlWrQpDm0pxsHf2df7RwKhUNHNWus+MRmc4myPClFia5tNBfkAyXsbG6Bxocq0pBjBXOme6wR+P9bI6qeCluwsacSaD98RzNuP5JHTE629OJz1hMObX73uiTTVySdzgrb8jK7gkVtCY5HQTvmRFqYWIFlQ0zmjjFp/T2ZwI27zMu/sROpyG+bCfIBzzE0zyJxf0/OkZwlVl2t86DLbhKpZoQYqiL2ynmRG0VeBAuzXiiiWyitTR1fT+cl3hbc0wW5AqurjweextjoudMaFeCBNW21RP66I44UVh8lkut8q/Ny2u/cvWibHJUyBGTUBrhhVh0QWrtl0gUx6e9IZKC0Ts8qttE8mcK3Q4euaZK0KwIqfaPe9xTzn3QboB5gkWxtrpG5VLxu5MtLnnuuU3d4kGD5vL2htii4o092Y5z+wpUHHES6rQ0HSFh/Y9LkjQlIRwzwbWaV3Ag3rkWmUPI3S2Mq3mpSBVoS5Uq/ZF32iv4dUddUsTjuFKSPcMfkKCWhZpkq7t73u7tWwI8FNaFYkZcSvyTvByMzw/z4nwJ8KsFhJ4lLoOM1R6mF1ssCyboTlG/3bz4bLhDEiX8a4PmVplFAXLg6lmxRJUMdBiiAuCiIlzkgOEC2g+aTK0yloqVGKVzU18kkcdwTfL6VPMIn417enDp69srKPH6d5EfwIiB+hPXORETPRfc8rUAP2JPFQFpswUzfiTZLiSc666c2lY6h2I/RuiN/ymcj45tQUtqZ1Wqwn92KzI9izVnfEULY1KSE8+5C762adxvUxs1Uqrx6ajJZayTiDa+iX2CPIiwexbTtP33WmKS4MZTtPsYkA7YdS8w60ZiaCiRUEU6ZJlntgbURah4dSKiCkXZbtQi9YyQXl05KsAQtUea/kOkCSmduNHA/KDO79JTwCO8k297XCBWY2L7lCNvDjfwma0Eiaw2Nu+ww9X4+uzq/e/2PAe2Me266ThBN/SsfGlOXqdYrXmr99bVdZraEX0fmDZ9dVJfwA2WmGZPdDeadAwfbBakG7WzEKq3xEuikdkfl2dwJsm1QvMGnd/mzwTVMXycUiMetgIS8dj3js5Fig8nwC09ZVVCDtoA67h9IAfS9UrT1ZA08ybiKvuSa4fTwt83y+3NB5fwMzuSHghxSWC5KN6y9KPjkmI+Faalvs0H+ghZNoo1fK6VvNhzehDH8bZLhhh+z0G5Uoe8uDNLZo+MztEcjtMUuFyO+v5WEyoEhrl+WlYZh9IQiXO5pQRSDZ2BTP7cNtn1yWlh6GtfuD23eDYvZS/FuGhe3nz0/u+I7f+bbv0TgcebVemVLbPsNvfpbmjrabZVzaE4mTp42yTxahE0v2IoXoWyceCL3a5240Ic3fdGrsJEZ3WRe8x29MQAQyKL/CFT7ousFSm6m6PyKVMEtHqyLue7PPLm7PrW1cZdTOqaZ6q1MXIQh8egAqSa9oTREKr3ua+ZVMqb87en52spzJYc3+UisDxqTONV5Qm1DS2eOKLUgirvoK+LY5gpovCwmxbV7NWgsJqLKjAPPlWrkifMoG+ewgma+Lbotsk2EG9eLu5Txth0SZy8u7KYbNyk03Dgs6X77oFnS/f3aD710evtsvVM3DRjql662dZU+d9Cu5O1vBXYkpLyrgY74wHwKzHpvBKcPev0cmHi6sPHy9Pg1rOCqd8M52o6RVMoGE38OBqz9SZoazzfWArOFoiWFrhJjvErrRzYwpalq7jvGoWa+wrLtVMkH613Tywne52iLZjxkdIG0C0q7TZh83ghPPVbF9GQ1IGdky1ZoUCWbdp/ZeNxF0SS7+b0MCU5J6IOHhpPVD30hYJwKYYb/zdu+Pb4/et766OL89PPpONMP3qbYSdKGzbCFaWCJLTUI9d6889YgR+206COPBoKwliyOwkdmzkaYwj5YDkxsZmCKdO4MnyX9s70WJkn9OFZjD5yq92LdVc0KkKFvMWvhheX530rGjzwHY2ise03bp2KuGsWVFzl6LmPDfXVUGZmxVL6Q8qp7H4mNKCtOrjGAdaALa2vj4J7iYtyLzoii9TP6g5EQsqLYjz06AIhls11+a5p204zbZg7vAsH66agaOVpum26W9Iz0/fmxEnb/Qes7+6UT4tYvQa2QtZKn3odrNSEcQNNuBDRoCY7WQmcEq48Ro4/QfU5pnN6XW7rgjl5+377jnA7rX3W7Z3UcX2knOOWS7n+I6MM15WBVG7tiz42XbFgKV+f4MYmXFFjXnqO980asn7ZSSR0j4TwWtaBJkq8IRlYlmBQ7W3lEVd7joKyxp6AAFhhniLwBYYR5Ug95TX0j3YRxLgGRvp1CFuqyiZ82l7xiLCjICpWU5EAZ4aKxFBsqAPTIuFCN4BzU25hXC456eQb6xodkdU87P5jMiDIqxntKYrxTgjwrbZJWPvBd8fb9muHUZpOh+7ijrMBbfdBFElSRGP2wVe2DcCgvtHNSdF0S0MuE3Bqe6ReBUbrJkR5ERtXFdHr5Q/Qk+W3R6tCwrtGhLt2LQ1UjMzZ3ktjLOSprg7HJRt8kLycUareV/hqXZo2waDe2/D2yzYcAxxb75auu6BIbFtcDeEQP1A+fboaLFYjChmeMTF7KjpWiaPVCGHjYpvfRw9zFVZ/Jf4y2FP2a9gWngJ4e+NDNjbFIVRgAEau+2jKbP0yEdOjIY+1GCHNG99MtOSngUvLNJDbm+ezpAhnE7vuwCS63zozQrXODOGk9qIqGVWEQFN5Mauj2iiEfjq/dkh2DGtb4b9TS8BuKoKi3Vc4CURY1/EJ9CcuxLUZRoU7K2AhiHQ4GXH6v026h+W3YBjoy4+E/mmFz+PtaHBODAxxIZFXIdpUlZqaaNIkxC1zsjvtRqQxPetAqECQJO3c4aB/oBqAQIeXI/D9ZLzXLnwG4hslaoJy3VCLo7XhCRWbSaTPDXfKiCvU7PIchzMap/GgVgar3D2O28GJpI1VcRjag3Pn95A9jpe64CbLB81qI662PMAO3pio2F2YHkpvPkYv4g2aIcMm3/rtMGOwjiluzvkX1pAJA/RoVju/r6C9aPteooZ4zXLbGwUbolYn+bSy/rIsH+wHui4WOClbAvjjQ4Ra6VrNLKT5sUeU8EEcEYBMaOErN5aWK9rs/v3757/2d4JNIXJe6SBoLgYJ65nt9j/sNubyYBIFg2260sLUTOuxqZWfRJvKxSxg/RUT7utdR+cPYI1oabIATQkXEEDnvZ1+9+IBHi9hwLI+SN9PaRND7DxHVmOcTHjgqp5uV8R7MG2NHC8WIYOjaWrks1RHl3fHA/Q6c2xtnLOTk5vjtcPqRWbhzZm3hv6m79VDElL86/rO/lFp7DD7o6KHipxoYhgkO85NsZ6isa157KbGgooo+MGHLqEi+HUyvZ18saLXfY5FKgMNxlDV2cX3QvTaJHqVDXoDXWxG3TQP9II2fZoYxjr9DCkgoqUKt1Ku50YMO2at21sXMwwo7/t5aD1IYBlc4o2wouLcc3ozvr8I6MmPZqyCPwKKkA5sqxbMXtL1FcWjpZCgsz0+C0hdjVX0JDxsuQs1cp9azIuwe0h4OhtE5tcOHSj/9fuQypl3aN31u6JM6aoWrrjlay1ocdyZNudP22Np63xh9kafbcdn8Uqd+fNJ6v8ySp/ssqj0TxZ5U9WOXqyyh+N8sn0+MOZHimCnqzyp63xtDU2MMrH2RzTbtLFyspCJ3NIWpgiJWqpvNa2VvlGsTGfh4KNonNwQYRpXrZjscpUR2XnOgUkANT0Vb6H7AP4UpCM0Ptk5OaUshkRlaAsUexp5WnpXfBmY68EQVobn4z+A796nNj8t+NXgNC5TBqKeiRk75aZYznfda+k3VXaxtJ0BsQBtjYHSbpSqsYp2p+BPuPrMkYxtLMrsrqAIgxzAgSPvvl/AQAA//8/9XaM"
+	return "eJzsff1zGzey4O/6K1D6JXI9krId27urqnd3iiQ7qliyItHZ7N6+osAZkMRpBpgAGNLMvfvfrxpfg5nB8NuO857Xro1JzvQXGo1Gd6PRR09keYYSnuecHSGkqMrIGbpwn1MiE0ELRTk7Q//jCOn/DWdEEjShJEslSjhTmDKUYoURHvNSITUjiLA5FZzlhClEGVrMaDKDHywIJTCTOAG4iAs0yfgCLbBECS5UKUg6OEIWwZl+o48YzskZkkTMibBAosQhNJwR/TTiE8Bo30FqhpX5d6q/DkgYHNWQJBklTI32xUUZVRSrtejgHZqQ7RBlfEoTnLmXd+PuMFg35ZMW65Fd3yGcpoJIGZNoF3/wNkITLnKszlDKFRDDuMLd7O9PzAq2t6FHEJytpea6ht5ipmzaRI2oRBgVgn9a9pCaUWkmkYdjJ6vU73FBp5ThzIokYNdxgNBbLtCPw+FdD6SLyCecFxkB0DXpkE9K4AREMRE8RxiMwoROS4HHmdMwpOGgGcEpET00XqKUTHCZKfT4a/8tFwssUpLCvx6thODvR5aBLlSsAIcplQA47SGqEM4WeCnRDAPnc5yVpIcwS+GnHKtkRqQHBlQ/+vF/1Cwxzoy8rBRkc/QuN9GmKeHxIQQ1ekf49R2izEDUFs8Mp8HoEKplQc7QVPDSQQoNYIg044mG43/wLxM+KjhlKvjFjtkZ+r8ZsPP6RQ9lQNnf/l/wUIfauYlgOHBoHfmhKJ3ioGFtpPAc06ymBPCXs2yJ6AQteQlKQBlBuPbATKlCnp2eLhaLAcmwVDQZJPx0WtKUnBJ2ar+TBItkdlpk5ZQyeZpjqYg4LSVl0z5lUyJVXw/MYKby7H8bJu4ET4iUXPwH0hpT0IJkQAFlwfJ0ADLaBFzrb6wwC0cHMu/9ByyDmnT0nk+lwnIWV7WCC3W0ctRgxDK8JAK9QvC0Gy+L8qDWS7+4GUn+UZhviic8Q6UEk8FFiwZ0PQF7iWRBEjqhJNUmh3l4KinAEGApy9w4CzVVL9OiQeayIBtQuCz8ShdQg05qtg/MWA/dLB9+ft9D9ySlsgdjd//x5hn89xh8mWPweRIsNTj4wpsVQX4rqSDpGVKiJHUqDzS0h1glAeB6UkLXYCMSogq9H6oVilzniLpl0NjKjLPperQO1fXl/nx+DgI25T7hJVM7oGdlPiYCeKcpYUo7fwEWiXIipiRFlCluHA4yJ0z10KI2XWHhxdOpIFOsyGNlAHjhvBbCYJlIG2QLkhEsN5i6kk/UAgvi3nDCco6q/i+bdk0KGGwKuw+GxkQ/lPA8pwrRFOY0RpLkGNhHcyJkW7yqZIxkR7W1OFjIVxBu3mzSVdsLIcp6sFxSJVGBkyeiJFoQ4aQCcklwIcsM9hoDDZWrGRHePWvs1AAXZax6gMjBGo8jsJ8r+Gnw5Ea5hzjTc/dxKshjDz3OP2WYPYJgH2lBi8dBzF3RDx21KLGTiRabk8NLRUTornK91CNJU7LWsa9Bi2rOOpMaUv85ya5s0YFpdmYrajs2YOSXX9+f3yJG1IKLJ2tHJhSsCgicoHf3VxCF0DpeCCK1T3xkIxN68Rxpo1KFJ47fQrzgCr48jgcpNglRAGhElSTZpCvccCwVFmqkaE6OLV9GAilWJL6i18X7j3/84x/9m5v+5eXwxx/Pbm7OHh4GOc0y+s+jhr6/fP7idf/5i/7LV8MXr86evzl7/nrw/C8v/nm0UsagKIrmdks2oUIqayO8X6XZhO3RmBCGJCFukD2T4E3/aXjMuVRIkAR2rHYpJOnWPE9g47sa7TVLaYIVkaCXWgHB5QRZuU9Mx620s6rhwe8TnElLqVNaJ0Jw2CTCDBZLInKSwsKtQSCp4J+wL2rSmfHFiKbrKFVEMJxZjU7RGINjzRloPiPaXqGcKGxnAEudTWlgm4PJXYOKEaGH4BeY1M40aUeeMj/J7TLVAK8t2mg9kgeScNjBb4urhkzyUiRk2yX5TvCCCEVJFfLRcNCMS7Vmkcyx82NXIIC/DwbkzfmFZwpLRK2+pRAbqc1k0F+v2kkpBOgijPXgqEXEpitMNZDXd/NXjsutyanBXEvaaLfIxfGr54O/vHjdQ/2/vBo8f/HieDMWV0QuaDEyHD8GC6xxnarYBZJK0NpC5wNoLvSWYUVVmRIdcYJV0XySpMDCyQ6iYXmOIwIx82HTEWvNip0GrgZyQ51ydH41w+cJWj+INZBmQA87iLSYv9mModqUe/OFptz8za6j9saP2ptDTbr5m69p2s3f7D7xth++fSbeVzSIAUlfweQLImbrBlETa+KhNqix8YxbN0zgvcn2wATexhriPoz/D0kUWlA1c3qlOAyQokyPusaMcoJlKUgepiliDklIGyNqZD2kkeLKO70rN3RryIW/Q4DlJMknVmzyqJOI8VKRz0uCxnDUcANBiEddw7KxExgOxSE9wcsA7tfkDob8bk1TDfBa+r4ap4IWI2D7q1iaNmMm7hFuO3Y1kNvoliP2qxlBT9C6cfzs69LOTuEXnHhfm2f41Uy+/fzCLz79vk7n8A+fgpu7huEi/PX7h6F+Ke7cxW/+4ab+YYiXJnmxPrp6cXMHeUAXd9SfTYQ1GG8Hsoq4rgVskp84Q8OLuzBSS1Of/dDJm1b2Y1ildPZOggTpoUgupMZaSoXhbFVSYG0wfTEjOjnZQg67sTEvWYpOSE6VnXQ6qyWeeUBcgOFrP1fVUz0boF+gDMyncymDTBMv1QDdcld15idIwaWk44yMdO1YzZmnlUHtA9b6SMOur5Sr2QabN6PTGcrInGT2FWcuA+6NdVzgJVIcLFpRKkhD08pqaOpQSgrCUp8KrDKsYzucgkgoqYN0DwZ7wMIVkzLzPpgqPqlBGKwa0xUi+vBT8OFKCC6Czw967FpfX+gUrv26JtKcqBlfM2uGNjmPWXo6J2J8al6KCrWqXgRZQnQp9JLsizCa6OTd1bCH7j48wP9/HJoSQskRZ8962h9++Pl9CATqAMbo5OHq/dXFsOdBfry7PB9e9dDl1fur4VUIpWEmBKnlJ1bw6kpu3Rsm2atJCXhFgkyIkEjxCNceHgjo4/17VGA1Q2UBygZf6ZyWzLCcoZPTZwaA9RJ0Uta9RiV6PC0lEfL0xWPFtNU7zU/wzKMBBPYGrKXstR5UywIKSrJlbVgUFIJoMTV8BigIm9AsszVjOKuVouiVqpnQAkZXafYKucOrTY1aKWUnJjeVTPEs6E1NBNWzIaPw6BNZ9s00l4oL97SHZt96Is0c4W8lEUv7GAjhDDLnCy42mEj6VVjUMJqVOWZIEJxqskwCO2STQoAqy4JRG1eDJjnMJnDiMvpE0OO7qyGyqjIyZZr/E4j9dwVuoYFqK+igyEZ2wjETDJZfXVmsIUJtkSAogNccdIFzB9IIRJFPar00wPhBhaYGQBQRsj7MUFIANUYweGAqYFkBRoPnPTx4bzgTdKL693cXzberNwxfqsLeGFzGndPSSfoNkRJPiQV1px2tMcHKredhTW4pSz101huQiIAVRrkHEVhqnaYuBFHOIRd4oTPIFmJY0WyX2hnJikmZ6fmpBC/HGZEzzgFCVdIh8KJyZu71hxpnUbfF4Q9no6alo3LDSnNLLYBRA13x62JjylqoEO3XkRK7Di9oUI11gosio3ZnZIo1IbFv7eqYMiyWFXwPnpeV5AWxdTC17VVcQQSRBWeSHJxTA/aPZrXmCIcbnMAfvgm+RieBdyyfbeMZh9ChjlDv+xRvLgJdtUJOYlBIs1r2sKotYPlKMp486doWOK6gOH9y/l9GFIkhrgAUgiRUes8Z6aoiqSOC3gwFO6caqUlRjrrIBNgXdx+3pqoLl951jSiL4aqLpLFTa+oCuuUq9H4k/Z00nZu2PlrLhjLCpmrW03tot/cx3zk813coMH6wJzNnVWLSrBdA2cRDm2vYM+zOtlGnPxffKZOBYrXeXCEGr2/4iYCHZX0T5Wq/bakjrCwYTemcsMpKVHCorDuW/oTB/ccbdAJnrfrgQ/RzzqjikGd+pvdOia9JQghnkqMZnhOkvTG9KNoC0b7ifUsI7EFK5oQO5c7o8vbBA6G2UMm9C0XIKZUJnxOxXDeTE8H9TI5FFw4iYhe8akQfFEdjgogE75TKmWHBg4EXjPC3MEyd7GQcpwflBUw57C0NEwDeVEQ31MJD2lQ9qNYQlOMniD8yCQXUpsjag4LTHjrSuyBZtrNEUp7vKJRrtoIJ8GIgpwwBsajkPJjLDzcN6V0zpIjIvWH6+/foFs/p1Cj+kObgHp7fXXv/wcMCnCmdTIggLCFoTNQCnKbHlOcXZqDeaxxXLH2EDbd/sfXEA5ThwrlA5w+QJHQAri4eOhb6K9jb0sQeaEYPyYzk2C38jZXMfQ1OuiBgdFIQhT/J8Fh413pAEjmwRxYeQbUkUaBnBD2a7e1AH4pjqf8MgeVHi0FDAyxyhqG+H9akqmxVR/tkl5dhwVWxzLX2eesFCAqw6yG1AbonRYYTItGjW8+bu64gLm2h75FvtzY2LHWP2JtQPrskvVed8Q1Z1kcUWlgaCYbWKGyAPDzC1UYLv8YQh8PaiTmmAxtQtEoX2hR6L8eR6Ui0tbmditA1cs3jhBtQvNWxwop0/1r89IlKiqMWdc0A+Brq/r4+0h1Es0PiPKIacZ1hWBet1nAa3zrw9fEJS9M3H520FPE0Y4fuRZ6nrCjVyD3AMHPr4GYihRnroLqJUxMtC2GGIg13Z7FJBbEESdTmZHQcFh2gc5TwYgk/PcIzcf2Co8X1ISlFNgjClesikxYzhKaqHVLFLkB6BF9DPxDQ13Q4gJCBA7Bp/D3EasPoIXLzTRf6hiTeXQ2jFNndj0mZjBKe7uIlakJt1gVAhFTW8cCvjy3iXj1/5V0OWPcrn+MH8ymC+8KF1hLjc8B7dkpV5+FBNhAB0BPRha6rmVcTCAAYgGzW8xuekgUKwVlNfT6dT922UOpDK/4p/VrguEkCJ0udR206OVgPyXZ/gGAQVjYrZfc6GqYO58JxshCYfkUfobCY7PNDDr9W1MKvPfhNf/UIHx89HHdWtIuuQVtoDuN6wXnasESCqFKwKq0IJ0IwhDCRXEpFcsSDfjaG8EB2omSQdItQA6bnd842oMY9+TmpsQdZ1xNjH3RqBayYwZ8SBqSQNDj427Aux/8LWJEK58VuZ8uC5/ykPC+npVTo5Rs1gxNlb3roxcuz71+fvf5+8P33L9cz5EnSrn11VAu628DJMi5Sff7W89dgSuGpXI3lXIypEhD8hGeNtGyEHPS9IMKoDaQH4UNgID0MkFMDcbB7cHLkunLYfmU+jLbIAXlbBUGRak6BgTLIGhSQIJXb9hs6kOhEbyPYCvqL05TaCghIJYQtEzQeOVjjj1hj5r+PRL9XkFWRZuEMWgiCVSe68mwEHYAM1p2ojo3ZRtDhxYFbopKMl2m1Rl3AR0g1zGmqQ4IKg5sTX7Zu7K8mi5TUXpWwR6pMEE7TkX5g5EC6k5hcdK5i8OhAvzVwYJsTmyRrZu9tsLzVKRygO+sdu6Ad7K9J8rKHpgnRDTpSOqUKZzwhmA06aaNMKswSsr4syD4YdGaARQRqv2eUkQ0wrF+ZPI5wXd8Mi31gFOiZl7N6OYDTp2W+GvuNAVHzdDdDbt0cmlG1HAVLnqeglH2Cpeq/SFaTcB4AQgAo7CVHpXYpwJ3wy1wXRYXg2jbStEmK/aX/aTUloerZV4CWd5xPM2JmWjd2QaZrl9p7/cw6/uxET3nyREQ10y/d5whw85uuPQLzm2Wk6tRlfoM5K2dcqJFZAc7MKeYjhDBLZlw4fH0/y4NJHrLsyYqvD+Er4Wt2TSBiQNP9bOJHRn8rSQUQ0XSwCl2Op3ta4VAvNDjnnVoCwJEYlzRTiLNVpATGYEdK7FpOhGZzFa4Mj0kmW9hqvsQaf2INLddaEgaPV1p7cMaq7I/mUwTINTgDgaJyETE9lW4C2LWaGRza2Vwv9x+TH+22oj0aB9J04Cuq5FByQxVJoIPMfpiAhxo4dEIG0wH69Nc3ozevegiLvIeKIumhnBbyWZsULgdFhhW49PtR8uEBOUCWBugCwWUPleOSqRKyuyzliw4i6jue3WmwcKI4Jjin2XJvFAaMZVKQdIZVD6VkTDHroYkgZCzTNdw+EVG1JNqRkmFkv/mdRAZ0txxq8XeDdtOQ/HsqdW3q9V2/1Zyo63zdDow5NDMsUmgbVSHr+RKpm/OLkAZnxZ7KMbBvourWlv0UfhdBW/3unfC6R10BrTzptYty9dJa81c9urURLHh6gMUpkEBha26PoqhKmh4M0x1P0cfryzYi+H9Z4IQcDFUFsY0M9n8HlSDjKekQ4aZL+2aIDDSU46KNCTPXM+pg6AKQcZyHdJcCvB5sh1AP6jBG8Rq41sLYyhK1NHtQa2Mu3Lfo+jJuZd7apkezum0JwdX36c6Q+Cf6NF1rSnZN7YVk7CfBUBK+09Os5zqLLV28W/+EodQC6omgAFGWBSRVm6cIqeqZcuN/EvIEhQjooRQ0sdWlCDV2jC/O3v/88W+//TP76d9+eP32+58uczJ/k7++u6FjMf13N4quJbAdPt0TIz5u7wifClzMaFKdqGsvERpefPz0T2sHbkr4DoPGFGXQpXP/CRfulDzc9tquO1WK5YhKHmZ9dkR6/fABAZQKsYbeRmu26Adm0wDtIe3GAXrb50vAOM4pSyIbgwSmyIGlTdWyjcjpWvBa96HRFZje+5OZMHPcoc02PizZHrHU81JxxnNeSpf3MKUKbTxcTDGjv2N1+PEMQbvvcJMwv9/F+W+BATg+v/n5rlWlBF+6LseJTUO4yHt8Gluo281jQYps2d8znK1p1ZDMlFIcmtnpUH0PSZrTDAsomILEahyjN6E6rxonshG832HEhlDWTj4VWXCWWFM5aONMMixln6Z7iOUtphksOfY4mYYYwWR+Piiq68sIHvIpmWF2yLCWg7gCWf8A6QwLSr87iCnNBDN/CDMkosBS0nkb/ZjzjGC2GfrrCaSieyjlkLxGiSBYVayf/laSMiaAtHHHwV64XUUFdmDX4yefkqw8HPeeAlZBRl24wfL1UwJHFQ6DPQBokJoEbcl0tUCbAMb7C0zVYZBXFW7mmBtogSkaSt05SVvp0iYk4Qwa0Yu+whvO5OuqC64rVHNAepDBoqYqlLJafwFQRkayyFikJKNQWd6gYFsDM/RC6MOkmkIVBKzqFnHfr1QOH1J4GiHHZlT7YdvxXempShItVOlqQLSO9Gy+RA/amKDfieC1knX4y8giW/ZTkmS64Fa/KCN0+4E8LOEOrIQPuHNCCV5CmUn/iewZQbQnAh3A4MxoiI7xPk6eWpj2nT0p1yEKvQRDlgwnT4wvMpJO7dGqSXDgME4WuKbZwQnz0xqKoiplspM7PAIxw+HYI1SU7iyEmoGD16KZTvrGSu1H9KWxfe5+lE7DRyd9khdqeVBsGmIEmdbW/fTRHjUubSLbtwCX1TQOzd0cshoRSgSxZmdfOVetne2xKuLUoWoaXQgyp7yU2RJ5rMheclADBgeSmD4L4qrl25TnZaZosa+fcF7NJA/R63EEKxbT0p2V3D1A98G1KQnqdTxk7XxBBRh0osndEikH6MKUO/FJDdYcC5CpK3hsUZxjlmLFxbJF8Y7j6wE6WxhBSnPbi3s/pPfWd/LgnN7EbK89qHQAv/nm+ubKgev2nWFXdap3RN20EJbwtB4b25ceBzIiAXvIcL1q7p6FdsugQWVPwMO5ttji6warn8d2yVvhveWsX0ByTOpBOXmhLwYKv3n5LEJBISgXVC33cDscxw5UDz2Hqfm3CLaEC33GmXIW25RuxfB5cPw0gBvc9TDo3O7zPVHbwzuKG4DQAaWNi3wqaMfZhZ00qoLngzdd12dY+3xQGVuYq+Xry1z3w+tY9uBiqPa3Yg4L1JoB/REsUKO6txgvYGMPPjFA05UTLTy4KA6HJjyZrbHZ4GCCpcQsFTiIEF6471phQv8Lmr86/X67gGGIKR41rKG6Drp6VG3CKgKqEEFanVFfG34Mm3HEiUCok2f7eriwNTGtXlm6Ma7H6sCF2LsoCKmw1RGt3zuMepSYSPm9O+Q26EQ8yar6dITWa3EU81sAotsKmHSavdxrImrFTE3UUgmCwyKfnXCjc4PHdjEzQGGq6kgRtl62PtoiUTVOzij607T+RfSrbTOEpiUWmClC0srzrx6zInZabbjGIWQTYvi1WwK82Jf7c+ZKbmxPK0NpSuF47bSEbShsWwjCiSpx5ojrJsl0udhLD8/1lTVTIqp2LS6wXu8lMebp0v3bjOEJtoMJd+fQnNqeKi9fv7n5AeI45v2ghKmrs9UmwqwRDZPn4uf3to+ECRIFqgOj601jZBVwWnC0zoR0mo+6bfxiVssqr4VnIyBKlKZ2D3qvSiT9USc9d76TFv03I/fNyH0zcp/PyB0dxYg3TTt3m/mXRGGaycBV8wcGDdhtp3TDl99peGvmqMzacYkG/3zRPZdjEthECsGdu5uwH9LDynzUQdNalWqRdt9UpiotADiQ/RXWQm194qNWJxCKSDtwrxZai7oLnhcc2iXwiRsrV6EaJ2G1BEMin8iyWWO5rVJFSf4A0XEnNTyBSzLhUOW7jI9xNtLhHTmCHVLP9cvUZNhdpQPZRbVqpHP/CJKDxqBr6e1aCPei9w6qk1JSb/FoGwCa3aE2jdYGCpLbSovg8fWSTng2aqbZtp5q20y3hGdlzqCniT1ZYnvcuEZjWLcQScuEpOunYshJ8USWIwv98zJz95PnApo5f9LFilqIcgMy8ZSy6UjXoB1aY8CJC+HDZgvbHjL6QKa9o3bGyyyFPZTrpv7zx6v7f5xe/Xp18XF4BYsmhI4pKx04G2dQgpI5CdQNDrR6/YNhsnl0Ko3DPzjqEsMKu7SO9RrLNsng9SyomPE2RzMd3EOqusmSujXVqFW8s5lhbw2GFQrUaNVBd/tSmy2OnQRuIsAWqW0Vd6dNDR64BXTOs3l1yX+cqhWDuhNdumWc+Wasm9bD7tEPK4yopW9wtOtqchiaNIbNCWplVw5JUTgNJKYpwpOJsbQGLTohtOqIBITDaRv4vCxID01Kplsg6NPa/rZ4PT0a8YEmVwqLKVHRR3bhSkNDiTNVx28/3l4Mrz/cHgNhx+fv3t1fvTsfXh33qiysT4iuJrRRjrofmTPiRXZaF9dqIrCYykMR8YERd3sL2F+Ck5mXhYaGTrDUYRj4EBlGR1Qh4PLGWmL/AJbv7v7q7vz+al+b54irH13YS3Atu+dw2HgV1Ha6F2MkCfLb6HDbgMhEriIO37YD37YD37YD37YD/7W2A6EoIBj6ea2ps6KWLE9ldEvwzbB+M6zfDOs3w/rnMKxHMRnYk7Ytf76jxm+DOr+WKIIqT7MVltAkvCxso0jTAczT0WxirD1euy2Auy2IzoviWl4MM/ThDjZ+D9UGIsotLqElprJ1PkebLh5d7FRZO1O4bjskygYec72Y4b3+C8oJhCeozIGNsp6E7l5bHDv6CFvjN4RWDUyDl5AV2KTqq06wlLUg2fV5RTMXoKOlJB0ZsgUWYPjk0eYk1QiC8CSUwDrcDl7PVL/zJCmFOWz0d/OLTjDrLpB6hY4SFTZR3nKw9a2NqCjlrK2Z5y73q8tNNH2CJITObZtK37Nfj4iEpC+Ef+6v3l0/DK/uwajyzcb7sEm/lhGtetsOOhGvCXduiBqGt5rLwh7bAmMO/4RTHXOiS0MjEUY04VnGF9U42J4vTlUYWZwKkvM5SU0rj05egh5TO3PSEiKgRLToxtrolr7RIrgBSgD7xYLVVq9Tm8UN7l4yiOxQtenp1uyNlGyT4WkR/C1k/S1k/S1k/d8oZB13ScJWyOvNXod75PonuL4uYFF8sRc4qfVqo2aNFmbIvq8bMoQrGbY/2Fc0LNazFwgDGrvNJJ8SosnqoZyL6galHC/tyjg42sziOsE0ej5svyANXb+GWueWdonj4KiThlxOj7ZXlQ4qnNR3IeQQjlVFiVtotibDrqz7r9RuieaTsK2Ge3y9koREwV2z0NzOnJNKmoW+m8pqgwU6QGJv4uaTZkhCCTqdmkOe4bQYHK3hQW/NuuhaqfQbEF4FVcApk83NPYYja+DWWje3zega8jWAz047HMyiCbbkL4ggCA6yurvmNBFVL36XeJrh1J3E1V2HSYpOJPRMgq4zJbM9prNgrKrDu34ww3N2MQHYndWXGr8ZnsNPwZH4NOR5DbFjuC602drgcxDrB2wx45KE5OpFUlcpGr2HIYRu4GQemWVr2FkIqmoNyvef+ZfWsau55fBvjctOdAoXu/Gyua1vkgfh+pEVUQfyrlPC3QReQ0IVM5di1WK20wLCT/LJNjwG5HoG2L1srQ1AjNpD7yb0+ud3D1aKE0z17evWhRscfbmdxAHokSoPGsDvT1FrApUMpjWr92WKUQIFxiDLUhD5+chpGh+tZtCUQ1B9HyNGlgbYl2k7SpLSv72ZSXKiPxgXzVuMthtiLKbaoBxOqlvuFrrJdl3h0llSzF8Fpz4vf7y4m79qHfk0X9dOeHYc8PQQ4/5c0xVzrwWXztRnRZeQauT9Z/ADQsGd2+j6sgcHVjBLuWvShxJYR5iNsNXeNLFOXQfmI3A2/gnRbRsBh1VGSp40ujQgtyOSNn0BthKuYXVXjfpzNPBzPbtrw61HLbm0L6SLLqwrpHHrJ56FhUiGCyh4Nf6LpWlMppj5cCNOfiup1BfihPcxwx9BGFngzDlCEZqb2ckdhtAehoJbSUnj+k9Y5Knds6IZX+ifQD/d8Jh0Uw0c1XeoQ6/Afh/ZGIq+Lhkabws0FhynCZYqwowRz2irLuLDoEtW+0rPzr4srsnVdsj+c9Utoj55EwoIsnQalT9nVhHl4PgLrPWE1QXUcBG1WVKwRMdLXorjAFWEHzMeB+SGT1q8eAatctjtCNzaZhMTNYAM0i5SkQLZ7j5jzpVUAhcr9BkiwMt92dBAQmYiNsYmQnESJtxqkE7ogAwQNiIwIM1Tz7pVd4f+9ENP03cS3ZxfeKJPzH3uasGfdQ94I0i3w/Rvrrt2sMP7A62tHfiOQAP0ESTq7/Y3f0FQH96+vbqHeQ4fzi9+8nY6wgIvNu3z67vZgAqB87zcnDdLAOKFiSqdGBjaxwFCPciYlGe8aFvXFQ1y693f4O1qFjlCFmCq1EzwcjqLobQ3EzT3RzsOrdsLObD1O3J1vzV3aS86uQJrzYjq1cC8h4eGOHvqIaKSmJhM4v1oXXCp6ZCEIKx0YrvCLs5rmlFvaLdGME44vp2Gk1JtoMYEJgA41vY+DneNf1088IfDXee+iyZEW5OMMtKDHXQPMfwEv2UES9KzNTyhGEM5FBgOlioiRhbYKKNSbSGRtWxTacfLXccJltGauco4WuzuUrVY8MFKj6SNOzk9C7KDRwtbr74ja+4a0CNGf0fmaOAMOqZOgNnL64eLD79c3T8DdjEE0Fvw6uuFe1uvgxgVWCialND7OFhqxsT7Fh3cu6Xat/A5COvtpRsctzlN4RR1uIqbspEZZmlmy7BasOwE6KDfe3CfbeicYsHOlVQeo2fQVIzYREYLkl9MZTlmnTUcOf40gv3TyDI7kvR3crRhXG1nXnL8ieZl7s6V18yNc69a4KwGUqmP6FtHEieQvulgTtfxrNOwQ5qPwHjovprcOgrZ0l/OEGVtTljq0huYNQ0Jn9Tt0gD9op+XKMftpEEy4xCzVBylZEJZYN0tFi2VoHWWpjThbF7rumobf1aTu0GTQKblo4PjWjxVlZktYOaIvrNCA4TeQkDB+DSmILUiClgz3fPICmX4TlYLeo2+DoVIOZT0NZviH1AX6mpu0Gnc9g7c5o6hBUxnAQSRPNOBcncts0RzikEQ6NLA1P3JH2y//TivTI6MjT2UZaozZO1oi3GMMnu5VEBqC5oh3QIJ7wVvbA6jrBlFdhMbepeMbM3j5tWWa7g9DyecVkbY36lgqKGwkOF8TKel6ZK60QwHLcgxKydYt6OBlYdUOly7ytrbuxYwexumbW3DJ0q/bJYDU4nBoGy3lEoswSmRcOdLqWsh9bLXAghwLIVjAoZeDhpbcVcxArsddP/2An3/t5evO4bHLDijHMung2megYkAps1izEh7OrmkPkRCWKS6xHr4HXSXKhlBl78Rn0wkUSNJkij9u6yEpn8gMpCtWOvGwv5kvRZn31qgrCAoc3E6c5HpBecipUzfRv6RQddViTM0pNCc5ePwosvNhqawB/K8gEcDbpVNqPwz403bV9p8claTg1WADjZAtgc3dopuZOVgMvz1zV/Dx9vcbGffmCoOzA2Vq1ioDYoNdMLqczu8a8HazWK7dexLrLphFGclUdWua6T3pCOrRoeb9W0nce02rBn7dhGl+6ufP149DKtdWseuDCPNi1HHWDyyvkuCdltAktVzHVRCJz6E9aznXE/7QCkjGbvGsmiGY2k7R3liaNN319GCjrGxu4EGquj19nvv9i1rirvb1P21Pm5T0gKoeN0lh1XRQLs998E+F/A1KSw+qRKO7Yqh81Wuhgd+eXXx/vrWH+dGtebBNu/g8hSAeTGrBXttOCZ1642u9tkgTKGzL59zdtQnMCDSDRfFHGdmebPaamMKkHtswSuZolltUkBOTueT/B0H91e3V3+/vn2nLwMnnfyOwQay6X8Njn+4vr1cxzIEf0cTmtXu5D+wkXbzTvHKU8bQYV4B4qr86Tv4+J1xkVoA7YyCiWY7NlY1Tz6iq3+1GwJ/GVnKgvtqjy9vH9oJ59uH/laNhVMmt046RxLNDUVa0VQZXKzL2wdU4OSJqHC37GJtLrtTCLhSMTeu8pQwuMJYh7nqg6tbLYCrX9t6U7gmv6DuuoeqV+LgqMVPO3mxAf1Vf1e7v8eqMSGeKNMt2TSBzo5aqxfJGOroLFj2IHXLBZ2CQ8yFv3RGLG10RTNHmbEKNXAVq5Hguu7V1JaBTj4P4AQatK/Hau/bqM61lACsFQtscattT3C8xy5dkCtlyFGwbIWpm8EI54rYElDZCJnUGRMkKXV30pH3+T4He4sZ0QEli27uilPtCUYYXY/f0l6DGgQlNmAlJbJRtnr4cbJWOaWCJEqGWUVwNUohS9KoyTDq7iWQLQfovlscLrrYya4/FDmCcrrPyqun2bEIYQcKfcMDlW3Euzx5nQwkM5I8QXgnpRKiQ19ovDSucMBqUMDSYgjeQFKIpj5G6+upO9lRomRwLDQdReRxWH70sUmgaEKFVOj1i5f2kLQl1Dj6UIpcg+h6p0ZYcCSvtPfOwoOzUcIyksYt6e2Hq/v7D/dtLN4aNRyRFVJoBiZNvhJGgpJ0gK7tMUb4Sa/K7tppuKSL9QtBWbtQM5lhgRNwitEJRMQW6PuXOrA25nOCXrx880wH38AKQbA9eBwicb5/bk1hERywJjLBBazTsC168dy13JXo5F+Xl5fPBugHnDwhmWHdARhWq99KDgeJAa59OZQoQkM8lj2UYCEobAnMCEpzNhqSr2hCSGre10F+YU8W/kv10L+Efq4G71/MVdMbCxQbvsViMZhyPs3IIOH5YMUwNvLYLWVxGWdBEi5S2Ri8GO7z8/PzFQibZ7dbGPUDgHIrrNe3K3ASlaWjIivliLOV3BLdDw6spOJFX9eIO9U9IcP3l88QQEGcEXMYSd8/H9ITyZnAe//2ApZ8dDzhfDDGYjDlGWbTARfTwTGsFMfhF3V4eva4xiwpUUTkwa2xw/eXtjmA2ZQwRPIx0ddyJ7xw57JqAGGpMZs2uAf37PRUXx6XyHIyoZ80BTH54hz/DqPHB+VTRJ8wk4t6NKwjtL/CTpwzhIXASzf/gUmMUqqrNjH4hjo/ZVq4aXwQYoUf7aSCaVtPkVUrRDfNrd4ju3j9VTEN5IZKkRCvu5abyqF7TJkcWOSPZh81OOokb6WhbZpWl0DwbUtCUlBBhLar0QG2/+iwF46YTc2FVrIG522KooTc/NqNfnPjAYvcHkRc33YToVTWRUJbMeqRgyArYL2aNj06mTUmKMHJrLE+jckErA71KZUxAW8owSKFlfSfcLOoLYSBQxyV56QlESmChTtkPapBfA50yqHhs64RBDxthTV25stxPrAVcJj5bkJwCNq8AedB5VEk9VBl492ghzD96Lbpt9swSj6zvarq8f3GzxksbX+bltko2GqK/yBrVRHgLVYTaMeDWp3hkCpeOnWjLMlKWKKah31rhDbqGSboTkdVxgSr1SL6SixmQNAXsJq3D6tJ+GMtp7+Y84vNuOoq0B2nXEXyHzTlKgLWTLnWg19qylWIv5IpFxD0R025gISvZcp9c1gCWfxZnRZeqEH7Mqsa+UDOFaiSfS6qK8fPj+PAU75trCu8wTw4qwdZJAmBr4eriw5GyCc1EqvCVFefFGFgrlxQS0eq2mawYuuH88tfru4fOpgr06JZOLveiNv7krn4TqKPl3eowMuMYzgj9ztBJxROCyoin1VXZsJ+Oshh/Tgc3rWSWPDldlksCzWextrgZkzAeKBLMVucRJ5p0xjDEeLRCe76ZFk5Md3khCDUsjqIIGHJg+JWa1EG8YcgztbMU7ih7n+8v26hgjid63fqjBUAgVSWfV2LWPfB8VlS26ZG35/tEl+Ko8dP/cVi0QdY/VJkpoA2fRxEBbPqxr2DdKhsy/Uc5bhwy5CzeAkuIJyeWoLsYHqHyilBnQn483cdi7BswLpvIYFAvK8Bt11DENg9Vt0cV/cpzB9LAghIh0xtILeRgtRGaenbEEnoXI9VOz4EfxKe51jGRwDGdKcSl2ZjpHCydKMaaYvSgBs1VPAXlAirMxR7ac0wA3najEXI0w2dBuhaVwPpNAXorlbsRziNAD9rY/rYAuvzJ/YcJfBv21Y+6tdmWM4e7XRYIQZ4bNcihzizM/LJVqunUJAw62S8Z+e1VAS7uwhbEKup3mDLKfXKBM1RB+WWlo7E0zYWtbG0tjANHecdaaDa0vrq+aujKJZiJrDcCo95oxPTLYfmvSVLB3GE1kL8Cexhu0bhAAaxBU1fkrqfQWzBHC+/qEGMCeprsojW+/xvaBIDzj+jTZyKIjnqIHt6f3eBEgy9mSF5Cj2RoHAB6Dt9OdjLQEJOiCZkV4EObdPoJfqtxBlUeKX1qmacwaFFi6XT3s0I1AlzkaWDd4KADxu3ezlRM57uQ2yEOAO0k7YHvPwRyItTZNaNZqXByrnZokkPb7AA9ZDCT6SK06FHUI6+eeIRaidpRkQnwa9XUXoo2TVp7qTm9sNw9PbDx9vLOFXWKu9sSS0JlXGPSc7+GoguSov1fgb2cbnzgFYhTgcKbodutGpZQ8TnMfdAneIKZzUTvwuVdr1yr25K6bayauWso1T84cJyZNr4B03yMP5xfXHTjn+YtQh+QltFQSzsuLlvmnr30gYBM/uIY1MTFrvdveBS0nFGRiYC0FxWXjU+vzlqEdOYZ0frzVGN2HM0K3PMdFNScBH1gDqyHexurI14eFRNWsKxL9sW+Z2wG8vAdrDh5RWwbZVwNpAi2a7fTt3rAZQ2xlodrnHig6CtLsfWtVw6D9M8YVLvpZHRJ2g1KxVlJj1ZMgGZXj0wcKYHsv7QuZikJN2Eu1Tu0E8qJGAnpjahTEd2Gz38N1LYoQsLw8t+Tm1FXWRhLdNiA6JBWaI0r9PKIZxMEDrca7UFwBxYpDDY+1IXjv0hSKwvbNuO9RrjZIGvwNtlnv4/e9f/3Lat5H/XX4HRZcbOjK3YSdq+vrnOVLWVq1/9RWc5r69zc0NDImThhSIZgrTs++tvFliAAAlSoqQk7Yxff3gxRS4+WACLxe5it5Ud+utm+YTUtxRQSB0lFG5yS7aUji5ro7vCR7XNTv8Q+i3+DVud1UK37U6vsa1SMtXFpcZRrl0OXli43CXnnTKwy/2QzGgMLsD+lIMfvu/QmsOtdfn8eEoFC49IH24I9mGSSG1XP4YISUxRqn6UeZTl3w7BOrA1GxSE2e7Oj4yulG3EBO4mmcanfxDk5vryjxYo+N7uaAwTkCLGyGI72oNsvQec9qo2Tswq6QuWqzrCDyz3xKKqES5Zn6SwPpRLRIp7VRpFXt7xt+2QRPTCyzKzfPfAs5FVJgPQyDlXdsOsdpSA9cT4mIrMPg6iRM8txhM+x8le0sabdS2zYs8LFhmmfMwm9wumjHAX7Mfr365vfr/uH5H+ZULDvqvn9Cd5kjH48ZxFLJf/OoNoGJbBPy/ieQL/P4no9CzPIvj35e3Hs4yuIpbVadFcwCuTYgZpFeGfHyiHr2C6QbWtfts0eGGSYVK1U74ZLZ1fbJlGCdyq04ZXSECyWrCMyWz3Nj+Jnrc+OksulWHrrFFKPGkQPRTMJXavGT0wA6hNOeWGYFp53TbwUnsJ3JIZW46+vpLlakQ1WambJoc+zkKHX7cpeqlX4egMtsIjg1aJXKxK40GBbPvWMGxmqGNzJx2sMxDZxHqGfFsomin0894xKKLoNkIJZu5cy6pzkbNVOQSl111zSnendVf+S/dBfjx4nBYQ37hjH5AKFmK1nW/YO0TSxsyamXrLtQriCjIpmhtuJpTTPomh9KuIB0IOa8PRKOkc3FVHwDZctKzqOO7mUg+PXfQdYKpx/sSe67yVsfub49O5eICWM8gCdn9QmGVwmN4V25i2dziGU5hPxoFCDvlce4XbmCSjnNG8ueNYltZtjJeSXn3PLitb1ivVVWvwWqjuRJgwuA+aA+tDqc+ApQPc2nYWtCWXJcramP8tuonz9uv0E0Wbv4NbzjJQCb9/b9zF2F2YUWW4lh7NtdMNB+JbINQCZLMVUXWVeOdIk19lDW54HcohxsZyh5DVMV6epOcsa48k+jMjVCwMWZTTdQDXAJEYIIlGPMuk9elNyPBfRNJfq27xmOecRl8QB7aAO5cJN+2+VT2ybJoInj/vCFYBSeauKOob8n0tcVqwZHQVVApb7qCWlDwAQ5ouUVMWi+6DBiDIYDDoyzDlfpQVZAamBPWsdWdVzFMm+6B68WIb/qH1X+fihb3rQER0Cv43mcvnYAMGgpF+L2hsa/9ukGiRJ1ArercxVTzStMgSlDbc9hQk/ZOBRNgTbAqgykMdMSxZNOh58lG5theR0zicPvcPfzp5fUT6IkpW/cOfTuHfskCrgNwr/cOf3r4+0iY6mF+YcWheacCIMdhF0Xbbwi1/2ZpuY2cWn+aEJOqokF23zr3CQq3EC6vbfsmeUrg9tCMw0HdgtnAofAl+VOsOkruf1zjr4vRoWWbo//PdCQnps8AUDXZrWN4bC1f242SlDJQsEnCTySGLeZ2mIomKnJGPMX+qYT589/Z4ylsZJyLG0qAQO3JOkoFYPXnK5zFZ8lmWaBxazh5EWRFIuQrlU+CTdrmBc24fVhO9g1bOd1NINKd/M7nHWvgVJ9XiKlsk55moGxl5JuUEQZpkRQWGO9pL051N0Je6fVPfvtRBtuuV9M8FZ/lee2FZHcx2qxc4F1jPNM+Y9NFIQSwxlEewRqwzKoIi5rubfM6GE3I4S5YpzdgxjcNjsaLpaye/nVnFbRPyKwJSbJO2NHncORtOlM+SFGlIXa2abCzFpb6zr/MPEOMi5zOtpevVNSAjuBDNYsg1zoV7X1qLUocshrP3AS6qYpJmq3OmHmq1papopIKR7jpES6sMOtxsmcQPSTi1HfHw5HzaEHSmfv2l4fYdNC4wA5XQ/o5ZlAiGgiZfmF+1KEWKZMWz0hkNbjZGFvwB3I0qhZBx9xNyOLfzA93L62n3ksn3+hLo/WtCUxBFeWJakFChRhtZsShqCpIrOdItcKBaKr5liC7mTs9ROsCdGliZRVSJOXEMF3iNou54cp0xZgZUogyrqCEC+yyJInW/v1LFoKULOnqbzMzH6MTY5C1QEPCCH8T1o1KOBy3QXUAwSHqlolIh4br8CoFpDJKcHA5em33aaaDRX39k3jdtz5PEXFq0Wp5SORrNvdLJqWqMVua8u2Tyiac7yNoJwySLpd0sTGZ4CoR000uek2MIr86kAmPCmVWKPP2upZ0WkXwReg5b9nHPU6kD5pK+uKM+qeQCbOrtrZyGO/TXinJkoSmp09D5aTnvmyDd4u87QvIBMCxNo+d683pEPmTJcrN2fgcPs6YKiSflHOW64iYXhma9NTksmzUzJP+Y3Fybfqj0Acb1IXyjrLkgHyvfNoolmdUNfMJgzmcqzgnS9EUR8Golr7IuC5GTJc1nC7nsqGnaoZ8nTsoNw1w5+WrXfMYYWWza1F+SVxLkEXmVZCHLps9H5NWCQy3aV+wpjSiPZXJA8krENBWLJK/zUk2pDyB9xYTBgk+yHVgb8SXPhb0Tmr6hyNbvi0HDxu5i0bwXDcyHmNqS+1w4ETgUtxUJrcwvobEc9Xy3uDGfIx5BTussEx3Z9EudTW6qZDmIcroo0loYld8kqEbCKTOUcTZ1WOqNvYHCBtVMTVkGNmI3oYbaZaxyUcRkRZdZTFHzhbthKpf9R/mA3OjzmzAAqJN/x+gOVzQuaFTvqpIXFx10RpQwlsZuJiRO0JtxcDsaX/6B4T1yHWMVfjUTTIl786XBqzdWVH81zPLmWl3RcvDexDN5bcirdq7RzJ54IxuAplaZ7PQbJRc8+jpc/toiE4a+iAdfKrVG2289bchz9HaNyE83a6XmMW9kDhKV73sIdTZZ2bSxQjAsCKDTQD4QebYFNdhAPXHVnkYg01cwj+hjs9wC1CahM65I+YGH3AycOkXHVPj6tiaDfCmFkKXnoIoomYFCDPK6yBfHRcyfmlp82KVFufy2aVJsRF5Z0eDMrBsS3VoSOV2mnbo3zKY8z6DJi3PcAc39TUjuNVtAEDIk+dIFBJraxnfXJenSG2vZyoHQ7VjH7mfxObIP3c+T/75sOnLDbw0H7qYzLJL3C9YmOclF9Qzb3Zamj7aAGXdttRkI/3G2zOoCNkQP76nMJszCIEtWotPQ+8/cEpi2dUPrKo52XkSNx2yDwSFYHgEAmR7yiAoohE5z1iByeSxYlgc87AT74noyur1Dhm6ImnuvmcdsBYcHiQJyQyUrD8i4WAaWvWVTlJPR5ehsPUprzM1RyiGXzHGOGj20AWNlTnxVhNB2G74ORzCQG1myUssWwUktiloalKWeHAhjUa63K9fwPoLJyvg20024WitXUGO7uAt3bQVUZLODb9KaWWLuStqmn5bGi4s4zSC1BwvLVkBnIGc3V8Hk7uouGN+OxsPbUS2rvHlh9K/R2ce7ka1S+lzutaRdW5p7y1AJHV0iQ8WgfldM2BObFWBRrPdqQG5ixwNErJwcECMWSMpCpuVhMShPobmoHM/tWkPXH+q1hq4/TMjj+zfvSKe9S9HttnM1Gc7bJgKgM+4dbR5XQt0zVEseJ1mwczuSzPrWctpK7vE9zMUxpEYoXX0kpz43WS2AvWUuAdSSHnwvnbYyNXr1uaW2aSwOLdB9ROcjh4tgzaEDamgFqrj5Zv371crWAh9jZXS55stWwW8g7Q7JQB7fLW471GQPj7DiH5CwSVo1mqRKMP549+HXsmue3qQ0X2zWjQ/QDnATBgc+a+zAkeOG1xgdYooFA5QjuTQQGV+8TEVDnfkGvQYJkuV1F0SUJJ+KtNyysiTJba7YphE0vUPVzCV7A0edNzHUABnkT3nP6Mnpg6snjxORP2SsWVkuX+imMeuGusmdLfb49GEPe/w+NHWtEo0f9qWqezUPBwBMsnKELA1j4BepDU2sUTK8reA3jbAFe2SZGzK4nqj+qEOSC1USSP8N/x2TD8O74WXlvfHw+uKsCWzIcsqjNqjqDaNSoWJT7UAjN8BE3kYeft+CeJzkfMbEZvMT2sEPQNMiKyqdUBjA5Pg1yWrBI9f0UxFN6AVQhliM29CjdySnoGwDu+QVVAfXN3cXZyNyAv+DPRr8DVCNpl8GnLMnLvIjIj7xNOXxw0GdBVBRJlDV0lxNosZj85JmMNhQTecpObsZ/1H3zKyZftxuElAlhT3Q8GSa5IsvdCSc/+mPhPN9HglxE8tYyG1d+Rb+bti/5G/dti5NvtvWpWDW7vFtFFiCjh+clhKyCR+EuAWfwdWb/b66i60fLbexLxkxoVrScRM16ClPGaRECEKW5gv/9rVRx1x3Mu75grgVBqATMUoDKz2y4sKK2jMeznBxfoQOUO0o0+/yXLBortMwnuKVQLyELP03kIMgTlyhoDvrW3RpMRXFtMYAew6u4cAVjoCsoSUWqnIbJbMFjWPm1P4FMVFMgdBUvjUgY/2NQxHHVCVUyBjkdtEiH060OZartvIk31+NJpPhf43uXY/n/Vg/B137fmL+qmf+qy40m0fYFee35tN+jV0wRWxu6CkLYZY20wY9b+sp7HhZvEvrSEK57c2cUpBU9IzFKcXbBjAVl1bjimm7dOOF6Em0prPNG5uF4HZutclFPbXa5KJjZnnB086yF85QPrtVfTBqvTyjUXR8cY4XlI+08epZj0nIaZQ0VJ/72/vp+9kP37Pvv//h9OTndPbu3YDmEY1zOsCXIEtIrw5XsM9bYZ2wzwboIomMMBIg5OKZkXs6rqC+qAz4d6fvT7/7kVxc//PiblSHOHejajaG+PH2Qs8ZCMxBtF4Egqd/pxGfsZ83Ylqe7IjnLlmHZppMf57yKHni7VCKjG+DxcrnP/AQFSwL6IMbtrN5PwXLjofwNXZS97r5zLvtLenqvWhsaA5ZxMrQWx+P356cNMKo5e3euOtw0SiJMY13BzQ3v9XBpFnyyMGUSCNTbVd05o5FhRyePj29tvkFCtaM8UdQjFQhmg3x/s/pyckROf3byf96mBimNZStCsOkWC7BiYvsEnDKS2L7HUHYE+yRD2W8imVNsRFWpbINLJnPWTZA8kHF19s2zF7Gapi2T3hyPq7cD6pO+DqeUvkLMD/hLqgsVbKS7XAbbEsWcroLHEnAfrwejqZVmXG0CHlC3v94+sNbcns3fjP855ic+LFjybKvO9B6sbQh+nZDvQm6rzrYGpDW3ZZOYaDJlacwEDzsqL4tWwoDVSWF/mjBoq12d6wym2RmNB74I4v1iVMfcmLy6+jyBg4co18vbwY9z1THDMut2/6S8ijYVjHKwDYm2LF0CRyCL5Blr2HyUHI1vDARFF5wSktqxZbN0jzYTkWaJ9mKZiEiy9iMp8ALBHd7Nr5rBQdKUys0sLINNgm82zIlgO+cUl7JPB/eDaXTDd+YLYr4U/m7Q+2X8+GdLqCm6GDBJMJFfABhKAkU2CjiCGT8PQxiAL0Dd65DyLh2a8wwq3ArtUv572ytq3oNxRqYt9+dtLSv1CWxzYSBdD66fYUITBnCC+LmNy1u8kXG57klcO7kg+Pb8VlN7Ci7YvlCN4e3aambEKoFD3gg6f9gjkBooTpgyYA8u5xXi81O290uzl0HBfgRK3XAwOhmxSLI+EU5jEJGFIBAMdZpm/mE3EBWyRUXWOz94tyqA6OnNjTVkACyXohiDSvsbdoeNUUH7EQhm5cVJAHRpfRjCliaDjlauiPuruAqTRqxJxaO0eE/6O3J8Fq/7VsBD2OKgdkyWANWnW2NdShuYJl9mjEJRnQyzwIKxyJb0mm0yVptbY5Xr9LINqzfXU6a1ublpJtekEei84oEJ7dY0E8sgIuwcAUh3M1D+/uCmXyrd5cTErOHJOcqSgFM31PGYstfYe5naHV0UTEMywORrOIpracwS7LnFEZq2ZRAXhTLXXuBUwM6YAFT4LEBtdwp3Ph95Ekh9ItNkCS3glpJl422BC+4JmAwaRkpQPuJpI0NJaiUROQGLju52TH6PFRpl+3uXpzDjt7POSTKK39WfxMGdTobeqv0vGDGMohQg7uSAZ6C9jm30IkKnTfUbY00T+yod4ZOBO8FTPzCAtzcK1m6x6JSX2m+1WYTwkAq57fmabCGI0bUYnSW3iAurfit6bMdRC67QVZgfodwoRq1WbJcFrHkAgkLuHitZCDOi0HP2ylRpMphEcx4umiq1Va94r5B5y7xmjuStfvAwRUSRRJhIjfueZJVwGoy+n8TxmRdVfH3N29Wq9WA05gOkuzhjUqkswQnwJs8EselSlD5c/C0yJfRf7gPj9+vZUuyhNuJopQBe2ORnQ3AagYVJ7y0rFiGeMSWjAHqx0D2mIeVvxRb/FwwwsLf5eriqXUZOijXnUUJpvcjD1lYrd+jKTQvRGeApBSRRrOAxyFekKm93r4+a4D1pF0kIgfSotcIQAbuqVaDiD6zLNDrNrB2zl0B1SeNvbYsDMcSg5Ed7ett0NwtXICB2i6+EHwWP+Smeh22iBvUEWR20VMEFGAZVbxM82fMJuGlCHtG+AjbgGB6rcidTxH1xXOqbeivuC1IxzlmqN9Acl6YkFGZ4ULkhjgyioWV+CYVWwSlcHz8zi145QbOY3vGSa4Oel5+qTu1ZsPZL9/UbkNEwXOGF4Nr3TOnPSl79VyrkZs+b9Wp2nax5w7W9omNulmjpbvdoY9fZTeo22w22Q12FMa+vbsG/xoJsdBujrhyt9cI8WsI1o8Cw1niOCnA5S1FBa2IWJPuSvPeS8sdDzKMVvRZVIWxNVMq6ralk/fWzRSnZ2flh7XJgZMUZJ57MXbQq8+Vnm8UUHD1fKyvGBhryP713cmPaBPQArBhpQiWcRoFnri99mF2moPlYnHxAEQ8kMU4hoam4yQPlOu0Qlu1W0lJUGv0HE4P6Hq1zh7WmHAVe/pIIx62YKDznGXbQ5CfNyCQuf+YaGhchijNgk/sOaDRQ5LxfLH04thaBBuylR3YHSyFA9ZzVZgRPMqT28nwiJxPhqDljM7OJ8P1XeoS0OR0ZWI5Amxo3gbh4EDzImNflYXOKB+IEkUDShpBoJm8cCLPAaK3qWx3OVPIQuRkWJIjkJpKeEe2AUtGV9tyyBSqKhuBTXk8uioNkL4mReEroL7hXqw7bVLvaCFb7W23fVimhHQuqWzCjho6WYsne8bLGo2tJdkDjfn/7eWgdWPRqhZIaWuXRpBjcef9/GPMlc+Nxw75FhRyc4xnbNemx0gHpFDGHqD/CARHswXDLFku/RENnWFc4xUzOHpjgjN9Vbbc/+2Z2fMB4kIULNtuTYzinOfP+nglClD04lCKIRa+LI2XpfHXWRq9Khq0dlhv9datj421cn3efNHKX7TyF638RSt/0cpftPIXrfxFK3/Ryl+08het3NLKq2DqSnkwW1Dn0rx/t3RwnMEnYE7MM8iRqndt1Mo3io35MgjQWt+OgEaQoA4aEb31A9GixAxjBVJH++nrfxD7JBuRk0xqDxAc+owP9X0jD7Q5jx9YlmY89hR9qMotB9kH60tkBxd2kNagVxdR1qMSw7/pO+d5U/M1CP8YvpMNapdJiahBQlYelxAWVLiR4O1D5EVT1ze1zgk4LXBQlmBRnUGCt0pVN1XrF8CnAg+UUpwnZEajWQE18+USB8AVcBrYv+k70fOh2mT0Jjhf5Cr+Mw/fpHn8EP03Hr/JJgP4/wMA58tk2Q=="
 }
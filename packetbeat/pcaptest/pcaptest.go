@@ -0,0 +1,226 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package pcaptest runs protocol analyzers on pcap files offline and compares
+// the events they publish with golden files. It neither requires libpcap nor
+// the privileges needed to capture traffic.
+//
+// The golden files are stored in the testdata directory of the tested package
+// and named after the pcap file, e.g. testdata/redis_session.golden.json for
+// redis_session.pcap. Run `go test -update` to create or update them after a
+// change of the analyzer, and review the diff.
+package pcaptest
+
+import (
+	"encoding/json"
+	"flag"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/packetbeat/config"
+	"github.com/elastic/beats/packetbeat/decoder"
+	"github.com/elastic/beats/packetbeat/protos"
+	"github.com/elastic/beats/packetbeat/protos/tcp"
+	"github.com/elastic/beats/packetbeat/protos/udp"
+)
+
+var updateFlag = flag.Bool("update", false, "Update the golden files of the pcap tests")
+
+// Analyzer is the protocol analyzer to run on the pcap files.
+type Analyzer struct {
+	// Name the protocol is registered with.
+	Name string
+
+	// New creates the analyzer instance.
+	New protos.ProtocolPlugin
+
+	// Config of the analyzer, like it is set in the protocols section of the
+	// configuration file. It must contain the ports to analyze.
+	Config map[string]interface{}
+}
+
+// Events decodes the packets of the pcap file and passes them to the
+// analyzer. It returns the events published once all packets are processed
+// and the connections and transactions still in progress are flushed.
+func Events(t testing.TB, analyzer Analyzer, pcap string) []beat.Event {
+	f, err := os.Open(pcap)
+	require.NoError(t, err)
+	defer f.Close()
+
+	reader, err := NewReader(f)
+	require.NoError(t, err)
+
+	var mutex sync.Mutex
+	var events []beat.Event
+	results := func(event beat.Event) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		events = append(events, event)
+	}
+
+	cfg, err := common.NewConfigFrom(analyzer.Config)
+	require.NoError(t, err)
+	plugin, err := analyzer.New(false, results, cfg)
+	require.NoError(t, err)
+
+	protocols := newProtocols(protos.Lookup(analyzer.Name), plugin)
+	tcpProc, err := tcp.NewTCP(protocols, config.TCPConfig{
+		ReassemblyWindow: tcp.DefaultReassemblyWindow,
+	})
+	require.NoError(t, err)
+	udpProc, err := udp.NewUDP(protocols)
+	require.NoError(t, err)
+
+	d, err := decoder.New(nil, reader.LinkType(), nil, nil, tcpProc, udpProc)
+	require.NoError(t, err)
+
+	for {
+		data, ci, err := reader.ReadPacket()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		d.OnPacket(data, &ci)
+	}
+
+	tcpProc.Flush()
+	udpProc.Flush()
+	if flusher, ok := plugin.(protos.Flusher); ok {
+		flusher.Flush()
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	return events
+}
+
+// TestGolden checks that the events the analyzer publishes for each of the
+// pcap files match the golden files. The golden files are written instead
+// when the tests are run with -update.
+func TestGolden(t *testing.T, analyzer Analyzer, pcaps ...string) {
+	for _, pcap := range pcaps {
+		name := strings.TrimSuffix(filepath.Base(pcap), filepath.Ext(pcap))
+		t.Run(name, func(t *testing.T) {
+			actual, err := json.MarshalIndent(documents(Events(t, analyzer, pcap)), "", "  ")
+			require.NoError(t, err)
+
+			golden := filepath.Join("testdata", name+".golden.json")
+			if *updateFlag {
+				require.NoError(t, os.MkdirAll("testdata", 0755))
+				require.NoError(t, ioutil.WriteFile(golden, append(actual, '\n'), 0644))
+				return
+			}
+
+			expected, err := ioutil.ReadFile(golden)
+			require.NoError(t, err, "run the tests with -update to create the golden file")
+			assert.JSONEq(t, string(expected), string(actual))
+		})
+	}
+}
+
+// documents returns the events in the form they are compared with the golden
+// files: the fields with the event timestamp. The source and destination
+// endpoints only contain the fields that are set.
+func documents(events []beat.Event) []common.MapStr {
+	docs := make([]common.MapStr, 0, len(events))
+	for _, event := range events {
+		doc := event.Fields.Clone()
+		doc["@timestamp"] = common.Time(event.Timestamp)
+		for _, key := range []string{"src", "dst"} {
+			if endpoint, ok := doc[key].(*common.Endpoint); ok {
+				doc[key] = endpointFields(endpoint)
+			}
+		}
+		docs = append(docs, doc)
+	}
+	return docs
+}
+
+func endpointFields(endpoint *common.Endpoint) common.MapStr {
+	fields := common.MapStr{
+		"ip":   endpoint.IP,
+		"port": endpoint.Port,
+	}
+	if endpoint.Name != "" {
+		fields["name"] = endpoint.Name
+	}
+	if endpoint.Proc != "" {
+		fields["proc"] = endpoint.Proc
+	}
+	if endpoint.Cmdline != "" {
+		fields["cmdline"] = endpoint.Cmdline
+	}
+	if endpoint.PID > 0 {
+		fields["pid"] = endpoint.PID
+	}
+	return fields
+}
+
+// protocols provides the single analyzer under test to the tcp and udp
+// processors.
+type protocols struct {
+	proto  protos.Protocol
+	plugin protos.Plugin
+}
+
+// Verify protocols implements the protos.Protocols interface.
+var _ protos.Protocols = &protocols{}
+
+func newProtocols(proto protos.Protocol, plugin protos.Plugin) *protocols {
+	return &protocols{proto: proto, plugin: plugin}
+}
+
+func (p *protocols) BpfFilter(withVlans bool, withICMP bool) string { return "" }
+
+func (p *protocols) GetPorts(proto protos.Protocol) []int {
+	if proto != p.proto {
+		return nil
+	}
+	return p.plugin.GetPorts()
+}
+
+func (p *protocols) GetTCP(proto protos.Protocol) protos.TCPPlugin {
+	return p.GetAllTCP()[proto]
+}
+
+func (p *protocols) GetUDP(proto protos.Protocol) protos.UDPPlugin {
+	return p.GetAllUDP()[proto]
+}
+
+func (p *protocols) GetAllTCP() map[protos.Protocol]protos.TCPPlugin {
+	if plugin, ok := p.plugin.(protos.TCPPlugin); ok {
+		return map[protos.Protocol]protos.TCPPlugin{p.proto: plugin}
+	}
+	return nil
+}
+
+func (p *protocols) GetAllUDP() map[protos.Protocol]protos.UDPPlugin {
+	if plugin, ok := p.plugin.(protos.UDPPlugin); ok {
+		return map[protos.Protocol]protos.UDPPlugin{p.proto: plugin}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pcaptest
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/tsg/gopacket"
+	"github.com/tsg/gopacket/layers"
+)
+
+const (
+	magicMicroseconds = 0xa1b2c3d4
+	magicNanoseconds  = 0xa1b23c4d
+
+	fileHeaderLen   = 24
+	packetHeaderLen = 16
+)
+
+// Reader reads the packets of a file in the libpcap format, without depending
+// on libpcap. The pcapng format is not supported.
+type Reader struct {
+	r        *bufio.Reader
+	order    binary.ByteOrder
+	nanos    bool
+	snaplen  uint32
+	linkType layers.LinkType
+	header   [packetHeaderLen]byte
+}
+
+// NewReader reads the file header from r and returns a Reader for the
+// packets following it.
+func NewReader(r io.Reader) (*Reader, error) {
+	br := bufio.NewReader(r)
+
+	var header [fileHeaderLen]byte
+	if _, err := io.ReadFull(br, header[:]); err != nil {
+		return nil, fmt.Errorf("failed to read the pcap file header: %v", err)
+	}
+
+	reader := &Reader{r: br}
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		switch order.Uint32(header[0:4]) {
+		case magicMicroseconds:
+			reader.order = order
+		case magicNanoseconds:
+			reader.order, reader.nanos = order, true
+		}
+	}
+	if reader.order == nil {
+		return nil, fmt.Errorf("unknown pcap magic number 0x%x", header[0:4])
+	}
+
+	reader.snaplen = reader.order.Uint32(header[16:20])
+	linkType := reader.order.Uint32(header[20:24])
+	if linkType > 0xff {
+		return nil, fmt.Errorf("unsupported pcap link type %d", linkType)
+	}
+	reader.linkType = layers.LinkType(linkType)
+	return reader, nil
+}
+
+// LinkType returns the link layer type of the packets.
+func (r *Reader) LinkType() layers.LinkType {
+	return r.linkType
+}
+
+// ReadPacket returns the data and the capture information of the next packet.
+// It returns io.EOF once all packets are read.
+func (r *Reader) ReadPacket() ([]byte, gopacket.CaptureInfo, error) {
+	var ci gopacket.CaptureInfo
+
+	if _, err := io.ReadFull(r.r, r.header[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			err = fmt.Errorf("truncated pcap packet header")
+		}
+		return nil, ci, err
+	}
+
+	sec := int64(r.order.Uint32(r.header[0:4]))
+	frac := int64(r.order.Uint32(r.header[4:8]))
+	if !r.nanos {
+		frac *= int64(time.Microsecond)
+	}
+	ci.Timestamp = time.Unix(sec, frac).UTC()
+	ci.CaptureLength = int(r.order.Uint32(r.header[8:12]))
+	ci.Length = int(r.order.Uint32(r.header[12:16]))
+
+	if r.snaplen > 0 && uint32(ci.CaptureLength) > r.snaplen {
+		return nil, ci, fmt.Errorf("pcap packet length %d exceeds the snapshot length %d",
+			ci.CaptureLength, r.snaplen)
+	}
+
+	data := make([]byte, ci.CaptureLength)
+	if _, err := io.ReadFull(r.r, data); err != nil {
+		return nil, ci, fmt.Errorf("truncated pcap packet: %v", err)
+	}
+	return data, ci, nil
+}
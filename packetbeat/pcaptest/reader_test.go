@@ -0,0 +1,113 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package pcaptest
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tsg/gopacket/layers"
+)
+
+func pcapFile(order binary.ByteOrder, magic uint32, frac uint32, packets ...[]byte) []byte {
+	var buf bytes.Buffer
+	binary.Write(&buf, order, []uint32{magic, 0x00040002, 0, 0, 65535, uint32(layers.LinkTypeEthernet)})
+	for _, data := range packets {
+		binary.Write(&buf, order, []uint32{1440000000, frac, uint32(len(data)), uint32(len(data)) + 10})
+		buf.Write(data)
+	}
+	return buf.Bytes()
+}
+
+func TestReader(t *testing.T) {
+	tests := []struct {
+		name  string
+		order binary.ByteOrder
+		magic uint32
+		frac  uint32
+		nanos int
+	}{
+		{"microseconds", binary.LittleEndian, magicMicroseconds, 250, 250000},
+		{"nanoseconds", binary.LittleEndian, magicNanoseconds, 250, 250},
+		{"big endian", binary.BigEndian, magicMicroseconds, 250, 250000},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			file := pcapFile(test.order, test.magic, test.frac, []byte("first"), []byte("second"))
+			r, err := NewReader(bytes.NewReader(file))
+			require.NoError(t, err)
+			assert.Equal(t, layers.LinkTypeEthernet, r.LinkType())
+
+			for _, expected := range []string{"first", "second"} {
+				data, ci, err := r.ReadPacket()
+				require.NoError(t, err)
+				assert.Equal(t, expected, string(data))
+				assert.Equal(t, time.Unix(1440000000, int64(test.nanos)).UTC(), ci.Timestamp)
+				assert.Equal(t, len(expected), ci.CaptureLength)
+				assert.Equal(t, len(expected)+10, ci.Length)
+			}
+
+			_, _, err = r.ReadPacket()
+			assert.Equal(t, io.EOF, err)
+		})
+	}
+}
+
+func TestReaderErrors(t *testing.T) {
+	_, err := NewReader(bytes.NewReader([]byte{0x0a, 0x0d, 0x0d, 0x0a}))
+	assert.Error(t, err)
+
+	_, err = NewReader(bytes.NewReader(make([]byte, fileHeaderLen)))
+	assert.Error(t, err, "unknown magic number")
+
+	file := pcapFile(binary.LittleEndian, magicMicroseconds, 0, []byte("packet"))
+	r, err := NewReader(bytes.NewReader(file[:len(file)-1]))
+	require.NoError(t, err)
+	_, _, err = r.ReadPacket()
+	assert.Error(t, err, "truncated packet")
+}
+
+func TestReaderFile(t *testing.T) {
+	f, err := os.Open("../tests/system/pcaps/redis_session.pcap")
+	require.NoError(t, err)
+	defer f.Close()
+
+	r, err := NewReader(f)
+	require.NoError(t, err)
+	assert.Equal(t, layers.LinkTypeEthernet, r.LinkType())
+
+	packets := 0
+	for {
+		_, _, err := r.ReadPacket()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		packets++
+	}
+	assert.NotZero(t, packets)
+}
@@ -0,0 +1,123 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package pool provides free lists based on sync.Pool for the short lived
+// objects allocated while processing packets. Each pool reports under the
+// pool metrics how many objects were taken from it and how many of them were
+// reused instead of allocated.
+package pool
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/monitoring"
+)
+
+var registry = monitoring.Default.NewRegistry("pool")
+
+// Pool is a sync.Pool counting the objects taken from it and the hits, the
+// objects that were reused.
+type Pool struct {
+	pool  sync.Pool
+	alloc func() interface{}
+
+	gets *monitoring.Int
+	hits *monitoring.Int
+}
+
+// New creates a pool allocating new objects with alloc. The metrics of the
+// pool are reported as pool.<name>.gets and pool.<name>.hits.
+func New(name string, alloc func() interface{}) *Pool {
+	return &Pool{
+		alloc: alloc,
+		gets:  monitoring.NewInt(registry, name+".gets"),
+		hits:  monitoring.NewInt(registry, name+".hits"),
+	}
+}
+
+// Get returns an object from the pool, or a new one if the pool is empty.
+func (p *Pool) Get() interface{} {
+	p.gets.Inc()
+	if x := p.pool.Get(); x != nil {
+		p.hits.Inc()
+		return x
+	}
+	return p.alloc()
+}
+
+// Put returns an object to the pool. It must not be used afterwards.
+func (p *Pool) Put(x interface{}) {
+	p.pool.Put(x)
+}
+
+// Buffers is a pool of byte buffers.
+type Buffers struct {
+	pool    *Pool
+	maxSize int
+}
+
+// NewBuffers creates a pool of byte buffers. Buffers grown larger than
+// maxSize are not put back to the pool, so that a single large message does
+// not keep its memory alive.
+func NewBuffers(name string, maxSize int) *Buffers {
+	return &Buffers{
+		pool:    New(name, func() interface{} { return new(bytes.Buffer) }),
+		maxSize: maxSize,
+	}
+}
+
+// Get returns an empty buffer.
+func (b *Buffers) Get() *bytes.Buffer {
+	return b.pool.Get().(*bytes.Buffer)
+}
+
+// Put resets the buffer and returns it to the pool. The contents of the
+// buffer must not be referenced afterwards.
+func (b *Buffers) Put(buf *bytes.Buffer) {
+	if buf.Cap() > b.maxSize {
+		return
+	}
+	buf.Reset()
+	b.pool.Put(buf)
+}
+
+// MapStrs is a pool of MapStr used as scratch space, that are not published.
+type MapStrs struct {
+	pool *Pool
+}
+
+// NewMapStrs creates a pool of MapStr.
+func NewMapStrs(name string) *MapStrs {
+	return &MapStrs{
+		pool: New(name, func() interface{} { return common.MapStr{} }),
+	}
+}
+
+// Get returns an empty MapStr.
+func (m *MapStrs) Get() common.MapStr {
+	return m.pool.Get().(common.MapStr)
+}
+
+// Put clears the MapStr and returns it to the pool.
+func (m *MapStrs) Put(mapStr common.MapStr) {
+	for k := range mapStr {
+		delete(mapStr, k)
+	}
+	m.pool.Put(mapStr)
+}
@@ -0,0 +1,66 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package pool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoolCountsHits(t *testing.T) {
+	p := New("test_hits", func() interface{} { return new(int) })
+
+	x := p.Get().(*int)
+	assert.Equal(t, int64(1), p.gets.Get())
+	assert.Equal(t, int64(0), p.hits.Get())
+
+	p.Put(x)
+	for i := 0; i < 10; i++ {
+		p.Put(p.Get())
+	}
+	assert.Equal(t, int64(11), p.gets.Get())
+	// sync.Pool gives no guarantee to return a pooled object
+	assert.True(t, p.hits.Get() <= 10)
+}
+
+func TestBuffers(t *testing.T) {
+	buffers := NewBuffers("test_buffers", 1024)
+
+	buf := buffers.Get()
+	buf.WriteString("data")
+	buffers.Put(buf)
+	assert.Equal(t, 0, buf.Len(), "the buffer must be reset")
+
+	large := buffers.Get()
+	large.Write(make([]byte, 2048))
+	buffers.Put(large)
+	assert.Equal(t, 2048, large.Len(), "large buffers must not be pooled")
+}
+
+func TestMapStrs(t *testing.T) {
+	mapStrs := NewMapStrs("test_mapstrs")
+
+	m := mapStrs.Get()
+	m["key"] = "value"
+	mapStrs.Put(m)
+	assert.Empty(t, m)
+	assert.Empty(t, mapStrs.Get())
+}
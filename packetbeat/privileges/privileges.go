@@ -0,0 +1,69 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package privileges drops the root privileges of the packetbeat process
+// after the capture device has been opened.
+package privileges
+
+import (
+	"fmt"
+	"os/user"
+	"strconv"
+)
+
+// lookup resolves the user and group names, or numeric IDs, to their IDs. If
+// no group is given, the primary group of the user is used. IDs that are not
+// configured are returned as -1.
+func lookup(userName, groupName string) (uid, gid int, err error) {
+	uid, gid = -1, -1
+
+	if userName != "" {
+		u, err := user.Lookup(userName)
+		if _, isID := err.(user.UnknownUserError); isID && isNumeric(userName) {
+			u, err = user.LookupId(userName)
+		}
+		if err != nil {
+			return -1, -1, fmt.Errorf("failed to look up user '%s': %v", userName, err)
+		}
+		if uid, err = strconv.Atoi(u.Uid); err != nil {
+			return -1, -1, fmt.Errorf("invalid uid '%s' of user '%s'", u.Uid, userName)
+		}
+		if gid, err = strconv.Atoi(u.Gid); err != nil {
+			return -1, -1, fmt.Errorf("invalid gid '%s' of user '%s'", u.Gid, userName)
+		}
+	}
+
+	if groupName != "" {
+		g, err := user.LookupGroup(groupName)
+		if _, isID := err.(user.UnknownGroupError); isID && isNumeric(groupName) {
+			g, err = user.LookupGroupId(groupName)
+		}
+		if err != nil {
+			return -1, -1, fmt.Errorf("failed to look up group '%s': %v", groupName, err)
+		}
+		if gid, err = strconv.Atoi(g.Gid); err != nil {
+			return -1, -1, fmt.Errorf("invalid gid '%s' of group '%s'", g.Gid, groupName)
+		}
+	}
+
+	return uid, gid, nil
+}
+
+func isNumeric(s string) bool {
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
@@ -0,0 +1,54 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration,!windows
+
+package privileges
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookup(t *testing.T) {
+	for _, c := range []struct {
+		user, group string
+		uid, gid    int
+	}{
+		{"", "", -1, -1},
+		{"root", "", 0, 0},
+		{"0", "", 0, 0},
+		{"", "root", -1, 0},
+		{"", "0", -1, 0},
+		{"root", "0", 0, 0},
+	} {
+		uid, gid, err := lookup(c.user, c.group)
+		if assert.NoError(t, err, "user=%s group=%s", c.user, c.group) {
+			assert.Equal(t, c.uid, uid, "user=%s group=%s", c.user, c.group)
+			assert.Equal(t, c.gid, gid, "user=%s group=%s", c.user, c.group)
+		}
+	}
+}
+
+func TestLookupUnknown(t *testing.T) {
+	_, _, err := lookup("no-such-packetbeat-user", "")
+	assert.Error(t, err)
+
+	_, _, err = lookup("", "no-such-packetbeat-group")
+	assert.Error(t, err)
+}
@@ -0,0 +1,57 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !windows
+
+package privileges
+
+import (
+	"fmt"
+	"syscall"
+
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/packetbeat/config"
+)
+
+// Drop switches the process to the configured user and group. The
+// supplementary groups are replaced by the group, so that the process keeps
+// no group membership of root. It does nothing if neither is configured.
+func Drop(cfg config.RuntimeConfig) error {
+	if cfg.User == "" && cfg.Group == "" {
+		return nil
+	}
+
+	uid, gid, err := lookup(cfg.User, cfg.Group)
+	if err != nil {
+		return err
+	}
+
+	if err := syscall.Setgroups([]int{gid}); err != nil {
+		return fmt.Errorf("failed to set the supplementary groups to %d: %v", gid, err)
+	}
+	if err := syscall.Setgid(gid); err != nil {
+		return fmt.Errorf("failed to set gid to %d: %v", gid, err)
+	}
+	if uid >= 0 {
+		if err := syscall.Setuid(uid); err != nil {
+			return fmt.Errorf("failed to set uid to %d: %v", uid, err)
+		}
+	}
+
+	logp.Info("Dropped privileges, running with uid=%d gid=%d", syscall.Getuid(), syscall.Getgid())
+	return nil
+}
@@ -36,4 +36,12 @@ func init() {
 		//Add field matcher with field to lookup as metricset.host
 		kubernetes.Indexing.AddDefaultMatcherConfig(kubernetes.FieldFormatMatcherName, *formatCfg)
 	}
+
+	fieldsCfg, err := common.NewConfigFrom(map[string]interface{}{
+		"lookup_fields": []string{"ip", "client_ip"},
+	})
+	if err == nil {
+		//Fall back to the pod IP of the server or the client
+		kubernetes.Indexing.AddDefaultMatcherConfig(kubernetes.FieldMatcherName, *fieldsCfg)
+	}
 }
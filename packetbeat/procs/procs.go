@@ -20,6 +20,7 @@ package procs
 import (
 	"net"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/elastic/beats/libbeat/common"
@@ -40,6 +41,7 @@ type portProcMapping struct {
 }
 
 type process struct {
+	pid         int
 	name        string
 	commandLine string
 	// To control cache expiration
@@ -58,6 +60,9 @@ type processWatcherImpl interface {
 }
 
 type ProcessesWatcher struct {
+	// mutex protects the maps, as the processes are looked up by several
+	// packet workers
+	mutex        sync.Mutex
 	portProcMap  map[applayer.Transport]map[uint16]portProcMapping
 	localAddrs   []net.IP
 	processCache map[int]*process
@@ -125,10 +130,14 @@ func (proc *ProcessesWatcher) FindProcessesTuple(tuple *common.IPPortTuple, tran
 		return
 	}
 
+	proc.mutex.Lock()
+	defer proc.mutex.Unlock()
+
 	if proc.isLocalIP(tuple.SrcIP) {
 		if p := proc.findProc(tuple.SrcPort, transport); p != nil {
 			procTuple.Src = []byte(p.name)
 			procTuple.SrcCommand = []byte(p.commandLine)
+			procTuple.SrcPID = p.pid
 			logp.Debug("procs", "Found process '%s' (%s) for port %d/%s", p.commandLine, p.name, tuple.SrcPort, transport)
 		}
 	}
@@ -137,6 +146,7 @@ func (proc *ProcessesWatcher) FindProcessesTuple(tuple *common.IPPortTuple, tran
 		if p := proc.findProc(tuple.DstPort, transport); p != nil {
 			procTuple.Dst = []byte(p.name)
 			procTuple.DstCommand = []byte(p.commandLine)
+			procTuple.DstPID = p.pid
 			logp.Debug("procs", "Found process '%s' (%s) for port %d/%s", p.commandLine, p.name, tuple.DstPort, transport)
 		}
 	}
@@ -229,6 +239,7 @@ func (proc *ProcessesWatcher) getProcessInfo(pid int) *process {
 	}
 	// Not in cache, resolve process info
 	p := &process{
+		pid:         pid,
 		commandLine: proc.impl.GetProcessCommandLine(pid),
 		expiration:  time.Now().Add(processCacheExpiration),
 	}
@@ -126,7 +126,7 @@ func TestFindProcessTuple(t *testing.T) {
 	for idx, testCase := range []struct {
 		name                                   string
 		srcIP, dstIP, src, dst, srcCmd, dstCmd string
-		srcPort, dstPort                       int
+		srcPort, dstPort, srcPID, dstPID       int
 		proto                                  applayer.Transport
 		preAction                              func()
 	}{
@@ -145,6 +145,7 @@ func TestFindProcessTuple(t *testing.T) {
 			dstIP: "1234:1234::AAAA", dstPort: 443,
 			src: "", srcCmd: "/usr/X11/bin/webbrowser",
 			dst: "", dstCmd: "",
+			srcPID: 102, dstPID: 0,
 		},
 		{
 			name:  "Curl request",
@@ -153,6 +154,7 @@ func TestFindProcessTuple(t *testing.T) {
 			dstIP: "1.1.1.1", dstPort: 80,
 			src: "Curl", srcCmd: "curl -o /dev/null http://example.net/",
 			dst: "", dstCmd: "",
+			srcPID: 101, dstPID: 0,
 		},
 		{
 			name:  "Unrelated UDP using same port as TCP",
@@ -169,6 +171,7 @@ func TestFindProcessTuple(t *testing.T) {
 			dstIP: "127.0.0.1", dstPort: 80,
 			src: "", srcCmd: "/usr/X11/bin/webbrowser",
 			dst: "NetCat", dstCmd: "nc -v -l -p 80",
+			srcPID: 102, dstPID: 105,
 		},
 		{
 			name:  "External to netcat server",
@@ -177,6 +180,7 @@ func TestFindProcessTuple(t *testing.T) {
 			dstIP: "192.168.1.1", dstPort: 80,
 			src: "", srcCmd: "",
 			dst: "NetCat", dstCmd: "nc -v -l -p 80",
+			srcPID: 0, dstPID: 105,
 		},
 		{
 			name: "New client",
@@ -190,6 +194,7 @@ func TestFindProcessTuple(t *testing.T) {
 			dstIP: "10.1.2.3", dstPort: 443,
 			src: "NMap", srcCmd: "/usr/bin/nmap -sT -P443 10.0.0.0/8",
 			dst: "", dstCmd: "",
+			srcPID: 555, dstPID: 0,
 		},
 		{
 			name:  "DNS request (UDP)",
@@ -198,6 +203,7 @@ func TestFindProcessTuple(t *testing.T) {
 			dstIP: "7777::33", dstPort: 53,
 			src: "", srcCmd: "",
 			dst: "", dstCmd: "bind",
+			srcPID: 0, dstPID: 333,
 		},
 	} {
 		msg := fmt.Sprintf("test case #%d: %s", idx+1, testCase.name)
@@ -221,6 +227,8 @@ func TestFindProcessTuple(t *testing.T) {
 		assert.Equal(t, testCase.dst, string(result.Dst), msg)
 		assert.Equal(t, testCase.srcCmd, string(result.SrcCommand), msg)
 		assert.Equal(t, testCase.dstCmd, string(result.DstCommand), msg)
+		assert.Equal(t, testCase.srcPID, result.SrcPID, msg)
+		assert.Equal(t, testCase.dstPID, result.DstPID, msg)
 	}
 
 }
@@ -94,10 +94,13 @@ func (cassandra *cassandra) setFromConfig(config *cassandraConfig) error {
 	parser := &cassandra.parserConfig
 	parser.maxBytes = tcp.TCPMaxDataInStream
 
-	// set parser's compressor, only `snappy` supported right now
-	if config.Compressor == gocql.Snappy {
+	// set parser's compressor
+	switch config.Compressor {
+	case gocql.Snappy:
 		parser.compressor = gocql.SnappyCompressor{}
-	} else {
+	case gocql.LZ4:
+		parser.compressor = gocql.LZ4Compressor{MaxSize: parser.maxBytes}
+	default:
 		parser.compressor = nil
 	}
 
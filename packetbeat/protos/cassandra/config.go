@@ -47,8 +47,10 @@ var (
 )
 
 func (c *cassandraConfig) Validate() error {
-	if !(c.Compressor == "" || c.Compressor == "snappy") {
-		return fmt.Errorf("invalid compressor config: %s, only snappy supported", c.Compressor)
+	switch c.Compressor {
+	case "", gocql.Snappy, gocql.LZ4:
+	default:
+		return fmt.Errorf("invalid compressor config: %s, only snappy and lz4 supported", c.Compressor)
 	}
 	return nil
 }
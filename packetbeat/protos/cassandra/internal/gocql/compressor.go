@@ -18,7 +18,12 @@
 package cassandra
 
 import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
 	"github.com/golang/snappy"
+	"github.com/pierrec/lz4"
 )
 
 type Compressor interface {
@@ -48,8 +53,71 @@ func (s SnappyCompressor) Decode(data []byte) ([]byte, error) {
 
 const LZ4 string = "lz4"
 
+// LZ4Compressor implements the Compressor interface for the lz4 compression
+// of the native protocol. Frame bodies are prefixed by the big endian length
+// of the uncompressed data, followed by a raw lz4 block.
 type LZ4Compressor struct {
-	//TODO
+	// MaxSize limits the uncompressed length accepted by Decode, if set.
+	MaxSize int
+}
+
+func (s LZ4Compressor) Name() string {
+	return LZ4
+}
+
+func (s LZ4Compressor) Encode(data []byte) ([]byte, error) {
+	buf := make([]byte, 4+lz4.CompressBlockBound(len(data)))
+	binary.BigEndian.PutUint32(buf, uint32(len(data)))
+	n, err := lz4.CompressBlock(data, buf[4:], 0)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		// incompressible data, store it as literals
+		n = putLZ4Literals(buf[4:], data)
+	}
+	return buf[:4+n], nil
+}
+
+func (s LZ4Compressor) Decode(data []byte) ([]byte, error) {
+	if len(data) < 4 {
+		return nil, errors.New("lz4: missing uncompressed length")
+	}
+	size := binary.BigEndian.Uint32(data)
+	if size == 0 {
+		return []byte{}, nil
+	}
+	if size > maxFrameSize || (s.MaxSize > 0 && int64(size) > int64(s.MaxSize)) {
+		return nil, fmt.Errorf("lz4: uncompressed length %d exceeds the limit", size)
+	}
+	buf := make([]byte, size)
+	n, err := lz4.UncompressBlock(data[4:], buf, 0)
+	if err != nil {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+// putLZ4Literals encodes data as a single lz4 sequence made of literals only.
+func putLZ4Literals(dst, data []byte) int {
+	n := len(data)
+	if n < 0xF {
+		dst[0] = byte(n << 4)
+		return 1 + copy(dst[1:], data)
+	}
+
+	dst[0] = 0xF0
+	i := 1
+	for l := n - 0xF; ; l -= 0xFF {
+		if l < 0xFF {
+			dst[i] = byte(l)
+			i++
+			break
+		}
+		dst[i] = 0xFF
+		i++
+	}
+	return i + copy(dst[i:], data)
 }
 
 const Deflate string = "deflate"
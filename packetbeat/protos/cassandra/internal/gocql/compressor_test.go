@@ -0,0 +1,69 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package cassandra
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLZ4RoundTrip(t *testing.T) {
+	compressor := LZ4Compressor{MaxSize: 1024}
+	for _, data := range [][]byte{
+		{},
+		[]byte("short"),
+		bytes.Repeat([]byte("cassandra"), 100),
+	} {
+		encoded, err := compressor.Encode(data)
+		if !assert.NoError(t, err) {
+			continue
+		}
+		decoded, err := compressor.Decode(encoded)
+		if assert.NoError(t, err) {
+			assert.Equal(t, data, decoded)
+		}
+	}
+}
+
+func TestLZ4DecodeOversizedLength(t *testing.T) {
+	encoded, err := LZ4Compressor{}.Encode([]byte("cassandra"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		compressor LZ4Compressor
+		size       uint32
+	}{
+		"above max size":   {LZ4Compressor{MaxSize: 1024}, 1025},
+		"above frame size": {LZ4Compressor{}, 0xFFFFFFFF},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			data := append([]byte{}, encoded...)
+			binary.BigEndian.PutUint32(data, test.size)
+			_, err := test.compressor.Decode(data)
+			assert.Error(t, err)
+		})
+	}
+}
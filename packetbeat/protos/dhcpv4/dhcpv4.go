@@ -20,6 +20,7 @@ package dhcpv4
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/insomniacslk/dhcp/dhcpv4"
 
@@ -74,10 +75,20 @@ func (p *dhcpv4Plugin) GetPorts() []int {
 	return p.dhcpv4Config.Ports
 }
 
-func (p *dhcpv4Plugin) ParseUDP(pkt *protos.Packet) {
+func (p *dhcpv4Plugin) FlowTimeout() time.Duration {
+	return 0
+}
+
+func (p *dhcpv4Plugin) ParseUDP(
+	pkt *protos.Packet,
+	flow *common.IPPortTuple,
+	dir uint8,
+	private protos.ProtocolData,
+) protos.ProtocolData {
 	if event := p.parseDHCPv4(pkt); event != nil {
 		p.report(*event)
 	}
+	return nil
 }
 
 func (p *dhcpv4Plugin) parseDHCPv4(pkt *protos.Packet) *beat.Event {
@@ -24,7 +24,6 @@
 package dns
 
 import (
-	"bytes"
 	"fmt"
 	"sort"
 	"strconv"
@@ -36,6 +35,7 @@ import (
 	"github.com/elastic/beats/libbeat/logp"
 	"github.com/elastic/beats/libbeat/monitoring"
 
+	"github.com/elastic/beats/packetbeat/pool"
 	"github.com/elastic/beats/packetbeat/protos"
 
 	mkdns "github.com/miekg/dns"
@@ -78,6 +78,12 @@ var (
 	unmatchedResponses = monitoring.NewInt(nil, "dns.unmatched_responses")
 )
 
+// scratch space of rrToString, called for each resource record
+var (
+	rrFieldsPool  = pool.NewMapStrs("dns.rr_fields")
+	rrStringsPool = pool.NewBuffers("dns.rr_strings", 4096)
+)
+
 const (
 	transportTCP = iota
 	transportUDP
@@ -295,6 +301,15 @@ func (dns *dnsPlugin) ConnectionTimeout() time.Duration {
 	return dns.transactionTimeout
 }
 
+func (dns *dnsPlugin) FlowTimeout() time.Duration {
+	return dns.transactionTimeout
+}
+
+// Flush publishes the transactions in progress as expired.
+func (dns *dnsPlugin) Flush() {
+	dns.transactions.RemoveAll()
+}
+
 func (dns *dnsPlugin) receivedDNSRequest(tuple *dnsTuple, msg *dnsMessage) {
 	debugf("Processing query. %s", tuple.String())
 
@@ -565,7 +580,9 @@ func rrToString(rr mkdns.RR) string {
 	var st string
 	var keys []string
 
-	mapStr := rrToMapStr(rr)
+	mapStr := rrFieldsPool.Get()
+	defer rrFieldsPool.Put(mapStr)
+	addRRToMapStr(mapStr, rr)
 	data, ok := mapStr["data"]
 	delete(mapStr, "data")
 
@@ -574,14 +591,15 @@ func rrToString(rr mkdns.RR) string {
 	}
 	sort.Strings(keys)
 
-	var b bytes.Buffer
+	b := rrStringsPool.Get()
+	defer rrStringsPool.Put(b)
 	for _, k := range keys {
 		v := mapStr[k]
 		switch x := v.(type) {
 		case int:
-			fmt.Fprintf(&b, "%s %d, ", k, x)
+			fmt.Fprintf(b, "%s %d, ", k, x)
 		case string:
-			fmt.Fprintf(&b, "%s %s, ", k, x)
+			fmt.Fprintf(b, "%s %s, ", k, x)
 		}
 	}
 	if !ok {
@@ -591,15 +609,24 @@ func rrToString(rr mkdns.RR) string {
 
 	switch x := data.(type) {
 	case int:
-		fmt.Fprintf(&b, "%d", x)
+		fmt.Fprintf(b, "%d", x)
 	case string:
-		fmt.Fprintf(&b, "%s", x)
+		fmt.Fprintf(b, "%s", x)
 	}
 	return b.String()
 }
 
 func rrToMapStr(rr mkdns.RR) common.MapStr {
 	mapStr := common.MapStr{}
+	if !addRRToMapStr(mapStr, rr) {
+		return nil
+	}
+	return mapStr
+}
+
+// addRRToMapStr adds the RDATA fields of a RR to mapStr. It returns false if
+// the RR is not reported.
+func addRRToMapStr(mapStr common.MapStr, rr mkdns.RR) bool {
 	rrType := rr.Header().Rrtype
 
 	switch x := rr.(type) {
@@ -655,7 +682,7 @@ func rrToMapStr(rr mkdns.RR) common.MapStr {
 		mapStr["data"] = dnsSaltToString(x.Salt)
 	case *mkdns.OPT: // EDNS [RFC6891]
 		// OPT pseudo-RR is managed in addDnsToMapStr function
-		return nil
+		return false
 	case *mkdns.PTR:
 		mapStr["data"] = x.Ptr
 	case *mkdns.RFC3597:
@@ -693,7 +720,7 @@ func rrToMapStr(rr mkdns.RR) common.MapStr {
 		mapStr["data"] = strings.Join(x.Txt, " ")
 	}
 
-	return mapStr
+	return true
 }
 
 // dnsQuestionToString converts a Question to a string.
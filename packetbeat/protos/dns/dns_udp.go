@@ -18,6 +18,7 @@
 package dns
 
 import (
+	"github.com/elastic/beats/libbeat/common"
 	"github.com/elastic/beats/libbeat/logp"
 
 	"github.com/elastic/beats/packetbeat/procs"
@@ -27,7 +28,7 @@ import (
 // Only EDNS packets should have their size beyond this value
 const maxDNSPacketSize = (1 << 9) // 512 (bytes)
 
-func (dns *dnsPlugin) ParseUDP(pkt *protos.Packet) {
+func (dns *dnsPlugin) ParseUDP(pkt *protos.Packet, flow *common.IPPortTuple, dir uint8, private protos.ProtocolData) protos.ProtocolData {
 	defer logp.Recover("Dns ParseUdp")
 	packetSize := len(pkt.Payload)
 
@@ -40,7 +41,7 @@ func (dns *dnsPlugin) ParseUDP(pkt *protos.Packet) {
 		// that someone is attempting to the DNS port for non-DNS traffic. Both
 		// are issues that a monitoring system should report.
 		debugf("%s", err.Error())
-		return
+		return nil
 	}
 
 	dnsTuple := dnsTupleFromIPPort(&pkt.Tuple, transportUDP, dnsPkt.Id)
@@ -57,4 +58,5 @@ func (dns *dnsPlugin) ParseUDP(pkt *protos.Packet) {
 	} else /* Query */ {
 		dns.receivedDNSRequest(&dnsTuple, dnsMsg)
 	}
+	return nil
 }
@@ -257,7 +257,7 @@ func TestParseUdp_emptyPacket(t *testing.T) {
 	store := &eventStore{}
 	dns := newDNS(store, testing.Verbose())
 	packet := newPacket(forward, []byte{})
-	dns.ParseUDP(packet)
+	dns.ParseUDP(packet, &packet.Tuple, 1, nil)
 	assert.Empty(t, dns.transactions.Size(), "There should be no transactions.")
 	assert.True(t, store.empty(), "No result should have been published.")
 }
@@ -267,7 +267,7 @@ func TestParseUdp_malformedPacket(t *testing.T) {
 	dns := newDNS(nil, testing.Verbose())
 	garbage := []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13}
 	packet := newPacket(forward, garbage)
-	dns.ParseUDP(packet)
+	dns.ParseUDP(packet, &packet.Tuple, 1, nil)
 	assert.Empty(t, dns.transactions.Size(), "There should be no transactions.")
 
 	// As a future addition, a malformed message should publish a result.
@@ -278,11 +278,25 @@ func TestParseUdp_requestPacket(t *testing.T) {
 	store := &eventStore{}
 	dns := newDNS(store, testing.Verbose())
 	packet := newPacket(forward, elasticA.request)
-	dns.ParseUDP(packet)
+	dns.ParseUDP(packet, &packet.Tuple, 1, nil)
 	assert.Equal(t, 1, dns.transactions.Size(), "There should be one transaction.")
 	assert.True(t, store.empty(), "No result should have been published.")
 }
 
+// Verify that Flush publishes the request without a response as expired.
+func TestParseUdp_requestPacketFlush(t *testing.T) {
+	store := &eventStore{}
+	dns := newDNS(store, testing.Verbose())
+	packet := newPacket(forward, elasticA.request)
+	dns.ParseUDP(packet, &packet.Tuple, 1, nil)
+	dns.Flush()
+	assert.Equal(t, 0, dns.transactions.Size(), "There should be no transaction.")
+
+	m := expectResult(t, store)
+	assert.Equal(t, common.ERROR_STATUS, mapValue(t, m, "status"))
+	assert.Equal(t, noResponse.Error(), mapValue(t, m, "notes"))
+}
+
 // Verify that the lone response packet is parsed and that an error
 // result is published.
 func TestParseUdp_responseOnly(t *testing.T) {
@@ -290,7 +304,7 @@ func TestParseUdp_responseOnly(t *testing.T) {
 	dns := newDNS(results, testing.Verbose())
 	q := elasticA
 	packet := newPacket(reverse, q.response)
-	dns.ParseUDP(packet)
+	dns.ParseUDP(packet, &packet.Tuple, 1, nil)
 
 	m := expectResult(t, results)
 	assert.Equal(t, "udp", mapValue(t, m, "transport"))
@@ -310,10 +324,10 @@ func TestParseUdp_duplicateRequests(t *testing.T) {
 	dns := newDNS(results, testing.Verbose())
 	q := elasticA
 	packet := newPacket(forward, q.request)
-	dns.ParseUDP(packet)
+	dns.ParseUDP(packet, &packet.Tuple, 1, nil)
 	assert.Equal(t, 1, dns.transactions.Size(), "There should be one transaction.")
 	packet = newPacket(forward, q.request)
-	dns.ParseUDP(packet)
+	dns.ParseUDP(packet, &packet.Tuple, 1, nil)
 	assert.Equal(t, 1, dns.transactions.Size(), "There should be one transaction.")
 
 	m := expectResult(t, results)
@@ -400,10 +414,10 @@ func TestPublishTransaction_edns(t *testing.T) {
 	dns := newDNS(results, testing.Verbose())
 	q := ednsSecA
 	packet := newPacket(forward, q.request)
-	dns.ParseUDP(packet)
+	dns.ParseUDP(packet, &packet.Tuple, 1, nil)
 	assert.Equal(t, 1, dns.transactions.Size(), "There should be one transaction.")
 	packet = newPacket(reverse, q.response)
-	dns.ParseUDP(packet)
+	dns.ParseUDP(packet, &packet.Tuple, 1, nil)
 	assert.Empty(t, dns.transactions.Size(), "There should be no transactions.")
 
 	m := expectResult(t, results)
@@ -424,10 +438,10 @@ func TestPublishTransaction_respEdnsNoSupport(t *testing.T) {
 	q.response = q.response[:len(q.response)-11] // Remove OPT RR
 
 	packet := newPacket(forward, q.request)
-	dns.ParseUDP(packet)
+	dns.ParseUDP(packet, &packet.Tuple, 1, nil)
 	assert.Equal(t, 1, dns.transactions.Size(), "There should be one transaction.")
 	packet = newPacket(reverse, q.response)
-	dns.ParseUDP(packet)
+	dns.ParseUDP(packet, &packet.Tuple, 1, nil)
 	assert.Empty(t, dns.transactions.Size(), "There should be no transactions.")
 
 	m := expectResult(t, results)
@@ -448,10 +462,10 @@ func TestPublishTransaction_respEdnsUnexpected(t *testing.T) {
 	q.request = q.request[:len(q.request)-11] // Remove OPT RR
 
 	packet := newPacket(forward, q.request)
-	dns.ParseUDP(packet)
+	dns.ParseUDP(packet, &packet.Tuple, 1, nil)
 	assert.Equal(t, 1, dns.transactions.Size(), "There should be one transaction.")
 	packet = newPacket(reverse, q.response)
-	dns.ParseUDP(packet)
+	dns.ParseUDP(packet, &packet.Tuple, 1, nil)
 	assert.Empty(t, dns.transactions.Size(), "There should be no transactions.")
 
 	m := expectResult(t, results)
@@ -469,9 +483,9 @@ func benchmarkUDP(b *testing.B, q dnsTestMessage) {
 	dns := newDNS(nil, false)
 	for i := 0; i < b.N; i++ {
 		packet := newPacket(forward, q.request)
-		dns.ParseUDP(packet)
+		dns.ParseUDP(packet, &packet.Tuple, 1, nil)
 		packet = newPacket(reverse, q.response)
-		dns.ParseUDP(packet)
+		dns.ParseUDP(packet, &packet.Tuple, 1, nil)
 	}
 }
 
@@ -501,7 +515,7 @@ func BenchmarkParallelUdpParse(b *testing.B) {
 			} else {
 				packet = newPacket(reverse, q.response)
 			}
-			dns.ParseUDP(packet)
+			dns.ParseUDP(packet, &packet.Tuple, 1, nil)
 		}
 	})
 }
@@ -510,9 +524,9 @@ func BenchmarkParallelUdpParse(b *testing.B) {
 // the published result.
 func parseUDPRequestResponse(t testing.TB, dns *dnsPlugin, results *eventStore, q dnsTestMessage) {
 	packet := newPacket(forward, q.request)
-	dns.ParseUDP(packet)
+	dns.ParseUDP(packet, &packet.Tuple, 1, nil)
 	packet = newPacket(reverse, q.response)
-	dns.ParseUDP(packet)
+	dns.ParseUDP(packet, &packet.Tuple, 1, nil)
 	assert.Empty(t, dns.transactions.Size(), "There should be no transactions.")
 
 	m := expectResult(t, results)
@@ -18,6 +18,12 @@
 package http
 
 import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+
 	"github.com/elastic/beats/packetbeat/config"
 	"github.com/elastic/beats/packetbeat/protos"
 	"github.com/elastic/beats/packetbeat/protos/tcp"
@@ -29,6 +35,7 @@ type httpConfig struct {
 	SendHeaders            []string `config:"send_headers"`
 	SplitCookie            bool     `config:"split_cookie"`
 	RealIPHeader           string   `config:"real_ip_header"`
+	RealIPHop              string   `config:"real_ip_hop"`
 	IncludeBodyFor         []string `config:"include_body_for"`
 	IncludeRequestBodyFor  []string `config:"include_request_body_for"`
 	IncludeResponseBodyFor []string `config:"include_response_body_for"`
@@ -36,6 +43,9 @@ type httpConfig struct {
 	RedactAuthorization    bool     `config:"redact_authorization"`
 	MaxMessageSize         int      `config:"max_message_size"`
 	DecodeBody             bool     `config:"decode_body"`
+	MaxBodySize            int      `config:"max_body_size" validate:"min=0"`
+	BodyHash               string   `config:"body_hash"`
+	MaxHTTP2Streams        int      `config:"max_http2_streams" validate:"min=1"`
 }
 
 var (
@@ -43,7 +53,28 @@ var (
 		ProtocolCommon: config.ProtocolCommon{
 			TransactionTimeout: protos.DefaultTransactionExpiration,
 		},
-		MaxMessageSize: tcp.TCPMaxDataInStream,
-		DecodeBody:     true,
+		RealIPHop:       "first",
+		MaxMessageSize:  tcp.TCPMaxDataInStream,
+		DecodeBody:      true,
+		MaxHTTP2Streams: 1000,
 	}
 )
+
+// bodyHashes are the hash functions supported by the body_hash option.
+var bodyHashes = map[string]func() hash.Hash{
+	"md5":    md5.New,
+	"sha1":   sha1.New,
+	"sha256": sha256.New,
+}
+
+func (c *httpConfig) Validate() error {
+	switch c.RealIPHop {
+	case "first", "last":
+	default:
+		return fmt.Errorf("invalid real_ip_hop config: %s, only first and last supported", c.RealIPHop)
+	}
+	if _, ok := bodyHashes[c.BodyHash]; !ok && c.BodyHash != "" {
+		return fmt.Errorf("invalid body_hash config: %s, only md5, sha1 and sha256 supported", c.BodyHash)
+	}
+	return nil
+}
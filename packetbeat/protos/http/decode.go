@@ -24,6 +24,8 @@ import (
 	"io"
 
 	"github.com/pkg/errors"
+
+	"github.com/elastic/beats/packetbeat/pool"
 )
 
 var (
@@ -47,6 +49,15 @@ var (
 
 	// ErrSizeLimited is returned when
 	ErrSizeLimited = errors.New("body truncated due to size limitation")
+
+	// the decompressors allocate their window on creation, they are reused
+	// between bodies
+	gzipReaders = pool.New("http.gzip_readers", func() interface{} {
+		return new(gzip.Reader)
+	})
+	flateReaders = pool.New("http.flate_readers", func() interface{} {
+		return flate.NewReader(nil)
+	})
 )
 
 func decodeHTTPBody(data []byte, format string, maxSize int) ([]byte, error) {
@@ -63,11 +74,33 @@ func decodeHTTPBody(data []byte, format string, maxSize int) ([]byte, error) {
 }
 
 func decodeGZIP(reader io.Reader) (io.ReadCloser, error) {
-	return gzip.NewReader(reader)
+	decoder := gzipReaders.Get().(*gzip.Reader)
+	if err := decoder.Reset(reader); err != nil {
+		gzipReaders.Put(decoder)
+		return nil, err
+	}
+	return &pooledReader{decoder, gzipReaders}, nil
 }
 
 func decodeDeflate(reader io.Reader) (io.ReadCloser, error) {
-	return flate.NewReader(reader), nil
+	decoder := flateReaders.Get().(io.ReadCloser)
+	if err := decoder.(flate.Resetter).Reset(reader, nil); err != nil {
+		flateReaders.Put(decoder)
+		return nil, err
+	}
+	return &pooledReader{decoder, flateReaders}, nil
+}
+
+// pooledReader returns the decoder to its pool when closed.
+type pooledReader struct {
+	io.ReadCloser
+	pool *pool.Pool
+}
+
+func (r *pooledReader) Close() error {
+	err := r.ReadCloser.Close()
+	r.pool.Put(r.ReadCloser)
+	return err
 }
 
 type closeDecorator struct {
@@ -0,0 +1,156 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+const grpcPrefixLen = 5
+
+var (
+	constGRPCContentType = []byte("application/grpc")
+
+	nameGRPCStatus  = []byte("grpc-status")
+	nameGRPCMessage = []byte("grpc-message")
+
+	grpcStatusNames = []string{
+		"OK",
+		"CANCELLED",
+		"UNKNOWN",
+		"INVALID_ARGUMENT",
+		"DEADLINE_EXCEEDED",
+		"NOT_FOUND",
+		"ALREADY_EXISTS",
+		"PERMISSION_DENIED",
+		"RESOURCE_EXHAUSTED",
+		"FAILED_PRECONDITION",
+		"ABORTED",
+		"OUT_OF_RANGE",
+		"UNIMPLEMENTED",
+		"INTERNAL",
+		"UNAVAILABLE",
+		"DATA_LOSS",
+		"UNAUTHENTICATED",
+	}
+)
+
+// grpcMessages counts the length-prefixed messages carried in the DATA frames
+// of a gRPC request or response.
+type grpcMessages struct {
+	prefix    [grpcPrefixLen]byte
+	prefixLen int
+	remaining int
+
+	count int
+	bytes int
+}
+
+func isGRPC(contentType []byte) bool {
+	return bytes.HasPrefix(contentType, constGRPCContentType)
+}
+
+// feed processes the content of a DATA frame. Messages can span several
+// frames.
+func (g *grpcMessages) feed(data []byte) {
+	for len(data) > 0 {
+		if g.remaining > 0 {
+			n := g.remaining
+			if n > len(data) {
+				n = len(data)
+			}
+			g.remaining -= n
+			data = data[n:]
+			continue
+		}
+
+		n := copy(g.prefix[g.prefixLen:], data)
+		g.prefixLen += n
+		data = data[n:]
+		if g.prefixLen < grpcPrefixLen {
+			return
+		}
+
+		size := int(binary.BigEndian.Uint32(g.prefix[1:]))
+		g.prefixLen = 0
+		g.remaining = size
+		g.count++
+		g.bytes += size
+	}
+}
+
+// splitGRPCPath returns the service and the method of a gRPC request path,
+// in the form `/<service>/<method>`.
+func splitGRPCPath(path string) (service, method string) {
+	path = strings.TrimPrefix(path, "/")
+	if idx := strings.LastIndexByte(path, '/'); idx >= 0 {
+		return path[:idx], path[idx+1:]
+	}
+	return path, ""
+}
+
+// grpcFields returns the gRPC details of a transaction and whether the
+// call failed.
+func grpcFields(requ, resp *message) (common.MapStr, bool) {
+	fields := common.MapStr{}
+	failed := false
+
+	if requ != nil {
+		fields["service"], fields["method"] = splitGRPCPath(string(requ.requestURI))
+		if requ.grpc != nil {
+			fields["request"] = common.MapStr{
+				"messages": requ.grpc.count,
+				"bytes":    requ.grpc.bytes,
+			}
+		}
+	}
+
+	if resp != nil {
+		if resp.grpc != nil {
+			fields["response"] = common.MapStr{
+				"messages": resp.grpc.count,
+				"bytes":    resp.grpc.bytes,
+			}
+		}
+		if len(resp.grpcStatus) > 0 {
+			code, err := strconv.Atoi(string(resp.grpcStatus))
+			if err == nil {
+				fields["status_code"] = code
+				if code >= 0 && code < len(grpcStatusNames) {
+					fields["status"] = grpcStatusNames[code]
+				}
+			}
+			failed = err != nil || code != 0
+		}
+		if len(resp.grpcMessage) > 0 {
+			msg, err := url.PathUnescape(string(resp.grpcMessage))
+			if err != nil {
+				msg = string(resp.grpcMessage)
+			}
+			fields["message"] = msg
+		}
+	}
+
+	return fields, failed
+}
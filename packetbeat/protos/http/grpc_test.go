@@ -0,0 +1,94 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package http
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/http2"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/packetbeat/protos"
+)
+
+func grpcMessage(payload string) []byte {
+	size := len(payload)
+	prefix := []byte{0, byte(size >> 24), byte(size >> 16), byte(size >> 8), byte(size)}
+	return append(prefix, payload...)
+}
+
+func TestGRPCMessages_feed(t *testing.T) {
+	data := append(grpcMessage("hello"), grpcMessage("grpc world")...)
+
+	for _, split := range []int{1, 3, 5, 7, len(data)} {
+		var g grpcMessages
+		for p := data; len(p) > 0; {
+			n := split
+			if n > len(p) {
+				n = len(p)
+			}
+			g.feed(p[:n])
+			p = p[n:]
+		}
+		assert.Equal(t, 2, g.count, "split=%d", split)
+		assert.Equal(t, 15, g.bytes, "split=%d", split)
+	}
+}
+
+func TestSplitGRPCPath(t *testing.T) {
+	service, method := splitGRPCPath("/helloworld.Greeter/SayHello")
+	assert.Equal(t, "helloworld.Greeter", service)
+	assert.Equal(t, "SayHello", method)
+}
+
+func TestGRPC_call(t *testing.T) {
+	var store eventStore
+	http := httpModForTests(&store)
+
+	client, server := newHTTP2TestPeer(), newHTTP2TestPeer()
+	client.buf.WriteString(http2.ClientPreface)
+	client.headers(1, false,
+		":method", "POST", ":scheme", "http", ":path", "/helloworld.Greeter/SayHello",
+		"content-type", "application/grpc", "te", "trailers")
+	client.framer.WriteData(1, true, grpcMessage("world"))
+
+	server.headers(1, false, ":status", "200", "content-type", "application/grpc+proto")
+	server.framer.WriteData(1, false, grpcMessage("hello"))
+	server.framer.WriteData(1, false, grpcMessage("world"))
+	server.headers(1, true, "grpc-status", "5", "grpc-message", "greeter%20not%20found")
+
+	private := protos.ProtocolData(&httpConnectionData{})
+	private = parseHTTP2Test(http, private, 0, client.payload())
+	private = parseHTTP2Test(http, private, 1, server.payload())
+	assert.NotNil(t, private)
+
+	trans := expectTransaction(t, &store)
+	assert.Equal(t, common.ERROR_STATUS, trans["status"])
+	assert.Equal(t, common.MapStr{
+		"service":     "helloworld.Greeter",
+		"method":      "SayHello",
+		"status_code": 5,
+		"status":      "NOT_FOUND",
+		"message":     "greeter not found",
+		"request":     common.MapStr{"messages": 1, "bytes": 5},
+		"response":    common.MapStr{"messages": 2, "bytes": 10},
+	}, fieldValue(t, trans, "http.grpc"))
+}
@@ -19,7 +19,9 @@ package http
 
 import (
 	"bytes"
+	"encoding/hex"
 	"fmt"
+	"hash"
 	"net/url"
 	"strings"
 	"time"
@@ -52,6 +54,7 @@ const (
 var (
 	unmatchedResponses = monitoring.NewInt(nil, "http.unmatched_responses")
 	unmatchedRequests  = monitoring.NewInt(nil, "http.unmatched_requests")
+	evictedStreams     = monitoring.NewInt(nil, "http.evicted_http2_streams")
 )
 
 type stream struct {
@@ -70,6 +73,9 @@ type httpConnectionData struct {
 	streams   [2]*stream
 	requests  messageList
 	responses messageList
+
+	// set once the connection speaks HTTP/2
+	http2 *http2Connection
 }
 
 type messageList struct {
@@ -87,6 +93,9 @@ type httpPlugin struct {
 	redactAuthorization bool
 	maxMessageSize      int
 	mustDecodeBody      bool
+	maxBodySize         int
+	bodyHash            func() hash.Hash
+	maxHTTP2Streams     int
 
 	parserConfig parserConfig
 
@@ -141,6 +150,7 @@ func (http *httpPlugin) setFromConfig(config *httpConfig) {
 	http.redactAuthorization = config.RedactAuthorization
 	http.splitCookie = config.SplitCookie
 	http.parserConfig.realIPHeader = strings.ToLower(config.RealIPHeader)
+	http.parserConfig.realIPLastHop = config.RealIPHop == "last"
 	http.transactionTimeout = config.TransactionTimeout
 	http.mustDecodeBody = config.DecodeBody
 
@@ -151,6 +161,9 @@ func (http *httpPlugin) setFromConfig(config *httpConfig) {
 		http.parserConfig.includeResponseBodyFor = append(http.parserConfig.includeResponseBodyFor, list...)
 	}
 	http.maxMessageSize = config.MaxMessageSize
+	http.maxBodySize = config.MaxBodySize
+	http.bodyHash = bodyHashes[config.BodyHash]
+	http.maxHTTP2Streams = config.MaxHTTP2Streams
 
 	if config.SendAllHeaders {
 		http.parserConfig.sendHeaders = true
@@ -286,10 +299,19 @@ func (http *httpPlugin) doParse(
 		detailedf("Payload received: [%s]", pkt.Payload)
 	}
 
+	if conn.http2 != nil {
+		return http.parseHTTP2(conn, pkt.Payload, pkt.Ts, tcptuple, dir)
+	}
+
 	extraMsgSize := 0 // size of a "seen" packet for which we don't store the actual bytes
 
 	st := conn.streams[dir]
 	if st == nil {
+		if bytes.HasPrefix(pkt.Payload, http2Preface) {
+			// HTTP/2 with prior knowledge
+			conn.http2 = newHTTP2Connection(dir)
+			return http.parseHTTP2(conn, pkt.Payload, pkt.Ts, tcptuple, dir)
+		}
 		st = newStream(pkt, tcptuple)
 		conn.streams[dir] = st
 	} else {
@@ -334,6 +356,12 @@ func (http *httpPlugin) doParse(
 
 		// and reset stream for next message
 		st.PrepareForNewMessage()
+
+		if conn.http2 != nil {
+			// upgraded to HTTP/2, the remaining data are frames
+			conn.streams = [2]*stream{}
+			return http.parseHTTP2(conn, st.data, pkt.Ts, tcptuple, dir)
+		}
 	}
 
 	return conn
@@ -386,6 +414,12 @@ func (http *httpPlugin) GapInStream(tcptuple *common.TCPTuple, dir uint8,
 		return private, false
 	}
 
+	if conn.http2 != nil {
+		// HPACK state is lost, drop the connection
+		http.flushHTTP2(conn, tcptuple, "Packet loss while capturing the HTTP/2 connection")
+		return conn, true
+	}
+
 	stream := conn.streams[dir]
 	if stream == nil || stream.message == nil {
 		// nothing to do
@@ -418,10 +452,7 @@ func (http *httpPlugin) handleHTTP(
 	dir uint8,
 ) {
 
-	m.tcpTuple = *tcptuple
-	m.direction = dir
-	m.cmdlineTuple = procs.ProcWatcher.FindProcessesTupleTCP(tcptuple.IPPort())
-	http.hideHeaders(m)
+	http.setMessageTuple(m, tcptuple, dir)
 
 	if m.isRequest {
 		if isDebug {
@@ -432,11 +463,22 @@ func (http *httpPlugin) handleHTTP(
 		if isDebug {
 			debugf("Received response with tuple: %s", m.tcpTuple)
 		}
+		if m.statusCode == 101 && bytes.EqualFold(m.upgrade, h2cUpgrade) && !conn.requests.empty() {
+			http.upgradeHTTP2(conn)
+			return
+		}
 		conn.responses.append(m)
 		http.correlate(conn)
 	}
 }
 
+func (http *httpPlugin) setMessageTuple(m *message, tcptuple *common.TCPTuple, dir uint8) {
+	m.tcpTuple = *tcptuple
+	m.direction = dir
+	m.cmdlineTuple = procs.ProcWatcher.FindProcessesTupleTCP(tcptuple.IPPort())
+	http.hideHeaders(m)
+}
+
 func (http *httpPlugin) flushResponses(conn *httpConnectionData) {
 	for !conn.responses.empty() {
 		unmatchedResponses.Add(1)
@@ -566,6 +608,14 @@ func (http *httpPlugin) newTransaction(requ, resp *message) beat.Event {
 		}
 	}
 
+	if (requ != nil && requ.grpc != nil) || (resp != nil && resp.grpc != nil) {
+		grpc, failed := grpcFields(requ, resp)
+		httpDetails["grpc"] = grpc
+		if failed {
+			fields["status"] = common.ERROR_STATUS
+		}
+	}
+
 	// resp_time in milliseconds
 	if requ != nil && resp != nil {
 		fields["responsetime"] = int32(resp.ts.Sub(requ.ts).Nanoseconds() / 1e6)
@@ -578,11 +628,11 @@ func (http *httpPlugin) newTransaction(requ, resp *message) beat.Event {
 }
 
 func (http *httpPlugin) makeRawMessage(m *message) string {
-	if m.sendBody {
+	if body := http.eventBody(m); len(body) > 0 {
 		var b strings.Builder
-		b.Grow(len(m.rawHeaders) + len(m.body))
+		b.Grow(len(m.rawHeaders) + len(body))
 		b.Write(m.rawHeaders)
-		b.Write(m.body)
+		b.Write(body)
 		return b.String()
 	}
 	return string(m.rawHeaders)
@@ -628,11 +678,37 @@ func (http *httpPlugin) collectHeaders(m *message) interface{} {
 }
 
 func (http *httpPlugin) setBody(result common.MapStr, m *message) {
-	if m.sendBody && len(m.body) > 0 {
-		result["body"] = string(m.body)
+	if !m.sendBody || len(m.body) == 0 {
+		return
+	}
+
+	if http.bodyHash != nil {
+		h := http.bodyHash()
+		h.Write(m.body)
+		result["body_hash"] = hex.EncodeToString(h.Sum(nil))
+		result["body_bytes"] = len(m.body)
+		return
+	}
+
+	body := http.eventBody(m)
+	result["body"] = string(body)
+	if len(body) < len(m.body) {
+		result["body_bytes"] = len(m.body)
 	}
 }
 
+// eventBody returns the part of the message body to be included in the
+// event. No body is included if only its hash is reported.
+func (http *httpPlugin) eventBody(m *message) []byte {
+	if !m.sendBody || http.bodyHash != nil {
+		return nil
+	}
+	if http.maxBodySize > 0 && len(m.body) > http.maxBodySize {
+		return m.body[:http.maxBodySize]
+	}
+	return m.body
+}
+
 func (http *httpPlugin) decodeBody(m *message) {
 	if m.saveBody && len(m.body) > 0 {
 		if http.mustDecodeBody && len(m.encodings) > 0 {
@@ -807,6 +883,8 @@ func (http *httpPlugin) Expired(tuple *common.TCPTuple, private protos.ProtocolD
 	if isDebug {
 		debugf("expired connection %s", tuple)
 	}
+	http.flushHTTP2(conn, tuple, "")
+
 	// terminate streams
 	for dir, s := range conn.streams {
 		// Do not send incomplete or empty messages
@@ -0,0 +1,613 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	nethttp "net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/hpack"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+const (
+	http2FrameHeaderLen = 9
+	http2StreamIDMask   = 1<<31 - 1
+
+	// Initial value of SETTINGS_HEADER_TABLE_SIZE (RFC 7540, section 6.5.2).
+	http2InitialHeaderTableSize = 4096
+)
+
+var (
+	http2Preface = []byte(http2.ClientPreface)
+	h2cUpgrade   = []byte("h2c")
+
+	errHTTP2Preface      = errors.New("missing HTTP/2 connection preface")
+	errHTTP2Continuation = errors.New("expected CONTINUATION frame")
+	errHTTP2Frame        = errors.New("malformed HTTP/2 frame")
+)
+
+// http2Connection holds the state of a cleartext HTTP/2 (h2c) connection.
+// Requests are multiplexed on streams, so they are correlated with their
+// responses by stream ID instead of by their order in the connection.
+type http2Connection struct {
+	// direction of the packets sent by the client
+	clientDir uint8
+
+	// awaitPreface is set until the client connection preface is received.
+	awaitPreface bool
+
+	data [2][]byte
+
+	// HPACK decoding state of each direction
+	decoders [2]*hpack.Decoder
+
+	// header blocks waiting for CONTINUATION frames
+	headerBlocks [2]*http2HeaderBlock
+
+	streams map[uint32]*http2Stream
+
+	// creation order of the streams, to find the oldest one
+	nextSeq uint64
+}
+
+type http2HeaderBlock struct {
+	streamID  uint32
+	frameType http2.FrameType
+	endStream bool
+	fragment  []byte
+}
+
+type http2Stream struct {
+	seq                       uint64
+	request, response         *message
+	requestDone, responseDone bool
+}
+
+type http2Frame struct {
+	frameType http2.FrameType
+	flags     http2.Flags
+	streamID  uint32
+	payload   []byte
+}
+
+func newHTTP2Connection(clientDir uint8) *http2Connection {
+	h2 := &http2Connection{
+		clientDir:    clientDir,
+		awaitPreface: true,
+		streams:      map[uint32]*http2Stream{},
+	}
+	for i := range h2.decoders {
+		h2.decoders[i] = hpack.NewDecoder(http2InitialHeaderTableSize, nil)
+	}
+	return h2
+}
+
+func (h2 *http2Connection) stream(id uint32) *http2Stream {
+	st := h2.streams[id]
+	if st == nil {
+		st = &http2Stream{seq: h2.nextSeq}
+		h2.nextSeq++
+		h2.streams[id] = st
+	}
+	return st
+}
+
+// openHTTP2Stream returns the state of a stream like stream, but evicts the
+// oldest stream of the connection first if a new stream would exceed
+// max_http2_streams. The evicted stream is published as is.
+func (http *httpPlugin) openHTTP2Stream(
+	conn *httpConnectionData,
+	id uint32,
+	tcptuple *common.TCPTuple,
+) *http2Stream {
+	h2 := conn.http2
+	if _, exists := h2.streams[id]; !exists && len(h2.streams) >= http.maxHTTP2Streams {
+		var oldestID uint32
+		var oldest *http2Stream
+		for sid, st := range h2.streams {
+			if oldest == nil || st.seq < oldest.seq {
+				oldestID, oldest = sid, st
+			}
+		}
+		if isDebug {
+			debugf("Too many HTTP/2 streams, evicting stream=%d", oldestID)
+		}
+		delete(h2.streams, oldestID)
+		evictedStreams.Add(1)
+		http.flushHTTP2Stream(conn, oldest, tcptuple, "Stream evicted")
+	}
+	return h2.stream(id)
+}
+
+// direction returns the direction of the packets carrying requests or
+// responses.
+func (h2 *http2Connection) direction(isRequest bool) uint8 {
+	if isRequest {
+		return h2.clientDir
+	}
+	return 1 - h2.clientDir
+}
+
+// upgradeHTTP2 switches the connection to HTTP/2 after the server accepted an
+// `Upgrade: h2c` request. The upgrade request becomes the request of stream 1.
+func (http *httpPlugin) upgradeHTTP2(conn *httpConnectionData) {
+	requ := conn.requests.pop()
+	if isDebug {
+		debugf("Connection upgraded to HTTP/2: %s", requ.tcpTuple)
+	}
+
+	h2 := newHTTP2Connection(requ.direction)
+	st := h2.stream(1)
+	st.request = requ
+	st.requestDone = true
+	conn.http2 = h2
+}
+
+// parseHTTP2 processes the payload of an HTTP/2 connection. It returns nil if
+// the connection can't be parsed anymore.
+func (http *httpPlugin) parseHTTP2(
+	conn *httpConnectionData,
+	data []byte,
+	ts time.Time,
+	tcptuple *common.TCPTuple,
+	dir uint8,
+) *httpConnectionData {
+	h2 := conn.http2
+	buf := append(h2.data[dir], data...)
+
+	if h2.awaitPreface && dir == h2.clientDir {
+		if len(buf) < len(http2Preface) {
+			if !bytes.HasPrefix(http2Preface, buf) {
+				return http.dropHTTP2(conn, tcptuple, errHTTP2Preface)
+			}
+			h2.data[dir] = buf
+			return conn
+		}
+		if !bytes.HasPrefix(buf, http2Preface) {
+			return http.dropHTTP2(conn, tcptuple, errHTTP2Preface)
+		}
+		buf = buf[len(http2Preface):]
+		h2.awaitPreface = false
+	}
+
+	for len(buf) >= http2FrameHeaderLen {
+		length := int(buf[0])<<16 | int(buf[1])<<8 | int(buf[2])
+		if length > http.maxMessageSize {
+			return http.dropHTTP2(conn, tcptuple, fmt.Errorf("HTTP/2 frame too large: %d bytes", length))
+		}
+		if len(buf) < http2FrameHeaderLen+length {
+			break
+		}
+
+		frame := http2Frame{
+			frameType: http2.FrameType(buf[3]),
+			flags:     http2.Flags(buf[4]),
+			streamID:  binary.BigEndian.Uint32(buf[5:]) & http2StreamIDMask,
+			payload:   buf[http2FrameHeaderLen : http2FrameHeaderLen+length],
+		}
+		buf = buf[http2FrameHeaderLen+length:]
+
+		if isDetailed {
+			detailedf("HTTP/2 frame %v, stream=%d, length=%d", frame.frameType, frame.streamID, length)
+		}
+		if err := http.handleHTTP2Frame(conn, &frame, ts, tcptuple, dir); err != nil {
+			return http.dropHTTP2(conn, tcptuple, err)
+		}
+	}
+
+	h2.data[dir] = buf
+	return conn
+}
+
+func (http *httpPlugin) dropHTTP2(
+	conn *httpConnectionData,
+	tcptuple *common.TCPTuple,
+	err error,
+) *httpConnectionData {
+	if isDebug {
+		debugf("Dropping HTTP/2 connection: %v", err)
+	}
+	http.flushHTTP2(conn, tcptuple, "")
+	return nil
+}
+
+func (http *httpPlugin) handleHTTP2Frame(
+	conn *httpConnectionData,
+	f *http2Frame,
+	ts time.Time,
+	tcptuple *common.TCPTuple,
+	dir uint8,
+) error {
+	h2 := conn.http2
+
+	if block := h2.headerBlocks[dir]; block != nil {
+		if f.frameType != http2.FrameContinuation || f.streamID != block.streamID {
+			return errHTTP2Continuation
+		}
+		// The connection can't be followed without decoding the header
+		// block, as HPACK updates the decoding state of every block.
+		if len(block.fragment)+len(f.payload) > http.maxMessageSize {
+			return fmt.Errorf("HTTP/2 header block too large: %d bytes",
+				len(block.fragment)+len(f.payload))
+		}
+		block.fragment = append(block.fragment, f.payload...)
+		if !f.flags.Has(http2.FlagContinuationEndHeaders) {
+			return nil
+		}
+		h2.headerBlocks[dir] = nil
+		return http.decodeHTTP2Headers(conn, block, ts, tcptuple, dir)
+	}
+
+	switch f.frameType {
+	case http2.FrameData:
+		data, err := f.unpad()
+		if err != nil {
+			return err
+		}
+		http.receivedHTTP2Data(conn, f.streamID, data, f.flags.Has(http2.FlagDataEndStream), tcptuple, dir)
+
+	case http2.FrameHeaders:
+		fragment, err := f.unpad()
+		if err != nil {
+			return err
+		}
+		if f.flags.Has(http2.FlagHeadersPriority) {
+			if len(fragment) < 5 {
+				return errHTTP2Frame
+			}
+			fragment = fragment[5:]
+		}
+		block := &http2HeaderBlock{
+			streamID:  f.streamID,
+			frameType: f.frameType,
+			endStream: f.flags.Has(http2.FlagHeadersEndStream),
+			fragment:  fragment,
+		}
+		return http.receivedHTTP2HeaderBlock(conn, block, f.flags.Has(http2.FlagHeadersEndHeaders), ts, tcptuple, dir)
+
+	case http2.FramePushPromise:
+		fragment, err := f.unpad()
+		if err != nil {
+			return err
+		}
+		if len(fragment) < 4 {
+			return errHTTP2Frame
+		}
+		block := &http2HeaderBlock{
+			streamID:  binary.BigEndian.Uint32(fragment) & http2StreamIDMask,
+			frameType: f.frameType,
+			fragment:  fragment[4:],
+		}
+		return http.receivedHTTP2HeaderBlock(conn, block, f.flags.Has(http2.FlagPushPromiseEndHeaders), ts, tcptuple, dir)
+
+	case http2.FrameRSTStream:
+		if st := h2.streams[f.streamID]; st != nil {
+			delete(h2.streams, f.streamID)
+			http.flushHTTP2Stream(conn, st, tcptuple, "Stream reset")
+		}
+
+	case http2.FrameSettings:
+		if f.flags.Has(http2.FlagSettingsAck) {
+			return nil
+		}
+		if len(f.payload)%6 != 0 {
+			return errHTTP2Frame
+		}
+		for p := f.payload; len(p) > 0; p = p[6:] {
+			id := http2.SettingID(binary.BigEndian.Uint16(p))
+			if id == http2.SettingHeaderTableSize {
+				// The setting limits the table of the encoder on the
+				// other side of the connection.
+				h2.decoders[1-dir].SetAllowedMaxDynamicTableSize(binary.BigEndian.Uint32(p[2:]))
+			}
+		}
+	}
+	return nil
+}
+
+// unpad strips the padding of DATA, HEADERS and PUSH_PROMISE frames.
+func (f *http2Frame) unpad() ([]byte, error) {
+	if !f.flags.Has(http2.FlagDataPadded) {
+		return f.payload, nil
+	}
+	if len(f.payload) == 0 || int(f.payload[0]) >= len(f.payload) {
+		return nil, errHTTP2Frame
+	}
+	return f.payload[1 : len(f.payload)-int(f.payload[0])], nil
+}
+
+func (http *httpPlugin) receivedHTTP2HeaderBlock(
+	conn *httpConnectionData,
+	block *http2HeaderBlock,
+	endHeaders bool,
+	ts time.Time,
+	tcptuple *common.TCPTuple,
+	dir uint8,
+) error {
+	if !endHeaders {
+		block.fragment = append([]byte(nil), block.fragment...)
+		conn.http2.headerBlocks[dir] = block
+		return nil
+	}
+	return http.decodeHTTP2Headers(conn, block, ts, tcptuple, dir)
+}
+
+func (http *httpPlugin) decodeHTTP2Headers(
+	conn *httpConnectionData,
+	block *http2HeaderBlock,
+	ts time.Time,
+	tcptuple *common.TCPTuple,
+	dir uint8,
+) error {
+	h2 := conn.http2
+
+	// Header blocks must always be decoded to keep the HPACK state in sync.
+	fields, err := h2.decoders[dir].DecodeFull(block.fragment)
+	if err != nil {
+		return err
+	}
+
+	if block.frameType == http2.FramePushPromise {
+		// The server sends the request it is about to answer in a promised
+		// stream.
+		st := http.openHTTP2Stream(conn, block.streamID, tcptuple)
+		st.request = http.newHTTP2Message(fields, true, ts, len(block.fragment))
+		st.request.notes = append(st.request.notes, "Server push")
+		http.completeHTTP2Message(conn, block.streamID, st, true, tcptuple)
+		return nil
+	}
+
+	isRequest := dir == h2.clientDir
+	st := http.openHTTP2Stream(conn, block.streamID, tcptuple)
+	m, done := st.request, st.requestDone
+	if !isRequest {
+		m, done = st.response, st.responseDone
+	}
+	if done {
+		return nil
+	}
+
+	if m == nil {
+		m = http.newHTTP2Message(fields, isRequest, ts, len(block.fragment))
+		if !isRequest && m.statusCode < 200 {
+			// ignore informational responses
+			return nil
+		}
+		if isRequest {
+			st.request = m
+		} else {
+			st.response = m
+		}
+	} else {
+		// trailers
+		parser := newParser(&http.parserConfig)
+		for _, hf := range fields {
+			if !hf.IsPseudo() {
+				parser.storeHeader(m, []byte(hf.Name), []byte(hf.Value))
+			}
+		}
+		m.size += uint64(len(block.fragment))
+	}
+
+	if block.endStream {
+		http.completeHTTP2Message(conn, block.streamID, st, isRequest, tcptuple)
+	}
+	return nil
+}
+
+// newHTTP2Message creates a message from a decoded header block. The headers
+// are also rendered in HTTP/1 form, to be reported in the raw request and
+// response.
+func (http *httpPlugin) newHTTP2Message(
+	fields []hpack.HeaderField,
+	isRequest bool,
+	ts time.Time,
+	size int,
+) *message {
+	m := &message{
+		ts:        ts,
+		isRequest: isRequest,
+		version:   version{major: 2},
+		headers:   map[string]common.NetString{},
+		size:      uint64(size),
+	}
+
+	var headers []hpack.HeaderField
+	for _, hf := range fields {
+		switch hf.Name {
+		case ":method":
+			m.method = common.NetString(hf.Value)
+		case ":path":
+			m.requestURI = common.NetString(hf.Value)
+		case ":status":
+			code, _ := strconv.Atoi(hf.Value)
+			m.statusCode = uint16(code)
+			m.statusPhrase = common.NetString(nethttp.StatusText(code))
+		case ":authority":
+			headers = append(headers, hpack.HeaderField{Name: "host", Value: hf.Value})
+		default:
+			if !hf.IsPseudo() {
+				headers = append(headers, hf)
+			}
+		}
+	}
+
+	var raw bytes.Buffer
+	if isRequest {
+		fmt.Fprintf(&raw, "%s %s HTTP/2\r\n", m.method, m.requestURI)
+	} else {
+		fmt.Fprintf(&raw, "HTTP/2 %d\r\n", m.statusCode)
+	}
+	m.headerOffset = raw.Len()
+
+	parser := newParser(&http.parserConfig)
+	for _, hf := range headers {
+		parser.storeHeader(m, []byte(hf.Name), []byte(hf.Value))
+		fmt.Fprintf(&raw, "%s: %s\r\n", hf.Name, hf.Value)
+	}
+	raw.WriteString("\r\n")
+	m.rawHeaders = raw.Bytes()
+
+	if isRequest {
+		m.sendBody = parser.shouldIncludeInBody(m.contentType, http.parserConfig.includeRequestBodyFor)
+	} else {
+		m.sendBody = parser.shouldIncludeInBody(m.contentType, http.parserConfig.includeResponseBodyFor)
+	}
+	m.saveBody = m.sendBody || bytes.Contains(m.contentType, []byte("urlencoded"))
+
+	if isGRPC(m.contentType) {
+		m.grpc = &grpcMessages{}
+	}
+	return m
+}
+
+func (http *httpPlugin) receivedHTTP2Data(
+	conn *httpConnectionData,
+	streamID uint32,
+	data []byte,
+	endStream bool,
+	tcptuple *common.TCPTuple,
+	dir uint8,
+) {
+	h2 := conn.http2
+	st := h2.streams[streamID]
+	if st == nil {
+		return
+	}
+
+	isRequest := dir == h2.clientDir
+	m, done := st.response, st.responseDone
+	if isRequest {
+		m, done = st.request, st.requestDone
+	}
+	if m == nil || done {
+		return
+	}
+
+	m.size += uint64(len(data))
+	if !m.hasContentLength {
+		m.contentLength += len(data)
+	}
+	if m.grpc != nil {
+		m.grpc.feed(data)
+	}
+	if m.saveBody && len(m.body)+len(data) <= http.maxMessageSize {
+		m.body = append(m.body, data...)
+	}
+
+	if endStream {
+		http.completeHTTP2Message(conn, streamID, st, isRequest, tcptuple)
+	}
+}
+
+// completeHTTP2Message marks the request or the response of a stream as
+// received, publishing the transaction once both sides are complete.
+func (http *httpPlugin) completeHTTP2Message(
+	conn *httpConnectionData,
+	streamID uint32,
+	st *http2Stream,
+	isRequest bool,
+	tcptuple *common.TCPTuple,
+) {
+	h2 := conn.http2
+	if isRequest {
+		http.setMessageTuple(st.request, tcptuple, h2.direction(true))
+		st.requestDone = true
+	} else {
+		http.setMessageTuple(st.response, tcptuple, h2.direction(false))
+		st.responseDone = true
+	}
+
+	if st.responseDone && (st.requestDone || st.request == nil) {
+		delete(h2.streams, streamID)
+		if st.request == nil {
+			unmatchedResponses.Add(1)
+		}
+		if isDebug {
+			debugf("HTTP/2 transaction completed, stream=%d", streamID)
+		}
+		http.publishTransaction(http.newTransaction(st.request, st.response))
+	}
+}
+
+// flushHTTP2 publishes the streams of an HTTP/2 connection that are still
+// waiting for their request or response.
+func (http *httpPlugin) flushHTTP2(
+	conn *httpConnectionData,
+	tcptuple *common.TCPTuple,
+	note string,
+) {
+	h2 := conn.http2
+	if h2 == nil {
+		return
+	}
+
+	ids := make([]uint32, 0, len(h2.streams))
+	for id := range h2.streams {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	for _, id := range ids {
+		http.flushHTTP2Stream(conn, h2.streams[id], tcptuple, note)
+	}
+	h2.streams = map[uint32]*http2Stream{}
+}
+
+func (http *httpPlugin) flushHTTP2Stream(
+	conn *httpConnectionData,
+	st *http2Stream,
+	tcptuple *common.TCPTuple,
+	note string,
+) {
+	h2 := conn.http2
+	requ, resp := st.request, st.response
+	if requ == nil && resp == nil {
+		return
+	}
+
+	if requ != nil && !st.requestDone {
+		http.setMessageTuple(requ, tcptuple, h2.direction(true))
+	}
+	if resp != nil && !st.responseDone {
+		http.setMessageTuple(resp, tcptuple, h2.direction(false))
+	}
+
+	if note != "" {
+		if requ != nil {
+			requ.notes = append(requ.notes, note)
+		} else {
+			resp.notes = append(resp.notes, note)
+		}
+	}
+	if resp == nil {
+		unmatchedRequests.Add(1)
+	} else if requ == nil {
+		unmatchedResponses.Add(1)
+	}
+	http.publishTransaction(http.newTransaction(requ, resp))
+}
@@ -0,0 +1,269 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package http
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/hpack"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/packetbeat/protos"
+)
+
+// http2TestPeer builds the frames sent by one side of an HTTP/2 connection.
+type http2TestPeer struct {
+	buf     bytes.Buffer
+	framer  *http2.Framer
+	block   bytes.Buffer
+	encoder *hpack.Encoder
+}
+
+func newHTTP2TestPeer() *http2TestPeer {
+	p := &http2TestPeer{}
+	p.framer = http2.NewFramer(&p.buf, nil)
+	p.encoder = hpack.NewEncoder(&p.block)
+	return p
+}
+
+func (p *http2TestPeer) headers(streamID uint32, endStream bool, fields ...string) {
+	p.block.Reset()
+	for i := 0; i < len(fields); i += 2 {
+		p.encoder.WriteField(hpack.HeaderField{Name: fields[i], Value: fields[i+1]})
+	}
+	p.framer.WriteHeaders(http2.HeadersFrameParam{
+		StreamID:      streamID,
+		BlockFragment: p.block.Bytes(),
+		EndStream:     endStream,
+		EndHeaders:    true,
+	})
+}
+
+func (p *http2TestPeer) payload() []byte {
+	data := append([]byte(nil), p.buf.Bytes()...)
+	p.buf.Reset()
+	return data
+}
+
+func parseHTTP2Test(
+	http *httpPlugin,
+	private protos.ProtocolData,
+	dir uint8,
+	data []byte,
+) protos.ProtocolData {
+	packet := protos.Packet{Payload: data}
+	return http.Parse(&packet, testCreateTCPTuple(), dir, private)
+}
+
+func TestHTTP2_multiplexedStreams(t *testing.T) {
+	var store eventStore
+	http := httpModForTests(&store)
+
+	client, server := newHTTP2TestPeer(), newHTTP2TestPeer()
+	client.buf.WriteString(http2.ClientPreface)
+	client.framer.WriteSettings()
+	client.headers(1, true, ":method", "GET", ":scheme", "http", ":path", "/first", ":authority", "example.org")
+	client.headers(3, false, ":method", "POST", ":scheme", "http", ":path", "/second", ":authority", "example.org")
+	client.framer.WriteData(3, true, []byte("hello"))
+
+	server.framer.WriteSettings()
+	server.headers(3, false, ":status", "201", "content-type", "text/plain")
+	server.framer.WriteData(3, true, []byte("created"))
+	server.headers(1, false, ":status", "404", "content-type", "text/plain")
+	server.framer.WriteData(1, true, []byte("not found"))
+
+	private := protos.ProtocolData(&httpConnectionData{})
+	private = parseHTTP2Test(http, private, 0, client.payload())
+	private = parseHTTP2Test(http, private, 1, server.payload())
+	assert.NotNil(t, private)
+
+	trans := expectTransaction(t, &store)
+	assert.Equal(t, common.NetString("POST"), trans["method"])
+	assert.Equal(t, "/second", trans["path"])
+	assert.Equal(t, common.OK_STATUS, trans["status"])
+	assert.Equal(t, uint16(201), fieldValue(t, trans, "http.response.code"))
+	assert.Equal(t, 5, fieldValue(t, trans, "http.request.headers.content-length"))
+	assert.Equal(t, 7, fieldValue(t, trans, "http.response.headers.content-length"))
+
+	trans = expectTransaction(t, &store)
+	assert.Equal(t, common.NetString("GET"), trans["method"])
+	assert.Equal(t, "/first", trans["path"])
+	assert.Equal(t, common.ERROR_STATUS, trans["status"])
+	assert.Equal(t, uint16(404), fieldValue(t, trans, "http.response.code"))
+	assert.Equal(t, common.NetString("Not Found"), fieldValue(t, trans, "http.response.phrase"))
+
+	assert.True(t, store.empty())
+}
+
+func TestHTTP2_upgrade(t *testing.T) {
+	var store eventStore
+	http := httpModForTests(&store)
+	http.sendResponse = true
+
+	req := "GET /index.html HTTP/1.1\r\n" +
+		"Host: example.org\r\n" +
+		"Connection: Upgrade, HTTP2-Settings\r\n" +
+		"Upgrade: h2c\r\n" +
+		"HTTP2-Settings: AAMAAABkAARAAAAAAAIAAAAA\r\n" +
+		"\r\n"
+
+	server := newHTTP2TestPeer()
+	server.buf.WriteString("HTTP/1.1 101 Switching Protocols\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Upgrade: h2c\r\n" +
+		"\r\n")
+	server.framer.WriteSettings()
+	server.headers(1, false, ":status", "200", "content-type", "text/html")
+	server.framer.WriteData(1, true, []byte("<html></html>"))
+
+	client := newHTTP2TestPeer()
+	client.buf.WriteString(http2.ClientPreface)
+	client.framer.WriteSettings()
+
+	private := protos.ProtocolData(&httpConnectionData{})
+	private = parseHTTP2Test(http, private, 0, []byte(req))
+	private = parseHTTP2Test(http, private, 1, server.payload())
+	private = parseHTTP2Test(http, private, 0, client.payload())
+	assert.NotNil(t, private)
+
+	trans := expectTransaction(t, &store)
+	assert.Equal(t, "/index.html", trans["path"])
+	assert.Equal(t, uint16(200), fieldValue(t, trans, "http.response.code"))
+	assert.Equal(t, "HTTP/2 200\r\ncontent-type: text/html\r\n\r\n", trans["response"])
+	assert.True(t, store.empty())
+}
+
+func TestHTTP2_continuationAndTrailers(t *testing.T) {
+	var store eventStore
+	http := httpModForTests(&store)
+	http.parserConfig.sendHeaders = true
+	http.parserConfig.sendAllHeaders = true
+
+	client, server := newHTTP2TestPeer(), newHTTP2TestPeer()
+	client.buf.WriteString(http2.ClientPreface)
+	client.block.Reset()
+	for _, hf := range []hpack.HeaderField{
+		{Name: ":method", Value: "GET"},
+		{Name: ":scheme", Value: "http"},
+		{Name: ":path", Value: "/split"},
+		{Name: "user-agent", Value: "test"},
+	} {
+		client.encoder.WriteField(hf)
+	}
+	block := client.block.Bytes()
+	client.framer.WriteHeaders(http2.HeadersFrameParam{
+		StreamID:      1,
+		BlockFragment: block[:3],
+		EndStream:     true,
+	})
+	client.framer.WriteContinuation(1, true, block[3:])
+
+	server.headers(1, false, ":status", "200")
+	server.framer.WriteData(1, false, []byte("data"))
+	server.headers(1, true, "x-trailer", "done")
+
+	private := protos.ProtocolData(&httpConnectionData{})
+	private = parseHTTP2Test(http, private, 0, client.payload())
+	private = parseHTTP2Test(http, private, 1, server.payload())
+	assert.NotNil(t, private)
+
+	trans := expectTransaction(t, &store)
+	assert.Equal(t, "/split", trans["path"])
+	assert.Equal(t, common.NetString("test"), fieldValue(t, trans, "http.request.headers.user-agent"))
+	assert.Equal(t, common.NetString("done"), fieldValue(t, trans, "http.response.headers.x-trailer"))
+}
+
+func TestHTTP2_headerBlockTooLarge(t *testing.T) {
+	var store eventStore
+	http := httpModForTests(&store)
+	http.maxMessageSize = 16
+
+	client := newHTTP2TestPeer()
+	client.buf.WriteString(http2.ClientPreface)
+	client.framer.WriteHeaders(http2.HeadersFrameParam{
+		StreamID:      1,
+		BlockFragment: make([]byte, 10),
+		EndStream:     true,
+	})
+	for i := 0; i < 3; i++ {
+		client.framer.WriteContinuation(1, false, make([]byte, 10))
+	}
+
+	private := protos.ProtocolData(&httpConnectionData{})
+	private = parseHTTP2Test(http, private, 0, client.payload())
+	assert.Nil(t, private)
+	assert.True(t, store.empty())
+}
+
+func TestHTTP2_resetAndExpire(t *testing.T) {
+	var store eventStore
+	http := httpModForTests(&store)
+
+	client := newHTTP2TestPeer()
+	client.buf.WriteString(http2.ClientPreface)
+	client.headers(1, true, ":method", "GET", ":scheme", "http", ":path", "/reset")
+	client.headers(3, true, ":method", "GET", ":scheme", "http", ":path", "/pending")
+	client.framer.WriteRSTStream(1, http2.ErrCodeCancel)
+
+	private := protos.ProtocolData(&httpConnectionData{})
+	private = parseHTTP2Test(http, private, 0, client.payload())
+
+	trans := expectTransaction(t, &store)
+	assert.Equal(t, "/reset", trans["path"])
+	assert.Equal(t, []string{"Stream reset", "Unmatched request"}, trans["notes"])
+	assert.True(t, store.empty())
+
+	http.Expired(testCreateTCPTuple(), private)
+	trans = expectTransaction(t, &store)
+	assert.Equal(t, "/pending", trans["path"])
+	assert.Equal(t, common.ERROR_STATUS, trans["status"])
+}
+
+func TestHTTP2_evictOldestStream(t *testing.T) {
+	var store eventStore
+	http := httpModForTests(&store)
+	http.maxHTTP2Streams = 2
+
+	client := newHTTP2TestPeer()
+	client.buf.WriteString(http2.ClientPreface)
+	client.headers(5, true, ":method", "GET", ":scheme", "http", ":path", "/first")
+	client.headers(1, true, ":method", "GET", ":scheme", "http", ":path", "/second")
+	client.headers(3, true, ":method", "GET", ":scheme", "http", ":path", "/third")
+
+	before := evictedStreams.Get()
+	private := protos.ProtocolData(&httpConnectionData{})
+	private = parseHTTP2Test(http, private, 0, client.payload())
+	assert.Equal(t, before+1, evictedStreams.Get())
+
+	trans := expectTransaction(t, &store)
+	assert.Equal(t, "/first", trans["path"])
+	assert.Equal(t, []string{"Stream evicted", "Unmatched request"}, trans["notes"])
+	assert.True(t, store.empty())
+	assert.Len(t, private.(*httpConnectionData).http2.streams, 2)
+}
+
+func fieldValue(t *testing.T, fields common.MapStr, key string) interface{} {
+	value, err := fields.GetValue(key)
+	assert.NoError(t, err)
+	return value
+}
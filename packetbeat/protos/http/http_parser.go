@@ -38,6 +38,7 @@ type message struct {
 	headerOffset     int
 	version          version
 	connection       common.NetString
+	upgrade          common.NetString
 	chunkedLength    int
 
 	isRequest    bool
@@ -70,6 +71,11 @@ type message struct {
 	saveBody bool
 	body     []byte
 
+	// gRPC calls over HTTP/2
+	grpc        *grpcMessages
+	grpcStatus  common.NetString
+	grpcMessage common.NetString
+
 	notes []string
 
 	next *message
@@ -86,6 +92,7 @@ type parser struct {
 
 type parserConfig struct {
 	realIPHeader           string
+	realIPLastHop          bool
 	sendHeaders            bool
 	sendAllHeaders         bool
 	headersWhitelist       map[string]bool
@@ -106,7 +113,9 @@ var (
 	nameContentType      = []byte("content-type")
 	nameTransferEncoding = []byte("transfer-encoding")
 	nameContentEncoding  = []byte("content-encoding")
+	nameForwarded        = []byte("forwarded")
 	nameConnection       = []byte("connection")
+	nameUpgrade          = []byte("upgrade")
 )
 
 func newParser(config *parserConfig) *parser {
@@ -333,8 +342,6 @@ func (parser *parser) parseHeader(m *message, data []byte) (bool, bool, int) {
 		return true, false, 0
 	}
 
-	config := parser.config
-
 	// enabled if required. Allocs for parameters slow down parser big times
 	if isDetailed {
 		detailedf("Data: %s", data)
@@ -359,65 +366,81 @@ func (parser *parser) parseHeader(m *message, data []byte) (bool, bool, int) {
 				debugf("Header: '%s' Value: '%s'\n", data[:i], headerVal)
 			}
 
-			// Headers we need for parsing. Make sure we always
-			// capture their value
-			if bytes.Equal(headerName, nameContentLength) {
-				m.contentLength, _ = parseInt(headerVal)
-				m.hasContentLength = true
-			} else if bytes.Equal(headerName, nameContentType) {
-				m.contentType = headerVal
-			} else if bytes.Equal(headerName, nameTransferEncoding) {
-				encodings := parseCommaSeparatedList(headerVal)
-				// 'chunked' can only appear at the end
-				if n := len(encodings); n > 0 && encodings[n-1] == transferEncodingChunked {
-					m.isChunked = true
-					encodings = encodings[:n-1]
-				}
-				if len(encodings) > 0 {
-					// Append at the end of encodings. If a content-encoding
-					// header is also present, it was applied by sender before
-					// transfer-encoding.
-					m.encodings = append(m.encodings, encodings...)
-				}
-
-			} else if bytes.Equal(headerName, nameContentEncoding) {
-				encodings := parseCommaSeparatedList(headerVal)
-				// Append at the beginning of m.encodings, as Content-Encoding
-				// is supposed to be applied before Transfer-Encoding.
-				m.encodings = append(encodings, m.encodings...)
-			} else if bytes.Equal(headerName, nameConnection) {
-				m.connection = headerVal
-			}
-			if len(config.realIPHeader) > 0 && bytes.Equal(headerName, []byte(config.realIPHeader)) {
-				if ips := bytes.SplitN(headerVal, []byte{','}, 2); len(ips) > 0 {
-					m.realIP = trim(ips[0])
-				}
+			parser.storeHeader(m, headerName, headerVal)
+			return true, true, p + 2
+		}
+	}
+
+	return true, false, len(data)
+}
+
+// storeHeader records a header field in the message. Headers needed by the
+// parser are always captured, others are kept according to the send_headers
+// settings.
+func (parser *parser) storeHeader(m *message, headerName, headerVal []byte) {
+	config := parser.config
+
+	// Headers we need for parsing. Make sure we always
+	// capture their value
+	if bytes.Equal(headerName, nameContentLength) {
+		m.contentLength, _ = parseInt(headerVal)
+		m.hasContentLength = true
+	} else if bytes.Equal(headerName, nameContentType) {
+		m.contentType = headerVal
+	} else if bytes.Equal(headerName, nameTransferEncoding) {
+		encodings := parseCommaSeparatedList(headerVal)
+		// 'chunked' can only appear at the end
+		if n := len(encodings); n > 0 && encodings[n-1] == transferEncodingChunked {
+			m.isChunked = true
+			encodings = encodings[:n-1]
+		}
+		if len(encodings) > 0 {
+			// Append at the end of encodings. If a content-encoding
+			// header is also present, it was applied by sender before
+			// transfer-encoding.
+			m.encodings = append(m.encodings, encodings...)
+		}
+
+	} else if bytes.Equal(headerName, nameContentEncoding) {
+		encodings := parseCommaSeparatedList(headerVal)
+		// Append at the beginning of m.encodings, as Content-Encoding
+		// is supposed to be applied before Transfer-Encoding.
+		m.encodings = append(encodings, m.encodings...)
+	} else if bytes.Equal(headerName, nameConnection) {
+		m.connection = headerVal
+	} else if bytes.Equal(headerName, nameUpgrade) {
+		m.upgrade = headerVal
+	} else if bytes.Equal(headerName, nameGRPCStatus) {
+		m.grpcStatus = headerVal
+	} else if bytes.Equal(headerName, nameGRPCMessage) {
+		m.grpcMessage = headerVal
+	}
+	if len(config.realIPHeader) > 0 && bytes.Equal(headerName, []byte(config.realIPHeader)) {
+		// keep the first hop seen, unless the last one is requested
+		if len(m.realIP) == 0 || config.realIPLastHop {
+			if ip := parseRealIP(headerName, headerVal, config.realIPLastHop); len(ip) > 0 {
+				m.realIP = ip
 			}
+		}
+	}
 
-			if config.sendHeaders {
-				if !config.sendAllHeaders {
-					_, exists := config.headersWhitelist[string(headerName)]
-					if !exists {
-						return true, true, p + 2
-					}
-				}
-				if val, ok := m.headers[string(headerName)]; ok {
-					composed := make([]byte, len(val)+len(headerVal)+2)
-					off := copy(composed, val)
-					copy(composed[off:], []byte(", "))
-					copy(composed[off+2:], headerVal)
-
-					m.headers[string(headerName)] = composed
-				} else {
-					m.headers[string(headerName)] = headerVal
-				}
+	if config.sendHeaders {
+		if !config.sendAllHeaders {
+			if _, exists := config.headersWhitelist[string(headerName)]; !exists {
+				return
 			}
+		}
+		if val, ok := m.headers[string(headerName)]; ok {
+			composed := make([]byte, len(val)+len(headerVal)+2)
+			off := copy(composed, val)
+			copy(composed[off:], []byte(", "))
+			copy(composed[off+2:], headerVal)
 
-			return true, true, p + 2
+			m.headers[string(headerName)] = composed
+		} else {
+			m.headers[string(headerName)] = headerVal
 		}
 	}
-
-	return true, false, len(data)
 }
 
 func parseCommaSeparatedList(s common.NetString) (list []string) {
@@ -615,6 +638,42 @@ func isVersion(v version, major, minor uint8) bool {
 	return v.major == major && v.minor == minor
 }
 
+// parseRealIP returns the first or last client address of a list of proxy
+// hops, as found in X-Forwarded-For like headers or in the Forwarded header
+// (RFC 7239).
+func parseRealIP(headerName, headerVal []byte, last bool) []byte {
+	hops := bytes.Split(headerVal, []byte{','})
+	hop := hops[0]
+	if last {
+		hop = hops[len(hops)-1]
+	}
+	hop = trim(hop)
+
+	if !bytes.Equal(headerName, nameForwarded) {
+		return hop
+	}
+
+	for _, pair := range bytes.Split(hop, []byte{';'}) {
+		pair = trim(pair)
+		if len(pair) < 4 || !bytes.EqualFold(pair[:4], []byte("for=")) {
+			continue
+		}
+		node := bytes.Trim(pair[4:], `"`)
+		if len(node) > 0 && node[0] == '[' {
+			// quoted IPv6 address, optionally followed by a port
+			if end := bytes.IndexByte(node, ']'); end > 0 {
+				return node[1:end]
+			}
+			return nil
+		}
+		if colon := bytes.IndexByte(node, ':'); colon >= 0 {
+			node = node[:colon]
+		}
+		return node
+	}
+	return nil
+}
+
 func trim(buf []byte) []byte {
 	return trimLeft(trimRight(buf))
 }
@@ -1423,6 +1423,138 @@ func TestHttp_includeBodies(t *testing.T) {
 	}
 }
 
+func TestHttp_bodyLimits(t *testing.T) {
+	const req = "PUT /node HTTP/1.1\r\n" +
+		"Host: server\r\n" +
+		"Content-Length: 12\r\n" +
+		"Content-Type: text/plain\r\n" +
+		"\r\n" +
+		"request_body"
+	const resp = "HTTP/1.1 200 OK\r\n" +
+		"Content-Length: 5\r\n" +
+		"Content-Type: text/plain\r\n" +
+		"\r\n" +
+		"done."
+
+	for _, testCase := range []struct {
+		name                    string
+		maxBodySize             int
+		bodyHash                string
+		expected                common.MapStr
+		rawRequest, rawResponse string
+	}{
+		{
+			name:        "truncated",
+			maxBodySize: 7,
+			expected: common.MapStr{
+				"request.body":        "request",
+				"request.body_bytes":  12,
+				"response.body":       "done.",
+				"response.body_bytes": nil,
+			},
+			rawRequest:  req[:len(req)-5],
+			rawResponse: resp,
+		},
+		{
+			name:        "hashed",
+			maxBodySize: 7,
+			bodyHash:    "sha1",
+			expected: common.MapStr{
+				"request.body":        nil,
+				"request.body_hash":   "971e656b53480deee4b4ba602cf34da5f20ad035",
+				"request.body_bytes":  12,
+				"response.body_hash":  "2b2622f014ebf40f0e6c3890ddf6d453a282776b",
+				"response.body_bytes": 5,
+			},
+			rawRequest:  req[:len(req)-12],
+			rawResponse: resp[:len(resp)-5],
+		},
+	} {
+		var store eventStore
+		http := httpModForTests(&store)
+		config := defaultConfig
+		config.SendRequest = true
+		config.SendResponse = true
+		config.IncludeBodyFor = []string{"text/plain"}
+		config.MaxBodySize = testCase.maxBodySize
+		config.BodyHash = testCase.bodyHash
+		http.setFromConfig(&config)
+
+		tcptuple := testCreateTCPTuple()
+		packet := protos.Packet{Payload: []byte(req)}
+		private := protos.ProtocolData(&httpConnectionData{})
+		private = http.Parse(&packet, tcptuple, 0, private)
+		packet.Payload = []byte(resp)
+		private = http.Parse(&packet, tcptuple, 1, private)
+		http.ReceivedFin(tcptuple, 1, private)
+
+		trans := expectTransaction(t, &store)
+		if !assert.NotNil(t, trans, testCase.name) {
+			continue
+		}
+		details := trans["http"].(common.MapStr)
+		for key, value := range testCase.expected {
+			actual, _ := details.GetValue(key)
+			assert.Equal(t, value, actual, "%s: %s", testCase.name, key)
+		}
+		assert.Equal(t, testCase.rawRequest, trans["request"], testCase.name)
+		assert.Equal(t, testCase.rawResponse, trans["response"], testCase.name)
+	}
+}
+
+func TestHttpConfig_bodyHash(t *testing.T) {
+	config := defaultConfig
+	config.BodyHash = "sha256"
+	assert.NoError(t, config.Validate())
+
+	config.BodyHash = "crc32"
+	assert.Error(t, config.Validate())
+}
+
+func TestHttpConfig_realIPHop(t *testing.T) {
+	config := defaultConfig
+	config.RealIPHop = "last"
+	assert.NoError(t, config.Validate())
+
+	config.RealIPHop = "middle"
+	assert.Error(t, config.Validate())
+}
+
+func TestHttpParser_realIP(t *testing.T) {
+	const req = "GET / HTTP/1.1\r\n" +
+		"Host: server\r\n" +
+		"%s: %s\r\n" +
+		"%s: %s\r\n" +
+		"\r\n"
+	for _, test := range []struct {
+		header, value1, value2 string
+		lastHop                bool
+		expected               string
+	}{
+		{"X-Forwarded-For", "10.0.0.1, 10.0.0.2", "10.0.0.3", false, "10.0.0.1"},
+		{"X-Forwarded-For", "10.0.0.1, 10.0.0.2", "10.0.0.3", true, "10.0.0.3"},
+		{"X-Forwarded-For", "10.0.0.1, 10.0.0.2", "", true, "10.0.0.2"},
+		{"Forwarded", "for=192.0.2.60;proto=http;by=203.0.113.43", "for=10.0.0.3", false, "192.0.2.60"},
+		{"Forwarded", `for="[2001:db8:cafe::17]:4711"`, `For="10.0.0.3:80", for=10.0.0.4`, true, "10.0.0.4"},
+		{"Forwarded", `proto=https;For="[2001:db8:cafe::17]:4711"`, "", false, "2001:db8:cafe::17"},
+		{"Forwarded", "proto=https", "", false, ""},
+	} {
+		http := httpModForTests(nil)
+		http.parserConfig.realIPHeader = strings.ToLower(test.header)
+		http.parserConfig.realIPLastHop = test.lastHop
+
+		header2 := test.header
+		if test.value2 == "" {
+			header2 = "X-Other"
+		}
+		data := fmt.Sprintf(req, test.header, test.value1, header2, test.value2)
+		msg, ok, complete := testParse(http, data)
+		assert.True(t, ok)
+		assert.True(t, complete)
+		assert.Equal(t, test.expected, string(msg.realIP), "%s: %s / %s", test.header, test.value1, test.value2)
+	}
+}
+
 func TestHTTP_Encodings(t *testing.T) {
 	const req = "GET / HTTP/1.1\r\n" +
 		"Host: server\r\n" +
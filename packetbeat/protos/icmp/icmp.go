@@ -121,6 +121,11 @@ func (icmp *icmpPlugin) setFromConfig(config *icmpConfig) {
 	icmp.transactionTimeout = config.TransactionTimeout
 }
 
+// Flush publishes the requests without a response yet as expired.
+func (icmp *icmpPlugin) Flush() {
+	icmp.transactions.RemoveAll()
+}
+
 func (icmp *icmpPlugin) ProcessICMPv4(
 	flowID *flows.FlowID,
 	icmp4 *layers.ICMPv4,
@@ -143,6 +148,9 @@ func (icmp *icmpPlugin) ProcessICMPv4(
 		code:   code,
 		length: len(icmp4.BaseLayer.Payload),
 	}
+	if isError(tuple, msg) {
+		msg.original = extractOriginalFlow(4, icmp4.BaseLayer.Payload)
+	}
 
 	if isRequest(tuple, msg) {
 		if flowID != nil {
@@ -178,6 +186,9 @@ func (icmp *icmpPlugin) ProcessICMPv6(
 		code:   code,
 		length: len(icmp6.BaseLayer.Payload),
 	}
+	if isError(tuple, msg) {
+		msg.original = extractOriginalFlow(6, icmp6.BaseLayer.Payload)
+	}
 
 	if isRequest(tuple, msg) {
 		if flowID != nil {
@@ -336,6 +347,9 @@ func (icmp *icmpPlugin) publishTransaction(trans *icmpTransaction) {
 		request["message"] = humanReadable(&trans.tuple, trans.request)
 		request["type"] = trans.request.Type
 		request["code"] = trans.request.code
+		if trans.request.original != nil {
+			request["original"] = trans.request.original.toMapStr()
+		}
 
 		// TODO: Add more info. The IPv4/IPv6 payload could be interesting.
 		// if icmp.SendRequest {
@@ -19,10 +19,12 @@ package icmp
 
 import (
 	"encoding/binary"
+	"net"
 	"time"
 
 	"github.com/tsg/gopacket/layers"
 
+	"github.com/elastic/beats/libbeat/common"
 	"github.com/elastic/beats/libbeat/logp"
 )
 
@@ -81,6 +83,27 @@ type icmpMessage struct {
 	Type   uint8
 	code   uint8
 	length int
+
+	// flow of the datagram an error message refers to
+	original *originalFlow
+}
+
+// Flow information of the datagram quoted in an ICMP error message.
+type originalFlow struct {
+	srcIP     net.IP
+	dstIP     net.IP
+	transport string
+	hasPorts  bool
+	srcPort   uint16
+	dstPort   uint16
+}
+
+// IP protocol numbers of the transports we report
+var originalTransports = map[uint8]string{
+	1:  "icmp",
+	6:  "tcp",
+	17: "udp",
+	58: "ipv6-icmp",
 }
 
 func isRequest(tuple *icmpTuple, msg *icmpMessage) bool {
@@ -147,3 +170,62 @@ func humanReadable(tuple *icmpTuple, msg *icmpMessage) string {
 	logp.WTF("icmp", "Invalid ICMP version[%d]", tuple.icmpVersion)
 	return ""
 }
+
+// extractOriginalFlow decodes the IP header, and the source and destination
+// ports of TCP and UDP, of the datagram quoted in an ICMP error message. The
+// payload is the message body following the first 8 bytes of the ICMP header.
+func extractOriginalFlow(icmpVersion uint8, payload []byte) *originalFlow {
+	var flow originalFlow
+	var proto uint8
+	var transportHeader []byte
+
+	switch icmpVersion {
+	case 4:
+		if len(payload) < 20 || payload[0]>>4 != 4 {
+			return nil
+		}
+		headerLength := int(payload[0]&0x0f) * 4
+		if headerLength < 20 || headerLength > len(payload) {
+			return nil
+		}
+		proto = payload[9]
+		flow.srcIP = net.IP(payload[12:16])
+		flow.dstIP = net.IP(payload[16:20])
+		transportHeader = payload[headerLength:]
+	case 6:
+		// extension headers aren't followed, the next header is reported
+		// as is
+		if len(payload) < 40 || payload[0]>>4 != 6 {
+			return nil
+		}
+		proto = payload[6]
+		flow.srcIP = net.IP(payload[8:24])
+		flow.dstIP = net.IP(payload[24:40])
+		transportHeader = payload[40:]
+	default:
+		return nil
+	}
+
+	flow.transport = originalTransports[proto]
+	if (proto == 6 || proto == 17) && len(transportHeader) >= 4 {
+		flow.hasPorts = true
+		flow.srcPort = binary.BigEndian.Uint16(transportHeader[0:2])
+		flow.dstPort = binary.BigEndian.Uint16(transportHeader[2:4])
+	}
+	return &flow
+}
+
+func (f *originalFlow) toMapStr() common.MapStr {
+	m := common.MapStr{
+		"src_ip": f.srcIP.String(),
+		"dst_ip": f.dstIP.String(),
+	}
+	if f.transport != "" {
+		m["transport"] = f.transport
+	}
+	if f.hasPorts {
+		m["src_port"] = f.srcPort
+		m["dst_port"] = f.dstPort
+	}
+	return m
+}
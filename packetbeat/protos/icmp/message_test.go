@@ -20,6 +20,7 @@
 package icmp
 
 import (
+	"net"
 	"testing"
 
 	"github.com/tsg/gopacket/layers"
@@ -114,3 +115,41 @@ func TestIcmpMessageHumanReadableICMPv6(t *testing.T) {
 
 	assert.Equal(t, "DestinationUnreachable(Address)", humanReadable(tuple, msg))
 }
+
+func TestIcmpMessageExtractOriginalFlowICMPv4(t *testing.T) {
+	payload := []byte{
+		// IPv4 header, UDP from 10.0.0.1 to 10.0.0.2
+		0x45, 0x00, 0x00, 0x24, 0x00, 0x00, 0x00, 0x00, 0x01, 0x11, 0x00, 0x00,
+		0x0a, 0x00, 0x00, 0x01, 0x0a, 0x00, 0x00, 0x02,
+		// first 8 bytes of the UDP header, ports 33434 -> 53
+		0x82, 0x9a, 0x00, 0x35, 0x00, 0x10, 0x00, 0x00,
+	}
+
+	flow := extractOriginalFlow(4, payload)
+	if assert.NotNil(t, flow) {
+		assert.Equal(t, "10.0.0.1", flow.srcIP.String())
+		assert.Equal(t, "10.0.0.2", flow.dstIP.String())
+		assert.Equal(t, "udp", flow.transport)
+		assert.True(t, flow.hasPorts)
+		assert.Equal(t, uint16(33434), flow.srcPort)
+		assert.Equal(t, uint16(53), flow.dstPort)
+	}
+
+	assert.Nil(t, extractOriginalFlow(4, payload[:12]))
+}
+
+func TestIcmpMessageExtractOriginalFlowICMPv6(t *testing.T) {
+	payload := make([]byte, 40)
+	payload[0] = 0x60
+	payload[6] = 58 // ICMPv6
+	copy(payload[8:24], net.ParseIP("2001:db8::1"))
+	copy(payload[24:40], net.ParseIP("2001:db8::2"))
+
+	flow := extractOriginalFlow(6, payload)
+	if assert.NotNil(t, flow) {
+		assert.Equal(t, "2001:db8::1", flow.srcIP.String())
+		assert.Equal(t, "2001:db8::2", flow.dstIP.String())
+		assert.Equal(t, "ipv6-icmp", flow.transport)
+		assert.False(t, flow.hasPorts)
+	}
+}
@@ -33,7 +33,11 @@ import (
 )
 
 type udpMemcache struct {
-	udpConfig      udpConfig
+	udpConfig udpConfig
+
+	// udpMutex protects the connections, as ParseUDP can be called by
+	// several packet workers
+	udpMutex       sync.Mutex
 	udpConnections map[common.HashableIPPortTuple]*udpConnection
 	udpExpTrans    udpExpTransList
 }
@@ -83,14 +87,26 @@ type udpMessage struct {
 	datagrams    [][]byte
 }
 
-func (mc *memcache) ParseUDP(pkt *protos.Packet) {
+func (mc *memcache) FlowTimeout() time.Duration {
+	return mc.udpConfig.transTimeout
+}
+
+func (mc *memcache) ParseUDP(
+	pkt *protos.Packet,
+	flow *common.IPPortTuple,
+	_ uint8,
+	private protos.ProtocolData,
+) protos.ProtocolData {
 	defer logp.Recover("ParseMemcache(UDP) exception")
 
+	mc.udpMutex.Lock()
+	defer mc.udpMutex.Unlock()
+
 	buffer := streambuf.NewFixed(pkt.Payload)
 	header, err := parseUDPHeader(buffer)
 	if err != nil {
 		debug("parsing memcache udp header failed")
-		return
+		return nil
 	}
 
 	debug("new udp datagram requestId=%v, seqNumber=%v, numDatagrams=%v",
@@ -120,7 +136,7 @@ func (mc *memcache) ParseUDP(pkt *protos.Packet) {
 	if udpMsg.numDatagrams != header.numDatagrams {
 		logp.Warn("number of datagram mismatches in stream")
 		connection.killTransaction(trans)
-		return
+		return nil
 	}
 
 	// try to combine datagrams into complete memcached message
@@ -132,7 +148,7 @@ func (mc *memcache) ParseUDP(pkt *protos.Packet) {
 		if err != nil {
 			logp.Warn("failed to parse memcached(UDP) message: %s", err)
 			connection.killTransaction(trans)
-			return
+			return nil
 		}
 
 		// apply memcached to transaction
@@ -150,6 +166,7 @@ func (mc *memcache) ParseUDP(pkt *protos.Packet) {
 			mc.udpExpTrans.push(trans)
 		})
 	}
+	return nil
 }
 
 func (mc *memcache) getUDPConnection(
@@ -230,7 +230,7 @@ func (mongodb *mongodbPlugin) handleMongodb(
 
 func (mongodb *mongodbPlugin) onRequest(conn *mongodbConnectionData, msg *mongodbMessage) {
 	// publish request only transaction
-	if !awaitsReply(msg.opCode) {
+	if !msg.expectsResponse {
 		mongodb.onTransComplete(msg, nil)
 		return
 	}
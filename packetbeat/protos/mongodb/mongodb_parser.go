@@ -72,8 +72,10 @@ func mongodbMessageParser(s *stream) (bool, bool) {
 	case opReply:
 		s.message.isResponse = true
 		return opReplyParse(d, s.message)
-	case opMsg:
+	case opMsgLegacy:
 		s.message.method = "msg"
+		return opMsgLegacyParse(d, s.message)
+	case opMsg:
 		return opMsgParse(d, s.message)
 	case opUpdate:
 		s.message.method = "update"
@@ -137,7 +139,7 @@ func opReplyParse(d *decoder, m *mongodbMessage) (bool, bool) {
 	return true, true
 }
 
-func opMsgParse(d *decoder, m *mongodbMessage) (bool, bool) {
+func opMsgLegacyParse(d *decoder, m *mongodbMessage) (bool, bool) {
 	var err error
 	m.event["message"], err = d.readCStr()
 	if err != nil {
@@ -147,6 +149,112 @@ func opMsgParse(d *decoder, m *mongodbMessage) (bool, bool) {
 	return true, true
 }
 
+// see https://docs.mongodb.com/manual/reference/mongodb-wire-protocol/#op-msg
+func opMsgParse(d *decoder, m *mongodbMessage) (bool, bool) {
+	flags, err := d.readInt32()
+	if err != nil {
+		logp.Err("An error occurred while parsing OP_MSG message: %s", err)
+		return false, false
+	}
+	if flags&msgFlagChecksumPresent != 0 {
+		if len(d.in)-d.i < 4 {
+			logp.Err("An error occurred while parsing OP_MSG message: missing checksum")
+			return false, false
+		}
+		d.truncate(len(d.in) - 4)
+	}
+
+	var body bson.M
+	var command string
+	sequences := map[string][]interface{}{}
+	for d.i < len(d.in) && err == nil {
+		var kind []byte
+		kind, err = d.readBytes(1)
+		if err != nil {
+			break
+		}
+
+		switch kind[0] {
+		case 0:
+			// the command document. Its first key is the command name.
+			command, err = d.peekFirstKey()
+			if err == nil {
+				body, err = d.readDocument()
+			}
+		case 1:
+			sequences, err = d.readDocumentSequence(sequences)
+		default:
+			err = errors.New("unknown section kind")
+		}
+	}
+	if err == nil && body == nil {
+		err = errors.New("missing body section")
+	}
+	if err != nil {
+		logp.Err("An error occurred while parsing OP_MSG message: %s", err)
+		return false, false
+	}
+
+	// Replies are OP_MSG messages too
+	if m.responseTo != 0 {
+		m.isResponse = true
+		opMsgReply(body, m)
+		return true, true
+	}
+
+	m.expectsResponse = flags&msgFlagMoreToCome == 0
+	m.method = command
+
+	m.resource, _ = body["$db"].(string)
+	if col, ok := body[command].(string); ok {
+		m.resource += "." + col
+		m.event["fullCollectionName"] = m.resource
+	}
+	delete(body, command)
+	delete(body, "$db")
+	for id, docs := range sequences {
+		body[id] = docs
+	}
+	m.params = body
+
+	return true, true
+}
+
+func opMsgReply(body bson.M, m *mongodbMessage) {
+	if !isOK(body["ok"]) {
+		m.error, _ = body["errmsg"].(string)
+	} else if writeErrors, present := body["writeErrors"]; present {
+		m.error, _ = doc2str(writeErrors)
+	}
+
+	m.documents = []interface{}{body}
+	if cursor, ok := body["cursor"].(bson.M); ok {
+		m.event["cursorId"] = cursor["id"]
+		for _, batch := range []string{"firstBatch", "nextBatch"} {
+			if docs, ok := cursor[batch].([]interface{}); ok {
+				m.documents = docs
+			}
+		}
+	}
+	m.event["numberReturned"] = len(m.documents)
+}
+
+// isOK checks the `ok` field of a command reply, which can be of any numeric
+// type.
+func isOK(v interface{}) bool {
+	switch n := v.(type) {
+	case float64:
+		return n == 1
+	case int:
+		return n == 1
+	case int64:
+		return n == 1
+	case bool:
+		return n
+	}
+	return false
+}
+
 func opUpdateParse(d *decoder, m *mongodbMessage) (bool, bool) {
 	_, err := d.readInt32() // always ZERO, a slot reserved in the protocol for future use
 	m.event["fullCollectionName"], err = d.readCStr()
@@ -375,6 +483,42 @@ func (d *decoder) readDocument() (bson.M, error) {
 	return documentMap, err
 }
 
+// peekFirstKey returns the name of the first element of the document at the
+// current position, without consuming it.
+func (d *decoder) peekFirstKey() (string, error) {
+	// skip the document length and the element type
+	start := d.i
+	if start+5 > len(d.in) {
+		return "", errors.New("document out of bounds")
+	}
+	d.i += 5
+	key, err := d.readCStr()
+	d.i = start
+	return key, err
+}
+
+// readDocumentSequence reads a kind 1 section of an OP_MSG message, appending
+// its documents to the sequence with the same identifier.
+func (d *decoder) readDocumentSequence(sequences map[string][]interface{}) (map[string][]interface{}, error) {
+	start := d.i
+	size, err := d.readInt32()
+	if err != nil {
+		return sequences, err
+	}
+	end := start + size
+	if size < 4 || end > len(d.in) {
+		return sequences, errors.New("document sequence out of bounds")
+	}
+
+	id, err := d.readCStr()
+	for err == nil && d.i < end {
+		var doc bson.M
+		doc, err = d.readDocument()
+		sequences[id] = append(sequences[id], doc)
+	}
+	return sequences, err
+}
+
 func doc2str(documentMap interface{}) (string, error) {
 	document, err := json.Marshal(documentMap)
 	return string(document), err
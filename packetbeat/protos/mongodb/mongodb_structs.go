@@ -101,7 +101,7 @@ type opCode int32
 
 const (
 	opReply      opCode = 1
-	opMsg        opCode = 1000
+	opMsgLegacy  opCode = 1000
 	opUpdate     opCode = 2001
 	opInsert     opCode = 2002
 	opReserved   opCode = 2003
@@ -109,13 +109,20 @@ const (
 	opGetMore    opCode = 2005
 	opDelete     opCode = 2006
 	opKillCursor opCode = 2007
+	opMsg        opCode = 2013
+)
+
+// OP_MSG flag bits
+const (
+	msgFlagChecksumPresent = 1 << 0
+	msgFlagMoreToCome      = 1 << 1
 )
 
 // List of valid mongodb wire protocol operation codes
 // see http://docs.mongodb.org/meta-driver/latest/legacy/mongodb-wire-protocol/#request-opcodes
 var opCodeNames = map[opCode]string{
 	1:    "OP_REPLY",
-	1000: "OP_MSG",
+	1000: "OP_MSG_LEGACY",
 	2001: "OP_UPDATE",
 	2002: "OP_INSERT",
 	2003: "RESERVED",
@@ -123,6 +130,7 @@ var opCodeNames = map[opCode]string{
 	2005: "OP_GET_MORE",
 	2006: "OP_DELETE",
 	2007: "OP_KILL_CURSORS",
+	2013: "OP_MSG",
 }
 
 func validOpcode(o opCode) bool {
@@ -137,10 +145,6 @@ func (o opCode) String() string {
 	return fmt.Sprintf("(value=%d)", int32(o))
 }
 
-func awaitsReply(c opCode) bool {
-	return c == opQuery || c == opGetMore
-}
-
 // List of mongodb user commands (send throuwh a query of the legacy protocol)
 // see http://docs.mongodb.org/manual/reference/command/
 //
@@ -25,6 +25,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/mgo.v2/bson"
 
 	"github.com/elastic/beats/libbeat/beat"
 	"github.com/elastic/beats/libbeat/common"
@@ -390,3 +391,87 @@ func TestDocumentLengthBoundsChecked(t *testing.T) {
 	private = mongodb.Parse(&req, tcptuple, 0, private)
 	assert.NotNil(t, private, "mongodb parser recovered from a panic")
 }
+
+// opMsgTestMessage encodes an OP_MSG message. The body is a bson.D, as the
+// command name must be its first key.
+func opMsgTestMessage(t *testing.T, requestID, responseTo int32, body bson.D, sequence string, docs ...bson.M) []byte {
+	var data []byte
+	data = addInt32(data, 0) // length, set below
+	data = addInt32(data, requestID)
+	data = addInt32(data, responseTo)
+	data = addInt32(data, int32(opMsg))
+	data = addInt32(data, 0) // flags
+
+	raw, err := bson.Marshal(body)
+	assert.NoError(t, err)
+	data = append(data, 0)
+	data = append(data, raw...)
+
+	if sequence != "" {
+		var section []byte
+		section = addCStr(section, sequence)
+		for _, doc := range docs {
+			raw, err := bson.Marshal(doc)
+			assert.NoError(t, err)
+			section = append(section, raw...)
+		}
+		data = append(data, 1)
+		data = addInt32(data, int32(len(section)+4))
+		data = append(data, section...)
+	}
+
+	size := addInt32(nil, int32(len(data)))
+	copy(data, size)
+	return data
+}
+
+func TestOpMsgFind(t *testing.T) {
+	results, mongodb := mongodbModForTests()
+	mongodb.sendResponse = true
+
+	req := protos.Packet{Payload: opMsgTestMessage(t, 7, 0,
+		bson.D{{Name: "find", Value: "restaurants"}, {Name: "filter", Value: bson.M{"cuisine": "Italian"}}, {Name: "$db", Value: "test"}}, "")}
+	resp := protos.Packet{Payload: opMsgTestMessage(t, 8, 7,
+		bson.D{
+			{Name: "cursor", Value: bson.M{
+				"firstBatch": []interface{}{bson.M{"name": "a"}, bson.M{"name": "b"}},
+				"id":         int64(0),
+				"ns":         "test.restaurants",
+			}},
+			{Name: "ok", Value: 1.0},
+		}, "")}
+
+	tcptuple := testTCPTuple()
+	var private protos.ProtocolData
+	private = mongodb.Parse(&req, tcptuple, 0, private)
+	mongodb.Parse(&resp, tcptuple, 1, private)
+
+	trans := expectTransaction(t, results)
+	assert.Equal(t, "find", trans["method"])
+	assert.Equal(t, "test.restaurants", trans["resource"])
+	assert.Equal(t, common.OK_STATUS, trans["status"])
+	assert.Equal(t, 2, trans["mongodb"].(common.MapStr)["numberReturned"])
+	assert.Equal(t, "{\"name\":\"a\"}\n{\"name\":\"b\"}", trans["response"])
+}
+
+func TestOpMsgInsertError(t *testing.T) {
+	results, mongodb := mongodbModForTests()
+
+	req := protos.Packet{Payload: opMsgTestMessage(t, 1, 0,
+		bson.D{{Name: "insert", Value: "users"}, {Name: "ordered", Value: true}, {Name: "$db", Value: "app"}},
+		"documents", bson.M{"_id": 1}, bson.M{"_id": 2})}
+	resp := protos.Packet{Payload: opMsgTestMessage(t, 2, 1,
+		bson.D{{Name: "ok", Value: 0.0}, {Name: "errmsg", Value: "not authorized"}, {Name: "code", Value: 13}}, "")}
+
+	tcptuple := testTCPTuple()
+	var private protos.ProtocolData
+	private = mongodb.Parse(&req, tcptuple, 0, private)
+	mongodb.Parse(&resp, tcptuple, 1, private)
+
+	trans := expectTransaction(t, results)
+	assert.Equal(t, "insert", trans["method"])
+	assert.Equal(t, "app.users", trans["resource"])
+	assert.Equal(t, common.ERROR_STATUS, trans["status"])
+	assert.Equal(t, "not authorized", trans["mongodb"].(common.MapStr)["error"])
+	assert.Equal(t, "app.users.insert({\"ordered\":true})", trans["query"])
+}
@@ -18,22 +18,28 @@
 package mysql
 
 import (
+	"time"
+
 	"github.com/elastic/beats/packetbeat/config"
 	"github.com/elastic/beats/packetbeat/protos"
 )
 
 type mysqlConfig struct {
 	config.ProtocolCommon `config:",inline"`
-	MaxRowLength          int `config:"max_row_length"`
-	MaxRows               int `config:"max_rows"`
+	MaxRowLength          int           `config:"max_row_length"`
+	MaxRows               int           `config:"max_rows"`
+	StatementTimeout      time.Duration `config:"statement_timeout"`
+	SendParams            bool          `config:"send_params"`
 }
 
 var (
 	defaultConfig = mysqlConfig{
 		ProtocolCommon: config.ProtocolCommon{
 			TransactionTimeout: protos.DefaultTransactionExpiration,
+			MaxTransactions:    protos.DefaultMaxTransactions,
 		},
-		MaxRowLength: 1024,
-		MaxRows:      10,
+		MaxRowLength:     1024,
+		MaxRows:          10,
+		StatementTimeout: time.Hour,
 	}
 )
@@ -18,6 +18,7 @@
 package mysql
 
 import (
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"strings"
@@ -35,7 +36,10 @@ import (
 
 // Packet types
 const (
-	mysqlCmdQuery = 3
+	mysqlCmdQuery       = 3
+	mysqlCmdStmtPrepare = 22
+	mysqlCmdStmtExecute = 23
+	mysqlCmdStmtClose   = 25
 )
 
 const maxPayloadSize = 100 * 1024
@@ -43,6 +47,7 @@ const maxPayloadSize = 100 * 1024
 var (
 	unmatchedRequests  = monitoring.NewInt(nil, "mysql.unmatched_requests")
 	unmatchedResponses = monitoring.NewInt(nil, "mysql.unmatched_responses")
+	droppedRequests    = monitoring.NewInt(nil, "mysql.dropped_requests")
 )
 
 type mysqlMessage struct {
@@ -69,6 +74,12 @@ type mysqlMessage struct {
 	query          string
 	ignoreMessage  bool
 
+	// COM_STMT_PREPARE response
+	isPrepareOK        bool
+	statementID        uint32
+	numberOfParams     int
+	pendingDefinitions int
+
 	direction    uint8
 	isTruncated  bool
 	tcpTuple     common.TCPTuple
@@ -89,6 +100,8 @@ type mysqlTransaction struct {
 	bytesOut     uint64
 	bytesIn      uint64
 	notes        []string
+	cmd          uint8
+	timedOut     bool
 
 	mysql common.MapStr
 
@@ -103,6 +116,12 @@ type mysqlStream struct {
 	parseState  parseState
 	isClient    bool
 
+	// expectPrepareOK is set if the next response answers a COM_STMT_PREPARE
+	// request. skipEOF is set if the EOF packet terminating the parameter
+	// and column definitions of a prepared statement may follow.
+	expectPrepareOK bool
+	skipEOF         bool
+
 	message *mysqlMessage
 }
 
@@ -113,6 +132,7 @@ const (
 	mysqlStateEatMessage
 	mysqlStateEatFields
 	mysqlStateEatRows
+	mysqlStateEatDefinitions
 
 	mysqlStateMax
 )
@@ -122,6 +142,7 @@ var stateStrings = []string{
 	"EatMessage",
 	"EatFields",
 	"EatRows",
+	"EatDefinitions",
 }
 
 func (state parseState) String() string {
@@ -136,9 +157,15 @@ type mysqlPlugin struct {
 	maxRowLength int
 	sendRequest  bool
 	sendResponse bool
+	sendParams   bool
 
 	transactions       *common.Cache
 	transactionTimeout time.Duration
+	maxTransactions    int
+
+	// statements prepared on the connections, by mysqlStatementKey
+	statements       *common.Cache
+	statementTimeout time.Duration
 
 	results protos.Reporter
 
@@ -173,10 +200,22 @@ func New(
 func (mysql *mysqlPlugin) init(results protos.Reporter, config *mysqlConfig) error {
 	mysql.setFromConfig(config)
 
-	mysql.transactions = common.NewCache(
+	mysql.transactions = common.NewCacheWithRemovalListener(
 		mysql.transactionTimeout,
-		protos.DefaultTransactionHashSize)
+		protos.DefaultTransactionHashSize,
+		func(k common.Key, v common.Value) {
+			trans, ok := v.(*mysqlTransaction)
+			if !ok {
+				logp.Err("Expired value is not a *mysqlTransaction.")
+				return
+			}
+			mysql.expireTransaction(trans)
+		})
 	mysql.transactions.StartJanitor(mysql.transactionTimeout)
+	mysql.statements = common.NewCache(
+		mysql.statementTimeout,
+		protos.DefaultTransactionHashSize)
+	mysql.statements.StartJanitor(mysql.statementTimeout)
 	mysql.handleMysql = handleMysql
 	mysql.results = results
 
@@ -190,6 +229,9 @@ func (mysql *mysqlPlugin) setFromConfig(config *mysqlConfig) {
 	mysql.sendRequest = config.SendRequest
 	mysql.sendResponse = config.SendResponse
 	mysql.transactionTimeout = config.TransactionTimeout
+	mysql.maxTransactions = config.MaxTransactions
+	mysql.statementTimeout = config.StatementTimeout
+	mysql.sendParams = config.SendParams
 }
 
 func (mysql *mysqlPlugin) getTransaction(k common.HashableTCPTuple) *mysqlTransaction {
@@ -200,6 +242,21 @@ func (mysql *mysqlPlugin) getTransaction(k common.HashableTCPTuple) *mysqlTransa
 	return nil
 }
 
+func (mysql *mysqlPlugin) getStatement(k mysqlStatementKey) *mysqlStatement {
+	v := mysql.statements.Get(k)
+	if v != nil {
+		return v.(*mysqlStatement)
+	}
+	return nil
+}
+
+// isPreparing returns true if a COM_STMT_PREPARE request is waiting for its
+// response on the connection.
+func (mysql *mysqlPlugin) isPreparing(tuple *common.TCPTuple) bool {
+	trans := mysql.getTransaction(tuple.Hashable())
+	return trans != nil && trans.mysql != nil && trans.cmd == mysqlCmdStmtPrepare
+}
+
 func (mysql *mysqlPlugin) GetPorts() []int {
 	return mysql.ports
 }
@@ -231,16 +288,20 @@ func mysqlMessageParser(s *mysqlStream) (bool, bool) {
 
 			logp.Debug("mysqldetailed", "MySQL Header: Packet length %d, Seq %d, Type=%d", m.packetLength, m.seq, m.typ)
 
+			skipEOF := s.skipEOF
+			s.skipEOF = false
+
 			if m.seq == 0 {
 				// starts Command Phase
 
-				if m.typ == mysqlCmdQuery {
+				switch m.typ {
+				case mysqlCmdQuery, mysqlCmdStmtPrepare, mysqlCmdStmtExecute, mysqlCmdStmtClose:
 					// parse request
 					m.isRequest = true
 					m.start = s.parseOffset
 					s.parseState = mysqlStateEatMessage
 
-				} else {
+				default:
 					// ignore command
 					m.ignoreMessage = true
 					s.parseState = mysqlStateEatMessage
@@ -254,7 +315,17 @@ func mysqlMessageParser(s *mysqlStream) (bool, bool) {
 				// parse response
 				m.isRequest = false
 
-				if hdr[4] == 0x00 || hdr[4] == 0xfe {
+				if hdr[4] == 0x00 && s.expectPrepareOK {
+					logp.Debug("mysqldetailed", "Received prepare OK response")
+					m.start = s.parseOffset
+					s.parseState = mysqlStateEatMessage
+					m.isOK = true
+					m.isPrepareOK = true
+				} else if hdr[4] == 0xfe && m.packetLength < 9 && skipEOF {
+					// EOF terminating the definitions of a prepared statement
+					m.ignoreMessage = true
+					s.parseState = mysqlStateEatMessage
+				} else if hdr[4] == 0x00 || hdr[4] == 0xfe {
 					logp.Debug("mysqldetailed", "Received OK response")
 					m.start = s.parseOffset
 					s.parseState = mysqlStateEatMessage
@@ -292,7 +363,27 @@ func mysqlMessageParser(s *mysqlStream) (bool, bool) {
 			s.parseOffset += int(m.packetLength)
 			m.end = s.parseOffset
 			if m.isRequest {
-				m.query = string(s.data[m.start+5 : m.end])
+				if m.typ == mysqlCmdQuery || m.typ == mysqlCmdStmtPrepare {
+					m.query = string(s.data[m.start+5 : m.end])
+				}
+			} else if m.isPrepareOK {
+				// int<4> statement id, int<2> columns, int<2> params,
+				// int<1> reserved, int<2> warnings
+				if m.packetLength < 9 {
+					logp.Debug("mysql", "Prepare OK response too short")
+					return false, false
+				}
+				body := s.data[m.start+5 : m.end]
+				m.statementID = binary.LittleEndian.Uint32(body[0:4])
+				m.numberOfFields = int(binary.LittleEndian.Uint16(body[4:6]))
+				m.numberOfParams = int(binary.LittleEndian.Uint16(body[6:8]))
+
+				// the parameter and column definitions follow
+				m.pendingDefinitions = m.numberOfParams + m.numberOfFields
+				if m.pendingDefinitions > 0 {
+					s.parseState = mysqlStateEatDefinitions
+					break
+				}
 			} else if m.isOK {
 				// affected rows
 				affectedRows, off, complete, err := readLinteger(s.data, m.start+5)
@@ -435,6 +526,35 @@ func mysqlMessageParser(s *mysqlStream) (bool, bool) {
 			}
 			m.numberOfRows++
 			// go to next row
+
+		case mysqlStateEatDefinitions:
+			if len(s.data[s.parseOffset:]) < 4 {
+				// wait for more
+				return true, false
+			}
+			hdr := s.data[s.parseOffset : s.parseOffset+4]
+			m.packetLength = uint32(hdr[0]) | uint32(hdr[1])<<8 | uint32(hdr[2])<<16
+			m.seq = hdr[3]
+			if len(s.data[s.parseOffset:]) < int(m.packetLength)+4 {
+				// wait for more
+				return true, false
+			}
+
+			// the parameter definitions are terminated by an EOF packet,
+			// unless CLIENT_DEPRECATE_EOF has been negotiated
+			isEOF := m.packetLength < 9 && m.packetLength > 0 && s.data[s.parseOffset+4] == 0xfe
+			s.parseOffset += 4 + int(m.packetLength)
+			m.end = s.parseOffset
+			if isEOF {
+				continue
+			}
+
+			m.pendingDefinitions--
+			if m.pendingDefinitions == 0 {
+				s.skipEOF = true
+				m.size = uint64(m.end - m.start)
+				return true, true
+			}
 		}
 	}
 
@@ -447,7 +567,7 @@ func mysqlMessageParser(s *mysqlStream) (bool, bool) {
 func (mysql *mysqlPlugin) messageGap(s *mysqlStream, nbytes int) (complete bool) {
 	m := s.message
 	switch s.parseState {
-	case mysqlStateStart, mysqlStateEatMessage:
+	case mysqlStateStart, mysqlStateEatMessage, mysqlStateEatDefinitions:
 		// not enough data yet to be useful
 		return false
 	case mysqlStateEatFields, mysqlStateEatRows:
@@ -485,6 +605,11 @@ func (mysql *mysqlPlugin) ConnectionTimeout() time.Duration {
 	return mysql.transactionTimeout
 }
 
+// Flush publishes the transactions in progress as expired.
+func (mysql *mysqlPlugin) Flush() {
+	mysql.transactions.RemoveAll()
+}
+
 func (mysql *mysqlPlugin) Parse(pkt *protos.Packet, tcptuple *common.TCPTuple,
 	dir uint8, private protos.ProtocolData) protos.ProtocolData {
 
@@ -520,6 +645,10 @@ func (mysql *mysqlPlugin) Parse(pkt *protos.Packet, tcptuple *common.TCPTuple,
 			stream.message = &mysqlMessage{ts: pkt.Ts}
 		}
 
+		if stream.parseState == mysqlStateStart {
+			stream.expectPrepareOK = mysql.isPreparing(tcptuple)
+		}
+
 		ok, complete := mysqlMessageParser(priv.data[dir])
 		//logp.Debug("mysqldetailed", "mysqlMessageParser returned ok=%b complete=%b", ok, complete)
 		if !ok {
@@ -594,6 +723,16 @@ func handleMysql(mysql *mysqlPlugin, m *mysqlMessage, tcptuple *common.TCPTuple,
 
 func (mysql *mysqlPlugin) receivedMysqlRequest(msg *mysqlMessage) {
 	tuple := msg.tcpTuple
+
+	if msg.typ == mysqlCmdStmtClose {
+		// COM_STMT_CLOSE has no response
+		if len(msg.raw) >= 9 {
+			id := binary.LittleEndian.Uint32(msg.raw[5:9])
+			mysql.statements.Delete(mysqlStatementKey{tuple.Hashable(), id})
+		}
+		return
+	}
+
 	trans := mysql.getTransaction(tuple.Hashable())
 	if trans != nil {
 		if trans.mysql != nil {
@@ -601,6 +740,11 @@ func (mysql *mysqlPlugin) receivedMysqlRequest(msg *mysqlMessage) {
 			unmatchedRequests.Add(1)
 		}
 	} else {
+		if !protos.CanAddTransaction(mysql.transactions, mysql.maxTransactions) {
+			logp.Debug("mysql", "Too many transactions in flight. Dropping request: %s", msg.query)
+			droppedRequests.Add(1)
+			return
+		}
 		trans = &mysqlTransaction{tuple: tuple}
 		mysql.transactions.Put(tuple.Hashable(), trans)
 	}
@@ -611,19 +755,11 @@ func (mysql *mysqlPlugin) receivedMysqlRequest(msg *mysqlMessage) {
 		trans.src, trans.dst = trans.dst, trans.src
 	}
 
-	// Extract the method, by simply taking the first word and
-	// making it upper case.
 	query := strings.Trim(msg.query, " \r\n\t")
-	index := strings.IndexAny(query, " \r\n\t")
-	var method string
-	if index > 0 {
-		method = strings.ToUpper(query[:index])
-	} else {
-		method = strings.ToUpper(query)
-	}
 
+	trans.cmd = msg.typ
 	trans.query = query
-	trans.method = method
+	trans.method = queryMethod(query)
 
 	trans.mysql = common.MapStr{}
 
@@ -632,6 +768,56 @@ func (mysql *mysqlPlugin) receivedMysqlRequest(msg *mysqlMessage) {
 	// save Raw message
 	trans.requestRaw = msg.query
 	trans.bytesIn = msg.size
+
+	switch msg.typ {
+	case mysqlCmdStmtPrepare:
+		trans.method = "PREPARE"
+	case mysqlCmdStmtExecute:
+		mysql.receivedExecute(trans, msg)
+	}
+}
+
+// receivedExecute fills in the transaction of a COM_STMT_EXECUTE request from
+// the statement prepared earlier on the connection.
+func (mysql *mysqlPlugin) receivedExecute(trans *mysqlTransaction, msg *mysqlMessage) {
+	if len(msg.raw) < 9 {
+		trans.notes = append(trans.notes, "Invalid statement execute request")
+		return
+	}
+	id := binary.LittleEndian.Uint32(msg.raw[5:9])
+	stmt := mysql.getStatement(mysqlStatementKey{msg.tcpTuple.Hashable(), id})
+	if stmt == nil {
+		trans.method = "EXECUTE"
+		trans.notes = append(trans.notes, fmt.Sprintf("Unknown prepared statement %d", id))
+		return
+	}
+
+	trans.query = stmt.query
+	trans.method = stmt.method
+	trans.requestRaw = stmt.query
+	trans.mysql["statement_id"] = id
+
+	if !mysql.sendParams {
+		return
+	}
+	params, err := parseExecuteParams(msg.raw[5:], stmt, mysql.maxRowLength)
+	if err != nil {
+		logp.Debug("mysql", "Error parsing statement parameters: %v", err)
+		trans.notes = append(trans.notes, "Failed to decode statement parameters")
+	}
+	if len(params) > 0 {
+		trans.mysql["params"] = params
+	}
+}
+
+// queryMethod extracts the method, by simply taking the first word of the
+// query and making it upper case.
+func queryMethod(query string) string {
+	index := strings.IndexAny(query, " \r\n\t")
+	if index > 0 {
+		return strings.ToUpper(query[:index])
+	}
+	return strings.ToUpper(query)
 }
 
 func (mysql *mysqlPlugin) receivedMysqlResponse(msg *mysqlMessage) {
@@ -663,9 +849,18 @@ func (mysql *mysqlPlugin) receivedMysqlResponse(msg *mysqlMessage) {
 
 	trans.responseTime = int32(msg.ts.Sub(trans.ts).Nanoseconds() / 1e6) // resp_time in milliseconds
 
+	if msg.isPrepareOK {
+		mysql.statements.Put(mysqlStatementKey{trans.tuple.Hashable(), msg.statementID}, &mysqlStatement{
+			query:     trans.query,
+			method:    queryMethod(trans.query),
+			numParams: msg.numberOfParams,
+		})
+		trans.mysql["statement_id"] = msg.statementID
+	}
+
 	// save Raw message
-	if len(msg.raw) > 0 {
-		fields, rows := mysql.parseMysqlResponse(msg.raw)
+	if len(msg.raw) > 0 && !msg.isPrepareOK {
+		fields, rows := mysql.parseMysqlResponse(msg.raw, trans.cmd == mysqlCmdStmtExecute)
 
 		trans.responseRaw = common.DumpInCSVFormat(fields, rows)
 	}
@@ -679,7 +874,22 @@ func (mysql *mysqlPlugin) receivedMysqlResponse(msg *mysqlMessage) {
 	logp.Debug("mysql", "%s", trans.responseRaw)
 }
 
-func (mysql *mysqlPlugin) parseMysqlResponse(data []byte) ([]string, [][]string) {
+// expireTransaction publishes a transaction whose response was not received
+// within the transaction timeout.
+func (mysql *mysqlPlugin) expireTransaction(trans *mysqlTransaction) {
+	if trans.mysql == nil {
+		return
+	}
+	logp.Debug("mysql", "Transaction timed out: %s", &trans.tuple)
+	unmatchedRequests.Add(1)
+	trans.timedOut = true
+	mysql.publishTransaction(trans)
+}
+
+// parseMysqlResponse extracts the column names and rows of a result set. If
+// binary is set, the rows are encoded by the binary protocol of prepared
+// statements.
+func (mysql *mysqlPlugin) parseMysqlResponse(data []byte, binary bool) ([]string, [][]string) {
 	length, err := readLength(data, 0)
 	if err != nil {
 		logp.Warn("Invalid response: %v", err)
@@ -692,6 +902,7 @@ func (mysql *mysqlPlugin) parseMysqlResponse(data []byte) ([]string, [][]string)
 
 	fields := []string{}
 	rows := [][]string{}
+	var types []columnType
 
 	if len(data) < 5 {
 		logp.Warn("Invalid response: data less than 4 bytes")
@@ -751,12 +962,25 @@ func (mysql *mysqlPlugin) parseMysqlResponse(data []byte) ([]string, [][]string)
 				logp.Debug("mysql", "Reading field: %v %v", err, complete)
 				return fields, rows
 			}
-			_ /* org name */, _ /*off*/, complete, err = readLstring(data, off)
+			_ /* org name */, off, complete, err = readLstring(data, off)
 			if err != nil || !complete {
 				logp.Debug("mysql", "Reading field: %v %v", err, complete)
 				return fields, rows
 			}
 
+			if binary {
+				// int<lenenc> length of fixed fields (0x0c), int<2> character set,
+				// int<4> column length, int<1> type, int<2> flags
+				if len(data) < off+10 {
+					logp.Debug("mysql", "Reading field: column type missing")
+					return fields, rows
+				}
+				types = append(types, columnType{
+					typ:      data[off+7],
+					unsigned: data[off+8]&0x20 != 0,
+				})
+			}
+
 			fields = append(fields, string(name))
 
 			offset += length + 4
@@ -788,8 +1012,16 @@ func (mysql *mysqlPlugin) parseMysqlResponse(data []byte) ([]string, [][]string)
 				break
 			}
 			off := offset + 4 // skip length + packet number
+			if binary {
+				row, err = mysql.parseBinaryRow(data[off:off+length], types)
+				if err != nil {
+					logp.Debug("mysql", "Error parsing rows: %s", err)
+					// nevertheless, return what we have so far
+					return fields, rows
+				}
+			}
 			start := off
-			for off < start+length {
+			for !binary && off < start+length {
 				var text []byte
 
 				if data[off] == 0xfb {
@@ -828,6 +1060,44 @@ func (mysql *mysqlPlugin) parseMysqlResponse(data []byte) ([]string, [][]string)
 	return fields, rows
 }
 
+type columnType struct {
+	typ      byte
+	unsigned bool
+}
+
+// parseBinaryRow decodes a row of a binary result set.
+func (mysql *mysqlPlugin) parseBinaryRow(data []byte, types []columnType) ([]string, error) {
+	// int<1> header (0x00), null bitmap with an offset of 2 bits, values
+	nullBitmapLen := (len(types) + 7 + 2) / 8
+	if len(data) < 1+nullBitmapLen {
+		return nil, errBinaryValueTruncated
+	}
+	nullBitmap := data[1 : 1+nullBitmapLen]
+	off := 1 + nullBitmapLen
+
+	var row []string
+	var rowLen int
+	for i, t := range types {
+		text := "NULL"
+		if bit := i + 2; nullBitmap[bit/8]&(1<<uint(bit%8)) == 0 {
+			var err error
+			text, off, err = readBinaryValue(data, off, t.typ, t.unsigned)
+			if err != nil {
+				return row, err
+			}
+		}
+
+		if rowLen < mysql.maxRowLength {
+			if rowLen+len(text) > mysql.maxRowLength {
+				text = text[:mysql.maxRowLength-rowLen]
+			}
+			row = append(row, text)
+			rowLen += len(text)
+		}
+	}
+	return row, nil
+}
+
 func (mysql *mysqlPlugin) publishTransaction(t *mysqlTransaction) {
 	if mysql.results == nil {
 		return
@@ -838,9 +1108,12 @@ func (mysql *mysqlPlugin) publishTransaction(t *mysqlTransaction) {
 	fields := common.MapStr{}
 	fields["type"] = "mysql"
 
-	if t.mysql["iserror"].(bool) {
+	switch {
+	case t.timedOut:
+		fields["status"] = common.TIMEOUT_STATUS
+	case t.mysql["iserror"].(bool):
 		fields["status"] = common.ERROR_STATUS
-	} else {
+	default:
 		fields["status"] = common.OK_STATUS
 	}
 
@@ -64,6 +64,7 @@ func Test_parseStateNames(t *testing.T) {
 	assert.Equal(t, "EatMessage", mysqlStateEatMessage.String())
 	assert.Equal(t, "EatFields", mysqlStateEatFields.String())
 	assert.Equal(t, "EatRows", mysqlStateEatRows.String())
+	assert.Equal(t, "EatDefinitions", mysqlStateEatDefinitions.String())
 
 	assert.NotNil(t, (mysqlStateMax - 1).String())
 }
@@ -224,7 +225,7 @@ func TestMySQLParser_dataResponse(t *testing.T) {
 	if len(raw) == 0 {
 		t.Errorf("Empty raw data")
 	}
-	fields, rows := mysql.parseMysqlResponse(raw)
+	fields, rows := mysql.parseMysqlResponse(raw, false)
 	if len(fields) != stream.message.numberOfFields {
 		t.Errorf("Failed to parse the fields")
 	}
@@ -624,7 +625,7 @@ func Test_parseMysqlResponse_invalid(t *testing.T) {
 	}
 
 	for _, input := range tests {
-		fields, rows := mysql.parseMysqlResponse(input)
+		fields, rows := mysql.parseMysqlResponse(input, false)
 		assert.Equal(t, []string{}, fields)
 		assert.Equal(t, [][]string{}, rows)
 	}
@@ -642,8 +643,162 @@ func Test_parseMysqlResponse_invalid(t *testing.T) {
 	}
 
 	for _, input := range tests {
-		fields, rows := mysql.parseMysqlResponse(input)
+		fields, rows := mysql.parseMysqlResponse(input, false)
 		assert.Equal(t, []string{""}, fields)
 		assert.Equal(t, [][]string{}, rows)
 	}
 }
+
+func mysqlPacket(seq byte, payload ...[]byte) []byte {
+	var body []byte
+	for _, p := range payload {
+		body = append(body, p...)
+	}
+	n := len(body)
+	return append([]byte{byte(n), byte(n >> 8), byte(n >> 16), seq}, body...)
+}
+
+func mysqlColumnDefinition(name string, typ byte) []byte {
+	var def []byte
+	for _, s := range []string{"def", "test", "users", "users", name, name} {
+		def = append(def, byte(len(s)))
+		def = append(def, s...)
+	}
+	return append(def, 0x0c, 0x21, 0x00, 0xff, 0x00, 0x00, 0x00, typ, 0x00, 0x00, 0x00, 0x00, 0x00)
+}
+
+// Test that executed prepared statements are reported with the SQL text of
+// the statement.
+func TestParseMySQL_preparedStatement(t *testing.T) {
+	logp.TestingSetup(logp.WithSelectors("mysql", "mysqldetailed"))
+
+	const query = "SELECT name FROM users WHERE id = ?"
+	eof := []byte{0xfe, 0x00, 0x00, 0x02, 0x00}
+
+	prepare := mysqlPacket(0, []byte{mysqlCmdStmtPrepare}, []byte(query))
+	prepareOK := append(append(append(append(
+		mysqlPacket(1, []byte{0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00}),
+		mysqlPacket(2, mysqlColumnDefinition("?", mysqlTypeLongLong))...),
+		mysqlPacket(3, eof)...),
+		mysqlPacket(4, mysqlColumnDefinition("name", mysqlTypeVarString))...),
+		mysqlPacket(5, eof)...)
+	execute := mysqlPacket(0, []byte{mysqlCmdStmtExecute,
+		0x01, 0x00, 0x00, 0x00, // statement id
+		0x00,                   // flags
+		0x01, 0x00, 0x00, 0x00, // iteration count
+		0x00,       // null bitmap
+		0x01,       // new params bound
+		0x08, 0x00, // longlong
+		0x2a, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	})
+	resultSet := append(append(append(append(
+		mysqlPacket(1, []byte{0x01}),
+		mysqlPacket(2, mysqlColumnDefinition("name", mysqlTypeVarString))...),
+		mysqlPacket(3, eof)...),
+		mysqlPacket(4, []byte{0x00, 0x00, 0x05}, []byte("alice"))...),
+		mysqlPacket(5, eof)...)
+	closeStmt := mysqlPacket(0, []byte{mysqlCmdStmtClose, 0x01, 0x00, 0x00, 0x00})
+
+	for _, sendParams := range []bool{false, true} {
+		store := &eventStore{}
+		mysql := mysqlModForTests(store)
+		mysql.sendParams = sendParams
+		mysql.sendRequest, mysql.sendResponse = true, true
+		tcptuple := testTCPTuple()
+
+		var private protos.ProtocolData
+		for _, msg := range []struct {
+			dir  uint8
+			data []byte
+		}{
+			{0, prepare}, {1, prepareOK}, {0, execute}, {1, resultSet},
+		} {
+			private = mysql.Parse(&protos.Packet{Payload: msg.data}, tcptuple, msg.dir, private)
+		}
+
+		trans := expectTransaction(t, store)
+		if assert.NotNil(t, trans) {
+			assert.Equal(t, "PREPARE", trans["method"])
+			assert.Equal(t, query, trans["query"])
+			assert.Equal(t, uint32(1), trans["mysql"].(common.MapStr)["statement_id"])
+		}
+
+		trans = expectTransaction(t, store)
+		if assert.NotNil(t, trans) {
+			assert.Equal(t, "SELECT", trans["method"])
+			assert.Equal(t, query, trans["query"])
+			assert.Equal(t, query, trans["request"])
+			assert.Equal(t, "name\nalice\n", trans["response"])
+
+			m := trans["mysql"].(common.MapStr)
+			assert.Equal(t, 1, m["num_rows"])
+			if sendParams {
+				assert.Equal(t, []string{"42"}, m["params"])
+			} else {
+				assert.NotContains(t, m, "params")
+			}
+		}
+		assert.True(t, store.empty())
+
+		key := mysqlStatementKey{tcptuple.Hashable(), 1}
+		assert.NotNil(t, mysql.getStatement(key))
+		mysql.Parse(&protos.Packet{Payload: closeStmt}, tcptuple, 0, private)
+		assert.Nil(t, mysql.getStatement(key))
+		assert.True(t, store.empty())
+	}
+}
+
+// Test that executing a statement prepared before the capture started is
+// still reported.
+func TestParseMySQL_unknownStatement(t *testing.T) {
+	logp.TestingSetup(logp.WithSelectors("mysql", "mysqldetailed"))
+
+	store := &eventStore{}
+	mysql := mysqlModForTests(store)
+	tcptuple := testTCPTuple()
+
+	execute := mysqlPacket(0, []byte{mysqlCmdStmtExecute,
+		0x07, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00})
+	ok := mysqlPacket(1, []byte{0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00})
+
+	private := mysql.Parse(&protos.Packet{Payload: execute}, tcptuple, 0, nil)
+	mysql.Parse(&protos.Packet{Payload: ok}, tcptuple, 1, private)
+
+	trans := expectTransaction(t, store)
+	if assert.NotNil(t, trans) {
+		assert.Equal(t, "EXECUTE", trans["method"])
+		assert.Equal(t, []string{"Unknown prepared statement 7"}, trans["notes"])
+	}
+}
+
+func TestMySQL_transactionTimeout(t *testing.T) {
+	logp.TestingSetup(logp.WithSelectors("mysql", "mysqldetailed"))
+
+	store := &eventStore{}
+	mysql := mysqlModForTests(store)
+	mysql.maxTransactions = 1
+	tcptuple := testTCPTuple()
+
+	query := mysqlPacket(0, []byte{mysqlCmdQuery}, []byte("SELECT 1"))
+	mysql.Parse(&protos.Packet{Payload: query}, tcptuple, 0, nil)
+
+	// the limit of in-flight transactions drops requests of other connections
+	other := *tcptuple
+	other.SrcPort++
+	other.ComputeHashables()
+	mysql.Parse(&protos.Packet{Payload: query}, &other, 0, nil)
+	assert.Equal(t, 1, mysql.transactions.Size())
+
+	// expire the pending transaction
+	key := tcptuple.Hashable()
+	mysql.transactions.PutWithTimeout(key, mysql.getTransaction(key), time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	mysql.transactions.CleanUp()
+
+	trans := expectTransaction(t, store)
+	if assert.NotNil(t, trans) {
+		assert.Equal(t, common.TIMEOUT_STATUS, trans["status"])
+		assert.Equal(t, "SELECT", trans["method"])
+	}
+	assert.True(t, store.empty())
+}
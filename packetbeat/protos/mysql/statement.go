@@ -0,0 +1,290 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mysql
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+// Column and parameter types of the binary protocol
+const (
+	mysqlTypeDecimal    = 0x00
+	mysqlTypeTiny       = 0x01
+	mysqlTypeShort      = 0x02
+	mysqlTypeLong       = 0x03
+	mysqlTypeFloat      = 0x04
+	mysqlTypeDouble     = 0x05
+	mysqlTypeNull       = 0x06
+	mysqlTypeTimestamp  = 0x07
+	mysqlTypeLongLong   = 0x08
+	mysqlTypeInt24      = 0x09
+	mysqlTypeDate       = 0x0a
+	mysqlTypeTime       = 0x0b
+	mysqlTypeDatetime   = 0x0c
+	mysqlTypeYear       = 0x0d
+	mysqlTypeNewDate    = 0x0e
+	mysqlTypeVarchar    = 0x0f
+	mysqlTypeBit        = 0x10
+	mysqlTypeJSON       = 0xf5
+	mysqlTypeNewDecimal = 0xf6
+	mysqlTypeEnum       = 0xf7
+	mysqlTypeSet        = 0xf8
+	mysqlTypeTinyBlob   = 0xf9
+	mysqlTypeMediumBlob = 0xfa
+	mysqlTypeLongBlob   = 0xfb
+	mysqlTypeBlob       = 0xfc
+	mysqlTypeVarString  = 0xfd
+	mysqlTypeString     = 0xfe
+	mysqlTypeGeometry   = 0xff
+)
+
+var (
+	errBinaryValueTruncated = errors.New("binary value truncated")
+	errParamTypesUnknown    = errors.New("statement parameter types unknown")
+)
+
+// mysqlStatement is a statement prepared by COM_STMT_PREPARE.
+type mysqlStatement struct {
+	query      string
+	method     string
+	numParams  int
+	paramTypes []byte // 2 bytes per parameter, as sent by the last COM_STMT_EXECUTE
+}
+
+type mysqlStatementKey struct {
+	tuple common.HashableTCPTuple
+	id    uint32
+}
+
+// parseExecuteParams decodes the parameter values of a COM_STMT_EXECUTE
+// request, data starting after the command byte.
+func parseExecuteParams(data []byte, stmt *mysqlStatement, maxLength int) ([]string, error) {
+	// int<4> statement id, int<1> flags, int<4> iteration count
+	off := 9
+	n := stmt.numParams
+	if n == 0 {
+		return nil, nil
+	}
+
+	nullBitmapLen := (n + 7) / 8
+	if len(data) < off+nullBitmapLen+1 {
+		return nil, errBinaryValueTruncated
+	}
+	nullBitmap := data[off : off+nullBitmapLen]
+	off += nullBitmapLen
+
+	newParamsBound := data[off] == 1
+	off++
+	if newParamsBound {
+		if len(data) < off+2*n {
+			return nil, errBinaryValueTruncated
+		}
+		stmt.paramTypes = append(stmt.paramTypes[:0], data[off:off+2*n]...)
+		off += 2 * n
+	}
+	if len(stmt.paramTypes) != 2*n {
+		return nil, errParamTypesUnknown
+	}
+
+	params := make([]string, n)
+	for i := range params {
+		if nullBitmap[i/8]&(1<<uint(i%8)) != 0 {
+			params[i] = "NULL"
+			continue
+		}
+
+		typ, unsigned := stmt.paramTypes[2*i], stmt.paramTypes[2*i+1]&0x80 != 0
+		value, next, err := readBinaryValue(data, off, typ, unsigned)
+		if err != nil {
+			return params[:i], err
+		}
+		if len(value) > maxLength {
+			value = value[:maxLength]
+		}
+		params[i] = value
+		off = next
+	}
+	return params, nil
+}
+
+// readBinaryValue decodes a value of the binary protocol, as found in
+// COM_STMT_EXECUTE requests and binary result set rows.
+func readBinaryValue(data []byte, off int, typ byte, unsigned bool) (string, int, error) {
+	fixed := func(n int) ([]byte, error) {
+		if len(data) < off+n {
+			return nil, errBinaryValueTruncated
+		}
+		return data[off : off+n], nil
+	}
+
+	switch typ {
+	case mysqlTypeNull:
+		return "NULL", off, nil
+
+	case mysqlTypeTiny:
+		b, err := fixed(1)
+		if err != nil {
+			return "", 0, err
+		}
+		if unsigned {
+			return strconv.FormatUint(uint64(b[0]), 10), off + 1, nil
+		}
+		return strconv.FormatInt(int64(int8(b[0])), 10), off + 1, nil
+
+	case mysqlTypeShort, mysqlTypeYear:
+		b, err := fixed(2)
+		if err != nil {
+			return "", 0, err
+		}
+		v := binary.LittleEndian.Uint16(b)
+		if unsigned || typ == mysqlTypeYear {
+			return strconv.FormatUint(uint64(v), 10), off + 2, nil
+		}
+		return strconv.FormatInt(int64(int16(v)), 10), off + 2, nil
+
+	case mysqlTypeLong, mysqlTypeInt24:
+		b, err := fixed(4)
+		if err != nil {
+			return "", 0, err
+		}
+		v := binary.LittleEndian.Uint32(b)
+		if unsigned {
+			return strconv.FormatUint(uint64(v), 10), off + 4, nil
+		}
+		return strconv.FormatInt(int64(int32(v)), 10), off + 4, nil
+
+	case mysqlTypeLongLong:
+		b, err := fixed(8)
+		if err != nil {
+			return "", 0, err
+		}
+		v := binary.LittleEndian.Uint64(b)
+		if unsigned {
+			return strconv.FormatUint(v, 10), off + 8, nil
+		}
+		return strconv.FormatInt(int64(v), 10), off + 8, nil
+
+	case mysqlTypeFloat:
+		b, err := fixed(4)
+		if err != nil {
+			return "", 0, err
+		}
+		v := math.Float32frombits(binary.LittleEndian.Uint32(b))
+		return strconv.FormatFloat(float64(v), 'g', -1, 32), off + 4, nil
+
+	case mysqlTypeDouble:
+		b, err := fixed(8)
+		if err != nil {
+			return "", 0, err
+		}
+		v := math.Float64frombits(binary.LittleEndian.Uint64(b))
+		return strconv.FormatFloat(v, 'g', -1, 64), off + 8, nil
+
+	case mysqlTypeDate, mysqlTypeDatetime, mysqlTypeTimestamp, mysqlTypeNewDate:
+		return readBinaryDatetime(data, off, typ == mysqlTypeDate || typ == mysqlTypeNewDate)
+
+	case mysqlTypeTime:
+		return readBinaryTime(data, off)
+
+	case mysqlTypeDecimal, mysqlTypeNewDecimal, mysqlTypeVarchar, mysqlTypeBit,
+		mysqlTypeJSON, mysqlTypeEnum, mysqlTypeSet, mysqlTypeTinyBlob,
+		mysqlTypeMediumBlob, mysqlTypeLongBlob, mysqlTypeBlob,
+		mysqlTypeVarString, mysqlTypeString, mysqlTypeGeometry:
+		text, next, complete, err := readLstring(data, off)
+		if err != nil {
+			return "", 0, err
+		}
+		if !complete {
+			return "", 0, errBinaryValueTruncated
+		}
+		return string(text), next, nil
+	}
+
+	return "", 0, fmt.Errorf("unsupported binary value type %d", typ)
+}
+
+func readBinaryDatetime(data []byte, off int, dateOnly bool) (string, int, error) {
+	if len(data) < off+1 {
+		return "", 0, errBinaryValueTruncated
+	}
+	n := int(data[off])
+	off++
+	if len(data) < off+n {
+		return "", 0, errBinaryValueTruncated
+	}
+	b := data[off : off+n]
+
+	var year, month, day, hour, minute, second, micro int
+	if n >= 4 {
+		year = int(binary.LittleEndian.Uint16(b))
+		month, day = int(b[2]), int(b[3])
+	}
+	if n >= 7 {
+		hour, minute, second = int(b[4]), int(b[5]), int(b[6])
+	}
+	if n >= 11 {
+		micro = int(binary.LittleEndian.Uint32(b[7:]))
+	}
+
+	s := fmt.Sprintf("%04d-%02d-%02d", year, month, day)
+	if !dateOnly {
+		s += fmt.Sprintf(" %02d:%02d:%02d", hour, minute, second)
+		if micro > 0 {
+			s += fmt.Sprintf(".%06d", micro)
+		}
+	}
+	return s, off + n, nil
+}
+
+func readBinaryTime(data []byte, off int) (string, int, error) {
+	if len(data) < off+1 {
+		return "", 0, errBinaryValueTruncated
+	}
+	n := int(data[off])
+	off++
+	if len(data) < off+n {
+		return "", 0, errBinaryValueTruncated
+	}
+	b := data[off : off+n]
+
+	var negative bool
+	var days, hour, minute, second, micro int
+	if n >= 8 {
+		negative = b[0] == 1
+		days = int(binary.LittleEndian.Uint32(b[1:]))
+		hour, minute, second = int(b[5]), int(b[6]), int(b[7])
+	}
+	if n >= 12 {
+		micro = int(binary.LittleEndian.Uint32(b[8:]))
+	}
+
+	s := fmt.Sprintf("%02d:%02d:%02d", days*24+hour, minute, second)
+	if micro > 0 {
+		s += fmt.Sprintf(".%06d", micro)
+	}
+	if negative {
+		s = "-" + s
+	}
+	return s, off + n, nil
+}
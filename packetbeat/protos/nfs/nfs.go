@@ -18,6 +18,9 @@
 package nfs
 
 import (
+	"encoding/hex"
+	"strings"
+
 	"github.com/elastic/beats/libbeat/beat"
 	"github.com/elastic/beats/libbeat/common"
 )
@@ -26,6 +29,10 @@ type nfs struct {
 	vers  uint32
 	proc  uint32
 	event beat.Event
+
+	// file handle and path components seen in the request
+	fileHandle []byte
+	path       []string
 }
 
 func (nfs *nfs) getRequestInfo(xdr *xdr) common.MapStr {
@@ -35,6 +42,7 @@ func (nfs *nfs) getRequestInfo(xdr *xdr) common.MapStr {
 	switch nfs.vers {
 	case 3:
 		nfsInfo["opcode"] = nfs.getV3Opcode(int(nfs.proc))
+		nfs.getV3Args(xdr)
 	case 4:
 		switch nfs.proc {
 		case 0:
@@ -46,6 +54,13 @@ func (nfs *nfs) getRequestInfo(xdr *xdr) common.MapStr {
 			nfsInfo["opcode"] = nfs.findV4MainOpcode(xdr)
 		}
 	}
+
+	if len(nfs.fileHandle) > 0 {
+		nfsInfo["file_handle"] = hex.EncodeToString(nfs.fileHandle)
+	}
+	if path := strings.Join(nfs.path, "/"); path != "" {
+		nfsInfo["path"] = path
+	}
 	return nfsInfo
 }
 
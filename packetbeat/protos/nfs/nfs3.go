@@ -48,3 +48,17 @@ func (nfs *nfs) getV3Opcode(proc int) string {
 	}
 	return "ILLEGAL"
 }
+
+// getV3Args reads the file handle all v3 procedures but NULL start with and,
+// for the procedures operating on a directory entry, the entry name.
+func (nfs *nfs) getV3Args(xdr *xdr) {
+	if nfs.proc == 0 || int(nfs.proc) >= len(nfsOpnum3) {
+		return
+	}
+
+	nfs.fileHandle = xdr.getDynamicOpaque()
+	switch nfsOpnum3[nfs.proc] {
+	case "LOOKUP", "CREATE", "MKDIR", "SYM_LINK", "MKNODE", "REMOVE", "RMDIR", "RENAME":
+		nfs.path = append(nfs.path, xdr.getString())
+	}
+}
@@ -172,18 +172,23 @@ func (nfs *nfs) eatData(op int, xdr *xdr) {
 	case opGetfh:
 		// nothing to eat
 	case opLookup:
-		xdr.getDynamicOpaque()
+		nfs.path = append(nfs.path, xdr.getString())
 	case opLookupp:
 		// nothing to eat
 	case opNverify:
 		xdr.getUIntVector()
 		xdr.getDynamicOpaque()
 	case opPutfh:
-		xdr.getDynamicOpaque()
+		// a new current file handle, the lookups so far were relative to
+		// the previous one
+		nfs.fileHandle = xdr.getDynamicOpaque()
+		nfs.path = nil
 	case opPutpubfh:
 		// nothing to eat
 	case opPutrootfh:
-		// nothing to eat
+		// lookups are relative to the root of the exported file system
+		nfs.fileHandle = nil
+		nfs.path = []string{""}
 	case opReadlink:
 		// nothing to eat
 	case opRenew:
@@ -303,6 +308,9 @@ func (nfs *nfs) findV4MainOpcode(xdr *xdr) string {
 			opClone:
 
 			found = true
+			if op == opRemove {
+				nfs.path = append(nfs.path, xdr.getString())
+			}
 		default:
 			nfs.eatData(op, xdr)
 		}
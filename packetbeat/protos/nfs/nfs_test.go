@@ -0,0 +1,98 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package nfs
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// xdrBuilder encodes XDR test payloads.
+type xdrBuilder []byte
+
+func (b xdrBuilder) uint(v uint32) xdrBuilder {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+func (b xdrBuilder) opaque(v []byte) xdrBuilder {
+	b = b.uint(uint32(len(v)))
+	b = append(b, v...)
+	for len(b)%4 != 0 {
+		b = append(b, 0)
+	}
+	return b
+}
+
+func (b xdrBuilder) string(v string) xdrBuilder {
+	return b.opaque([]byte(v))
+}
+
+func TestV3RequestInfo(t *testing.T) {
+	args := xdrBuilder{}.opaque([]byte{0x01, 0x02, 0xab}).string("notes.txt")
+	xdr := makeXDR(args)
+
+	nfs := nfs{vers: 3, proc: 3}
+	info := nfs.getRequestInfo(&xdr)
+
+	assert.Equal(t, "LOOKUP", info["opcode"])
+	assert.Equal(t, "0102ab", info["file_handle"])
+	assert.Equal(t, "notes.txt", info["path"])
+}
+
+func TestV4CompoundRequestInfo(t *testing.T) {
+	args := xdrBuilder{}.
+		string("tag").
+		uint(1).
+		uint(4).
+		uint(opPutfh).opaque([]byte{0xca, 0xfe}).
+		uint(opLookup).string("home").
+		uint(opLookup).string("user").
+		uint(opRemove).string("notes.txt")
+	xdr := makeXDR(args)
+
+	nfs := nfs{vers: 4, proc: 1}
+	info := nfs.getRequestInfo(&xdr)
+
+	assert.Equal(t, "REMOVE", info["opcode"])
+	assert.Equal(t, "cafe", info["file_handle"])
+	assert.Equal(t, "home/user/notes.txt", info["path"])
+}
+
+func TestV4CompoundRequestInfoFromRoot(t *testing.T) {
+	args := xdrBuilder{}.
+		string("").
+		uint(0).
+		uint(3).
+		uint(opPutrootfh).
+		uint(opLookup).string("export").
+		uint(opGetfh)
+	xdr := makeXDR(args)
+
+	nfs := nfs{vers: 4, proc: 1}
+	info := nfs.getRequestInfo(&xdr)
+
+	assert.Equal(t, "GETFH", info["opcode"])
+	assert.Nil(t, info["file_handle"])
+	assert.Equal(t, "/export", info["path"])
+}
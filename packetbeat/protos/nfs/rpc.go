@@ -165,6 +165,11 @@ func (r *rpc) ConnectionTimeout() time.Duration {
 	return r.transactionTimeout
 }
 
+// Flush publishes the calls without a reply yet.
+func (r *rpc) Flush() {
+	r.callsSeen.RemoveAll()
+}
+
 func ensureRPCConnection(private protos.ProtocolData) *rpcConnectionData {
 	conn := getRPCConnection(private)
 	if conn == nil {
@@ -32,6 +32,7 @@ var (
 	defaultConfig = pgsqlConfig{
 		ProtocolCommon: config.ProtocolCommon{
 			TransactionTimeout: protos.DefaultTransactionExpiration,
+			MaxTransactions:    protos.DefaultMaxTransactions,
 		},
 		MaxRowLength: 1024,
 		MaxRows:      10,
@@ -19,6 +19,8 @@ package pgsql
 
 import (
 	"errors"
+	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/elastic/beats/libbeat/common"
@@ -33,6 +35,10 @@ var (
 	errFieldBufferBig    = errors.New("field count to small for field buffer size")
 )
 
+// maxPreparedStatements limits the number of named statements and portals
+// remembered per connection.
+const maxPreparedStatements = 1000
+
 func (pgsql *pgsqlPlugin) pgsqlMessageParser(s *pgsqlStream) (bool, bool) {
 	debugf("pgsqlMessageParser, off=%v", s.parseOffset)
 
@@ -75,6 +81,8 @@ func (pgsql *pgsqlPlugin) parseMessageStart(s *pgsqlStream) (bool, bool) {
 			return true, false
 		}
 
+		s.isFrontend = true
+
 		// ignore non SSLRequest commands
 		if command != sslRequest {
 			s.parseOffset += length
@@ -128,11 +136,23 @@ func (pgsql *pgsqlPlugin) parseCommand(s *pgsqlStream) (bool, bool) {
 
 	detailedf("Pgsql type %c, length=%d", typ, length)
 
+	if !pgsqlValidType(typ) {
+		detailedf("invalid frame type: '%c'", typ)
+		return false, false
+	}
+
+	// Some message types are shared by the frontend and the backend with a
+	// different meaning.
+	if pgsqlFrontendType(typ) {
+		s.isFrontend = true
+	}
+	if s.isFrontend {
+		return pgsql.parseFrontendCommand(s, typ, length)
+	}
+
 	switch typ {
-	case 'Q':
-		return pgsql.parseSimpleQuery(s, length)
-	case 'T':
-		return pgsql.parseRowDescription(s, length)
+	case 'T', 'D', 'N', 'G', 'H', 'W':
+		return pgsql.parseResponseStart(s)
 	case 'I':
 		return pgsql.parseEmptyQueryResponse(s)
 	case 'C':
@@ -141,15 +161,18 @@ func (pgsql *pgsqlPlugin) parseCommand(s *pgsqlStream) (bool, bool) {
 		return pgsql.parseReadyForQuery(s, length)
 	case 'E':
 		return pgsql.parseErrorResponse(s, length)
-	case 'P':
-		return pgsql.parseExtReq(s, length)
-	case '1':
-		return pgsql.parseExtResp(s, length)
 	default:
-		if !pgsqlValidType(typ) {
-			detailedf("invalid frame type: '%c'", typ)
-			return false, false
-		}
+		return pgsql.parseSkipMessage(s, length)
+	}
+}
+
+func (pgsql *pgsqlPlugin) parseFrontendCommand(s *pgsqlStream, typ byte, length int) (bool, bool) {
+	switch typ {
+	case 'Q':
+		return pgsql.parseSimpleQuery(s, length)
+	case 'P', 'B', 'D', 'E', 'C', 'H', 'S':
+		return pgsql.parseExtReq(s)
+	default:
 		return pgsql.parseSkipMessage(s, length)
 	}
 }
@@ -170,29 +193,23 @@ func (pgsql *pgsqlPlugin) parseSimpleQuery(s *pgsqlStream, length int) (bool, bo
 	}
 
 	m.query = query
+	m.batch = s.batch
+	s.batch++
 
 	m.toExport = true
 	detailedf("Simple Query: %s", m.query)
 	return true, true
 }
 
-func (pgsql *pgsqlPlugin) parseRowDescription(s *pgsqlStream, length int) (bool, bool) {
-	// RowDescription
+func (pgsql *pgsqlPlugin) parseResponseStart(s *pgsqlStream) (bool, bool) {
+	// RowDescription, DataRow, NoticeResponse or COPY start a response
+	// spanning several messages, completed by CommandComplete
 	m := s.message
 	m.start = s.parseOffset
 	m.isRequest = false
 	m.isOK = true
 	m.toExport = true
 
-	err := pgsqlFieldsParser(s, s.data[s.parseOffset+5:s.parseOffset+length+1])
-	if err != nil {
-		detailedf("fields parse failed with: %v", err)
-		return false, false
-	}
-	detailedf("Fields: %s", m.fields)
-
-	s.parseOffset++         //type
-	s.parseOffset += length //length
 	s.parseState = pgsqlGetDataState
 	return pgsql.parseMessageData(s)
 }
@@ -215,6 +232,8 @@ func pgsqlQueryParser(query string) []string {
 func (pgsql *pgsqlPlugin) parseEmptyQueryResponse(s *pgsqlStream) (bool, bool) {
 	// EmptyQueryResponse, appears as a response for empty queries
 	// substitutes CommandComplete
+	// PortalSuspended substitutes CommandComplete if an Execute reached its
+	// row limit
 
 	m := s.message
 
@@ -278,7 +297,7 @@ func (pgsql *pgsqlPlugin) parseErrorResponse(s *pgsqlStream, length int) (bool,
 	m.toExport = true
 
 	s.parseOffset++ //type
-	pgsqlErrorParser(s, s.data[s.parseOffset+4:s.parseOffset+length])
+	m.setError(pgsqlNoticeParser(s.data[s.parseOffset+4 : s.parseOffset+length]))
 
 	s.parseOffset += length //length
 	m.end = s.parseOffset
@@ -287,55 +306,26 @@ func (pgsql *pgsqlPlugin) parseErrorResponse(s *pgsqlStream, length int) (bool,
 	return true, true
 }
 
-func (pgsql *pgsqlPlugin) parseExtReq(s *pgsqlStream, length int) (bool, bool) {
-	// Ready for query -> Parse for an extended query request
-	detailedf("Parse")
+func (pgsql *pgsqlPlugin) parseExtReq(s *pgsqlStream) (bool, bool) {
+	// Parse, Bind, Describe, Execute or Close start an extended query
+	// request, completed by Sync
+	detailedf("Extended query request")
 
 	m := s.message
 	m.start = s.parseOffset
 	m.isRequest = true
+	m.isExtended = true
 
-	s.parseOffset++ //type
-	s.parseOffset += length
-	m.end = s.parseOffset
-	m.size = uint64(m.end - m.start)
-	m.toExport = true
-
-	query, err := common.ReadString(s.data[m.start+6:])
-	if err != nil {
-		detailedf("Invalid extended query request")
-		return false, false
+	if s.statements == nil {
+		s.statements = map[string]string{}
+		s.portals = map[string]string{}
 	}
-	m.query = query
-	detailedf("Parse in an extended query request: %s", m.query)
 
-	// Ignore SET statement
-	if strings.HasPrefix(m.query, "SET ") {
-		m.toExport = false
-	}
 	s.parseState = pgsqlExtendedQueryState
 	return pgsql.parseMessageExtendedQuery(s)
 }
 
-func (pgsql *pgsqlPlugin) parseExtResp(s *pgsqlStream, length int) (bool, bool) {
-	// Sync -> Parse completion for an extended query response
-	detailedf("ParseCompletion")
-
-	m := s.message
-	m.start = s.parseOffset
-	m.isRequest = false
-	m.isOK = true
-	m.toExport = true
-
-	s.parseOffset++ //type
-	s.parseOffset += length
-	detailedf("Parse completion in an extended query response")
-	s.parseState = pgsqlGetDataState
-	return pgsql.parseMessageData(s)
-}
-
 func (pgsql *pgsqlPlugin) parseSkipMessage(s *pgsqlStream, length int) (bool, bool) {
-	// TODO: add info from NoticeResponse in case there are warning messages for a query
 	// ignore command
 	s.parseOffset++ //type
 	s.parseOffset += length
@@ -363,6 +353,7 @@ func pgsqlFieldsParser(s *pgsqlStream, buf []byte) error {
 
 	fields := []string{}
 	fieldsFormat := []byte{}
+	m.numberOfFields = 0
 
 	for i := 0; i < fieldCount; i++ {
 		if len(buf) <= off {
@@ -417,8 +408,18 @@ func pgsqlFieldsParser(s *pgsqlStream, buf []byte) error {
 	return nil
 }
 
-func pgsqlErrorParser(s *pgsqlStream, buf []byte) {
-	m := s.message
+// pgsqlNotice holds the fields of an ErrorResponse or NoticeResponse.
+type pgsqlNotice struct {
+	severity string
+	code     string
+	message  string
+	detail   string
+	hint     string
+}
+
+func pgsqlNoticeParser(buf []byte) pgsqlNotice {
+	var n pgsqlNotice
+	var localizedSeverity string
 	off := 0
 	for off < len(buf) {
 		// read field type(byte1)
@@ -437,14 +438,39 @@ func pgsqlErrorParser(s *pgsqlStream, buf []byte) {
 
 		switch typ {
 		case 'M':
-			m.errorInfo = val
+			n.message = val
 		case 'C':
-			m.errorCode = val
+			n.code = val
 		case 'S':
-			m.errorSeverity = val
+			localizedSeverity = val
+		case 'V':
+			// not localized, sent since PostgreSQL 9.6
+			n.severity = val
+		case 'D':
+			n.detail = val
+		case 'H':
+			n.hint = val
 		}
 	}
-	detailedf("%s %s %s", m.errorSeverity, m.errorCode, m.errorInfo)
+	if n.severity == "" {
+		n.severity = localizedSeverity
+	}
+	detailedf("%s %s %s", n.severity, n.code, n.message)
+	return n
+}
+
+func (n pgsqlNotice) String() string {
+	return fmt.Sprintf("%s %s: %s", n.severity, n.code, n.message)
+}
+
+func (m *pgsqlMessage) setError(n pgsqlNotice) {
+	m.isError = true
+	m.isOK = false
+	m.errorSeverity = n.severity
+	m.errorCode = n.code
+	m.errorInfo = n.message
+	m.errorDetail = n.detail
+	m.errorHint = n.hint
 }
 
 func (pgsql *pgsqlPlugin) parseMessageData(s *pgsqlStream) (bool, bool) {
@@ -456,9 +482,15 @@ func (pgsql *pgsqlPlugin) parseMessageData(s *pgsqlStream) (bool, bool) {
 	// CommandComplete
 	// ReadyForQuery
 
+	// A COPY response contains:
+	// CopyInResponse, CopyOutResponse or CopyBothResponse
+	// zero or more CopyData
+	// CopyDone
+	// CommandComplete
+
 	m := s.message
 
-	for len(s.data[s.parseOffset:]) > 5 {
+	for len(s.data[s.parseOffset:]) >= 5 {
 		// read type
 		typ := byte(s.data[s.parseOffset])
 
@@ -474,28 +506,63 @@ func (pgsql *pgsqlPlugin) parseMessageData(s *pgsqlStream) (bool, bool) {
 			detailedf("Wait for more data")
 			return true, false
 		}
+		buf := s.data[s.parseOffset+5 : s.parseOffset+length+1]
 
 		switch typ {
-		case 'D':
-			err := pgsql.parseDataRow(s, s.data[s.parseOffset+5:s.parseOffset+length+1])
+		case 'T':
+			// RowDescription
+			err := pgsqlFieldsParser(s, buf)
 			if err != nil {
+				detailedf("fields parse failed with: %v", err)
 				return false, false
 			}
-			s.parseOffset++
-			s.parseOffset += length
-		case 'C':
-			// CommandComplete
-
-			// skip type
-			s.parseOffset++
+			detailedf("Fields: %s", m.fields)
 
-			name, err := pgsqlString(s.data[s.parseOffset+4:], length-4)
+			// the rows of a described portal can follow in a later response
+			s.fields, s.fieldsFormat = m.fields, m.fieldsFormat
+		case 'D':
+			if m.fieldsFormat == nil {
+				m.fields, m.fieldsFormat = s.fields, s.fieldsFormat
+				m.numberOfFields = len(m.fields)
+			}
+			err := pgsql.parseDataRow(s, buf)
 			if err != nil {
-				detailedf("pgsql string invalid")
 				return false, false
 			}
+		case 'N':
+			// NoticeResponse
+			m.notices = append(m.notices, pgsqlNoticeParser(buf).String())
+		case 'G', 'H', 'W':
+			// CopyInResponse, CopyOutResponse, CopyBothResponse
+			if len(buf) < 3 {
+				return false, false
+			}
+			m.copyDirection = map[byte]string{'G': "in", 'H': "out", 'W': "both"}[typ]
+			m.copyFormat = buf[0]
+			m.numberOfFields = readCount(buf[1:])
+		case 'd':
+			// CopyData
+			if m.copyDirection == "out" {
+				pgsql.parseCopyData(s, buf)
+			}
+		case 'C', 'I', 's':
+			// CommandComplete, EmptyQueryResponse, PortalSuspended
+			if typ == 'C' {
+				tag, err := pgsqlString(buf, length-4)
+				if err != nil {
+					detailedf("pgsql string invalid")
+					return false, false
+				}
+				detailedf("CommandComplete length=%d, tag=%s", length, tag)
+
+				if m.copyDirection != "" && strings.HasPrefix(tag, "COPY ") {
+					if n, err := strconv.Atoi(tag[5:]); err == nil {
+						m.numberOfRows = n
+					}
+				}
+			}
 
-			detailedf("CommandComplete length=%d, tag=%s", length, name)
+			s.parseOffset++
 			s.parseOffset += length
 			m.end = s.parseOffset
 			m.size = uint64(m.end - m.start)
@@ -504,26 +571,68 @@ func (pgsql *pgsqlPlugin) parseMessageData(s *pgsqlStream) (bool, bool) {
 			detailedf("Rows: %s", m.rows)
 
 			return true, true
-		case '2':
-			// Parse completion -> Bind completion for an extended query response
+		case 'E':
+			// ErrorResponse
+			m.setError(pgsqlNoticeParser(buf))
 
-			// skip type
 			s.parseOffset++
 			s.parseOffset += length
+			m.end = s.parseOffset
+			m.size = uint64(m.end - m.start)
 			s.parseState = pgsqlStartState
-		case 'T':
-			return pgsql.parseRowDescription(s, length)
+			return true, true
+		case 'Z':
+			// ReadyForQuery without CommandComplete, the portal was only
+			// described or the COPY was aborted
+			s.parseOffset++
+			s.parseOffset += length
+			m.end = s.parseOffset
+			m.size = uint64(m.end - m.start)
+			m.toExport = false
+			s.parseState = pgsqlStartState
+			return true, true
+		case '1', '2', '3', 't', 'n', 'c', 'S', 'K', 'A':
+			// ParseComplete, BindComplete, CloseComplete, ParameterDescription,
+			// NoData, CopyDone, ParameterStatus, BackendKeyData and
+			// NotificationResponse don't carry information about the query
 		default:
 			// shouldn't happen -> return error
 			logp.Warn("Pgsql parser expected data message, but received command of type %v", typ)
 			s.parseState = pgsqlStartState
 			return false, false
 		}
+
+		s.parseOffset++ // type
+		s.parseOffset += length
 	}
 
 	return true, false
 }
 
+// parseCopyData adds a row of a COPY TO STDOUT in text format to the
+// response.
+func (pgsql *pgsqlPlugin) parseCopyData(s *pgsqlStream, buf []byte) {
+	m := s.message
+	m.numberOfRows++
+	if m.copyFormat != 0 || len(m.rows) >= pgsql.maxStoreRows {
+		return
+	}
+
+	row := []string{}
+	rowLength := 0
+	for _, value := range strings.Split(strings.TrimSuffix(string(buf), "\n"), "\t") {
+		if rowLength >= pgsql.maxRowLength {
+			break
+		}
+		if rowLength+len(value) > pgsql.maxRowLength {
+			value = value[:pgsql.maxRowLength-rowLength]
+		}
+		row = append(row, value)
+		rowLength += len(value)
+	}
+	m.rows = append(m.rows, row)
+}
+
 func (pgsql *pgsqlPlugin) parseDataRow(s *pgsqlStream, buf []byte) error {
 	m := s.message
 
@@ -552,7 +661,7 @@ func (pgsql *pgsqlPlugin) parseDataRow(s *pgsqlStream, buf []byte) error {
 
 		// read column value (byten)
 		var columnValue []byte
-		if m.fieldsFormat[i] == 0 {
+		if i < len(m.fieldsFormat) && m.fieldsFormat[i] == 0 {
 			// field value in text format
 			if columnLength > 0 {
 				columnValue = buf[off : off+columnLength]
@@ -592,6 +701,8 @@ func (pgsql *pgsqlPlugin) parseMessageExtendedQuery(s *pgsqlStream) (bool, bool)
 	// Describe
 	// Execute
 	// Sync
+	// The statements and portals are named, so that they can be executed
+	// several times, until they are closed.
 
 	m := s.message
 
@@ -611,37 +722,88 @@ func (pgsql *pgsqlPlugin) parseMessageExtendedQuery(s *pgsqlStream) (bool, bool)
 			detailedf("Wait for more data")
 			return true, false
 		}
+		buf := s.data[s.parseOffset+5 : s.parseOffset+length+1]
+
+		s.parseOffset++ // type
+		s.parseOffset += length
 
 		switch typ {
+		case 'P':
+			// Parse: statement name, query, parameter types
+			name, err := common.ReadString(buf)
+			if err != nil {
+				detailedf("Invalid Parse message")
+				return false, false
+			}
+			query, err := common.ReadString(buf[len(name)+1:])
+			if err != nil {
+				detailedf("Invalid Parse message")
+				return false, false
+			}
+			detailedf("Parse statement '%s': %s", name, query)
+			if name == "" || len(s.statements) < maxPreparedStatements {
+				s.statements[name] = query
+			}
 		case 'B':
-			// Parse -> Bind
-
-			// skip type
-			s.parseOffset++
-			s.parseOffset += length
-			//TODO: pgsql.parseBind(s)
-		case 'D':
-			// Bind -> Describe
-
-			// skip type
-			s.parseOffset++
-			s.parseOffset += length
-			//TODO: pgsql.parseDescribe(s)
+			// Bind: portal name, statement name, parameters
+			portal, err := common.ReadString(buf)
+			if err != nil {
+				detailedf("Invalid Bind message")
+				return false, false
+			}
+			name, err := common.ReadString(buf[len(portal)+1:])
+			if err != nil {
+				detailedf("Invalid Bind message")
+				return false, false
+			}
+			query, found := s.statements[name]
+			if !found {
+				detailedf("Bind of unknown statement '%s'", name)
+				delete(s.portals, portal)
+			} else if portal == "" || len(s.portals) < maxPreparedStatements {
+				s.portals[portal] = query
+			}
 		case 'E':
-			// Bind(or Describe) -> Execute
-
-			// skip type
-			s.parseOffset++
-			s.parseOffset += length
-			//TODO: pgsql.parseExecute(s)
-		case 'S':
-			// Execute -> Sync
-
-			// skip type
-			s.parseOffset++
-			s.parseOffset += length
+			// Execute: portal name, maximum number of rows
+			portal, err := common.ReadString(buf)
+			if err != nil {
+				detailedf("Invalid Execute message")
+				return false, false
+			}
+			query, found := s.portals[portal]
+			if !found {
+				m.notes = append(m.notes, fmt.Sprintf("Execute of unknown portal '%s'", portal))
+			}
+			m.queries = append(m.queries, strings.TrimSpace(query))
+		case 'C':
+			// Close: 'S' for a statement or 'P' for a portal, name
+			if len(buf) < 1 {
+				return false, false
+			}
+			name, err := common.ReadString(buf[1:])
+			if err != nil {
+				detailedf("Invalid Close message")
+				return false, false
+			}
+			if buf[0] == 'S' {
+				delete(s.statements, name)
+			} else {
+				delete(s.portals, name)
+			}
+		case 'D', 'd', 'c', 'f':
+			// Describe, CopyData, CopyDone, CopyFail
+		case 'H', 'S':
+			// Flush or Sync (which ends the implicit transaction)
+			if typ == 'H' && len(m.queries) == 0 {
+				break
+			}
 			m.end = s.parseOffset
 			m.size = uint64(m.end - m.start)
+			m.toExport = len(m.queries) > 0
+			m.batch = s.batch
+			if typ == 'S' {
+				s.batch++
+			}
 			s.parseState = pgsqlStartState
 
 			return true, true
@@ -706,6 +868,17 @@ func pgsqlString(b []byte, sz int) (string, error) {
 	return string(b[:sz-1]), nil
 }
 
+// pgsqlFrontendType returns true for message types only sent by the
+// frontend.
+func pgsqlFrontendType(t byte) bool {
+	switch t {
+	case 'B', 'F', 'P', 'Q', 'X', 'p':
+		return true
+	default:
+		return false
+	}
+}
+
 func pgsqlValidType(t byte) bool {
 	switch t {
 	case '1', '2', '3',
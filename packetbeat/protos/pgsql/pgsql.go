@@ -43,6 +43,7 @@ type pgsqlPlugin struct {
 
 	transactions       *common.Cache
 	transactionTimeout time.Duration
+	maxTransactions    int
 
 	results protos.Reporter
 
@@ -60,7 +61,10 @@ type pgsqlMessage struct {
 
 	ts             time.Time
 	isRequest      bool
+	isExtended     bool
 	query          string
+	queries        []string // executed through the extended query protocol
+	batch          int
 	size           uint64
 	fields         []string
 	fieldsFormat   []byte
@@ -72,6 +76,11 @@ type pgsqlMessage struct {
 	errorInfo      string
 	errorCode      string
 	errorSeverity  string
+	errorDetail    string
+	errorHint      string
+	notices        []string
+	copyDirection  string
+	copyFormat     byte
 	notes          []string
 
 	direction    uint8
@@ -91,6 +100,12 @@ type pgsqlTransaction struct {
 	bytesIn      uint64
 	notes        []string
 
+	// batch identifies the queries sent up to the same Sync or simple
+	// query, skipped by the server after an error
+	batch    int
+	ignored  bool
+	timedOut bool
+
 	pgsql common.MapStr
 
 	requestRaw  string
@@ -105,6 +120,16 @@ type pgsqlStream struct {
 	seenSSLRequest    bool
 	expectSSLResponse bool
 
+	// frontend state
+	isFrontend bool
+	batch      int
+	statements map[string]string // query by statement name
+	portals    map[string]string // query by portal name
+
+	// backend state, last RowDescription received
+	fields       []string
+	fieldsFormat []byte
+
 	message *pgsqlMessage
 }
 
@@ -130,7 +155,9 @@ var (
 )
 
 var (
+	unmatchedRequests  = monitoring.NewInt(nil, "pgsql.unmatched_requests")
 	unmatchedResponses = monitoring.NewInt(nil, "pgsql.unmatched_responses")
+	droppedRequests    = monitoring.NewInt(nil, "pgsql.dropped_requests")
 )
 
 func init() {
@@ -159,9 +186,17 @@ func New(
 func (pgsql *pgsqlPlugin) init(results protos.Reporter, config *pgsqlConfig) error {
 	pgsql.setFromConfig(config)
 
-	pgsql.transactions = common.NewCache(
+	pgsql.transactions = common.NewCacheWithRemovalListener(
 		pgsql.transactionTimeout,
-		protos.DefaultTransactionHashSize)
+		protos.DefaultTransactionHashSize,
+		func(k common.Key, v common.Value) {
+			transList, ok := v.([]*pgsqlTransaction)
+			if !ok {
+				logp.Err("Expired value is not a []*pgsqlTransaction.")
+				return
+			}
+			pgsql.expireTransactions(transList)
+		})
 	pgsql.transactions.StartJanitor(pgsql.transactionTimeout)
 	pgsql.handlePgsql = handlePgsql
 	pgsql.results = results
@@ -176,6 +211,7 @@ func (pgsql *pgsqlPlugin) setFromConfig(config *pgsqlConfig) {
 	pgsql.sendRequest = config.SendRequest
 	pgsql.sendResponse = config.SendResponse
 	pgsql.transactionTimeout = config.TransactionTimeout
+	pgsql.maxTransactions = config.MaxTransactions
 }
 
 func (pgsql *pgsqlPlugin) getTransaction(k common.HashableTCPTuple) []*pgsqlTransaction {
@@ -217,6 +253,11 @@ func (pgsql *pgsqlPlugin) ConnectionTimeout() time.Duration {
 	return pgsql.transactionTimeout
 }
 
+// Flush publishes the transactions in progress as expired.
+func (pgsql *pgsqlPlugin) Flush() {
+	pgsql.transactions.RemoveAll()
+}
+
 func (pgsql *pgsqlPlugin) Parse(pkt *protos.Packet, tcptuple *common.TCPTuple,
 	dir uint8, private protos.ProtocolData) protos.ProtocolData {
 
@@ -306,7 +347,7 @@ func messageHasEnoughData(msg *pgsqlMessage) bool {
 		return false
 	}
 	if msg.isRequest {
-		return len(msg.query) > 0
+		return len(msg.query) > 0 || len(msg.queries) > 0
 	}
 	return len(msg.rows) > 0
 }
@@ -375,16 +416,29 @@ func (pgsql *pgsqlPlugin) receivedPgsqlRequest(msg *pgsqlMessage) {
 
 	// parse the query, as it might contain a list of pgsql command
 	// separated by ';'
-	queries := pgsqlQueryParser(msg.query)
+	queries := msg.queries
+	if !msg.isExtended {
+		queries = pgsqlQueryParser(msg.query)
+	}
 
 	logp.Debug("pgsqldetailed", "Queries (%d) :%s", len(queries), queries)
 
 	transList := pgsql.getTransaction(tuple.Hashable())
 	if transList == nil {
+		if !protos.CanAddTransaction(pgsql.transactions, pgsql.maxTransactions) {
+			debugf("Too many transactions in flight. Dropping request: %s", msg.query)
+			droppedRequests.Add(1)
+			return
+		}
 		transList = []*pgsqlTransaction{}
 	}
 
 	for _, query := range queries {
+		if pgsql.maxTransactions > 0 && len(transList) >= pgsql.maxTransactions {
+			debugf("Too many transactions in flight. Dropping query: %s", query)
+			droppedRequests.Add(1)
+			break
+		}
 
 		trans := &pgsqlTransaction{tuple: tuple}
 
@@ -399,6 +453,15 @@ func (pgsql *pgsqlPlugin) receivedPgsqlRequest(msg *pgsqlMessage) {
 		trans.query = query
 		trans.method = getQueryMethod(query)
 		trans.bytesIn = msg.size
+		trans.batch = msg.batch
+
+		if msg.isExtended {
+			if query == "" {
+				trans.method = "EXECUTE"
+			}
+			// ignore the SET statements of client libraries
+			trans.ignored = strings.HasPrefix(query, "SET ")
+		}
 
 		trans.notes = msg.notes
 
@@ -428,6 +491,15 @@ func (pgsql *pgsqlPlugin) receivedPgsqlResponse(msg *pgsqlMessage) {
 		return
 	}
 
+	if msg.isError {
+		// the server skips the remaining queries of the batch
+		pgsql.removeBatch(tuple, trans.batch)
+	}
+
+	if trans.ignored {
+		return
+	}
+
 	trans.pgsql.Update(common.MapStr{
 		"iserror":        msg.isError,
 		"num_rows":       msg.numberOfRows,
@@ -436,6 +508,18 @@ func (pgsql *pgsqlPlugin) receivedPgsqlResponse(msg *pgsqlMessage) {
 		"error_message":  msg.errorInfo,
 		"error_severity": msg.errorSeverity,
 	})
+	if msg.errorDetail != "" {
+		trans.pgsql["error_detail"] = msg.errorDetail
+	}
+	if msg.errorHint != "" {
+		trans.pgsql["error_hint"] = msg.errorHint
+	}
+	if len(msg.notices) > 0 {
+		trans.pgsql["notices"] = msg.notices
+	}
+	if msg.copyDirection != "" {
+		trans.pgsql["copy_direction"] = msg.copyDirection
+	}
 	trans.bytesOut = msg.size
 
 	trans.responseTime = int32(msg.ts.Sub(trans.ts).Nanoseconds() / 1e6) // resp_time in milliseconds
@@ -448,6 +532,20 @@ func (pgsql *pgsqlPlugin) receivedPgsqlResponse(msg *pgsqlMessage) {
 	debugf("Postgres transaction completed: %s\n%s", trans.pgsql, trans.responseRaw)
 }
 
+// expireTransactions publishes the transactions of a connection whose
+// responses were not received within the transaction timeout.
+func (pgsql *pgsqlPlugin) expireTransactions(transList []*pgsqlTransaction) {
+	for _, trans := range transList {
+		if trans.ignored {
+			continue
+		}
+		debugf("Transaction timed out: %s", trans.query)
+		unmatchedRequests.Add(1)
+		trans.timedOut = true
+		pgsql.publishTransaction(trans)
+	}
+}
+
 func (pgsql *pgsqlPlugin) publishTransaction(t *pgsqlTransaction) {
 	if pgsql.results == nil {
 		return
@@ -456,9 +554,12 @@ func (pgsql *pgsqlPlugin) publishTransaction(t *pgsqlTransaction) {
 	fields := common.MapStr{}
 
 	fields["type"] = "pgsql"
-	if t.pgsql["iserror"].(bool) {
+	switch {
+	case t.timedOut:
+		fields["status"] = common.TIMEOUT_STATUS
+	case t.pgsql["iserror"].(bool):
 		fields["status"] = common.ERROR_STATUS
-	} else {
+	default:
 		fields["status"] = common.OK_STATUS
 	}
 	fields["responsetime"] = t.responseTime
@@ -500,3 +601,14 @@ func (pgsql *pgsqlPlugin) removeTransaction(transList []*pgsqlTransaction,
 
 	return trans
 }
+
+// removeBatch drops the transactions of a batch the server will not respond
+// to.
+func (pgsql *pgsqlPlugin) removeBatch(tuple common.TCPTuple, batch int) {
+	transList := pgsql.getTransaction(tuple.Hashable())
+	for len(transList) > 0 && transList[0].batch == batch {
+		debugf("Dropping query skipped after an error: %s", transList[0].query)
+		pgsql.removeTransaction(transList, tuple, 0)
+		transList = pgsql.getTransaction(tuple.Hashable())
+	}
+}
@@ -22,6 +22,7 @@ package pgsql
 import (
 	"encoding/hex"
 	"net"
+	"strings"
 	"testing"
 	"time"
 
@@ -383,3 +384,224 @@ func Test_gap_in_response(t *testing.T) {
 	assert.NotNil(t, trans)
 	assert.Equal(t, trans["notes"], []string{"Packet loss while capturing the response"})
 }
+
+func pgsqlMsg(typ byte, body ...string) []byte {
+	b := strings.Join(body, "")
+	n := len(b) + 4
+	return append([]byte{typ, byte(n >> 24), byte(n >> 16), byte(n >> 8), byte(n)}, b...)
+}
+
+func pgsqlMsgs(msgs ...[]byte) []byte {
+	var b []byte
+	for _, m := range msgs {
+		b = append(b, m...)
+	}
+	return b
+}
+
+type pgsqlExchange struct {
+	dir  uint8
+	data []byte
+}
+
+func parsePgsqlExchanges(pgsql *pgsqlPlugin, exchanges []pgsqlExchange) {
+	tcptuple := testTCPTuple()
+	var private protos.ProtocolData
+	for _, e := range exchanges {
+		private = pgsql.Parse(&protos.Packet{Payload: e.data}, tcptuple, e.dir, private)
+	}
+}
+
+// Test an extended query, from tests/pcaps/pgsql_extended_query.pcap
+func TestParsePgsql_extendedQuery(t *testing.T) {
+	logp.TestingSetup(logp.WithSelectors("pgsql", "pgsqldetailed"))
+
+	store := &eventStore{}
+	pgsql := pgsqlModForTests(store)
+
+	var exchanges []pgsqlExchange
+	for _, e := range []struct {
+		dir  uint8
+		data string
+	}{
+		// StartupMessage
+		{0, "00000075000300007573657200706f73746772657300646174616261736500706f73746772657300636c69656e745f656e636f64696e67005554463800446174655374796c650049534f0054696d655a6f6e6500417369612f546f6b796f0065787472615f666c6f61745f64696769747300320000"},
+		// AuthenticationOk, ParameterStatus, BackendKeyData, ReadyForQuery
+		{1, "52000000080000000053000000166170706c69636174696f6e5f6e616d6500005300000019636c69656e745f656e636f64696e670055544638005300000017446174655374796c650049534f2c204d4459005300000019696e74656765725f6461746574696d6573006f6e00530000001b496e74657276616c5374796c6500706f73746772657300530000001469735f737570657275736572006f6e0053000000197365727665725f656e636f64696e6700555446380053000000197365727665725f76657273696f6e00392e342e3500530000002373657373696f6e5f617574686f72697a6174696f6e00706f7374677265730053000000237374616e646172645f636f6e666f726d696e675f737472696e6773006f6e00530000001854696d655a6f6e6500417369612f546f6b796f004b0000000c00000c4d1b7458475a0000000549"},
+		// SET extra_float_digits = 3
+		{0, "5000000022005345542065787472615f666c6f61745f646967697473203d2033000000420000000c0000000000000000450000000900000000015300000004"},
+		{1, "310000000432000000044300000008534554005a0000000549"},
+		// SELECT * from test where id = $1
+		{0, "500000002c0053454c454354202a2066726f6d2074657374207768657265206964203d20243100000100000017420000001600000001000100010000000400000001000044000000065000450000000900000000005300000004"},
+		{1, "3100000004320000000454000000320002696400000040010001000000170004ffffffff00006e616d650000004001000200000412ffff000000180000440000002300020000000131000000143232322020202020202020202020202020202020430000000d53454c4543542031005a0000000549"},
+	} {
+		data, err := hex.DecodeString(e.data)
+		assert.NoError(t, err)
+		exchanges = append(exchanges, pgsqlExchange{e.dir, data})
+	}
+	parsePgsqlExchanges(pgsql, exchanges)
+
+	trans := expectTransaction(t, store)
+	if assert.NotNil(t, trans) {
+		assert.Equal(t, "SELECT", trans["method"])
+		assert.Equal(t, "SELECT * from test where id = $1", trans["query"])
+		assert.Equal(t, uint64(90), trans["bytes_in"])
+		assert.Equal(t, uint64(101), trans["bytes_out"])
+		assert.Equal(t, 1, trans["pgsql"].(common.MapStr)["num_rows"])
+	}
+	assert.True(t, store.empty())
+}
+
+// Test that named statements and portals are mapped back to their query, and
+// that the queries skipped after an error don't get a response.
+func TestParsePgsql_namedStatements(t *testing.T) {
+	logp.TestingSetup(logp.WithSelectors("pgsql", "pgsqldetailed"))
+
+	store := &eventStore{}
+	pgsql := pgsqlModForTests(store)
+
+	const z = "\x00"
+	execute := func(portal, stmt string) []byte {
+		return pgsqlMsgs(
+			pgsqlMsg('B', portal, z, stmt, z, "\x00\x00\x00\x00\x00\x00"),
+			pgsqlMsg('E', portal, z, "\x00\x00\x00\x00"))
+	}
+	sync := pgsqlMsg('S')
+	ready := pgsqlMsg('Z', "I")
+	selectOne := pgsqlMsgs(pgsqlMsg('2'), pgsqlMsg('C', "SELECT 1", z))
+
+	parsePgsqlExchanges(pgsql, []pgsqlExchange{
+		// prepare, then execute the statement twice
+		{0, pgsqlMsgs(pgsqlMsg('P', "s1", z, "SELECT name FROM users WHERE id = $1", z, "\x00\x00"), sync)},
+		{1, pgsqlMsgs(pgsqlMsg('1'), ready)},
+		{0, pgsqlMsgs(execute("p1", "s1"), execute("", "s1"), sync)},
+		{1, pgsqlMsgs(selectOne, selectOne, ready)},
+
+		// the second query is skipped after the error
+		{0, pgsqlMsgs(
+			pgsqlMsg('P', "", z, "SELECT 1/0", z, "\x00\x00"), execute("", ""),
+			pgsqlMsg('P', "", z, "SELECT 2", z, "\x00\x00"), execute("", ""),
+			sync)},
+		{1, pgsqlMsgs(
+			pgsqlMsg('1'), pgsqlMsg('2'),
+			pgsqlMsg('E', "SERROR", z, "VERROR", z, "C22012", z, "Mdivision by zero", z, z),
+			ready)},
+
+		// close the statement
+		{0, pgsqlMsgs(pgsqlMsg('C', "S", "s1", z), sync)},
+		{1, pgsqlMsgs(pgsqlMsg('3'), ready)},
+		{0, pgsqlMsgs(execute("", "s1"), sync)},
+		{1, pgsqlMsgs(selectOne, ready)},
+	})
+
+	for i := 0; i < 2; i++ {
+		trans := expectTransaction(t, store)
+		if assert.NotNil(t, trans) {
+			assert.Equal(t, "SELECT", trans["method"])
+			assert.Equal(t, "SELECT name FROM users WHERE id = $1", trans["query"])
+			assert.Equal(t, common.OK_STATUS, trans["status"])
+		}
+	}
+
+	trans := expectTransaction(t, store)
+	if assert.NotNil(t, trans) {
+		assert.Equal(t, "SELECT 1/0", trans["query"])
+		assert.Equal(t, common.ERROR_STATUS, trans["status"])
+		m := trans["pgsql"].(common.MapStr)
+		assert.Equal(t, "22012", m["error_code"])
+		assert.Equal(t, "division by zero", m["error_message"])
+	}
+
+	trans = expectTransaction(t, store)
+	if assert.NotNil(t, trans) {
+		assert.Equal(t, "EXECUTE", trans["method"])
+		assert.Equal(t, []string{"Execute of unknown portal ''"}, trans["notes"])
+		assert.Equal(t, common.OK_STATUS, trans["status"])
+	}
+	assert.True(t, store.empty())
+}
+
+// Test decoding COPY and notice responses.
+func TestParsePgsql_copyAndNotice(t *testing.T) {
+	logp.TestingSetup(logp.WithSelectors("pgsql", "pgsqldetailed"))
+
+	store := &eventStore{}
+	pgsql := pgsqlModForTests(store)
+	pgsql.sendResponse = true
+
+	const z = "\x00"
+	ready := pgsqlMsg('Z', "I")
+
+	parsePgsqlExchanges(pgsql, []pgsqlExchange{
+		{0, pgsqlMsg('Q', "COPY users TO STDOUT", z)},
+		{1, pgsqlMsgs(
+			pgsqlMsg('H', "\x00\x00\x02\x00\x00\x00\x00"),
+			pgsqlMsg('d', "1\talice\n"),
+			pgsqlMsg('d', "2\tbob\n"),
+			pgsqlMsg('c'),
+			pgsqlMsg('C', "COPY 2", z),
+			ready)},
+		{0, pgsqlMsg('Q', "COPY users FROM STDIN", z)},
+		{1, pgsqlMsg('G', "\x00\x00\x02\x00\x00\x00\x00")},
+		{0, pgsqlMsgs(pgsqlMsg('d', "3\tcarol\n"), pgsqlMsg('c'))},
+		{1, pgsqlMsgs(pgsqlMsg('C', "COPY 1", z), ready)},
+		{0, pgsqlMsg('Q', "DROP TABLE IF EXISTS foo", z)},
+		{1, pgsqlMsgs(
+			pgsqlMsg('N', "SNOTICE", z, "VNOTICE", z, "C00000", z, "Mtable \"foo\" does not exist, skipping", z, z),
+			pgsqlMsg('C', "DROP TABLE", z),
+			ready)},
+	})
+
+	trans := expectTransaction(t, store)
+	if assert.NotNil(t, trans) {
+		m := trans["pgsql"].(common.MapStr)
+		assert.Equal(t, "out", m["copy_direction"])
+		assert.Equal(t, 2, m["num_rows"])
+		assert.Equal(t, "1,alice\n2,bob\n", trans["response"])
+	}
+
+	trans = expectTransaction(t, store)
+	if assert.NotNil(t, trans) {
+		m := trans["pgsql"].(common.MapStr)
+		assert.Equal(t, "in", m["copy_direction"])
+		assert.Equal(t, 1, m["num_rows"])
+	}
+
+	trans = expectTransaction(t, store)
+	if assert.NotNil(t, trans) {
+		assert.Equal(t, "DROP", trans["method"])
+		assert.Equal(t, common.OK_STATUS, trans["status"])
+		assert.Equal(t, []string{`NOTICE 00000: table "foo" does not exist, skipping`},
+			trans["pgsql"].(common.MapStr)["notices"])
+	}
+	assert.True(t, store.empty())
+}
+
+func TestParsePgsql_transactionTimeout(t *testing.T) {
+	logp.TestingSetup(logp.WithSelectors("pgsql", "pgsqldetailed"))
+
+	store := &eventStore{}
+	pgsql := pgsqlModForTests(store)
+	pgsql.maxTransactions = 2
+
+	// SELECT 1; SELECT 2; SELECT 3
+	query, err := hex.DecodeString("510000002153454c45435420313b2053454c45435420323b2053454c454354203300")
+	assert.NoError(t, err)
+	tcptuple := testTCPTuple()
+	pgsql.Parse(&protos.Packet{Payload: query}, tcptuple, 0, nil)
+
+	// expire the pending transactions, the last query is over the limit
+	key := tcptuple.Hashable()
+	pgsql.transactions.PutWithTimeout(key, pgsql.getTransaction(key), time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	pgsql.transactions.CleanUp()
+
+	for _, query := range []string{"SELECT 1", "SELECT 2"} {
+		trans := expectTransaction(t, store)
+		if assert.NotNil(t, trans) {
+			assert.Equal(t, common.TIMEOUT_STATUS, trans["status"])
+			assert.Equal(t, query, trans["query"])
+		}
+	}
+	assert.True(t, store.empty())
+}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/elastic/beats/libbeat/beat"
@@ -33,6 +34,7 @@ import (
 const (
 	DefaultTransactionHashSize                 = 2 ^ 16
 	DefaultTransactionExpiration time.Duration = 10 * time.Second
+	DefaultMaxTransactions                     = 10000
 )
 
 // ProtocolData interface to represent an upper
@@ -48,6 +50,18 @@ type Packet struct {
 
 var ErrInvalidPort = errors.New("port number out of range")
 
+// CanAddTransaction checks if a new transaction can be added to the
+// transactions cache, holding at most max in-flight transactions. Expired
+// transactions are removed first once the limit is reached. The limit is
+// disabled if max is 0.
+func CanAddTransaction(transactions *common.Cache, max int) bool {
+	if max <= 0 || transactions.Size() < max {
+		return true
+	}
+	transactions.CleanUp()
+	return transactions.Size() < max
+}
+
 // Protocol Plugin Port configuration with validation on init
 type PortsConfig struct {
 	Ports []int
@@ -76,6 +90,7 @@ func validatePorts(ports []int) error {
 
 type Protocols interface {
 	BpfFilter(withVlans bool, withICMP bool) string
+	GetPorts(proto Protocol) []int
 	GetTCP(proto Protocol) TCPPlugin
 	GetUDP(proto Protocol) UDPPlugin
 
@@ -90,13 +105,23 @@ type ProtocolsStruct struct {
 	all map[Protocol]protocolInstance
 	tcp map[Protocol]TCPPlugin
 	udp map[Protocol]UDPPlugin
+
+	ports *protocolPorts
+}
+
+// protocolPorts holds the ports changed at runtime, overriding the ports the
+// plugins have been configured with.
+type protocolPorts struct {
+	sync.RWMutex
+	ports map[Protocol][]int
 }
 
 // Singleton of Protocols type.
 var Protos = ProtocolsStruct{
-	all: map[Protocol]protocolInstance{},
-	tcp: map[Protocol]TCPPlugin{},
-	udp: map[Protocol]UDPPlugin{},
+	all:   map[Protocol]protocolInstance{},
+	tcp:   map[Protocol]TCPPlugin{},
+	udp:   map[Protocol]UDPPlugin{},
+	ports: &protocolPorts{ports: map[Protocol][]int{}},
 }
 
 type protocolInstance struct {
@@ -192,6 +217,84 @@ func (s ProtocolsStruct) configureProtocol(
 	return nil
 }
 
+// GetPorts returns the ports of the protocol. Ports changed by SetPorts take
+// precedence over the ports configured in the plugin.
+func (s ProtocolsStruct) GetPorts(proto Protocol) []int {
+	if s.ports != nil {
+		s.ports.RLock()
+		ports, exists := s.ports.ports[proto]
+		s.ports.RUnlock()
+		if exists {
+			return ports
+		}
+	}
+
+	inst, exists := s.all[proto]
+	if !exists {
+		return nil
+	}
+	return inst.plugin.GetPorts()
+}
+
+// SetPorts replaces the ports of the given protocols. The ports are only
+// changed if all protocols are enabled, all ports are valid and no port is
+// assigned to more than one TCP or UDP protocol.
+func (s ProtocolsStruct) SetPorts(ports map[Protocol][]int) error {
+	if s.ports == nil {
+		return errors.New("changing ports is not supported")
+	}
+
+	for proto, list := range ports {
+		if _, exists := s.all[proto]; !exists {
+			return fmt.Errorf("protocol %s is not enabled, enabling protocols requires a restart", proto)
+		}
+		if err := validatePorts(list); err != nil {
+			return fmt.Errorf("invalid %s ports %v: %v", proto, list, err)
+		}
+	}
+
+	portsOf := func(proto Protocol) []int {
+		if list, exists := ports[proto]; exists {
+			return list
+		}
+		return s.GetPorts(proto)
+	}
+	var tcp, udp []Protocol
+	for proto := range s.tcp {
+		tcp = append(tcp, proto)
+	}
+	for proto := range s.udp {
+		udp = append(udp, proto)
+	}
+	if err := checkDuplicatePorts(tcp, portsOf); err != nil {
+		return err
+	}
+	if err := checkDuplicatePorts(udp, portsOf); err != nil {
+		return err
+	}
+
+	s.ports.Lock()
+	defer s.ports.Unlock()
+	for proto, list := range ports {
+		s.ports.ports[proto] = list
+	}
+	return nil
+}
+
+func checkDuplicatePorts(protocols []Protocol, portsOf func(Protocol) []int) error {
+	assigned := map[int]Protocol{}
+	for _, proto := range protocols {
+		for _, port := range portsOf(proto) {
+			if other, exists := assigned[port]; exists && other != proto {
+				return fmt.Errorf("Duplicate port (%d) exists in %s and %s protocols",
+					port, other, proto)
+			}
+			assigned[port] = proto
+		}
+	}
+	return nil
+}
+
 func (s ProtocolsStruct) GetTCP(proto Protocol) TCPPlugin {
 	plugin, exists := s.tcp[proto]
 	if !exists {
@@ -218,6 +321,17 @@ func (s ProtocolsStruct) GetAllUDP() map[Protocol]UDPPlugin {
 	return s.udp
 }
 
+// Flush publishes the transactions in progress of the plugins implementing
+// Flusher.
+func (s ProtocolsStruct) Flush() {
+	for proto, instance := range s.all {
+		if flusher, ok := instance.plugin.(Flusher); ok {
+			logp.Debug("protos", "flushing the %v transactions", proto)
+			flusher.Flush()
+		}
+	}
+}
+
 // BpfFilter returns a Berkeley Packer Filter (BFP) expression that
 // will match against packets for the registered protocols. If with_vlans is
 // true the filter will match against both IEEE 802.1Q VLAN encapsulated
@@ -233,8 +347,7 @@ func (s ProtocolsStruct) BpfFilter(withVlans bool, withICMP bool) string {
 	var expressions []string
 	for _, key := range protos {
 		proto := Protocol(key)
-		plugin := s.all[proto].plugin
-		for _, port := range plugin.GetPorts() {
+		for _, port := range s.GetPorts(proto) {
 			hasTCP := false
 			hasUDP := false
 
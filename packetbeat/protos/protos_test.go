@@ -69,10 +69,13 @@ func (proto *UDPProtocol) GetPorts() []int {
 	return proto.Ports
 }
 
-func (proto *UDPProtocol) ParseUDP(pkt *Packet) {
-	return
+func (proto *UDPProtocol) ParseUDP(pkt *Packet, flow *common.IPPortTuple,
+	dir uint8, private ProtocolData) ProtocolData {
+	return private
 }
 
+func (proto *UDPProtocol) FlowTimeout() time.Duration { return 0 }
+
 type TCPUDPProtocol TestProtocol
 
 func (proto *TCPUDPProtocol) Init(testMode bool, results Reporter) error {
@@ -98,10 +101,13 @@ func (proto *TCPUDPProtocol) GapInStream(tcptuple *common.TCPTuple, dir uint8,
 	return private, true
 }
 
-func (proto *TCPUDPProtocol) ParseUDP(pkt *Packet) {
-	return
+func (proto *TCPUDPProtocol) ParseUDP(pkt *Packet, flow *common.IPPortTuple,
+	dir uint8, private ProtocolData) ProtocolData {
+	return private
 }
 
+func (proto *TCPUDPProtocol) FlowTimeout() time.Duration { return 0 }
+
 func (proto *TCPUDPProtocol) ConnectionTimeout() time.Duration { return 0 }
 
 func TestProtocolNames(t *testing.T) {
@@ -114,6 +120,7 @@ func newProtocols() Protocols {
 	p.all = make(map[Protocol]protocolInstance)
 	p.tcp = make(map[Protocol]TCPPlugin)
 	p.udp = make(map[Protocol]UDPPlugin)
+	p.ports = &protocolPorts{ports: make(map[Protocol][]int)}
 
 	tcp := &TCPProtocol{Ports: []int{80}}
 	udp := &UDPProtocol{Ports: []int{5060}}
@@ -204,3 +211,55 @@ func TestGetUDP(t *testing.T) {
 	assert.NotNil(t, udp)
 	assert.Contains(t, udp.GetPorts(), 53)
 }
+
+func TestSetPorts(t *testing.T) {
+	p := newProtocols().(ProtocolsStruct)
+
+	err := p.SetPorts(map[Protocol][]int{1: {8080, 8081}})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{8080, 8081}, p.GetPorts(1))
+	assert.Equal(t, []int{5060}, p.GetPorts(2))
+	assert.Equal(t, "tcp port 8080 or tcp port 8081 or udp port 5060 or port 53",
+		p.BpfFilter(false, false))
+}
+
+func TestSetPortsRejected(t *testing.T) {
+	tests := map[string]map[Protocol][]int{
+		"unknown protocol": {4: {8080}},
+		"duplicate tcp port": {
+			1: {8080},
+			3: {53, 8080},
+		},
+		"duplicate udp port": {2: {53}},
+	}
+
+	for name, ports := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := newProtocols().(ProtocolsStruct)
+
+			err := p.SetPorts(ports)
+			assert.Error(t, err)
+
+			// no port has been changed
+			assert.Equal(t, []int{80}, p.GetPorts(1))
+			assert.Equal(t, []int{5060}, p.GetPorts(2))
+			assert.Equal(t, []int{53}, p.GetPorts(3))
+		})
+	}
+}
+
+func TestCanAddTransaction(t *testing.T) {
+	transactions := common.NewCache(time.Hour, 10)
+	transactions.Put(1, true)
+	transactions.Put(2, true)
+
+	assert.True(t, CanAddTransaction(transactions, 0))
+	assert.True(t, CanAddTransaction(transactions, 3))
+	assert.False(t, CanAddTransaction(transactions, 2))
+
+	// expired transactions are removed once the limit is reached
+	transactions.PutWithTimeout(2, true, time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	assert.True(t, CanAddTransaction(transactions, 2))
+	assert.Equal(t, 1, transactions.Size())
+}
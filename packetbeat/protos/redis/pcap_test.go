@@ -0,0 +1,37 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package redis
+
+import (
+	"testing"
+
+	"github.com/elastic/beats/packetbeat/pcaptest"
+)
+
+func TestPcapGolden(t *testing.T) {
+	pcaptest.TestGolden(t, pcaptest.Analyzer{
+		Name:   "redis",
+		New:    New,
+		Config: map[string]interface{}{"ports": []int{6380}},
+	},
+		"../../tests/system/pcaps/redis_one_transaction.pcap",
+		"../../tests/system/pcaps/redis_session.pcap",
+	)
+}
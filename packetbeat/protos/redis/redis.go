@@ -19,6 +19,7 @@ package redis
 
 import (
 	"bytes"
+	"strings"
 	"time"
 
 	"github.com/elastic/beats/libbeat/beat"
@@ -42,10 +43,19 @@ type redisConnectionData struct {
 	streams   [2]*stream
 	requests  messageList
 	responses messageList
+
+	// direction of the client once known. The direction is only learned
+	// from a redis command if no reply has been seen yet.
+	clientDir   uint8
+	clientKnown bool
+	serverKnown bool
+
+	subscribed bool
 }
 
 type messageList struct {
 	head, tail *redisMessage
+	count      int
 }
 
 // Redis protocol plugin
@@ -67,6 +77,7 @@ var (
 
 var (
 	unmatchedResponses = monitoring.NewInt(nil, "redis.unmatched_responses")
+	pubsubMessages     = monitoring.NewInt(nil, "redis.pubsub_messages")
 )
 
 func init() {
@@ -243,12 +254,138 @@ func (redis *redisPlugin) handleRedis(
 	m.direction = dir
 	m.cmdlineTuple = procs.ProcWatcher.FindProcessesTupleTCP(tcptuple.IPPort())
 
+	conn.classify(m, dir)
+
 	if m.isRequest {
-		conn.requests.append(m) // wait for response
+		redis.handleRequest(conn, m)
+		return
+	}
+
+	if kind, ok := conn.pubsubMessage(m); ok {
+		pubsubMessages.Add(1)
+		if redis.results != nil {
+			redis.results(redis.newPubSubEvent(kind, m))
+		}
+		return
+	}
+	if m.isPush && !isSubscribeReply(m) {
+		debugf("Ignoring push message: %s", m.message)
+		return
+	}
+
+	conn.responses.append(m)
+	redis.correlate(conn)
+}
+
+// classify marks the message as request or response based on the direction
+// of the client, as array replies may look like commands and commands
+// unknown to the parser look like array replies.
+func (conn *redisConnectionData) classify(m *redisMessage, dir uint8) {
+	if !conn.serverKnown && m.values == nil {
+		// only servers send non array messages
+		conn.serverKnown = true
+		conn.clientKnown = true
+		conn.clientDir = 1 - dir
+	} else if !conn.clientKnown && m.isRequest {
+		conn.clientKnown = true
+		conn.clientDir = dir
+	}
+	if !conn.clientKnown {
+		return
+	}
+
+	isRequest := dir == conn.clientDir
+	if isRequest == m.isRequest || m.isPush || len(m.values) == 0 {
+		return
+	}
+	m.isRequest = isRequest
+	if isRequest {
+		m.method = m.values[0]
+		m.path = nil
+		if len(m.values) > 1 {
+			m.path = m.values[1]
+		}
+		m.message = common.NetString(bytes.Join(netStringsToBytes(m.values), []byte(" ")))
 	} else {
-		conn.responses.append(m)
-		redis.correlate(conn)
+		m.method, m.path = nil, nil
+		m.message = common.NetString(
+			"[" + string(bytes.Join(netStringsToBytes(m.values), []byte(", "))) + "]")
+	}
+}
+
+func (redis *redisPlugin) handleRequest(conn *redisConnectionData, m *redisMessage) {
+	switch string(bytes.ToUpper(m.method)) {
+	case "SUBSCRIBE", "PSUBSCRIBE", "SSUBSCRIBE":
+		conn.subscribed = true
+		m.pendingReplies = len(m.values) - 1
+	case "UNSUBSCRIBE", "PUNSUBSCRIBE", "SUNSUBSCRIBE":
+		m.pendingReplies = len(m.values) - 1
+		if m.pendingReplies == 0 {
+			m.pendingReplies = -1
+		}
+	}
+
+	conn.requests.append(m) // wait for response
+	m.pipelineDepth = conn.requests.count
+}
+
+// pubsubMessage checks if the server message is a message published to a
+// subscribed channel. Published messages are not replies to any request.
+func (conn *redisConnectionData) pubsubMessage(m *redisMessage) (string, bool) {
+	if !m.isPush && !conn.subscribed {
+		return "", false
+	}
+	if len(m.values) < 3 {
+		return "", false
+	}
+
+	kind := string(bytes.ToLower(m.values[0]))
+	switch kind {
+	case "message", "smessage":
+	case "pmessage":
+		if len(m.values) < 4 {
+			return "", false
+		}
+	default:
+		return "", false
 	}
+	return kind, true
+}
+
+func isSubscribeReply(m *redisMessage) bool {
+	if len(m.values) == 0 {
+		return false
+	}
+
+	switch string(bytes.ToLower(m.values[0])) {
+	case "subscribe", "psubscribe", "ssubscribe",
+		"unsubscribe", "punsubscribe", "sunsubscribe":
+		return true
+	}
+	return false
+}
+
+// lastReply accounts the reply to the request and reports if no more replies
+// are expected. (Un)subscribe commands get one reply per channel.
+func (m *redisMessage) lastReply(resp *redisMessage) bool {
+	m.repliesSize += resp.size
+	switch {
+	case m.pendingReplies > 1:
+		m.pendingReplies--
+		return false
+	case m.pendingReplies < 0:
+		// unsubscribe from all channels, until no subscription is left
+		return len(resp.values) < 3 || string(resp.values[2]) == "0"
+	}
+	return true
+}
+
+func netStringsToBytes(values []common.NetString) [][]byte {
+	b := make([][]byte, len(values))
+	for i, v := range values {
+		b[i] = v
+	}
+	return b
 }
 
 func (redis *redisPlugin) correlate(conn *redisConnectionData) {
@@ -264,8 +401,16 @@ func (redis *redisPlugin) correlate(conn *redisConnectionData) {
 
 	// merge requests with responses into transactions
 	for !conn.responses.empty() && !conn.requests.empty() {
-		requ := conn.requests.pop()
+		requ := conn.requests.head
 		resp := conn.responses.pop()
+		if !requ.lastReply(resp) {
+			continue
+		}
+		conn.requests.pop()
+
+		if isSubscribeReply(resp) && len(resp.values) >= 3 {
+			conn.subscribed = string(resp.values[2]) != "0"
+		}
 
 		if redis.results != nil {
 			event := redis.newTransaction(requ, resp)
@@ -280,15 +425,13 @@ func (redis *redisPlugin) newTransaction(requ, resp *redisMessage) beat.Event {
 		error = common.ERROR_STATUS
 	}
 
-	var returnValue map[string]common.NetString
+	returnValue := common.MapStr{
+		"pipeline_depth": requ.pipelineDepth,
+	}
 	if resp.isError {
-		returnValue = map[string]common.NetString{
-			"error": resp.message,
-		}
+		returnValue["error"] = resp.message
 	} else {
-		returnValue = map[string]common.NetString{
-			"return_value": resp.message,
-		}
+		returnValue["return_value"] = resp.message
 	}
 
 	source, destination := common.MakeEndpointPair(requ.tcpTuple.BaseTuple, requ.cmdlineTuple)
@@ -309,7 +452,7 @@ func (redis *redisPlugin) newTransaction(requ, resp *redisMessage) beat.Event {
 		"resource":     requ.path,
 		"query":        requ.message,
 		"bytes_in":     uint64(requ.size),
-		"bytes_out":    uint64(resp.size),
+		"bytes_out":    uint64(requ.repliesSize),
 		"src":          src,
 		"dst":          dst,
 	}
@@ -326,6 +469,45 @@ func (redis *redisPlugin) newTransaction(requ, resp *redisMessage) beat.Event {
 	}
 }
 
+// newPubSubEvent creates an event for a message published to a channel the
+// client is subscribed to.
+func (redis *redisPlugin) newPubSubEvent(kind string, m *redisMessage) beat.Event {
+	pubsub := common.MapStr{}
+	channel, payload := m.values[1], m.values[2]
+	if kind == "pmessage" {
+		pubsub["pattern"] = m.values[1]
+		channel, payload = m.values[2], m.values[3]
+	}
+	pubsub["channel"] = channel
+	pubsub["size"] = len(payload)
+
+	// source is the subscribed client, receiving the message
+	source, destination := common.MakeEndpointPair(m.tcpTuple.BaseTuple, m.cmdlineTuple)
+	src, dst := &source, &destination
+	if m.direction == tcp.TCPDirectionOriginal {
+		src, dst = dst, src
+	}
+
+	fields := common.MapStr{
+		"type":      "redis",
+		"status":    common.OK_STATUS,
+		"redis":     common.MapStr{"pubsub": pubsub},
+		"method":    strings.ToUpper(kind),
+		"resource":  channel,
+		"bytes_out": uint64(m.size),
+		"src":       src,
+		"dst":       dst,
+	}
+	if redis.sendResponse {
+		fields["response"] = m.message
+	}
+
+	return beat.Event{
+		Timestamp: m.ts,
+		Fields:    fields,
+	}
+}
+
 func (redis *redisPlugin) GapInStream(tcptuple *common.TCPTuple, dir uint8,
 	nbytes int, private protos.ProtocolData) (priv protos.ProtocolData, drop bool) {
 
@@ -351,6 +533,7 @@ func (ml *messageList) append(msg *redisMessage) {
 	}
 	msg.next = nil
 	ml.tail = msg
+	ml.count++
 }
 
 func (ml *messageList) empty() bool {
@@ -367,6 +550,7 @@ func (ml *messageList) pop() *redisMessage {
 	if ml.head == nil {
 		ml.tail = nil
 	}
+	ml.count--
 	return msg
 }
 
@@ -40,11 +40,21 @@ type redisMessage struct {
 
 	isRequest bool
 	isError   bool
+	isPush    bool
 	size      int
 	message   common.NetString
 	method    common.NetString
 	path      common.NetString
 
+	// elements of a top-level array or push message
+	values []common.NetString
+
+	// number of replies still expected for (un)subscribe commands. A
+	// negative value waits for the reply reporting no subscriptions left.
+	pendingReplies int
+	repliesSize    int
+	pipelineDepth  int
+
 	next *redisMessage
 }
 
@@ -57,7 +67,10 @@ const (
 var (
 	empty    = common.NetString("")
 	emptyArr = common.NetString("[]")
+	emptyMap = common.NetString("{}")
 	nilStr   = common.NetString("nil")
+	trueStr  = common.NetString("true")
+	falseStr = common.NetString("false")
 )
 
 // Keep sorted for future command addition
@@ -103,6 +116,7 @@ var redisCommands = map[string]struct{}{
 	"GETRANGE":         {},
 	"GETSET":           {},
 	"HDEL":             {},
+	"HELLO":            {},
 	"HEXISTS":          {},
 	"HGET":             {},
 	"HGETALL":          {},
@@ -188,13 +202,16 @@ var redisCommands = map[string]struct{}{
 	"SMOVE":            {},
 	"SORT":             {},
 	"SPOP":             {},
+	"SPUBLISH":         {},
 	"SRANDMEMBER":      {},
 	"SREM":             {},
 	"SSCAN":            {},
+	"SSUBSCRIBE":       {},
 	"STRLEN":           {},
 	"SUBSCRIBE":        {},
 	"SUNION":           {},
 	"SUNIONSTORE":      {},
+	"SUNSUBSCRIBE":     {},
 	"SYNC":             {},
 	"TIME":             {},
 	"TTL":              {},
@@ -292,17 +309,38 @@ func (p *parser) dispatch(depth int, buf *streambuf.Buffer) (common.NetString, b
 	snapshot := buf.Snapshot()
 
 	switch buf.Bytes()[0] {
-	case '*':
+	case '*', '~':
+		value, iserror, ok, complete = p.parseArray(depth, buf)
+	case '>':
+		if depth == 0 {
+			p.message.isPush = true
+		}
 		value, iserror, ok, complete = p.parseArray(depth, buf)
+	case '%':
+		value, iserror, ok, complete = p.parseMap(depth, buf)
+	case '|':
+		// RESP3 attributes carry auxiliary data preceding the actual reply
+		if _, _, ok, complete = p.parseMap(depth, buf); ok && complete {
+			value, iserror, ok, complete = p.dispatch(depth, buf)
+		}
 	case '$':
 		value, ok, complete = p.parseString(buf)
+	case '=':
+		value, ok, complete = p.parseVerbatimString(buf)
+	case '!':
+		iserror = true
+		value, ok, complete = p.parseString(buf)
 	case ':':
 		value, ok, complete = p.parseInt(buf)
-	case '+':
+	case '+', ',', '(':
 		value, ok, complete = p.parseSimpleString(buf)
 	case '-':
 		iserror = true
 		value, ok, complete = p.parseSimpleString(buf)
+	case '#':
+		value, ok, complete = p.parseBoolean(buf)
+	case '_':
+		value, ok, complete = p.parseNull(buf)
 	default:
 		if isDebug {
 			debugf("Unexpected message starting with %s", buf.Bytes()[0])
@@ -335,6 +373,43 @@ func (p *parser) parseSimpleString(buf *streambuf.Buffer) (common.NetString, boo
 	return common.NetString(line[1:]), true, true
 }
 
+func (p *parser) parseBoolean(buf *streambuf.Buffer) (common.NetString, bool, bool) {
+	value, ok, complete := p.parseSimpleString(buf)
+	if !ok || !complete {
+		return value, ok, complete
+	}
+
+	switch string(value) {
+	case "t":
+		return trueStr, true, true
+	case "f":
+		return falseStr, true, true
+	}
+	logp.Err("Failed to read boolean reply: %s", value)
+	return empty, false, false
+}
+
+func (p *parser) parseNull(buf *streambuf.Buffer) (common.NetString, bool, bool) {
+	_, err := buf.UntilCRLF()
+	if err != nil {
+		return empty, true, false
+	}
+	return nilStr, true, true
+}
+
+func (p *parser) parseVerbatimString(buf *streambuf.Buffer) (common.NetString, bool, bool) {
+	value, ok, complete := p.parseString(buf)
+	if !ok || !complete {
+		return value, ok, complete
+	}
+
+	// strip the 3 characters format prefix, e.g. 'txt:'
+	if len(value) >= 4 && value[3] == ':' {
+		value = value[4:]
+	}
+	return value, true, true
+}
+
 func (p *parser) parseString(buf *streambuf.Buffer) (common.NetString, bool, bool) {
 	line, err := buf.UntilCRLF()
 	if err != nil {
@@ -368,7 +443,7 @@ func (p *parser) parseArray(depth int, buf *streambuf.Buffer) (common.NetString,
 		if isDebug {
 			debugf("End of line not found, waiting for more data")
 		}
-		return empty, false, false, false
+		return empty, false, true, false
 	}
 	if isDebug {
 		debugf("line %s: %d", line, buf.BufferConsumed())
@@ -426,8 +501,15 @@ func (p *parser) parseArray(depth int, buf *streambuf.Buffer) (common.NetString,
 		contentLen += len(value)
 	}
 
+	if depth == 0 {
+		p.message.values = make([]common.NetString, len(content))
+		for i, v := range content {
+			p.message.values[i] = v
+		}
+	}
+
 	// handle top-level request command
-	if depth == 0 && isRedisCommand(content[0]) {
+	if depth == 0 && line[0] == '*' && isRedisCommand(content[0]) {
 		p.message.isRequest = true
 		p.message.method = content[0]
 		if len(content) > 1 {
@@ -454,6 +536,56 @@ func (p *parser) parseArray(depth int, buf *streambuf.Buffer) (common.NetString,
 	return value, iserror, true, true
 }
 
+func (p *parser) parseMap(depth int, buf *streambuf.Buffer) (common.NetString, bool, bool, bool) {
+	line, err := buf.UntilCRLF()
+	if err != nil {
+		if isDebug {
+			debugf("End of line not found, waiting for more data")
+		}
+		return empty, false, true, false
+	}
+
+	count, err := parseInt(line[1:])
+	if err != nil {
+		logp.Err("Failed to read number of map entries: %s", err)
+		return empty, false, false, false
+	}
+	if count < 0 {
+		return nilStr, false, true, true
+	} else if count == 0 {
+		return emptyMap, false, true, true
+	}
+
+	var content [][]byte
+	contentLen := 0
+	for i := 0; i < 2*int(count); i++ {
+		value, _, ok, complete := p.dispatch(depth+1, buf)
+		if !ok || !complete {
+			if isDebug {
+				debugf("Map incomplete")
+			}
+			return empty, false, ok, complete
+		}
+
+		content = append(content, []byte(value))
+		contentLen += len(value)
+	}
+
+	// return redis map: {k1: v1, k2: v2}
+	tmp := make([]byte, 0, 2+contentLen+int(count)*2+(int(count)-1)*2)
+	tmp = append(tmp, '{')
+	for i := 0; i < len(content); i += 2 {
+		if i > 0 {
+			tmp = append(tmp, ", "...)
+		}
+		tmp = append(tmp, content[i]...)
+		tmp = append(tmp, ": "...)
+		tmp = append(tmp, content[i+1]...)
+	}
+	tmp = append(tmp, '}')
+	return common.NetString(tmp), false, true, true
+}
+
 func parseInt(line []byte) (int64, error) {
 	buf := streambuf.NewFixed(line)
 	return buf.IntASCII(false)
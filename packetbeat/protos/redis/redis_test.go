@@ -20,10 +20,16 @@
 package redis
 
 import (
+	"net"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+
+	"github.com/elastic/beats/packetbeat/protos"
 )
 
 func newTestStream(content []byte) *stream {
@@ -200,6 +206,150 @@ func TestRedisParser_Array2Passes(t *testing.T) {
 	assert.Equal(t, len(array2PassesPart1)+len(array2PassesPart2), msg.size)
 }
 
+func TestRedisParser_Resp3(t *testing.T) {
+	tests := []struct {
+		name, message, expected string
+		isError, isPush         bool
+	}{
+		{name: "null", message: "_\r\n", expected: "nil"},
+		{name: "boolean", message: "#f\r\n", expected: "false"},
+		{name: "double", message: ",3.14\r\n", expected: "3.14"},
+		{name: "big number", message: "(3492890328409238509324850943850943825024385\r\n",
+			expected: "3492890328409238509324850943850943825024385"},
+		{name: "blob error", message: "!21\r\nSYNTAX invalid syntax\r\n",
+			expected: "SYNTAX invalid syntax", isError: true},
+		{name: "verbatim string", message: "=15\r\ntxt:Some string\r\n", expected: "Some string"},
+		{name: "map", message: "%2\r\n+first\r\n:1\r\n+second\r\n#t\r\n",
+			expected: "{first: 1, second: true}"},
+		{name: "set", message: "~2\r\n+a\r\n+b\r\n", expected: "[a, b]"},
+		{name: "attribute", message: "|1\r\n+ttl\r\n:3600\r\n$3\r\nbar\r\n", expected: "bar"},
+		{name: "push", message: ">3\r\n$7\r\nmessage\r\n$2\r\nch\r\n$5\r\nhello\r\n",
+			expected: "[message, ch, hello]", isPush: true},
+	}
+
+	for _, test := range tests {
+		msg, ok, complete := parse([]byte(test.message))
+
+		assert.True(t, ok, test.name)
+		assert.True(t, complete, test.name)
+		assert.False(t, msg.isRequest, test.name)
+		assert.Equal(t, test.isError, msg.isError, test.name)
+		assert.Equal(t, test.isPush, msg.isPush, test.name)
+		assert.Equal(t, test.expected, string(msg.message), test.name)
+		assert.Equal(t, len(test.message), msg.size, test.name)
+	}
+}
+
+func TestRedisParser_SplitArrayHeader(t *testing.T) {
+	st := newTestStream([]byte("*3"))
+	ok, complete := st.parser.parse(&st.Buf)
+	assert.True(t, ok)
+	assert.False(t, complete)
+}
+
+type eventStore struct {
+	events []beat.Event
+}
+
+func (e *eventStore) publish(event beat.Event) {
+	e.events = append(e.events, event)
+}
+
+func redisModForTests(store *eventStore) *redisPlugin {
+	var redis redisPlugin
+	redis.init(store.publish, &defaultConfig)
+	return &redis
+}
+
+func testTCPTuple() *common.TCPTuple {
+	t := &common.TCPTuple{
+		IPLength: 4,
+		BaseTuple: common.BaseTuple{
+			SrcIP: net.IPv4(192, 168, 0, 1), DstIP: net.IPv4(192, 168, 0, 2),
+			SrcPort: 6512, DstPort: 6379,
+		},
+	}
+	t.ComputeHashables()
+	return t
+}
+
+func parsePackets(redis *redisPlugin, tcptuple *common.TCPTuple, packets ...string) {
+	var private protos.ProtocolData
+	for i, payload := range packets {
+		pkt := &protos.Packet{Ts: time.Now(), Payload: []byte(payload)}
+		private = redis.Parse(pkt, tcptuple, uint8(i%2), private)
+	}
+}
+
+func TestRedisPlugin_Pipelined(t *testing.T) {
+	store := &eventStore{}
+	redis := redisModForTests(store)
+
+	parsePackets(redis, testTCPTuple(),
+		"*3\r\n$3\r\nSET\r\n$1\r\nk\r\n$1\r\nv\r\n"+
+			"*2\r\n$4\r\nXLEN\r\n$1\r\ns\r\n"+
+			"*4\r\n$6\r\nLRANGE\r\n$1\r\nl\r\n$1\r\n0\r\n$2\r\n-1\r\n",
+		"+OK\r\n:3\r\n*2\r\n$3\r\nGET\r\n$3\r\nSET\r\n")
+
+	if !assert.Len(t, store.events, 3) {
+		return
+	}
+	expected := []struct {
+		method, query, returnValue string
+	}{
+		{"SET", "SET k v", "OK"},
+		{"XLEN", "XLEN s", "3"},
+		{"LRANGE", "LRANGE l 0 -1", "[GET, SET]"},
+	}
+	for i, e := range expected {
+		fields := store.events[i].Fields
+		assert.Equal(t, common.NetString(e.method), fields["method"])
+		assert.Equal(t, common.NetString(e.query), fields["query"])
+		assert.Equal(t, common.NetString(e.returnValue), fields["redis"].(common.MapStr)["return_value"])
+		assert.Equal(t, i+1, fields["redis"].(common.MapStr)["pipeline_depth"])
+	}
+}
+
+func TestRedisPlugin_PubSub(t *testing.T) {
+	store := &eventStore{}
+	redis := redisModForTests(store)
+
+	subscribe := "*3\r\n$9\r\nSUBSCRIBE\r\n$1\r\na\r\n$1\r\nb\r\n"
+	replies := "*3\r\n$9\r\nsubscribe\r\n$1\r\na\r\n:1\r\n" +
+		"*3\r\n$9\r\nsubscribe\r\n$1\r\nb\r\n:2\r\n"
+	message := "*3\r\n$7\r\nmessage\r\n$1\r\na\r\n$5\r\nhello\r\n"
+	push := ">4\r\n$8\r\npmessage\r\n$2\r\nb*\r\n$1\r\nb\r\n$2\r\nhi\r\n"
+	parsePackets(redis, testTCPTuple(), subscribe, replies+message+push)
+
+	if !assert.Len(t, store.events, 3) {
+		return
+	}
+
+	fields := store.events[0].Fields
+	assert.Equal(t, common.NetString("SUBSCRIBE"), fields["method"])
+	assert.Equal(t, uint64(len(replies)), fields["bytes_out"])
+
+	fields = store.events[1].Fields
+	assert.Equal(t, "MESSAGE", fields["method"])
+	assert.Equal(t, common.NetString("a"), fields["resource"])
+	assert.Equal(t, uint64(len(message)), fields["bytes_out"])
+	assert.Equal(t, common.MapStr{
+		"pubsub": common.MapStr{"channel": common.NetString("a"), "size": 5},
+	}, fields["redis"])
+	// the subscriber is the source, like for the subscribe transaction
+	assert.Equal(t, store.events[0].Fields["src"], fields["src"])
+
+	fields = store.events[2].Fields
+	assert.Equal(t, "PMESSAGE", fields["method"])
+	assert.Equal(t, common.MapStr{
+		"pubsub": common.MapStr{
+			"channel": common.NetString("b"),
+			"pattern": common.NetString("b*"),
+			"size":    2,
+		},
+	}, fields["redis"])
+}
+
 func BenchmarkParserNoArgsResult(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		parse(noArgsRequest)
@@ -64,7 +64,25 @@ type UDPPlugin interface {
 	Plugin
 
 	// ParseUDP is invoked when UDP payload data is available for parsing.
-	ParseUDP(pkt *Packet)
+	// The flow tuple is oriented like the first datagram of the flow, and
+	// dir is the direction of the datagram relative to it. The returned
+	// private data is passed to the next call for the same flow. The flow
+	// is not tracked if no private data is returned.
+	ParseUDP(pkt *Packet, flow *common.IPPortTuple, dir uint8,
+		private ProtocolData) ProtocolData
+
+	// FlowTimeout returns the time after which an idle flow is removed.
+	// Return <=0 to set default udp module flow timeout.
+	FlowTimeout() time.Duration
+}
+
+// ExpirationAwareUDPPlugin is a UDPPlugin that also provides the Expired()
+// method. No need to use this type directly, just implement the method.
+type ExpirationAwareUDPPlugin interface {
+	UDPPlugin
+
+	// Expired is called when the UDP flow is expired due to flow timeout.
+	Expired(flow *common.IPPortTuple, private ProtocolData)
 }
 
 // ExpirationAwareTCPPlugin is a TCPPlugin that also provides the Expired()
@@ -76,6 +94,13 @@ type ExpirationAwareTCPPlugin interface {
 	Expired(tuple *common.TCPTuple, private ProtocolData)
 }
 
+// Flusher is a Plugin that holds transactions in progress, that are only
+// published once complete or expired. Flush is called on shutdown to publish
+// them right away.
+type Flusher interface {
+	Flush()
+}
+
 // Protocol identifier.
 type Protocol uint16
 
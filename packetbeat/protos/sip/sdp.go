@@ -0,0 +1,64 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sip
+
+import (
+	"strings"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+const contentTypeSDP = "application/sdp"
+
+// parseSDP summarizes a session description (RFC 4566). It reports the
+// session name, the first connection address and the media descriptions.
+func parseSDP(body []byte) common.MapStr {
+	sdp := common.MapStr{}
+	var media []string
+
+	for _, line := range strings.Split(string(body), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if len(line) < 2 || line[1] != '=' {
+			continue
+		}
+
+		value := line[2:]
+		switch line[0] {
+		case 's':
+			sdp["session_name"] = value
+		case 'c':
+			// c=<nettype> <addrtype> <connection-address>
+			if _, found := sdp["connection_address"]; !found {
+				if fields := strings.Fields(value); len(fields) == 3 {
+					sdp["connection_address"] = fields[2]
+				}
+			}
+		case 'm':
+			// m=<media> <port> <proto> <fmt> ...
+			media = append(media, value)
+		}
+	}
+
+	if len(media) > 0 {
+		sdp["media"] = media
+	}
+	if len(sdp) == 0 {
+		return nil
+	}
+	return sdp
+}
@@ -0,0 +1,415 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sip
+
+import (
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/monitoring"
+
+	"github.com/elastic/beats/packetbeat/procs"
+	"github.com/elastic/beats/packetbeat/protos"
+)
+
+type sipPlugin struct {
+	// Configuration data.
+	ports        []int
+	sendRequest  bool
+	sendResponse bool
+	parseSDP     bool
+
+	// Cache of active SIP transactions, keyed by Call-ID, CSeq and the
+	// address tuple of the request.
+	transactions       *common.Cache
+	transactionTimeout time.Duration
+
+	results protos.Reporter
+}
+
+type transport uint8
+
+const (
+	transportTCP transport = iota
+	transportUDP
+)
+
+var transportNames = []string{
+	"tcp",
+	"udp",
+}
+
+func (t transport) String() string {
+	if int(t) >= len(transportNames) {
+		return "impossible"
+	}
+	return transportNames[t]
+}
+
+// transactionKey identifies a SIP transaction. The tuple is the one of the
+// request, i.e. src is the client.
+type transactionKey struct {
+	callID string
+	cseq   string
+	tuple  common.HashableIPPortTuple
+}
+
+type sipTransaction struct {
+	ts        time.Time
+	key       transactionKey
+	transport transport
+	src       common.Endpoint
+	dst       common.Endpoint
+	notes     []string
+
+	request     *message
+	response    *message
+	provisional []int
+
+	// published is set once the final response has been reported. The
+	// transaction is kept until it expires so retransmitted final responses
+	// aren't reported as orphans.
+	published bool
+}
+
+var (
+	debugf = logp.MakeDebug("sip")
+)
+
+const (
+	noteNoResponse       = "No response"
+	noteOrphanResponse   = "Response without request"
+	noteTruncatedBody    = "Message body truncated"
+	methodACK            = "ACK"
+	finalResponseMinimum = 200
+)
+
+var (
+	unmatchedRequests  = monitoring.NewInt(nil, "sip.unmatched_requests")
+	unmatchedResponses = monitoring.NewInt(nil, "sip.unmatched_responses")
+)
+
+func init() {
+	protos.Register("sip", New)
+}
+
+func New(
+	testMode bool,
+	results protos.Reporter,
+	cfg *common.Config,
+) (protos.Plugin, error) {
+	p := &sipPlugin{}
+	config := defaultConfig
+	if !testMode {
+		if err := cfg.Unpack(&config); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.init(results, &config); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (sip *sipPlugin) init(results protos.Reporter, config *sipConfig) error {
+	sip.setFromConfig(config)
+	sip.transactions = common.NewCacheWithRemovalListener(
+		sip.transactionTimeout,
+		protos.DefaultTransactionHashSize,
+		func(k common.Key, v common.Value) {
+			trans, ok := v.(*sipTransaction)
+			if !ok {
+				logp.Err("Expired value is not a *sipTransaction.")
+				return
+			}
+			sip.expireTransaction(trans)
+		})
+	sip.transactions.StartJanitor(sip.transactionTimeout)
+
+	sip.results = results
+
+	return nil
+}
+
+func (sip *sipPlugin) setFromConfig(config *sipConfig) {
+	sip.ports = config.Ports
+	sip.sendRequest = config.SendRequest
+	sip.sendResponse = config.SendResponse
+	sip.parseSDP = config.ParseSDP
+	sip.transactionTimeout = config.TransactionTimeout
+}
+
+func (sip *sipPlugin) GetPorts() []int {
+	return sip.ports
+}
+
+func (sip *sipPlugin) FlowTimeout() time.Duration {
+	return sip.transactionTimeout
+}
+
+// Flush publishes the transactions in progress as expired.
+func (sip *sipPlugin) Flush() {
+	sip.transactions.RemoveAll()
+}
+
+func (sip *sipPlugin) ParseUDP(
+	pkt *protos.Packet,
+	flow *common.IPPortTuple,
+	dir uint8,
+	private protos.ProtocolData,
+) protos.ProtocolData {
+	defer logp.Recover("SIP ParseUdp")
+
+	debugf("Parsing packet addressed with %s of length %d.",
+		pkt.Tuple.String(), len(pkt.Payload))
+
+	msg, _, err := parseMessage(pkt.Payload, false)
+	if err != nil {
+		debugf("%s", err.Error())
+		return nil
+	}
+	if msg == nil {
+		// keep-alive
+		return nil
+	}
+
+	msg.ts = pkt.Ts
+	msg.tuple = pkt.Tuple
+	msg.cmdlineTuple = procs.ProcWatcher.FindProcessesTupleUDP(&pkt.Tuple)
+	sip.handleMessage(transportUDP, msg)
+	return nil
+}
+
+func (sip *sipPlugin) handleMessage(trans transport, msg *message) {
+	if msg.isRequest {
+		sip.receivedRequest(trans, msg)
+	} else {
+		sip.receivedResponse(trans, msg)
+	}
+}
+
+func (sip *sipPlugin) getTransaction(k transactionKey) *sipTransaction {
+	v := sip.transactions.Get(k)
+	if v != nil {
+		return v.(*sipTransaction)
+	}
+	return nil
+}
+
+func newTransaction(
+	trans transport,
+	key transactionKey,
+	ts time.Time,
+	tuple *common.IPPortTuple,
+	cmdline *common.CmdlineTuple,
+) *sipTransaction {
+	t := &sipTransaction{
+		ts:        ts,
+		key:       key,
+		transport: trans,
+	}
+	t.src, t.dst = common.MakeEndpointPair(tuple.BaseTuple, cmdline)
+	return t
+}
+
+func (sip *sipPlugin) receivedRequest(trans transport, msg *message) {
+	key := transactionKey{
+		callID: msg.callID,
+		cseq:   msg.cseq,
+		tuple:  msg.tuple.Hashable(),
+	}
+
+	if t := sip.getTransaction(key); t != nil {
+		debugf("Ignoring retransmitted %s request, Call-ID %s", msg.method, msg.callID)
+		return
+	}
+
+	t := newTransaction(trans, key, msg.ts, &msg.tuple, msg.cmdlineTuple)
+	t.request = msg
+	if msg.truncated {
+		t.notes = append(t.notes, noteTruncatedBody)
+	}
+
+	// ACK requests never get a response.
+	if msg.method == methodACK {
+		sip.publishTransaction(t)
+		return
+	}
+
+	sip.transactions.Put(key, t)
+}
+
+func (sip *sipPlugin) receivedResponse(trans transport, msg *message) {
+	key := transactionKey{
+		callID: msg.callID,
+		cseq:   msg.cseq,
+		tuple:  msg.tuple.RevHashable(),
+	}
+
+	t := sip.getTransaction(key)
+	if t == nil {
+		if msg.statusCode < finalResponseMinimum {
+			debugf("Ignoring provisional response without request, Call-ID %s", msg.callID)
+			return
+		}
+
+		reverse := msg.cmdlineTuple.Reverse()
+		tuple := common.NewIPPortTuple(msg.tuple.IPLength,
+			msg.tuple.DstIP, msg.tuple.DstPort, msg.tuple.SrcIP, msg.tuple.SrcPort)
+		t = newTransaction(trans, key, msg.ts, &tuple, &reverse)
+		t.notes = append(t.notes, noteOrphanResponse)
+		debugf("%s, Call-ID %s", noteOrphanResponse, msg.callID)
+		unmatchedResponses.Add(1)
+	}
+
+	if t.published {
+		debugf("Ignoring retransmitted response, Call-ID %s", msg.callID)
+		return
+	}
+
+	if msg.statusCode < finalResponseMinimum {
+		if !containsCode(t.provisional, msg.statusCode) {
+			t.provisional = append(t.provisional, msg.statusCode)
+		}
+		return
+	}
+
+	t.response = msg
+	if msg.truncated {
+		t.notes = append(t.notes, noteTruncatedBody)
+	}
+	sip.publishTransaction(t)
+	t.published = true
+	if t.request != nil {
+		sip.transactions.Put(key, t)
+	}
+}
+
+func containsCode(codes []int, code int) bool {
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (sip *sipPlugin) expireTransaction(t *sipTransaction) {
+	if t.published {
+		return
+	}
+
+	t.notes = append(t.notes, noteNoResponse)
+	debugf("%s, Call-ID %s", noteNoResponse, t.key.callID)
+	sip.publishTransaction(t)
+	unmatchedRequests.Add(1)
+}
+
+func (sip *sipPlugin) publishTransaction(t *sipTransaction) {
+	if sip.results == nil {
+		return
+	}
+
+	fields := common.MapStr{}
+	fields["type"] = "sip"
+	fields["transport"] = t.transport.String()
+	fields["src"] = &t.src
+	fields["dst"] = &t.dst
+	fields["status"] = common.OK_STATUS
+	if len(t.notes) == 1 {
+		fields["notes"] = t.notes[0]
+	} else if len(t.notes) > 1 {
+		fields["notes"] = strings.Join(t.notes, " ")
+	}
+
+	sipEvent := common.MapStr{}
+	fields["sip"] = sipEvent
+
+	// Use whichever message is available for the dialog fields. The request
+	// takes precedence as the response copies them from it.
+	first := t.request
+	if first == nil {
+		first = t.response
+	}
+	sipEvent["call_id"] = first.callID
+	sipEvent["cseq"] = first.cseq
+	if first.from != "" {
+		sipEvent["from"] = first.from
+	}
+	if first.to != "" {
+		sipEvent["to"] = first.to
+	}
+
+	if req := t.request; req != nil {
+		fields["method"] = req.method
+		fields["query"] = req.method + " " + req.requestURI
+		fields["bytes_in"] = req.size
+		sipEvent["uri"] = req.requestURI
+		if req.userAgent != "" {
+			sipEvent["user_agent"] = req.userAgent
+		}
+		if sdp := sip.sdpSummary(req); sdp != nil {
+			sipEvent.Put("sdp.offer", sdp)
+		}
+		if sip.sendRequest {
+			fields["request"] = string(req.raw)
+		}
+	} else {
+		fields["method"] = t.response.cseqMethod
+	}
+
+	if len(t.provisional) > 0 {
+		sipEvent["provisional_responses"] = t.provisional
+	}
+
+	if resp := t.response; resp != nil {
+		fields["bytes_out"] = resp.size
+		if t.request != nil {
+			fields["responsetime"] = int32(resp.ts.Sub(t.ts).Nanoseconds() / 1e6)
+		}
+		sipEvent["status_code"] = resp.statusCode
+		sipEvent["status_phrase"] = resp.statusPhrase
+		if sdp := sip.sdpSummary(resp); sdp != nil {
+			sipEvent.Put("sdp.answer", sdp)
+		}
+		if resp.statusCode >= 400 {
+			fields["status"] = common.ERROR_STATUS
+		}
+		if sip.sendResponse {
+			fields["response"] = string(resp.raw)
+		}
+	} else if t.request.method != methodACK {
+		fields["status"] = common.ERROR_STATUS
+	}
+
+	sip.results(beat.Event{
+		Timestamp: t.ts,
+		Fields:    fields,
+	})
+}
+
+func (sip *sipPlugin) sdpSummary(m *message) common.MapStr {
+	if !sip.parseSDP || len(m.body) == 0 || !strings.HasPrefix(m.contentType, contentTypeSDP) {
+		return nil
+	}
+	return parseSDP(m.body)
+}
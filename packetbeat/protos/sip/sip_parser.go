@@ -0,0 +1,253 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sip
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/packetbeat/protos/tcp"
+)
+
+var (
+	constCRLF      = []byte("\r\n")
+	constEOH       = []byte("\r\n\r\n")
+	constSIPPrefix = []byte("SIP/")
+
+	errInvalidStartLine = errors.New("invalid SIP start line")
+	errInvalidHeader    = errors.New("invalid SIP header")
+	errMissingCallID    = errors.New("missing Call-ID header")
+	errMissingCSeq      = errors.New("missing or invalid CSeq header")
+)
+
+// compact header names, see RFC 3261 section 7.3.3
+var compactHeaders = map[string]string{
+	"i": "call-id",
+	"f": "from",
+	"t": "to",
+	"v": "via",
+	"m": "contact",
+	"l": "content-length",
+	"c": "content-type",
+	"e": "content-encoding",
+	"s": "subject",
+	"k": "supported",
+}
+
+// message is a SIP request or response.
+type message struct {
+	ts           time.Time
+	tuple        common.IPPortTuple
+	cmdlineTuple *common.CmdlineTuple
+
+	isRequest bool
+
+	// request line
+	method     string
+	requestURI string
+
+	// status line
+	statusCode   int
+	statusPhrase string
+
+	callID     string
+	cseq       string
+	cseqMethod string
+	from       string
+	to         string
+	userAgent  string
+
+	contentType   string
+	contentLength int
+	body          []byte
+
+	// truncated is set when less than Content-Length bytes of body were
+	// captured.
+	truncated bool
+
+	raw  []byte
+	size int
+}
+
+// parseMessage parses a SIP message at the beginning of data. For stream
+// transports it returns a nil message when more data is needed. Otherwise the
+// message is the whole UDP datagram. It returns the number of bytes consumed.
+func parseMessage(data []byte, stream bool) (*message, int, error) {
+	// skip CRLF keep-alives
+	offset := 0
+	for bytes.HasPrefix(data[offset:], constCRLF) {
+		offset += len(constCRLF)
+	}
+	data = data[offset:]
+	if len(data) == 0 {
+		return nil, offset, nil
+	}
+
+	eoh := bytes.Index(data, constEOH)
+	if eoh < 0 {
+		if stream {
+			return nil, offset, nil
+		}
+		return nil, 0, errInvalidHeader
+	}
+
+	m := &message{contentLength: -1}
+	lines := bytes.Split(data[:eoh], constCRLF)
+	if err := m.parseStartLine(string(lines[0])); err != nil {
+		return nil, 0, err
+	}
+	if err := m.parseHeaders(lines[1:]); err != nil {
+		return nil, 0, err
+	}
+
+	bodyStart := eoh + len(constEOH)
+	bodyEnd := len(data)
+	if m.contentLength >= 0 {
+		bodyEnd = bodyStart + m.contentLength
+		if bodyEnd > len(data) {
+			if stream {
+				return nil, offset, nil
+			}
+			bodyEnd = len(data)
+			m.truncated = true
+		}
+	} else if stream {
+		// Content-Length is mandatory on stream transports
+		bodyEnd = bodyStart
+	}
+
+	m.body = data[bodyStart:bodyEnd]
+	m.raw = data[:bodyEnd]
+	m.size = bodyEnd
+	return m, offset + bodyEnd, nil
+}
+
+func (m *message) parseStartLine(line string) error {
+	if strings.HasPrefix(line, string(constSIPPrefix)) {
+		// Status-Line = SIP-Version SP Status-Code SP Reason-Phrase
+		parts := strings.SplitN(line, " ", 3)
+		if len(parts) < 2 {
+			return errInvalidStartLine
+		}
+		code, err := strconv.Atoi(parts[1])
+		if err != nil || code < 100 || code > 699 {
+			return errInvalidStartLine
+		}
+		m.statusCode = code
+		if len(parts) == 3 {
+			m.statusPhrase = parts[2]
+		}
+		return nil
+	}
+
+	// Request-Line = Method SP Request-URI SP SIP-Version
+	parts := strings.Split(line, " ")
+	if len(parts) != 3 || !strings.HasPrefix(parts[2], string(constSIPPrefix)) {
+		return errInvalidStartLine
+	}
+	m.isRequest = true
+	m.method = parts[0]
+	m.requestURI = parts[1]
+	return nil
+}
+
+func (m *message) parseHeaders(lines [][]byte) error {
+	var name, value string
+	for i := 0; i < len(lines); i++ {
+		line := string(lines[i])
+		idx := strings.IndexByte(line, ':')
+		if idx <= 0 {
+			return errInvalidHeader
+		}
+		name = strings.ToLower(strings.TrimSpace(line[:idx]))
+		value = strings.TrimSpace(line[idx+1:])
+
+		// join folded lines
+		for i+1 < len(lines) && len(lines[i+1]) > 0 && (lines[i+1][0] == ' ' || lines[i+1][0] == '\t') {
+			i++
+			value += " " + strings.TrimSpace(string(lines[i]))
+		}
+
+		if long, found := compactHeaders[name]; found {
+			name = long
+		}
+		if err := m.setHeader(name, value); err != nil {
+			return err
+		}
+	}
+
+	if m.callID == "" {
+		return errMissingCallID
+	}
+	if m.cseqMethod == "" {
+		return errMissingCSeq
+	}
+	return nil
+}
+
+func (m *message) setHeader(name, value string) error {
+	switch name {
+	case "call-id":
+		m.callID = value
+	case "cseq":
+		fields := strings.Fields(value)
+		if len(fields) != 2 {
+			return errMissingCSeq
+		}
+		if _, err := strconv.ParseUint(fields[0], 10, 32); err != nil {
+			return errMissingCSeq
+		}
+		m.cseq = fields[0] + " " + fields[1]
+		m.cseqMethod = fields[1]
+	case "from":
+		m.from = headerURI(value)
+	case "to":
+		m.to = headerURI(value)
+	case "user-agent":
+		m.userAgent = value
+	case "content-type":
+		m.contentType = strings.ToLower(value)
+	case "content-length":
+		// bound the length by the stream buffer limit, so the body end can't
+		// overflow
+		length, err := strconv.Atoi(value)
+		if err != nil || length < 0 || length > tcp.TCPMaxDataInStream {
+			return errInvalidHeader
+		}
+		m.contentLength = length
+	}
+	return nil
+}
+
+// headerURI extracts the URI of a From or To header value, dropping the
+// display name and the parameters.
+func headerURI(value string) string {
+	if start := strings.IndexByte(value, '<'); start >= 0 {
+		if end := strings.IndexByte(value[start:], '>'); end > 0 {
+			return value[start+1 : start+end]
+		}
+	}
+	if idx := strings.IndexByte(value, ';'); idx >= 0 {
+		value = value[:idx]
+	}
+	return strings.TrimSpace(value)
+}
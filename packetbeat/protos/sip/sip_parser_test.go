@@ -0,0 +1,133 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package sip
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const inviteWithSDP = "INVITE sip:bob@biloxi.example.com SIP/2.0\r\n" +
+	"Via: SIP/2.0/UDP pc33.atlanta.example.com;branch=z9hG4bK776asdhds\r\n" +
+	"Max-Forwards: 70\r\n" +
+	"To: Bob <sip:bob@biloxi.example.com>\r\n" +
+	"From: Alice <sip:alice@atlanta.example.com>;tag=1928301774\r\n" +
+	"Call-ID: a84b4c76e66710@pc33.atlanta.example.com\r\n" +
+	"CSeq: 314159 INVITE\r\n" +
+	"User-Agent: softphone/1.0\r\n" +
+	"Contact: <sip:alice@pc33.atlanta.example.com>\r\n" +
+	"Content-Type: application/sdp\r\n" +
+	"Content-Length: 139\r\n" +
+	"\r\n" +
+	"v=0\r\n" +
+	"o=alice 2890844526 2890844526 IN IP4 pc33\r\n" +
+	"s=Session SDP\r\n" +
+	"c=IN IP4 192.0.2.101\r\n" +
+	"t=0 0\r\n" +
+	"m=audio 49172 RTP/AVP 0\r\n" +
+	"a=rtpmap:0 PCMU/8000\r\n"
+
+func TestParseRequest(t *testing.T) {
+	m, n, err := parseMessage([]byte(inviteWithSDP), true)
+	if !assert.NoError(t, err) || !assert.NotNil(t, m) {
+		return
+	}
+
+	assert.Equal(t, len(inviteWithSDP), n)
+	assert.True(t, m.isRequest)
+	assert.Equal(t, "INVITE", m.method)
+	assert.Equal(t, "sip:bob@biloxi.example.com", m.requestURI)
+	assert.Equal(t, "a84b4c76e66710@pc33.atlanta.example.com", m.callID)
+	assert.Equal(t, "314159 INVITE", m.cseq)
+	assert.Equal(t, "INVITE", m.cseqMethod)
+	assert.Equal(t, "sip:alice@atlanta.example.com", m.from)
+	assert.Equal(t, "sip:bob@biloxi.example.com", m.to)
+	assert.Equal(t, "softphone/1.0", m.userAgent)
+	assert.Equal(t, 139, len(m.body))
+	assert.False(t, m.truncated)
+}
+
+func TestParseCompactHeadersAndFolding(t *testing.T) {
+	raw := "SIP/2.0 180 Ringing\r\n" +
+		"v: SIP/2.0/UDP pc33.atlanta.example.com;branch=z9hG4bK776asdhds\r\n" +
+		"t: sip:bob@biloxi.example.com;tag=a6c85cf\r\n" +
+		"f: Alice\r\n <sip:alice@atlanta.example.com>;tag=1928301774\r\n" +
+		"i: a84b4c76e66710\r\n" +
+		"CSeq: 1 INVITE\r\n" +
+		"l: 0\r\n" +
+		"\r\n"
+
+	m, _, err := parseMessage([]byte(raw), false)
+	if !assert.NoError(t, err) || !assert.NotNil(t, m) {
+		return
+	}
+
+	assert.False(t, m.isRequest)
+	assert.Equal(t, 180, m.statusCode)
+	assert.Equal(t, "Ringing", m.statusPhrase)
+	assert.Equal(t, "a84b4c76e66710", m.callID)
+	assert.Equal(t, "sip:alice@atlanta.example.com", m.from)
+	assert.Equal(t, "sip:bob@biloxi.example.com", m.to)
+	assert.Equal(t, 0, m.contentLength)
+}
+
+func TestParseStreamNeedsMoreData(t *testing.T) {
+	raw := []byte(inviteWithSDP)
+
+	// skip keep-alives, then wait for the body
+	data := append([]byte("\r\n\r\n"), raw[:len(raw)-10]...)
+	m, n, err := parseMessage(data, true)
+	assert.NoError(t, err)
+	assert.Nil(t, m)
+	assert.Equal(t, 4, n)
+
+	// the datagram is reported with a truncated body
+	m, _, err = parseMessage(raw[:len(raw)-10], false)
+	assert.NoError(t, err)
+	if assert.NotNil(t, m) {
+		assert.True(t, m.truncated)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []string{
+		"GET / HTTP/1.1\r\nHost: example.com\r\n\r\n",
+		"SIP/2.0 abc OK\r\nCall-ID: x\r\nCSeq: 1 INVITE\r\n\r\n",
+		"OPTIONS sip:a@b SIP/2.0\r\nCSeq: 1 OPTIONS\r\n\r\n",
+		"OPTIONS sip:a@b SIP/2.0\r\nCall-ID: x\r\nCSeq: one OPTIONS\r\n\r\n",
+		"OPTIONS sip:a@b SIP/2.0\r\nCall-ID: x\r\nCSeq: 1 OPTIONS\r\nContent-Length: -1\r\n\r\n",
+		"OPTIONS sip:a@b SIP/2.0\r\nCall-ID: x\r\nCSeq: 1 OPTIONS\r\nContent-Length: 9223372036854775807\r\n\r\n",
+	}
+
+	for _, raw := range tests {
+		_, _, err := parseMessage([]byte(raw), false)
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestParseSDP(t *testing.T) {
+	m, _, _ := parseMessage([]byte(inviteWithSDP), false)
+	sdp := parseSDP(m.body)
+
+	assert.Equal(t, "Session SDP", sdp["session_name"])
+	assert.Equal(t, "192.0.2.101", sdp["connection_address"])
+	assert.Equal(t, []string{"audio 49172 RTP/AVP 0"}, sdp["media"])
+}
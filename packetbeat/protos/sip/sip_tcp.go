@@ -0,0 +1,125 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sip
+
+import (
+	"time"
+
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+
+	"github.com/elastic/beats/packetbeat/procs"
+	"github.com/elastic/beats/packetbeat/protos"
+	"github.com/elastic/beats/packetbeat/protos/tcp"
+)
+
+// sipStream holds the unparsed data of one direction of a TCP connection.
+type sipStream struct {
+	data []byte
+}
+
+type sipConnectionData struct {
+	streams [2]*sipStream
+}
+
+func (sip *sipPlugin) ConnectionTimeout() time.Duration {
+	return sip.transactionTimeout
+}
+
+func (sip *sipPlugin) Parse(
+	pkt *protos.Packet,
+	tcptuple *common.TCPTuple,
+	dir uint8,
+	private protos.ProtocolData,
+) protos.ProtocolData {
+	defer logp.Recover("SIP ParseTcp")
+
+	debugf("Parsing packet addressed with %s of length %d.",
+		pkt.Tuple.String(), len(pkt.Payload))
+
+	conn := ensureSIPConnection(private)
+	stream := conn.streams[dir]
+	if stream == nil {
+		stream = &sipStream{}
+		conn.streams[dir] = stream
+	}
+
+	stream.data = append(stream.data, pkt.Payload...)
+	if len(stream.data) > tcp.TCPMaxDataInStream {
+		debugf("Stream data too large, dropping SIP stream")
+		conn.streams[dir] = nil
+		return conn
+	}
+
+	for len(stream.data) > 0 {
+		msg, n, err := parseMessage(stream.data, true)
+		if err != nil {
+			debugf("%s addresses %s", err.Error(), tcptuple.String())
+			conn.streams[dir] = nil
+			return conn
+		}
+		stream.data = stream.data[n:]
+		if msg == nil {
+			// wait for more data
+			break
+		}
+
+		msg.ts = pkt.Ts
+		msg.tuple = pkt.Tuple
+		msg.cmdlineTuple = procs.ProcWatcher.FindProcessesTupleTCP(&pkt.Tuple)
+		sip.handleMessage(transportTCP, msg)
+	}
+
+	if len(stream.data) == 0 {
+		stream.data = nil
+	}
+	return conn
+}
+
+func ensureSIPConnection(private protos.ProtocolData) *sipConnectionData {
+	if private == nil {
+		return &sipConnectionData{}
+	}
+
+	conn, ok := private.(*sipConnectionData)
+	if !ok || conn == nil {
+		logp.Warn("SIP connection data type error, create new one")
+		return &sipConnectionData{}
+	}
+	return conn
+}
+
+func (sip *sipPlugin) ReceivedFin(
+	tcptuple *common.TCPTuple,
+	dir uint8,
+	private protos.ProtocolData,
+) protos.ProtocolData {
+	return private
+}
+
+func (sip *sipPlugin) GapInStream(
+	tcptuple *common.TCPTuple,
+	dir uint8,
+	nbytes int,
+	private protos.ProtocolData,
+) (protos.ProtocolData, bool) {
+	// Message boundaries are lost, drop the buffered data.
+	conn := ensureSIPConnection(private)
+	conn.streams[dir] = nil
+	return conn, true
+}
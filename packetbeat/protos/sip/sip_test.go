@@ -0,0 +1,204 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package sip
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/packetbeat/protos"
+)
+
+type eventStore struct {
+	events []beat.Event
+}
+
+func (e *eventStore) publish(event beat.Event) {
+	e.events = append(e.events, event)
+}
+
+var (
+	clientIP = net.ParseIP("192.0.2.101")
+	serverIP = net.ParseIP("192.0.2.200")
+)
+
+func newTestPlugin(t *testing.T) (*sipPlugin, *eventStore) {
+	store := &eventStore{}
+	p, err := New(true, store.publish, common.NewConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p.(*sipPlugin), store
+}
+
+func request() common.IPPortTuple {
+	return common.NewIPPortTuple(4, clientIP, 5060, serverIP, 5060)
+}
+
+func response() common.IPPortTuple {
+	return common.NewIPPortTuple(4, serverIP, 5060, clientIP, 5060)
+}
+
+func sendUDP(sip *sipPlugin, tuple common.IPPortTuple, ts time.Time, raw string) {
+	sip.ParseUDP(&protos.Packet{Ts: ts, Tuple: tuple, Payload: []byte(raw)}, &tuple, 1, nil)
+}
+
+func responseTo(code string, cseq string, body string) string {
+	msg := "SIP/2.0 " + code + "\r\n" +
+		"Via: SIP/2.0/UDP pc33.atlanta.example.com;branch=z9hG4bK776asdhds\r\n" +
+		"To: Bob <sip:bob@biloxi.example.com>;tag=a6c85cf\r\n" +
+		"From: Alice <sip:alice@atlanta.example.com>;tag=1928301774\r\n" +
+		"Call-ID: a84b4c76e66710@pc33.atlanta.example.com\r\n" +
+		"CSeq: " + cseq + "\r\n"
+	if body != "" {
+		msg += "Content-Type: application/sdp\r\n"
+	}
+	return msg + "\r\n" + body
+}
+
+func fieldValue(t *testing.T, event beat.Event, key string) interface{} {
+	v, err := event.Fields.GetValue(key)
+	if err != nil {
+		t.Errorf("missing field %s: %v", key, err)
+	}
+	return v
+}
+
+func TestInviteTransaction(t *testing.T) {
+	sip, store := newTestPlugin(t)
+	ts := time.Now()
+
+	answer := "v=0\r\ns=Session SDP\r\nc=IN IP4 192.0.2.200\r\nm=audio 3456 RTP/AVP 0\r\n"
+
+	sendUDP(sip, request(), ts, inviteWithSDP)
+	sendUDP(sip, request(), ts.Add(500*time.Millisecond), inviteWithSDP) // retransmission
+	sendUDP(sip, response(), ts.Add(10*time.Millisecond), responseTo("100 Trying", "314159 INVITE", ""))
+	sendUDP(sip, response(), ts.Add(20*time.Millisecond), responseTo("180 Ringing", "314159 INVITE", ""))
+	sendUDP(sip, response(), ts.Add(2*time.Second), responseTo("200 OK", "314159 INVITE", answer))
+	sendUDP(sip, response(), ts.Add(3*time.Second), responseTo("200 OK", "314159 INVITE", answer)) // retransmission
+
+	if !assert.Len(t, store.events, 1) {
+		return
+	}
+	event := store.events[0]
+	assert.Equal(t, "sip", fieldValue(t, event, "type"))
+	assert.Equal(t, "udp", fieldValue(t, event, "transport"))
+	assert.Equal(t, common.OK_STATUS, fieldValue(t, event, "status"))
+	assert.Equal(t, "INVITE", fieldValue(t, event, "method"))
+	assert.Equal(t, "INVITE sip:bob@biloxi.example.com", fieldValue(t, event, "query"))
+	assert.Equal(t, int32(2000), fieldValue(t, event, "responsetime"))
+	assert.Equal(t, "a84b4c76e66710@pc33.atlanta.example.com", fieldValue(t, event, "sip.call_id"))
+	assert.Equal(t, "314159 INVITE", fieldValue(t, event, "sip.cseq"))
+	assert.Equal(t, "sip:alice@atlanta.example.com", fieldValue(t, event, "sip.from"))
+	assert.Equal(t, "sip:bob@biloxi.example.com", fieldValue(t, event, "sip.to"))
+	assert.Equal(t, "softphone/1.0", fieldValue(t, event, "sip.user_agent"))
+	assert.Equal(t, 200, fieldValue(t, event, "sip.status_code"))
+	assert.Equal(t, "OK", fieldValue(t, event, "sip.status_phrase"))
+	assert.Equal(t, []int{100, 180}, fieldValue(t, event, "sip.provisional_responses"))
+	assert.Equal(t, "192.0.2.101", fieldValue(t, event, "sip.sdp.offer.connection_address"))
+	assert.Equal(t, "192.0.2.200", fieldValue(t, event, "sip.sdp.answer.connection_address"))
+
+	// ACK is published on its own
+	ack := "ACK sip:bob@192.0.2.200 SIP/2.0\r\n" +
+		"Call-ID: a84b4c76e66710@pc33.atlanta.example.com\r\n" +
+		"CSeq: 314159 ACK\r\n" +
+		"Content-Length: 0\r\n\r\n"
+	sendUDP(sip, request(), ts.Add(4*time.Second), ack)
+	if assert.Len(t, store.events, 2) {
+		event = store.events[1]
+		assert.Equal(t, "ACK", fieldValue(t, event, "method"))
+		assert.Equal(t, common.OK_STATUS, fieldValue(t, event, "status"))
+	}
+}
+
+func TestErrorResponse(t *testing.T) {
+	sip, store := newTestPlugin(t)
+	ts := time.Now()
+
+	sendUDP(sip, request(), ts, inviteWithSDP)
+	sendUDP(sip, response(), ts, responseTo("486 Busy Here", "314159 INVITE", ""))
+
+	if assert.Len(t, store.events, 1) {
+		event := store.events[0]
+		assert.Equal(t, common.ERROR_STATUS, fieldValue(t, event, "status"))
+		assert.Equal(t, 486, fieldValue(t, event, "sip.status_code"))
+	}
+}
+
+func TestOrphanResponse(t *testing.T) {
+	sip, store := newTestPlugin(t)
+
+	sendUDP(sip, response(), time.Now(), responseTo("200 OK", "2 BYE", ""))
+
+	if assert.Len(t, store.events, 1) {
+		event := store.events[0]
+		assert.Equal(t, "BYE", fieldValue(t, event, "method"))
+		assert.Equal(t, noteOrphanResponse, fieldValue(t, event, "notes"))
+		assert.Equal(t, "192.0.2.101", fieldValue(t, event, "src").(*common.Endpoint).IP)
+	}
+}
+
+func TestExpiredRequest(t *testing.T) {
+	sip, store := newTestPlugin(t)
+
+	sendUDP(sip, request(), time.Now(), inviteWithSDP)
+	assert.Empty(t, store.events)
+
+	for _, v := range sip.transactions.Entries() {
+		sip.expireTransaction(v.(*sipTransaction))
+	}
+	if assert.Len(t, store.events, 1) {
+		event := store.events[0]
+		assert.Equal(t, common.ERROR_STATUS, fieldValue(t, event, "status"))
+		assert.Equal(t, noteNoResponse, fieldValue(t, event, "notes"))
+	}
+}
+
+func TestTCPStream(t *testing.T) {
+	sip, store := newTestPlugin(t)
+	ts := time.Now()
+
+	req := request()
+	resp := response()
+	tcptuple := common.TCPTupleFromIPPort(&req, 1)
+
+	// the request is split across segments, the responses share one
+	payload := []byte(inviteWithSDP)
+	split := len(payload) / 2
+	private := sip.Parse(&protos.Packet{Ts: ts, Tuple: req, Payload: payload[:split]}, &tcptuple, 0, nil)
+	private = sip.Parse(&protos.Packet{Ts: ts, Tuple: req, Payload: payload[split:]}, &tcptuple, 0, private)
+
+	responses := strings.Replace(responseTo("100 Trying", "314159 INVITE", ""), "\r\n\r\n", "\r\nContent-Length: 0\r\n\r\n", 1) +
+		strings.Replace(responseTo("603 Decline", "314159 INVITE", ""), "\r\n\r\n", "\r\nContent-Length: 0\r\n\r\n", 1)
+	sip.Parse(&protos.Packet{Ts: ts, Tuple: resp, Payload: []byte(responses)}, &tcptuple, 1, private)
+
+	if assert.Len(t, store.events, 1) {
+		event := store.events[0]
+		assert.Equal(t, "tcp", fieldValue(t, event, "transport"))
+		assert.Equal(t, 603, fieldValue(t, event, "sip.status_code"))
+		assert.Equal(t, []int{100}, fieldValue(t, event, "sip.provisional_responses"))
+	}
+}
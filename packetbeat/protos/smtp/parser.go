@@ -0,0 +1,165 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package smtp
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	constCRLF = []byte("\r\n")
+
+	// end of the message sent with DATA, see RFC 5321 section 4.1.1.4
+	constEndOfData = []byte("\r\n.\r\n")
+
+	errInvalidReply   = errors.New("invalid SMTP reply")
+	errInvalidCommand = errors.New("invalid SMTP command")
+)
+
+// maxLineLength is the maximum length of a command or reply line we accept.
+// RFC 5321 limits them to 512 and 1000 octets, but extensions like AUTH
+// allow longer ones.
+const maxLineLength = 16 * 1024
+
+type command struct {
+	ts     time.Time
+	verb   string
+	params string
+	line   []byte
+	size   int
+}
+
+type reply struct {
+	ts    time.Time
+	code  int
+	lines []string
+	raw   []byte
+	size  int
+}
+
+// readLine returns the next CRLF terminated line of data, without the line
+// terminator. A nil line means more data is needed.
+func readLine(data []byte) (line []byte, n int, err error) {
+	idx := bytes.Index(data, constCRLF)
+	if idx < 0 {
+		if len(data) > maxLineLength {
+			return nil, 0, errInvalidCommand
+		}
+		return nil, 0, nil
+	}
+	return data[:idx], idx + len(constCRLF), nil
+}
+
+// parseCommand parses a command line like `MAIL FROM:<alice@example.com>`.
+func parseCommand(line []byte) (*command, error) {
+	text := string(line)
+	verb, params := text, ""
+	if idx := strings.IndexByte(text, ' '); idx >= 0 {
+		verb, params = text[:idx], strings.TrimSpace(text[idx+1:])
+	}
+	if len(verb) == 0 || len(verb) > 16 {
+		return nil, errInvalidCommand
+	}
+	for _, c := range verb {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+			return nil, errInvalidCommand
+		}
+	}
+
+	return &command{
+		verb:   strings.ToUpper(verb),
+		params: params,
+		line:   line,
+	}, nil
+}
+
+// parseReply parses a complete, possibly multi-line, reply at the beginning
+// of data. A nil reply means more data is needed.
+func parseReply(data []byte) (*reply, int, error) {
+	r := &reply{}
+	offset := 0
+	for {
+		line, n, err := readLine(data[offset:])
+		if err != nil {
+			return nil, 0, errInvalidReply
+		}
+		if line == nil {
+			return nil, 0, nil
+		}
+		offset += n
+
+		// Reply-line = *( Reply-code "-" [ textstring ] CRLF )
+		//              Reply-code [ SP textstring ] CRLF
+		if len(line) < 3 {
+			return nil, 0, errInvalidReply
+		}
+		code, err := strconv.Atoi(string(line[:3]))
+		if err != nil || code < 200 || code > 599 {
+			return nil, 0, errInvalidReply
+		}
+		if r.code != 0 && r.code != code {
+			return nil, 0, errInvalidReply
+		}
+		r.code = code
+
+		last := true
+		text := ""
+		if len(line) > 3 {
+			switch line[3] {
+			case '-':
+				last = false
+			case ' ':
+			default:
+				return nil, 0, errInvalidReply
+			}
+			text = string(line[4:])
+		}
+		r.lines = append(r.lines, text)
+
+		if last {
+			break
+		}
+	}
+
+	r.raw = data[:offset]
+	r.size = offset
+	return r, offset, nil
+}
+
+// addressParam extracts the mailbox of a MAIL FROM or RCPT TO parameter
+// string like `FROM:<alice@example.com> SIZE=1024`.
+func addressParam(params string) string {
+	idx := strings.IndexByte(params, ':')
+	if idx < 0 {
+		return ""
+	}
+	path := strings.TrimSpace(params[idx+1:])
+	if strings.HasPrefix(path, "<") {
+		if end := strings.IndexByte(path, '>'); end > 0 {
+			return path[1:end]
+		}
+	}
+	if end := strings.IndexByte(path, ' '); end >= 0 {
+		path = path[:end]
+	}
+	return path
+}
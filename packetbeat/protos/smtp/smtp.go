@@ -0,0 +1,556 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package smtp
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/monitoring"
+
+	"github.com/elastic/beats/packetbeat/procs"
+	"github.com/elastic/beats/packetbeat/protos"
+	"github.com/elastic/beats/packetbeat/protos/tcp"
+)
+
+// SMTP protocol plugin
+type smtpPlugin struct {
+	// config
+	ports        []int
+	sendRequest  bool
+	sendResponse bool
+	sendData     bool
+
+	transactionTimeout time.Duration
+
+	results protos.Reporter
+}
+
+// clientState is what the client is expected to send next.
+type clientState uint8
+
+const (
+	stateCommand clientState = iota
+	stateData                // message content following DATA, up to <CRLF>.<CRLF>
+	stateBDAT                // message chunk of a BDAT command
+	stateAuth                // response to an AUTH challenge
+)
+
+type stream struct {
+	data []byte
+}
+
+type smtpConnectionData struct {
+	streams [2]*stream
+
+	clientDir   uint8
+	clientKnown bool
+
+	// transactions waiting for a reply, in the order the commands were sent
+	pending []*transaction
+
+	state         clientState
+	bdatRemaining int
+	// lineStart is set when the message content seen so far ends with CRLF
+	lineStart bool
+
+	greeted bool
+
+	// set once STARTTLS has been accepted, the rest of the connection is
+	// encrypted
+	tls bool
+}
+
+type transaction struct {
+	ts      time.Time
+	src     common.Endpoint
+	dst     common.Endpoint
+	command *command
+	reply   *reply
+	notes   []string
+
+	bytesIn  int
+	bytesOut int
+
+	hasData  bool
+	dataSize int
+	data     []byte
+}
+
+var (
+	debugf = logp.MakeDebug("smtp")
+)
+
+const (
+	noteNoResponse = "No response"
+	noteGap        = "Packet loss in message content"
+)
+
+var (
+	unmatchedRequests  = monitoring.NewInt(nil, "smtp.unmatched_requests")
+	unmatchedResponses = monitoring.NewInt(nil, "smtp.unmatched_responses")
+)
+
+func init() {
+	protos.Register("smtp", New)
+}
+
+func New(
+	testMode bool,
+	results protos.Reporter,
+	cfg *common.Config,
+) (protos.Plugin, error) {
+	p := &smtpPlugin{}
+	config := defaultConfig
+	if !testMode {
+		if err := cfg.Unpack(&config); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := p.init(results, &config); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (smtp *smtpPlugin) init(results protos.Reporter, config *smtpConfig) error {
+	smtp.setFromConfig(config)
+	smtp.results = results
+	return nil
+}
+
+func (smtp *smtpPlugin) setFromConfig(config *smtpConfig) {
+	smtp.ports = config.Ports
+	smtp.sendRequest = config.SendRequest
+	smtp.sendResponse = config.SendResponse
+	smtp.sendData = config.SendData
+	smtp.transactionTimeout = config.TransactionTimeout
+}
+
+func (smtp *smtpPlugin) GetPorts() []int {
+	return smtp.ports
+}
+
+func (smtp *smtpPlugin) ConnectionTimeout() time.Duration {
+	return smtp.transactionTimeout
+}
+
+func (smtp *smtpPlugin) Parse(
+	pkt *protos.Packet,
+	tcptuple *common.TCPTuple,
+	dir uint8,
+	private protos.ProtocolData,
+) protos.ProtocolData {
+	defer logp.Recover("ParseSmtp exception")
+
+	conn := ensureSMTPConnection(private)
+	if conn.tls {
+		return conn
+	}
+	if !conn.clientKnown {
+		smtp.detectClient(conn, pkt, dir)
+	}
+
+	st := conn.streams[dir]
+	if st == nil {
+		st = &stream{}
+		conn.streams[dir] = st
+	}
+	st.data = append(st.data, pkt.Payload...)
+	if len(st.data) > tcp.TCPMaxDataInStream {
+		debugf("Stream data too large, dropping SMTP stream")
+		conn.streams[dir] = nil
+		return conn
+	}
+
+	var err error
+	if dir == conn.clientDir {
+		err = smtp.parseClient(conn, st, pkt.Ts, tcptuple)
+	} else {
+		err = smtp.parseServer(conn, st, pkt.Ts)
+	}
+	if err != nil {
+		debugf("%v, dropping SMTP stream %s", err, tcptuple.String())
+		conn.streams[dir] = nil
+	}
+	if conn.tls {
+		conn.streams = [2]*stream{}
+	}
+	return conn
+}
+
+func ensureSMTPConnection(private protos.ProtocolData) *smtpConnectionData {
+	if private == nil {
+		return &smtpConnectionData{lineStart: true}
+	}
+
+	priv, ok := private.(*smtpConnectionData)
+	if !ok || priv == nil {
+		logp.Warn("smtp connection data type error, create new one")
+		return &smtpConnectionData{lineStart: true}
+	}
+	return priv
+}
+
+// detectClient finds out which direction of the connection the client sends
+// on, based on the configured ports or, if none matches, on the content.
+func (smtp *smtpPlugin) detectClient(conn *smtpConnectionData, pkt *protos.Packet, dir uint8) {
+	conn.clientKnown = true
+	for _, port := range smtp.ports {
+		if int(pkt.Tuple.DstPort) == port {
+			conn.clientDir = dir
+			return
+		}
+		if int(pkt.Tuple.SrcPort) == port {
+			conn.clientDir = 1 - dir
+			return
+		}
+	}
+
+	if _, _, err := parseReply(pkt.Payload); err == nil {
+		conn.clientDir = 1 - dir
+	} else {
+		conn.clientDir = dir
+	}
+}
+
+func (smtp *smtpPlugin) parseClient(
+	conn *smtpConnectionData,
+	st *stream,
+	ts time.Time,
+	tcptuple *common.TCPTuple,
+) error {
+	for len(st.data) > 0 {
+		switch conn.state {
+		case stateData:
+			if !smtp.consumeData(conn, st) {
+				return nil
+			}
+
+		case stateBDAT:
+			n := conn.bdatRemaining
+			if n > len(st.data) {
+				n = len(st.data)
+			}
+			if t := conn.current(); t != nil {
+				smtp.addData(t, st.data[:n])
+			}
+			st.data = st.data[n:]
+			conn.bdatRemaining -= n
+			if conn.bdatRemaining == 0 {
+				conn.state = stateCommand
+			}
+
+		case stateAuth:
+			line, n, err := readLine(st.data)
+			if err != nil || line == nil {
+				return err
+			}
+			if t := conn.current(); t != nil {
+				t.bytesIn += n
+			}
+			st.data = st.data[n:]
+			conn.state = stateCommand
+
+		default:
+			line, n, err := readLine(st.data)
+			if err != nil || line == nil {
+				return err
+			}
+			cmd, err := parseCommand(line)
+			if err != nil {
+				return err
+			}
+			cmd.ts = ts
+			cmd.size = n
+			cmd.line = append([]byte(nil), line...)
+			st.data = st.data[n:]
+			smtp.receivedCommand(conn, cmd, tcptuple)
+		}
+	}
+	st.data = nil
+	return nil
+}
+
+// consumeData handles the message content sent after DATA was accepted. It
+// returns false when more data is needed.
+func (smtp *smtpPlugin) consumeData(conn *smtpConnectionData, st *stream) bool {
+	t := conn.current()
+
+	// empty message
+	if conn.lineStart && bytes.HasPrefix(st.data, constEndOfData[2:]) {
+		st.data = st.data[len(constEndOfData)-2:]
+		conn.state = stateCommand
+		return true
+	}
+
+	if idx := bytes.Index(st.data, constEndOfData); idx >= 0 {
+		if t != nil {
+			smtp.addData(t, st.data[:idx+2])
+			t.bytesIn += len(constEndOfData) - 2
+		}
+		st.data = st.data[idx+len(constEndOfData):]
+		conn.state = stateCommand
+		return true
+	}
+
+	// keep enough data to match a terminator split across segments
+	keep := len(constEndOfData) - 1
+	if len(st.data) > keep {
+		n := len(st.data) - keep
+		if t != nil {
+			smtp.addData(t, st.data[:n])
+		}
+		conn.lineStart = bytes.HasSuffix(st.data[:n], constCRLF)
+		st.data = append([]byte(nil), st.data[n:]...)
+	}
+	return false
+}
+
+func (smtp *smtpPlugin) addData(t *transaction, data []byte) {
+	t.dataSize += len(data)
+	t.bytesIn += len(data)
+	if smtp.sendData && len(t.data) < tcp.TCPMaxDataInStream {
+		t.data = append(t.data, data...)
+	}
+}
+
+func (smtp *smtpPlugin) parseServer(conn *smtpConnectionData, st *stream, ts time.Time) error {
+	for len(st.data) > 0 {
+		r, n, err := parseReply(st.data)
+		if err != nil {
+			return err
+		}
+		if r == nil {
+			return nil
+		}
+		r.ts = ts
+		r.raw = append([]byte(nil), r.raw...)
+		st.data = st.data[n:]
+		smtp.receivedReply(conn, r)
+	}
+	st.data = nil
+	return nil
+}
+
+func (conn *smtpConnectionData) current() *transaction {
+	if len(conn.pending) == 0 {
+		return nil
+	}
+	return conn.pending[0]
+}
+
+func (smtp *smtpPlugin) receivedCommand(
+	conn *smtpConnectionData,
+	cmd *command,
+	tcptuple *common.TCPTuple,
+) {
+	t := &transaction{
+		ts:      cmd.ts,
+		command: cmd,
+		bytesIn: cmd.size,
+	}
+
+	cmdline := procs.ProcWatcher.FindProcessesTupleTCP(tcptuple.IPPort())
+	src, dst := common.MakeEndpointPair(tcptuple.BaseTuple, cmdline)
+	if conn.clientDir == tcp.TCPDirectionReverse {
+		src, dst = dst, src
+	}
+	t.src, t.dst = src, dst
+
+	if cmd.verb == "BDAT" {
+		fields := strings.Fields(cmd.params)
+		if len(fields) > 0 {
+			if size, err := strconv.Atoi(fields[0]); err == nil && size > 0 {
+				conn.bdatRemaining = size
+				conn.state = stateBDAT
+			}
+		}
+		t.hasData = true
+	}
+
+	conn.pending = append(conn.pending, t)
+}
+
+func (smtp *smtpPlugin) receivedReply(conn *smtpConnectionData, r *reply) {
+	t := conn.current()
+	if t == nil {
+		if !conn.greeted {
+			debugf("Server greeting: %d %s", r.code, strings.Join(r.lines, " "))
+		} else {
+			debugf("Response from unknown transaction. Ignoring")
+			unmatchedResponses.Add(1)
+		}
+		conn.greeted = true
+		return
+	}
+	conn.greeted = true
+	t.bytesOut += r.size
+
+	// intermediate replies, the command continues
+	switch {
+	case r.code == 354 && t.command.verb == "DATA" && !t.hasData:
+		t.hasData = true
+		conn.state = stateData
+		conn.lineStart = true
+		return
+	case r.code == 334 && t.command.verb == "AUTH":
+		conn.state = stateAuth
+		return
+	}
+
+	conn.pending = conn.pending[1:]
+	t.reply = r
+	if t.command.verb == "STARTTLS" && r.code == 220 {
+		debugf("Connection switched to TLS")
+		conn.tls = true
+	}
+	smtp.publishTransaction(t)
+}
+
+func (smtp *smtpPlugin) GapInStream(tcptuple *common.TCPTuple, dir uint8,
+	nbytes int, private protos.ProtocolData) (priv protos.ProtocolData, drop bool) {
+
+	conn := ensureSMTPConnection(private)
+	if dir == conn.clientDir && conn.state == stateData {
+		// The end of the message can still be found, only account for the
+		// missing content.
+		if t := conn.current(); t != nil {
+			t.dataSize += nbytes
+			t.bytesIn += nbytes
+			t.notes = append(t.notes, noteGap)
+		}
+		conn.lineStart = false
+		return conn, false
+	}
+
+	return private, true
+}
+
+func (smtp *smtpPlugin) ReceivedFin(tcptuple *common.TCPTuple, dir uint8,
+	private protos.ProtocolData) protos.ProtocolData {
+
+	return private
+}
+
+// Expired publishes the commands that didn't get a reply before the
+// connection timed out.
+func (smtp *smtpPlugin) Expired(tcptuple *common.TCPTuple, private protos.ProtocolData) {
+	conn, ok := private.(*smtpConnectionData)
+	if !ok || conn == nil {
+		return
+	}
+
+	for _, t := range conn.pending {
+		t.notes = append(t.notes, noteNoResponse)
+		smtp.publishTransaction(t)
+		unmatchedRequests.Add(1)
+	}
+	conn.pending = nil
+}
+
+func (smtp *smtpPlugin) publishTransaction(t *transaction) {
+	if smtp.results == nil {
+		return
+	}
+
+	cmd := t.command
+	fields := common.MapStr{
+		"type":     "smtp",
+		"status":   common.OK_STATUS,
+		"method":   cmd.verb,
+		"query":    query(cmd),
+		"bytes_in": t.bytesIn,
+		"src":      &t.src,
+		"dst":      &t.dst,
+	}
+	if len(t.notes) == 1 {
+		fields["notes"] = t.notes[0]
+	} else if len(t.notes) > 1 {
+		fields["notes"] = strings.Join(t.notes, " ")
+	}
+
+	smtpEvent := common.MapStr{}
+	fields["smtp"] = smtpEvent
+
+	switch cmd.verb {
+	case "HELO", "EHLO":
+		if cmd.params != "" {
+			smtpEvent["helo"] = cmd.params
+		}
+	case "MAIL":
+		if addr := addressParam(cmd.params); addr != "" {
+			smtpEvent["mail_from"] = addr
+		}
+	case "RCPT":
+		if addr := addressParam(cmd.params); addr != "" {
+			smtpEvent["rcpt_to"] = addr
+		}
+	}
+
+	if t.hasData {
+		smtpEvent["data"] = common.MapStr{"size": t.dataSize}
+	}
+
+	if smtp.sendRequest {
+		request := query(cmd)
+		if smtp.sendData && len(t.data) > 0 {
+			request += "\r\n" + string(t.data)
+		}
+		fields["request"] = request
+	}
+
+	if r := t.reply; r != nil {
+		fields["bytes_out"] = t.bytesOut
+		fields["responsetime"] = int32(r.ts.Sub(t.ts).Nanoseconds() / 1e6)
+		smtpEvent["response"] = common.MapStr{
+			"code":    r.code,
+			"phrases": r.lines,
+		}
+		if r.code >= 400 {
+			fields["status"] = common.ERROR_STATUS
+		}
+		if smtp.sendResponse {
+			fields["response"] = string(r.raw)
+		}
+	} else {
+		fields["status"] = common.ERROR_STATUS
+	}
+
+	smtp.results(beat.Event{
+		Timestamp: t.ts,
+		Fields:    fields,
+	})
+}
+
+// query returns the command line, without the credentials of AUTH commands.
+func query(cmd *command) string {
+	if cmd.verb == "AUTH" {
+		if fields := strings.Fields(cmd.params); len(fields) > 0 {
+			return cmd.verb + " " + fields[0]
+		}
+		return cmd.verb
+	}
+	return string(cmd.line)
+}
@@ -0,0 +1,241 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package smtp
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/packetbeat/protos"
+	"github.com/elastic/beats/packetbeat/protos/tcp"
+)
+
+type eventStore struct {
+	events []beat.Event
+}
+
+func (e *eventStore) publish(event beat.Event) {
+	e.events = append(e.events, event)
+}
+
+type testSession struct {
+	t       *testing.T
+	smtp    *smtpPlugin
+	tuple   common.TCPTuple
+	private protos.ProtocolData
+	ts      time.Time
+}
+
+func newTestSession(t *testing.T, config *smtpConfig) (*testSession, *eventStore) {
+	store := &eventStore{}
+	smtp := &smtpPlugin{}
+	config.Ports = []int{25}
+	if err := smtp.init(store.publish, config); err != nil {
+		t.Fatal(err)
+	}
+
+	ipTuple := common.NewIPPortTuple(4,
+		net.ParseIP("192.0.2.1"), 51234,
+		net.ParseIP("192.0.2.25"), 25)
+	return &testSession{
+		t:     t,
+		smtp:  smtp,
+		tuple: common.TCPTupleFromIPPort(&ipTuple, 1),
+		ts:    time.Now(),
+	}, store
+}
+
+func (s *testSession) send(dir uint8, payload string) {
+	s.ts = s.ts.Add(10 * time.Millisecond)
+	ipTuple := *s.tuple.IPPort()
+	if dir == tcp.TCPDirectionReverse {
+		ipTuple = common.NewIPPortTuple(4,
+			s.tuple.DstIP, s.tuple.DstPort, s.tuple.SrcIP, s.tuple.SrcPort)
+	}
+	pkt := &protos.Packet{Ts: s.ts, Tuple: ipTuple, Payload: []byte(payload)}
+	s.private = s.smtp.Parse(pkt, &s.tuple, dir, s.private)
+}
+
+func (s *testSession) client(payload string) {
+	s.send(tcp.TCPDirectionOriginal, payload)
+}
+
+func (s *testSession) server(payload string) {
+	s.send(tcp.TCPDirectionReverse, payload)
+}
+
+func fieldValue(t *testing.T, event beat.Event, key string) interface{} {
+	v, err := event.Fields.GetValue(key)
+	if err != nil {
+		t.Errorf("missing field %s: %v", key, err)
+	}
+	return v
+}
+
+func TestMailTransaction(t *testing.T) {
+	config := defaultConfig
+	config.SendRequest = true
+	s, store := newTestSession(t, &config)
+
+	s.server("220 mx.example.com ESMTP ready\r\n")
+	s.client("EHLO client.example.com\r\n")
+	s.server("250-mx.example.com\r\n250-PIPELINING\r\n250 SIZE 10240000\r\n")
+	s.client("MAIL FROM:<alice@example.com> SIZE=42\r\nRCPT TO:<bob@example.com>\r\nRCPT TO:<carol@example.org>\r\nDATA\r\n")
+	s.server("250 OK\r\n250 OK\r\n550 No such user\r\n")
+	s.server("354 End data with <CR><LF>.<CR><LF>\r\n")
+	s.client("Subject: hello\r\n\r\nHi Bob,\r\n")
+	s.client("..and see you\r")
+	s.client("\n.\r\n")
+	s.server("250 OK queued as 12345\r\n")
+	s.client("QUIT\r\n")
+	s.server("221 Bye\r\n")
+
+	if !assert.Len(t, store.events, 6) {
+		return
+	}
+
+	ehlo := store.events[0]
+	assert.Equal(t, "EHLO", fieldValue(t, ehlo, "method"))
+	assert.Equal(t, "client.example.com", fieldValue(t, ehlo, "smtp.helo"))
+	assert.Equal(t, []string{"mx.example.com", "PIPELINING", "SIZE 10240000"},
+		fieldValue(t, ehlo, "smtp.response.phrases"))
+	assert.Equal(t, "192.0.2.1", fieldValue(t, ehlo, "src").(*common.Endpoint).IP)
+
+	mail := store.events[1]
+	assert.Equal(t, "alice@example.com", fieldValue(t, mail, "smtp.mail_from"))
+	assert.Equal(t, "MAIL FROM:<alice@example.com> SIZE=42", fieldValue(t, mail, "query"))
+
+	rcpt := store.events[3]
+	assert.Equal(t, "carol@example.org", fieldValue(t, rcpt, "smtp.rcpt_to"))
+	assert.Equal(t, 550, fieldValue(t, rcpt, "smtp.response.code"))
+	assert.Equal(t, common.ERROR_STATUS, fieldValue(t, rcpt, "status"))
+
+	data := store.events[4]
+	assert.Equal(t, "DATA", fieldValue(t, data, "method"))
+	assert.Equal(t, 250, fieldValue(t, data, "smtp.response.code"))
+	assert.Equal(t, common.OK_STATUS, fieldValue(t, data, "status"))
+	assert.Equal(t, len("Subject: hello\r\n\r\nHi Bob,\r\n..and see you\r\n"),
+		fieldValue(t, data, "smtp.data.size"))
+	// the message isn't stored by default
+	assert.Equal(t, "DATA", fieldValue(t, data, "request"))
+
+	assert.Equal(t, "QUIT", fieldValue(t, store.events[5], "method"))
+}
+
+func TestSendData(t *testing.T) {
+	config := defaultConfig
+	config.SendRequest = true
+	config.SendData = true
+	s, store := newTestSession(t, &config)
+
+	s.client("DATA\r\n")
+	s.server("354 Go ahead\r\n")
+	s.client("Subject: hi\r\n\r\nbody\r\n.\r\n")
+	s.server("250 OK\r\n")
+
+	if assert.Len(t, store.events, 1) {
+		assert.Equal(t, "DATA\r\nSubject: hi\r\n\r\nbody\r\n", fieldValue(t, store.events[0], "request"))
+	}
+}
+
+func TestBDAT(t *testing.T) {
+	config := defaultConfig
+	s, store := newTestSession(t, &config)
+
+	s.client("BDAT 10 LAST\r\n0123")
+	s.client("456789NOOP\r\n")
+	s.server("250 Message accepted\r\n250 OK\r\n")
+
+	if assert.Len(t, store.events, 2) {
+		assert.Equal(t, 10, fieldValue(t, store.events[0], "smtp.data.size"))
+		assert.Equal(t, "NOOP", fieldValue(t, store.events[1], "method"))
+	}
+}
+
+func TestAuthIsMasked(t *testing.T) {
+	config := defaultConfig
+	config.SendRequest = true
+	s, store := newTestSession(t, &config)
+
+	s.client("AUTH LOGIN\r\n")
+	s.server("334 VXNlcm5hbWU6\r\n")
+	s.client("YWxpY2U=\r\n")
+	s.server("334 UGFzc3dvcmQ6\r\n")
+	s.client("c2VjcmV0\r\n")
+	s.server("235 Authentication successful\r\n")
+	s.client("AUTH PLAIN AGFsaWNlAHNlY3JldA==\r\n")
+	s.server("535 Authentication failed\r\n")
+
+	if assert.Len(t, store.events, 2) {
+		assert.Equal(t, "AUTH LOGIN", fieldValue(t, store.events[0], "query"))
+		assert.Equal(t, 235, fieldValue(t, store.events[0], "smtp.response.code"))
+		assert.Equal(t, "AUTH PLAIN", fieldValue(t, store.events[1], "request"))
+		assert.Equal(t, common.ERROR_STATUS, fieldValue(t, store.events[1], "status"))
+	}
+}
+
+func TestStartTLS(t *testing.T) {
+	config := defaultConfig
+	s, store := newTestSession(t, &config)
+
+	s.client("STARTTLS\r\n")
+	s.server("220 Ready to start TLS\r\n")
+	s.client("\x16\x03\x01\x02\x00\x01\x00\x01\xfc\x03\x03")
+	s.server("\x16\x03\x03\x00\x5d\x02\x00\x00")
+
+	if assert.Len(t, store.events, 1) {
+		assert.Equal(t, "STARTTLS", fieldValue(t, store.events[0], "method"))
+	}
+	assert.True(t, s.private.(*smtpConnectionData).tls)
+}
+
+func TestExpired(t *testing.T) {
+	config := defaultConfig
+	s, store := newTestSession(t, &config)
+
+	s.client("NOOP\r\n")
+	s.smtp.Expired(&s.tuple, s.private)
+
+	if assert.Len(t, store.events, 1) {
+		assert.Equal(t, noteNoResponse, fieldValue(t, store.events[0], "notes"))
+		assert.Equal(t, common.ERROR_STATUS, fieldValue(t, store.events[0], "status"))
+	}
+}
+
+func TestParseReplyInvalid(t *testing.T) {
+	for _, raw := range []string{
+		"hello\r\n",
+		"250-first\r\n251 second\r\n",
+		"2500 OK\r\n",
+		"099 OK\r\n",
+	} {
+		_, _, err := parseReply([]byte(raw))
+		assert.Error(t, err, raw)
+	}
+
+	r, _, err := parseReply([]byte("250-first\r\n"))
+	assert.NoError(t, err)
+	assert.Nil(t, r)
+}
@@ -0,0 +1,120 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tcp
+
+import (
+	"github.com/tsg/gopacket/layers"
+
+	"github.com/elastic/beats/packetbeat/protos"
+)
+
+// maxPendingSegments limits the number of buffered segments of a stream
+// direction, as segments without payload don't count against the window.
+const maxPendingSegments = 1024
+
+// segment is a TCP segment received ahead of the expected sequence number.
+type segment struct {
+	hdr layers.TCP
+	pkt protos.Packet
+}
+
+// segmentBuffer holds the out of order segments of a stream direction,
+// sorted by sequence number.
+type segmentBuffer struct {
+	segments []segment
+	bytes    int
+}
+
+func (b *segmentBuffer) empty() bool {
+	return len(b.segments) == 0
+}
+
+// add buffers a copy of the segment. It returns false if the segment does
+// not fit into the window or too many segments are buffered.
+func (b *segmentBuffer) add(tcphdr *layers.TCP, pkt *protos.Packet, window int) bool {
+	if b.bytes+len(pkt.Payload) > window || len(b.segments) >= maxPendingSegments {
+		return false
+	}
+
+	b.insert(tcphdr, pkt)
+	return true
+}
+
+// insert buffers a copy of the segment in sequence number order, ignoring the
+// limits.
+func (b *segmentBuffer) insert(tcphdr *layers.TCP, pkt *protos.Packet) {
+	i := len(b.segments)
+	for i > 0 && tcpSeqBefore(tcphdr.Seq, b.segments[i-1].hdr.Seq) {
+		i--
+	}
+	if i > 0 {
+		prev := &b.segments[i-1]
+		if prev.hdr.Seq == tcphdr.Seq && len(prev.pkt.Payload) >= len(pkt.Payload) {
+			// retransmission of a buffered segment
+			return
+		}
+	}
+
+	// only keep the header fields used by the stream, not referencing the
+	// packet buffer
+	seg := segment{
+		hdr: layers.TCP{Seq: tcphdr.Seq, FIN: tcphdr.FIN},
+		pkt: *pkt,
+	}
+	seg.pkt.Payload = append([]byte(nil), pkt.Payload...)
+
+	b.segments = append(b.segments, segment{})
+	copy(b.segments[i+1:], b.segments[i:])
+	b.segments[i] = seg
+	b.bytes += len(pkt.Payload)
+}
+
+func (b *segmentBuffer) pop() *segment {
+	seg := &b.segments[0]
+	b.segments = b.segments[1:]
+	b.bytes -= len(seg.pkt.Payload)
+	if len(b.segments) == 0 {
+		b.segments = nil
+	}
+	return seg
+}
+
+// drainPending processes the buffered segments no longer preceded by a gap.
+func (stream *TCPStream) drainPending() {
+	pending := &stream.conn.pending[stream.dir]
+	for !pending.empty() {
+		lastSeq := stream.conn.lastSeq[stream.dir]
+		if tcpSeqBefore(lastSeq, pending.segments[0].hdr.Seq) {
+			return
+		}
+
+		filledGaps.Add(1)
+		seg := pending.pop()
+		stream.processSegment(&seg.hdr, &seg.pkt)
+	}
+}
+
+// flushPending processes all buffered segments, reporting the gaps which
+// could not be filled to the protocol analyzer.
+func (stream *TCPStream) flushPending() {
+	pending := &stream.conn.pending[stream.dir]
+	for !pending.empty() {
+		seg := pending.pop()
+		stream.processSegment(&seg.hdr, &seg.pkt)
+	}
+}
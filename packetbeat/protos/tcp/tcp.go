@@ -23,9 +23,11 @@ import (
 	"time"
 
 	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/atomic"
 	"github.com/elastic/beats/libbeat/logp"
 	"github.com/elastic/beats/libbeat/monitoring"
 
+	"github.com/elastic/beats/packetbeat/config"
 	"github.com/elastic/beats/packetbeat/flows"
 	"github.com/elastic/beats/packetbeat/protos"
 
@@ -34,17 +36,29 @@ import (
 
 const TCPMaxDataInStream = 10 * (1 << 20)
 
+// DefaultReassemblyWindow is the default number of bytes buffered per TCP
+// stream direction, while waiting for missing segments.
+const DefaultReassemblyWindow = 64 * (1 << 10)
+
 const (
 	TCPDirectionReverse  = 0
 	TCPDirectionOriginal = 1
 )
 
+// streamID is shared by all TCP instances, so the stream IDs stay unique when
+// the packets are processed by several workers.
+var streamID atomic.Uint32
+
 type TCP struct {
-	id           uint32
 	streams      *common.Cache
-	portMap      map[uint16]protos.Protocol
 	protocols    protos.Protocols
 	expiredConns expirationQueue
+
+	portsMutex sync.RWMutex
+	portMap    map[uint16]protos.Protocol
+
+	// maximum number of out of order bytes buffered per stream direction
+	reassemblyWindow int
 }
 
 type expiredConnection struct {
@@ -63,6 +77,8 @@ type Processor interface {
 
 var (
 	droppedBecauseOfGaps = monitoring.NewInt(nil, "tcp.dropped_because_of_gaps")
+	outOfOrderSegments   = monitoring.NewInt(nil, "tcp.out_of_order_segments")
+	filledGaps           = monitoring.NewInt(nil, "tcp.filled_gaps")
 )
 
 type seqCompare int
@@ -79,11 +95,13 @@ var (
 )
 
 func (tcp *TCP) getID() uint32 {
-	tcp.id++
-	return tcp.id
+	return streamID.Inc()
 }
 
 func (tcp *TCP) decideProtocol(tuple *common.IPPortTuple) protos.Protocol {
+	tcp.portsMutex.RLock()
+	defer tcp.portsMutex.RUnlock()
+
 	protocol, exists := tcp.portMap[tuple.SrcPort]
 	if exists {
 		return protocol
@@ -114,6 +132,9 @@ type TCPConnection struct {
 
 	lastSeq [2]uint32
 
+	// segments received ahead of lastSeq, per direction
+	pending [2]segmentBuffer
+
 	// protocols private data
 	data protos.ProtocolData
 }
@@ -183,6 +204,37 @@ func (tcp *TCP) Process(id *flows.FlowID, tcphdr *layers.TCP, pkt *protos.Packet
 		return
 	}
 
+	pending := &conn.pending[stream.dir]
+	lastSeq := conn.lastSeq[stream.dir]
+	ahead := tcpSeqBefore(lastSeq, tcphdr.Seq) || (len(pkt.Payload) == 0 && !pending.empty())
+	if !created && lastSeq != 0 && ahead {
+		// segment ahead of the stream, wait for the gap to be filled
+		if pending.add(tcphdr, pkt, tcp.reassemblyWindow) {
+			outOfOrderSegments.Add(1)
+			if isDebug {
+				debugf("Buffering out of order segment. seq: %d, last_seq: %d, buffered: %d",
+					tcphdr.Seq, lastSeq, pending.bytes)
+			}
+			return
+		}
+
+		// the window is full, give up on the missing segments. The segment
+		// may precede buffered ones, so it's flushed in order with them.
+		pending.insert(tcphdr, pkt)
+		stream.flushPending()
+		return
+	}
+
+	stream.processSegment(tcphdr, pkt)
+	stream.drainPending()
+}
+
+// processSegment forwards the segment to the protocol analyzer, ignoring
+// retransmitted data and reporting gaps before the segment.
+func (stream *TCPStream) processSegment(tcphdr *layers.TCP, pkt *protos.Packet) {
+	conn := stream.conn
+	tcp := conn.tcp
+
 	tcpStartSeq := tcphdr.Seq
 	tcpSeq := tcpStartSeq + uint32(len(pkt.Payload))
 	lastSeq := conn.lastSeq[stream.dir]
@@ -202,10 +254,6 @@ func (tcp *TCP) Process(id *flows.FlowID, tcphdr *layers.TCP, pkt *protos.Packet
 
 		switch tcpSeqCompare(lastSeq, tcpStartSeq) {
 		case seqLT: // lastSeq < tcpStartSeq => Gap in tcp stream detected
-			if created {
-				break
-			}
-
 			gap := int(tcpStartSeq - lastSeq)
 			debugf("Gap in tcp stream. last_seq: %d, seq: %d, gap: %d", lastSeq, tcpStartSeq, gap)
 			drop := stream.gapInStream(gap)
@@ -297,11 +345,11 @@ func tcpSeqBeforeEq(seq1 uint32, seq2 uint32) bool {
 	return int32(seq1-seq2) <= 0
 }
 
-func buildPortsMap(plugins map[protos.Protocol]protos.TCPPlugin) (map[uint16]protos.Protocol, error) {
+func buildPortsMap(p protos.Protocols) (map[uint16]protos.Protocol, error) {
 	var res = map[uint16]protos.Protocol{}
 
-	for proto, protoPlugin := range plugins {
-		for _, port := range protoPlugin.GetPorts() {
+	for proto := range p.GetAllTCP() {
+		for _, port := range p.GetPorts(proto) {
 			oldProto, exists := res[uint16(port)]
 			if exists {
 				if oldProto == proto {
@@ -318,17 +366,18 @@ func buildPortsMap(plugins map[protos.Protocol]protos.TCPPlugin) (map[uint16]pro
 }
 
 // Creates and returns a new Tcp.
-func NewTCP(p protos.Protocols) (*TCP, error) {
+func NewTCP(p protos.Protocols, cfg config.TCPConfig) (*TCP, error) {
 	isDebug = logp.IsDebug("tcp")
 
-	portMap, err := buildPortsMap(p.GetAllTCP())
+	portMap, err := buildPortsMap(p)
 	if err != nil {
 		return nil, err
 	}
 
 	tcp := &TCP{
-		protocols: p,
-		portMap:   portMap,
+		protocols:        p,
+		portMap:          portMap,
+		reassemblyWindow: cfg.ReassemblyWindow,
 	}
 	tcp.streams = common.NewCacheWithRemovalListener(
 		protos.DefaultTransactionExpiration,
@@ -343,19 +392,52 @@ func NewTCP(p protos.Protocols) (*TCP, error) {
 	return tcp, nil
 }
 
+// UpdatePorts rebuilds the port to protocol mapping from the current ports
+// of the protocols. It is called after the ports have been changed at runtime.
+func (tcp *TCP) UpdatePorts() error {
+	portMap, err := buildPortsMap(tcp.protocols)
+	if err != nil {
+		return err
+	}
+
+	tcp.portsMutex.Lock()
+	tcp.portMap = portMap
+	tcp.portsMutex.Unlock()
+
+	if isDebug {
+		debugf("tcp", "Port map: %v", portMap)
+	}
+	return nil
+}
+
+// Flush removes all TCP streams, notifying the protocol plugins of the
+// connections as on expiry. It must not be called concurrently to Process.
+func (tcp *TCP) Flush() {
+	tcp.streams.RemoveAll()
+	tcp.expiredConns.notifyAll()
+}
+
 func (tcp *TCP) removalListener(_ common.Key, value common.Value) {
 	conn := value.(*TCPConnection)
 	mod := conn.tcp.protocols.GetTCP(conn.protocol)
 	if mod != nil {
-		awareMod, ok := mod.(protos.ExpirationAwareTCPPlugin)
-		if ok {
+		awareMod, _ := mod.(protos.ExpirationAwareTCPPlugin)
+		if awareMod != nil || !conn.pending[0].empty() || !conn.pending[1].empty() {
 			tcp.expiredConns.add(awareMod, conn)
 		}
 	}
 }
 
 func (ec *expiredConnection) notify() {
-	ec.mod.Expired(&ec.conn.tcptuple, ec.conn.data)
+	// process the segments still waiting for missing data
+	for dir := range ec.conn.pending {
+		stream := TCPStream{conn: ec.conn, dir: uint8(dir)}
+		stream.flushPending()
+	}
+
+	if ec.mod != nil {
+		ec.mod.Expired(&ec.conn.tcptuple, ec.conn.data)
+	}
 }
 
 func (eq *expirationQueue) add(mod protos.ExpirationAwareTCPPlugin, conn *TCPConnection) {
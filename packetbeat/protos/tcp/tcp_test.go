@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/packetbeat/config"
 	"github.com/elastic/beats/packetbeat/protos"
 
 	"github.com/stretchr/testify/assert"
@@ -152,7 +153,7 @@ func Test_configToPortsMap(t *testing.T) {
 	}
 
 	for _, test := range configTests {
-		output, err := buildPortsMap(test.Input)
+		output, err := buildPortsMap(protocols{tcp: test.Input})
 		assert.Nil(t, err)
 		assert.Equal(t, test.Output, output)
 	}
@@ -177,7 +178,7 @@ func Test_configToPortsMap_negative(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		_, err := buildPortsMap(test.Input)
+		_, err := buildPortsMap(protocols{tcp: test.Input})
 		assert.NotNil(t, err)
 		assert.Contains(t, err.Error(), test.Err)
 	}
@@ -192,6 +193,7 @@ type protocols struct {
 var _ protos.Protocols = &protocols{}
 
 func (p protocols) BpfFilter(withVlans bool, withICMP bool) string       { return "" }
+func (p protocols) GetPorts(proto protos.Protocol) []int                 { return p.tcp[proto].GetPorts() }
 func (p protocols) GetTCP(proto protos.Protocol) protos.TCPPlugin        { return p.tcp[proto] }
 func (p protocols) GetUDP(proto protos.Protocol) protos.UDPPlugin        { return nil }
 func (p protocols) GetAll() map[protos.Protocol]protos.Plugin            { return nil }
@@ -295,7 +297,7 @@ func TestTCSeqPayload(t *testing.T) {
 					parse: makeCollectPayload(&state, true),
 				},
 			},
-		})
+		}, config.TCPConfig{})
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -323,6 +325,120 @@ func TestTCSeqPayload(t *testing.T) {
 	}
 }
 
+func TestTCPReassembly(t *testing.T) {
+	type segment struct {
+		seq     uint32
+		payload []byte
+	}
+
+	tests := []struct {
+		name          string
+		window        int
+		segments      []segment
+		expectedGaps  int
+		expectedState []byte
+	}{
+		{
+			name:   "Gap filled",
+			window: 100,
+			segments: []segment{
+				{1, []byte{1, 2}},
+				{5, []byte{5, 6}},
+				{3, []byte{3, 4}},
+			},
+			expectedState: []byte{1, 2, 3, 4, 5, 6},
+		},
+		{
+			name:   "Retransmitted out of order segment",
+			window: 100,
+			segments: []segment{
+				{1, []byte{1, 2}},
+				{5, []byte{5, 6}},
+				{5, []byte{5, 6}},
+				{3, []byte{3, 4}},
+				{7, []byte{7}},
+			},
+			expectedState: []byte{1, 2, 3, 4, 5, 6, 7},
+		},
+		{
+			name:   "Overlapping buffered segment",
+			window: 100,
+			segments: []segment{
+				{1, []byte{1, 2}},
+				{4, []byte{4, 5}},
+				{3, []byte{3, 4}},
+			},
+			expectedState: []byte{1, 2, 3, 4, 5},
+		},
+		{
+			name:   "Window exceeded",
+			window: 4,
+			segments: []segment{
+				{1, []byte{1, 2}},
+				{5, []byte{5, 6, 7}},
+				{8, []byte{8, 9}},
+			},
+			expectedGaps:  2,
+			expectedState: []byte{5, 6, 7, 8, 9},
+		},
+		{
+			name:   "Window exceeded by segment filling a gap",
+			window: 3,
+			segments: []segment{
+				{1, []byte{1, 2}},
+				{7, []byte{7, 8}},
+				{4, []byte{4, 5}},
+			},
+			expectedGaps:  2,
+			expectedState: []byte{7, 8},
+		},
+	}
+
+	for _, test := range tests {
+		gap := 0
+		var state []byte
+		tcp, err := NewTCP(protocols{
+			tcp: map[protos.Protocol]protos.TCPPlugin{
+				httpProtocol: &TestProtocol{
+					Ports: []int{ServerPort},
+					gap:   makeCountGaps(nil, &gap),
+					parse: makeCollectPayload(&state, true),
+				},
+			},
+		}, config.TCPConfig{ReassemblyWindow: test.window})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		addr := common.NewIPPortTuple(4,
+			net.ParseIP(ServerIP), ServerPort,
+			net.ParseIP(ClientIP), uint16(rand.Intn(65535)))
+
+		for _, segment := range test.segments {
+			hdr := &layers.TCP{Seq: segment.seq}
+			pkt := &protos.Packet{
+				Ts:      time.Now(),
+				Tuple:   addr,
+				Payload: segment.payload,
+			}
+			tcp.Process(nil, hdr, pkt)
+		}
+
+		assert.Equal(t, test.expectedGaps, gap, test.name)
+		assert.Equal(t, test.expectedState, state, test.name)
+	}
+}
+
+func TestSegmentBufferLimit(t *testing.T) {
+	var pending segmentBuffer
+	pkt := &protos.Packet{}
+	for i := 0; i < maxPendingSegments; i++ {
+		assert.True(t, pending.add(&layers.TCP{Seq: uint32(i), FIN: true}, pkt, 100))
+	}
+	assert.False(t, pending.add(&layers.TCP{Seq: maxPendingSegments, FIN: true}, pkt, 100))
+	assert.Len(t, pending.segments, maxPendingSegments)
+}
+
 // Benchmark that runs with parallelism to help find concurrency related
 // issues. To run with parallelism, the 'go test' cpu flag must be set
 // greater than 1, otherwise it just runs concurrently but not in parallel.
@@ -331,7 +447,7 @@ func BenchmarkParallelProcess(b *testing.B) {
 	p := protocols{}
 	p.tcp = make(map[protos.Protocol]protos.TCPPlugin)
 	p.tcp[1] = &TestProtocol{Ports: []int{ServerPort}}
-	tcp, _ := NewTCP(p)
+	tcp, _ := NewTCP(p, config.TCPConfig{})
 
 	b.ResetTimer()
 	b.RunParallel(func(pb *testing.PB) {
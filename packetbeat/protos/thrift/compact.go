@@ -0,0 +1,443 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package thrift
+
+import (
+	"encoding/binary"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/beats/libbeat/logp"
+)
+
+// TCompactProtocol constants
+const (
+	thriftCompactProtocolID  = 0x82
+	thriftCompactVersion1    = 1
+	thriftCompactVersionMask = 0x1f
+	thriftCompactTypeShift   = 5
+)
+
+// TCompactProtocol types
+const (
+	thriftCompactTypeStop      = 0
+	thriftCompactTypeBoolTrue  = 1
+	thriftCompactTypeBoolFalse = 2
+	thriftCompactTypeByte      = 3
+	thriftCompactTypeI16       = 4
+	thriftCompactTypeI32       = 5
+	thriftCompactTypeI64       = 6
+	thriftCompactTypeDouble    = 7
+	thriftCompactTypeBinary    = 8
+	thriftCompactTypeList      = 9
+	thriftCompactTypeSet       = 10
+	thriftCompactTypeMap       = 11
+	thriftCompactTypeStruct    = 12
+)
+
+// readVarint decodes an unsigned LEB128 integer. It returns ok=false if the
+// value overflows 64 bits.
+func readVarint(data []byte) (value uint64, ok bool, complete bool, off int) {
+	value, off = binary.Uvarint(data)
+	if off == 0 {
+		return 0, true, false, 0
+	}
+	if off < 0 {
+		return 0, false, false, 0
+	}
+	return value, true, true, off
+}
+
+func readZigzag(data []byte) (value int64, ok bool, complete bool, off int) {
+	u, ok, complete, off := readVarint(data)
+	return int64(u>>1) ^ -int64(u&1), ok, complete, off
+}
+
+func (thrift *thriftPlugin) readCompactMessageBegin(s *thriftStream) (bool, bool) {
+	m := s.message
+	data := s.data[s.parseOffset:]
+
+	if len(data) < 3 {
+		return true, false // ok, not complete
+	}
+	if data[0] != thriftCompactProtocolID {
+		logp.Debug("thrift", "Unexpected compact protocol id: %d", data[0])
+		return false, false
+	}
+	m.version = uint32(data[1] & thriftCompactVersionMask)
+	if m.version != thriftCompactVersion1 {
+		logp.Debug("thrift", "Unexpected version: %d", m.version)
+	}
+	m.Type = uint32(data[1] >> thriftCompactTypeShift)
+	offset := 2
+
+	seqID, ok, complete, off := readVarint(data[offset:])
+	if !ok {
+		return false, false
+	}
+	if !complete {
+		return true, false
+	}
+	m.seqID = uint32(seqID)
+	offset += off
+
+	m.method, ok, complete, off = thrift.readCompactString(data[offset:])
+	if !ok {
+		return false, false // not ok, not complete
+	}
+	if !complete {
+		logp.Debug("thriftdetailed", "Method name not complete")
+		return true, false // ok, not complete
+	}
+	offset += off
+
+	logp.Debug("thriftdetailed", "method = %s", m.method)
+
+	s.parseOffset += offset
+	return true, true
+}
+
+func (thrift *thriftPlugin) readCompactString(data []byte) (value string, ok bool, complete bool, off int) {
+	size, ok, complete, off := readVarint(data)
+	if !ok || !complete {
+		return "", ok, complete, 0
+	}
+	if size > math.MaxInt32 {
+		return "", false, false, 0 // not ok
+	}
+	sz := int(size)
+	if len(data[off:]) < sz {
+		return "", true, false, 0 // ok, not complete
+	}
+
+	if sz > thrift.stringMaxSize {
+		value = string(data[off:off+thrift.stringMaxSize]) + "..."
+	} else {
+		value = string(data[off : off+sz])
+	}
+	return value, true, true, off + sz
+}
+
+func (thrift *thriftPlugin) readCompactAndQuoteString(data []byte) (value string, ok bool, complete bool, off int) {
+	value, ok, complete, off = thrift.readCompactString(data)
+	return thrift.quoteString(value), ok, complete, off
+}
+
+// readCompactBool reads a bool element of a collection. Bools of struct
+// fields are encoded in the field type.
+func (thrift *thriftPlugin) readCompactBool(data []byte) (value string, ok bool, complete bool, off int) {
+	if len(data) < 1 {
+		return "", true, false, 0
+	}
+	if data[0] == thriftCompactTypeBoolTrue {
+		value = "true"
+	} else {
+		value = "false"
+	}
+	return value, true, true, 1
+}
+
+func (thrift *thriftPlugin) readCompactInt(data []byte) (value string, ok bool, complete bool, off int) {
+	i, ok, complete, off := readZigzag(data)
+	if !ok || !complete {
+		return "", ok, complete, 0
+	}
+	return strconv.FormatInt(i, 10), true, true, off
+}
+
+func (thrift *thriftPlugin) readCompactDouble(data []byte) (value string, ok bool, complete bool, off int) {
+	if len(data) < 8 {
+		return "", true, false, 0
+	}
+
+	bits := binary.LittleEndian.Uint64(data[:8])
+	double := math.Float64frombits(bits)
+	value = strconv.FormatFloat(double, 'f', -1, 64)
+
+	return value, true, true, 8
+}
+
+// Common implementation for lists and sets (they share the same compact repr).
+func (thrift *thriftPlugin) readCompactListOrSet(data []byte) (value string, ok bool, complete bool, off int) {
+	if len(data) < 1 {
+		return "", true, false, 0
+	}
+	typ := data[0] & 0x0f
+	sz := int(data[0] >> 4)
+	offset := 1
+	if sz == 0x0f {
+		size, ok, complete, n := readVarint(data[offset:])
+		if !ok || !complete {
+			return "", ok, complete, 0
+		}
+		if size > math.MaxInt32 {
+			logp.Debug("thrift", "List/Set too big: %d", size)
+			return "", false, false, 0
+		}
+		sz = int(size)
+		offset += n
+	}
+
+	funcReader, typeFound := thrift.funcReadersByCompactType(typ)
+	if !typeFound {
+		logp.Debug("thrift", "Field type %d not known", typ)
+		return "", false, false, 0
+	}
+
+	fields := []string{}
+	for i := 0; i < sz; i++ {
+		value, ok, complete, bytesRead := funcReader(data[offset:])
+		if !ok {
+			return "", false, false, 0
+		}
+		if !complete {
+			return "", true, false, 0
+		}
+
+		if i < thrift.collectionMaxSize {
+			fields = append(fields, value)
+		} else if i == thrift.collectionMaxSize {
+			fields = append(fields, "...")
+		}
+		offset += bytesRead
+	}
+
+	return strings.Join(fields, ", "), true, true, offset
+}
+
+func (thrift *thriftPlugin) readCompactSet(data []byte) (value string, ok bool, complete bool, off int) {
+	value, ok, complete, off = thrift.readCompactListOrSet(data)
+	if value != "" {
+		value = "{" + value + "}"
+	}
+	return value, ok, complete, off
+}
+
+func (thrift *thriftPlugin) readCompactList(data []byte) (value string, ok bool, complete bool, off int) {
+	value, ok, complete, off = thrift.readCompactListOrSet(data)
+	if value != "" {
+		value = "[" + value + "]"
+	}
+	return value, ok, complete, off
+}
+
+func (thrift *thriftPlugin) readCompactMap(data []byte) (value string, ok bool, complete bool, off int) {
+	size, ok, complete, offset := readVarint(data)
+	if !ok || !complete {
+		return "", ok, complete, 0
+	}
+	if size == 0 {
+		// the key and value types are omitted from empty maps
+		return "{}", true, true, offset
+	}
+	if size > math.MaxInt32 {
+		logp.Debug("thrift", "Map too big: %d", size)
+		return "", false, false, 0
+	}
+	if len(data[offset:]) < 1 {
+		return "", true, false, 0
+	}
+	typeKey := data[offset] >> 4
+	typeValue := data[offset] & 0x0f
+	offset++
+
+	funcReaderKey, typeFound := thrift.funcReadersByCompactType(typeKey)
+	if !typeFound {
+		logp.Debug("thrift", "Field type %d not known", typeKey)
+		return "", false, false, 0
+	}
+
+	funcReaderValue, typeFound := thrift.funcReadersByCompactType(typeValue)
+	if !typeFound {
+		logp.Debug("thrift", "Field type %d not known", typeValue)
+		return "", false, false, 0
+	}
+
+	fields := []string{}
+	for i := 0; i < int(size); i++ {
+		key, ok, complete, bytesRead := funcReaderKey(data[offset:])
+		if !ok {
+			return "", false, false, 0
+		}
+		if !complete {
+			return "", true, false, 0
+		}
+		offset += bytesRead
+
+		value, ok, complete, bytesRead := funcReaderValue(data[offset:])
+		if !ok {
+			return "", false, false, 0
+		}
+		if !complete {
+			return "", true, false, 0
+		}
+		offset += bytesRead
+
+		if i < thrift.collectionMaxSize {
+			fields = append(fields, key+": "+value)
+		} else if i == thrift.collectionMaxSize {
+			fields = append(fields, "...")
+		}
+	}
+
+	return "{" + strings.Join(fields, ", ") + "}", true, true, offset
+}
+
+// readCompactFieldHeader reads the type and id of a struct field. The id is
+// either encoded as a delta to the id of the previous field in the high
+// nibble of the type, or follows the type as an i16.
+func readCompactFieldHeader(data []byte, lastID uint16) (field thriftField, ok bool, complete bool, off int) {
+	if len(data) < 1 {
+		return field, true, false, 0
+	}
+	field.Type = data[0] & 0x0f
+	if field.Type == thriftCompactTypeStop {
+		return field, true, true, 1
+	}
+
+	delta := uint16(data[0] >> 4)
+	if delta != 0 {
+		field.id = lastID + delta
+		return field, true, true, 1
+	}
+
+	id, ok, complete, n := readZigzag(data[1:])
+	if !ok || !complete {
+		return field, ok, complete, 0
+	}
+	field.id = uint16(id)
+	return field, true, true, 1 + n
+}
+
+// readCompactFieldValue reads the value of a struct field, bool values being
+// part of the field header.
+func (thrift *thriftPlugin) readCompactFieldValue(field *thriftField, data []byte) (ok bool, complete bool, off int) {
+	switch field.Type {
+	case thriftCompactTypeBoolTrue:
+		field.value = "true"
+		return true, true, 0
+	case thriftCompactTypeBoolFalse:
+		field.value = "false"
+		return true, true, 0
+	}
+
+	funcReader, typeFound := thrift.funcReadersByCompactType(field.Type)
+	if !typeFound {
+		logp.Debug("thrift", "Field type %d not known", field.Type)
+		return false, false, 0
+	}
+
+	field.value, ok, complete, off = funcReader(data)
+	return ok, complete, off
+}
+
+func (thrift *thriftPlugin) readCompactStruct(data []byte) (value string, ok bool, complete bool, off int) {
+	var lastID uint16
+	offset := 0
+	fields := []thriftField{}
+
+	// Loop until hitting a STOP or reaching the maximum number of elements
+	// we follow in a stream (at which point, we assume we interpreted something
+	// wrong).
+	for i := 0; ; i++ {
+		if i >= thrift.dropAfterNStructFields {
+			logp.Debug("thrift", "Too many fields in struct. Dropping as error")
+			return "", false, false, 0
+		}
+
+		field, ok, complete, bytesRead := readCompactFieldHeader(data[offset:], lastID)
+		if !ok || !complete {
+			return "", ok, false, 0
+		}
+		offset += bytesRead
+		if field.Type == thriftCompactTypeStop {
+			return thrift.formatStruct(fields, false, []*string{}), true, true, offset
+		}
+
+		ok, complete, bytesRead = thrift.readCompactFieldValue(&field, data[offset:])
+		if !ok {
+			return "", false, false, 0
+		}
+		if !complete {
+			return "", true, false, 0
+		}
+		fields = append(fields, field)
+		lastID = field.id
+		offset += bytesRead
+	}
+}
+
+// Dictionary wrapped in a function to avoid "initialization loop"
+func (thrift *thriftPlugin) funcReadersByCompactType(typ byte) (fn thriftFieldReader, exists bool) {
+	switch typ {
+	case thriftCompactTypeBoolTrue, thriftCompactTypeBoolFalse:
+		return thrift.readCompactBool, true
+	case thriftCompactTypeByte:
+		return thrift.readByte, true
+	case thriftCompactTypeDouble:
+		return thrift.readCompactDouble, true
+	case thriftCompactTypeI16, thriftCompactTypeI32, thriftCompactTypeI64:
+		return thrift.readCompactInt, true
+	case thriftCompactTypeBinary:
+		return thrift.readCompactAndQuoteString, true
+	case thriftCompactTypeList:
+		return thrift.readCompactList, true
+	case thriftCompactTypeSet:
+		return thrift.readCompactSet, true
+	case thriftCompactTypeMap:
+		return thrift.readCompactMap, true
+	case thriftCompactTypeStruct:
+		return thrift.readCompactStruct, true
+	default:
+		return nil, false
+	}
+}
+
+func (thrift *thriftPlugin) readCompactField(s *thriftStream) (ok bool, complete bool, field *thriftField) {
+	var lastID uint16
+	if n := len(s.message.fields); n > 0 {
+		lastID = s.message.fields[n-1].id
+	}
+
+	header, ok, complete, off := readCompactFieldHeader(s.data[s.parseOffset:], lastID)
+	if !ok {
+		return false, false, nil
+	}
+	if !complete {
+		return true, false, nil // ok, not complete
+	}
+	offset := s.parseOffset + off
+	if header.Type == thriftCompactTypeStop {
+		s.parseOffset = offset
+		return true, true, nil // done
+	}
+
+	field = &header
+	ok, complete, off = thrift.readCompactFieldValue(field, s.data[offset:])
+	if !ok {
+		return false, false, nil
+	}
+	if !complete {
+		return true, false, nil
+	}
+	offset += off
+
+	s.parseOffset = offset
+	return true, false, field
+}
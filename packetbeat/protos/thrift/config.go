@@ -38,6 +38,7 @@ var (
 	defaultConfig = thriftConfig{
 		ProtocolCommon: config.ProtocolCommon{
 			TransactionTimeout: protos.DefaultTransactionExpiration,
+			MaxTransactions:    protos.DefaultMaxTransactions,
 		},
 		StringMaxSize:          200,
 		CollectionMaxSize:      15,
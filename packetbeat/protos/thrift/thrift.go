@@ -54,6 +54,7 @@ type thriftPlugin struct {
 
 	transactions       *common.Cache
 	transactionTimeout time.Duration
+	maxTransactions    int
 
 	publishQueue chan *thriftTransaction
 	results      protos.Reporter
@@ -115,6 +116,7 @@ type thriftTransaction struct {
 	ts           time.Time
 	bytesIn      uint64
 	bytesOut     uint64
+	timedOut     bool
 
 	request *thriftMessage
 	reply   *thriftMessage
@@ -165,6 +167,10 @@ const (
 	thriftTCompact = 2
 )
 
+// TMultiplexedProtocol separates the service name from the method name with a
+// colon.
+const thriftMultiplexedSeparator = ':'
+
 // Thrift transport types
 const (
 	thriftTSocket = 1
@@ -174,6 +180,7 @@ const (
 var (
 	unmatchedRequests  = monitoring.NewInt(nil, "thrift.unmatched_requests")
 	unmatchedResponses = monitoring.NewInt(nil, "thrift.unmatched_responses")
+	droppedRequests    = monitoring.NewInt(nil, "thrift.dropped_requests")
 )
 
 func init() {
@@ -211,9 +218,17 @@ func (thrift *thriftPlugin) init(
 		return err
 	}
 
-	thrift.transactions = common.NewCache(
+	thrift.transactions = common.NewCacheWithRemovalListener(
 		thrift.transactionTimeout,
-		protos.DefaultTransactionHashSize)
+		protos.DefaultTransactionHashSize,
+		func(k common.Key, v common.Value) {
+			trans, ok := v.(*thriftTransaction)
+			if !ok {
+				logp.Err("Expired value is not a *thriftTransaction.")
+				return
+			}
+			thrift.expireTransaction(trans)
+		})
 	thrift.transactions.StartJanitor(thrift.transactionTimeout)
 
 	if !testMode {
@@ -245,6 +260,7 @@ func (thrift *thriftPlugin) InitDefaults() {
 	thrift.sendRequest = false
 	thrift.sendResponse = false
 	thrift.transactionTimeout = protos.DefaultTransactionExpiration
+	thrift.maxTransactions = protos.DefaultMaxTransactions
 }
 
 func (thrift *thriftPlugin) readConfig(config *thriftConfig) error {
@@ -253,6 +269,8 @@ func (thrift *thriftPlugin) readConfig(config *thriftConfig) error {
 	thrift.ports = config.Ports
 	thrift.sendRequest = config.SendRequest
 	thrift.sendResponse = config.SendResponse
+	thrift.transactionTimeout = config.TransactionTimeout
+	thrift.maxTransactions = config.MaxTransactions
 
 	thrift.stringMaxSize = config.StringMaxSize
 	thrift.collectionMaxSize = config.CollectionMaxSize
@@ -272,6 +290,8 @@ func (thrift *thriftPlugin) readConfig(config *thriftConfig) error {
 	switch config.ProtocolType {
 	case "binary":
 		thrift.ProtocolType = thriftTBinary
+	case "compact":
+		thrift.ProtocolType = thriftTCompact
 	default:
 		return fmt.Errorf("Protocol type `%s` not known", config.ProtocolType)
 	}
@@ -301,6 +321,15 @@ func (thrift *thriftPlugin) readMessageBegin(s *thriftStream) (bool, bool) {
 
 	m := s.message
 
+	if thrift.ProtocolType == thriftTCompact {
+		ok, complete = thrift.readCompactMessageBegin(s)
+		if ok && complete {
+			thrift.readServiceName(m)
+			m.isRequest = m.Type == ThriftMsgTypeCall || m.Type == ThriftMsgTypeOneway
+		}
+		return ok, complete
+	}
+
 	if len(s.data[s.parseOffset:]) < 9 {
 		return true, false // ok, not complete
 	}
@@ -365,6 +394,8 @@ func (thrift *thriftPlugin) readMessageBegin(s *thriftStream) (bool, bool) {
 		s.parseOffset = offset + 4
 	}
 
+	thrift.readServiceName(m)
+
 	if m.Type == ThriftMsgTypeCall || m.Type == ThriftMsgTypeOneway {
 		m.isRequest = true
 	} else {
@@ -374,6 +405,15 @@ func (thrift *thriftPlugin) readMessageBegin(s *thriftStream) (bool, bool) {
 	return true, true
 }
 
+// readServiceName splits the service name prefixed to the method name by
+// TMultiplexedProtocol.
+func (thrift *thriftPlugin) readServiceName(m *thriftMessage) {
+	if i := strings.IndexByte(m.method, thriftMultiplexedSeparator); i >= 0 {
+		m.service = m.method[:i]
+		m.method = m.method[i+1:]
+	}
+}
+
 // Functions to decode simple types
 // They all have the same signature, returning the string value and the
 // number of bytes consumed (off).
@@ -406,19 +446,18 @@ func (thrift *thriftPlugin) readString(data []byte) (value string, ok bool, comp
 
 func (thrift *thriftPlugin) readAndQuoteString(data []byte) (value string, ok bool, complete bool, off int) {
 	value, ok, complete, off = thrift.readString(data)
+	return thrift.quoteString(value), ok, complete, off
+}
+
+func (thrift *thriftPlugin) quoteString(value string) string {
 	if value == "" {
-		value = `""`
+		return `""`
 	} else if thrift.obfuscateStrings {
-		value = `"*"`
-	} else {
-		if utf8.ValidString(value) {
-			value = strconv.Quote(value)
-		} else {
-			value = hex.EncodeToString([]byte(value))
-		}
+		return `"*"`
+	} else if utf8.ValidString(value) {
+		return strconv.Quote(value)
 	}
-
-	return value, ok, complete, off
+	return hex.EncodeToString([]byte(value))
 }
 
 func (thrift *thriftPlugin) readBool(data []byte) (value string, ok bool, complete bool, off int) {
@@ -779,7 +818,12 @@ func (thrift *thriftPlugin) messageParser(s *thriftStream) (bool, bool) {
 			}
 			s.parseState = thriftFieldState
 		case thriftFieldState:
-			ok, complete, field := thrift.readField(s)
+			var field *thriftField
+			if thrift.ProtocolType == thriftTCompact {
+				ok, complete, field = thrift.readCompactField(s)
+			} else {
+				ok, complete, field = thrift.readField(s)
+			}
 			logp.Debug("thriftdetailed", "readField returned: %v %v", ok, complete)
 			if !ok {
 				return false, false
@@ -788,7 +832,7 @@ func (thrift *thriftPlugin) messageParser(s *thriftStream) (bool, bool) {
 				// done
 				var method *thriftIdlMethod
 				if thrift.idl != nil {
-					method = thrift.idl.findMethod(m.method)
+					method = thrift.idl.findMethod(m.service, m.method)
 				}
 				if m.isRequest {
 					if method != nil {
@@ -910,6 +954,11 @@ func (thrift *thriftPlugin) ConnectionTimeout() time.Duration {
 	return thrift.transactionTimeout
 }
 
+// Flush publishes the transactions in progress as expired.
+func (thrift *thriftPlugin) Flush() {
+	thrift.transactions.RemoveAll()
+}
+
 func (thrift *thriftPlugin) Parse(pkt *protos.Packet, tcptuple *common.TCPTuple, dir uint8,
 	private protos.ProtocolData) protos.ProtocolData {
 
@@ -992,6 +1041,10 @@ func (thrift *thriftPlugin) receivedRequest(msg *thriftMessage) {
 		logp.Debug("thrift", "Two requests without reply, assuming the old one is oneway")
 		unmatchedRequests.Add(1)
 		thrift.publishQueue <- trans
+	} else if !protos.CanAddTransaction(thrift.transactions, thrift.maxTransactions) {
+		logp.Debug("thrift", "Too many transactions in flight. Dropping request: %s", msg.method)
+		droppedRequests.Add(1)
+		return
 	}
 
 	trans = &thriftTransaction{
@@ -1038,6 +1091,20 @@ func (thrift *thriftPlugin) receivedReply(msg *thriftMessage) {
 	logp.Debug("thrift", "Transaction queued")
 }
 
+// expireTransaction publishes a request whose reply was not received within
+// the transaction timeout. Oneway calls expect no reply.
+func (thrift *thriftPlugin) expireTransaction(trans *thriftTransaction) {
+	if trans.request == nil || trans.reply != nil {
+		return
+	}
+	if trans.request.Type != ThriftMsgTypeOneway {
+		logp.Debug("thrift", "Transaction timed out: %s", &trans.tuple)
+		unmatchedRequests.Add(1)
+		trans.timedOut = true
+	}
+	thrift.publishQueue <- trans
+}
+
 func (thrift *thriftPlugin) ReceivedFin(tcptuple *common.TCPTuple, dir uint8,
 	private protos.ProtocolData) protos.ProtocolData {
 
@@ -1088,9 +1155,12 @@ func (thrift *thriftPlugin) publishTransactions() {
 		fields := common.MapStr{}
 
 		fields["type"] = "thrift"
-		if t.reply != nil && t.reply.hasException {
+		switch {
+		case t.timedOut:
+			fields["status"] = common.TIMEOUT_STATUS
+		case t.reply != nil && t.reply.hasException:
 			fields["status"] = common.ERROR_STATUS
-		} else {
+		default:
 			fields["status"] = common.OK_STATUS
 		}
 		fields["responsetime"] = t.responseTime
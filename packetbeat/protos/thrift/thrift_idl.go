@@ -34,7 +34,8 @@ type thriftIdlMethod struct {
 }
 
 type thriftIdl struct {
-	methodsByName map[string]*thriftIdlMethod
+	methodsByName    map[string]*thriftIdlMethod
+	methodsByService map[string]map[string]*thriftIdlMethod
 }
 
 func fieldsToArrayByID(fields []*parser.Field) []*string {
@@ -60,27 +61,38 @@ func fieldsToArrayByID(fields []*parser.Field) []*string {
 	return output
 }
 
-func buildMethodsMap(thriftFiles map[string]parser.Thrift) map[string]*thriftIdlMethod {
+func buildMethodsMap(thriftFiles map[string]parser.Thrift) (map[string]*thriftIdlMethod,
+	map[string]map[string]*thriftIdlMethod) {
+
 	output := make(map[string]*thriftIdlMethod)
+	byService := make(map[string]map[string]*thriftIdlMethod)
 
 	for _, thrift := range thriftFiles {
 		for _, service := range thrift.Services {
+			methods := byService[service.Name]
+			if methods == nil {
+				methods = make(map[string]*thriftIdlMethod)
+				byService[service.Name] = methods
+			}
+
 			for _, method := range service.Methods {
 				if _, exists := output[method.Name]; exists {
 					logp.Warn("Thrift IDL: Method %s is defined in more services: %s and %s",
-						output[method.Name].service.Name, service.Name)
+						method.Name, output[method.Name].service.Name, service.Name)
 				}
-				output[method.Name] = &thriftIdlMethod{
+				idlMethod := &thriftIdlMethod{
 					service:    service,
 					method:     method,
 					params:     fieldsToArrayByID(method.Arguments),
 					exceptions: fieldsToArrayByID(method.Exceptions),
 				}
+				output[method.Name] = idlMethod
+				methods[method.Name] = idlMethod
 			}
 		}
 	}
 
-	return output
+	return output, byService
 }
 
 func readFiles(files []string) (map[string]parser.Thrift, error) {
@@ -102,7 +114,13 @@ func readFiles(files []string) (map[string]parser.Thrift, error) {
 	return output, nil
 }
 
-func (thriftidl *thriftIdl) findMethod(name string) *thriftIdlMethod {
+// findMethod looks up a method by name. The service name, known when the
+// client uses TMultiplexedProtocol, disambiguates methods defined in more
+// services.
+func (thriftidl *thriftIdl) findMethod(service, name string) *thriftIdlMethod {
+	if method, found := thriftidl.methodsByService[service][name]; found {
+		return method
+	}
 	return thriftidl.methodsByName[name]
 }
 
@@ -115,7 +133,9 @@ func newThriftIdl(idlFiles []string) (*thriftIdl, error) {
 		return nil, err
 	}
 
+	methodsByName, methodsByService := buildMethodsMap(thriftFiles)
 	return &thriftIdl{
-		methodsByName: buildMethodsMap(thriftFiles),
+		methodsByName:    methodsByName,
+		methodsByService: methodsByService,
 	}, nil
 }
@@ -23,6 +23,7 @@ import (
 	"encoding/hex"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/elastic/beats/libbeat/common"
 	"github.com/elastic/beats/libbeat/logp"
@@ -1095,3 +1096,139 @@ func TestThrift_GapInStream_request(t *testing.T) {
 		// ok
 	}
 }
+
+func TestThrift_ParseCompact(t *testing.T) {
+	logp.TestingSetup(logp.WithSelectors("thrift", "thriftdetailed"))
+
+	thrift := thriftForTests()
+	thrift.ProtocolType = thriftTCompact
+	thrift.publishQueue = make(chan *thriftTransaction, 10)
+
+	tcptuple := testTCPTuple()
+
+	req := createTestPacket(t, "82210004"+"70696e67"+
+		"11"+ // 1: true
+		"19250201"+ // 2: [1, -1]
+		"0828026869"+ // 20: "hi"
+		"1b0186016103"+ // 21: {"a": -2}
+		"17000000000000f83f"+ // 22: 1.5
+		"00")
+	repl := createTestPacket(t, "82410004"+"70696e67"+"00")
+
+	var private thriftPrivateData
+	thrift.Parse(req, tcptuple, 0, private)
+	thrift.Parse(repl, tcptuple, 1, private)
+
+	trans := expectThriftTransaction(t, thrift)
+	if trans.request.method != "ping" ||
+		trans.request.params != `(1: true, 2: [1, -1], 20: "hi", 21: {"a": -2}, 22: 1.5)` ||
+		trans.reply.returnValue != "" ||
+		trans.request.frameSize != 34 ||
+		trans.reply.frameSize != 9 {
+
+		t.Error("Bad result:", trans.request, trans.reply)
+	}
+}
+
+func TestThrift_ParseCompactMultiplexed(t *testing.T) {
+	logp.TestingSetup(logp.WithSelectors("thrift", "thriftdetailed"))
+
+	thrift := thriftForTests()
+	thrift.ProtocolType = thriftTCompact
+	thrift.TransportType = thriftTFramed
+	thrift.idl = thriftIdlForTesting(t, `
+		exception InvalidOperation {
+		  1: i32 what,
+		  2: string why
+		}
+		service Scientific {
+		   double calculate(1:double x) throws (1:InvalidOperation error),
+		}
+		service Calculator {
+		   i32 calculate(1:i32 logid, 2:Work w) throws (1:InvalidOperation ouch),
+		}
+		`)
+	thrift.publishQueue = make(chan *thriftTransaction, 10)
+
+	tcptuple := testTCPTuple()
+
+	// Calculator:calculate
+	req := createTestPacket(t, "00000025"+"822100"+
+		"1443616c63756c61746f723a63616c63756c617465"+
+		"15021c1502150015080000")
+	repl := createTestPacket(t, "00000024"+"82410009"+"63616c63756c617465"+
+		"1c1508181243616e6e6f742064697669646520627920300000")
+
+	var private thriftPrivateData
+	thrift.Parse(req, tcptuple, 0, private)
+	thrift.Parse(repl, tcptuple, 1, private)
+
+	trans := expectThriftTransaction(t, thrift)
+	if trans.request.method != "calculate" ||
+		trans.request.service != "Calculator" ||
+		trans.request.params != "(logid: 1, w: (1: 1, 2: 0, 3: 4))" ||
+		trans.reply.exceptions != `(ouch: (1: 4, 2: "Cannot divide by 0"))` ||
+		!trans.reply.hasException {
+
+		t.Error("Bad result:", trans.request, trans.reply)
+	}
+}
+
+func TestThrift_ParseMultiplexedTBinary(t *testing.T) {
+	logp.TestingSetup(logp.WithSelectors("thrift", "thriftdetailed"))
+
+	thrift := thriftForTests()
+	thrift.publishQueue = make(chan *thriftTransaction, 10)
+
+	tcptuple := testTCPTuple()
+
+	// Pinger:ping, the reply has no service name
+	req := createTestPacket(t, "800100010000000b50696e6765723a70696e670000000000")
+	repl := createTestPacket(t, "800100020000000470696e670000000000")
+
+	var private thriftPrivateData
+	thrift.Parse(req, tcptuple, 0, private)
+	thrift.Parse(repl, tcptuple, 1, private)
+
+	trans := expectThriftTransaction(t, thrift)
+	if trans.request.method != "ping" ||
+		trans.request.service != "Pinger" ||
+		trans.reply == nil {
+
+		t.Error("Bad result:", trans)
+	}
+}
+
+func TestThrift_TransactionTimeout(t *testing.T) {
+	logp.TestingSetup(logp.WithSelectors("thrift", "thriftdetailed"))
+
+	thrift := thriftForTests()
+	thrift.publishQueue = make(chan *thriftTransaction, 10)
+
+	// ping call and oneway ping
+	for i, data := range []string{
+		"800100010000000470696e670000000000",
+		"800100040000000470696e670000000000",
+	} {
+		tcptuple := testTCPTuple()
+		tcptuple.SrcPort += uint16(i)
+		tcptuple.ComputeHashables()
+		thrift.Parse(createTestPacket(t, data), tcptuple, 0, nil)
+
+		key := tcptuple.Hashable()
+		thrift.transactions.PutWithTimeout(key, thrift.getTransaction(key), time.Nanosecond)
+	}
+	time.Sleep(time.Millisecond)
+	thrift.transactions.CleanUp()
+
+	for i := 0; i < 2; i++ {
+		trans := expectThriftTransaction(t, thrift)
+		if trans == nil {
+			continue
+		}
+		oneway := trans.request.Type == ThriftMsgTypeOneway
+		if trans.timedOut == oneway {
+			t.Error("Bad timeout of transaction", trans.request)
+		}
+	}
+}
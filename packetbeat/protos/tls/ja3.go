@@ -66,6 +66,28 @@ func getJa3Fingerprint(hello *helloMessage) (hash string, ja3str string) {
 	return hex.EncodeToString(sum[:]), ja3str
 }
 
+// getJa3sFingerprint computes the JA3S fingerprint of a server hello, built
+// from the version, the selected cipher suite and the extensions.
+func getJa3sFingerprint(hello *helloMessage) (hash string, ja3str string) {
+	version := uint16(hello.version.major)*256 + uint16(hello.version.minor)
+
+	extensions := make([]string, 0, len(hello.extensions.InOrder))
+	for _, extid := range hello.extensions.InOrder {
+		if !isGreaseValue(uint16(extid)) {
+			extensions = append(extensions, strconv.Itoa(int(extid)))
+		}
+	}
+
+	ja3str = strings.Join([]string{
+		strconv.Itoa(int(version)),
+		strconv.Itoa(int(hello.selected.cipherSuite)),
+		strings.Join(extensions, "-"),
+	}, ",")
+	sum := md5.Sum([]byte(ja3str))
+
+	return hex.EncodeToString(sum[:]), ja3str
+}
+
 func extractJa3Array(raw []byte, size int) []uint16 {
 	if size < 1 || size > 2 {
 		return nil
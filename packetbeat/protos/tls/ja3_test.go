@@ -88,3 +88,27 @@ func TestJa3(t *testing.T) {
 		assert.Equal(t, test.Fingerprint, actual)
 	}
 }
+
+func TestJa3s(t *testing.T) {
+	results, tls := testInit()
+	reqData, err := hex.DecodeString(
+		"160303004a0200004603037806e1be0c363bcc1fe14a906d1ff1b11dc5369d91" +
+			"c631ed660d6c0f156f420700c02f00001eff01000100000b0004030001020023" +
+			"000000050000001000050003026832")
+	assert.NoError(t, err)
+
+	tcpTuple := testTCPTuple()
+	req := protos.Packet{Payload: reqData}
+	var private protos.ProtocolData
+
+	private = tls.Parse(&req, tcpTuple, 0, private)
+	tls.ReceivedFin(tcpTuple, 0, private)
+	assert.Len(t, results.events, 1)
+	event := results.events[0]
+	actual, err := event.Fields.GetValue("tls.fingerprints.ja3s.str")
+	assert.NoError(t, err)
+	assert.Equal(t, "771,49199,65281-11-35-5-16", actual)
+	actual, err = event.Fields.GetValue("tls.fingerprints.ja3s.hash")
+	assert.NoError(t, err)
+	assert.Equal(t, "49b45fc1ab090aa3a159778313fc9b9e", actual)
+}
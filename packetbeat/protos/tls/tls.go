@@ -277,6 +277,11 @@ func (plugin *tlsPlugin) createEvent(conn *tlsConnectionData) beat.Event {
 	if server.parser.hello != nil {
 		serverHello = server.parser.hello
 		tls["server_hello"] = serverHello.toMap()
+		hash, str := getJa3sFingerprint(serverHello)
+		fingerprints["ja3s"] = common.MapStr{
+			"hash": hash,
+			"str":  str,
+		}
 	} else {
 		serverHello = emptyHello
 	}
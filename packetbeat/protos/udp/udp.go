@@ -19,6 +19,7 @@ package udp
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/elastic/beats/libbeat/common"
 	"github.com/elastic/beats/libbeat/logp"
@@ -27,9 +28,38 @@ import (
 	"github.com/elastic/beats/packetbeat/protos"
 )
 
+const (
+	UDPDirectionReverse  = 0
+	UDPDirectionOriginal = 1
+)
+
 type UDP struct {
-	protocols protos.Protocols
-	portMap   map[uint16]protos.Protocol
+	protocols    protos.Protocols
+	flows        *common.Cache
+	expiredFlows expirationQueue
+
+	portsMutex sync.RWMutex
+	portMap    map[uint16]protos.Protocol
+}
+
+// udpFlow holds the protocol private data of the datagrams exchanged
+// between two endpoints.
+type udpFlow struct {
+	tuple    common.IPPortTuple
+	protocol protos.Protocol
+
+	// protocols private data
+	data protos.ProtocolData
+}
+
+type expiredFlow struct {
+	mod  protos.ExpirationAwareUDPPlugin
+	flow *udpFlow
+}
+
+type expirationQueue struct {
+	mutex sync.Mutex
+	flows []expiredFlow
 }
 
 type Processor interface {
@@ -40,6 +70,9 @@ type Processor interface {
 // ports. If the protocol cannot be determined then protos.UnknownProtocol
 // is returned.
 func (udp *UDP) decideProtocol(tuple *common.IPPortTuple) protos.Protocol {
+	udp.portsMutex.RLock()
+	defer udp.portsMutex.RUnlock()
+
 	protocol, exists := udp.portMap[tuple.SrcPort]
 	if exists {
 		return protocol
@@ -53,11 +86,21 @@ func (udp *UDP) decideProtocol(tuple *common.IPPortTuple) protos.Protocol {
 	return protos.UnknownProtocol
 }
 
+func (udp *UDP) findFlow(k common.HashableIPPortTuple) *udpFlow {
+	v := udp.flows.Get(k)
+	if v != nil {
+		return v.(*udpFlow)
+	}
+	return nil
+}
+
 // Process handles UDP packets that have been received. It attempts to
 // determine the protocol type and then invokes the associated
 // UdpProtocolPlugin's ParseUDP method. If the protocol cannot be determined
 // or the payload is empty then the method is a noop.
 func (udp *UDP) Process(id *flows.FlowID, pkt *protos.Packet) {
+	udp.expiredFlows.notifyAll()
+
 	protocol := udp.decideProtocol(&pkt.Tuple)
 	if protocol == protos.UnknownProtocol {
 		logp.Debug("udp", "unknown protocol")
@@ -73,18 +116,87 @@ func (udp *UDP) Process(id *flows.FlowID, pkt *protos.Packet) {
 	if len(pkt.Payload) > 0 {
 		logp.Debug("udp", "Parsing packet from %v of length %d.",
 			pkt.Tuple.String(), len(pkt.Payload))
-		plugin.ParseUDP(pkt)
+		udp.parse(plugin, protocol, pkt)
+	}
+}
+
+// parse passes the datagram to the plugin along with the private data of
+// its flow. The flow is tracked as long as the plugin returns private data.
+func (udp *UDP) parse(plugin protos.UDPPlugin, protocol protos.Protocol, pkt *protos.Packet) {
+	dir := uint8(UDPDirectionOriginal)
+	flow := udp.findFlow(pkt.Tuple.Hashable())
+	if flow == nil {
+		if flow = udp.findFlow(pkt.Tuple.RevHashable()); flow != nil {
+			dir = UDPDirectionReverse
+		}
+	}
+
+	created := flow == nil
+	if created {
+		flow = &udpFlow{tuple: pkt.Tuple, protocol: protocol}
+	}
+
+	flow.data = plugin.ParseUDP(pkt, &flow.tuple, dir, flow.data)
+	switch {
+	case flow.data == nil && !created:
+		udp.flows.Delete(flow.tuple.Hashable())
+	case flow.data != nil && created:
+		udp.flows.PutWithTimeout(flow.tuple.Hashable(), flow, plugin.FlowTimeout())
+	}
+}
+
+// Flush removes all UDP flows, notifying the protocol plugins of the flows as
+// on expiry. It must not be called concurrently to Process.
+func (udp *UDP) Flush() {
+	udp.flows.RemoveAll()
+	udp.expiredFlows.notifyAll()
+}
+
+func (udp *UDP) removalListener(_ common.Key, value common.Value) {
+	flow := value.(*udpFlow)
+	mod := udp.protocols.GetUDP(flow.protocol)
+	if mod != nil {
+		awareMod, ok := mod.(protos.ExpirationAwareUDPPlugin)
+		if ok {
+			udp.expiredFlows.add(awareMod, flow)
+		}
+	}
+}
+
+func (ef *expiredFlow) notify() {
+	ef.mod.Expired(&ef.flow.tuple, ef.flow.data)
+}
+
+func (eq *expirationQueue) add(mod protos.ExpirationAwareUDPPlugin, flow *udpFlow) {
+	eq.mutex.Lock()
+	eq.flows = append(eq.flows, expiredFlow{
+		mod:  mod,
+		flow: flow,
+	})
+	eq.mutex.Unlock()
+}
+
+func (eq *expirationQueue) getExpired() (flows []expiredFlow) {
+	eq.mutex.Lock()
+	flows, eq.flows = eq.flows, nil
+	eq.mutex.Unlock()
+	return flows
+}
+
+func (eq *expirationQueue) notifyAll() {
+	for _, expiration := range eq.getExpired() {
+		expiration.notify()
 	}
 }
 
 // buildPortsMap creates a mapping of port numbers to protocol identifiers. If
 // any two UdpProtocolPlugins operate on the same port number then an error
 // will be returned.
-func buildPortsMap(plugins map[protos.Protocol]protos.UDPPlugin) (map[uint16]protos.Protocol, error) {
+func buildPortsMap(p protos.Protocols) (map[uint16]protos.Protocol, error) {
 	var res = map[uint16]protos.Protocol{}
 
-	for proto, protoPlugin := range plugins {
-		for _, port := range protoPlugin.GetPorts() {
+	for proto := range p.GetAllUDP() {
+		for _, port := range p.GetPorts(proto) {
 			oldProto, exists := res[uint16(port)]
 			if exists {
 				if oldProto == proto {
@@ -102,13 +214,34 @@ func buildPortsMap(plugins map[protos.Protocol]protos.UDPPlugin) (map[uint16]pro
 
 // NewUdp creates and returns a new Udp.
 func NewUDP(p protos.Protocols) (*UDP, error) {
-	portMap, err := buildPortsMap(p.GetAllUDP())
+	portMap, err := buildPortsMap(p)
 	if err != nil {
 		return nil, err
 	}
 
 	udp := &UDP{protocols: p, portMap: portMap}
+	udp.flows = common.NewCacheWithRemovalListener(
+		protos.DefaultTransactionExpiration,
+		protos.DefaultTransactionHashSize,
+		udp.removalListener)
+	udp.flows.StartJanitor(protos.DefaultTransactionExpiration)
 	logp.Debug("udp", "Port map: %v", portMap)
 
 	return udp, nil
 }
+
+// UpdatePorts rebuilds the port to protocol mapping from the current ports
+// of the protocols. It is called after the ports have been changed at runtime.
+func (udp *UDP) UpdatePorts() error {
+	portMap, err := buildPortsMap(udp.protocols)
+	if err != nil {
+		return err
+	}
+
+	udp.portsMutex.Lock()
+	udp.portMap = portMap
+	udp.portsMutex.Unlock()
+
+	logp.Debug("udp", "Port map: %v", portMap)
+	return nil
+}
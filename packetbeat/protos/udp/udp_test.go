@@ -56,6 +56,10 @@ func (p TestProtocols) BpfFilter(withVlans bool, withICMP bool) string {
 	return "mock bpf filter"
 }
 
+func (p TestProtocols) GetPorts(proto protos.Protocol) []int {
+	return p.udp[proto].GetPorts()
+}
+
 func (p TestProtocols) GetTCP(proto protos.Protocol) protos.TCPPlugin {
 	return nil
 }
@@ -83,6 +87,12 @@ func (p TestProtocols) Register(proto protos.Protocol, plugin protos.Plugin) {
 type TestProtocol struct {
 	Ports []int          // Ports that the protocol operates on.
 	pkt   *protos.Packet // UDP packet that the plugin was called to process.
+
+	flow    *common.IPPortTuple // Flow tuple passed with the last packet.
+	dir     uint8               // Direction passed with the last packet.
+	private protos.ProtocolData // Private data passed with the last packet.
+	track   bool                // Return a packet counter as private data.
+	expired protos.ProtocolData // Private data of the last expired flow.
 }
 
 func (proto *TestProtocol) Init(testMode bool, results protos.Reporter) error {
@@ -93,8 +103,23 @@ func (proto *TestProtocol) GetPorts() []int {
 	return proto.Ports
 }
 
-func (proto *TestProtocol) ParseUDP(pkt *protos.Packet) {
+func (proto *TestProtocol) ParseUDP(pkt *protos.Packet, flow *common.IPPortTuple,
+	dir uint8, private protos.ProtocolData) protos.ProtocolData {
 	proto.pkt = pkt
+	proto.flow = flow
+	proto.dir = dir
+	proto.private = private
+	if !proto.track {
+		return nil
+	}
+	count, _ := private.(int)
+	return count + 1
+}
+
+func (proto *TestProtocol) FlowTimeout() time.Duration { return 0 }
+
+func (proto *TestProtocol) Expired(flow *common.IPPortTuple, private protos.ProtocolData) {
+	proto.expired = private
 }
 
 type TestStruct struct {
@@ -168,7 +193,7 @@ func Test_buildPortsMap(t *testing.T) {
 	}
 
 	for _, test := range configTests {
-		output, err := buildPortsMap(test.Input)
+		output, err := buildPortsMap(TestProtocols{udp: test.Input})
 		assert.Nil(t, err)
 		assert.Equal(t, test.Output, output)
 	}
@@ -197,7 +222,7 @@ func Test_buildPortsMap_portOverlapError(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		_, err := buildPortsMap(test.Input)
+		_, err := buildPortsMap(TestProtocols{udp: test.Input})
 		assert.NotNil(t, err)
 		assert.Contains(t, err.Error(), test.Err)
 	}
@@ -256,3 +281,58 @@ func TestProcess_nonEmptyPayload(t *testing.T) {
 	test.udp.Process(nil, pkt)
 	assert.Equal(t, pkt, test.plugin.pkt)
 }
+
+// Verify that Process passes the direction relative to the first datagram of
+// a flow along with the private data returned by the previous call.
+func TestProcess_flowPrivateData(t *testing.T) {
+	test := testSetup(t)
+	test.plugin.track = true
+	tuple := common.NewIPPortTuple(4,
+		net.ParseIP("10.0.0.1"), 34898,
+		net.ParseIP("192.168.0.1"), PORT)
+	reverse := common.NewIPPortTuple(4,
+		net.ParseIP("192.168.0.1"), PORT,
+		net.ParseIP("10.0.0.1"), 34898)
+
+	test.udp.Process(nil, &protos.Packet{Ts: time.Now(), Tuple: tuple, Payload: []byte{1}})
+	assert.Equal(t, uint8(UDPDirectionOriginal), test.plugin.dir)
+	assert.Nil(t, test.plugin.private)
+
+	test.udp.Process(nil, &protos.Packet{Ts: time.Now(), Tuple: reverse, Payload: []byte{1}})
+	assert.Equal(t, uint8(UDPDirectionReverse), test.plugin.dir)
+	assert.Equal(t, 1, test.plugin.private)
+	assert.Equal(t, tuple.Hashable(), test.plugin.flow.Hashable())
+
+	test.udp.Process(nil, &protos.Packet{Ts: time.Now(), Tuple: tuple, Payload: []byte{1}})
+	assert.Equal(t, uint8(UDPDirectionOriginal), test.plugin.dir)
+	assert.Equal(t, 2, test.plugin.private)
+}
+
+// Verify that flows without private data are not tracked.
+func TestProcess_untrackedFlow(t *testing.T) {
+	test := testSetup(t)
+	tuple := common.NewIPPortTuple(4,
+		net.ParseIP("10.0.0.1"), 34898,
+		net.ParseIP("192.168.0.1"), PORT)
+
+	test.udp.Process(nil, &protos.Packet{Ts: time.Now(), Tuple: tuple, Payload: []byte{1}})
+	assert.Equal(t, 0, test.udp.flows.Size())
+}
+
+// Verify that the plugin is notified with the private data of expired flows.
+func TestProcess_expiredFlow(t *testing.T) {
+	test := testSetup(t)
+	test.plugin.track = true
+	tuple := common.NewIPPortTuple(4,
+		net.ParseIP("10.0.0.1"), 34898,
+		net.ParseIP("192.168.0.1"), PORT)
+
+	test.udp.Process(nil, &protos.Packet{Ts: time.Now(), Tuple: tuple, Payload: []byte{1}})
+	v := test.udp.flows.Get(tuple.Hashable())
+	test.udp.flows.PutWithTimeout(tuple.Hashable(), v, time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	test.udp.flows.CleanUp()
+
+	test.udp.Process(nil, &protos.Packet{Ts: time.Now(), Tuple: tuple, Payload: []byte{}})
+	assert.Equal(t, 1, test.plugin.expired)
+}
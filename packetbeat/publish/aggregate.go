@@ -0,0 +1,137 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package publish
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/packetbeat/pool"
+)
+
+var keyBuffers = pool.NewBuffers("publish.aggregate_keys", 1024)
+
+type aggregateConfig struct {
+	Fields     []string      `config:"fields"`      // Fields identifying identical events.
+	Window     time.Duration `config:"window"`      // Time events are aggregated for.
+	MaxKeys    int           `config:"max_keys"`    // Maximum number of open windows.
+	CountField string        `config:"count_field"` // Field the number of events is written to.
+}
+
+var defaultAggregateConfig = aggregateConfig{
+	Window:     10 * time.Second,
+	MaxKeys:    10000,
+	CountField: "count",
+}
+
+func (c *aggregateConfig) Validate() error {
+	if len(c.Fields) == 0 {
+		return errors.New("aggregate requires at least one field")
+	}
+	if c.Window <= 0 {
+		return errors.New("aggregate window must be greater than 0")
+	}
+	if c.MaxKeys <= 0 {
+		return errors.New("aggregate max_keys must be greater than 0")
+	}
+	return nil
+}
+
+// aggregator merges identical events seen within a time window into a single
+// event. Windows are aligned on the event timestamps, so that out of order
+// events are counted in the window they belong to. The first event of a window
+// is published when the window expires, a window length after it was opened,
+// with the timestamp of the earliest event and the number of events seen in
+// count_field.
+type aggregator struct {
+	config  aggregateConfig
+	windows map[string]*aggregateWindow
+}
+
+type aggregateWindow struct {
+	event   beat.Event
+	count   int
+	expires time.Time
+}
+
+func newAggregator(config aggregateConfig) *aggregator {
+	return &aggregator{
+		config:  config,
+		windows: map[string]*aggregateWindow{},
+	}
+}
+
+// add adds an event to its window. It returns false if the event can't be
+// aggregated, because any of the fields is missing or too many windows are
+// open, and must be published as is.
+func (a *aggregator) add(event beat.Event, now time.Time) bool {
+	key, ok := a.key(&event)
+	if !ok {
+		return false
+	}
+
+	if w, exists := a.windows[key]; exists {
+		w.count++
+		if event.Timestamp.Before(w.event.Timestamp) {
+			w.event.Timestamp = event.Timestamp
+		}
+		return true
+	}
+
+	if len(a.windows) >= a.config.MaxKeys {
+		return false
+	}
+	a.windows[key] = &aggregateWindow{
+		event:   event,
+		count:   1,
+		expires: now.Add(a.config.Window),
+	}
+	return true
+}
+
+// flush returns the events of all windows expired at now, or of all windows if
+// force is set.
+func (a *aggregator) flush(now time.Time, force bool) []beat.Event {
+	var events []beat.Event
+	for key, w := range a.windows {
+		if !force && now.Before(w.expires) {
+			continue
+		}
+
+		w.event.Fields.Put(a.config.CountField, w.count)
+		events = append(events, w.event)
+		delete(a.windows, key)
+	}
+	return events
+}
+
+func (a *aggregator) key(event *beat.Event) (string, bool) {
+	buf := keyBuffers.Get()
+	defer keyBuffers.Put(buf)
+	for _, field := range a.config.Fields {
+		v, err := event.Fields.GetValue(field)
+		if err != nil {
+			return "", false
+		}
+		fmt.Fprintf(buf, "%v\x00", v)
+	}
+	fmt.Fprintf(buf, "%d", event.Timestamp.Truncate(a.config.Window).UnixNano())
+	return buf.String(), true
+}
@@ -0,0 +1,113 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package publish
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestAggregator(t *testing.T) {
+	config := defaultAggregateConfig
+	config.Fields = []string{"query", "ip"}
+	config.Window = 10 * time.Second
+	config.MaxKeys = 2
+	agg := newAggregator(config)
+
+	start := time.Now().Truncate(config.Window)
+	newEvent := func(query, ip string) beat.Event {
+		return beat.Event{Timestamp: start, Fields: common.MapStr{"query": query, "ip": ip}}
+	}
+
+	for i := 0; i < 3; i++ {
+		assert.True(t, agg.add(newEvent("SELECT 1", "10.0.0.1"), start))
+	}
+	assert.True(t, agg.add(newEvent("SELECT 1", "10.0.0.2"), start.Add(5*time.Second)))
+
+	// Missing fields and new keys beyond max_keys are not aggregated
+	assert.False(t, agg.add(beat.Event{Fields: common.MapStr{"query": "SELECT 1"}}, start))
+	assert.False(t, agg.add(newEvent("SELECT 2", "10.0.0.1"), start))
+
+	assert.Empty(t, agg.flush(start.Add(9*time.Second), false))
+
+	events := agg.flush(start.Add(10*time.Second), false)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, common.MapStr{"query": "SELECT 1", "ip": "10.0.0.1", "count": 3}, events[0].Fields)
+		assert.Equal(t, start, events[0].Timestamp)
+	}
+
+	events = agg.flush(start.Add(11*time.Second), true)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, common.MapStr{"query": "SELECT 1", "ip": "10.0.0.2", "count": 1}, events[0].Fields)
+	}
+	assert.Empty(t, agg.windows)
+}
+
+func TestAggregatorEventTime(t *testing.T) {
+	config := defaultAggregateConfig
+	config.Fields = []string{"query"}
+	config.Window = 10 * time.Second
+	agg := newAggregator(config)
+
+	start := time.Now().Truncate(config.Window)
+	newEvent := func(offset time.Duration) beat.Event {
+		return beat.Event{Timestamp: start.Add(offset), Fields: common.MapStr{"query": "SELECT 1"}}
+	}
+
+	// Events are bucketed by their timestamp, not by the time they are
+	// added, even if they arrive out of order.
+	now := time.Now()
+	for _, offset := range []time.Duration{5, 12, 1, 15, 9, -1} {
+		assert.True(t, agg.add(newEvent(offset*time.Second), now))
+	}
+
+	events := agg.flush(now.Add(config.Window), false)
+	assert.Len(t, events, 3)
+
+	counts := map[time.Time]interface{}{}
+	for _, event := range events {
+		counts[event.Timestamp], _ = event.Fields.GetValue("count")
+	}
+	assert.Equal(t, map[time.Time]interface{}{
+		start.Add(-1 * time.Second): 1,
+		start.Add(1 * time.Second):  3,
+		start.Add(12 * time.Second): 2,
+	}, counts)
+}
+
+func TestAggregateConfigValidate(t *testing.T) {
+	configs := map[string]map[string]interface{}{
+		"missing fields": {},
+		"invalid window": {"fields": []string{"query"}, "window": "-1s"},
+	}
+
+	for name, config := range configs {
+		t.Run(name, func(t *testing.T) {
+			aggConfig := defaultAggregateConfig
+			err := common.MustNewConfigFrom(config).Unpack(&aggConfig)
+			assert.Error(t, err)
+		})
+	}
+}
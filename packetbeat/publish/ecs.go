@@ -0,0 +1,117 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package publish
+
+import (
+	"time"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+// ecsConfig configures the renaming of the transaction fields to their
+// Elastic Common Schema (ECS) equivalents.
+type ecsConfig struct {
+	Enabled bool `config:"enabled"`
+
+	// KeepLegacy keeps the legacy fields next to the ECS fields during the
+	// transition period.
+	KeepLegacy bool `config:"keep_legacy"`
+}
+
+// ecsField maps a legacy transaction field to its ECS name. If eventType is
+// set, the field is only mapped for events of this type. If convert is set,
+// it converts the legacy value to the ECS representation.
+type ecsField struct {
+	legacy    string
+	ecs       string
+	eventType string
+	convert   func(interface{}) interface{}
+}
+
+var ecsFields = []ecsField{
+	{legacy: "client_ip", ecs: "source.ip"},
+	{legacy: "client_port", ecs: "source.port"},
+	{legacy: "ip", ecs: "destination.ip"},
+	{legacy: "port", ecs: "destination.port"},
+	{legacy: "bytes_in", ecs: "source.bytes"},
+	{legacy: "bytes_out", ecs: "destination.bytes"},
+	{legacy: "transport", ecs: "network.transport"},
+	{legacy: "direction", ecs: "network.direction", convert: ecsDirection},
+	{legacy: "responsetime", ecs: "event.duration", convert: millisToNanos},
+	{legacy: "method", ecs: "http.request.method", eventType: "http"},
+	{legacy: "path", ecs: "url.path", eventType: "http"},
+	{legacy: "http.response.code", ecs: "http.response.status_code"},
+}
+
+// applyECS renames the legacy fields of a transaction event to ECS. The
+// event type is kept and copied to `event.dataset`.
+func applyECS(config ecsConfig, event common.MapStr) {
+	typ, _ := event["type"].(string)
+
+	for _, field := range ecsFields {
+		if field.eventType != "" && field.eventType != typ {
+			continue
+		}
+
+		value, err := event.GetValue(field.legacy)
+		if err != nil {
+			continue
+		}
+
+		if !config.KeepLegacy {
+			event.Delete(field.legacy)
+		}
+		if field.convert != nil {
+			value = field.convert(value)
+		}
+		event.Put(field.ecs, value)
+	}
+
+	event.Put("event.dataset", typ)
+}
+
+func ecsDirection(v interface{}) interface{} {
+	switch v {
+	case "in":
+		return "inbound"
+	case "out":
+		return "outbound"
+	}
+	return v
+}
+
+// millisToNanos converts a response time in milliseconds to the event
+// duration in nanoseconds.
+func millisToNanos(v interface{}) interface{} {
+	var ms int64
+	switch n := v.(type) {
+	case int:
+		ms = int64(n)
+	case int32:
+		ms = int64(n)
+	case int64:
+		ms = n
+	case uint32:
+		ms = int64(n)
+	case uint64:
+		ms = int64(n)
+	default:
+		return v
+	}
+	return ms * int64(time.Millisecond)
+}
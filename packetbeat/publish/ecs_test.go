@@ -0,0 +1,120 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package publish
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func testTransaction() common.MapStr {
+	return common.MapStr{
+		"type":         "http",
+		"client_ip":    "10.0.0.1",
+		"client_port":  uint16(34567),
+		"ip":           "10.0.0.2",
+		"port":         uint16(80),
+		"bytes_in":     uint64(120),
+		"bytes_out":    uint64(3400),
+		"transport":    "tcp",
+		"direction":    "in",
+		"responsetime": int32(12),
+		"method":       "GET",
+		"path":         "/index.html",
+		"http": common.MapStr{
+			"response": common.MapStr{
+				"code":   404,
+				"phrase": "Not found",
+			},
+		},
+	}
+}
+
+func TestApplyECS(t *testing.T) {
+	event := testTransaction()
+	applyECS(ecsConfig{Enabled: true}, event)
+
+	assert.Equal(t, common.MapStr{
+		"type": "http",
+		"source": common.MapStr{
+			"ip":    "10.0.0.1",
+			"port":  uint16(34567),
+			"bytes": uint64(120),
+		},
+		"destination": common.MapStr{
+			"ip":    "10.0.0.2",
+			"port":  uint16(80),
+			"bytes": uint64(3400),
+		},
+		"network": common.MapStr{
+			"transport": "tcp",
+			"direction": "inbound",
+		},
+		"event": common.MapStr{
+			"duration": int64(12000000),
+			"dataset":  "http",
+		},
+		"url": common.MapStr{
+			"path": "/index.html",
+		},
+		"http": common.MapStr{
+			"request": common.MapStr{
+				"method": "GET",
+			},
+			"response": common.MapStr{
+				"status_code": 404,
+				"phrase":      "Not found",
+			},
+		},
+	}, event)
+}
+
+func TestApplyECSKeepLegacy(t *testing.T) {
+	event := testTransaction()
+	applyECS(ecsConfig{Enabled: true, KeepLegacy: true}, event)
+
+	legacy := testTransaction()
+	for key := range legacy.Flatten() {
+		assert.Contains(t, event.Flatten(), key)
+	}
+	assert.Equal(t, "10.0.0.1", event["client_ip"])
+	assert.Equal(t, "10.0.0.1", event["source"].(common.MapStr)["ip"])
+	assert.Equal(t, 404, event["http"].(common.MapStr)["response"].(common.MapStr)["code"])
+	assert.Equal(t, 404, event["http"].(common.MapStr)["response"].(common.MapStr)["status_code"])
+}
+
+func TestApplyECSProtocolFields(t *testing.T) {
+	event := common.MapStr{
+		"type":   "mysql",
+		"method": "SELECT",
+		"path":   "test.users",
+	}
+	applyECS(ecsConfig{Enabled: true}, event)
+
+	assert.Equal(t, common.MapStr{
+		"type":   "mysql",
+		"method": "SELECT",
+		"path":   "test.users",
+		"event":  common.MapStr{"dataset": "mysql"},
+	}, event)
+}
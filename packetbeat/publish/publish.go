@@ -19,54 +19,174 @@ package publish
 
 import (
 	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
 
 	"github.com/elastic/beats/libbeat/beat"
 	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/atomic"
 	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/monitoring"
 	"github.com/elastic/beats/libbeat/processors"
 )
 
 type TransactionPublisher struct {
 	done      chan struct{}
+	closing   chan struct{}
+	workers   sync.WaitGroup
+	waitClose time.Duration
 	pipeline  beat.Pipeline
-	canDrop   bool
+	queue     queueConfig
+	limiter   *rateLimiter
+	tunnels   *Tunnels
 	processor transProcessor
+
+	// time each worker started publishing its current event in unix
+	// nanoseconds, 0 if the worker is not publishing
+	publishingMutex sync.Mutex
+	publishing      []*atomic.Int64
 }
 
 type transProcessor struct {
 	ignoreOutgoing bool
 	localIPs       []string
 	name           string
+	tunnels        *Tunnels
+	ecs            ecsConfig
+	validator      *fieldsValidator // nil unless validate_fields is enabled
 }
 
 var debugf = logp.MakeDebug("publish")
 
+// transactionCounters counts the published events per event type.
+var transactionCounters = struct {
+	sync.Mutex
+	registry *monitoring.Registry
+	counters map[string]*monitoring.Int
+}{
+	registry: monitoring.Default.NewRegistry("publish.transactions"),
+	counters: map[string]*monitoring.Int{},
+}
+
+func countTransaction(event *beat.Event) {
+	typ, _ := event.Fields["type"].(string)
+
+	transactionCounters.Lock()
+	counter, exists := transactionCounters.counters[typ]
+	if !exists {
+		counter = monitoring.NewInt(transactionCounters.registry, typ)
+		transactionCounters.counters[typ] = counter
+	}
+	transactionCounters.Unlock()
+
+	counter.Inc()
+}
+
 func NewTransactionPublisher(
 	name string,
 	pipeline beat.Pipeline,
 	ignoreOutgoing bool,
 	canDrop bool,
+	queue *common.Config,
+	ecs *common.Config,
+	rateLimit *common.Config,
 ) (*TransactionPublisher, error) {
 	localIPs, err := common.LocalIPAddrsAsStrings(false)
 	if err != nil {
 		return nil, err
 	}
 
+	queueConfig := defaultQueueConfig
+	if queue != nil {
+		if err := queue.Unpack(&queueConfig); err != nil {
+			return nil, err
+		}
+	}
+	if queueConfig.OnFull == "" {
+		// events can only be dropped when capturing live traffic
+		if canDrop && !queueConfig.Guaranteed {
+			queueConfig.OnFull = policyDropNewest
+		} else {
+			queueConfig.OnFull = policyBlock
+		}
+	}
+
+	var ecsConfig ecsConfig
+	if ecs != nil {
+		if err := ecs.Unpack(&ecsConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	rateLimitConfig := defaultRateLimitConfig
+	if rateLimit != nil {
+		if err := rateLimit.Unpack(&rateLimitConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	tunnels := NewTunnels()
+	tunnels.cache.StartJanitor(tunnelTimeout)
+
 	p := &TransactionPublisher{
 		done:     make(chan struct{}),
+		closing:  make(chan struct{}),
 		pipeline: pipeline,
-		canDrop:  canDrop,
+		queue:    queueConfig,
+		limiter:  newRateLimiter(rateLimitConfig),
+		tunnels:  tunnels,
 		processor: transProcessor{
 			localIPs:       localIPs,
 			name:           name,
 			ignoreOutgoing: ignoreOutgoing,
+			tunnels:        tunnels,
+			ecs:            ecsConfig,
 		},
 	}
 	return p, nil
 }
 
+// Stop stops the workers right away, dropping the events not yet published.
 func (p *TransactionPublisher) Stop() {
 	close(p.done)
+	p.tunnels.cache.StopJanitor()
+}
+
+// Drain publishes the events still buffered in the queues and closes the
+// clients, which wait for the output to acknowledge the published events for
+// up to the duration set by SetWaitClose. It returns once all workers are
+// done. Stop must still be called afterwards, it aborts the draining if
+// called concurrently.
+func (p *TransactionPublisher) Drain() {
+	close(p.closing)
+	p.workers.Wait()
+}
+
+// SetWaitClose sets how long the clients wait on close for the output to
+// acknowledge the published events. It must be called before the reporters
+// are created.
+func (p *TransactionPublisher) SetWaitClose(timeout time.Duration) {
+	p.waitClose = timeout
+}
+
+// ValidateFields enables the validation of the published events against the
+// fields definition, given as the contents of a fields.yml file. It must be
+// called before the reporters are created.
+func (p *TransactionPublisher) ValidateFields(fields []byte) error {
+	validator, err := newFieldsValidator(fields)
+	if err != nil {
+		return err
+	}
+	p.processor.validator = validator
+	return nil
+}
+
+// Tunnels returns the tunnel table used to annotate transaction events. The
+// packet decoder records the tunnels it decapsulates into it.
+func (p *TransactionPublisher) Tunnels() *Tunnels {
+	return p.tunnels
 }
 
 func (p *TransactionPublisher) CreateReporter(
@@ -77,11 +197,21 @@ func (p *TransactionPublisher) CreateReporter(
 	meta := struct {
 		Event      common.EventMetadata    `config:",inline"`
 		Processors processors.PluginConfig `config:"processors"`
+		Aggregate  *common.Config          `config:"aggregate"`
 	}{}
 	if err := config.Unpack(&meta); err != nil {
 		return nil, err
 	}
 
+	var agg *aggregator
+	if meta.Aggregate.Enabled() {
+		aggConfig := defaultAggregateConfig
+		if err := meta.Aggregate.Unpack(&aggConfig); err != nil {
+			return nil, err
+		}
+		agg = newAggregator(aggConfig)
+	}
+
 	processors, err := processors.New(meta.Processors)
 	if err != nil {
 		return nil, err
@@ -90,8 +220,12 @@ func (p *TransactionPublisher) CreateReporter(
 	clientConfig := beat.ClientConfig{
 		EventMetadata: meta.Event,
 		Processor:     processors,
+		WaitClose:     p.waitClose,
 	}
-	if p.canDrop {
+	switch {
+	case p.queue.Guaranteed:
+		clientConfig.PublishMode = beat.GuaranteedSend
+	case p.queue.OnFull == policyDropNewest:
 		clientConfig.PublishMode = beat.DropIfFull
 	}
 
@@ -100,31 +234,135 @@ func (p *TransactionPublisher) CreateReporter(
 		return nil, err
 	}
 
+	publishing := atomic.NewInt64(0)
+	p.publishingMutex.Lock()
+	p.publishing = append(p.publishing, publishing)
+	p.publishingMutex.Unlock()
+
 	// start worker, so post-processing and processor-pipeline
 	// can work concurrently to sniffer acquiring new events
-	ch := make(chan beat.Event, 3)
-	go p.worker(ch, client)
-	return func(event beat.Event) {
+	queue := newEventQueue(p.queue, p.done)
+	p.workers.Add(1)
+	if agg != nil {
+		go p.aggregateWorker(queue, client, publishing, agg)
+	} else {
+		go p.worker(queue, client, publishing)
+	}
+	return queue.push, nil
+}
+
+// CheckStalled returns an error if a worker has been blocked publishing an
+// event for longer than timeout.
+func (p *TransactionPublisher) CheckStalled(timeout time.Duration) error {
+	p.publishingMutex.Lock()
+	defer p.publishingMutex.Unlock()
+
+	now := time.Now().UnixNano()
+	for _, publishing := range p.publishing {
+		if since := publishing.Load(); since != 0 && time.Duration(now-since) > timeout {
+			return fmt.Errorf("publishing an event is blocked for %v", time.Duration(now-since))
+		}
+	}
+	return nil
+}
+
+func (p *TransactionPublisher) worker(queue *eventQueue, client beat.Client, publishing *atomic.Int64) {
+	defer p.workers.Done()
+	defer queue.stop()
+
+	ch := queue.ch
+	for {
 		select {
-		case ch <- event:
 		case <-p.done:
-			ch = nil // stop serving more send requests
+			return
+		case <-p.closing:
+			for {
+				select {
+				case event := <-ch:
+					p.publish(client, publishing, event)
+				default:
+					client.Close()
+					return
+				}
+			}
+		case event := <-ch:
+			p.publish(client, publishing, event)
 		}
-	}, nil
+	}
+}
+
+func (p *TransactionPublisher) publish(client beat.Client, publishing *atomic.Int64, event beat.Event) {
+	pub, _ := p.processor.Run(&event)
+	if pub != nil && p.limiter.wait(p.done) {
+		countTransaction(pub)
+		publishing.Store(time.Now().UnixNano())
+		client.Publish(*pub)
+		publishing.Store(0)
+	}
 }
 
-func (p *TransactionPublisher) worker(ch chan beat.Event, client beat.Client) {
+// aggregateWorker is like worker, but aggregates identical events before
+// publishing them.
+func (p *TransactionPublisher) aggregateWorker(queue *eventQueue, client beat.Client, publishing *atomic.Int64, agg *aggregator) {
+	interval := agg.config.Window
+	if interval > time.Second {
+		interval = time.Second
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	defer p.workers.Done()
+	defer queue.stop()
+
+	ch := queue.ch
 	for {
 		select {
 		case <-p.done:
+			// the pipeline may be closed already, drop the open windows
 			return
-		case event := <-ch:
-			pub, _ := p.processor.Run(&event)
-			if pub != nil {
-				client.Publish(*pub)
+		case <-p.closing:
+			for drained := false; !drained; {
+				select {
+				case event := <-ch:
+					p.aggregate(client, publishing, agg, event)
+				default:
+					drained = true
+				}
 			}
+			p.publishAll(client, publishing, agg.flush(time.Now(), true))
+			client.Close()
+			return
+		case now := <-ticker.C:
+			p.publishAll(client, publishing, agg.flush(now, false))
+		case event := <-ch:
+			p.aggregate(client, publishing, agg, event)
+		}
+	}
+}
+
+func (p *TransactionPublisher) aggregate(client beat.Client, publishing *atomic.Int64, agg *aggregator, event beat.Event) {
+	pub, _ := p.processor.Run(&event)
+	if pub != nil {
+		countTransaction(pub)
+	}
+	if pub != nil && !agg.add(*pub, time.Now()) && p.limiter.wait(p.done) {
+		publishing.Store(time.Now().UnixNano())
+		client.Publish(*pub)
+		publishing.Store(0)
+	}
+}
+
+// publishAll publishes the events that are within the rate limit.
+func (p *TransactionPublisher) publishAll(client beat.Client, publishing *atomic.Int64, events []beat.Event) {
+	allowed := events[:0]
+	for _, event := range events {
+		if p.limiter.wait(p.done) {
+			allowed = append(allowed, event)
 		}
 	}
+
+	publishing.Store(time.Now().UnixNano())
+	client.PublishAll(allowed)
+	publishing.Store(0)
 }
 
 func (p *transProcessor) Run(event *beat.Event) (*beat.Event, error) {
@@ -137,6 +375,14 @@ func (p *transProcessor) Run(event *beat.Event) (*beat.Event, error) {
 		return nil, nil
 	}
 
+	if p.ecs.Enabled {
+		applyECS(p.ecs, event.Fields)
+	}
+
+	if p.validator != nil {
+		p.validator.check(event)
+	}
+
 	return event, nil
 }
 
@@ -193,6 +439,9 @@ func (p *transProcessor) normalizeTransAddr(event common.MapStr) bool {
 		if len(src.Cmdline) > 0 {
 			event["client_cmdline"] = src.Cmdline
 		}
+		if src.PID > 0 {
+			event["client_pid"] = src.PID
+		}
 		if _, exists := event["client_server"]; !exists {
 			event["client_server"] = p.GetServerName(src.IP)
 		}
@@ -208,6 +457,9 @@ func (p *transProcessor) normalizeTransAddr(event common.MapStr) bool {
 		if len(dst.Cmdline) > 0 {
 			event["cmdline"] = dst.Cmdline
 		}
+		if dst.PID > 0 {
+			event["pid"] = dst.PID
+		}
 		if _, exists := event["server"]; !exists {
 			event["server"] = p.GetServerName(dst.IP)
 		}
@@ -221,6 +473,13 @@ func (p *transProcessor) normalizeTransAddr(event common.MapStr) bool {
 
 	}
 
+	if p.tunnels != nil && src != nil && dst != nil {
+		tunnel := p.tunnels.Lookup(net.ParseIP(src.IP), net.ParseIP(dst.IP))
+		if tunnel != nil {
+			event["tunnel"] = tunnel.toMapStr()
+		}
+	}
+
 	return true
 }
 
@@ -27,6 +27,9 @@ import (
 
 	"github.com/elastic/beats/libbeat/beat"
 	"github.com/elastic/beats/libbeat/common"
+	"github.com/elastic/beats/libbeat/common/atomic"
+	"github.com/elastic/beats/libbeat/monitoring"
+	pubtest "github.com/elastic/beats/libbeat/publisher/testing"
 )
 
 func testEvent() beat.Event {
@@ -105,6 +108,7 @@ func TestDirectionOut(t *testing.T) {
 				Name:    "server1",
 				Cmdline: "proc1 start",
 				Proc:    "proc1",
+				PID:     1234,
 			},
 			"dst": &common.Endpoint{
 				IP:      "192.145.2.5",
@@ -121,6 +125,8 @@ func TestDirectionOut(t *testing.T) {
 	}
 	assert.True(t, event.Fields["client_ip"] == "192.145.2.4")
 	assert.True(t, event.Fields["direction"] == "out")
+	assert.Equal(t, 1234, event.Fields["client_pid"])
+	assert.NotContains(t, event.Fields, "pid")
 }
 
 func TestDirectionIn(t *testing.T) {
@@ -193,3 +199,94 @@ func TestNoDirection(t *testing.T) {
 	_, ok := event.Fields["direction"]
 	assert.False(t, ok)
 }
+
+func TestCountTransaction(t *testing.T) {
+	event := testEvent()
+	countTransaction(&event)
+	countTransaction(&event)
+
+	counter := transactionCounters.registry.Get("test")
+	if assert.NotNil(t, counter) {
+		assert.Equal(t, int64(2), counter.(*monitoring.Int).Get())
+	}
+}
+
+func TestCheckStalled(t *testing.T) {
+	idle, publishing := atomic.NewInt64(0), atomic.NewInt64(0)
+	p := &TransactionPublisher{
+		publishing: []*atomic.Int64{idle, publishing},
+	}
+	assert.NoError(t, p.CheckStalled(time.Second))
+
+	publishing.Store(time.Now().UnixNano())
+	assert.NoError(t, p.CheckStalled(time.Second))
+
+	publishing.Store(time.Now().Add(-2 * time.Second).UnixNano())
+	assert.Error(t, p.CheckStalled(time.Second))
+}
+
+type closeRecorder struct {
+	*pubtest.ChanClient
+	closed bool
+}
+
+func (c *closeRecorder) Close() error {
+	c.closed = true
+	return c.ChanClient.Close()
+}
+
+// Test that Drain publishes the buffered events and closes the client.
+func TestDrain(t *testing.T) {
+	client := &closeRecorder{ChanClient: pubtest.NewChanClient(10)}
+	p, err := NewTransactionPublisher("test", pubtest.PublisherWithClient(client), false, false, nil, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Stop()
+
+	report, err := p.CreateReporter(common.NewConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		event := testEvent()
+		event.Fields["type"] = "drain"
+		report(event)
+	}
+	p.Drain()
+
+	assert.Len(t, client.Channel, 3)
+	assert.True(t, client.closed)
+}
+
+// Test that reporting events after Drain does not block, even if the queue is
+// full.
+func TestReportAfterDrain(t *testing.T) {
+	client := &closeRecorder{ChanClient: pubtest.NewChanClient(10)}
+	p, err := NewTransactionPublisher("test", pubtest.PublisherWithClient(client), false, false,
+		common.MustNewConfigFrom(map[string]interface{}{"size": 1}), nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Stop()
+
+	report, err := p.CreateReporter(common.NewConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Drain()
+
+	reported := make(chan struct{})
+	go func() {
+		defer close(reported)
+		for i := 0; i < 3; i++ {
+			report(testEvent())
+		}
+	}()
+
+	select {
+	case <-reported:
+	case <-time.After(5 * time.Second):
+		t.Fatal("report blocked after Drain")
+	}
+}
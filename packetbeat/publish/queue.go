@@ -0,0 +1,124 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package publish
+
+import (
+	"fmt"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/monitoring"
+)
+
+// Policies applied by the event queue when it is full.
+const (
+	policyBlock      = "block"
+	policyDropNewest = "drop_newest"
+	policyDropOldest = "drop_oldest"
+)
+
+var droppedEvents = monitoring.NewInt(nil, "publish.dropped_events")
+
+type queueConfig struct {
+	Size       int    `config:"size"`       // Number of events buffered per protocol.
+	OnFull     string `config:"on_full"`    // Policy applied when the queue is full.
+	Guaranteed bool   `config:"guaranteed"` // Retry events until acknowledged by the output.
+}
+
+var defaultQueueConfig = queueConfig{
+	Size: 1024,
+}
+
+func (c *queueConfig) Validate() error {
+	if c.Size <= 0 {
+		return fmt.Errorf("publish_queue size must be greater than 0")
+	}
+	if c.Guaranteed && c.OnFull != "" && c.OnFull != policyBlock {
+		return fmt.Errorf("publish_queue guaranteed requires the on_full policy to be %s", policyBlock)
+	}
+	switch c.OnFull {
+	case "", policyBlock, policyDropNewest, policyDropOldest:
+		return nil
+	default:
+		return fmt.Errorf("invalid publish_queue on_full policy '%s'", c.OnFull)
+	}
+}
+
+// eventQueue buffers the events reported by a protocol analyzer until they
+// are published by the worker. It decouples the analyzers from the publisher
+// pipeline, so that a slow output stalls packet processing only if the
+// policy is to block.
+type eventQueue struct {
+	ch      chan beat.Event
+	policy  string
+	done    <-chan struct{}
+	stopped chan struct{} // closed once the worker does not consume events anymore
+}
+
+func newEventQueue(config queueConfig, done <-chan struct{}) *eventQueue {
+	return &eventQueue{
+		ch:      make(chan beat.Event, config.Size),
+		policy:  config.OnFull,
+		done:    done,
+		stopped: make(chan struct{}),
+	}
+}
+
+// push adds an event to the queue. If the queue is full, push blocks until
+// there is room, drops the event or drops the oldest event in the queue,
+// depending on the policy. Events pushed once the publisher is stopped or the
+// queue is drained are dropped.
+func (q *eventQueue) push(event beat.Event) {
+	if q.policy == policyBlock {
+		select {
+		case q.ch <- event:
+		case <-q.done:
+		case <-q.stopped:
+		}
+		return
+	}
+
+	for {
+		select {
+		case <-q.done:
+			return
+		case <-q.stopped:
+			return
+		case q.ch <- event:
+			return
+		default:
+		}
+
+		if q.policy == policyDropNewest {
+			droppedEvents.Add(1)
+			return
+		}
+
+		// make room by dropping the oldest event, unless the worker did already
+		select {
+		case <-q.ch:
+			droppedEvents.Add(1)
+		default:
+		}
+	}
+}
+
+// stop is called by the worker when it exits, so that pushes don't block on a
+// queue that is not consumed anymore.
+func (q *eventQueue) stop() {
+	close(q.stopped)
+}
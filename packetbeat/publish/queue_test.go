@@ -0,0 +1,94 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package publish
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestEventQueuePolicies(t *testing.T) {
+	newEvent := func(i int) beat.Event {
+		return beat.Event{Fields: common.MapStr{"i": i}}
+	}
+	drain := func(q *eventQueue) []interface{} {
+		var values []interface{}
+		for len(q.ch) > 0 {
+			event := <-q.ch
+			values = append(values, event.Fields["i"])
+		}
+		return values
+	}
+
+	tests := map[string][]interface{}{
+		policyDropNewest: {0, 1},
+		policyDropOldest: {2, 3},
+	}
+	for policy, expected := range tests {
+		t.Run(policy, func(t *testing.T) {
+			q := newEventQueue(queueConfig{Size: 2, OnFull: policy}, make(chan struct{}))
+			before := droppedEvents.Get()
+			for i := 0; i < 4; i++ {
+				q.push(newEvent(i))
+			}
+			assert.Equal(t, expected, drain(q))
+			assert.Equal(t, before+2, droppedEvents.Get())
+		})
+	}
+}
+
+func TestEventQueueBlockStopsOnDone(t *testing.T) {
+	done := make(chan struct{})
+	q := newEventQueue(queueConfig{Size: 1, OnFull: policyBlock}, done)
+	q.push(beat.Event{})
+
+	close(done)
+	q.push(beat.Event{}) // must not block once the publisher is stopped
+	assert.Len(t, q.ch, 1)
+}
+
+func TestEventQueueBlockStopsOnStop(t *testing.T) {
+	q := newEventQueue(queueConfig{Size: 1, OnFull: policyBlock}, make(chan struct{}))
+	q.push(beat.Event{})
+
+	q.stop()
+	q.push(beat.Event{}) // must not block once the worker exited
+	assert.Len(t, q.ch, 1)
+}
+
+func TestQueueConfigValidate(t *testing.T) {
+	configs := map[string]map[string]interface{}{
+		"invalid size":   {"size": 0},
+		"invalid policy": {"on_full": "drop_all"},
+		"guaranteed":     {"guaranteed": true, "on_full": "drop_oldest"},
+	}
+
+	for name, config := range configs {
+		t.Run(name, func(t *testing.T) {
+			queueConfig := defaultQueueConfig
+			err := common.MustNewConfigFrom(config).Unpack(&queueConfig)
+			assert.Error(t, err)
+		})
+	}
+}
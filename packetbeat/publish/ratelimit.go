@@ -0,0 +1,139 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package publish
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/libbeat/common/ratelimit"
+	"github.com/elastic/beats/libbeat/monitoring"
+)
+
+// Policies applied by the rate limiter when the events exceed the limit.
+const (
+	policyDrop     = "drop"
+	policyThrottle = "throttle"
+)
+
+var (
+	rateLimitRegistry = monitoring.Default.NewRegistry("publish.rate_limit")
+	throttledEvents   = monitoring.NewInt(rateLimitRegistry, "throttled")
+	rateLimitDropped  = monitoring.NewInt(rateLimitRegistry, "dropped")
+)
+
+type rateLimitConfig struct {
+	EventsPerSecond float64 `config:"events_per_second"` // Maximum rate of published events. 0 disables the limit.
+	Burst           int     `config:"burst"`             // Bucket size. Defaults to events_per_second.
+	OnLimit         string  `config:"on_limit"`          // Policy applied to events over the limit.
+}
+
+var defaultRateLimitConfig = rateLimitConfig{
+	OnLimit: policyThrottle,
+}
+
+func (c *rateLimitConfig) Validate() error {
+	if c.EventsPerSecond < 0 {
+		return fmt.Errorf("rate_limit events_per_second must be >= 0")
+	}
+	if c.Burst < 0 {
+		return fmt.Errorf("rate_limit burst must be >= 0")
+	}
+	switch c.OnLimit {
+	case policyDrop, policyThrottle:
+		return nil
+	default:
+		return fmt.Errorf("invalid rate_limit on_limit policy '%s'", c.OnLimit)
+	}
+}
+
+// rateLimiter is a token bucket shared by the workers of all protocols, so
+// that the total rate of published events is limited. Events over the limit
+// are dropped, or delayed until a token is available, depending on the
+// policy. Delaying events blocks the worker, so the protocol queues fill up
+// and their on_full policy applies.
+type rateLimiter struct {
+	policy string
+
+	mutex  sync.Mutex
+	now    func() time.Time
+	bucket *ratelimit.Bucket
+}
+
+// newRateLimiter returns the rate limiter for config, or nil if no limit is
+// configured.
+func newRateLimiter(config rateLimitConfig) *rateLimiter {
+	if config.EventsPerSecond == 0 {
+		return nil
+	}
+
+	return &rateLimiter{
+		policy: config.OnLimit,
+		now:    time.Now,
+		bucket: ratelimit.NewBucket(config.EventsPerSecond, float64(config.Burst)),
+	}
+}
+
+// wait reports whether the event can be published. It blocks while the
+// event is throttled, and returns false if the event is dropped or done is
+// closed while waiting.
+func (l *rateLimiter) wait(done <-chan struct{}) bool {
+	if l == nil {
+		return true
+	}
+
+	delay, ok := l.reserve()
+	if !ok {
+		rateLimitDropped.Inc()
+		return false
+	}
+	if delay == 0 {
+		return true
+	}
+
+	throttledEvents.Inc()
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-done:
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// reserve takes a token from the bucket. If the bucket is empty, the event
+// is dropped, or a token is borrowed from the future and the time until it
+// becomes available is returned.
+func (l *rateLimiter) reserve() (time.Duration, bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.bucket.Refill(l.now())
+	if l.bucket.Tokens() >= 1 {
+		l.bucket.Take()
+		return 0, true
+	}
+	if l.policy == policyDrop {
+		return 0, false
+	}
+
+	l.bucket.Take()
+	return l.bucket.Delay(), true
+}
@@ -0,0 +1,120 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package publish
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func newTestRateLimiter(t *testing.T, settings map[string]interface{}) (*rateLimiter, *time.Time) {
+	config := defaultRateLimitConfig
+	require.NoError(t, common.MustNewConfigFrom(settings).Unpack(&config))
+
+	limiter := newRateLimiter(config)
+	require.NotNil(t, limiter)
+
+	now := time.Now()
+	limiter.now = func() time.Time { return now }
+	return limiter, &now
+}
+
+func TestRateLimitConfig(t *testing.T) {
+	for _, c := range []struct {
+		config map[string]interface{}
+		valid  bool
+	}{
+		{map[string]interface{}{}, true},
+		{map[string]interface{}{"events_per_second": 100, "on_limit": "drop"}, true},
+		{map[string]interface{}{"events_per_second": -1}, false},
+		{map[string]interface{}{"events_per_second": 100, "burst": -1}, false},
+		{map[string]interface{}{"events_per_second": 100, "on_limit": "block"}, false},
+	} {
+		config := defaultRateLimitConfig
+		err := common.MustNewConfigFrom(c.config).Unpack(&config)
+		assert.Equal(t, c.valid, err == nil, "%v: %v", c.config, err)
+	}
+}
+
+func TestRateLimitDisabled(t *testing.T) {
+	limiter := newRateLimiter(defaultRateLimitConfig)
+	assert.Nil(t, limiter)
+	assert.True(t, limiter.wait(nil))
+}
+
+func TestRateLimitDrop(t *testing.T) {
+	limiter, now := newTestRateLimiter(t, map[string]interface{}{
+		"events_per_second": 2,
+		"on_limit":          "drop",
+	})
+
+	for i := 0; i < 2; i++ {
+		_, ok := limiter.reserve()
+		assert.True(t, ok)
+	}
+	_, ok := limiter.reserve()
+	assert.False(t, ok)
+
+	*now = now.Add(500 * time.Millisecond)
+	delay, ok := limiter.reserve()
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), delay)
+	_, ok = limiter.reserve()
+	assert.False(t, ok)
+}
+
+func TestRateLimitThrottle(t *testing.T) {
+	limiter, now := newTestRateLimiter(t, map[string]interface{}{
+		"events_per_second": 10,
+		"burst":             1,
+	})
+
+	delay, ok := limiter.reserve()
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), delay)
+
+	// events over the limit are spread out at the configured rate
+	delay, ok = limiter.reserve()
+	assert.True(t, ok)
+	assert.Equal(t, 100*time.Millisecond, delay)
+	delay, _ = limiter.reserve()
+	assert.Equal(t, 200*time.Millisecond, delay)
+
+	*now = now.Add(200 * time.Millisecond)
+	delay, _ = limiter.reserve()
+	assert.Equal(t, 100*time.Millisecond, delay)
+}
+
+func TestRateLimitWaitDone(t *testing.T) {
+	limiter, _ := newTestRateLimiter(t, map[string]interface{}{
+		"events_per_second": 0.001,
+	})
+
+	done := make(chan struct{})
+	assert.True(t, limiter.wait(done))
+
+	close(done)
+	assert.False(t, limiter.wait(done))
+}
@@ -0,0 +1,120 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package publish
+
+import (
+	"net"
+	"time"
+
+	"github.com/elastic/beats/libbeat/common"
+)
+
+const tunnelTimeout = 5 * time.Minute
+
+// Tunnel describes the encapsulation seen for traffic between two inner
+// addresses.
+type Tunnel struct {
+	Type  string // "gre", "vxlan" or "ipip"
+	SrcIP net.IP // outer source address
+	DstIP net.IP // outer destination address
+	ID    uint32 // VXLAN network identifier or GRE key
+	HasID bool
+}
+
+// Tunnels remembers, per pair of inner addresses, the tunnel the traffic was
+// last seen in, so transaction events can be annotated with the outer
+// addresses.
+type Tunnels struct {
+	cache *common.Cache
+}
+
+type tunnelKey [2 * net.IPv6len]byte
+
+// NewTunnels creates a new tunnel table. Entries not refreshed for 5 minutes
+// are removed.
+func NewTunnels() *Tunnels {
+	return &Tunnels{cache: common.NewCache(tunnelTimeout, 16)}
+}
+
+func makeTunnelKey(src, dst net.IP) (k tunnelKey, ok bool) {
+	src, dst = src.To16(), dst.To16()
+	if src == nil || dst == nil {
+		return k, false
+	}
+	copy(k[:net.IPv6len], src)
+	copy(k[net.IPv6len:], dst)
+	return k, true
+}
+
+// Record stores the tunnel for packets sent from src to dst, src and dst being
+// the inner addresses.
+func (t *Tunnels) Record(src, dst net.IP, tunnel *Tunnel) {
+	k, ok := makeTunnelKey(src, dst)
+	if !ok {
+		return
+	}
+
+	// Get refreshes the entry, only replace it if the tunnel has changed.
+	if old, ok := t.cache.Get(k).(*Tunnel); ok && old.equal(tunnel) {
+		return
+	}
+
+	stored := *tunnel
+	stored.SrcIP = append(net.IP(nil), tunnel.SrcIP...)
+	stored.DstIP = append(net.IP(nil), tunnel.DstIP...)
+	t.cache.Put(k, &stored)
+}
+
+// Lookup returns the tunnel the traffic between the inner addresses src and
+// dst was seen in. The outer addresses of the returned tunnel are oriented the
+// same way as src and dst.
+func (t *Tunnels) Lookup(src, dst net.IP) *Tunnel {
+	if k, ok := makeTunnelKey(src, dst); ok {
+		if tunnel, ok := t.cache.Get(k).(*Tunnel); ok {
+			return tunnel
+		}
+	}
+	if k, ok := makeTunnelKey(dst, src); ok {
+		if tunnel, ok := t.cache.Get(k).(*Tunnel); ok {
+			reversed := *tunnel
+			reversed.SrcIP, reversed.DstIP = tunnel.DstIP, tunnel.SrcIP
+			return &reversed
+		}
+	}
+	return nil
+}
+
+func (t *Tunnel) equal(other *Tunnel) bool {
+	return t.Type == other.Type &&
+		t.ID == other.ID &&
+		t.HasID == other.HasID &&
+		t.SrcIP.Equal(other.SrcIP) &&
+		t.DstIP.Equal(other.DstIP)
+}
+
+func (t *Tunnel) toMapStr() common.MapStr {
+	m := common.MapStr{
+		"type":      t.Type,
+		"client_ip": t.SrcIP.String(),
+		"ip":        t.DstIP.String(),
+	}
+	if t.HasID {
+		m["id"] = t.ID
+	}
+	return m
+}
@@ -0,0 +1,110 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package publish
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+func TestTunnelsLookup(t *testing.T) {
+	tunnels := NewTunnels()
+	tunnels.Record(net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.2"), &Tunnel{
+		Type:  "vxlan",
+		SrcIP: net.ParseIP("192.168.0.1"),
+		DstIP: net.ParseIP("192.168.0.2"),
+		ID:    42,
+		HasID: true,
+	})
+
+	tunnel := tunnels.Lookup(net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.2"))
+	if assert.NotNil(t, tunnel) {
+		assert.Equal(t, "vxlan", tunnel.Type)
+		assert.Equal(t, "192.168.0.1", tunnel.SrcIP.String())
+		assert.Equal(t, "192.168.0.2", tunnel.DstIP.String())
+		assert.Equal(t, uint32(42), tunnel.ID)
+	}
+
+	// only the other direction has been seen
+	tunnel = tunnels.Lookup(net.ParseIP("10.0.0.2"), net.ParseIP("10.0.0.1"))
+	if assert.NotNil(t, tunnel) {
+		assert.Equal(t, "192.168.0.2", tunnel.SrcIP.String())
+		assert.Equal(t, "192.168.0.1", tunnel.DstIP.String())
+	}
+
+	assert.Nil(t, tunnels.Lookup(net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.3")))
+}
+
+func TestTunnelsRecordCopiesAddresses(t *testing.T) {
+	tunnels := NewTunnels()
+	outer := net.ParseIP("192.168.0.1")
+	tunnels.Record(net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.2"), &Tunnel{
+		Type:  "ipip",
+		SrcIP: outer,
+		DstIP: net.ParseIP("192.168.0.2"),
+	})
+	outer[15] = 99
+
+	tunnel := tunnels.Lookup(net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.2"))
+	if assert.NotNil(t, tunnel) {
+		assert.Equal(t, "192.168.0.1", tunnel.SrcIP.String())
+	}
+}
+
+func TestTunnelAnnotation(t *testing.T) {
+	tunnels := NewTunnels()
+	tunnels.Record(net.ParseIP("10.0.0.1"), net.ParseIP("10.0.0.2"), &Tunnel{
+		Type:  "gre",
+		SrcIP: net.ParseIP("192.168.0.1"),
+		DstIP: net.ParseIP("192.168.0.2"),
+	})
+	processor := transProcessor{name: "test", tunnels: tunnels}
+
+	event := beat.Event{
+		Timestamp: time.Now(),
+		Fields: common.MapStr{
+			"type": "test",
+			"src":  &common.Endpoint{IP: "10.0.0.1", Port: 3267},
+			"dst":  &common.Endpoint{IP: "10.0.0.2", Port: 80},
+		},
+	}
+	if res, _ := processor.Run(&event); res == nil {
+		t.Fatalf("event has been filtered out")
+	}
+	assert.Equal(t, common.MapStr{
+		"type":      "gre",
+		"client_ip": "192.168.0.1",
+		"ip":        "192.168.0.2",
+	}, event.Fields["tunnel"])
+
+	event.Fields = common.MapStr{
+		"type": "test",
+		"src":  &common.Endpoint{IP: "10.0.0.3"},
+		"dst":  &common.Endpoint{IP: "10.0.0.2"},
+	}
+	processor.Run(&event)
+	assert.NotContains(t, event.Fields, "tunnel")
+}
@@ -0,0 +1,75 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package publish
+
+import (
+	"sync"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/monitoring"
+	"github.com/elastic/beats/libbeat/template"
+)
+
+// maxReportedFields bounds the number of distinct violations remembered, in
+// case a parser bug creates many different field names.
+const maxReportedFields = 10000
+
+var invalidFields = monitoring.NewInt(nil, "publish.invalid_fields")
+
+// fieldsValidator checks the published events against the fields definition.
+// Each violation is logged once per protocol and field, so parser bugs causing
+// mapping explosions can be traced back to their protocol.
+type fieldsValidator struct {
+	validator *template.Validator
+
+	mutex    sync.Mutex
+	reported map[string]struct{}
+}
+
+func newFieldsValidator(fields []byte) (*fieldsValidator, error) {
+	validator, err := template.NewValidator(fields)
+	if err != nil {
+		return nil, err
+	}
+	return &fieldsValidator{
+		validator: validator,
+		reported:  map[string]struct{}{},
+	}, nil
+}
+
+func (v *fieldsValidator) check(event *beat.Event) {
+	errs := v.validator.Validate(event.Fields)
+	if len(errs) == 0 {
+		return
+	}
+	invalidFields.Add(int64(len(errs)))
+
+	proto, _ := event.Fields["type"].(string)
+
+	v.mutex.Lock()
+	defer v.mutex.Unlock()
+	for _, err := range errs {
+		key := proto + "/" + err.Field + "/" + err.Reason
+		if _, done := v.reported[key]; done || len(v.reported) >= maxReportedFields {
+			continue
+		}
+		v.reported[key] = struct{}{}
+		logp.Warn("Event of protocol %v doesn't match the fields definition: %v", proto, err)
+	}
+}
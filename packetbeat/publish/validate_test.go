@@ -0,0 +1,62 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package publish
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/libbeat/beat"
+	"github.com/elastic/beats/libbeat/common"
+)
+
+const validateTestFields = `
+- key: test
+  title: Test
+  fields:
+    - name: type
+      type: keyword
+    - name: bytes_in
+      type: long
+`
+
+func TestFieldsValidator(t *testing.T) {
+	v, err := newFieldsValidator([]byte(validateTestFields))
+	require.NoError(t, err)
+
+	before := invalidFields.Get()
+	for i := 0; i < 2; i++ {
+		v.check(&beat.Event{Fields: common.MapStr{
+			"type":     "http",
+			"bytes_in": "12",
+			"unknown":  true,
+		}})
+	}
+	assert.Equal(t, int64(4), invalidFields.Get()-before)
+
+	// each violation is remembered once per protocol
+	assert.Len(t, v.reported, 2)
+	assert.Contains(t, v.reported, "http/unknown/not defined")
+
+	v.check(&beat.Event{Fields: common.MapStr{"type": "dns", "bytes_in": 12}})
+	assert.Len(t, v.reported, 2)
+}
@@ -0,0 +1,93 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var validName = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+
+func generateProtocol(protocol, beatPath, beatsPath string) error {
+	protocolPath := filepath.Join(beatPath, "protos", protocol)
+	if _, err := os.Stat(protocolPath); !os.IsNotExist(err) {
+		return fmt.Errorf("protocol already exists: %s", protocol)
+	}
+
+	replace := strings.NewReplacer(
+		"{protocol}", protocol,
+		"{plugin_type}", protocol+"Plugin",
+		"{plugin_var}", protocol[:1]+"p",
+	)
+
+	templatesPath := filepath.Join(beatsPath, "packetbeat", "scripts", "tcp-protocol", "{protocol}")
+	return filepath.Walk(templatesPath, func(p string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		rel, err := filepath.Rel(templatesPath, p)
+		if err != nil {
+			return err
+		}
+		dest := filepath.Join(protocolPath, strings.TrimSuffix(replace.Replace(rel), ".tmpl"))
+
+		c, err := ioutil.ReadFile(p)
+		if err != nil {
+			return fmt.Errorf("cannot read template: %v", err)
+		}
+		if err := os.MkdirAll(filepath.Dir(dest), 0750); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(dest, []byte(replace.Replace(string(c))), 0644); err != nil {
+			return fmt.Errorf("cannot copy template: %v", err)
+		}
+		return nil
+	})
+}
+
+func main() {
+	protocol := flag.String("protocol", "", "Name of the protocol")
+	beatPath := flag.String("path", ".", "Path to the beat the protocol is added to")
+	beatsPath := flag.String("beats_path", ".", "Path to elastic/beats")
+	flag.Parse()
+
+	if *protocol == "" {
+		fmt.Println("Missing parameter: protocol")
+		os.Exit(1)
+	}
+	if !validName.MatchString(*protocol) {
+		fmt.Printf("Invalid protocol name: %s, it must be a lower case Go package name\n", *protocol)
+		os.Exit(1)
+	}
+
+	err := generateProtocol(*protocol, *beatPath, *beatsPath)
+	if err != nil {
+		fmt.Printf("Cannot generate protocol: %v\n", err)
+		os.Exit(2)
+	}
+
+	fmt.Printf("New protocol was generated in protos/%s. Run `make update` to register it, "+
+		"then implement the parser and add its tests to %s_test.go.\n", *protocol, *protocol)
+}
@@ -20,6 +20,7 @@
 package sniffer
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/tsg/gopacket"
@@ -31,8 +32,12 @@ type afpacketHandle struct {
 	TPacket *afpacket.TPacket
 }
 
+// newAfpacketHandle opens a TPACKET ring, using the highest version the kernel
+// supports. If fanoutGroupID is set, the socket joins the fanout group so that
+// the traffic is spread over all the sockets in the group. Packets are
+// distributed by flow hash, so each flow is seen by a single socket.
 func newAfpacketHandle(device string, snaplen int, block_size int, num_blocks int,
-	timeout time.Duration) (*afpacketHandle, error) {
+	timeout time.Duration, fanoutGroupID *uint16) (*afpacketHandle, error) {
 
 	h := &afpacketHandle{}
 	var err error
@@ -51,8 +56,18 @@ func newAfpacketHandle(device string, snaplen int, block_size int, num_blocks in
 			afpacket.OptNumBlocks(num_blocks),
 			afpacket.OptPollTimeout(timeout))
 	}
+	if err != nil {
+		return nil, err
+	}
+
+	if fanoutGroupID != nil {
+		if err = h.TPacket.SetFanout(afpacket.FanoutHashWithDefrag, *fanoutGroupID); err != nil {
+			h.TPacket.Close()
+			return nil, fmt.Errorf("failed to join fanout group %d: %v", *fanoutGroupID, err)
+		}
+	}
 
-	return h, err
+	return h, nil
 }
 
 func (h *afpacketHandle) ReadPacketData() (data []byte, ci gopacket.CaptureInfo, err error) {
@@ -63,6 +78,11 @@ func (h *afpacketHandle) SetBPFFilter(expr string) (_ error) {
 	return h.TPacket.SetBPFFilter(expr)
 }
 
+func (h *afpacketHandle) stats() (captureStats, error) {
+	st, err := h.TPacket.SocketStats()
+	return captureStats{received: st.Packets, dropped: st.Drops}, err
+}
+
 func (h *afpacketHandle) LinkType() layers.LinkType {
 	return layers.LinkTypeEthernet
 }
@@ -36,13 +36,21 @@ type fileHandler struct {
 	loopCount, maxLoopCount int
 
 	topSpeed bool
+	speed    float64
 	lastTS   time.Time
 }
 
-func newFileHandler(file string, topSpeed bool, maxLoopCount int) (*fileHandler, error) {
+// newFileHandler opens a pcap file for replay. Unless topSpeed is set, the
+// gaps between packets are respected, divided by the speed factor. The file
+// is read maxLoopCount times, or forever if maxLoopCount is 0.
+func newFileHandler(file string, topSpeed bool, speed float64, maxLoopCount int) (*fileHandler, error) {
+	if speed <= 0 {
+		speed = 1
+	}
 	h := &fileHandler{
 		file:         file,
 		topSpeed:     topSpeed,
+		speed:        speed,
 		maxLoopCount: maxLoopCount,
 	}
 	if err := h.open(); err != nil {
@@ -73,7 +81,7 @@ func (h *fileHandler) ReadPacketData() ([]byte, gopacket.CaptureInfo, error) {
 		h.pcapHandle = nil
 
 		h.loopCount++
-		if h.loopCount >= h.maxLoopCount {
+		if h.maxLoopCount > 0 && h.loopCount >= h.maxLoopCount {
 			return data, ci, err
 		}
 
@@ -83,8 +91,12 @@ func (h *fileHandler) ReadPacketData() ([]byte, gopacket.CaptureInfo, error) {
 		}
 
 		data, ci, err = h.pcapHandle.ReadPacketData()
-		h.lastTS = ci.Timestamp
-		return data, ci, err
+		if err != nil {
+			return data, ci, err
+		}
+
+		// don't sleep for the gap between the end and the start of the file
+		h.lastTS = time.Time{}
 	}
 
 	if h.topSpeed {
@@ -94,8 +106,8 @@ func (h *fileHandler) ReadPacketData() ([]byte, gopacket.CaptureInfo, error) {
 	if !h.lastTS.IsZero() {
 		sleep := ci.Timestamp.Sub(h.lastTS)
 		if sleep > 0 {
-			time.Sleep(sleep)
-		} else {
+			time.Sleep(time.Duration(float64(sleep) / h.speed))
+		} else if sleep < 0 {
 			logp.Warn("Time in pcap went backwards: %d", sleep)
 		}
 	}
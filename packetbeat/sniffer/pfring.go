@@ -0,0 +1,88 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build linux,havepfring
+
+package sniffer
+
+import (
+	"fmt"
+
+	"github.com/tsg/gopacket"
+	"github.com/tsg/gopacket/layers"
+	"github.com/tsg/gopacket/pfring"
+)
+
+const pfringAvailable = true
+
+type pfringHandle struct {
+	Ring *pfring.Ring
+}
+
+// newPfringHandle opens a PF_RING socket on the device. If clusterID is set,
+// the ring joins the cluster so that the traffic is spread over all the rings
+// of the cluster. Packets are distributed per flow, so each flow is seen by a
+// single ring.
+func newPfringHandle(device string, snaplen int, promisc bool, clusterID *int) (*pfringHandle, error) {
+	var flags pfring.Flag
+	if promisc {
+		flags |= pfring.FlagPromisc
+	}
+
+	ring, err := pfring.NewRing(device, uint32(snaplen), flags)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &pfringHandle{Ring: ring}
+	if clusterID != nil {
+		if err := ring.SetCluster(*clusterID, pfring.ClusterPerFlow); err != nil {
+			ring.Close()
+			return nil, fmt.Errorf("failed to join cluster %d: %v", *clusterID, err)
+		}
+	}
+	if err := ring.SetSocketMode(pfring.ReadOnly); err != nil {
+		ring.Close()
+		return nil, err
+	}
+	return h, nil
+}
+
+func (h *pfringHandle) ReadPacketData() (data []byte, ci gopacket.CaptureInfo, err error) {
+	return h.Ring.ReadPacketData()
+}
+
+func (h *pfringHandle) SetBPFFilter(expr string) (_ error) {
+	return h.Ring.SetBPFFilter(expr)
+}
+
+func (h *pfringHandle) Enable() (_ error) {
+	return h.Ring.Enable()
+}
+
+func (h *pfringHandle) stats() (captureStats, error) {
+	st, err := h.Ring.Stats()
+	return captureStats{received: st.Received, dropped: st.Dropped}, err
+}
+
+func (h *pfringHandle) LinkType() layers.LinkType {
+	return layers.LinkTypeEthernet
+}
+
+func (h *pfringHandle) Close() {
+	h.Ring.Close()
+}
@@ -0,0 +1,61 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !linux !havepfring
+
+package sniffer
+
+import (
+	"fmt"
+
+	"github.com/tsg/gopacket"
+	"github.com/tsg/gopacket/layers"
+)
+
+const pfringAvailable = false
+
+var errPfringNotAvailable = fmt.Errorf("PF_RING sniffing is only available on Linux builds with the havepfring tag")
+
+type pfringHandle struct {
+}
+
+func newPfringHandle(device string, snaplen int, promisc bool, clusterID *int) (*pfringHandle, error) {
+	return nil, errPfringNotAvailable
+}
+
+func (h *pfringHandle) ReadPacketData() (data []byte, ci gopacket.CaptureInfo, err error) {
+	return data, ci, errPfringNotAvailable
+}
+
+func (h *pfringHandle) SetBPFFilter(expr string) (_ error) {
+	return errPfringNotAvailable
+}
+
+func (h *pfringHandle) Enable() (_ error) {
+	return errPfringNotAvailable
+}
+
+func (h *pfringHandle) stats() (captureStats, error) {
+	return captureStats{}, errPfringNotAvailable
+}
+
+func (h *pfringHandle) LinkType() layers.LinkType {
+	return layers.LinkTypeEthernet
+}
+
+func (h *pfringHandle) Close() {
+}
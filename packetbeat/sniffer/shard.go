@@ -0,0 +1,197 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sniffer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sync"
+
+	"github.com/tsg/gopacket"
+	"github.com/tsg/gopacket/layers"
+)
+
+// shardQueueSize is the number of packets buffered per worker.
+const shardQueueSize = 1024
+
+// shardedWorker dispatches the packets to a pool of workers running
+// concurrently. The flows are sharded by a hash of their addresses, ports and
+// transport protocol, so all packets of a flow are processed in order by the
+// same worker.
+type shardedWorker struct {
+	linkType layers.LinkType
+	shards   []chan shardPacket
+	wg       sync.WaitGroup
+}
+
+type shardPacket struct {
+	data []byte
+	ci   gopacket.CaptureInfo
+}
+
+func newShardedWorker(factory WorkerFactory, linkType layers.LinkType, n int) (*shardedWorker, error) {
+	w := &shardedWorker{linkType: linkType}
+	for i := 0; i < n; i++ {
+		worker, err := factory(linkType)
+		if err != nil {
+			w.stop()
+			return nil, err
+		}
+
+		ch := make(chan shardPacket, shardQueueSize)
+		w.shards = append(w.shards, ch)
+		w.wg.Add(1)
+		go func() {
+			defer w.wg.Done()
+			for pkt := range ch {
+				worker.OnPacket(pkt.data, &pkt.ci)
+			}
+		}()
+	}
+	return w, nil
+}
+
+// OnPacket queues the packet to the worker owning its flow. The packet data
+// must not be reused by the caller.
+func (w *shardedWorker) OnPacket(data []byte, ci *gopacket.CaptureInfo) {
+	shard := flowHash(w.linkType, data) % uint32(len(w.shards))
+	w.shards[shard] <- shardPacket{data: data, ci: *ci}
+}
+
+// stop waits for the workers to process the queued packets.
+func (w *shardedWorker) stop() {
+	for _, ch := range w.shards {
+		close(ch)
+	}
+	w.wg.Wait()
+}
+
+// flowHash returns a hash of the addresses, ports and transport protocol of
+// an IPv4 or IPv6 packet. The hash is the same for both directions of a flow.
+// Packets that are not IP are hashed to 0.
+func flowHash(linkType layers.LinkType, data []byte) uint32 {
+	var etherType layers.EthernetType
+	switch linkType {
+	case layers.LinkTypeEthernet:
+		if len(data) < 14 {
+			return 0
+		}
+		etherType = layers.EthernetType(binary.BigEndian.Uint16(data[12:14]))
+		data = data[14:]
+		for etherType == layers.EthernetTypeDot1Q && len(data) >= 4 {
+			etherType = layers.EthernetType(binary.BigEndian.Uint16(data[2:4]))
+			data = data[4:]
+		}
+	case layers.LinkTypeLinuxSLL:
+		if len(data) < 16 {
+			return 0
+		}
+		etherType = layers.EthernetType(binary.BigEndian.Uint16(data[14:16]))
+		data = data[16:]
+	case layers.LinkTypeNull:
+		// the address family is in host byte order and its value for IPv6
+		// depends on the OS, so the IP version is checked instead
+		if len(data) < 5 {
+			return 0
+		}
+		data = data[4:]
+		switch data[0] >> 4 {
+		case 4:
+			etherType = layers.EthernetTypeIPv4
+		case 6:
+			etherType = layers.EthernetTypeIPv6
+		}
+	default:
+		return 0
+	}
+
+	switch etherType {
+	case layers.EthernetTypeIPv4:
+		return hashIPv4(data)
+	case layers.EthernetTypeIPv6:
+		return hashIPv6(data)
+	default:
+		return 0
+	}
+}
+
+func hashIPv4(data []byte) uint32 {
+	if len(data) < 20 {
+		return 0
+	}
+	headerLen := int(data[0]&0x0f) * 4
+	proto := layers.IPProtocol(data[9])
+
+	// fragments are hashed by their addresses only, as only the first
+	// fragment has the ports
+	flagsOffset := binary.BigEndian.Uint16(data[6:8])
+	fragmented := flagsOffset&0x3fff != 0
+
+	var ports []byte
+	if !fragmented && hasPorts(proto) && len(data) >= headerLen+4 {
+		ports = data[headerLen : headerLen+4]
+	}
+	return hashTuple(proto, data[12:16], data[16:20], ports)
+}
+
+func hashIPv6(data []byte) uint32 {
+	if len(data) < 40 {
+		return 0
+	}
+	proto := layers.IPProtocol(data[6])
+
+	// packets with extension headers are hashed by their addresses only
+	var ports []byte
+	if hasPorts(proto) && len(data) >= 44 {
+		ports = data[40:44]
+	}
+	return hashTuple(proto, data[8:24], data[24:40], ports)
+}
+
+func hasPorts(proto layers.IPProtocol) bool {
+	return proto == layers.IPProtocolTCP || proto == layers.IPProtocolUDP
+}
+
+// hashTuple computes the FNV-1a hash of the tuple, with the endpoints sorted
+// so that the hash does not depend on the direction.
+func hashTuple(proto layers.IPProtocol, src, dst, ports []byte) uint32 {
+	var srcPort, dstPort []byte
+	if ports != nil {
+		srcPort, dstPort = ports[0:2], ports[2:4]
+	}
+
+	cmp := bytes.Compare(src, dst)
+	if cmp > 0 || (cmp == 0 && bytes.Compare(srcPort, dstPort) > 0) {
+		src, dst = dst, src
+		srcPort, dstPort = dstPort, srcPort
+	}
+
+	h := (2166136261 ^ uint32(proto)) * 16777619
+	h = fnvAdd(h, src)
+	h = fnvAdd(h, srcPort)
+	h = fnvAdd(h, dst)
+	return fnvAdd(h, dstPort)
+}
+
+func fnvAdd(h uint32, data []byte) uint32 {
+	for _, b := range data {
+		h ^= uint32(b)
+		h *= 16777619
+	}
+	return h
+}
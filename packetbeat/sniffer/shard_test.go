@@ -0,0 +1,120 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// +build !integration
+
+package sniffer
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tsg/gopacket"
+	"github.com/tsg/gopacket/layers"
+)
+
+func ethernetIPv4(src, dst [4]byte, proto layers.IPProtocol, srcPort, dstPort uint16, flags byte) []byte {
+	data := make([]byte, 14+20+4)
+	data[12], data[13] = 0x08, 0x00
+	ip := data[14:]
+	ip[0] = 0x45
+	ip[6] = flags
+	ip[9] = byte(proto)
+	copy(ip[12:16], src[:])
+	copy(ip[16:20], dst[:])
+	ip[20], ip[21] = byte(srcPort>>8), byte(srcPort)
+	ip[22], ip[23] = byte(dstPort>>8), byte(dstPort)
+	return data
+}
+
+func TestFlowHash(t *testing.T) {
+	a, b := [4]byte{10, 0, 0, 1}, [4]byte{10, 0, 0, 2}
+	tcp := layers.IPProtocolTCP
+
+	forward := flowHash(layers.LinkTypeEthernet, ethernetIPv4(a, b, tcp, 40000, 80, 0))
+	reverse := flowHash(layers.LinkTypeEthernet, ethernetIPv4(b, a, tcp, 80, 40000, 0))
+	assert.NotZero(t, forward)
+	assert.Equal(t, forward, reverse, "both directions must hash the same")
+
+	other := flowHash(layers.LinkTypeEthernet, ethernetIPv4(a, b, tcp, 40001, 80, 0))
+	assert.NotEqual(t, forward, other, "the ports must be hashed")
+
+	udp := flowHash(layers.LinkTypeEthernet, ethernetIPv4(a, b, layers.IPProtocolUDP, 40000, 80, 0))
+	assert.NotEqual(t, forward, udp, "the protocol must be hashed")
+
+	// fragments are hashed by their addresses only
+	first := flowHash(layers.LinkTypeEthernet, ethernetIPv4(a, b, tcp, 40000, 80, 0x20))
+	next := flowHash(layers.LinkTypeEthernet, ethernetIPv4(a, b, tcp, 0, 0, 0x01))
+	assert.Equal(t, first, next)
+
+	// VLAN tags are skipped
+	plain := ethernetIPv4(a, b, tcp, 40000, 80, 0)
+	tagged := append([]byte{}, plain[:12]...)
+	tagged = append(tagged, 0x81, 0x00, 0x00, 0x0a)
+	tagged = append(tagged, plain[12:]...)
+	assert.Equal(t, forward, flowHash(layers.LinkTypeEthernet, tagged))
+
+	assert.Zero(t, flowHash(layers.LinkTypeEthernet, []byte{1, 2, 3}))
+	assert.Zero(t, flowHash(layers.LinkTypeTokenRing, plain))
+}
+
+type recordingWorker struct {
+	sync.Mutex
+	packets [][]byte
+}
+
+func (w *recordingWorker) OnPacket(data []byte, ci *gopacket.CaptureInfo) {
+	w.Lock()
+	w.packets = append(w.packets, data)
+	w.Unlock()
+}
+
+func TestShardedWorker(t *testing.T) {
+	var workers []*recordingWorker
+	factory := func(layers.LinkType) (Worker, error) {
+		worker := &recordingWorker{}
+		workers = append(workers, worker)
+		return worker, nil
+	}
+
+	sharded, err := newShardedWorker(factory, layers.LinkTypeEthernet, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, b := [4]byte{10, 0, 0, 1}, [4]byte{10, 0, 0, 2}
+	for port := uint16(1); port <= 100; port++ {
+		ci := gopacket.CaptureInfo{}
+		sharded.OnPacket(ethernetIPv4(a, b, layers.IPProtocolTCP, port, 80, 0), &ci)
+		sharded.OnPacket(ethernetIPv4(b, a, layers.IPProtocolTCP, 80, port, 0), &ci)
+	}
+	sharded.stop()
+
+	total := 0
+	for _, worker := range workers {
+		packets := worker.packets
+		assert.NotEmpty(t, packets, "flows must be spread over all workers")
+		total += len(packets)
+
+		// both directions of a flow are processed by the same worker
+		for _, data := range packets {
+			assert.Equal(t, flowHash(layers.LinkTypeEthernet, packets[0])%4, flowHash(layers.LinkTypeEthernet, data)%4)
+		}
+	}
+	assert.Equal(t, 200, total)
+}
@@ -20,8 +20,10 @@ package sniffer
 import (
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"runtime"
+	"sync"
 	"syscall"
 	"time"
 
@@ -39,12 +41,15 @@ import (
 // to a Worker.
 type Sniffer struct {
 	config config.InterfacesConfig
+	handle snifferHandle
 	dumper *pcap.Dumper
 
 	state atomic.Int32 // store snifferState
 
 	// bpf filter
-	filter string
+	filterMutex   sync.Mutex
+	filter        string
+	filterChanged bool
 
 	factory WorkerFactory
 }
@@ -65,6 +70,12 @@ type snifferHandle interface {
 	Close()
 }
 
+// filterHandle is implemented by handles that support replacing their BPF
+// filter without being reopened.
+type filterHandle interface {
+	SetBPFFilter(expr string) error
+}
+
 // sniffer state values
 const (
 	snifferInactive = 0
@@ -138,32 +149,59 @@ func New(
 	return s, nil
 }
 
-// Run opens the sniffing device and processes packets being read from that device.
-// Worker instances are instantiated as needed.
-func (s *Sniffer) Run() error {
-	var (
-		counter = 0
-		dumper  *pcap.Dumper
-	)
+// Open opens the sniffing device and the dump file. It is called by Run if
+// the device is not open yet. Opening the device first allows to drop the
+// privileges required to open it before packets are processed.
+func (s *Sniffer) Open() error {
+	if s.handle != nil {
+		return nil
+	}
 
 	handle, err := s.open()
 	if err != nil {
 		return fmt.Errorf("Error starting sniffer: %s", err)
 	}
-	defer handle.Close()
 
 	if s.config.Dumpfile != "" {
-		dumper, err = openDumper(s.config.Dumpfile, handle.LinkType())
+		s.dumper, err = openDumper(s.config.Dumpfile, handle.LinkType())
 		if err != nil {
+			handle.Close()
 			return err
 		}
+	}
 
+	s.handle = handle
+	return nil
+}
+
+// Run opens the sniffing device and processes packets being read from that device.
+// Worker instances are instantiated as needed.
+func (s *Sniffer) Run() error {
+	counter := 0
+
+	if err := s.Open(); err != nil {
+		return err
+	}
+	handle, dumper := s.handle, s.dumper
+	defer handle.Close()
+	if dumper != nil {
 		defer dumper.Close()
 	}
 
-	worker, err := s.factory(handle.LinkType())
-	if err != nil {
-		return err
+	var worker Worker
+	if s.config.Workers > 1 {
+		sharded, err := newShardedWorker(s.factory, handle.LinkType(), s.config.Workers)
+		if err != nil {
+			return err
+		}
+		defer sharded.stop()
+		worker = sharded
+	} else {
+		var err error
+		worker, err = s.factory(handle.LinkType())
+		if err != nil {
+			return err
+		}
 	}
 
 	// Mark inactive sniffer as active. In case of the sniffer/packetbeat closing
@@ -174,7 +212,22 @@ func (s *Sniffer) Run() error {
 	}
 	defer s.state.Store(snifferInactive)
 
+	var lastStats captureStats
+	statsTicker := time.NewTicker(statsPeriod)
+	defer statsTicker.Stop()
+	defer reportStats(handle, &lastStats)
+
 	for s.state.Load() == snifferActive {
+		select {
+		case <-statsTicker.C:
+			reportStats(handle, &lastStats)
+		default:
+		}
+
+		if filter, changed := s.takeFilter(); changed {
+			s.applyFilter(handle, filter)
+		}
+
 		if s.config.OneAtATime {
 			fmt.Println("Press enter to read packet")
 			fmt.Scanln()
@@ -206,6 +259,7 @@ func (s *Sniffer) Run() error {
 		}
 
 		counter++
+		packetsRead.Inc()
 		logp.Debug("sniffer", "Packet number: %d", counter)
 		worker.OnPacket(data, &ci)
 	}
@@ -215,19 +269,70 @@ func (s *Sniffer) Run() error {
 
 func (s *Sniffer) open() (snifferHandle, error) {
 	if s.config.File != "" {
-		return newFileHandler(s.config.File, s.config.TopSpeed, s.config.Loop)
+		return newFileHandler(s.config.File, s.config.TopSpeed, s.config.ReplaySpeed, s.config.Loop)
 	}
 
+	filter, _ := s.takeFilter()
 	switch s.config.Type {
 	case "pcap":
-		return openPcap(s.filter, &s.config)
+		return openPcap(filter, &s.config)
 	case "af_packet":
-		return openAFPacket(s.filter, &s.config)
+		return openAFPacket(filter, &s.config)
+	case "pf_ring":
+		return openPFRing(filter, &s.config)
 	default:
 		return nil, fmt.Errorf("Unknown sniffer type: %s", s.config.Type)
 	}
 }
 
+// SetFilter replaces the BPF filter. If the sniffer is running, the filter
+// is applied to the open capture handle before the next packet is read.
+// Filters are not applied when reading from a file.
+func (s *Sniffer) SetFilter(filter string) error {
+	if s.config.File == "" {
+		if err := validatePcapFilter(filter); err != nil {
+			return err
+		}
+	}
+
+	s.filterMutex.Lock()
+	defer s.filterMutex.Unlock()
+	if filter == s.filter {
+		return nil
+	}
+	logp.Debug("sniffer", "New BPF filter: '%s'", filter)
+	s.filter = filter
+	s.filterChanged = true
+	return nil
+}
+
+// takeFilter returns the current BPF filter and whether it has been changed
+// since the last call.
+func (s *Sniffer) takeFilter() (string, bool) {
+	s.filterMutex.Lock()
+	defer s.filterMutex.Unlock()
+	changed := s.filterChanged
+	s.filterChanged = false
+	return s.filter, changed
+}
+
+func (s *Sniffer) applyFilter(handle snifferHandle, filter string) {
+	if s.config.File != "" {
+		return
+	}
+
+	h, ok := handle.(filterHandle)
+	if !ok {
+		logp.Warn("Sniffer type %s does not support changing the BPF filter", s.config.Type)
+		return
+	}
+	if err := h.SetBPFFilter(filter); err != nil {
+		logp.Err("Failed to apply BPF filter '%s': %v", filter, err)
+		return
+	}
+	logp.Info("Applied new BPF filter: '%s'", filter)
+}
+
 // Stop marks a sniffer as stopped. The Run method will return once the stop
 // signal has been given.
 func (s *Sniffer) Stop() error {
@@ -236,6 +341,10 @@ func (s *Sniffer) Stop() error {
 }
 
 func validateConfig(filter string, cfg *config.InterfacesConfig) error {
+	if cfg.Workers < 0 {
+		return fmt.Errorf("workers must not be negative")
+	}
+
 	if cfg.File == "" {
 		if err := validatePcapFilter(filter); err != nil {
 			return err
@@ -247,6 +356,8 @@ func validateConfig(filter string, cfg *config.InterfacesConfig) error {
 		return validatePcapConfig(cfg)
 	case "af_packet":
 		return validateAfPacketConfig(cfg)
+	case "pf_ring":
+		return validatePfringConfig(cfg)
 	default:
 		return fmt.Errorf("Unknown sniffer type: %s", cfg.Type)
 	}
@@ -258,7 +369,24 @@ func validatePcapConfig(cfg *config.InterfacesConfig) error {
 
 func validateAfPacketConfig(cfg *config.InterfacesConfig) error {
 	_, _, _, err := afpacketComputeSize(cfg.BufferSizeMb, cfg.Snaplen, os.Getpagesize())
-	return err
+	if err != nil {
+		return err
+	}
+
+	if cfg.FanoutGroup != nil && (*cfg.FanoutGroup < 0 || *cfg.FanoutGroup > math.MaxUint16) {
+		return fmt.Errorf("fanout_group must be between 0 and %d", math.MaxUint16)
+	}
+	return nil
+}
+
+func validatePfringConfig(cfg *config.InterfacesConfig) error {
+	if !pfringAvailable {
+		return errPfringNotAvailable
+	}
+	if cfg.ClusterID != nil && *cfg.ClusterID < 0 {
+		return fmt.Errorf("cluster_id must not be negative")
+	}
+	return nil
 }
 
 func validatePcapFilter(expr string) error {
@@ -304,8 +432,14 @@ func openAFPacket(filter string, cfg *config.InterfacesConfig) (snifferHandle, e
 		return nil, err
 	}
 
+	var fanoutGroupID *uint16
+	if cfg.FanoutGroup != nil {
+		id := uint16(*cfg.FanoutGroup)
+		fanoutGroupID = &id
+	}
+
 	timeout := 500 * time.Millisecond
-	h, err := newAfpacketHandle(cfg.Device, szFrame, szBlock, numBlocks, timeout)
+	h, err := newAfpacketHandle(cfg.Device, szFrame, szBlock, numBlocks, timeout, fanoutGroupID)
 	if err != nil {
 		return nil, err
 	}
@@ -319,6 +453,27 @@ func openAFPacket(filter string, cfg *config.InterfacesConfig) (snifferHandle, e
 	return h, nil
 }
 
+func openPFRing(filter string, cfg *config.InterfacesConfig) (snifferHandle, error) {
+	h, err := newPfringHandle(cfg.Device, cfg.Snaplen, true, cfg.ClusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if filter != "" {
+		if err = h.SetBPFFilter(filter); err != nil {
+			h.Close()
+			return nil, err
+		}
+	}
+
+	if err = h.Enable(); err != nil {
+		h.Close()
+		return nil, err
+	}
+
+	return h, nil
+}
+
 func openDumper(file string, linkType layers.LinkType) (*pcap.Dumper, error) {
 	p, err := pcap.OpenDead(linkType, 65535)
 	if err != nil {
@@ -0,0 +1,95 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sniffer
+
+import (
+	"time"
+
+	"github.com/tsg/gopacket/pcap"
+
+	"github.com/elastic/beats/libbeat/logp"
+	"github.com/elastic/beats/libbeat/monitoring"
+)
+
+// statsPeriod controls how often the capture statistics are collected and
+// logged.
+const statsPeriod = 30 * time.Second
+
+var (
+	packetsRead      = monitoring.NewUint(nil, "sniffer.packets.read")
+	packetsReceived  = monitoring.NewUint(nil, "sniffer.packets.received")
+	packetsDropped   = monitoring.NewUint(nil, "sniffer.packets.dropped")
+	packetsIfDropped = monitoring.NewUint(nil, "sniffer.packets.if_dropped")
+)
+
+// captureStats holds the statistics of a capture handle since it was opened.
+type captureStats struct {
+	received  uint64 // packets received by the capture filter
+	dropped   uint64 // packets dropped by the kernel, because the buffer was full
+	ifDropped uint64 // packets dropped by the network interface or its driver
+}
+
+type statsHandle interface {
+	stats() (captureStats, error)
+}
+
+func handleStats(handle snifferHandle) (captureStats, bool, error) {
+	switch h := handle.(type) {
+	case *pcap.Handle:
+		st, err := h.Stats()
+		if err != nil {
+			return captureStats{}, true, err
+		}
+		return captureStats{
+			received:  uint64(st.PacketsReceived),
+			dropped:   uint64(st.PacketsDropped),
+			ifDropped: uint64(st.PacketsIfDropped),
+		}, true, nil
+	case statsHandle:
+		st, err := h.stats()
+		return st, true, err
+	default:
+		return captureStats{}, false, nil
+	}
+}
+
+// reportStats updates the sniffer metrics from the handle statistics and
+// logs a summary. A warning is logged if packets have been dropped since
+// the previous report.
+func reportStats(handle snifferHandle, last *captureStats) {
+	st, ok, err := handleStats(handle)
+	if !ok {
+		return
+	}
+	if err != nil {
+		logp.Err("Failed to read capture statistics: %v", err)
+		return
+	}
+
+	packetsReceived.Set(st.received)
+	packetsDropped.Set(st.dropped)
+	packetsIfDropped.Set(st.ifDropped)
+
+	logp.Info("Capture statistics: %d packets read, %d received, %d dropped by kernel, %d dropped by interface",
+		packetsRead.Get(), st.received, st.dropped, st.ifDropped)
+	if st.dropped > last.dropped || st.ifDropped > last.ifDropped {
+		logp.Warn("Packets have been dropped before being read: %d by kernel, %d by interface",
+			st.dropped-last.dropped, st.ifDropped-last.ifDropped)
+	}
+	*last = st
+}
@@ -31,5 +31,5 @@ func init() {
 
 // Asset returns asset data
 func Asset() string {
-	return "eJzMWkuTG7cRvu+v6NLFUhXJWnIlxd5DKorkxKxYsSqyo5xEgpgeEtEMMAYw5DK/PtUNzJPDh3bpxHvbGczXHxr9BsfwBff3IE2eG30D4JXP8B6eveUH8EnpzKxXKPyzG4AEnbSq8Mroe/jjDQDAW6O9UNpFBEgVZokDsRUqE6sMQWkQWQa4Re3B7wt0kxuIy+4ZYgxa5HjPL/kBgMVfS2UxuQdvy+rhgHT6+3mDET0za3jzYc5AUDpMwBuwKBLwGwSL0thkwusL45widluRlehAWKzxnu2UZrzMrJ9Baix//UnpxOwcfM+SfoySjIVn1dq10s36Gq1eT6/pm8lNh/nBe9gJR1tVa40Jw1WiP6LdooXZ7e1dDdF6Pbu9vQVToBWeoNzeeczdBOa6XvJP5bwY8X5qjZHcGk7p1ArnbSl9aZGpWKzITOCnHhSJz4RH28itoaL80QntKcenNGlZGYjSm1x4JUWW7WuwBD1K72C3UXITztjQt6wfOmHacb0lN7m5uYmGXZ1OY9rf17YSLOKMXSfCC0ityUHU28AexDGLFtKrrfL7hUriVsg074nZLkjuGnsqMnfG2t/AOjMrUg6UWv1aIqgEtVepQjI84Zv/HateltYS24oLO0ANxztx4UNhEYpylSm3wQR2ym/Ab5RrC+AlwnowKYM7kTdYtYSbjhKkyYvSo13oZvFpNVzm8wRX8ahkhI2sUZM1YtfvP21QQ+nIUuI5drVAtrQTlkxpFDbOEqTQkKg0RRusgCCXZKqTjXGelix7G2a0BdlNZ7dm9W+UPj4K/yyebA518Bu7AqVKlWSDpUNWLtgkuVle+pJtBh9kVjq1RT7fGmZZOrRMeTmBeQp7U/JZS1H40jbOxc5gNGzROmW0g8IqY2sYb4ZCTSGsyNGjdZQMlo16liyDlJbAkldNl6OGEz+ZLUcgdALOgNEjWKEU5PeNB7bQCa3UAU/p5nSFzch4a9ImrWgOnlzPWTNTR7XHpaXGf0Ly4ZxDp1IfmTfBm0xpZW3T/HGPYLQUd61oQnQqzKC+mDVlJpxT6R6EHiSSmfVv6c/N8bLPhbDPPhm8OWYmkURDr1Ta5EVdYxGua/luOGRKE0uQJstQEhsyzRBJdKrWJSUzo/ubxi1m11Q9A3bPm55b5LNIyU15CZtsO1RTUFo1kZfSOIn9WEqJzo1grlNjc97DCD4JqzmofW+tsSN4UybKN2vJu/hRDfcXobLSYm/3OTon1t0T9/jgH7v5iNeczGFWHZK/QNrFNU+BAYNejeR0mZDFZVhXFqSioFAurXyPfRP/6EzNukc87Gahy3yFlxG/zGGiKwTcQ89p17upss7XvmOV96jbUbvycv6w8bIIPQ1GYvwGbXwVokV4j0lT9OGvJQU7yjWcSPwBT4tCbmJtkosHlZd5dN/ns893s881VlV5H1bIRGb2+fXLz6er8xejzsFofPA9LjuVZbBCuD04Mapqk8XvoX6rONAxcRysoaTR3pqMY6G3QrsU2Xa9mUT74F1UgWNnyowrIg0bsUUOp03h36rxWK6ysGxtf9nOYl1lmUKa5LJEcKFDBkRIMFWaU3knPgr3JZhjWEV26PdFaBiq/NXf1z66phTtWC+KIlPxUcwzFHsbN9oJ14uxvb0X1mxVgnaxLv+PJlKxCC8D0Y7pD2XwwhqK/xdVOxeeWwM5wJDeDJXnQ9xCIXTtAmOgvGoFSnjeNwljwaHdKonMu2cJrdD2okffC/flmv5AeL9Pb2B6UnhcGxvFrfZHovZzo6Gw2O0QmoKtP7h4QXkopqpQIFcNTV/bG0rT17TjGrFvxuHFpVZc91T/wzYQntYGtnqzgc1Mmjj01eaNDyIvMryHj+Pp+NV4Nh3fvXo5fXl3+93s2/Hs9tX0D9PpbHo7nt59N7379uXd6+/G09vb6fltV+bkUJaW8mQrWD7/OH/3onJ4IaUptQfhnJGKT66zeTaw6hzrp/O0PZ2SQmtDVYQz2Tb4xsf5O66gWE+jkF+5z2CN8Xxg1O2zJ4nJhdKxsQ2PSJHLanhalSUmJ+tPehXypM2NGvVEOWm2aNtEG5bkUh/n79wILG4V7qoilWqnppQIU1AXigzhq6C9yjCHXOxh1e8G6t1dM9C1W8Ajx9U9pgNGQbXX5BQQg89fxos8TUCOsSwfotkadV8nQ+wLVtxXKy3ORJ4QOnmiEVCGWpFvXMhcaqCffshPddNP7zCt2MG/3v8IFguLDrWPeb1dAJiVF5xX2cmqsRDHzaYTqeKn0dm+dbVBWP20Ba4sCmPrPrE/auxOwJ+/V9IaZ1LfG6xTZNC4Q/uiH8O1aSoRpWVWJiHpJpiKMvP8ZV46TwEENfHk1w5D77qM3ywe8iwUTssaTpDddgYgYIpQDxhLPaLSidqqpBRZUz61gyUp/ZzCw9A/LbPQvFlTrjJ0G2M6twdFaQvj0IUKg8eOsUyJMdIiaTnsrRugaePS5IVoN6ZxwtYGIqLeQKLEWhtXhzs3qe8OCLG5N/hz+O+COzBm8pUXYR3H4PFyK7heGDKJITjUSWV8FObjoMLV7Tiv4s9aJu7QD07AIFVZk9WEb4aX4eZmnoLyfbN06MPkt5qQN+t/Niyqw2PENzmcMzldtmrCo5M55jU5VFol8bziam48SfSl1U3x2vdq8vRqBhmJt3RnS62VXg+w8SrH/xh9SQCPK39LNt1Yf4JMFc6jWbE5H9S8RxLKsz/RVpwXefGsE8gT4U/e7ob5WmddXTO+KdcU0Wav/QZmt9PXI5jO7u9e3b+6m9zdzS7TLlOinkYPt3874Zr9HTR0a3emZ7cr5a2we17bHtKyvRdow0FRdKZ/eF4jePLcxKh90a+yQnR4VP9whGgdq6gOaXyKAlQQ1r8SOZi2rq0pi9NCeNBcRUAZJPIcNUm4DhAZKJ0a8mwpHMcvluOq2rZ9mQpHZ9AwVCWcoNVQiziTAwGtURYMFUQXoRPIIXSr5oMjJc9F6MFMqt9tZKZMmhz1lv5t5kE5ehEb0AHY9/FtKH1k51NHZ9WEIJEkC16wqCCrkY6xR7MYLZ3wV5MKtu/YKM9479/bl7wdhhP4cPgjDgIcwVriCIyFRK2VF5mRKPqlZ4ub0s4LLbGZIBzhMo8LYf6uokRJBHIhN0ofXpccSjifmWoZ7bx+mZS4YNGys1rPfjbJMVFlflr6+wBRtxOXC49ljsqU3y9aKa9mULoxCufHU3kmkLaAgDOi0u37P6ajXJPmTpgcx8b6VGsq8c344XLTi58Ql78as84weNpx6RbXZ1PtP3jNuf1FR0+M/ML+Ez39XfX/AHh4B84L76orzqrDCe/IZ93GWL8IGaBpqYSWG2MreePay4/8wqWmBYP54VgcjzmBR0pPi4m/hAl5DQgqGYrqtbh8KH18lcS2XTBcVZ1GAlRIrEqVeaCG9zgV3f7tzKOYvK1lEtYpWZlYYeYOpHVqCThdT5zhMmdNBDm10ZIxNyb7Q/hvAGROxUDLUOOtXjf0NLZJz89aZpT9dXb59DP5IbYVh6dxJUsPAWLAyIWVG+WRf7n39D104OA5TtYTePj29eL1yxEIm4+gKOQIclW4F4dUjJsUmfBU0j+NyU8foQKKHCRqb9wIylWpfTmCXRibDJPodjyP5xBxBmWkIletXyo+VkSAiZu0mGyEH0GCKyX0CFKLuHLJmd1+Qavr36c8ksnPA/3mNw4C9HE9qOJAbOfRCYk/Ksc/J5x/GIsksegcukMBuZBP21glZiNsshMWG2EjKF24FXn/5m2bQxXFvpQr2r5H18Syv7WfDYht3tdFeLeibkChHclOJ+Xmo7Phr0MavioIFia5QnJqaaAwSYisg6LKpwbGlqQPJoFf5u8OBfGPwAohr7epBvFQGPV/V9UgIR5R4aWp/TJBAQ1yURxK4rsvnr5dTVwLcljmNculllzZqZxOib1CwTgoN+D+NwAA//8LSiHr"
+	return "eJzMW1tv28gVfvevOMjLJqgkWHKSbgy0qDfei7BJN613m2IfIo2GR9TU5AwzM7Ss/fXFmRspirbkSNsuEAQQZ/idy5z70EO4xc0lcFWWSp4BWGELvIRnb90D+ChkofIFMvvsDCBDw7WorFDyEv56BgDwVknLhDQBAZYCi8wAu2OiYIsCQUhgRQF4h9KC3VRoRmcQtl06iCFIVuKlW3QPADR+roXG7BKsruPDHur07+cVBvRC5XD1YeqAoDaYgVWgkWVgVwgaudLZyO2vlDGCuLtjRY0GmI5EAJ6thXR4hcqfwVJp9/ZHITO1NvAtrcC7QElpeBb35kI2+xNa2k/L9M7oLK0RJzvrsGaGFC1yiZmDi6RvUN+hhsn5+UWCaC1Pzs/PQVWomSUoszEWSzOCqUxb/iWMZQOwbY0RXwlOyKVmxuqa21qjY0VjZGYEP3WgQGkomEXd0E1Qgf7gEe0J405p1LIyYLVVJbOCs6LYJLAMLXJrYL0SfEVapJOtDTr90AmTxEkkMzo7OwuGHU+nMe1v47ZgEXvsOmOWwVKrElgSAzsQD1k041bcCbuZiSyIQqZ5SZytPeVtY1+ywuyx9ivIC7Ug5UAtxecaQWQorVgKJENltvltnOp5rTVxG3lxDpDgnCS0kVlyAqjqRSHMCjNYC7sCuxKmTcBtYdqCWjpww8oGK1E421ICV2VVW9Qz2Wx+XA2H+TzBRT4iDS9IjpK8ALf9/uMKJdSGLCWcY4JzWiBbWjOdCZmT0QrjwhJwJiETyyVqbwVEbk4BcbRSxtKWeUdghzYjuwkEvLRq8R/kNjzyP2ZHm0MKfkNTIRdLwYEI0yEL46MsCANlbWtnM3jPi9qIO3Tnm2DmtUHtWJ6PYLqEjaqdOXBW2Vo3zkU7QEm4Q22EkgYqLZROMFb1hZqKaVaiRW0oGcwb9cwdDVJhBnO3azwfNDy5J5P5AJjMwChQcgAL5Iz8vvHAFjqh1dLjCZmAkOmCjDcxrZaRzd6T6zhroWS+czRPSUuN/9CxhJxDp5KOzCpnqUbVmiebdi93GAyWYg5yowPNJ2K6w4hZkxfMGLHcAJO9jBQq/z39uTle53M+7Duf9AE3ZCaWBUOPKk2ASiYswjUt33XYM0oTc+CqKJATN2SatMyVXIq8pmSmZFdovMPiIIkPVL0D3JKZ5EGN7iyW5KZui6E97VDNmYRFJAAujZOib2rO0ZgBTOVSaUqj5DMfmZYuqH2rtdIDuKozYZu95F3uUYL7jomi1tiRvkRjWB6J+hO3eG+/VPiA15zMblbtoz9DkuKUp+AAvV4Vd+kyo0KjoJLRVxakIq9QKnHAdrhPaLRQqLzDuJdmJutygfqEDhNcweNuWVFbiU7XS6GNjS+stbAWm/hoVfJy96IwHeixNxJlV6jDkiuZwzpGAQAMfq4p2FGucYmkScARTCPjq1CblOxelHUZ3Pf55NPF5FPCipX3boVMzEw+vX756fHq/EWTSwhH4r3t8LIWRQELhPOdE6OqNpv9Eeq3yANFZRcHExRX0mpVuFhoNZNmic52rRoF+3BSxMCxVnXhKiIJK3aHrsRJWC0mCG2FQsO8Jf68xWZHWariKsNT6YcY94iQ4VJQDyTkVnxk5tabo99Fdmg3lW8YYtfXlWvjEArFWTvWs6oqRHgU8gzF3saN1qzJKD7GdmSvtLoTGepZXv8fTSRy4Rc9owmtUd0O6xT/D6p2Djy3BrKHQyLWV5738eYLoVMXGD3lVStQwvOuSSgNBvWd4GQSzHYsodVbvOiwb5m5PYjvA/VKeH9Mb3DscWYxVzqQW2weiNrPlYRKN7HaTRAS4M7g4gVVyCFVUeyzqaHpantFafqUdpwQu2bsFw614tRT/Q/bQDiuDWz1Zj3CjJI6nl5/4T0rKxq/3AzHw1fDyXh48erl+OXF+ZvJ18PJ+avxn8fjyfh8OL54M774+uXF6zfD8fn5eL/Y0ZwM8loLu2nOTMPzm+n1i+jwjHNV0yDEGMWFy4xbwjsDi+eYnk6X7ekUZ1IqqiKMKlwKRbiZXpN5Mqcn1/B6d3It9Ygetnta9zBTJRMyNLZ+HylyHmZIqSxRJVl/1qmQR23eqFHPhOGKJoMtRhsuyaVuptdmABrvBK6D7+dUOyUk7qeghiRZk3WHoL0osISSbWDR7QaSdE+2hANbwAeOa/uYdjjyqj0lTx7R+/xhfJGnMSgxlOV9bLZG3SdhkkDIzp+stDATOSJ0ko4CSiyrwwm6Q/qKhtlLIUVPP31fPtZNH99haraGf79/BxorjQalDXm9XQCoBV1fRCcLwcTHzYSV4qeSxaZ1taHk7rwdTF1VSqc+MSAmrK0OBZ6/F1wro5Y2xTGXFl1kkLhG/aIbw6WK+gAQkhd15pNuhktWF9a9WdbGUl+Dkvh0ywat42ke3pndl4UvnOYJjpHdbg1AQLlTplhBPaKQmbgTWc3ifY5vdhMAKX2fwv3Qf1kXDtRqVS8KNCultm4PqlpXyqAh6dGNNGOZEmKkRtKyF307QJPgNA9mul0FUwGxDUSMWgWZYLlUJoU7M0p3B4TY3Bt84389dlcQ7sDovadehG05BgG0g+uBIZM4BIMyi8ZH1W0YVJjUjrtdBN6elxm0vRMwWIqiyWrMpklbuLmZLkHYrlkatO4ViBPyZv/PypHa4mNAWG7/nDY39hiM70G+RrtKixT3Ky7x5iaJttayKV67Xk0j7ziDDIy3dKdrSfO1Hm6sKPE3JQ/gJu78PbnZjvWPMBPDeTArEmW35n0goTz7G4liLCurZwHUB/KMWdwJ5K3Gzc/XtvalmvGqzimiTV7bFUzOx68HMJ5cXry6fHUxuriY7BcosUQ9jexv/2iKkuTrCGVZbh6ncqUXwmqmN25ve0jr7L1C7c2GojP9cPMamq+k8/B66hD20eGL+ocHGE2xisqlxqcoQHliHQ52p625VnX1OBE3aA54RIOiI9kvyzJXB7AChFwqijicGRe/HB0Ta9v2ZWqbmxDM0vOeKuERthrWAs5oh0BrlNVbEB2ETiC70K2a76GS5yB0enEUUxQvVJ01Oeot/WzmQSVaFhrQHtj3YdWXPnzrVQMsC4UFeQvLspnbMIuQcaSj9INZjLaO3FujCNt1bOR7vPfvrfS2zeEIPux+xAHIJwPIOQ7o04RM5MKyQnFkcvQgb0IayyTHZoLwAC/TsBGm15ElSiJQMr4SEg+gsD8zJRrtvH4YlbBh1rKzpGc7GZWYibp8nPp7D5HaicOJhzJHFPS1QyvlJQ5qM0Rm7HDMH2fhqgUEBASiyXbCuJKCyomU5h7iqNLKxUaRdVkJK8P7xzlpm154hXj5Xqm8QO9pD1PXmO9Ntf90e/bJFxw9U/wWdePp1/F3D7hfA2OZpZrUXXHGDsevkc+aldJ25jNA01IxyVdKR3rD5OUtJ2+LnNjqzw/tV9qvhZyAeiSy42LiL35CngBBZKPHyJUsPzIKt+3CwcXqNDBAhcSiFoUFangfZqUVDL6Qk5DLUTv7e4xWwRZYmB1qW7XEnnpiDy9TpwlPJxktOWtjsj/4Xz0gdF3dNlSle0JPY5sEu9cyA+2n2eXxZ/JDaCt2T+NElk5y9Ro503wlLLov946jRDJswcFzHOUjuP/69ez1ywEwXQ6gqvgASlGZF7usKDOqCmappD+Ok59uIAIFHjhKq8wA6kUtbT2AtR+b9DOx3fF8OQ8Bp5fGkpWi2BxNwsMEITVmK2YHkOFCMDmApUZcmGyPtLeoJRbHcfJzT7/5lQEP/bAeRLVDVlSHUXwnjPuccPphyLJMo6Gpzw6BkvEdCk8SLJJZMZ2tmcaGGI0f/Mdx76/etnmIUey2XpD4Fk0Ty35sP+sh26ynIny7om5Am0p6b1JuXtob/pqtTw6ClcpOkJxaGqhU5qDPekk1N+fHU/qgMvhler1LiP43FeOnE6pB3CVG/d9JNSjpS4d+FR6a2g8j5NGgZNUuJXf35aaCJyPXguynecpyqUU3wT6g1IbsCQrGXroeN0QYrsqylulDoxBj3sanML3ujzLfFWoNK2ZW27GlDbfdp8dAknYMRbY3lEi0a6VvnxxH2mwcp8G2JmAZhR7Eq95NnHe7JUY3KnSTqYr2bcwWoLADKMQtwq+I/vuJm1rTxw+sST2tjnF8+e4fv7z5/Gvx45++efXdxY/XJd69Ll99eC8WOv9LPMUclaia4/se1fRD/7l9jyrXrFoJ3nyFsZsiHF7/+bmlvQeXo/qCQ5NWSPoK4HiHa3dKCXc3t7srS72ZCaNmvfO3JxGd3vwEhNIQdui7ZH2LfmIxPegAXBlH5KkJRxpGubmV5D2NAScXObG23Z9hdAlFW2u9FgwD1axSQh4YwN8pmQtb0+dGMqM/AHI/dukxI4+YpV7VVklVqtrEew9/vbxLR+mcSfEbs6c/zzZ0fMa6jI3O/jsABmLR6A=="
 }